@@ -21,6 +21,8 @@ type config struct {
 	readOnly           bool
 	disableDestructive bool
 	logLevel           string
+	logFormat          string
+	preflight          bool
 }
 
 func Execute(ctx context.Context, args []string, run RunServerFunc, version string, stderr io.Writer) error {
@@ -53,6 +55,8 @@ func newRootCmd(ctx context.Context, run RunServerFunc, version string, stderr i
 	flags.BoolVar(&cfg.readOnly, "read-only", false, "disable write operations")
 	flags.BoolVar(&cfg.disableDestructive, "disable-destructive", false, "disable destructive operations")
 	flags.StringVar(&cfg.logLevel, "log-level", "", "log level")
+	flags.StringVar(&cfg.logFormat, "log-format", "", "log format (text or json)")
+	flags.BoolVar(&cfg.preflight, "preflight", false, "check connectivity for each enabled toolset and exit")
 	cmd.AddCommand(newSyncCmd(stderr))
 	cmd.AddCommand(newInitConfigCmd(stderr))
 	return cmd
@@ -67,6 +71,7 @@ func (cfg *config) toServerOptions(cmd *cobra.Command, version string, stderr io
 		ReadOnly:           false,
 		DisableDestructive: false,
 		LogLevel:           "",
+		LogFormat:          "",
 		Version:            version,
 		Stderr:             stderr,
 	}
@@ -88,6 +93,13 @@ func (cfg *config) toServerOptions(cmd *cobra.Command, version string, stderr io
 	if cmd.Flags().Lookup("log-level").Changed {
 		options.LogLevel = cfg.logLevel
 	}
+	if cmd.Flags().Lookup("log-format").Changed {
+		options.LogFormat = cfg.logFormat
+	}
+	if cfg.preflight {
+		options.Preflight = true
+		options.Stdout = cmd.OutOrStdout()
+	}
 	return options
 }
 
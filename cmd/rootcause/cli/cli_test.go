@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"rootcause/pkg/server"
+)
+
+func TestExecutePassesLogAndPreflightFlags(t *testing.T) {
+	var got server.Options
+	run := func(_ context.Context, opts server.Options) error {
+		got = opts
+		return nil
+	}
+	if err := Execute(context.Background(), []string{"--preflight", "--log-format", "json"}, run, "test", io.Discard); err != nil {
+		t.Fatalf("execute --preflight: %v", err)
+	}
+	if !got.Preflight || got.Stdout == nil || got.LogFormat != "json" {
+		t.Fatalf("expected preflight options, got %#v", got)
+	}
+}
+
+func TestExecuteWithoutFlagsLeavesOverridesUnset(t *testing.T) {
+	var got server.Options
+	run := func(_ context.Context, opts server.Options) error {
+		got = opts
+		return nil
+	}
+	if err := Execute(context.Background(), []string{}, run, "test", io.Discard); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if got.Preflight || got.Stdout != nil || got.LogFormat != "" || got.LogLevel != "" {
+		t.Fatalf("expected no overrides, got %#v", got)
+	}
+}
@@ -0,0 +1,115 @@
+// Package archive persists redacted tool results to a durable sink (a local
+// directory or an S3 bucket) so incident investigations are kept after the
+// session ends. Records from one investigation share a trace id.
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record is one archived tool call. Result is the payload returned to the
+// client, after redaction.
+type Record struct {
+	Timestamp  time.Time `json:"timestamp"`
+	UserID     string    `json:"userId"`
+	TraceID    string    `json:"traceId,omitempty"`
+	ParentTool string    `json:"parentTool,omitempty"`
+	Tool       string    `json:"tool"`
+	Toolset    string    `json:"toolset"`
+	Outcome    string    `json:"outcome"`
+	Error      string    `json:"error,omitempty"`
+	Result     any       `json:"result"`
+}
+
+// Sink stores one archived record under key.
+type Sink interface {
+	Put(ctx context.Context, key string, body []byte) error
+}
+
+// writeTimeout bounds a single sink write so a slow bucket can't tie up a
+// write slot indefinitely.
+const writeTimeout = 10 * time.Second
+
+// maxInFlight bounds concurrent writes. Records past it are dropped rather
+// than queued so a slow sink can't pile up goroutines.
+const maxInFlight = 16
+
+type Archiver struct {
+	sink  Sink
+	slots chan struct{}
+	wg    sync.WaitGroup
+}
+
+var jsonMarshal = json.Marshal
+
+func New(sink Sink) *Archiver {
+	return &Archiver{sink: sink, slots: make(chan struct{}, maxInFlight)}
+}
+
+// Archive writes the record to the sink in the background, so the tool call
+// never waits on it. Failures are logged rather than returned: archiving
+// must never change the outcome of the tool call.
+func (a *Archiver) Archive(ctx context.Context, record Record) {
+	if a == nil || a.sink == nil {
+		return
+	}
+	data, err := jsonMarshal(record)
+	if err != nil {
+		slog.WarnContext(ctx, "archive record encode failed", "tool", record.Tool, "error", err)
+		return
+	}
+	key := Key(record)
+	select {
+	case a.slots <- struct{}{}:
+	default:
+		slog.WarnContext(ctx, "archive record dropped, too many writes in flight", "tool", record.Tool, "key", key)
+		return
+	}
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		defer func() { <-a.slots }()
+		writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), writeTimeout)
+		defer cancel()
+		if err := a.sink.Put(writeCtx, key, data); err != nil {
+			slog.WarnContext(ctx, "archive write failed", "tool", record.Tool, "key", key, "error", err)
+		}
+	}()
+}
+
+// Wait blocks until in-flight writes have completed or failed.
+func (a *Archiver) Wait() {
+	if a == nil {
+		return
+	}
+	a.wg.Wait()
+}
+
+// Key names a record by day, timestamp and tool, e.g.
+// "2026-10-15/20261015T093000.123456789Z_k8s.describe.json", so a listing
+// reads in call order.
+func Key(record Record) string {
+	ts := record.Timestamp.UTC()
+	return ts.Format("2006-01-02") + "/" + ts.Format("20060102T150405.000000000Z") + "_" + keySafe(record.Tool) + ".json"
+}
+
+// keySafe keeps a tool name to characters that are valid in file names and
+// need no escaping in S3 keys.
+func keySafe(name string) string {
+	if name == "" {
+		return "unknown"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
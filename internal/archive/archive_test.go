@@ -0,0 +1,127 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	sdkaws "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+var sampleRecord = Record{
+	Timestamp: time.Date(2026, 10, 15, 9, 30, 0, 5, time.UTC),
+	UserID:    "user",
+	TraceID:   "trace-1",
+	Tool:      "k8s.describe",
+	Toolset:   "k8s",
+	Outcome:   "success",
+	Result:    map[string]any{"ok": true},
+}
+
+func TestKey(t *testing.T) {
+	if got := Key(sampleRecord); got != "2026-10-15/20261015T093000.000000005Z_k8s.describe.json" {
+		t.Fatalf("unexpected key %q", got)
+	}
+	record := sampleRecord
+	record.Tool = "../x y"
+	if got := Key(record); got != "2026-10-15/20261015T093000.000000005Z_.._x_y.json" {
+		t.Fatalf("expected a safe key, got %q", got)
+	}
+}
+
+func TestFileSinkArchivesRecord(t *testing.T) {
+	dir := t.TempDir()
+	archiver := New(NewFileSink(dir))
+	archiver.Archive(context.Background(), sampleRecord)
+	archiver.Wait()
+
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(Key(sampleRecord))))
+	if err != nil {
+		t.Fatalf("read archived record: %v", err)
+	}
+	var got Record
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("decode archived record: %v", err)
+	}
+	if got.Tool != "k8s.describe" || got.TraceID != "trace-1" || got.Result.(map[string]any)["ok"] != true {
+		t.Fatalf("unexpected record %+v", got)
+	}
+}
+
+func TestS3SinkSignsPut(t *testing.T) {
+	var method, path, auth string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, auth = r.Method, r.URL.Path, r.Header.Get("Authorization")
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+	cfg := sdkaws.Config{Region: "us-west-2", Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", "")}
+	sink := NewS3Sink("incidents", "/rootcause/", server.URL, cfg)
+
+	if err := sink.Put(context.Background(), "2026-10-15/a.json", []byte(`{"ok":true}`)); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	if method != http.MethodPut || path != "/incidents/rootcause/2026-10-15/a.json" {
+		t.Fatalf("unexpected request %s %s", method, path)
+	}
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(auth, "/us-west-2/s3/") {
+		t.Fatalf("expected SigV4 authorization, got %q", auth)
+	}
+	if string(body) != `{"ok":true}` {
+		t.Fatalf("unexpected body %q", body)
+	}
+}
+
+func TestS3SinkReportsHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, `<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`)
+	}))
+	defer server.Close()
+	cfg := sdkaws.Config{Region: "us-east-1", Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", "")}
+
+	err := NewS3Sink("incidents", "", server.URL, cfg).Put(context.Background(), "a.json", []byte("{}"))
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Fatalf("expected access denied error, got %v", err)
+	}
+}
+
+type blockingSink struct {
+	release chan struct{}
+	puts    chan string
+}
+
+func (s *blockingSink) Put(_ context.Context, key string, _ []byte) error {
+	<-s.release
+	s.puts <- key
+	return nil
+}
+
+func TestArchiveDoesNotBlockAndBoundsWrites(t *testing.T) {
+	sink := &blockingSink{release: make(chan struct{}), puts: make(chan string, maxInFlight+1)}
+	archiver := New(sink)
+	for i := 0; i <= maxInFlight; i++ {
+		archiver.Archive(context.Background(), sampleRecord)
+	}
+	close(sink.release)
+	archiver.Wait()
+	if got := len(sink.puts); got != maxInFlight {
+		t.Fatalf("expected %d writes with the rest dropped, got %d", maxInFlight, got)
+	}
+}
+
+func TestArchiveNilArchiver(t *testing.T) {
+	var archiver *Archiver
+	archiver.Archive(context.Background(), sampleRecord)
+	archiver.Wait()
+}
@@ -0,0 +1,69 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	sdkaws "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// FileSink writes each record to its own file below dir.
+type FileSink struct {
+	dir string
+}
+
+func NewFileSink(dir string) *FileSink {
+	return &FileSink{dir: dir}
+}
+
+func (s *FileSink) Put(_ context.Context, key string, body []byte) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, body, 0o600)
+}
+
+// S3Sink uploads each record as an object, using the credentials from the
+// standard AWS chain. Endpoint, when set, points at an S3-compatible store
+// and switches to path-style addressing.
+type S3Sink struct {
+	bucket string
+	prefix string
+	client *s3.Client
+}
+
+func NewS3Sink(bucket, prefix, endpoint string, cfg sdkaws.Config) *S3Sink {
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if endpoint = strings.TrimRight(endpoint, "/"); endpoint != "" {
+			o.BaseEndpoint = sdkaws.String(endpoint)
+			o.UsePathStyle = true
+		}
+	})
+	return &S3Sink{
+		bucket: bucket,
+		prefix: strings.Trim(prefix, "/"),
+		client: client,
+	}
+}
+
+func (s *S3Sink) Put(ctx context.Context, key string, body []byte) error {
+	if s.prefix != "" {
+		key = s.prefix + "/" + key
+	}
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      sdkaws.String(s.bucket),
+		Key:         sdkaws.String(key),
+		Body:        bytes.NewReader(body),
+		ContentType: sdkaws.String("application/json"),
+	})
+	if err != nil {
+		return fmt.Errorf("put s3://%s/%s: %w", s.bucket, key, err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+// Package argconv converts loosely typed tool arguments. Arguments arrive
+// as JSON-decoded values (float64, json.Number, strings) or, from internal
+// callers and tests, as Go ints.
+package argconv
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Int accepts the numeric types JSON decoding and direct callers produce,
+// plus numeric strings, returning fallback for anything else.
+func Int(value any, fallback int) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case json.Number:
+		if parsed, err := v.Int64(); err == nil {
+			return int(parsed)
+		}
+	case string:
+		if parsed, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
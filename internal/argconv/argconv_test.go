@@ -0,0 +1,29 @@
+package argconv
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInt(t *testing.T) {
+	cases := []struct {
+		in   any
+		want int
+	}{
+		{7, 7},
+		{int64(8), 8},
+		{float64(5), 5},
+		{float64(2.9), 2},
+		{json.Number("9"), 9},
+		{json.Number("1.5"), 3},
+		{"11", 11},
+		{" 12 ", 12},
+		{"bad", 3},
+		{nil, 3},
+	}
+	for _, tc := range cases {
+		if got := Int(tc.in, 3); got != tc.want {
+			t.Fatalf("Int(%#v) = %d, want %d", tc.in, got, tc.want)
+		}
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	sdkaws "github.com/aws/aws-sdk-go-v2/aws"
 	sdkconfig "github.com/aws/aws-sdk-go-v2/config"
+
+	"rootcause/internal/logging"
 )
 
 const defaultRegion = "us-east-1"
@@ -35,6 +37,32 @@ func ResolveRegionWithConfig(region, cfgRegion string) string {
 	return strings.TrimSpace(cfgRegion)
 }
 
+// DefaultRegionWithConfig reports the region clients fall back to when a
+// caller passes none, and where it came from: the AWS_REGION /
+// AWS_DEFAULT_REGION env, the [aws].region config value, the shared AWS
+// config profile, or the built-in us-east-1.
+func DefaultRegionWithConfig(ctx context.Context, cfgRegion, cfgProfile, cfgCredentialsFile string) (region, source string) {
+	for _, env := range []string{"AWS_REGION", "AWS_DEFAULT_REGION"} {
+		if v := strings.TrimSpace(os.Getenv(env)); v != "" {
+			return v, env
+		}
+	}
+	if v := strings.TrimSpace(cfgRegion); v != "" {
+		return v, "config"
+	}
+	loadOpts := []func(*sdkconfig.LoadOptions) error{}
+	if profile := ResolveProfileWithConfig(cfgProfile); profile != "" {
+		loadOpts = append(loadOpts, sdkconfig.WithSharedConfigProfile(profile))
+	}
+	if cf := strings.TrimSpace(cfgCredentialsFile); cf != "" {
+		loadOpts = append(loadOpts, sdkconfig.WithSharedCredentialsFiles([]string{cf}))
+	}
+	if cfg, err := sdkconfig.LoadDefaultConfig(ctx, loadOpts...); err == nil && strings.TrimSpace(cfg.Region) != "" {
+		return strings.TrimSpace(cfg.Region), "shared config"
+	}
+	return defaultRegion, "default"
+}
+
 // LoadConfig builds an AWS SDK config honoring the standard discovery chain.
 // Use LoadConfigWithDefaults to thread [aws] config-file defaults
 // (region/profile/credentials_file) through the SDK load options.
@@ -52,7 +80,7 @@ func LoadConfigWithDefaults(ctx context.Context, region, cfgRegion, cfgProfile s
 // LoadConfigWithSecrets is the fullest form of the loader: it accepts the
 // config-file values for region, profile, and shared credentials file.
 func LoadConfigWithSecrets(ctx context.Context, region, cfgRegion, cfgProfile, cfgCredentialsFile string) (sdkaws.Config, error) {
-	loadOpts := []func(*sdkconfig.LoadOptions) error{}
+	loadOpts := []func(*sdkconfig.LoadOptions) error{sdkconfig.WithLogger(logging.AWSLogger())}
 	if profile := ResolveProfileWithConfig(cfgProfile); profile != "" {
 		loadOpts = append(loadOpts, sdkconfig.WithSharedConfigProfile(profile))
 	}
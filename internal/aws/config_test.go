@@ -118,3 +118,34 @@ aws_secret_access_key = secrettest
 		t.Errorf("expected acme profile creds from [aws].credentials_file, got AccessKeyID=%q", creds.AccessKeyID)
 	}
 }
+
+func TestDefaultRegionWithConfigSources(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config")
+	if err := os.WriteFile(configPath, []byte("[default]\nregion = eu-north-1\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("AWS_CONFIG_FILE", configPath)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_DEFAULT_PROFILE", "")
+	t.Setenv("AWS_REGION", "us-west-2")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	ctx := context.Background()
+	if region, source := DefaultRegionWithConfig(ctx, "cfg-region", "", ""); region != "us-west-2" || source != "AWS_REGION" {
+		t.Errorf("env should win, got %q from %q", region, source)
+	}
+	t.Setenv("AWS_REGION", "")
+	if region, source := DefaultRegionWithConfig(ctx, "cfg-region", "", ""); region != "cfg-region" || source != "config" {
+		t.Errorf("config should win when env empty, got %q from %q", region, source)
+	}
+	if region, source := DefaultRegionWithConfig(ctx, "", "", ""); region != "eu-north-1" || source != "shared config" {
+		t.Errorf("expected shared config profile region, got %q from %q", region, source)
+	}
+	if err := os.WriteFile(configPath, []byte("[default]\n"), 0o600); err != nil {
+		t.Fatalf("rewrite config: %v", err)
+	}
+	if region, source := DefaultRegionWithConfig(ctx, "", "", ""); region != defaultRegion || source != "default" {
+		t.Errorf("expected built-in default, got %q from %q", region, source)
+	}
+}
@@ -0,0 +1,107 @@
+package aws
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Cursor is the opaque nextToken handed back by aws.* list tools. AWS tokens
+// only point at page boundaries, so when a tool's limit cuts a page short the
+// cursor also records how many items of that page were already returned. The
+// next call re-fetches the same page and skips them instead of silently
+// dropping the rest of the page.
+type Cursor struct {
+	Token string `json:"t,omitempty"`
+	Skip  int    `json:"s,omitempty"`
+}
+
+// ParseCursor decodes a nextToken produced by Cursor.String. An empty string
+// is the zero cursor (start from the first page).
+func ParseCursor(raw string) (Cursor, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return Cursor{}, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return Cursor{}, errors.New("invalid nextToken: pass the nextToken value from a previous result unchanged")
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Skip < 0 {
+		return Cursor{}, errors.New("invalid nextToken: pass the nextToken value from a previous result unchanged")
+	}
+	return cursor, nil
+}
+
+// String encodes the cursor, or returns "" for the zero cursor.
+func (c Cursor) String() string {
+	if c.Token == "" && c.Skip == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Pager applies a result limit across AWS pages and works out the nextToken
+// to return. Handlers feed it one page at a time and keep only the [start,
+// end) range it hands back.
+type Pager struct {
+	limit   int
+	skip    int
+	startAt string
+	count   int
+	next    Cursor
+}
+
+// NewPager starts a listing at rawToken (a previous result's nextToken, or ""
+// for the first page). limit <= 0 means no limit.
+func NewPager(limit int, rawToken string) (*Pager, error) {
+	cursor, err := ParseCursor(rawToken)
+	if err != nil {
+		return nil, err
+	}
+	return &Pager{limit: limit, skip: cursor.Skip, startAt: cursor.Token}, nil
+}
+
+// StartToken is the AWS token for the first request, or nil.
+func (p *Pager) StartToken() *string {
+	if p.startAt == "" {
+		return nil
+	}
+	token := p.startAt
+	return &token
+}
+
+// Page records a page of n items fetched with pageToken ("" for the first
+// page) whose response carried awsNext. It returns the range of the page to
+// keep and whether another page should be fetched.
+func (p *Pager) Page(pageToken string, n int, awsNext *string) (start, end int, more bool) {
+	start = min(p.skip, n)
+	p.skip = 0
+	end = n
+	next := ""
+	if awsNext != nil {
+		next = *awsNext
+	}
+	if p.limit > 0 && p.count+(end-start) >= p.limit {
+		end = start + (p.limit - p.count)
+		p.count = p.limit
+		switch {
+		case end < n:
+			p.next = Cursor{Token: pageToken, Skip: end}
+		case next != "":
+			p.next = Cursor{Token: next}
+		}
+		return start, end, false
+	}
+	p.count += end - start
+	return start, end, next != ""
+}
+
+// NextToken is the cursor for the item after the last one kept, or "" when
+// the listing is exhausted.
+func (p *Pager) NextToken() string {
+	return p.next.String()
+}
@@ -0,0 +1,78 @@
+package aws
+
+import "testing"
+
+func TestPagerStopsMidPageAndResumes(t *testing.T) {
+	pager, err := NewPager(3, "")
+	if err != nil {
+		t.Fatalf("NewPager: %v", err)
+	}
+	if pager.StartToken() != nil {
+		t.Fatalf("expected nil start token")
+	}
+	next := "page-2"
+	start, end, more := pager.Page("", 2, &next)
+	if start != 0 || end != 2 || !more {
+		t.Fatalf("unexpected first page: %d %d %v", start, end, more)
+	}
+	last := "page-3"
+	start, end, more = pager.Page("page-2", 4, &last)
+	if start != 0 || end != 1 || more {
+		t.Fatalf("unexpected second page: %d %d %v", start, end, more)
+	}
+	token := pager.NextToken()
+	if token == "" {
+		t.Fatalf("expected nextToken")
+	}
+
+	resumed, err := NewPager(10, token)
+	if err != nil {
+		t.Fatalf("NewPager resume: %v", err)
+	}
+	if got := resumed.StartToken(); got == nil || *got != "page-2" {
+		t.Fatalf("expected resume at page-2, got %v", got)
+	}
+	start, end, more = resumed.Page("page-2", 4, nil)
+	if start != 1 || end != 4 || more {
+		t.Fatalf("unexpected resumed page: %d %d %v", start, end, more)
+	}
+	if resumed.NextToken() != "" {
+		t.Fatalf("expected exhausted listing")
+	}
+}
+
+func TestPagerPageBoundaryUsesAWSToken(t *testing.T) {
+	pager, _ := NewPager(2, "")
+	next := "page-2"
+	pager.Page("", 2, &next)
+	cursor, err := ParseCursor(pager.NextToken())
+	if err != nil {
+		t.Fatalf("ParseCursor: %v", err)
+	}
+	if cursor.Token != "page-2" || cursor.Skip != 0 {
+		t.Fatalf("unexpected cursor: %#v", cursor)
+	}
+}
+
+func TestPagerNoLimit(t *testing.T) {
+	pager, _ := NewPager(0, "")
+	next := "page-2"
+	if _, end, more := pager.Page("", 50, &next); end != 50 || !more {
+		t.Fatalf("expected full page and more")
+	}
+	if _, _, more := pager.Page("page-2", 5, nil); more {
+		t.Fatalf("expected listing to end")
+	}
+	if pager.NextToken() != "" {
+		t.Fatalf("expected no nextToken")
+	}
+}
+
+func TestParseCursorInvalid(t *testing.T) {
+	if _, err := ParseCursor("not a cursor!"); err == nil {
+		t.Fatalf("expected error for invalid cursor")
+	}
+	if _, err := NewPager(1, "e30x"); err == nil {
+		t.Fatalf("expected error for invalid cursor")
+	}
+}
@@ -14,10 +14,12 @@ import (
 type Config struct {
 	Kubeconfig         string          `yaml:"kubeconfig"`
 	Context            string          `yaml:"context"`
+	AllowedContexts    []string        `yaml:"allowed_contexts"`
 	Toolsets           []string        `yaml:"toolsets"`
 	ReadOnly           bool            `yaml:"read_only"`
 	DisableDestructive bool            `yaml:"disable_destructive"`
 	LogLevel           string          `yaml:"log_level"`
+	LogFormat          string          `yaml:"log_format"`
 	Safety             SafetyConfig    `yaml:"safety"`
 	Exec               ExecConfig      `yaml:"exec_readonly"`
 	Timeouts           TimeoutConfig   `yaml:"timeouts"`
@@ -25,9 +27,17 @@ type Config struct {
 	Prompts            PromptsConfig   `yaml:"prompts"`
 	Skills             SkillsConfig    `yaml:"skills"`
 	Limits             LimitsConfig    `yaml:"limits"`
+	Render             RenderConfig    `yaml:"render"`
 	GCP                GCPConfig            `yaml:"gcp"`
 	AWS                AWSConfig            `yaml:"aws"`
 	Observability      ObservabilityConfig  `yaml:"observability"`
+	Archive            ArchiveConfig        `yaml:"archive"`
+	Webhook            WebhookConfig        `yaml:"webhook"`
+
+	// sources and overridden record where values came from so Validate can
+	// point at the offending file and line.
+	sources    []source
+	overridden []string
 }
 
 // GCPConfig holds baseline GCP auth defaults that apply to every gcp.* tool
@@ -93,6 +103,47 @@ type AWSConfig struct {
 	// Optional. Leave empty for SSO, instance metadata, environment
 	// credentials, and the SDK default discovery chain.
 	CredentialsFile string `yaml:"credentials_file"`
+	// RedactAccountID masks 12-digit account ids across the aws toolsets:
+	// caller identity (sts whoami, get_caller_identity, preflight), principal
+	// and role ARNs, and the trust, key and grant policy documents from iam
+	// and kms. Use it for transcripts that leave the team.
+	RedactAccountID bool `yaml:"redact_account_id"`
+}
+
+// ArchiveConfig persists every redacted tool result to a sink so incident
+// investigations outlive the session. Records are keyed by timestamp and
+// tool name; calls from one investigation share a trace id.
+type ArchiveConfig struct {
+	// Sink is "" (disabled), "file" or "s3".
+	Sink string `yaml:"sink"`
+	// Dir is the directory the file sink writes to.
+	Dir string `yaml:"dir"`
+	// S3 configures the s3 sink. Credentials and profile come from the aws
+	// section and the standard AWS chain.
+	S3 ArchiveS3Config `yaml:"s3"`
+}
+
+type ArchiveS3Config struct {
+	Bucket string `yaml:"bucket"`
+	// Prefix is prepended to every object key.
+	Prefix string `yaml:"prefix"`
+	// Region overrides the aws section region for the bucket.
+	Region string `yaml:"region"`
+	// Endpoint points at an S3-compatible store (e.g. MinIO) and switches to
+	// path-style URLs. Leave empty for AWS.
+	Endpoint string `yaml:"endpoint"`
+}
+
+// WebhookConfig posts an alert to URL whenever a tool result reports a likely
+// root cause at MinSeverity or worse. Delivery runs in the background and
+// never delays the tool response.
+type WebhookConfig struct {
+	// URL receives a JSON POST per alerting tool call. Empty disables it.
+	URL string `yaml:"url"`
+	// MinSeverity is critical, high (default), medium or low.
+	MinSeverity string `yaml:"min_severity"`
+	// TimeoutSeconds bounds each POST.
+	TimeoutSeconds int `yaml:"timeout_seconds"`
 }
 
 type LimitsConfig struct {
@@ -100,6 +151,20 @@ type LimitsConfig struct {
 	MaxResultBytes int  `yaml:"max_result_bytes"`
 	MaxCallGraph   int  `yaml:"max_call_graph"`
 	StrictSchema   bool `yaml:"strict_schema"`
+	// MaxGraphNodes and MaxGraphEdges cap how far k8s.graph expands before
+	// it stops and reports the graph as truncated.
+	MaxGraphNodes int `yaml:"max_graph_nodes"`
+	MaxGraphEdges int `yaml:"max_graph_edges"`
+}
+
+// RenderConfig controls how tool results are presented as text. Callers can
+// override Format per call with the `outputFormat` tool argument.
+type RenderConfig struct {
+	// Format is one of json (default), markdown, or plain.
+	Format string `yaml:"format"`
+	// OmitAge drops the derived ageSeconds/age fields that summaries add next
+	// to creation timestamps, to keep results smaller.
+	OmitAge bool `yaml:"omit_age"`
 }
 
 type SafetyConfig struct {
@@ -147,6 +212,7 @@ type Overrides struct {
 	ReadOnly           *bool
 	DisableDestructive *bool
 	LogLevel           *string
+	LogFormat          *string
 }
 
 func DefaultConfig() Config {
@@ -154,6 +220,7 @@ func DefaultConfig() Config {
 		Kubeconfig: "",
 		Toolsets:   []string{"k8s", "linkerd", "karpenter", "istio", "helm", "aws", "terraform", "observability", "rootcause"},
 		LogLevel:   "info",
+		LogFormat:  "text",
 		Timeouts: TimeoutConfig{
 			DefaultSeconds: 60,
 			MaxSeconds:     900,
@@ -176,6 +243,15 @@ func DefaultConfig() Config {
 			MaxCallDepth:   8,
 			MaxResultBytes: 8 * 1024 * 1024,
 			MaxCallGraph:   10000,
+			MaxGraphNodes:  2000,
+			MaxGraphEdges:  5000,
+		},
+		Render: RenderConfig{
+			Format: "json",
+		},
+		Webhook: WebhookConfig{
+			MinSeverity:    "high",
+			TimeoutSeconds: 10,
 		},
 	}
 }
@@ -215,9 +291,17 @@ func readFile(path string) (Config, error) {
 	if err != nil {
 		return cfg, err
 	}
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return cfg, fmt.Errorf("decode %s: %w", path, err)
+	}
+	if err := validateFile(path, &root); err != nil {
+		return cfg, err
+	}
+	if err := root.Decode(&cfg); err != nil {
 		return cfg, fmt.Errorf("decode %s: %w", path, err)
 	}
+	cfg.sources = []source{{path: path, root: &root}}
 	return cfg, nil
 }
 
@@ -241,12 +325,16 @@ func dropInFiles(dir string) ([]string, error) {
 }
 
 func merge(dst *Config, src Config) {
+	dst.sources = append(dst.sources, src.sources...)
 	if src.Kubeconfig != "" {
 		dst.Kubeconfig = src.Kubeconfig
 	}
 	if src.Context != "" {
 		dst.Context = src.Context
 	}
+	if len(src.AllowedContexts) > 0 {
+		dst.AllowedContexts = append([]string{}, src.AllowedContexts...)
+	}
 	if len(src.Toolsets) > 0 {
 		dst.Toolsets = append([]string{}, src.Toolsets...)
 	}
@@ -259,6 +347,9 @@ func merge(dst *Config, src Config) {
 	if src.LogLevel != "" {
 		dst.LogLevel = src.LogLevel
 	}
+	if src.LogFormat != "" {
+		dst.LogFormat = src.LogFormat
+	}
 	if len(src.Safety.AllowDestructiveTools) > 0 {
 		dst.Safety.AllowDestructiveTools = append([]string{}, src.Safety.AllowDestructiveTools...)
 	}
@@ -307,9 +398,21 @@ func merge(dst *Config, src Config) {
 	if src.Limits.MaxCallGraph > 0 {
 		dst.Limits.MaxCallGraph = src.Limits.MaxCallGraph
 	}
+	if src.Limits.MaxGraphNodes > 0 {
+		dst.Limits.MaxGraphNodes = src.Limits.MaxGraphNodes
+	}
+	if src.Limits.MaxGraphEdges > 0 {
+		dst.Limits.MaxGraphEdges = src.Limits.MaxGraphEdges
+	}
 	if src.Limits.StrictSchema {
 		dst.Limits.StrictSchema = src.Limits.StrictSchema
 	}
+	if src.Render.Format != "" {
+		dst.Render.Format = src.Render.Format
+	}
+	if src.Render.OmitAge {
+		dst.Render.OmitAge = src.Render.OmitAge
+	}
 	if src.Prompts.Dir != "" {
 		dst.Prompts.Dir = src.Prompts.Dir
 	}
@@ -331,25 +434,65 @@ func merge(dst *Config, src Config) {
 	if src.AWS.CredentialsFile != "" {
 		dst.AWS.CredentialsFile = src.AWS.CredentialsFile
 	}
+	if src.AWS.RedactAccountID {
+		dst.AWS.RedactAccountID = src.AWS.RedactAccountID
+	}
+	if src.Archive.Sink != "" {
+		dst.Archive.Sink = src.Archive.Sink
+	}
+	if src.Archive.Dir != "" {
+		dst.Archive.Dir = src.Archive.Dir
+	}
+	if src.Archive.S3.Bucket != "" {
+		dst.Archive.S3.Bucket = src.Archive.S3.Bucket
+	}
+	if src.Archive.S3.Prefix != "" {
+		dst.Archive.S3.Prefix = src.Archive.S3.Prefix
+	}
+	if src.Archive.S3.Region != "" {
+		dst.Archive.S3.Region = src.Archive.S3.Region
+	}
+	if src.Archive.S3.Endpoint != "" {
+		dst.Archive.S3.Endpoint = src.Archive.S3.Endpoint
+	}
+	if src.Webhook.URL != "" {
+		dst.Webhook.URL = src.Webhook.URL
+	}
+	if src.Webhook.MinSeverity != "" {
+		dst.Webhook.MinSeverity = src.Webhook.MinSeverity
+	}
+	if src.Webhook.TimeoutSeconds > 0 {
+		dst.Webhook.TimeoutSeconds = src.Webhook.TimeoutSeconds
+	}
 }
 
 func applyOverrides(cfg *Config, overrides Overrides) {
 	if overrides.Kubeconfig != nil {
 		cfg.Kubeconfig = *overrides.Kubeconfig
+		cfg.overridden = append(cfg.overridden, "kubeconfig")
 	}
 	if overrides.Context != nil {
 		cfg.Context = *overrides.Context
+		cfg.overridden = append(cfg.overridden, "context")
 	}
 	if overrides.Toolsets != nil {
 		cfg.Toolsets = append([]string{}, (*overrides.Toolsets)...)
+		cfg.overridden = append(cfg.overridden, "toolsets")
 	}
 	if overrides.ReadOnly != nil {
 		cfg.ReadOnly = *overrides.ReadOnly
+		cfg.overridden = append(cfg.overridden, "read_only")
 	}
 	if overrides.DisableDestructive != nil {
 		cfg.DisableDestructive = *overrides.DisableDestructive
+		cfg.overridden = append(cfg.overridden, "disable_destructive")
 	}
 	if overrides.LogLevel != nil {
 		cfg.LogLevel = *overrides.LogLevel
+		cfg.overridden = append(cfg.overridden, "log_level")
+	}
+	if overrides.LogFormat != nil {
+		cfg.LogFormat = *overrides.LogFormat
+		cfg.overridden = append(cfg.overridden, "log_format")
 	}
 }
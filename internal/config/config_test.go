@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -148,6 +150,7 @@ func TestMergeTimeoutsAndCache(t *testing.T) {
 			CustomDirs:           []string{"/tmp/skills"},
 			AllowCustomOverrides: true,
 		},
+		AllowedContexts: []string{"prod", "staging"},
 	}
 	merge(&dst, src)
 	if !dst.ReadOnly {
@@ -171,6 +174,9 @@ func TestMergeTimeoutsAndCache(t *testing.T) {
 	if len(dst.Skills.CustomDirs) != 1 || dst.Skills.CustomDirs[0] != "/tmp/skills" || !dst.Skills.AllowCustomOverrides {
 		t.Fatalf("unexpected skills config: %#v", dst.Skills)
 	}
+	if len(dst.AllowedContexts) != 2 || dst.AllowedContexts[1] != "staging" {
+		t.Fatalf("unexpected allowed contexts: %#v", dst.AllowedContexts)
+	}
 }
 
 func TestApplyOverrides(t *testing.T) {
@@ -179,6 +185,7 @@ func TestApplyOverrides(t *testing.T) {
 	readOnly := true
 	disable := true
 	logLevel := "warn"
+	logFormat := "json"
 	kubeconfig := "/tmp/kubeconfig"
 	context := "demo"
 	applyOverrides(&cfg, Overrides{
@@ -188,6 +195,7 @@ func TestApplyOverrides(t *testing.T) {
 		ReadOnly:           &readOnly,
 		DisableDestructive: &disable,
 		LogLevel:           &logLevel,
+		LogFormat:          &logFormat,
 	})
 	if cfg.Kubeconfig != kubeconfig || cfg.Context != context {
 		t.Fatalf("unexpected overrides: %#v", cfg)
@@ -195,7 +203,148 @@ func TestApplyOverrides(t *testing.T) {
 	if len(cfg.Toolsets) != 1 || cfg.Toolsets[0] != "k8s" {
 		t.Fatalf("unexpected toolsets: %#v", cfg.Toolsets)
 	}
-	if !cfg.ReadOnly || !cfg.DisableDestructive || cfg.LogLevel != "warn" {
+	if !cfg.ReadOnly || !cfg.DisableDestructive || cfg.LogLevel != "warn" || cfg.LogFormat != "json" {
 		t.Fatalf("unexpected overrides applied: %#v", cfg)
 	}
 }
+
+func TestLoadRejectsUnknownKeyWithLine(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("toolsets: [k8s]\ncache:\n  graph_ttl: 30\n"), 0600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	_, err := Load(path, "", Overrides{})
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if len(verr.Issues) != 1 {
+		t.Fatalf("expected one issue, got %#v", verr.Issues)
+	}
+	issue := verr.Issues[0]
+	if issue.Key != "cache.graph_ttl" || issue.Line != 3 || issue.File != path {
+		t.Fatalf("unexpected issue: %#v", issue)
+	}
+}
+
+func TestLoadRejectsInvalidValueWithLine(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("cache:\n  graph_ttl_seconds: -5\ntimeouts:\n  per_tool:\n    helm.install: \"slow\"\n"), 0600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	_, err := Load(path, "", Overrides{})
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	got := map[string]int{}
+	for _, issue := range verr.Issues {
+		got[issue.Key] = issue.Line
+	}
+	if got["cache.graph_ttl_seconds"] != 2 || got["timeouts.per_tool.helm.install"] != 5 {
+		t.Fatalf("unexpected issues: %#v", verr.Issues)
+	}
+	if !strings.Contains(err.Error(), path+":2: cache.graph_ttl_seconds") {
+		t.Fatalf("expected file:line in message, got %q", err.Error())
+	}
+}
+
+func TestLoadEmptyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if _, err := Load(path, "", Overrides{}); err != nil {
+		t.Fatalf("load empty file: %v", err)
+	}
+}
+
+func TestValidateUnknownToolset(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("toolsets:\n  - k8s\n  - kubernetes\n"), 0600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cfg, err := Load(path, "", Overrides{})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	err = cfg.Validate([]string{"k8s", "aws"})
+	var verr *ValidationError
+	if !errors.As(err, &verr) || len(verr.Issues) != 1 {
+		t.Fatalf("expected one validation issue, got %v", err)
+	}
+	if issue := verr.Issues[0]; issue.Key != "toolsets[1]" || issue.Line != 3 || issue.File != path {
+		t.Fatalf("unexpected issue: %#v", issue)
+	}
+
+	toolsets := []string{"bogus"}
+	cfg, err = Load(path, "", Overrides{Toolsets: &toolsets})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	err = cfg.Validate([]string{"k8s"})
+	if !errors.As(err, &verr) || verr.Issues[0].File != "command-line flags" || verr.Issues[0].Line != 0 {
+		t.Fatalf("expected override attribution, got %v", err)
+	}
+}
+
+func TestValidateTimeoutBounds(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Timeouts.DefaultSeconds = 1000
+	err := cfg.Validate(nil)
+	if err == nil || !strings.Contains(err.Error(), "timeouts.default_seconds") {
+		t.Fatalf("expected default_seconds issue, got %v", err)
+	}
+	if err := DefaultConfig().Validate(nil); err != nil {
+		t.Fatalf("default config should validate: %v", err)
+	}
+}
+
+func TestValidateArchiveSink(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Archive.Sink = "s3"
+	err := cfg.Validate(nil)
+	if err == nil || !strings.Contains(err.Error(), "archive.s3.bucket") {
+		t.Fatalf("expected bucket issue, got %v", err)
+	}
+	cfg.Archive.S3.Bucket = "incidents"
+	if err := cfg.Validate(nil); err != nil {
+		t.Fatalf("s3 sink with bucket should validate: %v", err)
+	}
+	cfg.Archive.Sink = "gcs"
+	if err := cfg.Validate(nil); err == nil || !strings.Contains(err.Error(), "archive.sink") {
+		t.Fatalf("expected sink enum issue, got %v", err)
+	}
+}
+
+func TestValidateWebhook(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Webhook.URL = "hooks.example.com/alert"
+	if err := cfg.Validate(nil); err == nil || !strings.Contains(err.Error(), "webhook.url") {
+		t.Fatalf("expected url issue, got %v", err)
+	}
+	cfg.Webhook.URL = "https://hooks.example.com/alert"
+	if err := cfg.Validate(nil); err != nil {
+		t.Fatalf("https webhook should validate: %v", err)
+	}
+	cfg.Webhook.MinSeverity = "urgent"
+	if err := cfg.Validate(nil); err == nil || !strings.Contains(err.Error(), "webhook.min_severity") {
+		t.Fatalf("expected min_severity issue, got %v", err)
+	}
+}
+
+func TestSchemaCoversConfig(t *testing.T) {
+	doc, err := toDocument(DefaultConfig())
+	if err != nil {
+		t.Fatalf("toDocument: %v", err)
+	}
+	props := Schema(nil)["properties"].(map[string]any)
+	for key := range doc.(map[string]any) {
+		if _, ok := props[key]; !ok {
+			t.Fatalf("schema missing top-level key %q", key)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package config
+
+// Schema returns the JSON schema for the RootCause config file. It mirrors the
+// yaml tags on Config and is used by Validate, so a field added to Config must
+// be added here too (TestSchemaCoversConfig enforces this).
+//
+// knownToolsets, when non-empty, restricts `toolsets` entries to the toolset
+// ids compiled into the binary. Zero values are allowed everywhere because the
+// loader treats them as "unset, keep the default".
+func Schema(knownToolsets []string) map[string]any {
+	toolsetItem := map[string]any{"type": "string", "minLength": 1}
+	if len(knownToolsets) > 0 {
+		toolsetItem["enum"] = stringsToAny(knownToolsets)
+	}
+	return object(map[string]any{
+		"kubeconfig":          map[string]any{"type": "string"},
+		"context":             map[string]any{"type": "string"},
+		"allowed_contexts":    stringList(),
+		"toolsets":            map[string]any{"type": []any{"array", "null"}, "items": toolsetItem},
+		"read_only":           map[string]any{"type": "boolean"},
+		"disable_destructive": map[string]any{"type": "boolean"},
+		"log_level":           map[string]any{"type": "string", "enum": []any{"", "debug", "info", "warn", "warning", "error"}},
+		"log_format":          map[string]any{"type": "string", "enum": []any{"", "text", "json"}},
+		"safety": object(map[string]any{
+			"allow_destructive_tools": stringList(),
+		}),
+		"exec_readonly": object(map[string]any{
+			"enabled":          map[string]any{"type": "boolean"},
+			"allowed_commands": stringList(),
+		}),
+		"timeouts": object(map[string]any{
+			"default_seconds": nonNegativeInt(),
+			"max_seconds":     nonNegativeInt(),
+			"per_tool": map[string]any{
+				"type":                 []any{"object", "null"},
+				"additionalProperties": map[string]any{"type": "integer", "minimum": 1},
+			},
+		}),
+		"cache": object(map[string]any{
+			"discovery_ttl_seconds": nonNegativeInt(),
+			"graph_ttl_seconds":     nonNegativeInt(),
+			"aws_list_ttl_seconds":  nonNegativeInt(),
+		}),
+		"prompts": object(map[string]any{
+			"file": map[string]any{"type": "string"},
+			"dir":  map[string]any{"type": "string"},
+		}),
+		"skills": object(map[string]any{
+			"custom_dirs":            stringList(),
+			"allow_custom_overrides": map[string]any{"type": "boolean"},
+		}),
+		"limits": object(map[string]any{
+			"max_call_depth":   nonNegativeInt(),
+			"max_result_bytes": nonNegativeInt(),
+			"max_call_graph":   nonNegativeInt(),
+			"max_graph_nodes":  nonNegativeInt(),
+			"max_graph_edges":  nonNegativeInt(),
+			"strict_schema":    map[string]any{"type": "boolean"},
+		}),
+		"render": object(map[string]any{
+			"format":   map[string]any{"type": "string", "enum": []any{"", "json", "markdown", "plain"}},
+			"omit_age": map[string]any{"type": "boolean"},
+		}),
+		"gcp": object(map[string]any{
+			"credentials_file": map[string]any{"type": "string"},
+		}),
+		"aws": object(map[string]any{
+			"region":            map[string]any{"type": "string"},
+			"profile":           map[string]any{"type": "string"},
+			"credentials_file":  map[string]any{"type": "string"},
+			"redact_account_id": map[string]any{"type": "boolean"},
+		}),
+		"observability": object(map[string]any{
+			"gcp": object(map[string]any{
+				"project":          map[string]any{"type": "string"},
+				"credentials_file": map[string]any{"type": "string"},
+			}),
+		}),
+		"archive": object(map[string]any{
+			"sink": map[string]any{"type": "string", "enum": []any{"", "file", "s3"}},
+			"dir":  map[string]any{"type": "string"},
+			"s3": object(map[string]any{
+				"bucket":   map[string]any{"type": "string"},
+				"prefix":   map[string]any{"type": "string"},
+				"region":   map[string]any{"type": "string"},
+				"endpoint": map[string]any{"type": "string"},
+			}),
+		}),
+		"webhook": object(map[string]any{
+			"url":             map[string]any{"type": "string"},
+			"min_severity":    map[string]any{"type": "string", "enum": []any{"", "critical", "high", "medium", "low"}},
+			"timeout_seconds": nonNegativeInt(),
+		}),
+	})
+}
+
+func object(properties map[string]any) map[string]any {
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+func stringList() map[string]any {
+	return map[string]any{"type": []any{"array", "null"}, "items": map[string]any{"type": "string"}}
+}
+
+func nonNegativeInt() map[string]any {
+	return map[string]any{"type": "integer", "minimum": 0}
+}
+
+func stringsToAny(values []string) []any {
+	out := make([]any, 0, len(values))
+	for _, v := range values {
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,247 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// Issue is a single config validation failure. Key is the dotted YAML path of
+// the offending value (e.g. "cache.graph_ttl_seconds" or "toolsets[2]"). File
+// and Line point at the place the value was set when it came from a config
+// file; both are empty for defaults and command-line overrides.
+type Issue struct {
+	Key     string
+	Message string
+	File    string
+	Line    int
+}
+
+func (i Issue) String() string {
+	location := ""
+	switch {
+	case i.File != "" && i.Line > 0:
+		location = fmt.Sprintf("%s:%d: ", i.File, i.Line)
+	case i.File != "":
+		location = i.File + ": "
+	}
+	return fmt.Sprintf("%s%s: %s", location, i.Key, i.Message)
+}
+
+// ValidationError aggregates every Issue found so a broken config can be fixed
+// in one pass instead of one restart per mistake.
+type ValidationError struct {
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		lines = append(lines, "  "+issue.String())
+	}
+	return "invalid config:\n" + strings.Join(lines, "\n")
+}
+
+// source remembers the parsed YAML of a loaded file so validation issues on
+// the merged config can be traced back to a file and line.
+type source struct {
+	path string
+	root *yaml.Node
+}
+
+// Validate checks the merged config. knownToolsets is the set of toolset ids
+// compiled into the binary; unknown entries in `toolsets` are reported by
+// name instead of failing later with a bare "unknown toolset".
+func (c Config) Validate(knownToolsets []string) error {
+	doc, err := toDocument(c)
+	if err != nil {
+		return err
+	}
+	issues, err := validateDocument(doc, Schema(knownToolsets))
+	if err != nil {
+		return err
+	}
+	if c.Timeouts.DefaultSeconds > 0 && c.Timeouts.MaxSeconds > 0 && c.Timeouts.DefaultSeconds > c.Timeouts.MaxSeconds {
+		issues = append(issues, Issue{
+			Key:     "timeouts.default_seconds",
+			Message: fmt.Sprintf("must not exceed timeouts.max_seconds (%d)", c.Timeouts.MaxSeconds),
+		})
+	}
+	if c.Archive.Sink == "file" && c.Archive.Dir == "" {
+		issues = append(issues, Issue{Key: "archive.dir", Message: "is required when archive.sink is file"})
+	}
+	if c.Archive.Sink == "s3" && c.Archive.S3.Bucket == "" {
+		issues = append(issues, Issue{Key: "archive.s3.bucket", Message: "is required when archive.sink is s3"})
+	}
+	if c.Webhook.URL != "" {
+		if u, err := url.Parse(c.Webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			issues = append(issues, Issue{Key: "webhook.url", Message: "must be an http or https URL"})
+		}
+	}
+	for i := range issues {
+		c.locate(&issues[i])
+	}
+	return issuesError(issues)
+}
+
+// locate attributes an issue to the last source that set the key, mirroring
+// merge order. Keys overridden on the command line have no file position.
+func (c Config) locate(issue *Issue) {
+	top := strings.SplitN(strings.SplitN(issue.Key, "[", 2)[0], ".", 2)[0]
+	for _, name := range c.overridden {
+		if name == top {
+			issue.File = "command-line flags"
+			return
+		}
+	}
+	path := keyPath(issue.Key)
+	for i := len(c.sources) - 1; i >= 0; i-- {
+		if line := lineOf(c.sources[i].root, path); line > 0 {
+			issue.File = c.sources[i].path
+			issue.Line = line
+			return
+		}
+	}
+}
+
+// validateFile checks a single config file before it is decoded so unknown
+// keys, wrong types, and negative values are reported with their line.
+func validateFile(path string, root *yaml.Node) error {
+	var doc any
+	if err := root.Decode(&doc); err != nil {
+		return fmt.Errorf("decode %s: %w", path, err)
+	}
+	if doc == nil {
+		return nil
+	}
+	issues, err := validateDocument(doc, Schema(nil))
+	if err != nil {
+		return err
+	}
+	for i := range issues {
+		issues[i].File = path
+		issues[i].Line = lineOf(root, keyPath(issues[i].Key))
+	}
+	return issuesError(issues)
+}
+
+func validateDocument(doc any, schema map[string]any) ([]Issue, error) {
+	compiled, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("compile config schema: %w", err)
+	}
+	result, err := compiled.Validate(gojsonschema.NewGoLoader(doc))
+	if err != nil {
+		return nil, fmt.Errorf("validate config: %w", err)
+	}
+	var issues []Issue
+	for _, resErr := range result.Errors() {
+		key := resErr.Field()
+		message := resErr.Description()
+		if resErr.Type() == "additional_property_not_allowed" {
+			property, _ := resErr.Details()["property"].(string)
+			if key == "(root)" {
+				key = property
+			} else {
+				key = key + "." + property
+			}
+			message = "unknown key"
+		}
+		issues = append(issues, Issue{Key: displayKey(key), Message: message})
+	}
+	return issues, nil
+}
+
+func issuesError(issues []Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].File != issues[j].File {
+			return issues[i].File < issues[j].File
+		}
+		return issues[i].Line < issues[j].Line
+	})
+	return &ValidationError{Issues: issues}
+}
+
+func toDocument(c Config) (any, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("encode config: %w", err)
+	}
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("encode config: %w", err)
+	}
+	return doc, nil
+}
+
+// displayKey turns a gojsonschema field path ("toolsets.1") into the form
+// users see in YAML ("toolsets[1]").
+func displayKey(field string) string {
+	parts := strings.Split(field, ".")
+	var b strings.Builder
+	for i, part := range parts {
+		if _, err := strconv.Atoi(part); err == nil && i > 0 {
+			b.WriteString("[" + part + "]")
+			continue
+		}
+		if i > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+func keyPath(key string) []string {
+	key = strings.ReplaceAll(key, "[", ".")
+	key = strings.ReplaceAll(key, "]", "")
+	return strings.Split(key, ".")
+}
+
+// lineOf returns the line of the value at path, or 0 when the path is not
+// present. Mapping keys may themselves contain dots (timeouts.per_tool keys
+// are tool names like "helm.install"), so the longest matching key wins.
+func lineOf(node *yaml.Node, path []string) int {
+	if node == nil {
+		return 0
+	}
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return 0
+		}
+		return lineOf(node.Content[0], path)
+	}
+	if len(path) == 0 {
+		return node.Line
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for n := len(path); n >= 1; n-- {
+			want := strings.Join(path[:n], ".")
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value != want {
+					continue
+				}
+				if n == len(path) {
+					return node.Content[i].Line
+				}
+				return lineOf(node.Content[i+1], path[n:])
+			}
+		}
+	case yaml.SequenceNode:
+		idx, err := strconv.Atoi(path[0])
+		if err != nil || idx < 0 || idx >= len(node.Content) {
+			return 0
+		}
+		return lineOf(node.Content[idx], path[1:])
+	}
+	return 0
+}
@@ -3,6 +3,9 @@ package evidence
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -21,6 +24,59 @@ type Collector interface {
 	ResourceRef(gvr schema.GroupVersionResource, namespace, name string) string
 }
 
+// BatchPodCollector is implemented by collectors that can resolve several
+// pod selectors in one namespace with a single List.
+type BatchPodCollector interface {
+	RelatedPodsForSelectors(ctx context.Context, namespace string, selectors []labels.Selector) ([][]corev1.Pod, error)
+}
+
+// RelatedPodsForSelectors resolves selectors in namespace, returning one pod
+// slice per selector in the same order. Collectors that do not implement
+// BatchPodCollector fall back to one RelatedPods call per selector.
+func RelatedPodsForSelectors(ctx context.Context, collector Collector, namespace string, selectors []labels.Selector) ([][]corev1.Pod, error) {
+	if batch, ok := collector.(BatchPodCollector); ok {
+		return batch.RelatedPodsForSelectors(ctx, namespace, selectors)
+	}
+	out := make([][]corev1.Pod, len(selectors))
+	for i, selector := range selectors {
+		pods, err := collector.RelatedPods(ctx, namespace, selector)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = pods
+	}
+	return out, nil
+}
+
+// EventCollector is implemented by collectors that can look events up by
+// involved object reference, including objects without a known UID.
+type EventCollector interface {
+	RecentEvents(ctx context.Context, namespace string, involvedObject corev1.ObjectReference) ([]corev1.Event, error)
+}
+
+// RecentEvents returns the events of involvedObject, newest first.
+// Collectors that do not implement EventCollector fall back to
+// EventsForObject, which only matches on the object's UID.
+func RecentEvents(ctx context.Context, collector Collector, namespace string, involvedObject corev1.ObjectReference) ([]corev1.Event, error) {
+	if events, ok := collector.(EventCollector); ok {
+		return events.RecentEvents(ctx, namespace, involvedObject)
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(involvedObject.APIVersion)
+	obj.SetKind(involvedObject.Kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(involvedObject.Name)
+	obj.SetUID(involvedObject.UID)
+	events, err := collector.EventsForObject(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventTime(events[i]).After(EventTime(events[j]))
+	})
+	return events, nil
+}
+
 type KubeCollector struct {
 	clients *kube.Clients
 }
@@ -47,6 +103,62 @@ func (c *KubeCollector) EventsForObject(ctx context.Context, obj *unstructured.U
 	return list.Items, nil
 }
 
+// RecentEvents lists the events whose involvedObject matches the given
+// reference, newest first. Name and kind narrow the list server-side; the UID,
+// when set, drops events left behind by an earlier object with the same name.
+func (c *KubeCollector) RecentEvents(ctx context.Context, namespace string, involvedObject corev1.ObjectReference) ([]corev1.Event, error) {
+	if namespace == "" || involvedObject.Name == "" {
+		return nil, nil
+	}
+	fields := []string{"involvedObject.name=" + involvedObject.Name}
+	if involvedObject.Kind != "" {
+		fields = append(fields, "involvedObject.kind="+involvedObject.Kind)
+	}
+	if involvedObject.UID != "" {
+		fields = append(fields, "involvedObject.uid="+string(involvedObject.UID))
+	}
+	list, err := c.clients.Typed.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: strings.Join(fields, ","),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var events []corev1.Event
+	for _, event := range list.Items {
+		ref := event.InvolvedObject
+		if ref.Name != involvedObject.Name {
+			continue
+		}
+		if involvedObject.Kind != "" && ref.Kind != involvedObject.Kind {
+			continue
+		}
+		if involvedObject.UID != "" && ref.UID != "" && ref.UID != involvedObject.UID {
+			continue
+		}
+		events = append(events, event)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventTime(events[i]).After(EventTime(events[j]))
+	})
+	return events, nil
+}
+
+// EventTime is when an event last fired, falling back through the fields
+// older and newer emitters populate.
+func EventTime(event corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
 func (c *KubeCollector) OwnerChain(ctx context.Context, obj *unstructured.Unstructured) ([]string, error) {
 	var chain []string
 	current := obj
@@ -94,13 +206,25 @@ func (c *KubeCollector) PodStatusSummary(pod *corev1.Pod) map[string]any {
 		if cs.State.Terminated != nil {
 			state["terminated"] = cs.State.Terminated.Reason
 		}
+		if cs.RestartCount > 0 {
+			state["restartCount"] = cs.RestartCount
+		}
+		if last := cs.LastTerminationState.Terminated; last != nil {
+			state["lastTerminated"] = map[string]any{"reason": last.Reason, "exitCode": last.ExitCode}
+		}
 		containerStates = append(containerStates, state)
 	}
 	summary["containers"] = containerStates
 	return summary
 }
 
+// RelatedPods lists pods matching selector. When ctx carries a PodIndex (every
+// tool invocation does, see WithPodIndex) the namespace is listed once and
+// later selectors are matched in memory.
 func (c *KubeCollector) RelatedPods(ctx context.Context, namespace string, selector labels.Selector) ([]corev1.Pod, error) {
+	if idx, ok := PodIndexFromContext(ctx); ok {
+		return idx.Pods(ctx, c.clients.Typed, namespace, selector)
+	}
 	list, err := c.clients.Typed.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
 	if err != nil {
 		return nil, err
@@ -108,6 +232,44 @@ func (c *KubeCollector) RelatedPods(ctx context.Context, namespace string, selec
 	return list.Items, nil
 }
 
+// RelatedPodsForSelectors lists the namespace once and matches every
+// selector in memory, instead of one List per selector. With a PodIndex in
+// ctx the index's cached list is used.
+func (c *KubeCollector) RelatedPodsForSelectors(ctx context.Context, namespace string, selectors []labels.Selector) ([][]corev1.Pod, error) {
+	out := make([][]corev1.Pod, len(selectors))
+	if idx, ok := PodIndexFromContext(ctx); ok {
+		for i, selector := range selectors {
+			pods, err := idx.Pods(ctx, c.clients.Typed, namespace, selector)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = pods
+		}
+		return out, nil
+	}
+	switch len(selectors) {
+	case 0:
+		return out, nil
+	case 1:
+		// A single selector is cheaper to filter server-side.
+		pods, err := c.RelatedPods(ctx, namespace, selectors[0])
+		if err != nil {
+			return nil, err
+		}
+		out[0] = pods
+		return out, nil
+	}
+	list, err := c.clients.Typed.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	entry := newNamespacePods(list.Items)
+	for i, selector := range selectors {
+		out[i] = entry.matching(selector)
+	}
+	return out, nil
+}
+
 func (c *KubeCollector) EndpointsForService(ctx context.Context, namespace, name string) (*corev1.Endpoints, error) {
 	endpoints, err := c.clients.Typed.CoreV1().Endpoints(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -183,6 +184,22 @@ func TestPodStatusSummary(t *testing.T) {
 	}
 }
 
+func TestPodStatusSummaryRestarts(t *testing.T) {
+	pod := &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+		Name:                 "app",
+		RestartCount:         3,
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+	}}}}
+	containers := NewCollector(nil).PodStatusSummary(pod)["containers"].([]map[string]any)
+	if containers[0]["restartCount"] != int32(3) {
+		t.Fatalf("expected restart count, got %#v", containers[0])
+	}
+	last, _ := containers[0]["lastTerminated"].(map[string]any)
+	if last["reason"] != "OOMKilled" || last["exitCode"] != int32(137) {
+		t.Fatalf("expected last termination, got %#v", containers[0])
+	}
+}
+
 func TestPodStatusSummaryNil(t *testing.T) {
 	collector := NewCollector(nil)
 	summary := collector.PodStatusSummary(nil)
@@ -269,3 +286,226 @@ func TestResourceRefAndStatusFromUnstructured(t *testing.T) {
 		t.Fatalf("expected raw status")
 	}
 }
+
+func TestPodIndexListsOncePerNamespace(t *testing.T) {
+	var objects []runtime.Object
+	for i := 0; i < 40; i++ {
+		objects = append(objects, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("pod-%d", i),
+			Namespace: "busy",
+			Labels:    map[string]string{"app": fmt.Sprintf("svc-%d", i), "tier": "web"},
+		}})
+	}
+	client := fake.NewSimpleClientset(objects...)
+	collector := NewCollector(&kube.Clients{Typed: client})
+	ctx := WithPodIndex(context.Background())
+	for i := 0; i < 40; i++ {
+		pods, err := collector.RelatedPods(ctx, "busy", labels.SelectorFromSet(labels.Set{"app": fmt.Sprintf("svc-%d", i)}))
+		if err != nil {
+			t.Fatalf("related pods: %v", err)
+		}
+		if len(pods) != 1 || pods[0].Name != fmt.Sprintf("pod-%d", i) {
+			t.Fatalf("unexpected pods for svc-%d: %d", i, len(pods))
+		}
+	}
+	idx, _ := PodIndexFromContext(ctx)
+	if idx.Lists() != 1 {
+		t.Fatalf("expected a single pod list, got %d", idx.Lists())
+	}
+	lists := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "list" && action.GetResource().Resource == "pods" {
+			lists++
+		}
+	}
+	if lists != 1 {
+		t.Fatalf("expected 1 list call against the API, got %d", lists)
+	}
+
+	// Without an index every selector is its own List.
+	client.ClearActions()
+	for i := 0; i < 3; i++ {
+		if _, err := collector.RelatedPods(context.Background(), "busy", labels.SelectorFromSet(labels.Set{"tier": "web"})); err != nil {
+			t.Fatalf("related pods: %v", err)
+		}
+	}
+	if got := len(client.Actions()); got != 3 {
+		t.Fatalf("expected 3 list calls without index, got %d", got)
+	}
+}
+
+func TestPodIndexSelectorsAndAllNamespaces(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns1", Labels: map[string]string{"app": "x", "track": "canary"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns1", Labels: map[string]string{"app": "y"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "ns2", Labels: map[string]string{"app": "x"}}},
+	)
+	collector := NewCollector(&kube.Clients{Typed: client})
+	ctx := WithPodIndex(context.Background())
+	all, err := collector.RelatedPods(ctx, "", labels.SelectorFromSet(labels.Set{"app": "x"}))
+	if err != nil || len(all) != 2 {
+		t.Fatalf("expected 2 pods across namespaces, got %d (%v)", len(all), err)
+	}
+	selector, err := labels.Parse("app in (x,y),track!=canary")
+	if err != nil {
+		t.Fatalf("parse selector: %v", err)
+	}
+	pods, err := collector.RelatedPods(ctx, "ns1", selector)
+	if err != nil || len(pods) != 1 || pods[0].Name != "b" {
+		t.Fatalf("unexpected set-based match: %#v (%v)", pods, err)
+	}
+	exists, _ := labels.Parse("track")
+	pods, _ = collector.RelatedPods(ctx, "ns1", exists)
+	if len(pods) != 1 || pods[0].Name != "a" {
+		t.Fatalf("unexpected exists match: %#v", pods)
+	}
+	idx, _ := PodIndexFromContext(ctx)
+	if idx.Lists() != 1 {
+		t.Fatalf("expected namespace view to reuse the all-namespaces list, got %d lists", idx.Lists())
+	}
+	if WithPodIndex(ctx) != ctx {
+		t.Fatalf("expected WithPodIndex to reuse the existing index")
+	}
+}
+
+func TestRelatedPodsForSelectorsSingleList(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns1", Labels: map[string]string{"app": "x"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns1", Labels: map[string]string{"app": "y", "tier": "web"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "ns2", Labels: map[string]string{"app": "x"}}},
+	)
+	collector := NewCollector(&kube.Clients{Typed: client})
+	tier, _ := labels.Parse("tier")
+	selectors := []labels.Selector{
+		labels.SelectorFromSet(labels.Set{"app": "x"}),
+		tier,
+		labels.SelectorFromSet(labels.Set{"app": "z"}),
+	}
+	got, err := RelatedPodsForSelectors(context.Background(), collector, "ns1", selectors)
+	if err != nil {
+		t.Fatalf("related pods for selectors: %v", err)
+	}
+	if len(got) != 3 || len(got[0]) != 1 || got[0][0].Name != "a" || len(got[1]) != 1 || got[1][0].Name != "b" || len(got[2]) != 0 {
+		t.Fatalf("unexpected batched pods: %#v", got)
+	}
+	if lists := len(client.Actions()); lists != 1 {
+		t.Fatalf("expected a single list for three selectors, got %d", lists)
+	}
+
+	// Collectors without the batch method fall back to RelatedPods.
+	fallback := struct{ Collector }{collector}
+	client.ClearActions()
+	if got, err := RelatedPodsForSelectors(context.Background(), fallback, "ns1", selectors); err != nil || len(got[0]) != 1 {
+		t.Fatalf("fallback: %#v (%v)", got, err)
+	}
+	if lists := len(client.Actions()); lists != 3 {
+		t.Fatalf("expected one list per selector in fallback, got %d", lists)
+	}
+
+	// With a PodIndex the batch shares the index's list.
+	client.ClearActions()
+	ctx := WithPodIndex(context.Background())
+	if _, err := collector.RelatedPodsForSelectors(ctx, "ns1", selectors); err != nil {
+		t.Fatalf("indexed batch: %v", err)
+	}
+	if _, err := collector.RelatedPods(ctx, "ns1", selectors[0]); err != nil {
+		t.Fatalf("indexed single: %v", err)
+	}
+	if lists := len(client.Actions()); lists != 1 {
+		t.Fatalf("expected indexed batch to list once, got %d", lists)
+	}
+}
+
+func TestRecentEventsMatchesObjectNewestFirst(t *testing.T) {
+	now := time.Now()
+	event := func(name, kind, objName, uid string, at time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: kind, Name: objName, UID: types.UID(uid)},
+			LastTimestamp:  metav1.NewTime(at),
+			Type:           corev1.EventTypeWarning,
+		}
+	}
+	client := fake.NewSimpleClientset(
+		event("old", "Pod", "api", "u1", now.Add(-time.Hour)),
+		event("new", "Pod", "api", "u1", now),
+		event("previous-pod", "Pod", "api", "u0", now),
+		event("other-kind", "Service", "api", "", now),
+		event("other-pod", "Pod", "web", "u2", now),
+	)
+	collector := NewCollector(&kube.Clients{Typed: client})
+	events, err := collector.RecentEvents(context.Background(), "default", corev1.ObjectReference{Kind: "Pod", Name: "api", UID: types.UID("u1")})
+	if err != nil {
+		t.Fatalf("recent events: %v", err)
+	}
+	if len(events) != 2 || events[0].Name != "new" || events[1].Name != "old" {
+		t.Fatalf("unexpected events: %#v", events)
+	}
+	if events, _ := collector.RecentEvents(context.Background(), "default", corev1.ObjectReference{}); events != nil {
+		t.Fatalf("expected nil events without a name")
+	}
+
+	// Collectors without RecentEvents fall back to EventsForObject.
+	client = fake.NewSimpleClientset(
+		event("old", "Pod", "api", "u1", now.Add(-time.Hour)),
+		event("new", "Pod", "api", "u1", now),
+	)
+	fallback := struct{ Collector }{NewCollector(&kube.Clients{Typed: client})}
+	events, err = RecentEvents(context.Background(), fallback, "default", corev1.ObjectReference{Kind: "Pod", Name: "api", UID: types.UID("u1")})
+	if err != nil {
+		t.Fatalf("fallback recent events: %v", err)
+	}
+	if len(events) != 2 || events[0].Name != "new" {
+		t.Fatalf("expected fallback events newest first: %#v", events)
+	}
+}
+
+func TestPrinterColumnsFromCRD(t *testing.T) {
+	crd := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": "nodepools.karpenter.sh"},
+		"spec": map[string]any{
+			"group": "karpenter.sh",
+			"versions": []any{
+				map[string]any{"name": "v1beta1"},
+				map[string]any{"name": "v1", "additionalPrinterColumns": []any{
+					map[string]any{"name": "NodeClass", "type": "string", "jsonPath": ".spec.template.spec.nodeClassRef.name"},
+					map[string]any{"name": "Ready", "type": "string", "jsonPath": `.status.conditions[?(@.type=="Ready")].status`},
+					map[string]any{"name": "Age", "type": "date", "jsonPath": ".metadata.creationTimestamp"},
+					map[string]any{"name": "Weight", "type": "integer", "jsonPath": ".spec.weight", "priority": int64(1)},
+					map[string]any{"name": "Broken"},
+				}},
+			},
+		},
+	}}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), crd)
+	collector := NewCollector(&kube.Clients{Dynamic: dynamicClient})
+
+	gvr := schema.GroupVersionResource{Group: "karpenter.sh", Version: "v1", Resource: "nodepools"}
+	columns, err := collector.PrinterColumns(context.Background(), gvr)
+	if err != nil {
+		t.Fatalf("PrinterColumns: %v", err)
+	}
+	if len(columns) != 4 || columns[3].Priority != 1 {
+		t.Fatalf("unexpected columns: %#v", columns)
+	}
+	if columns, err := collector.PrinterColumns(context.Background(), schema.GroupVersionResource{Group: "karpenter.sh", Version: "v1beta1", Resource: "nodepools"}); err != nil || len(columns) != 0 {
+		t.Fatalf("expected no columns for v1beta1, got %#v (%v)", columns, err)
+	}
+	if columns, err := collector.PrinterColumns(context.Background(), schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}); err != nil || columns != nil {
+		t.Fatalf("expected built-in resources to be skipped, got %#v (%v)", columns, err)
+	}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "default", "creationTimestamp": now.Add(-3 * time.Hour).Format(time.RFC3339)},
+		"spec":     map[string]any{"template": map[string]any{"spec": map[string]any{"nodeClassRef": map[string]any{"name": "default"}}}},
+		"status":   map[string]any{"conditions": []any{map[string]any{"type": "Ready", "status": "False"}}},
+	}}
+	values := PrinterColumnValues(columns, obj, now)
+	want := []PrinterColumnValue{{"NodeClass", "default"}, {"Ready", "False"}, {"Age", "3h"}, {"Weight", ""}}
+	if fmt.Sprint(values) != fmt.Sprint(want) {
+		t.Fatalf("expected %v, got %v", want, values)
+	}
+}
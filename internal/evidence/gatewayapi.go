@@ -0,0 +1,125 @@
+package evidence
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// GatewayAPIGroup is the API group of Gateways and their routes.
+const GatewayAPIGroup = "gateway.networking.k8s.io"
+
+// gatewayNegativeConditions are the Gateway API condition types that signal
+// a problem when True; every other type is healthy when True.
+var gatewayNegativeConditions = map[string]bool{
+	"Conflicted":           true,
+	"OverlappingTLSConfig": true,
+}
+
+// GatewayConditionReport is the pass/fail reading of one status scope: the
+// Gateway itself, one of its listeners, or one parent of a route.
+type GatewayConditionReport struct {
+	Scope      string            `json:"scope"`
+	OK         bool              `json:"ok"`
+	Conditions map[string]string `json:"conditions"`
+	Summary    string            `json:"summary"`
+	// Failures holds "Type=Status: message" for each unhealthy condition.
+	Failures []string `json:"failures,omitempty"`
+}
+
+// GatewayAPIConditions reads the conditions of a Gateway (its own and each
+// listener's) or of a route (each status.parents entry) into one report per
+// scope, e.g. "listener https is Accepted=True, Programmed=True". Objects of
+// other kinds, or without status, return nil.
+func GatewayAPIConditions(obj *unstructured.Unstructured) []GatewayConditionReport {
+	if obj == nil {
+		return nil
+	}
+	var reports []GatewayConditionReport
+	if obj.GetKind() == "Gateway" {
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		if len(conditions) > 0 {
+			reports = append(reports, gatewayConditionReport(fmt.Sprintf("gateway %s", obj.GetName()), conditions))
+		}
+		listeners, _, _ := unstructured.NestedSlice(obj.Object, "status", "listeners")
+		for _, raw := range listeners {
+			listener, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _ := listener["name"].(string)
+			conditions, _, _ := unstructured.NestedSlice(listener, "conditions")
+			reports = append(reports, gatewayConditionReport(fmt.Sprintf("listener %s", name), conditions))
+		}
+		return reports
+	}
+	parents, _, _ := unstructured.NestedSlice(obj.Object, "status", "parents")
+	for _, raw := range parents {
+		parent, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		scope := fmt.Sprintf("%s %s on parent %s", strings.ToLower(obj.GetKind()), obj.GetName(), gatewayParentRef(parent, obj.GetNamespace()))
+		conditions, _, _ := unstructured.NestedSlice(parent, "conditions")
+		reports = append(reports, gatewayConditionReport(scope, conditions))
+	}
+	return reports
+}
+
+func gatewayParentRef(parent map[string]any, defaultNamespace string) string {
+	ref, _ := parent["parentRef"].(map[string]any)
+	name, _ := ref["name"].(string)
+	namespace, _ := ref["namespace"].(string)
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	out := namespace + "/" + name
+	if section, _ := ref["sectionName"].(string); section != "" {
+		out += "#" + section
+	}
+	return out
+}
+
+func gatewayConditionReport(scope string, conditions []any) GatewayConditionReport {
+	report := GatewayConditionReport{Scope: scope, OK: true, Conditions: map[string]string{}}
+	var parts []string
+	for _, raw := range conditions {
+		condition, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		condType, _ := condition["type"].(string)
+		status, _ := condition["status"].(string)
+		if condType == "" {
+			continue
+		}
+		report.Conditions[condType] = status
+		parts = append(parts, condType+"="+status)
+		healthy := status == "True"
+		if gatewayNegativeConditions[condType] {
+			healthy = status == "False"
+		}
+		if healthy {
+			continue
+		}
+		report.OK = false
+		failure := condType + "=" + status
+		if message, _ := condition["message"].(string); message != "" {
+			failure += ": " + message
+		} else if reason, _ := condition["reason"].(string); reason != "" {
+			failure += ": " + reason
+		}
+		report.Failures = append(report.Failures, failure)
+	}
+	switch {
+	case len(parts) == 0:
+		report.OK = false
+		report.Summary = scope + " has no conditions yet; no controller has reconciled it"
+	case report.OK:
+		report.Summary = scope + " is " + strings.Join(parts, ", ")
+	default:
+		report.Summary = scope + " has " + strings.Join(report.Failures, "; ")
+	}
+	return report
+}
@@ -0,0 +1,72 @@
+package evidence
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGatewayAPIConditionsGateway(t *testing.T) {
+	gateway := &unstructured.Unstructured{Object: map[string]any{
+		"kind":     "Gateway",
+		"metadata": map[string]any{"name": "edge", "namespace": "infra"},
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Accepted", "status": "True"},
+				map[string]any{"type": "Programmed", "status": "True"},
+			},
+			"listeners": []any{
+				map[string]any{"name": "https", "conditions": []any{
+					map[string]any{"type": "Accepted", "status": "True"},
+					map[string]any{"type": "Programmed", "status": "True"},
+					map[string]any{"type": "Conflicted", "status": "False"},
+				}},
+				map[string]any{"name": "tls", "conditions": []any{
+					map[string]any{"type": "ResolvedRefs", "status": "False", "reason": "InvalidCertificateRef"},
+				}},
+			},
+		},
+	}}
+	reports := GatewayAPIConditions(gateway)
+	if len(reports) != 3 {
+		t.Fatalf("expected gateway and two listener reports, got %#v", reports)
+	}
+	if !reports[0].OK || reports[0].Summary != "gateway edge is Accepted=True, Programmed=True" {
+		t.Fatalf("unexpected gateway report: %#v", reports[0])
+	}
+	if !reports[1].OK || reports[1].Conditions["Conflicted"] != "False" {
+		t.Fatalf("expected Conflicted=False to be healthy: %#v", reports[1])
+	}
+	if reports[2].OK || reports[2].Summary != "listener tls has ResolvedRefs=False: InvalidCertificateRef" {
+		t.Fatalf("unexpected tls listener report: %#v", reports[2])
+	}
+}
+
+func TestGatewayAPIConditionsRoute(t *testing.T) {
+	route := &unstructured.Unstructured{Object: map[string]any{
+		"kind":     "GRPCRoute",
+		"metadata": map[string]any{"name": "api", "namespace": "shop"},
+		"status": map[string]any{
+			"parents": []any{
+				map[string]any{
+					"parentRef":  map[string]any{"name": "edge", "namespace": "infra", "sectionName": "https"},
+					"conditions": []any{map[string]any{"type": "Accepted", "status": "False", "message": "no matching listener"}},
+				},
+				map[string]any{"parentRef": map[string]any{"name": "mesh"}},
+			},
+		},
+	}}
+	reports := GatewayAPIConditions(route)
+	if len(reports) != 2 {
+		t.Fatalf("expected one report per parent, got %#v", reports)
+	}
+	if reports[0].OK || reports[0].Summary != "grpcroute api on parent infra/edge#https has Accepted=False: no matching listener" {
+		t.Fatalf("unexpected parent report: %#v", reports[0])
+	}
+	if reports[1].OK || reports[1].Scope != "grpcroute api on parent shop/mesh" {
+		t.Fatalf("expected an unreconciled parent to fail: %#v", reports[1])
+	}
+	if GatewayAPIConditions(nil) != nil {
+		t.Fatalf("expected nil for nil object")
+	}
+}
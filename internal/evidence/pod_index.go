@@ -0,0 +1,163 @@
+package evidence
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/kubernetes"
+)
+
+type podIndexKey struct{}
+
+// PodIndex memoizes pod Lists for the lifetime of a single tool invocation.
+// Handlers that resolve many selectors in one namespace (graph expansion,
+// istio pods-by-service, mesh fan-out) otherwise issue one List per selector;
+// with an index they issue one List per namespace and match selectors in
+// memory against a label index.
+//
+// On a namespace with 40 Services, k8s.graph previously listed pods 40 times;
+// with the index it lists once (see TestPodIndexListsOncePerNamespace).
+type PodIndex struct {
+	mu         sync.Mutex
+	namespaces map[string]*namespacePods
+	lists      int
+}
+
+type namespacePods struct {
+	pods []corev1.Pod
+	// byLabel maps "key=value" to positions in pods so equality selectors
+	// only test the pods that can possibly match.
+	byLabel map[string][]int
+}
+
+// WithPodIndex returns ctx carrying a PodIndex. It is a no-op when ctx already
+// carries one, so nested tool calls share the caller's index.
+func WithPodIndex(ctx context.Context) context.Context {
+	if _, ok := PodIndexFromContext(ctx); ok {
+		return ctx
+	}
+	return WithNewPodIndex(ctx)
+}
+
+// WithNewPodIndex returns ctx carrying a fresh PodIndex, replacing any index
+// inherited from the caller. Nested calls routed to another cluster use it so
+// they never read pods listed from the caller's cluster.
+func WithNewPodIndex(ctx context.Context) context.Context {
+	return context.WithValue(ctx, podIndexKey{}, &PodIndex{namespaces: map[string]*namespacePods{}})
+}
+
+// PodIndexFromContext returns the PodIndex attached by WithPodIndex.
+func PodIndexFromContext(ctx context.Context) (*PodIndex, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	idx, ok := ctx.Value(podIndexKey{}).(*PodIndex)
+	return idx, ok && idx != nil
+}
+
+// Lists reports how many pod List calls the index has issued.
+func (p *PodIndex) Lists() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lists
+}
+
+// Pods returns the pods in namespace ("" for all namespaces) matching
+// selector, listing the namespace on first use.
+func (p *PodIndex) Pods(ctx context.Context, client kubernetes.Interface, namespace string, selector labels.Selector) ([]corev1.Pod, error) {
+	entry, err := p.load(ctx, client, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return entry.matching(selector), nil
+}
+
+func (p *PodIndex) load(ctx context.Context, client kubernetes.Interface, namespace string) (*namespacePods, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if entry, ok := p.namespaces[namespace]; ok {
+		return entry, nil
+	}
+	// An all-namespaces list already holds every namespace's pods.
+	if all, ok := p.namespaces[metav1.NamespaceAll]; ok && namespace != metav1.NamespaceAll {
+		var pods []corev1.Pod
+		for i := range all.pods {
+			if all.pods[i].Namespace == namespace {
+				pods = append(pods, all.pods[i])
+			}
+		}
+		entry := newNamespacePods(pods)
+		p.namespaces[namespace] = entry
+		return entry, nil
+	}
+	list, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	p.lists++
+	if err != nil {
+		return nil, err
+	}
+	entry := newNamespacePods(list.Items)
+	p.namespaces[namespace] = entry
+	return entry, nil
+}
+
+func newNamespacePods(pods []corev1.Pod) *namespacePods {
+	entry := &namespacePods{pods: pods, byLabel: map[string][]int{}}
+	for i := range pods {
+		for key, value := range pods[i].Labels {
+			entry.byLabel[key+"="+value] = append(entry.byLabel[key+"="+value], i)
+		}
+	}
+	return entry
+}
+
+// matching returns the pods selected by selector; nil selects every pod.
+func (n *namespacePods) matching(selector labels.Selector) []corev1.Pod {
+	if selector == nil {
+		selector = labels.Everything()
+	}
+	candidates, indexed := n.candidates(selector)
+	var out []corev1.Pod
+	if !indexed {
+		for i := range n.pods {
+			if selector.Matches(labels.Set(n.pods[i].Labels)) {
+				out = append(out, n.pods[i])
+			}
+		}
+		return out
+	}
+	for _, i := range candidates {
+		if selector.Matches(labels.Set(n.pods[i].Labels)) {
+			out = append(out, n.pods[i])
+		}
+	}
+	return out
+}
+
+// candidates narrows the pods to test using the first equality requirement
+// of the selector. indexed is false when the selector has no equality
+// requirement and every pod must be tested.
+func (n *namespacePods) candidates(selector labels.Selector) ([]int, bool) {
+	requirements, selectable := selector.Requirements()
+	if !selectable {
+		return nil, false
+	}
+	for _, req := range requirements {
+		switch req.Operator() {
+		case selection.Equals, selection.DoubleEquals, selection.In:
+		default:
+			continue
+		}
+		var out []int
+		for value := range req.Values() {
+			out = append(out, n.byLabel[req.Key()+"="+value]...)
+		}
+		sort.Ints(out)
+		return out, true
+	}
+	return nil, false
+}
@@ -0,0 +1,109 @@
+package evidence
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+var crdGVR = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// PrinterColumn is one additionalPrinterColumns entry of a CRD version.
+type PrinterColumn struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	JSONPath string `json:"jsonPath"`
+	Priority int64  `json:"priority,omitempty"`
+}
+
+// PrinterColumnValue is a printer column evaluated against one object, as
+// kubectl get would print it.
+type PrinterColumnValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// PrinterColumnSource is implemented by collectors that can look up the
+// printer columns of a custom resource. It is separate from Collector so
+// existing Collector implementations keep working.
+type PrinterColumnSource interface {
+	PrinterColumns(ctx context.Context, gvr schema.GroupVersionResource) ([]PrinterColumn, error)
+}
+
+// PrinterColumns reads the additionalPrinterColumns served for gvr from its
+// CustomResourceDefinition. Discovery does not carry printer columns, so the
+// CRD itself is fetched. Built-in resources have no CRD and return nil.
+func (c *KubeCollector) PrinterColumns(ctx context.Context, gvr schema.GroupVersionResource) ([]PrinterColumn, error) {
+	if c.clients == nil || c.clients.Dynamic == nil || !strings.Contains(gvr.Group, ".") {
+		return nil, nil
+	}
+	crd, err := c.clients.Dynamic.Resource(crdGVR).Get(ctx, gvr.Resource+"."+gvr.Group, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return crdPrinterColumns(crd, gvr.Version), nil
+}
+
+func crdPrinterColumns(crd *unstructured.Unstructured, version string) []PrinterColumn {
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, raw := range versions {
+		entry, ok := raw.(map[string]any)
+		if !ok || entry["name"] != version {
+			continue
+		}
+		columns, _, _ := unstructured.NestedSlice(entry, "additionalPrinterColumns")
+		var out []PrinterColumn
+		for _, rawColumn := range columns {
+			column, ok := rawColumn.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(column, "name")
+			path, _, _ := unstructured.NestedString(column, "jsonPath")
+			if name == "" || path == "" {
+				continue
+			}
+			kind, _, _ := unstructured.NestedString(column, "type")
+			priority, _, _ := unstructured.NestedInt64(column, "priority")
+			out = append(out, PrinterColumn{Name: name, Type: kind, JSONPath: path, Priority: priority})
+		}
+		return out
+	}
+	return nil
+}
+
+// PrinterColumnValues evaluates columns against obj. Missing fields render
+// empty and date columns render as an age, matching kubectl get.
+func PrinterColumnValues(columns []PrinterColumn, obj *unstructured.Unstructured, now time.Time) []PrinterColumnValue {
+	out := make([]PrinterColumnValue, 0, len(columns))
+	for _, column := range columns {
+		value := evaluateJSONPath(column.JSONPath, obj.Object)
+		if column.Type == "date" && value != "" {
+			if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+				value = duration.HumanDuration(now.Sub(parsed))
+			}
+		}
+		out = append(out, PrinterColumnValue{Name: column.Name, Value: value})
+	}
+	return out
+}
+
+func evaluateJSONPath(path string, data map[string]any) string {
+	parser := jsonpath.New("column").AllowMissingKeys(true)
+	if err := parser.Parse(fmt.Sprintf("{%s}", path)); err != nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := parser.Execute(&buf, data); err != nil {
+		return ""
+	}
+	return buf.String()
+}
@@ -0,0 +1,401 @@
+// Package istioauthz parses Istio AuthorizationPolicies and evaluates them
+// against a simulated request the way the sidecar does: CUSTOM and DENY
+// first, then ALLOW, with allow-by-default when no ALLOW policy applies.
+package istioauthz
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	ActionAllow  = "ALLOW"
+	ActionDeny   = "DENY"
+	ActionAudit  = "AUDIT"
+	ActionCustom = "CUSTOM"
+)
+
+// Policy is the subset of an AuthorizationPolicy needed to evaluate it.
+type Policy struct {
+	Name      string
+	Namespace string
+	Action    string
+	Provider  string
+	Selector  map[string]string
+	Rules     []Rule
+}
+
+// Rule is one entry of spec.rules. Every populated section must match; within
+// From and To any one entry is enough.
+type Rule struct {
+	From []Source
+	To   []Operation
+	When []Condition
+}
+
+type Source struct {
+	Principals           []string
+	NotPrincipals        []string
+	Namespaces           []string
+	NotNamespaces        []string
+	RequestPrincipals    []string
+	NotRequestPrincipals []string
+	IPBlocks             []string
+	NotIPBlocks          []string
+	RemoteIPBlocks       []string
+	NotRemoteIPBlocks    []string
+}
+
+type Operation struct {
+	Hosts      []string
+	NotHosts   []string
+	Ports      []string
+	NotPorts   []string
+	Methods    []string
+	NotMethods []string
+	Paths      []string
+	NotPaths   []string
+}
+
+type Condition struct {
+	Key       string
+	Values    []string
+	NotValues []string
+}
+
+// Request is the simulated request. Empty fields are treated as unknown:
+// a rule constraining an unknown field does not match, and the evaluation
+// records a warning saying so.
+type Request struct {
+	Principal string `json:"principal,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Host      string `json:"host,omitempty"`
+	Port      int    `json:"port,omitempty"`
+	Method    string `json:"method,omitempty"`
+	Path      string `json:"path,omitempty"`
+}
+
+// Parse converts an AuthorizationPolicy object.
+func Parse(obj *unstructured.Unstructured) Policy {
+	policy := Policy{
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+		Action:    ActionAllow,
+	}
+	if action, _, _ := unstructured.NestedString(obj.Object, "spec", "action"); action != "" {
+		policy.Action = strings.ToUpper(action)
+	}
+	policy.Provider, _, _ = unstructured.NestedString(obj.Object, "spec", "provider", "name")
+	policy.Selector, _, _ = unstructured.NestedStringMap(obj.Object, "spec", "selector", "matchLabels")
+	rules, _, _ := unstructured.NestedSlice(obj.Object, "spec", "rules")
+	for _, raw := range rules {
+		ruleMap, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		var rule Rule
+		for _, from := range maps(ruleMap["from"]) {
+			source, _ := from["source"].(map[string]any)
+			rule.From = append(rule.From, Source{
+				Principals:           strs(source["principals"]),
+				NotPrincipals:        strs(source["notPrincipals"]),
+				Namespaces:           strs(source["namespaces"]),
+				NotNamespaces:        strs(source["notNamespaces"]),
+				RequestPrincipals:    strs(source["requestPrincipals"]),
+				NotRequestPrincipals: strs(source["notRequestPrincipals"]),
+				IPBlocks:             strs(source["ipBlocks"]),
+				NotIPBlocks:          strs(source["notIpBlocks"]),
+				RemoteIPBlocks:       strs(source["remoteIpBlocks"]),
+				NotRemoteIPBlocks:    strs(source["notRemoteIpBlocks"]),
+			})
+		}
+		for _, to := range maps(ruleMap["to"]) {
+			operation, _ := to["operation"].(map[string]any)
+			rule.To = append(rule.To, Operation{
+				Hosts:      strs(operation["hosts"]),
+				NotHosts:   strs(operation["notHosts"]),
+				Ports:      strs(operation["ports"]),
+				NotPorts:   strs(operation["notPorts"]),
+				Methods:    strs(operation["methods"]),
+				NotMethods: strs(operation["notMethods"]),
+				Paths:      strs(operation["paths"]),
+				NotPaths:   strs(operation["notPaths"]),
+			})
+		}
+		for _, when := range maps(ruleMap["when"]) {
+			rule.When = append(rule.When, Condition{
+				Key:       fmt.Sprint(when["key"]),
+				Values:    strs(when["values"]),
+				NotValues: strs(when["notValues"]),
+			})
+		}
+		policy.Rules = append(policy.Rules, rule)
+	}
+	return policy
+}
+
+// Principals lists every source principal the policy's rules reference.
+func (p Policy) Principals() []string {
+	out := []string{}
+	for _, rule := range p.Rules {
+		for _, source := range rule.From {
+			out = append(out, source.Principals...)
+		}
+	}
+	return out
+}
+
+// AppliesTo reports whether the policy selects a workload in namespace with
+// workloadLabels. Policies in rootNamespace without a selector are mesh-wide.
+func (p Policy) AppliesTo(rootNamespace, namespace string, workloadLabels map[string]string) bool {
+	if p.Namespace != namespace && p.Namespace != rootNamespace {
+		return false
+	}
+	if len(p.Selector) == 0 {
+		return true
+	}
+	return labels.SelectorFromSet(p.Selector).Matches(labels.Set(workloadLabels))
+}
+
+// PolicyResult records how one applicable policy evaluated.
+type PolicyResult struct {
+	Policy      string `json:"policy"`
+	Action      string `json:"action"`
+	Matched     bool   `json:"matched"`
+	MatchedRule int    `json:"matchedRule,omitempty"`
+}
+
+// Decision is the outcome of Evaluate.
+type Decision struct {
+	Allowed   bool           `json:"allowed"`
+	Decision  string         `json:"decision"`
+	DecidedBy string         `json:"decidedBy,omitempty"`
+	Reason    string         `json:"reason"`
+	Policies  []PolicyResult `json:"policies"`
+	Warnings  []string       `json:"warnings,omitempty"`
+}
+
+// Evaluate applies the policies (already filtered to those that apply to the
+// target workload) to req. A matching CUSTOM policy hands the decision to an
+// external authorizer, so unless a DENY also matches the result only says
+// which provider decides.
+func Evaluate(policies []Policy, req Request) Decision {
+	decision := Decision{}
+	eval := evaluator{req: req, warned: map[string]bool{}}
+	custom, deny, allow := -1, -1, -1
+	allowPolicies := 0
+	provider := ""
+	for _, policy := range policies {
+		result := PolicyResult{Policy: policy.Namespace + "/" + policy.Name, Action: policy.Action}
+		for i, rule := range policy.Rules {
+			if eval.rule(rule) {
+				result.Matched = true
+				result.MatchedRule = i + 1
+				break
+			}
+		}
+		idx := len(decision.Policies)
+		decision.Policies = append(decision.Policies, result)
+		switch policy.Action {
+		case ActionCustom:
+			if result.Matched && custom < 0 {
+				custom = idx
+				provider = policy.Provider
+			}
+		case ActionDeny:
+			if result.Matched && deny < 0 {
+				deny = idx
+			}
+		case ActionAllow:
+			allowPolicies++
+			if result.Matched && allow < 0 {
+				allow = idx
+			}
+		}
+	}
+	decision.Warnings = eval.warnings
+	switch {
+	case deny >= 0:
+		// A local DENY wins whatever the external authorizer answers.
+		decision.Decision = ActionDeny
+		decision.DecidedBy = decision.Policies[deny].Policy
+		decision.Reason = fmt.Sprintf("matched rule %d of DENY policy", decision.Policies[deny].MatchedRule)
+	case custom >= 0:
+		// The external authorizer may deny; only if it allows do the ALLOW
+		// policies get a say. Report that rather than guessing.
+		decision.Decision = ActionCustom
+		decision.DecidedBy = decision.Policies[custom].Policy
+		decision.Reason = fmt.Sprintf("delegated to external authorizer %q", provider)
+	case allowPolicies == 0:
+		decision.Allowed = true
+		decision.Decision = ActionAllow
+		decision.Reason = "no ALLOW policy applies to the workload; allowed by default"
+	case allow >= 0:
+		decision.Allowed = true
+		decision.Decision = ActionAllow
+		decision.DecidedBy = decision.Policies[allow].Policy
+		decision.Reason = fmt.Sprintf("matched rule %d of ALLOW policy", decision.Policies[allow].MatchedRule)
+	default:
+		decision.Decision = ActionDeny
+		decision.Reason = fmt.Sprintf("%d ALLOW policy(ies) apply but none matched; denied by default", allowPolicies)
+	}
+	return decision
+}
+
+type evaluator struct {
+	req      Request
+	warned   map[string]bool
+	warnings []string
+}
+
+func (e *evaluator) warn(field string) {
+	if e.warned[field] {
+		return
+	}
+	e.warned[field] = true
+	e.warnings = append(e.warnings, fmt.Sprintf("%s is not part of the simulated request; rules constraining it were treated as not matching", field))
+}
+
+func (e *evaluator) rule(rule Rule) bool {
+	if len(rule.From) > 0 && !anyOf(len(rule.From), func(i int) bool { return e.source(rule.From[i]) }) {
+		return false
+	}
+	if len(rule.To) > 0 && !anyOf(len(rule.To), func(i int) bool { return e.operation(rule.To[i]) }) {
+		return false
+	}
+	for _, condition := range rule.When {
+		if !e.condition(condition) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *evaluator) source(source Source) bool {
+	principal := normalizePrincipal(e.req.Principal)
+	return e.field("source principal", principal, mapStrings(source.Principals, normalizePrincipal), mapStrings(source.NotPrincipals, normalizePrincipal)) &&
+		e.field("source namespace", e.req.Namespace, source.Namespaces, source.NotNamespaces) &&
+		e.unsupported("requestPrincipals", source.RequestPrincipals, source.NotRequestPrincipals) &&
+		e.unsupported("ipBlocks", source.IPBlocks, source.NotIPBlocks) &&
+		e.unsupported("remoteIpBlocks", source.RemoteIPBlocks, source.NotRemoteIPBlocks)
+}
+
+func (e *evaluator) operation(operation Operation) bool {
+	port := ""
+	if e.req.Port > 0 {
+		port = strconv.Itoa(e.req.Port)
+	}
+	return e.field("host", e.req.Host, operation.Hosts, operation.NotHosts) &&
+		e.field("port", port, operation.Ports, operation.NotPorts) &&
+		e.field("method", e.req.Method, operation.Methods, operation.NotMethods) &&
+		e.field("path", e.req.Path, operation.Paths, operation.NotPaths)
+}
+
+func (e *evaluator) condition(condition Condition) bool {
+	var value string
+	switch condition.Key {
+	case "source.principal":
+		value = normalizePrincipal(e.req.Principal)
+	case "source.namespace":
+		value = e.req.Namespace
+	case "destination.port":
+		if e.req.Port > 0 {
+			value = strconv.Itoa(e.req.Port)
+		}
+	case "request.headers[:method]":
+		value = e.req.Method
+	case "request.headers[:path]":
+		value = e.req.Path
+	default:
+		e.warn("condition " + condition.Key)
+		return false
+	}
+	return e.field("condition "+condition.Key, value, condition.Values, condition.NotValues)
+}
+
+// field matches value against Istio's values/notValues pair: value must
+// match one of values (when set) and none of notValues.
+func (e *evaluator) field(name, value string, values, notValues []string) bool {
+	if len(values) == 0 && len(notValues) == 0 {
+		return true
+	}
+	if value == "" {
+		e.warn(name)
+		return false
+	}
+	if len(values) > 0 && !anyOf(len(values), func(i int) bool { return MatchValue(values[i], value) }) {
+		return false
+	}
+	return !anyOf(len(notValues), func(i int) bool { return MatchValue(notValues[i], value) })
+}
+
+func (e *evaluator) unsupported(name string, values, notValues []string) bool {
+	if len(values) == 0 && len(notValues) == 0 {
+		return true
+	}
+	e.warn(name)
+	return false
+}
+
+// MatchValue implements Istio's string match: exact, "prefix*", "*suffix",
+// or "*" for any non-empty value.
+func MatchValue(pattern, value string) bool {
+	switch {
+	case pattern == "*":
+		return value != ""
+	case strings.HasSuffix(pattern, "*"):
+		return strings.HasPrefix(value, strings.TrimSuffix(pattern, "*"))
+	case strings.HasPrefix(pattern, "*"):
+		return strings.HasSuffix(value, strings.TrimPrefix(pattern, "*"))
+	}
+	return pattern == value
+}
+
+// normalizePrincipal strips the spiffe:// scheme so "spiffe://cluster.local/..."
+// and "cluster.local/..." compare equal.
+func normalizePrincipal(principal string) string {
+	return strings.TrimPrefix(strings.TrimSpace(principal), "spiffe://")
+}
+
+func anyOf(n int, match func(int) bool) bool {
+	for i := 0; i < n; i++ {
+		if match(i) {
+			return true
+		}
+	}
+	return false
+}
+
+func mapStrings(values []string, fn func(string) string) []string {
+	out := make([]string, len(values))
+	for i, value := range values {
+		out[i] = fn(value)
+	}
+	return out
+}
+
+func maps(value any) []map[string]any {
+	items, _ := value.([]any)
+	var out []map[string]any
+	for _, item := range items {
+		if m, ok := item.(map[string]any); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func strs(value any) []string {
+	items, _ := value.([]any)
+	var out []string
+	for _, item := range items {
+		if s, ok := item.(string); ok && s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,132 @@
+package istioauthz
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func policyObject(namespace, name string, spec map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "security.istio.io/v1",
+		"kind":       "AuthorizationPolicy",
+		"metadata":   map[string]any{"name": name, "namespace": namespace},
+		"spec":       spec,
+	}}
+}
+
+func TestParse(t *testing.T) {
+	policy := Parse(policyObject("shop", "cart", map[string]any{
+		"action":   "deny",
+		"selector": map[string]any{"matchLabels": map[string]any{"app": "cart"}},
+		"rules": []any{map[string]any{
+			"from": []any{map[string]any{"source": map[string]any{"principals": []any{"cluster.local/ns/web/sa/frontend"}}}},
+			"to":   []any{map[string]any{"operation": map[string]any{"methods": []any{"POST"}, "paths": []any{"/admin*"}}}},
+			"when": []any{map[string]any{"key": "request.headers[:method]", "values": []any{"POST"}}},
+		}},
+	}))
+	if policy.Action != ActionDeny || policy.Selector["app"] != "cart" || len(policy.Rules) != 1 {
+		t.Fatalf("unexpected policy: %#v", policy)
+	}
+	rule := policy.Rules[0]
+	if len(rule.From) != 1 || len(rule.To) != 1 || rule.To[0].Paths[0] != "/admin*" || len(rule.When) != 1 {
+		t.Fatalf("unexpected rule: %#v", rule)
+	}
+	if principals := policy.Principals(); len(principals) != 1 || principals[0] != "cluster.local/ns/web/sa/frontend" {
+		t.Fatalf("unexpected principals: %v", principals)
+	}
+	if Parse(policyObject("shop", "default", map[string]any{})).Action != ActionAllow {
+		t.Fatalf("expected ALLOW default action")
+	}
+}
+
+func TestAppliesTo(t *testing.T) {
+	scoped := Policy{Namespace: "shop", Selector: map[string]string{"app": "cart"}}
+	if !scoped.AppliesTo("istio-system", "shop", map[string]string{"app": "cart", "v": "1"}) {
+		t.Fatalf("expected selector match")
+	}
+	if scoped.AppliesTo("istio-system", "shop", map[string]string{"app": "web"}) || scoped.AppliesTo("istio-system", "other", map[string]string{"app": "cart"}) {
+		t.Fatalf("expected no match for other workload or namespace")
+	}
+	if !(Policy{Namespace: "istio-system"}).AppliesTo("istio-system", "shop", nil) {
+		t.Fatalf("expected root namespace policy to apply mesh-wide")
+	}
+}
+
+func TestEvaluatePrecedence(t *testing.T) {
+	allowFrontend := Parse(policyObject("shop", "allow-frontend", map[string]any{
+		"rules": []any{map[string]any{
+			"from": []any{map[string]any{"source": map[string]any{"principals": []any{"cluster.local/ns/web/sa/frontend"}}}},
+			"to":   []any{map[string]any{"operation": map[string]any{"methods": []any{"GET", "POST"}}}},
+		}},
+	}))
+	denyAdmin := Parse(policyObject("shop", "deny-admin", map[string]any{
+		"action": "DENY",
+		"rules": []any{map[string]any{
+			"to": []any{map[string]any{"operation": map[string]any{"paths": []any{"/admin*"}}}},
+		}},
+	}))
+	denyAll := Parse(policyObject("shop", "allow-nothing", map[string]any{}))
+	custom := Parse(policyObject("shop", "ext", map[string]any{
+		"action":   "CUSTOM",
+		"provider": map[string]any{"name": "opa"},
+		"rules":    []any{map[string]any{"to": []any{map[string]any{"operation": map[string]any{"paths": []any{"/api/*"}}}}}},
+	}))
+	frontend := Request{Principal: "spiffe://cluster.local/ns/web/sa/frontend", Namespace: "web", Method: "GET", Path: "/cart"}
+
+	cases := []struct {
+		name      string
+		policies  []Policy
+		req       Request
+		allowed   bool
+		decision  string
+		decidedBy string
+	}{
+		{"no policies", nil, frontend, true, ActionAllow, ""},
+		{"allow matches", []Policy{allowFrontend}, frontend, true, ActionAllow, "shop/allow-frontend"},
+		{"allow misses", []Policy{allowFrontend}, Request{Principal: "cluster.local/ns/web/sa/batch", Method: "GET", Path: "/cart"}, false, ActionDeny, ""},
+		{"deny overrides allow", []Policy{allowFrontend, denyAdmin}, Request{Principal: frontend.Principal, Method: "GET", Path: "/admin/users"}, false, ActionDeny, "shop/deny-admin"},
+		{"allow-nothing", []Policy{denyAll}, frontend, false, ActionDeny, ""},
+		{"custom delegates", []Policy{allowFrontend, custom}, Request{Principal: frontend.Principal, Method: "GET", Path: "/api/v1"}, false, ActionCustom, "shop/ext"},
+		{"deny beats custom", []Policy{custom, denyAdmin}, Request{Method: "GET", Path: "/admin/api/x"}, false, ActionDeny, "shop/deny-admin"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Evaluate(tc.policies, tc.req)
+			if got.Allowed != tc.allowed || got.Decision != tc.decision || got.DecidedBy != tc.decidedBy {
+				t.Fatalf("got %+v", got)
+			}
+		})
+	}
+}
+
+func TestEvaluateWarnsOnUnsimulatedFields(t *testing.T) {
+	policy := Parse(policyObject("shop", "jwt", map[string]any{
+		"rules": []any{map[string]any{
+			"from": []any{map[string]any{"source": map[string]any{"requestPrincipals": []any{"*"}}}},
+		}},
+	}))
+	got := Evaluate([]Policy{policy}, Request{Method: "GET"})
+	if got.Allowed || len(got.Warnings) != 1 || !strings.Contains(got.Warnings[0], "requestPrincipals") {
+		t.Fatalf("expected warning about requestPrincipals, got %+v", got)
+	}
+}
+
+func TestMatchValue(t *testing.T) {
+	cases := []struct {
+		pattern, value string
+		want           bool
+	}{
+		{"*", "x", true},
+		{"*", "", false},
+		{"/api/*", "/api/v1", true},
+		{"*.example.com", "a.example.com", true},
+		{"GET", "POST", false},
+	}
+	for _, tc := range cases {
+		if got := MatchValue(tc.pattern, tc.value); got != tc.want {
+			t.Fatalf("MatchValue(%q, %q) = %v", tc.pattern, tc.value, got)
+		}
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
+
+	"rootcause/internal/logging"
 )
 
 type Clients struct {
@@ -70,6 +72,7 @@ func NewClients(cfg Config) (*Clients, error) {
 	if err != nil {
 		return nil, err
 	}
+	logging.WrapKubeConfig(restConfig)
 
 	typed, err := newTypedClient(restConfig)
 	if err != nil {
@@ -103,6 +106,25 @@ func NewClients(cfg Config) (*Clients, error) {
 	return clients, nil
 }
 
+// Contexts returns the kubeconfig's current context and every context name,
+// sorted, using the same loading rules as NewClients.
+func Contexts(kubeconfig string) (string, []string, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if explicit := kubeconfigPath(kubeconfig); explicit != "" {
+		loadingRules.ExplicitPath = explicit
+	}
+	raw, err := loadingRules.Load()
+	if err != nil {
+		return "", nil, err
+	}
+	names := make([]string, 0, len(raw.Contexts))
+	for name := range raw.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return raw.CurrentContext, names, nil
+}
+
 func (c *Clients) RefreshDiscovery(ttl time.Duration) {
 	if c == nil || ttl <= 0 {
 		return
@@ -117,6 +139,22 @@ func (c *Clients) RefreshDiscovery(ttl time.Duration) {
 	if last > 0 && time.Since(time.Unix(0, last)) < ttl {
 		return
 	}
+	c.resetDiscoveryLocked()
+}
+
+// InvalidateDiscovery drops cached discovery data and resets the RESTMapper
+// immediately, regardless of the refresh TTL, so CRDs installed mid-session
+// become resolvable.
+func (c *Clients) InvalidateDiscovery() {
+	if c == nil {
+		return
+	}
+	c.discoveryMu.Lock()
+	defer c.discoveryMu.Unlock()
+	c.resetDiscoveryLocked()
+}
+
+func (c *Clients) resetDiscoveryLocked() {
 	if c.Discovery != nil {
 		c.Discovery.Invalidate()
 	}
@@ -207,8 +245,8 @@ func ResolveResourceBestEffort(mapper meta.RESTMapper, discoveryClient discovery
 		return schema.GroupVersionResource{}, false, errors.New("missing discovery client")
 	}
 
-	lists, err := discoveryClient.ServerPreferredResources()
-	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+	lists, _, err := PreferredResources(discoveryClient)
+	if err != nil {
 		return schema.GroupVersionResource{}, false, err
 	}
 
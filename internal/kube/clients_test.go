@@ -374,6 +374,26 @@ func TestRefreshDiscoveryNoop(t *testing.T) {
 	}
 }
 
+func TestInvalidateDiscoveryIgnoresTTL(t *testing.T) {
+	fake := &fakeDiscovery{}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(fake)
+	clients := &Clients{
+		Discovery:          fake,
+		deferredRESTMapper: mapper,
+	}
+	start := time.Now().Add(-time.Second).UnixNano()
+	clients.discoveryResetNs.Store(start)
+	clients.InvalidateDiscovery()
+	if !fake.invalidated {
+		t.Fatalf("expected discovery invalidate to be called within the ttl")
+	}
+	if clients.discoveryResetNs.Load() <= start {
+		t.Fatalf("expected reset timestamp to advance")
+	}
+	var nilClients *Clients
+	nilClients.InvalidateDiscovery()
+}
+
 func TestNewClientsFromKubeconfig(t *testing.T) {
 	dir := t.TempDir()
 	kubeconfigPath := filepath.Join(dir, "kubeconfig")
@@ -440,6 +460,43 @@ current-context: test
 	}
 }
 
+func TestContexts(t *testing.T) {
+	dir := t.TempDir()
+	kubeconfigPath := filepath.Join(dir, "kubeconfig")
+	kubeconfig := `
+apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://example.com
+users:
+- name: test
+  user:
+    token: fake
+contexts:
+- name: prod
+  context:
+    cluster: test
+    user: test
+- name: dev
+  context:
+    cluster: test
+    user: test
+current-context: dev
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(kubeconfig), 0600); err != nil {
+		t.Fatalf("write kubeconfig: %v", err)
+	}
+	current, names, err := Contexts(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("contexts: %v", err)
+	}
+	if current != "dev" || len(names) != 2 || names[0] != "dev" || names[1] != "prod" {
+		t.Fatalf("unexpected contexts: current=%q names=%v", current, names)
+	}
+}
+
 func TestNewClientsInvalidConfig(t *testing.T) {
 	dir := t.TempDir()
 	kubeconfigPath := filepath.Join(dir, "kubeconfig")
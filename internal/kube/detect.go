@@ -9,11 +9,10 @@ import (
 	"k8s.io/client-go/discovery"
 )
 
+// GroupsPresent reports which of groupNames the API server serves. Partial
+// discovery failures are tolerated: the groups that resolved are still checked.
 func GroupsPresent(discoveryClient discovery.DiscoveryInterface, groupNames []string) (bool, []string, error) {
-	if discoveryClient == nil {
-		return false, nil, errors.New("missing discovery client")
-	}
-	groups, err := discoveryClient.ServerGroups()
+	groups, _, err := ServerGroups(discoveryClient)
 	if err != nil {
 		return false, nil, err
 	}
@@ -0,0 +1,69 @@
+package kube
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+)
+
+// IsPartialDiscoveryError reports whether err only means some API groups
+// failed discovery (typically a broken aggregated API such as a down
+// metrics-server) while the rest of the groups resolved.
+func IsPartialDiscoveryError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return discovery.IsGroupDiscoveryFailedError(err)
+}
+
+// DiscoveryWarning formats a partial discovery error as a warning naming the
+// group versions that could not be discovered.
+func DiscoveryWarning(err error) string {
+	var failed *discovery.ErrGroupDiscoveryFailed
+	if !errors.As(err, &failed) || len(failed.Groups) == 0 {
+		return fmt.Sprintf("partial API discovery: %v", err)
+	}
+	groups := make([]string, 0, len(failed.Groups))
+	for gv := range failed.Groups {
+		groups = append(groups, gv.String())
+	}
+	sort.Strings(groups)
+	return fmt.Sprintf("partial API discovery: skipped unavailable API groups %s", strings.Join(groups, ", "))
+}
+
+// PreferredResources wraps ServerPreferredResources so that partial failures
+// return the resource lists that did resolve plus a warning instead of an
+// error.
+func PreferredResources(discoveryClient discovery.DiscoveryInterface) ([]*metav1.APIResourceList, []string, error) {
+	if discoveryClient == nil {
+		return nil, nil, errors.New("missing discovery client")
+	}
+	lists, err := discoveryClient.ServerPreferredResources()
+	if err == nil {
+		return lists, nil, nil
+	}
+	if IsPartialDiscoveryError(err) {
+		return lists, []string{DiscoveryWarning(err)}, nil
+	}
+	return nil, nil, err
+}
+
+// ServerGroups wraps ServerGroups with the same partial-failure handling as
+// PreferredResources.
+func ServerGroups(discoveryClient discovery.DiscoveryInterface) (*metav1.APIGroupList, []string, error) {
+	if discoveryClient == nil {
+		return nil, nil, errors.New("missing discovery client")
+	}
+	groups, err := discoveryClient.ServerGroups()
+	if err == nil {
+		return groups, nil, nil
+	}
+	if IsPartialDiscoveryError(err) && groups != nil {
+		return groups, []string{DiscoveryWarning(err)}, nil
+	}
+	return nil, nil, err
+}
@@ -0,0 +1,85 @@
+package kube
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// partialDiscovery simulates a cluster whose aggregated metrics API is down:
+// discovery returns the groups that resolved alongside a group failure.
+type partialDiscovery struct {
+	groupsDiscovery
+	err error
+}
+
+func newPartialDiscovery() *partialDiscovery {
+	return &partialDiscovery{
+		groupsDiscovery: groupsDiscovery{groups: []string{"apps", "networking.istio.io"}},
+		err: &discovery.ErrGroupDiscoveryFailed{Groups: map[schema.GroupVersion]error{
+			{Group: "metrics.k8s.io", Version: "v1beta1"}: errors.New("the server is currently unable to handle the request"),
+		}},
+	}
+}
+
+func (p *partialDiscovery) ServerGroups() (*metav1.APIGroupList, error) {
+	groups, _ := p.groupsDiscovery.ServerGroups()
+	return groups, p.err
+}
+
+func (p *partialDiscovery) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	return []*metav1.APIResourceList{
+		{GroupVersion: "networking.istio.io/v1beta1", APIResources: []metav1.APIResource{{Name: "virtualservices", Kind: "VirtualService", Namespaced: true}}},
+	}, p.err
+}
+
+func TestPreferredResourcesPartialFailure(t *testing.T) {
+	lists, warnings, err := PreferredResources(newPartialDiscovery())
+	if err != nil {
+		t.Fatalf("expected partial failure to degrade to a warning, got %v", err)
+	}
+	if len(lists) != 1 || lists[0].GroupVersion != "networking.istio.io/v1beta1" {
+		t.Fatalf("expected resolved resource lists, got %#v", lists)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "metrics.k8s.io/v1beta1") {
+		t.Fatalf("expected warning naming the failed group, got %#v", warnings)
+	}
+}
+
+func TestServerGroupsPartialFailure(t *testing.T) {
+	groups, warnings, err := ServerGroups(newPartialDiscovery())
+	if err != nil {
+		t.Fatalf("expected partial failure to degrade to a warning, got %v", err)
+	}
+	if groups == nil || len(groups.Groups) != 2 || len(warnings) != 1 {
+		t.Fatalf("unexpected groups %#v warnings %#v", groups, warnings)
+	}
+	present, found, err := GroupsPresent(newPartialDiscovery(), []string{"networking.istio.io"})
+	if err != nil || !present || len(found) != 1 {
+		t.Fatalf("expected istio group despite partial failure, got present=%v found=%v err=%v", present, found, err)
+	}
+}
+
+func TestDiscoveryHardFailure(t *testing.T) {
+	failing := newPartialDiscovery()
+	failing.err = errors.New("connection refused")
+	if _, _, err := PreferredResources(failing); err == nil {
+		t.Fatalf("expected hard discovery error")
+	}
+	if _, _, err := ServerGroups(failing); err == nil {
+		t.Fatalf("expected hard discovery error")
+	}
+	if _, _, err := PreferredResources(nil); err == nil {
+		t.Fatalf("expected error for missing discovery client")
+	}
+	if IsPartialDiscoveryError(nil) || IsPartialDiscoveryError(failing.err) {
+		t.Fatalf("expected only group discovery failures to be partial")
+	}
+	if got := DiscoveryWarning(failing.err); !strings.Contains(got, "connection refused") {
+		t.Fatalf("unexpected warning: %s", got)
+	}
+}
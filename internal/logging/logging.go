@@ -0,0 +1,165 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	smithylogging "github.com/aws/smithy-go/logging"
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+type contextKey string
+
+const correlationIDKey contextKey = "rootcause.correlation_id"
+
+// CorrelationIDAttr is the attribute name every log line emitted during a
+// tool call carries.
+const CorrelationIDAttr = "correlation_id"
+
+// New builds a logger writing format ("text" or "json") to out. Empty level
+// and format fall back to info and text.
+func New(out io.Writer, level, format string) (*slog.Logger, error) {
+	if out == nil {
+		out = io.Discard
+	}
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+	var handler slog.Handler
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", "text":
+		handler = slog.NewTextHandler(out, opts)
+	case "json":
+		handler = slog.NewJSONHandler(out, opts)
+	default:
+		return nil, fmt.Errorf("unknown log format %q (expected text or json)", format)
+	}
+	return slog.New(contextHandler{handler}), nil
+}
+
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "debug":
+		return slog.LevelDebug, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return slog.LevelInfo, fmt.Errorf("unknown log level %q (expected debug, info, warn or error)", level)
+}
+
+// Install makes logger the process-wide default and routes the stdlib log
+// package and klog (client-go's logger) through it.
+func Install(logger *slog.Logger) {
+	slog.SetDefault(logger)
+	klog.SetLogger(logr.FromSlogHandler(logger.Handler()))
+}
+
+// WithCorrelationID tags ctx so log lines emitted with it carry id.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, correlationIDKey, id)
+}
+
+func CorrelationID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(correlationIDKey).(string)
+	return id
+}
+
+// contextHandler adds the correlation id from the record's context, so any
+// *Context logging call made during a tool call is attributable to it.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := CorrelationID(ctx); id != "" {
+		record.AddAttrs(slog.String(CorrelationIDAttr, id))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// AWSLogger adapts the default slog logger for the AWS SDK. The SDK passes
+// the request context through WithContext, which is how SDK warnings (retry
+// and checksum notices) pick up the correlation id.
+func AWSLogger() smithylogging.Logger {
+	return awsLogger{ctx: context.Background()}
+}
+
+type awsLogger struct {
+	ctx context.Context
+}
+
+func (l awsLogger) WithContext(ctx context.Context) smithylogging.Logger {
+	return awsLogger{ctx: ctx}
+}
+
+func (l awsLogger) Logf(classification smithylogging.Classification, format string, v ...interface{}) {
+	level := slog.LevelDebug
+	if classification == smithylogging.Warn {
+		level = slog.LevelWarn
+	}
+	slog.Default().Log(l.ctx, level, fmt.Sprintf(format, v...), "source", "aws-sdk")
+}
+
+// WrapKubeConfig logs API server warnings (deprecated APIs, admission
+// warnings) from the transport, where the request context is available,
+// instead of client-go's context-free warning handler.
+func WrapKubeConfig(cfg *rest.Config) {
+	if cfg == nil {
+		return
+	}
+	cfg.WarningHandler = rest.NoWarnings{}
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return warningTransport{next: rt}
+	})
+}
+
+type warningTransport struct {
+	next http.RoundTripper
+}
+
+func (t warningTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	for _, header := range resp.Header.Values("Warning") {
+		slog.Default().WarnContext(req.Context(), kubeWarningText(header), "source", "kubernetes", "path", req.URL.Path)
+	}
+	return resp, err
+}
+
+// kubeWarningText strips the RFC 7234 envelope (299 - "text") the API server
+// wraps warnings in.
+func kubeWarningText(header string) string {
+	parts := strings.SplitN(header, " ", 3)
+	if len(parts) == 3 && parts[0] == "299" {
+		return strings.Trim(parts[2], `"`)
+	}
+	return header
+}
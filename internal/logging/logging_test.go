@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	smithylogging "github.com/aws/smithy-go/logging"
+	"k8s.io/client-go/rest"
+)
+
+func useLogger(t *testing.T, level, format string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	logger, err := New(&buf, level, format)
+	if err != nil {
+		t.Fatalf("new logger: %v", err)
+	}
+	prev := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return &buf
+}
+
+func TestNewJSONAddsCorrelationID(t *testing.T) {
+	buf := useLogger(t, "info", "json")
+	ctx := WithCorrelationID(context.Background(), "abc123")
+	slog.InfoContext(ctx, "hello", "tool", "k8s.get")
+	slog.Info("no context")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected two lines, got %q", buf.String())
+	}
+	if !strings.Contains(lines[0], `"correlation_id":"abc123"`) || !strings.Contains(lines[0], `"tool":"k8s.get"`) {
+		t.Fatalf("expected correlation id and attrs, got %s", lines[0])
+	}
+	if strings.Contains(lines[1], "correlation_id") {
+		t.Fatalf("expected no correlation id without context, got %s", lines[1])
+	}
+}
+
+func TestNewTextAndLevel(t *testing.T) {
+	buf := useLogger(t, "warn", "")
+	ctx := WithCorrelationID(context.Background(), "abc123")
+	slog.InfoContext(ctx, "dropped")
+	slog.With("toolset", "aws").WarnContext(ctx, "kept")
+	out := buf.String()
+	if strings.Contains(out, "dropped") {
+		t.Fatalf("expected info to be filtered at warn: %s", out)
+	}
+	if !strings.Contains(out, "msg=kept") || !strings.Contains(out, "toolset=aws") || !strings.Contains(out, "correlation_id=abc123") {
+		t.Fatalf("unexpected text output: %s", out)
+	}
+}
+
+func TestNewRejectsUnknownValues(t *testing.T) {
+	if _, err := New(nil, "verbose", "text"); err == nil {
+		t.Fatalf("expected unknown level error")
+	}
+	if _, err := New(nil, "info", "xml"); err == nil {
+		t.Fatalf("expected unknown format error")
+	}
+}
+
+func TestAWSLoggerUsesRequestContext(t *testing.T) {
+	buf := useLogger(t, "info", "json")
+	ctx := WithCorrelationID(context.Background(), "aws-1")
+	logger := smithylogging.WithContext(ctx, AWSLogger())
+	logger.Logf(smithylogging.Warn, "retrying %s", "DescribeVpcs")
+	logger.Logf(smithylogging.Debug, "filtered")
+	out := buf.String()
+	if !strings.Contains(out, `"msg":"retrying DescribeVpcs"`) || !strings.Contains(out, `"correlation_id":"aws-1"`) {
+		t.Fatalf("unexpected aws log output: %s", out)
+	}
+	if strings.Contains(out, "filtered") {
+		t.Fatalf("expected debug line to be filtered: %s", out)
+	}
+}
+
+func TestWrapKubeConfigLogsWarnings(t *testing.T) {
+	buf := useLogger(t, "info", "json")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Add("Warning", `299 - "policy/v1beta1 PodSecurityPolicy is deprecated"`)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &rest.Config{Host: server.URL}
+	WrapKubeConfig(cfg)
+	if _, ok := cfg.WarningHandler.(rest.NoWarnings); !ok {
+		t.Fatalf("expected client-go warning handler to be disabled")
+	}
+	client, err := rest.HTTPClientFor(cfg)
+	if err != nil {
+		t.Fatalf("http client: %v", err)
+	}
+	ctx := WithCorrelationID(context.Background(), "kube-1")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	resp.Body.Close()
+	out := buf.String()
+	if !strings.Contains(out, `"msg":"policy/v1beta1 PodSecurityPolicy is deprecated"`) || !strings.Contains(out, `"correlation_id":"kube-1"`) {
+		t.Fatalf("unexpected warning output: %s", out)
+	}
+}
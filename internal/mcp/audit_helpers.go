@@ -4,7 +4,10 @@ import (
 	"context"
 	"time"
 
+	"rootcause/internal/archive"
 	"rootcause/internal/audit"
+	"rootcause/internal/notify"
+	"rootcause/internal/render"
 )
 
 func logAudit(callCtx context.Context, ctx ToolContext, spec ToolSpec, userID string, namespaces, resources []string, outcome string, err error) {
@@ -34,3 +37,62 @@ func logAudit(callCtx context.Context, ctx ToolContext, spec ToolSpec, userID st
 	}
 	ctx.Audit.Log(event)
 }
+
+// archiveResult hands the redacted result of a completed call to the
+// configured archive sink, next to its audit line.
+func archiveResult(callCtx context.Context, ctx ToolContext, spec ToolSpec, userID string, outcome string, err error, data any) {
+	if ctx.Archive == nil {
+		return
+	}
+	traceID, _ := traceIDFromContext(callCtx)
+	callChain, _ := callChainFromContext(callCtx)
+	record := archive.Record{
+		Timestamp: time.Now().UTC(),
+		UserID:    userID,
+		TraceID:   traceID,
+		Tool:      spec.Name,
+		Toolset:   spec.ToolsetID,
+		Outcome:   outcome,
+		Result:    data,
+	}
+	if len(callChain) > 1 {
+		record.ParentTool = callChain[len(callChain)-2]
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	ctx.Archive.Archive(callCtx, record)
+}
+
+// notifyCauses passes the likely root causes of a rendered analysis to the
+// webhook, which alerts on those at or above its severity threshold. Only
+// top-level calls notify: a nested call's causes surface through the tool
+// that made it, and alerting on both would post the same finding twice.
+func notifyCauses(callCtx context.Context, ctx ToolContext, spec ToolSpec, userID string, data any) {
+	if ctx.Notifier == nil {
+		return
+	}
+	if callChain, _ := callChainFromContext(callCtx); len(callChain) > 1 {
+		return
+	}
+	result, ok := data.(map[string]any)
+	if !ok {
+		return
+	}
+	causes, _ := result["likelyRootCauses"].([]render.Cause)
+	if len(causes) == 0 {
+		return
+	}
+	verdict, _ := result["verdict"].(render.Verdict)
+	traceID, _ := traceIDFromContext(callCtx)
+	event := notify.Event{
+		Timestamp: time.Now().UTC(),
+		UserID:    userID,
+		TraceID:   traceID,
+		Tool:      spec.Name,
+		Toolset:   spec.ToolsetID,
+		Verdict:   verdict,
+		Causes:    causes,
+	}
+	ctx.Notifier.Notify(callCtx, event)
+}
@@ -4,13 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"maps"
 	"slices"
 	"sync/atomic"
 	"time"
 
 	"rootcause/internal/config"
+	"rootcause/internal/evidence"
 	"rootcause/internal/policy"
+	"rootcause/internal/redact"
 )
 
 type invokerRuntime struct {
@@ -21,6 +24,7 @@ type invokerRuntime struct {
 type ToolInvoker struct {
 	rt         atomic.Pointer[invokerRuntime]
 	skillCache atomic.Pointer[customSkillCache]
+	contexts   atomic.Pointer[contextRuntimeCache]
 }
 
 func NewToolInvoker(reg *ToolRegistry, ctx ToolContext) *ToolInvoker {
@@ -36,6 +40,10 @@ func (i *ToolInvoker) Swap(reg *ToolRegistry, ctx ToolContext) {
 	}
 	i.rt.Store(&invokerRuntime{reg: reg, ctx: ctx})
 	i.skillCache.Store(newCustomSkillCache())
+	// Drop per-context runtimes built against the previous config.
+	if cache := i.contexts.Load(); cache != nil {
+		i.contexts.Store(newContextRuntimeCache(cache.build))
+	}
 }
 
 func (i *ToolInvoker) Runtime() (*ToolRegistry, ToolContext, bool) {
@@ -50,7 +58,7 @@ func (i *ToolInvoker) Runtime() (*ToolRegistry, ToolContext, bool) {
 }
 
 func (i *ToolInvoker) Call(ctx context.Context, user policy.User, toolName string, args map[string]any) (ToolResult, error) {
-	ctx = ensureTraceContext(ctx)
+	ctx = withCorrelationID(ensureTraceContext(ctx))
 	if i == nil {
 		err := errors.New("tool invoker not available")
 		return ToolResult{Data: BuildErrorEnvelope(err, nil)}, err
@@ -66,6 +74,30 @@ func (i *ToolInvoker) Call(ctx context.Context, user policy.User, toolName strin
 		err := errors.New("tool not found")
 		return ToolResult{Data: BuildErrorEnvelope(err, map[string]any{"tool": toolName})}, err
 	}
+	kubeContext := ""
+	if KubeContextToolset(spec.ToolsetID) {
+		kubeContext = requestedKubeContext(ctx, args)
+	}
+	if kubeContext != "" {
+		if err := checkAllowedContext(tctx.Config, kubeContext); err != nil {
+			logAudit(ctx, tctx, spec, user.ID, nil, nil, "error", err)
+			return ToolResult{Data: BuildErrorEnvelope(err, map[string]any{"tool": spec.Name, "context": kubeContext})}, err
+		}
+		scoped, err := i.contextRuntime(kubeContext)
+		if err != nil {
+			logAudit(ctx, tctx, spec, user.ID, nil, nil, "error", err)
+			return ToolResult{Data: BuildErrorEnvelope(err, map[string]any{"tool": spec.Name, "context": kubeContext})}, err
+		}
+		if scoped != nil {
+			scopedSpec, ok := scoped.reg.Get(toolName)
+			if !ok {
+				err := fmt.Errorf("tool %s not available for context %s", toolName, kubeContext)
+				logAudit(ctx, tctx, spec, user.ID, nil, nil, "error", err)
+				return ToolResult{Data: BuildErrorEnvelope(err, map[string]any{"tool": spec.Name, "context": kubeContext})}, err
+			}
+			rt, tctx, spec = scoped, scoped.ctx, scopedSpec
+		}
+	}
 	chain, _ := callChainFromContext(ctx)
 	if maxDepth := maxCallDepth(tctx.Config); maxDepth > 0 && len(chain) >= maxDepth {
 		err := fmt.Errorf("call depth %d exceeds max %d at tool %s", len(chain), maxDepth, spec.Name)
@@ -107,28 +139,66 @@ func (i *ToolInvoker) Call(ctx context.Context, user policy.User, toolName strin
 		tctx.CallGraph.Record(parent, spec.Name)
 	}
 	chain = append(chain, spec.Name)
-	execCtx := withCallChain(ctx, chain)
+	// One pod index per top-level invocation; nested calls inherit it unless
+	// they switch to another kube context, whose pods the parent never saw.
+	// Nested kube tool calls stay on the context the parent was routed to.
+	indexCtx := evidence.WithPodIndex(ctx)
+	if parentContext, _ := ctx.Value(kubeContextKey).(string); kubeContext != "" && kubeContext != parentContext {
+		indexCtx = evidence.WithNewPodIndex(ctx)
+	}
+	execCtx := withKubeContext(withCallChain(indexCtx, chain), kubeContext)
 	execCtx, cancel := withToolTimeout(execCtx, tctx.Config, spec)
+	started := time.Now()
+	slog.DebugContext(execCtx, "tool call started", "tool", spec.Name, "user", user.ID)
 	result, toolErr := spec.Handler(execCtx, ToolRequest{Arguments: args, User: user, Context: tctx})
 	cancel()
 	outcome := "success"
 	if toolErr != nil {
 		outcome = "error"
 		result.Data = canonicalErrorPayload(toolErr, result.Data)
+		slog.WarnContext(execCtx, "tool call failed", "tool", spec.Name, "duration", time.Since(started), "error", toolErr)
+	} else {
+		slog.DebugContext(execCtx, "tool call finished", "tool", spec.Name, "duration", time.Since(started))
 	}
 	// Redact tokens/secrets from the result before it leaves the server.
 	// This covers k8s.logs payloads, observability.logs.* entries, and any
 	// other handler that surfaces strings sourced from user workloads.
 	if tctx.Redactor != nil {
-		result.Data = tctx.Redactor.RedactValue(result.Data)
+		result.Data = redactResult(tctx.Redactor, spec.ToolsetID, result.Data)
 	}
 	cache := i.skillCache.Load()
 	guidance, guidanceErr := customSkillGuidanceForTool(tctx.Config, spec, args, cache)
 	result = attachCustomSkillGuidance(result, guidance, guidanceErr)
 	logAudit(execCtx, tctx, spec, user.ID, result.Metadata.Namespaces, result.Metadata.Resources, outcome, toolErr)
+	archiveResult(execCtx, tctx, spec, user.ID, outcome, toolErr, result.Data)
+	notifyCauses(execCtx, tctx, spec, user.ID, result.Data)
 	return result, toolErr
 }
 
+// resultCursorKey holds the opaque pagination cursor of aws.* list tools. It
+// is base64 of AWS's own token, so it always looks token-ish, and the caller
+// has to hand it back byte for byte to fetch the next page.
+const resultCursorKey = "nextToken"
+
+// cursorToolsetID is the toolset whose results carry resultCursorKey.
+const cursorToolsetID = "aws"
+
+// redactResult redacts a tool result. For aws.* tools it keeps the top-level
+// pagination cursor intact; keys of the same name deeper in the payload, or
+// in any other toolset's result, are redacted like everything else.
+func redactResult(redactor *redact.Redactor, toolsetID string, data any) any {
+	root, ok := data.(map[string]any)
+	if !ok || toolsetID != cursorToolsetID {
+		return redactor.RedactValue(data)
+	}
+	cursor, hasCursor := root[resultCursorKey].(string)
+	redacted := redactor.RedactMap(root)
+	if hasCursor {
+		redacted[resultCursorKey] = cursor
+	}
+	return redacted
+}
+
 func maxCallDepth(cfg *config.Config) int {
 	if cfg == nil {
 		return defaultMaxCallDepth
@@ -1,17 +1,32 @@
 package mcp
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"rootcause/internal/archive"
 	"rootcause/internal/config"
+	"rootcause/internal/evidence"
+	"rootcause/internal/logging"
+	"rootcause/internal/notify"
 	"rootcause/internal/policy"
+	"rootcause/internal/redact"
+	"rootcause/internal/render"
 )
 
 func TestInvokerToolNotFound(t *testing.T) {
@@ -46,6 +61,42 @@ func TestInvokerHandlerError(t *testing.T) {
 	}
 }
 
+func TestInvokerLogsCarryCorrelationID(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "debug", "json")
+	if err != nil {
+		t.Fatalf("logger: %v", err)
+	}
+	prev := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	cfg := config.DefaultConfig()
+	reg := NewRegistry(&cfg)
+	_ = reg.Add(ToolSpec{
+		Name:      "demo",
+		ToolsetID: "core",
+		Handler: func(ctx context.Context, _ ToolRequest) (ToolResult, error) {
+			slog.WarnContext(ctx, "from handler")
+			return ToolResult{Data: map[string]any{"ok": true}}, nil
+		},
+	})
+	invoker := NewToolInvoker(reg, ToolContext{Policy: policy.NewAuthorizer()})
+	ctx := withTraceID(context.Background(), "trace-42")
+	if _, err := invoker.Call(ctx, policy.User{Role: policy.RoleCluster}, "demo", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected start, handler and finish lines, got %q", buf.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, `"correlation_id":"trace-42"`) {
+			t.Fatalf("expected correlation id on every line, got %s", line)
+		}
+	}
+}
+
 func TestInvokerMissingRegistry(t *testing.T) {
 	invoker := &ToolInvoker{}
 	result, err := invoker.Call(context.Background(), policy.User{Role: policy.RoleCluster}, "demo", nil)
@@ -78,6 +129,135 @@ func TestInvokerSuccess(t *testing.T) {
 	}
 }
 
+func TestInvokerKeepsTopLevelCursor(t *testing.T) {
+	cfg := config.DefaultConfig()
+	reg := NewRegistry(&cfg)
+	cursor := "eyJ0IjoiQVFJQ0FIZ0hJM0V4YW1wbGVUb2tlblZhbHVlMTIzNDU2Nzg5MCJ9"
+	handler := func(_ context.Context, _ ToolRequest) (ToolResult, error) {
+		return ToolResult{Data: map[string]any{
+			"nextToken": cursor,
+			"values":    map[string]any{"nextToken": "abcdefghijklmnopqrstuvwxyz123456"},
+		}}, nil
+	}
+	_ = reg.Add(ToolSpec{Name: "aws.demo", ToolsetID: "aws", Handler: handler})
+	_ = reg.Add(ToolSpec{Name: "demo", ToolsetID: "core", Handler: handler})
+	invoker := NewToolInvoker(reg, ToolContext{Policy: policy.NewAuthorizer(), Redactor: redact.New()})
+	result, err := invoker.Call(context.Background(), policy.User{Role: policy.RoleCluster}, "aws.demo", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if data["nextToken"] != cursor {
+		t.Fatalf("expected top-level cursor untouched, got %v", data["nextToken"])
+	}
+	if nested := data["values"].(map[string]any)["nextToken"]; nested == "abcdefghijklmnopqrstuvwxyz123456" {
+		t.Fatalf("expected nested nextToken redacted")
+	}
+	result, err = invoker.Call(context.Background(), policy.User{Role: policy.RoleCluster}, "demo", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.Data.(map[string]any)["nextToken"]; got == cursor {
+		t.Fatalf("expected nextToken from a non-aws toolset to be redacted")
+	}
+}
+
+func TestInvokerArchivesRedactedResult(t *testing.T) {
+	cfg := config.DefaultConfig()
+	reg := NewRegistry(&cfg)
+	_ = reg.Add(ToolSpec{
+		Name:      "demo",
+		ToolsetID: "core",
+		Handler: func(_ context.Context, _ ToolRequest) (ToolResult, error) {
+			return ToolResult{Data: map[string]any{"log": "token=abcdEFGHijklMNOPqrst"}}, nil
+		},
+	})
+	dir := t.TempDir()
+	archiver := archive.New(archive.NewFileSink(dir))
+	invoker := NewToolInvoker(reg, ToolContext{
+		Policy:   policy.NewAuthorizer(),
+		Redactor: redact.New(),
+		Archive:  archiver,
+	})
+	if _, err := invoker.Call(context.Background(), policy.User{ID: "alice", Role: policy.RoleCluster}, "demo", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	archiver.Wait()
+	files, _ := filepath.Glob(filepath.Join(dir, "*", "*_demo.json"))
+	if len(files) != 1 {
+		t.Fatalf("expected one archived record, got %v", files)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("read archived record: %v", err)
+	}
+	if !strings.Contains(string(data), `"token=[REDACTED]"`) || strings.Contains(string(data), "abcdEFGH") {
+		t.Fatalf("expected redacted result in archive: %s", data)
+	}
+	if !strings.Contains(string(data), `"userId":"alice"`) || !strings.Contains(string(data), `"outcome":"success"`) {
+		t.Fatalf("expected call metadata in archive: %s", data)
+	}
+}
+
+func TestInvokerNotifiesOnSevereCause(t *testing.T) {
+	events := make(chan notify.Event, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event notify.Event
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		events <- event
+	}))
+	defer server.Close()
+	cfg := config.DefaultConfig()
+	reg := NewRegistry(&cfg)
+	for name, severity := range map[string]string{"severe": "critical", "mild": "low"} {
+		_ = reg.Add(ToolSpec{
+			Name:      name,
+			ToolsetID: "core",
+			Handler: func(_ context.Context, _ ToolRequest) (ToolResult, error) {
+				analysis := render.NewAnalysis()
+				analysis.AddCause("Node NotReady", "kubelet stopped posting status", severity)
+				return ToolResult{Data: render.NewRenderer().Render(analysis)}, nil
+			},
+		})
+	}
+	var invoker *ToolInvoker
+	_ = reg.Add(ToolSpec{
+		Name:      "bundle",
+		ToolsetID: "core",
+		Handler: func(ctx context.Context, req ToolRequest) (ToolResult, error) {
+			return invoker.Call(ctx, req.User, "severe", nil)
+		},
+	})
+	notifier := notify.NewWebhook(server.URL, "high", time.Second)
+	invoker = NewToolInvoker(reg, ToolContext{Policy: policy.NewAuthorizer(), Notifier: notifier})
+	user := policy.User{ID: "alice", Role: policy.RoleCluster}
+	for _, name := range []string{"mild", "severe", "bundle"} {
+		if _, err := invoker.Call(context.Background(), user, name, nil); err != nil {
+			t.Fatalf("call %s: %v", name, err)
+		}
+	}
+	notifier.Wait()
+	close(events)
+	var got []notify.Event
+	for event := range events {
+		got = append(got, event)
+	}
+	tools := map[string]int{}
+	for _, event := range got {
+		tools[event.Tool]++
+	}
+	// bundle returns severe's result, so it alerts once; the nested severe
+	// call does not alert again.
+	if len(got) != 2 || tools["severe"] != 1 || tools["bundle"] != 1 {
+		t.Fatalf("expected one notification per top-level critical call, got %+v", got)
+	}
+	for _, event := range got {
+		if event.UserID != "alice" || event.Verdict.Status != render.StatusCritical || event.Causes[0].Details != "kubelet stopped posting status" {
+			t.Fatalf("unexpected notification %+v", event)
+		}
+	}
+}
+
 func TestInvokerAttachesTaggedCustomSkillGuidance(t *testing.T) {
 	customRoot := t.TempDir()
 	content := "---\ntags: [demo]\ndescription: Demo tool guidance\n---\n# Demo Skill\n"
@@ -492,7 +672,7 @@ func TestRegistryListExposesGlobalSkillTagArguments(t *testing.T) {
 	if _, exists := props["namespace"]; !exists {
 		t.Fatalf("expected original namespace property to be preserved: %#v", props)
 	}
-	for _, field := range []string{"skillTags", "customSkillTags"} {
+	for _, field := range []string{"skillTags", "customSkillTags", "fields"} {
 		fieldSchema, exists := props[field]
 		if !exists {
 			t.Fatalf("expected %s schema property in %#v", field, props)
@@ -747,3 +927,167 @@ func TestInvokerEnforcesMaxCallDepth(t *testing.T) {
 		t.Fatalf("expected call depth error, got: %v", err)
 	}
 }
+
+func TestInvokerRoutesKubeContextToScopedRuntime(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.AllowedContexts = []string{"prod", "staging"}
+	newReg := func(label string) *ToolRegistry {
+		reg := NewRegistry(&cfg)
+		_ = reg.Add(ToolSpec{
+			Name:      "k8s.demo",
+			ToolsetID: "k8s",
+			Handler: func(_ context.Context, _ ToolRequest) (ToolResult, error) {
+				return ToolResult{Data: map[string]any{"runtime": label}}, nil
+			},
+		})
+		return reg
+	}
+	base := ToolContext{Config: &cfg, Policy: policy.NewAuthorizer()}
+	invoker := NewToolInvoker(newReg("default"), base)
+	builds := map[string]int{}
+	invoker.SetContextRuntimeBuilder(func(name string) (*ToolRegistry, ToolContext, error) {
+		builds[name]++
+		if name == "staging" {
+			return nil, ToolContext{}, nil
+		}
+		return newReg(name), base, nil
+	})
+	user := policy.User{Role: policy.RoleCluster}
+
+	runtimeFor := func(args map[string]any) string {
+		t.Helper()
+		result, err := invoker.Call(context.Background(), user, "k8s.demo", args)
+		if err != nil {
+			t.Fatalf("call with %v: %v", args, err)
+		}
+		return result.Data.(map[string]any)["runtime"].(string)
+	}
+	if got := runtimeFor(nil); got != "default" {
+		t.Fatalf("expected default runtime, got %q", got)
+	}
+	if got := runtimeFor(map[string]any{"context": "prod"}); got != "prod" {
+		t.Fatalf("expected prod runtime, got %q", got)
+	}
+	if got := runtimeFor(map[string]any{"context": "prod"}); got != "prod" {
+		t.Fatalf("expected cached prod runtime, got %q", got)
+	}
+	if got := runtimeFor(map[string]any{"context": "staging"}); got != "default" {
+		t.Fatalf("expected default runtime for default context, got %q", got)
+	}
+	if builds["prod"] != 1 || builds["staging"] != 1 {
+		t.Fatalf("expected one build per context, got %#v", builds)
+	}
+	if _, err := invoker.Call(context.Background(), user, "k8s.demo", map[string]any{"context": "dev"}); err == nil || !strings.Contains(err.Error(), "allowed_contexts") {
+		t.Fatalf("expected allowed_contexts rejection, got %v", err)
+	}
+	if builds["dev"] != 0 {
+		t.Fatalf("expected disallowed context not to be built")
+	}
+
+	invoker.Swap(newReg("default"), base)
+	_ = runtimeFor(map[string]any{"context": "prod"})
+	if builds["prod"] != 2 {
+		t.Fatalf("expected swap to drop cached context runtimes, got %#v", builds)
+	}
+}
+
+func TestInvokerKubeContextWithoutBuilder(t *testing.T) {
+	cfg := config.DefaultConfig()
+	reg := NewRegistry(&cfg)
+	_ = reg.Add(ToolSpec{
+		Name:      "k8s.demo",
+		ToolsetID: "k8s",
+		Handler: func(_ context.Context, _ ToolRequest) (ToolResult, error) {
+			return ToolResult{Data: map[string]any{"ok": true}}, nil
+		},
+	})
+	_ = reg.Add(ToolSpec{
+		Name:      "core.demo",
+		ToolsetID: "core",
+		Handler: func(_ context.Context, _ ToolRequest) (ToolResult, error) {
+			return ToolResult{Data: map[string]any{"ok": true}}, nil
+		},
+	})
+	invoker := NewToolInvoker(reg, ToolContext{Config: &cfg, Policy: policy.NewAuthorizer()})
+	user := policy.User{Role: policy.RoleCluster}
+	if _, err := invoker.Call(context.Background(), user, "k8s.demo", map[string]any{"context": "prod"}); err == nil {
+		t.Fatalf("expected error when context switching is unavailable")
+	}
+	if _, err := invoker.Call(context.Background(), user, "core.demo", map[string]any{"context": "prod"}); err != nil {
+		t.Fatalf("expected non-kube tool to ignore context, got %v", err)
+	}
+}
+
+func TestInvokerPodIndexPerKubeContext(t *testing.T) {
+	cfg := config.DefaultConfig()
+	clusters := map[string]*fake.Clientset{}
+	for _, name := range []string{"prod", "staging"} {
+		clusters[name] = fake.NewSimpleClientset(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name + "-pod", Namespace: "default"}})
+	}
+	var invoker *ToolInvoker
+	user := policy.User{Role: policy.RoleCluster}
+	newReg := func(name string) *ToolRegistry {
+		reg := NewRegistry(&cfg)
+		_ = reg.Add(ToolSpec{
+			Name:      "k8s.pods",
+			ToolsetID: "k8s",
+			Handler: func(ctx context.Context, _ ToolRequest) (ToolResult, error) {
+				idx, _ := evidence.PodIndexFromContext(ctx)
+				pods, err := idx.Pods(ctx, clusters[name], "default", nil)
+				if err != nil || len(pods) != 1 {
+					return ToolResult{}, fmt.Errorf("pods: %v %v", pods, err)
+				}
+				return ToolResult{Data: map[string]any{"pod": pods[0].Name, "lists": idx.Lists()}}, nil
+			},
+		})
+		_ = reg.Add(ToolSpec{
+			Name:      "k8s.parent",
+			ToolsetID: "k8s",
+			Handler: func(ctx context.Context, req ToolRequest) (ToolResult, error) {
+				idx, _ := evidence.PodIndexFromContext(ctx)
+				if _, err := idx.Pods(ctx, clusters[name], "default", nil); err != nil {
+					return ToolResult{}, err
+				}
+				same, err := invoker.Call(ctx, req.User, "k8s.pods", nil)
+				if err != nil {
+					return ToolResult{}, err
+				}
+				other, err := invoker.Call(ctx, req.User, "k8s.pods", map[string]any{"context": "staging"})
+				if err != nil {
+					return ToolResult{}, err
+				}
+				return ToolResult{Data: map[string]any{"same": same.Data, "other": other.Data}}, nil
+			},
+		})
+		return reg
+	}
+	base := ToolContext{Config: &cfg, Policy: policy.NewAuthorizer()}
+	invoker = NewToolInvoker(newReg("prod"), base)
+	invoker.SetContextRuntimeBuilder(func(name string) (*ToolRegistry, ToolContext, error) {
+		return newReg(name), base, nil
+	})
+	result, err := invoker.Call(context.Background(), user, "k8s.parent", map[string]any{"context": "prod"})
+	if err != nil {
+		t.Fatalf("parent call: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if same := data["same"].(map[string]any); same["pod"] != "prod-pod" || same["lists"] != 1 {
+		t.Fatalf("expected same-context nested call to share the parent index, got %#v", same)
+	}
+	if other := data["other"].(map[string]any); other["pod"] != "staging-pod" {
+		t.Fatalf("expected nested call to staging to see only staging pods, got %#v", other)
+	}
+}
+
+func TestAugmentedSchemaAddsKubeContextForKubeToolsets(t *testing.T) {
+	kubeSpec := ToolSpec{Name: "k8s.demo", ToolsetID: "k8s", InputSchema: map[string]any{"type": "object", "properties": map[string]any{}}}
+	props := kubeSpec.AugmentedSchema()["properties"].(map[string]any)
+	if _, ok := props[KubeContextArg]; !ok {
+		t.Fatalf("expected context property on kube tool: %#v", props)
+	}
+	awsSpec := ToolSpec{Name: "aws.demo", ToolsetID: "aws", InputSchema: map[string]any{"type": "object", "properties": map[string]any{}}}
+	props = awsSpec.AugmentedSchema()["properties"].(map[string]any)
+	if _, ok := props[KubeContextArg]; ok {
+		t.Fatalf("expected no context property on non-kube tool: %#v", props)
+	}
+}
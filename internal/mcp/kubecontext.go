@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"rootcause/internal/config"
+)
+
+// KubeContextArg is the per-call argument that routes a kube-backed tool to a
+// different kubeconfig context without restarting the server.
+const KubeContextArg = "context"
+
+const kubeContextKey traceContextKey = "rootcause.kube_context"
+
+var kubeContextToolsets = []string{"k8s", "helm", "istio", "karpenter", "linkerd"}
+
+// KubeContextToolset reports whether tools in the toolset talk to the cluster
+// through kube clients and therefore accept the per-call context argument.
+func KubeContextToolset(id string) bool {
+	return slices.Contains(kubeContextToolsets, id)
+}
+
+// ContextRuntimeBuilder builds the registry and tool context for a named
+// kubeconfig context. Returning a nil registry means the name resolves to the
+// server's default context, so the main runtime is used as-is.
+type ContextRuntimeBuilder func(name string) (*ToolRegistry, ToolContext, error)
+
+type contextRuntimeCache struct {
+	build   ContextRuntimeBuilder
+	mu      sync.Mutex
+	entries map[string]*invokerRuntime
+}
+
+func newContextRuntimeCache(build ContextRuntimeBuilder) *contextRuntimeCache {
+	return &contextRuntimeCache{build: build, entries: map[string]*invokerRuntime{}}
+}
+
+// SetContextRuntimeBuilder enables per-call kubeconfig context switching.
+// Built runtimes (clients, discovery and RESTMapper) are cached per context
+// until the next Swap.
+func (i *ToolInvoker) SetContextRuntimeBuilder(build ContextRuntimeBuilder) {
+	if i == nil {
+		return
+	}
+	if build == nil {
+		i.contexts.Store(nil)
+		return
+	}
+	i.contexts.Store(newContextRuntimeCache(build))
+}
+
+// contextRuntime returns the runtime for the named context, or nil when the
+// default runtime should serve the call.
+func (i *ToolInvoker) contextRuntime(name string) (*invokerRuntime, error) {
+	cache := i.contexts.Load()
+	if cache == nil {
+		return nil, fmt.Errorf("kubeconfig context switching is not available; cannot use context %q", name)
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if rt, ok := cache.entries[name]; ok {
+		return rt, nil
+	}
+	reg, tctx, err := cache.build(name)
+	if err != nil {
+		return nil, err
+	}
+	var rt *invokerRuntime
+	if reg != nil {
+		rt = &invokerRuntime{reg: reg, ctx: tctx}
+	}
+	cache.entries[name] = rt
+	return rt, nil
+}
+
+func requestedKubeContext(ctx context.Context, args map[string]any) string {
+	if value, ok := args[KubeContextArg].(string); ok {
+		if name := strings.TrimSpace(value); name != "" {
+			return name
+		}
+	}
+	if ctx == nil {
+		return ""
+	}
+	name, _ := ctx.Value(kubeContextKey).(string)
+	return name
+}
+
+func withKubeContext(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, kubeContextKey, name)
+}
+
+func checkAllowedContext(cfg *config.Config, name string) error {
+	if cfg == nil || len(cfg.AllowedContexts) == 0 {
+		return nil
+	}
+	if slices.Contains(cfg.AllowedContexts, name) {
+		return nil
+	}
+	return fmt.Errorf("kubeconfig context %q is not in allowed_contexts", name)
+}
@@ -0,0 +1,117 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultPreflightTimeout bounds each toolset's connectivity check.
+const DefaultPreflightTimeout = 10 * time.Second
+
+const (
+	PreflightReady   = "ready"
+	PreflightFailed  = "failed"
+	PreflightSkipped = "skipped"
+)
+
+// PreflightChecker is implemented by toolsets that can verify they reach
+// their backend (API server, cloud credentials, CRDs). Preflight returns a
+// short description of what it found.
+type PreflightChecker interface {
+	Preflight(ctx context.Context) (string, error)
+}
+
+type PreflightResult struct {
+	Toolset    string `json:"toolset"`
+	Status     string `json:"status"`
+	Detail     string `json:"detail,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+type preflightEntry struct {
+	toolsetID string
+	checker   PreflightChecker
+}
+
+// AddPreflight records the connectivity check for toolsetID; checks run in
+// the order toolsets were added.
+func (r *ToolRegistry) AddPreflight(toolsetID string, checker PreflightChecker) {
+	if checker == nil {
+		return
+	}
+	r.preflights = append(r.preflights, preflightEntry{toolsetID: toolsetID, checker: checker})
+}
+
+// AddToolset records that toolsetID is enabled in this runtime. That can
+// differ from Config.Toolsets, e.g. browser is enabled from the environment.
+func (r *ToolRegistry) AddToolset(toolsetID string) {
+	r.toolsets = append(r.toolsets, toolsetID)
+}
+
+// Toolsets returns the enabled toolsets in the order they were added.
+func (r *ToolRegistry) Toolsets() []string {
+	return append([]string{}, r.toolsets...)
+}
+
+// RunPreflights runs every registered check. Toolsets in enabled without a
+// check are reported as skipped so the table covers the whole config.
+func RunPreflights(ctx context.Context, reg Registry, enabled []string, timeout time.Duration) []PreflightResult {
+	var entries []preflightEntry
+	if toolReg, ok := reg.(*ToolRegistry); ok && toolReg != nil {
+		entries = toolReg.preflights
+	}
+	checked := map[string]bool{}
+	results := make([]PreflightResult, 0, len(entries)+len(enabled))
+	for _, entry := range entries {
+		checked[entry.toolsetID] = true
+		results = append(results, RunPreflight(ctx, entry.toolsetID, entry.checker, timeout))
+	}
+	for _, id := range enabled {
+		if checked[id] {
+			continue
+		}
+		checked[id] = true
+		results = append(results, PreflightResult{Toolset: id, Status: PreflightSkipped, Detail: "no connectivity check"})
+	}
+	return results
+}
+
+// RunPreflight runs one check under timeout and recovers a panicking check
+// into a failure, so one broken backend never hides the rest of the table.
+func RunPreflight(ctx context.Context, toolsetID string, checker PreflightChecker, timeout time.Duration) (result PreflightResult) {
+	if timeout <= 0 {
+		timeout = DefaultPreflightTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	started := time.Now()
+	result = PreflightResult{Toolset: toolsetID}
+	defer func() {
+		result.DurationMs = time.Since(started).Milliseconds()
+		if recovered := recover(); recovered != nil {
+			result.Status = PreflightFailed
+			result.Detail = fmt.Sprintf("check panicked: %v", recovered)
+		}
+	}()
+	detail, err := checker.Preflight(ctx)
+	if err != nil {
+		result.Status = PreflightFailed
+		result.Detail = err.Error()
+		return result
+	}
+	result.Status = PreflightReady
+	result.Detail = detail
+	return result
+}
+
+// PreflightFailures counts results that are not ready or skipped.
+func PreflightFailures(results []PreflightResult) int {
+	failed := 0
+	for _, result := range results {
+		if result.Status == PreflightFailed {
+			failed++
+		}
+	}
+	return failed
+}
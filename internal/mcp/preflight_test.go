@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"rootcause/internal/config"
+)
+
+type preflightFunc func(context.Context) (string, error)
+
+func (f preflightFunc) Preflight(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+func TestRunPreflights(t *testing.T) {
+	cfg := config.DefaultConfig()
+	reg := NewRegistry(&cfg)
+	reg.AddPreflight("k8s", preflightFunc(func(context.Context) (string, error) {
+		return "Kubernetes v1.29.0", nil
+	}))
+	reg.AddPreflight("aws", preflightFunc(func(context.Context) (string, error) {
+		return "", errors.New("no credentials")
+	}))
+	reg.AddPreflight("istio", preflightFunc(func(context.Context) (string, error) {
+		panic("boom")
+	}))
+	reg.AddPreflight("helm", preflightFunc(func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}))
+	results := RunPreflights(context.Background(), reg, []string{"k8s", "aws", "terraform"}, 20*time.Millisecond)
+	want := map[string]string{
+		"k8s":       PreflightReady,
+		"aws":       PreflightFailed,
+		"istio":     PreflightFailed,
+		"helm":      PreflightFailed,
+		"terraform": PreflightSkipped,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("unexpected results: %#v", results)
+	}
+	for _, result := range results {
+		if result.Status != want[result.Toolset] {
+			t.Fatalf("unexpected status for %s: %#v", result.Toolset, result)
+		}
+	}
+	if !strings.Contains(results[2].Detail, "panicked") {
+		t.Fatalf("expected panic to be reported: %#v", results[2])
+	}
+	if !strings.Contains(results[3].Detail, "deadline") {
+		t.Fatalf("expected timeout to be reported: %#v", results[3])
+	}
+	if got := PreflightFailures(results); got != 3 {
+		t.Fatalf("expected 3 failures, got %d", got)
+	}
+}
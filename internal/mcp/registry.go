@@ -15,8 +15,10 @@ type Registry interface {
 }
 
 type ToolRegistry struct {
-	cfg   *config.Config
-	tools map[string]ToolSpec
+	cfg        *config.Config
+	tools      map[string]ToolSpec
+	preflights []preflightEntry
+	toolsets   []string
 }
 
 func NewRegistry(cfg *config.Config) *ToolRegistry {
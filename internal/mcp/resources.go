@@ -19,6 +19,7 @@ import (
 	"k8s.io/client-go/util/homedir"
 	"sigs.k8s.io/yaml"
 
+	"rootcause/internal/kube"
 	"rootcause/internal/policy"
 	"rootcause/internal/skills/catalog"
 )
@@ -295,7 +296,7 @@ func readClusterResource(callCtx context.Context, ctx ToolContext, resource stri
 		}
 		return ver, nil
 	case "api-resources":
-		resources, err := ctx.Clients.Discovery.ServerPreferredResources()
+		resources, _, err := kube.PreferredResources(ctx.Clients.Discovery)
 		if err != nil {
 			return nil, err
 		}
@@ -10,6 +10,9 @@ import (
 	sdkjsonrpc "github.com/modelcontextprotocol/go-sdk/jsonrpc"
 	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/xeipuuv/gojsonschema"
+
+	"rootcause/internal/config"
+	"rootcause/internal/render"
 )
 
 func RegisterSDKTools(server *sdkmcp.Server, inv *ToolInvoker) ([]string, error) {
@@ -55,10 +58,36 @@ func schemaWithGlobalSkillTags(schema map[string]any) map[string]any {
 			{"type": "array", "items": map[string]any{"type": "string"}},
 		},
 	}
+	props["outputFormat"] = map[string]any{
+		"description": "Optional text format for the result: json (compact, default), markdown, or plain.",
+		"type":        "string",
+		"enum":        render.Formats,
+	}
+	props["fields"] = map[string]any{
+		"description": "Optional projection: only return these JSON paths, e.g. [\"instances[].id\", \"instances[].state\"]. \"[]\" steps into a list and keys may use * wildcards.",
+		"oneOf": []map[string]any{
+			{"type": "string"},
+			{"type": "array", "items": map[string]any{"type": "string"}},
+		},
+	}
 	out["properties"] = props
 	return out
 }
 
+func schemaWithKubeContext(schema map[string]any) map[string]any {
+	props, ok := schema["properties"].(map[string]any)
+	if !ok {
+		return schema
+	}
+	if _, exists := props[KubeContextArg]; !exists {
+		props[KubeContextArg] = map[string]any{
+			"description": "Optional kubeconfig context to run this call against instead of the server default.",
+			"type":        "string",
+		}
+	}
+	return schema
+}
+
 func toolHandler(spec ToolSpec, inv *ToolInvoker) sdkmcp.ToolHandler {
 	return func(callCtx context.Context, req *sdkmcp.CallToolRequest) (*sdkmcp.CallToolResult, error) {
 		args := map[string]any{}
@@ -84,6 +113,15 @@ func toolHandler(spec ToolSpec, inv *ToolInvoker) sdkmcp.ToolHandler {
 
 		callCtx = withTraceID(callCtx, traceID)
 
+		format, err := resultFormat(args, tctx.Config)
+		if err != nil {
+			return nil, &sdkjsonrpc.Error{Code: sdkjsonrpc.CodeInvalidParams, Message: err.Error()}
+		}
+		fields, err := render.ParseFields(args["fields"])
+		if err != nil {
+			return nil, &sdkjsonrpc.Error{Code: sdkjsonrpc.CodeInvalidParams, Message: err.Error()}
+		}
+
 		if tctx.Config != nil && tctx.Config.Limits.StrictSchema {
 			if schema, schemaErr := spec.CompileSchema(); schemaErr == nil && schema != nil {
 				validation, vErr := schema.Validate(gojsonschema.NewGoLoader(args))
@@ -91,19 +129,59 @@ func toolHandler(spec ToolSpec, inv *ToolInvoker) sdkmcp.ToolHandler {
 					detail := schemaValidationErrors(validation, vErr)
 					envelope := BuildErrorEnvelope(fmt.Errorf("invalid arguments: %s", strings.Join(detail, "; ")), map[string]any{"tool": spec.Name, "violations": detail})
 					result := ToolResult{Data: envelope}
-					return buildCallToolResult(callCtx, result, fmt.Errorf("invalid arguments"), tctx.Config.Limits.MaxResultBytes), nil
+					return buildCallToolResult(callCtx, result, fmt.Errorf("invalid arguments"), tctx.Config.Limits.MaxResultBytes, format), nil
 				}
 			}
 		}
 
 		result, toolErr := inv.Call(callCtx, user, spec.Name, args)
+		var unmatched []string
+		if toolErr == nil && len(fields) > 0 {
+			result, unmatched = projectResult(result, fields)
+		}
 
 		maxBytes := 0
 		if tctx.Config != nil {
 			maxBytes = tctx.Config.Limits.MaxResultBytes
 		}
-		return buildCallToolResult(callCtx, result, toolErr, maxBytes), nil
+		res := buildCallToolResult(callCtx, result, toolErr, maxBytes, format)
+		if len(unmatched) > 0 {
+			if res.Meta == nil {
+				res.Meta = sdkmcp.Meta{}
+			}
+			res.Meta["unmatchedFields"] = unmatched
+		}
+		return res, nil
+	}
+}
+
+// resultFormat picks the text format for a call: the per-call `outputFormat`
+// argument wins over the configured default. It is not called `format`
+// because some tools already take a `format` argument of their own.
+func resultFormat(args map[string]any, cfg *config.Config) (render.Format, error) {
+	if value, ok := args["outputFormat"].(string); ok && value != "" {
+		return render.ParseFormat(value)
+	}
+	if cfg != nil && cfg.Render.Format != "" {
+		return render.ParseFormat(cfg.Render.Format)
+	}
+	return render.FormatJSON, nil
+}
+
+// projectResult trims a successful result to the caller's `fields` before
+// it is serialized. Unlike the max_result_bytes cap this is caller-directed,
+// and it applies to the structured content as well as the text. A result
+// that cannot be projected is returned whole.
+func projectResult(result ToolResult, fields []string) (ToolResult, []string) {
+	if result.Data == nil {
+		return result, nil
+	}
+	projected, unmatched, err := render.Project(result.Data, fields)
+	if err != nil {
+		return result, nil
 	}
+	result.Data = projected
+	return result, unmatched
 }
 
 func schemaValidationErrors(result *gojsonschema.Result, err error) []string {
@@ -122,7 +200,7 @@ func schemaValidationErrors(result *gojsonschema.Result, err error) []string {
 
 const truncationNotice = "\n... [truncated: result exceeds max_result_bytes; full payload available in StructuredContent]"
 
-func buildCallToolResult(callCtx context.Context, result ToolResult, toolErr error, maxBytes int) *sdkmcp.CallToolResult {
+func buildCallToolResult(callCtx context.Context, result ToolResult, toolErr error, maxBytes int, format render.Format) *sdkmcp.CallToolResult {
 	res := &sdkmcp.CallToolResult{}
 	meta := sdkmcp.Meta{}
 	if traceID, ok := traceIDFromContext(callCtx); ok && traceID != "" {
@@ -166,19 +244,19 @@ func buildCallToolResult(callCtx context.Context, result ToolResult, toolErr err
 			}
 		}
 		if res.Content == nil {
-			dataJSON, err := json.Marshal(result.Data)
+			text, err := render.FormatText(result.Data, format)
 			switch {
 			case err != nil:
 				res.Content = []sdkmcp.Content{&sdkmcp.TextContent{Text: fmt.Sprintf("%v", result.Data)}}
-			case maxBytes > 0 && len(dataJSON) > maxBytes:
+			case maxBytes > 0 && len(text) > maxBytes:
 				if res.Meta == nil {
 					res.Meta = sdkmcp.Meta{}
 				}
 				res.Meta["truncated"] = true
-				res.Meta["originalBytes"] = len(dataJSON)
-				res.Content = []sdkmcp.Content{&sdkmcp.TextContent{Text: string(dataJSON[:maxBytes]) + truncationNotice}}
+				res.Meta["originalBytes"] = len(text)
+				res.Content = []sdkmcp.Content{&sdkmcp.TextContent{Text: text[:maxBytes] + truncationNotice}}
 			default:
-				res.Content = []sdkmcp.Content{&sdkmcp.TextContent{Text: string(dataJSON)}}
+				res.Content = []sdkmcp.Content{&sdkmcp.TextContent{Text: text}}
 			}
 		}
 	} else if res.Content == nil {
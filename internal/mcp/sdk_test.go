@@ -112,7 +112,7 @@ func TestBuildCallToolResultSuccess(t *testing.T) {
 		},
 	}
 	ctx := withTraceID(context.Background(), "trace-1")
-	out := buildCallToolResult(ctx, result, nil, 0)
+	out := buildCallToolResult(ctx, result, nil, 0, render.FormatJSON)
 	if out.StructuredContent == nil {
 		t.Fatalf("expected structured content")
 	}
@@ -127,7 +127,7 @@ func TestBuildCallToolResultSuccess(t *testing.T) {
 func TestBuildCallToolResultError(t *testing.T) {
 	err := errors.New("boom")
 	result := ToolResult{Data: map[string]any{"hint": "test"}}
-	out := buildCallToolResult(context.Background(), result, err, 0)
+	out := buildCallToolResult(context.Background(), result, err, 0, render.FormatJSON)
 	if !out.IsError {
 		t.Fatalf("expected error result")
 	}
@@ -141,17 +141,75 @@ func TestBuildCallToolResultError(t *testing.T) {
 }
 
 func TestBuildCallToolResultFallbacks(t *testing.T) {
-	out := buildCallToolResult(context.Background(), ToolResult{}, nil, 0)
+	out := buildCallToolResult(context.Background(), ToolResult{}, nil, 0, render.FormatJSON)
 	if out.Content == nil || len(out.Content) == 0 {
 		t.Fatalf("expected content for empty result")
 	}
 	result := ToolResult{Data: map[string]any{"bad": func() {}}}
-	out = buildCallToolResult(context.Background(), result, nil, 0)
+	out = buildCallToolResult(context.Background(), result, nil, 0, render.FormatJSON)
 	if out.Content == nil || len(out.Content) == 0 {
 		t.Fatalf("expected content fallback for marshal error")
 	}
 }
 
+func TestBuildCallToolResultMarkdown(t *testing.T) {
+	analysis := render.NewAnalysis()
+	analysis.AddCause("cause", "details", "high")
+	result := ToolResult{Data: render.NewRenderer().Render(analysis)}
+	out := buildCallToolResult(context.Background(), result, nil, 0, render.FormatMarkdown)
+	text, ok := out.Content[0].(*sdkmcp.TextContent)
+	if !ok || !strings.Contains(text.Text, "### cause (high)") {
+		t.Fatalf("expected markdown content, got %#v", out.Content)
+	}
+	if _, ok := out.StructuredContent.(map[string]any); !ok {
+		t.Fatalf("expected structured content to stay structured")
+	}
+}
+
+func TestProjectResult(t *testing.T) {
+	result := ToolResult{Data: map[string]any{
+		"count":     2,
+		"instances": []map[string]any{{"id": "i-1", "state": "running", "ami": "ami-1"}, {"id": "i-2", "state": "stopped"}},
+	}}
+	projected, unmatched := projectResult(result, []string{"instances[].id", "instances[].sate"})
+	if len(unmatched) != 1 || unmatched[0] != "instances[].sate" {
+		t.Fatalf("expected typo to be reported, got %#v", unmatched)
+	}
+	out := buildCallToolResult(context.Background(), projected, nil, 0, render.FormatJSON)
+	text := out.Content[0].(*sdkmcp.TextContent).Text
+	if text != `{"instances":[{"id":"i-1"},{"id":"i-2"}]}` {
+		t.Fatalf("unexpected projected text: %s", text)
+	}
+	if empty, _ := projectResult(ToolResult{}, []string{"a"}); empty.Data != nil {
+		t.Fatalf("expected empty result to stay empty")
+	}
+}
+
+func TestResultFormat(t *testing.T) {
+	cfg := config.DefaultConfig()
+	if format, err := resultFormat(map[string]any{}, &cfg); err != nil || format != render.FormatJSON {
+		t.Fatalf("expected json default, got %q (%v)", format, err)
+	}
+	cfg.Render.Format = "plain"
+	if format, err := resultFormat(map[string]any{}, &cfg); err != nil || format != render.FormatPlain {
+		t.Fatalf("expected configured plain, got %q (%v)", format, err)
+	}
+	if format, err := resultFormat(map[string]any{"outputFormat": "markdown"}, &cfg); err != nil || format != render.FormatMarkdown {
+		t.Fatalf("expected argument to win, got %q (%v)", format, err)
+	}
+	if _, err := resultFormat(map[string]any{"outputFormat": "xml"}, &cfg); err == nil {
+		t.Fatalf("expected error for unknown format")
+	}
+	if format, err := resultFormat(map[string]any{"format": "prometheus"}, &cfg); err != nil || format != render.FormatPlain {
+		t.Fatalf("expected a tool's own format argument to be ignored, got %q (%v)", format, err)
+	}
+	schema := schemaWithGlobalSkillTags(map[string]any{"properties": map[string]any{"format": map[string]any{"type": "string"}}})
+	props := schema["properties"].(map[string]any)
+	if props["format"].(map[string]any)["enum"] != nil || props["outputFormat"] == nil {
+		t.Fatalf("expected the tool's format property to be kept, got %#v", props)
+	}
+}
+
 func TestToolHandlerInvalidArgs(t *testing.T) {
 	cfg := config.DefaultConfig()
 	spec := ToolSpec{
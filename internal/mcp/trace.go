@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+
+	"rootcause/internal/logging"
 )
 
 type traceContextKey string
@@ -26,6 +28,13 @@ func ensureTraceContext(ctx context.Context) context.Context {
 	return ctx
 }
 
+// withCorrelationID tags ctx for logging with the trace id, so log lines
+// from a call (and the nested calls sharing its trace) match its audit events.
+func withCorrelationID(ctx context.Context) context.Context {
+	traceID, _ := traceIDFromContext(ctx)
+	return logging.WithCorrelationID(ctx, traceID)
+}
+
 func withTraceID(ctx context.Context, traceID string) context.Context {
 	if traceID == "" {
 		return ensureTraceContext(ctx)
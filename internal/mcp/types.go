@@ -5,11 +5,13 @@ import (
 
 	"github.com/xeipuuv/gojsonschema"
 
+	"rootcause/internal/archive"
 	"rootcause/internal/audit"
 	"rootcause/internal/cache"
 	"rootcause/internal/config"
 	"rootcause/internal/evidence"
 	"rootcause/internal/kube"
+	"rootcause/internal/notify"
 	"rootcause/internal/policy"
 	"rootcause/internal/redact"
 	"rootcause/internal/render"
@@ -76,6 +78,9 @@ func (s *ToolSpec) AugmentedSchema() map[string]any {
 		schema = map[string]any{"type": "object"}
 	}
 	s.augmentedCache = schemaWithGlobalSkillTags(schema)
+	if KubeContextToolset(s.ToolsetID) {
+		s.augmentedCache = schemaWithKubeContext(s.augmentedCache)
+	}
 	return s.augmentedCache
 }
 
@@ -150,6 +155,8 @@ type ToolContext struct {
 	Renderer  render.Renderer
 	Redactor  *redact.Redactor
 	Audit     *audit.Logger
+	Archive   *archive.Archiver
+	Notifier  *notify.Webhook
 	Cache     *cache.Store
 	CallGraph *CallGraph
 	Invoker   *ToolInvoker
@@ -0,0 +1,124 @@
+// Package notify alerts an external endpoint when a tool result reports a
+// severe likely root cause, so automated sweeps surface problems without
+// someone reading every transcript.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"rootcause/internal/render"
+)
+
+// Event is the JSON body posted to the webhook. Causes holds only the causes
+// at or above the configured severity, most severe first.
+type Event struct {
+	Timestamp time.Time      `json:"timestamp"`
+	UserID    string         `json:"userId"`
+	TraceID   string         `json:"traceId,omitempty"`
+	Tool      string         `json:"tool"`
+	Toolset   string         `json:"toolset"`
+	Verdict   render.Verdict `json:"verdict"`
+	Causes    []render.Cause `json:"causes"`
+}
+
+// DefaultMinSeverity is the threshold used when none is configured.
+const DefaultMinSeverity = "high"
+
+// maxInFlight bounds concurrent deliveries. Events past it are dropped
+// rather than queued so a dead endpoint can't pile up goroutines.
+const maxInFlight = 16
+
+type Webhook struct {
+	url         string
+	minSeverity string
+	client      *http.Client
+	slots       chan struct{}
+	wg          sync.WaitGroup
+}
+
+func NewWebhook(url, minSeverity string, timeout time.Duration) *Webhook {
+	if minSeverity == "" {
+		minSeverity = DefaultMinSeverity
+	}
+	return &Webhook{
+		url:         url,
+		minSeverity: minSeverity,
+		client:      &http.Client{Timeout: timeout},
+		slots:       make(chan struct{}, maxInFlight),
+	}
+}
+
+// Notify posts event in the background when any of its causes meets the
+// threshold. It never blocks the caller; delivery failures are logged.
+func (w *Webhook) Notify(ctx context.Context, event Event) {
+	if w == nil {
+		return
+	}
+	var causes []render.Cause
+	for _, cause := range event.Causes {
+		if render.SeverityAtLeast(cause.Severity, w.minSeverity) {
+			causes = append(causes, cause)
+		}
+	}
+	if len(causes) == 0 {
+		return
+	}
+	event.Causes = causes
+	select {
+	case w.slots <- struct{}{}:
+	default:
+		slog.WarnContext(ctx, "webhook notification dropped, too many in flight", "tool", event.Tool)
+		return
+	}
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		defer func() { <-w.slots }()
+		if err := w.post(context.WithoutCancel(ctx), event); err != nil {
+			slog.WarnContext(ctx, "webhook notification failed", "tool", event.Tool, "error", err)
+		}
+	}()
+}
+
+// Wait blocks until in-flight notifications have been delivered or failed.
+func (w *Webhook) Wait() {
+	if w == nil {
+		return
+	}
+	w.wg.Wait()
+}
+
+func (w *Webhook) post(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		// Webhook URLs often carry a token; keep it out of the logs.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return fmt.Errorf("%s webhook: %w", urlErr.Op, urlErr.Err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"rootcause/internal/render"
+)
+
+func TestWebhookPostsSevereCauses(t *testing.T) {
+	var mu sync.Mutex
+	var received []Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("decode event: %v", err)
+		}
+		mu.Lock()
+		received = append(received, event)
+		mu.Unlock()
+	}))
+	defer server.Close()
+	webhook := NewWebhook(server.URL, "", time.Second)
+
+	webhook.Notify(context.Background(), Event{Tool: "k8s.diagnose", UserID: "alice", Causes: []render.Cause{
+		{Summary: "CrashLoopBackOff", Severity: "critical"},
+		{Summary: "Missing limits", Severity: "low"},
+		{Summary: "Image pull failing", Severity: "high"},
+	}})
+	webhook.Notify(context.Background(), Event{Tool: "k8s.events", Causes: []render.Cause{{Summary: "Slow start", Severity: "medium"}}})
+	webhook.Wait()
+
+	if len(received) != 1 {
+		t.Fatalf("expected one notification, got %+v", received)
+	}
+	event := received[0]
+	if event.Tool != "k8s.diagnose" || event.UserID != "alice" || len(event.Causes) != 2 || event.Causes[1].Summary != "Image pull failing" {
+		t.Fatalf("unexpected event %+v", event)
+	}
+}
+
+func TestWebhookFailureHidesURL(t *testing.T) {
+	webhook := NewWebhook("http://127.0.0.1:1/hooks/secret-token", "low", time.Second)
+	err := webhook.post(context.Background(), Event{Tool: "k8s.diagnose"})
+	if err == nil || strings.Contains(err.Error(), "secret-token") {
+		t.Fatalf("expected an error without the URL, got %v", err)
+	}
+}
+
+func TestWebhookDropsWhenSaturated(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	webhook := NewWebhook(server.URL, "high", 5*time.Second)
+	event := Event{Tool: "k8s.diagnose", Causes: []render.Cause{{Summary: "down", Severity: "high"}}}
+
+	started := time.Now()
+	for i := 0; i < maxInFlight+4; i++ {
+		webhook.Notify(context.Background(), event)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("Notify blocked for %s", elapsed)
+	}
+	if len(webhook.slots) != maxInFlight {
+		t.Fatalf("expected %d deliveries in flight, got %d", maxInFlight, len(webhook.slots))
+	}
+	close(release)
+	webhook.Wait()
+}
+
+func TestNilWebhook(t *testing.T) {
+	var webhook *Webhook
+	webhook.Notify(context.Background(), Event{Causes: []render.Cause{{Severity: "critical"}}})
+	webhook.Wait()
+}
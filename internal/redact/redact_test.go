@@ -29,3 +29,11 @@ func TestRedactValueNested(t *testing.T) {
 		t.Fatalf("expected list entry redacted")
 	}
 }
+
+func TestRedactMapRedactsNextTokenKeys(t *testing.T) {
+	r := New()
+	out := r.RedactMap(map[string]any{"values": map[string]any{"nextToken": "abcdefghijklmnopqrstuvwxyz123456"}})
+	if out["values"].(map[string]any)["nextToken"] == "abcdefghijklmnopqrstuvwxyz123456" {
+		t.Fatalf("expected user data named nextToken to be redacted")
+	}
+}
@@ -0,0 +1,23 @@
+package render
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// AddAge sets ageSeconds and a kubectl-style age ("3d4h") on summary,
+// measured from created to now, so callers do not have to work out how old
+// something is from a raw timestamp. An unset created time adds nothing.
+func AddAge(summary map[string]any, created, now time.Time) map[string]any {
+	if summary == nil || created.IsZero() {
+		return summary
+	}
+	age := now.Sub(created)
+	if age < 0 {
+		age = 0
+	}
+	summary["ageSeconds"] = int64(age / time.Second)
+	summary["age"] = duration.HumanDuration(age)
+	return summary
+}
@@ -0,0 +1,22 @@
+package render
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddAge(t *testing.T) {
+	now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	summary := AddAge(map[string]any{"id": "i-1"}, now.Add(-(3*24*time.Hour + 4*time.Hour)), now)
+	if summary["ageSeconds"] != int64(3*24*3600+4*3600) || summary["age"] != "3d4h" {
+		t.Fatalf("unexpected age fields: %#v", summary)
+	}
+	future := AddAge(map[string]any{}, now.Add(time.Minute), now)
+	if future["ageSeconds"] != int64(0) {
+		t.Fatalf("expected clock skew to clamp to zero, got %#v", future)
+	}
+	unset := AddAge(map[string]any{"id": "vol-1"}, time.Time{}, now)
+	if _, ok := unset["age"]; ok {
+		t.Fatalf("expected no age for an unset time: %#v", unset)
+	}
+}
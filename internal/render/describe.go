@@ -2,7 +2,9 @@ package render
 
 import (
 	"context"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -10,17 +12,49 @@ import (
 	"rootcause/internal/redact"
 )
 
+// DefaultDescribeEvents is how many events DescribeAnalysis includes.
+const DefaultDescribeEvents = 10
+
+// DescribeOptions tunes the events section of DescribeAnalysis.
+type DescribeOptions struct {
+	// IncludeNormalEvents keeps Normal events alongside Warnings.
+	IncludeNormalEvents bool
+	// MaxEvents caps the events included; <= 0 means DefaultDescribeEvents.
+	MaxEvents int
+}
+
 func DescribeAnalysis(ctx context.Context, collector evidence.Collector, redactor *redact.Redactor, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) Analysis {
+	return DescribeAnalysisWithOptions(ctx, collector, redactor, gvr, obj, DescribeOptions{})
+}
+
+// DescribeAnalysisWithOptions is DescribeAnalysis with control over which of
+// the object's recent events are included. By default only the newest Warning
+// events are shown, since those are what triage hinges on.
+func DescribeAnalysisWithOptions(ctx context.Context, collector evidence.Collector, redactor *redact.Redactor, gvr schema.GroupVersionResource, obj *unstructured.Unstructured, opts DescribeOptions) Analysis {
 	analysis := NewAnalysis()
 	if obj == nil {
 		analysis.AddEvidence("status", "object not found")
 		return analysis
 	}
-	analysis.AddEvidence("object", redactObject(redactor, obj))
+	redacted := redactObject(redactor, obj)
+	analysis.AddEvidence("object", redacted)
 	if collector != nil {
-		events, err := collector.EventsForObject(ctx, obj)
-		if err == nil && len(events) > 0 {
-			analysis.AddEvidence("events", events)
+		// Custom resources get the same summary columns kubectl get prints;
+		// without printer columns the object alone has to speak for itself.
+		if source, ok := collector.(evidence.PrinterColumnSource); ok {
+			if columns, err := source.PrinterColumns(ctx, gvr); err == nil && len(columns) > 0 {
+				analysis.AddEvidence("printerColumns", evidence.PrinterColumnValues(columns, &unstructured.Unstructured{Object: redacted}, time.Now()))
+			}
+		}
+		events, err := evidence.RecentEvents(ctx, collector, obj.GetNamespace(), corev1.ObjectReference{
+			Kind: obj.GetKind(),
+			Name: obj.GetName(),
+			UID:  obj.GetUID(),
+		})
+		if err == nil {
+			if events = filterEvents(events, opts); len(events) > 0 {
+				analysis.AddEvidence("events", events)
+			}
 		}
 		owners, err := collector.OwnerChain(ctx, obj)
 		if err == nil && len(owners) > 0 {
@@ -32,6 +66,26 @@ func DescribeAnalysis(ctx context.Context, collector evidence.Collector, redacto
 	return analysis
 }
 
+// filterEvents keeps the newest opts.MaxEvents events, dropping Normal ones
+// unless asked for. events must already be sorted newest first.
+func filterEvents(events []corev1.Event, opts DescribeOptions) []corev1.Event {
+	limit := opts.MaxEvents
+	if limit <= 0 {
+		limit = DefaultDescribeEvents
+	}
+	var out []corev1.Event
+	for _, event := range events {
+		if !opts.IncludeNormalEvents && event.Type != corev1.EventTypeWarning {
+			continue
+		}
+		out = append(out, event)
+		if len(out) == limit {
+			break
+		}
+	}
+	return out
+}
+
 func redactObject(redactor *redact.Redactor, obj *unstructured.Unstructured) map[string]any {
 	if obj == nil {
 		return map[string]any{}
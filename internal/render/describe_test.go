@@ -10,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"rootcause/internal/evidence"
 	"rootcause/internal/redact"
 )
 
@@ -19,6 +20,14 @@ func (f *fakeCollector) EventsForObject(ctx context.Context, obj *unstructured.U
 	return []corev1.Event{{ObjectMeta: metav1.ObjectMeta{Name: "e1"}}}, nil
 }
 
+func (f *fakeCollector) RecentEvents(ctx context.Context, namespace string, involvedObject corev1.ObjectReference) ([]corev1.Event, error) {
+	return []corev1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "w2"}, Type: corev1.EventTypeWarning},
+		{ObjectMeta: metav1.ObjectMeta{Name: "n1"}, Type: corev1.EventTypeNormal},
+		{ObjectMeta: metav1.ObjectMeta{Name: "w1"}, Type: corev1.EventTypeWarning},
+	}, nil
+}
+
 func (f *fakeCollector) OwnerChain(ctx context.Context, obj *unstructured.Unstructured) ([]string, error) {
 	return []string{"Deployment/demo"}, nil
 }
@@ -74,3 +83,70 @@ func TestRedactObjectSecretStringData(t *testing.T) {
 		t.Fatalf("expected stringData redacted")
 	}
 }
+
+func TestDescribeAnalysisEventsWarningOnlyByDefault(t *testing.T) {
+	pod := &unstructured.Unstructured{}
+	pod.SetKind("Pod")
+	pod.SetNamespace("default")
+	pod.SetName("demo")
+	gvr := schema.GroupVersionResource{Resource: "pods"}
+	eventNames := func(analysis Analysis) []string {
+		for _, item := range analysis.Evidence {
+			if item.Summary != "events" {
+				continue
+			}
+			var names []string
+			for _, event := range item.Details.([]corev1.Event) {
+				names = append(names, event.Name)
+			}
+			return names
+		}
+		return nil
+	}
+
+	names := eventNames(DescribeAnalysis(context.Background(), &fakeCollector{}, redact.New(), gvr, pod))
+	if len(names) != 2 || names[0] != "w2" || names[1] != "w1" {
+		t.Fatalf("expected newest warnings only, got %v", names)
+	}
+	names = eventNames(DescribeAnalysisWithOptions(context.Background(), &fakeCollector{}, redact.New(), gvr, pod, DescribeOptions{IncludeNormalEvents: true, MaxEvents: 2}))
+	if len(names) != 2 || names[0] != "w2" || names[1] != "n1" {
+		t.Fatalf("expected normal events and limit honored, got %v", names)
+	}
+}
+
+type printerColumnCollector struct {
+	fakeCollector
+}
+
+func (p *printerColumnCollector) PrinterColumns(ctx context.Context, gvr schema.GroupVersionResource) ([]evidence.PrinterColumn, error) {
+	if gvr.Group != "networking.istio.io" {
+		return nil, nil
+	}
+	return []evidence.PrinterColumn{{Name: "Hosts", Type: "string", JSONPath: ".spec.hosts"}}, nil
+}
+
+func TestDescribeAnalysisPrinterColumns(t *testing.T) {
+	vs := &unstructured.Unstructured{Object: map[string]any{
+		"kind":     "VirtualService",
+		"metadata": map[string]any{"name": "reviews", "namespace": "default"},
+		"spec":     map[string]any{"hosts": []any{"reviews"}},
+	}}
+	gvr := schema.GroupVersionResource{Group: "networking.istio.io", Version: "v1beta1", Resource: "virtualservices"}
+	analysis := DescribeAnalysis(context.Background(), &printerColumnCollector{}, redact.New(), gvr, vs)
+	var columns []evidence.PrinterColumnValue
+	for _, item := range analysis.Evidence {
+		if item.Summary == "printerColumns" {
+			columns = item.Details.([]evidence.PrinterColumnValue)
+		}
+	}
+	if len(columns) != 1 || columns[0].Name != "Hosts" || columns[0].Value != `["reviews"]` {
+		t.Fatalf("unexpected printer columns: %#v", columns)
+	}
+
+	analysis = DescribeAnalysis(context.Background(), &printerColumnCollector{}, redact.New(), schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, vs)
+	for _, item := range analysis.Evidence {
+		if item.Summary == "printerColumns" {
+			t.Fatalf("expected no printer columns without a CRD")
+		}
+	}
+}
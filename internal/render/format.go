@@ -0,0 +1,197 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Format selects how a rendered analysis is presented as text. The
+// structured result is the same for every format; only the text content
+// handed to the client changes.
+type Format string
+
+const (
+	// FormatJSON is compact JSON, the default and the best fit for agents.
+	FormatJSON Format = "json"
+	// FormatMarkdown renders causes as headings, evidence as a table and
+	// next checks as a list, for humans reading the result directly.
+	FormatMarkdown Format = "markdown"
+	// FormatPlain is markdown without markup, for terminals and logs.
+	FormatPlain Format = "plain"
+)
+
+// Formats lists the accepted format names in schema order.
+var Formats = []string{string(FormatJSON), string(FormatMarkdown), string(FormatPlain)}
+
+// ParseFormat validates a format name. An empty name selects FormatJSON.
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(value))) {
+	case "", FormatJSON:
+		return FormatJSON, nil
+	case FormatMarkdown, "md":
+		return FormatMarkdown, nil
+	case FormatPlain, "text":
+		return FormatPlain, nil
+	}
+	return "", fmt.Errorf("unknown format %q (expected one of %s)", value, strings.Join(Formats, ", "))
+}
+
+// FormatText renders data, usually the output of Renderer.Render, as text in
+// the given format. Data that does not have the analysis shape is always
+// returned as JSON since there are no sections to lay out.
+func FormatText(data any, format Format) (string, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	if format == FormatJSON || format == "" {
+		return string(raw), nil
+	}
+	analysis, ok := analysisFromJSON(raw)
+	if !ok {
+		return string(raw), nil
+	}
+	if format == FormatPlain {
+		return analysis.Plain(), nil
+	}
+	return analysis.Markdown(), nil
+}
+
+func analysisFromJSON(raw []byte) (Analysis, bool) {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &keys); err != nil {
+		return Analysis{}, false
+	}
+	for _, key := range []string{"likelyRootCauses", "evidence", "recommendedNextChecks"} {
+		if _, ok := keys[key]; !ok {
+			return Analysis{}, false
+		}
+	}
+	var analysis Analysis
+	if err := json.Unmarshal(raw, &analysis); err != nil {
+		return Analysis{}, false
+	}
+	return analysis, true
+}
+
+// Markdown renders the analysis for humans.
+func (a Analysis) Markdown() string {
+	var b strings.Builder
+	b.WriteString("**Verdict:** " + verdictText(a.Verdict()) + "\n\n")
+	b.WriteString("## Likely root causes\n\n")
+	if len(a.LikelyRootCauses) == 0 {
+		b.WriteString("None identified.\n\n")
+	}
+	for _, cause := range a.RankedCauses() {
+		b.WriteString("### " + cause.Summary)
+		if qualifier := causeQualifier(cause); qualifier != "" {
+			b.WriteString(" (" + qualifier + ")")
+		}
+		b.WriteString("\n\n")
+		if cause.Details != "" {
+			b.WriteString(cause.Details + "\n\n")
+		}
+	}
+	if len(a.Evidence) > 0 {
+		b.WriteString("## Evidence\n\n| Item | Details |\n| --- | --- |\n")
+		for _, item := range a.Evidence {
+			b.WriteString("| " + markdownCell(item.Summary) + " | " + markdownCell(evidenceText(item.Details)) + " |\n")
+		}
+		b.WriteString("\n")
+	}
+	writeMarkdownList(&b, "Recommended next checks", a.RecommendedNextChecks)
+	writeMarkdownList(&b, "Resources examined", a.ResourcesExamined)
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// Plain renders the analysis as unadorned text.
+func (a Analysis) Plain() string {
+	var b strings.Builder
+	b.WriteString("Verdict: " + verdictText(a.Verdict()) + "\n\n")
+	b.WriteString("Likely root causes:\n")
+	if len(a.LikelyRootCauses) == 0 {
+		b.WriteString("  none identified\n")
+	}
+	for _, cause := range a.RankedCauses() {
+		line := "  - " + cause.Summary
+		if qualifier := causeQualifier(cause); qualifier != "" {
+			line += " [" + qualifier + "]"
+		}
+		if cause.Details != "" {
+			line += ": " + cause.Details
+		}
+		b.WriteString(line + "\n")
+	}
+	if len(a.Evidence) > 0 {
+		b.WriteString("\nEvidence:\n")
+		for _, item := range a.Evidence {
+			b.WriteString("  - " + item.Summary)
+			if text := evidenceText(item.Details); text != "" {
+				b.WriteString(": " + text)
+			}
+			b.WriteString("\n")
+		}
+	}
+	writePlainList(&b, "Recommended next checks", a.RecommendedNextChecks)
+	writePlainList(&b, "Resources examined", a.ResourcesExamined)
+	return b.String()
+}
+
+func verdictText(verdict Verdict) string {
+	if verdict.HighestSeverity == "" {
+		return verdict.Status
+	}
+	return fmt.Sprintf("%s (highest severity %s)", verdict.Status, verdict.HighestSeverity)
+}
+
+func causeQualifier(cause Cause) string {
+	parts := make([]string, 0, 2)
+	if cause.Severity != "" {
+		parts = append(parts, cause.Severity)
+	}
+	if cause.Confidence != "" {
+		parts = append(parts, cause.Confidence+" confidence")
+	}
+	return strings.Join(parts, ", ")
+}
+
+func evidenceText(details any) string {
+	switch v := details.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	raw, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Sprintf("%v", details)
+	}
+	return string(raw)
+}
+
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", "<br>")
+}
+
+func writeMarkdownList(b *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	b.WriteString("## " + title + "\n\n")
+	for _, item := range items {
+		b.WriteString("- " + item + "\n")
+	}
+	b.WriteString("\n")
+}
+
+func writePlainList(b *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	b.WriteString("\n" + title + ":\n")
+	for _, item := range items {
+		b.WriteString("  - " + item + "\n")
+	}
+}
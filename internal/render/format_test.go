@@ -0,0 +1,77 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	cases := map[string]Format{"": FormatJSON, "JSON": FormatJSON, "md": FormatMarkdown, "markdown": FormatMarkdown, "plain": FormatPlain, "text": FormatPlain}
+	for input, want := range cases {
+		got, err := ParseFormat(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseFormat(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("yaml"); err == nil {
+		t.Fatalf("expected error for unknown format")
+	}
+}
+
+func TestFormatText(t *testing.T) {
+	analysis := NewAnalysis()
+	analysis.AddCause("Pod OOMKilled", "app exceeded 128Mi", "high")
+	analysis.AddEvidence("pod", map[string]any{"phase": "Running"})
+	analysis.AddEvidence("note", "a|b")
+	analysis.AddNextCheck("Raise the memory limit")
+	analysis.AddResource("pods/default/demo")
+	data := NewRenderer().Render(analysis)
+
+	markdown, err := FormatText(data, FormatMarkdown)
+	if err != nil {
+		t.Fatalf("markdown: %v", err)
+	}
+	for _, want := range []string{"**Verdict:** critical (highest severity high)", "### Pod OOMKilled (high)", "| pod | {\"phase\":\"Running\"} |", "| note | a\\|b |", "- Raise the memory limit", "- pods/default/demo"} {
+		if !strings.Contains(markdown, want) {
+			t.Fatalf("markdown missing %q:\n%s", want, markdown)
+		}
+	}
+
+	plain, err := FormatText(data, FormatPlain)
+	if err != nil {
+		t.Fatalf("plain: %v", err)
+	}
+	if !strings.Contains(plain, "Verdict: critical (highest severity high)") || !strings.Contains(plain, "  - Pod OOMKilled [high]: app exceeded 128Mi") || strings.Contains(plain, "#") {
+		t.Fatalf("unexpected plain output:\n%s", plain)
+	}
+
+	compact, err := FormatText(data, FormatJSON)
+	if err != nil || !strings.HasPrefix(compact, "{") || strings.Contains(compact, "\n") {
+		t.Fatalf("expected compact json, got %q (%v)", compact, err)
+	}
+
+	raw, err := FormatText(map[string]any{"kind": "Pod"}, FormatMarkdown)
+	if err != nil || raw != `{"kind":"Pod"}` {
+		t.Fatalf("expected non-analysis data as json, got %q (%v)", raw, err)
+	}
+}
+
+func TestFormatTextConfidence(t *testing.T) {
+	analysis := NewAnalysis()
+	analysis.AddCauseWithConfidence("Missing ServiceEntry", "api.example.com", "medium", ConfidenceHigh)
+	analysis.AddCauseWithConfidence("Unresolved host", "", "", ConfidenceLow)
+	data := NewRenderer().Render(analysis)
+
+	markdown, _ := FormatText(data, FormatMarkdown)
+	if !strings.Contains(markdown, "### Missing ServiceEntry (medium, high confidence)") || !strings.Contains(markdown, "### Unresolved host (low confidence)") {
+		t.Fatalf("expected confidence in markdown:\n%s", markdown)
+	}
+	plain, _ := FormatText(data, FormatPlain)
+	if !strings.Contains(plain, "Missing ServiceEntry [medium, high confidence]") {
+		t.Fatalf("expected confidence in plain output:\n%s", plain)
+	}
+	compact, _ := FormatText(data, FormatJSON)
+	if !strings.Contains(compact, `"confidence":"high"`) {
+		t.Fatalf("expected confidence in json: %s", compact)
+	}
+}
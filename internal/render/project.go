@@ -0,0 +1,185 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ParseFields reads a `fields` argument: a list of paths or one
+// comma-separated string. Each path is a dot-separated list of keys, e.g.
+// "instances[].id"; "[]" (or "[*]") steps into an array, and arrays are also
+// stepped into implicitly when a key follows them. Keys may be glob patterns
+// such as "*" or "*Id".
+func ParseFields(value any) ([]string, error) {
+	var raw []string
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		raw = strings.Split(v, ",")
+	case []string:
+		raw = v
+	case []any:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("fields must be a list of strings, got %T", item)
+			}
+			raw = append(raw, s)
+		}
+	default:
+		return nil, fmt.Errorf("fields must be a string or a list of strings, got %T", value)
+	}
+	fields := make([]string, 0, len(raw))
+	for _, field := range raw {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		for _, token := range fieldTokens(field) {
+			if token == "[]" {
+				continue
+			}
+			if _, err := path.Match(token, ""); err != nil {
+				return nil, fmt.Errorf("invalid field %q: %v", field, err)
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// Project keeps only the parts of data selected by fields, preserving the
+// surrounding structure so the result still reads like the full payload.
+// It also returns the fields that matched nothing, which usually means a
+// typo in the path. Data is normalized through JSON first, so structs are
+// addressed by their JSON names.
+func Project(data any, fields []string) (any, []string, error) {
+	if len(fields) == 0 {
+		return data, nil, nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, nil, err
+	}
+	var out any
+	var unmatched []string
+	for _, field := range fields {
+		projected, ok := projectTokens(value, fieldTokens(field))
+		if !ok {
+			unmatched = append(unmatched, field)
+			continue
+		}
+		out = mergeProjection(out, projected)
+	}
+	if out == nil {
+		if _, isList := value.([]any); isList {
+			out = []any{}
+		} else {
+			out = map[string]any{}
+		}
+	}
+	return out, unmatched, nil
+}
+
+// fieldTokens splits "a.b[].c" into ["a", "b", "[]", "c"].
+func fieldTokens(field string) []string {
+	field = strings.ReplaceAll(field, "[*]", "[]")
+	var tokens []string
+	for _, part := range strings.Split(field, ".") {
+		depth := 0
+		for strings.HasSuffix(part, "[]") {
+			part = strings.TrimSuffix(part, "[]")
+			depth++
+		}
+		if part != "" {
+			tokens = append(tokens, part)
+		}
+		for range depth {
+			tokens = append(tokens, "[]")
+		}
+	}
+	return tokens
+}
+
+func projectTokens(value any, tokens []string) (any, bool) {
+	if len(tokens) == 0 {
+		return value, true
+	}
+	switch v := value.(type) {
+	case []any:
+		rest := tokens
+		if tokens[0] == "[]" {
+			rest = tokens[1:]
+		}
+		out := make([]any, len(v))
+		matched := false
+		for i, item := range v {
+			if projected, ok := projectTokens(item, rest); ok {
+				out[i] = projected
+				matched = true
+			} else if _, isMap := item.(map[string]any); isMap {
+				out[i] = map[string]any{}
+			}
+		}
+		return out, matched
+	case map[string]any:
+		if tokens[0] == "[]" {
+			return nil, false
+		}
+		out := map[string]any{}
+		for key, item := range v {
+			if ok, _ := path.Match(tokens[0], key); !ok {
+				continue
+			}
+			if projected, ok := projectTokens(item, tokens[1:]); ok {
+				out[key] = projected
+			}
+		}
+		return out, len(out) > 0
+	default:
+		return nil, false
+	}
+}
+
+// mergeProjection combines the projections of two paths over the same data,
+// so "items[].id" and "items[].state" yield one list of {id, state}.
+func mergeProjection(a, b any) any {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			return b
+		}
+		for key, value := range bv {
+			av[key] = mergeProjection(av[key], value)
+		}
+		return av
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return b
+		}
+		for i := range av {
+			av[i] = mergeProjection(av[i], bv[i])
+		}
+		return av
+	default:
+		return b
+	}
+}
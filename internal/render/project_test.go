@@ -0,0 +1,69 @@
+package render
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields("instances[].id, instances[].state")
+	if err != nil || len(fields) != 2 || fields[1] != "instances[].state" {
+		t.Fatalf("unexpected fields %#v (%v)", fields, err)
+	}
+	fields, err = ParseFields([]any{"a.b", " "})
+	if err != nil || len(fields) != 1 {
+		t.Fatalf("unexpected fields %#v (%v)", fields, err)
+	}
+	if fields, err := ParseFields(nil); err != nil || fields != nil {
+		t.Fatalf("expected no fields, got %#v (%v)", fields, err)
+	}
+	if _, err := ParseFields([]any{1}); err == nil {
+		t.Fatalf("expected error for non-string field")
+	}
+	if _, err := ParseFields("items[].[bad"); err == nil {
+		t.Fatalf("expected error for bad pattern")
+	}
+}
+
+func TestProject(t *testing.T) {
+	type instance struct {
+		ID    string            `json:"id"`
+		State string            `json:"state"`
+		Tags  map[string]string `json:"tags"`
+	}
+	data := map[string]any{
+		"region": "us-east-1",
+		"instances": []instance{
+			{ID: "i-1", State: "running", Tags: map[string]string{"Name": "web", "team": "a"}},
+			{ID: "i-2", State: "stopped"},
+		},
+	}
+	out, unmatched, err := Project(data, []string{"instances[].id", "instances[*].state", "instances.tags.Na*", "missing"})
+	if err != nil {
+		t.Fatalf("project: %v", err)
+	}
+	if len(unmatched) != 1 || unmatched[0] != "missing" {
+		t.Fatalf("unexpected unmatched fields: %#v", unmatched)
+	}
+	raw, _ := json.Marshal(out)
+	want := `{"instances":[{"id":"i-1","state":"running","tags":{"Name":"web"}},{"id":"i-2","state":"stopped"}]}`
+	if string(raw) != want {
+		t.Fatalf("unexpected projection:\n got %s\nwant %s", raw, want)
+	}
+
+	out, unmatched, err = Project([]any{map[string]any{"name": "a", "size": 1}}, []string{"[].name"})
+	if err != nil || len(unmatched) != 0 {
+		t.Fatalf("project list: %v %#v", err, unmatched)
+	}
+	if raw, _ := json.Marshal(out); string(raw) != `[{"name":"a"}]` {
+		t.Fatalf("unexpected list projection: %s", raw)
+	}
+
+	out, _, _ = Project(data, []string{"nothing"})
+	if m, ok := out.(map[string]any); !ok || len(m) != 0 {
+		t.Fatalf("expected empty object when nothing matches, got %#v", out)
+	}
+	if out, _, _ := Project(data, nil); out == nil {
+		t.Fatalf("expected data unchanged without fields")
+	}
+}
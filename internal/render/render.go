@@ -1,6 +1,9 @@
 package render
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 type Analysis struct {
 	LikelyRootCauses      []Cause        `json:"likelyRootCauses"`
@@ -14,6 +17,33 @@ type Cause struct {
 	Summary  string `json:"summary"`
 	Details  string `json:"details,omitempty"`
 	Severity string `json:"severity,omitempty"`
+	// Confidence says how sure the tool is that this is the cause, as
+	// opposed to how bad it would be (Severity). Empty means unstated.
+	Confidence string `json:"confidence,omitempty"`
+}
+
+// Confidence levels for Cause.Confidence. High means the evidence directly
+// shows the problem; low means it was inferred from naming or absence.
+const (
+	ConfidenceHigh   = "high"
+	ConfidenceMedium = "medium"
+	ConfidenceLow    = "low"
+)
+
+// Verdict statuses. Critical means at least one critical or high severity
+// cause; degraded means causes were found but none that severe.
+const (
+	StatusHealthy  = "healthy"
+	StatusDegraded = "degraded"
+	StatusCritical = "critical"
+)
+
+// Verdict is the single top-line signal for an analysis, derived from its
+// most severe cause.
+type Verdict struct {
+	Status          string `json:"status"`
+	HighestSeverity string `json:"highestSeverity,omitempty"`
+	CauseCount      int    `json:"causeCount"`
 }
 
 type EvidenceItem struct {
@@ -31,14 +61,9 @@ func NewRenderer() *JSONRenderer {
 	return &JSONRenderer{}
 }
 
+// Render emits the analysis as its Report, in map form.
 func (r *JSONRenderer) Render(analysis Analysis) map[string]any {
-	return map[string]any{
-		"likelyRootCauses":      analysis.LikelyRootCauses,
-		"evidence":              analysis.Evidence,
-		"recommendedNextChecks": analysis.RecommendedNextChecks,
-		"resourcesExamined":     analysis.ResourcesExamined,
-		"generatedAt":           analysis.GeneratedAt,
-	}
+	return analysis.Report().Map()
 }
 
 func NewAnalysis() Analysis {
@@ -49,6 +74,78 @@ func (a *Analysis) AddCause(summary, details, severity string) {
 	a.LikelyRootCauses = append(a.LikelyRootCauses, Cause{Summary: summary, Details: details, Severity: severity})
 }
 
+// AddCauseWithConfidence records a cause along with how certain the tool is
+// of it, so callers combining several results can weight findings.
+func (a *Analysis) AddCauseWithConfidence(summary, details, severity, confidence string) {
+	a.LikelyRootCauses = append(a.LikelyRootCauses, Cause{Summary: summary, Details: details, Severity: severity, Confidence: confidence})
+}
+
+// RankedCauses returns the causes most severe first, with identical
+// summary/details pairs collapsed into one. Causes of equal severity keep
+// the order they were added in. LikelyRootCauses itself is left untouched.
+func (a Analysis) RankedCauses() []Cause {
+	if a.LikelyRootCauses == nil {
+		return nil
+	}
+	out := make([]Cause, 0, len(a.LikelyRootCauses))
+	seen := map[Cause]int{}
+	for _, cause := range a.LikelyRootCauses {
+		key := Cause{Summary: cause.Summary, Details: cause.Details}
+		if idx, ok := seen[key]; ok {
+			if severityRank(cause.Severity) < severityRank(out[idx].Severity) {
+				out[idx].Severity = cause.Severity
+			}
+			if out[idx].Confidence == "" {
+				out[idx].Confidence = cause.Confidence
+			}
+			continue
+		}
+		seen[key] = len(out)
+		out = append(out, cause)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return severityRank(out[i].Severity) < severityRank(out[j].Severity)
+	})
+	return out
+}
+
+// Verdict rolls the causes up into one status: healthy with no causes,
+// critical when the worst is critical or high, degraded otherwise. Causes
+// without a severity still count as degraded since something was found.
+func (a Analysis) Verdict() Verdict {
+	causes := a.RankedCauses()
+	if len(causes) == 0 {
+		return Verdict{Status: StatusHealthy}
+	}
+	worst := causes[0].Severity
+	status := StatusDegraded
+	if severityRank(worst) <= severityRank("high") {
+		status = StatusCritical
+	}
+	return Verdict{Status: status, HighestSeverity: worst, CauseCount: len(causes)}
+}
+
+// SeverityAtLeast reports whether severity is threshold or worse. Causes
+// without a recognized severity never meet a threshold.
+func SeverityAtLeast(severity, threshold string) bool {
+	rank := severityRank(severity)
+	return rank < severityRank("") && rank <= severityRank(threshold)
+}
+
+func severityRank(severity string) int {
+	switch severity {
+	case "critical":
+		return 0
+	case "high":
+		return 1
+	case "medium", "warning":
+		return 2
+	case "low":
+		return 3
+	}
+	return 4
+}
+
 func (a *Analysis) AddEvidence(summary string, details any) {
 	a.Evidence = append(a.Evidence, EvidenceItem{Summary: summary, Details: details})
 }
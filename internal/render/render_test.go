@@ -24,3 +24,82 @@ func TestRenderAnalysis(t *testing.T) {
 		t.Fatalf("expected resourcesExamined")
 	}
 }
+
+func TestRenderRanksAndDedupesCauses(t *testing.T) {
+	analysis := NewAnalysis()
+	analysis.AddCause("proxy not ready", "default/a", "medium")
+	analysis.AddCause("unknown", "", "")
+	analysis.AddCause("cleanup", "", "low")
+	analysis.AddCause("OOMKilled", "default/b", "high")
+	analysis.AddCause("proxy not ready", "default/a", "medium")
+	analysis.AddCause("proxy not ready", "default/c", "medium")
+	analysis.AddCause("CrashLoopBackOff", "", "warning")
+	analysis.AddCause("OOMKilled", "default/b", "high")
+
+	out := NewRenderer().Render(analysis)
+	causes, ok := out["likelyRootCauses"].([]Cause)
+	if !ok {
+		t.Fatalf("expected []Cause, got %T", out["likelyRootCauses"])
+	}
+	want := []string{"OOMKilled default/b", "proxy not ready default/a", "proxy not ready default/c", "CrashLoopBackOff ", "cleanup ", "unknown "}
+	if len(causes) != len(want) {
+		t.Fatalf("expected %d causes, got %#v", len(want), causes)
+	}
+	for i, cause := range causes {
+		if got := cause.Summary + " " + cause.Details; got != want[i] {
+			t.Fatalf("cause %d: expected %q, got %q", i, want[i], got)
+		}
+	}
+	if len(analysis.LikelyRootCauses) != 8 {
+		t.Fatalf("expected analysis causes to be left as added")
+	}
+}
+
+func TestAnalysisVerdict(t *testing.T) {
+	cases := []struct {
+		name     string
+		severity []string
+		want     Verdict
+	}{
+		{"no causes", nil, Verdict{Status: StatusHealthy}},
+		{"low only", []string{"low"}, Verdict{Status: StatusDegraded, HighestSeverity: "low", CauseCount: 1}},
+		{"medium beats low", []string{"low", "medium"}, Verdict{Status: StatusDegraded, HighestSeverity: "medium", CauseCount: 2}},
+		{"unstated severity", []string{""}, Verdict{Status: StatusDegraded, CauseCount: 1}},
+		{"high", []string{"medium", "high"}, Verdict{Status: StatusCritical, HighestSeverity: "high", CauseCount: 2}},
+		{"critical", []string{"critical", "warning"}, Verdict{Status: StatusCritical, HighestSeverity: "critical", CauseCount: 2}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			analysis := NewAnalysis()
+			for i, severity := range tc.severity {
+				analysis.AddCause("cause", string(rune('a'+i)), severity)
+			}
+			if got := analysis.Verdict(); got != tc.want {
+				t.Fatalf("verdict = %+v, want %+v", got, tc.want)
+			}
+			if got := NewRenderer().Render(analysis)["verdict"]; got != tc.want {
+				t.Fatalf("rendered verdict = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSeverityAtLeast(t *testing.T) {
+	cases := []struct {
+		severity, threshold string
+		want                bool
+	}{
+		{"critical", "high", true},
+		{"high", "high", true},
+		{"medium", "high", false},
+		{"warning", "medium", true},
+		{"low", "low", true},
+		{"", "low", false},
+		{"unknown", "low", false},
+	}
+	for _, tc := range cases {
+		if got := SeverityAtLeast(tc.severity, tc.threshold); got != tc.want {
+			t.Fatalf("SeverityAtLeast(%q, %q) = %v, want %v", tc.severity, tc.threshold, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package render
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SchemaVersion identifies the shape of Report. It changes only when a field
+// is removed, renamed or changes type; new optional fields keep the version.
+const SchemaVersion = "1"
+
+// Report is the stable, machine-readable form of an Analysis and the shape
+// JSONRenderer emits. Automation should decode tool results into Report
+// (see ParseReport) rather than poking at the map keys.
+type Report struct {
+	SchemaVersion         string         `json:"schemaVersion"`
+	Verdict               Verdict        `json:"verdict"`
+	LikelyRootCauses      []Cause        `json:"likelyRootCauses"`
+	Evidence              []EvidenceItem `json:"evidence"`
+	RecommendedNextChecks []string       `json:"recommendedNextChecks"`
+	ResourcesExamined     []string       `json:"resourcesExamined"`
+	GeneratedAt           time.Time      `json:"generatedAt"`
+}
+
+// Report freezes the analysis into its published form: causes ranked and
+// deduplicated, with the verdict computed from them.
+func (a Analysis) Report() Report {
+	return Report{
+		SchemaVersion:         SchemaVersion,
+		Verdict:               a.Verdict(),
+		LikelyRootCauses:      a.RankedCauses(),
+		Evidence:              a.Evidence,
+		RecommendedNextChecks: a.RecommendedNextChecks,
+		ResourcesExamined:     a.ResourcesExamined,
+		GeneratedAt:           a.GeneratedAt,
+	}
+}
+
+// Map returns the report keyed by its JSON field names with the typed values
+// left in place, which is what tool results carry in memory. It marshals to
+// the same JSON as the report itself.
+func (r Report) Map() map[string]any {
+	return map[string]any{
+		"schemaVersion":         r.SchemaVersion,
+		"verdict":               r.Verdict,
+		"likelyRootCauses":      r.LikelyRootCauses,
+		"evidence":              r.Evidence,
+		"recommendedNextChecks": r.RecommendedNextChecks,
+		"resourcesExamined":     r.ResourcesExamined,
+		"generatedAt":           r.GeneratedAt,
+	}
+}
+
+// ParseReport decodes a rendered analysis. It rejects JSON without a
+// schemaVersion and reports written by a different schema version, so
+// callers notice a contract change instead of reading zero values.
+func ParseReport(data []byte) (Report, error) {
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return Report{}, err
+	}
+	switch report.SchemaVersion {
+	case SchemaVersion:
+		return report, nil
+	case "":
+		return Report{}, errors.New("not an analysis report: schemaVersion missing")
+	}
+	return Report{}, fmt.Errorf("unsupported analysis schemaVersion %q (expected %s)", report.SchemaVersion, SchemaVersion)
+}
@@ -0,0 +1,78 @@
+package render
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func sampleReportAnalysis() Analysis {
+	analysis := Analysis{GeneratedAt: time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)}
+	analysis.AddCause("Pod OOMKilled", "app exceeded 128Mi", "high")
+	analysis.AddCauseWithConfidence("Limit below usage", "", "medium", ConfidenceMedium)
+	analysis.AddEvidence("pod", map[string]any{"phase": "Running", "restarts": float64(3)})
+	analysis.AddEvidence("note", "text evidence")
+	analysis.AddNextCheck("Raise the memory limit")
+	analysis.AddResource("pods/default/demo")
+	return analysis
+}
+
+func TestReportRoundTrip(t *testing.T) {
+	analysis := sampleReportAnalysis()
+	rendered := NewRenderer().Render(analysis)
+	raw, err := json.Marshal(rendered)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	report, err := ParseReport(raw)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if want := analysis.Report(); !reflect.DeepEqual(report, want) {
+		t.Fatalf("round trip mismatch:\n got %#v\nwant %#v", report, want)
+	}
+	if report.SchemaVersion != SchemaVersion || report.Verdict.Status != StatusCritical || len(report.LikelyRootCauses) != 2 {
+		t.Fatalf("unexpected report: %#v", report)
+	}
+
+	again, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("marshal report: %v", err)
+	}
+	var fromReport, fromMap map[string]any
+	_ = json.Unmarshal(again, &fromReport)
+	_ = json.Unmarshal(raw, &fromMap)
+	if !reflect.DeepEqual(fromReport, fromMap) {
+		t.Fatalf("report and rendered map marshal differently:\n%s\n%s", again, raw)
+	}
+}
+
+func TestReportMapMatchesStructFields(t *testing.T) {
+	fields := map[string]bool{}
+	reportType := reflect.TypeOf(Report{})
+	for i := 0; i < reportType.NumField(); i++ {
+		fields[reportType.Field(i).Tag.Get("json")] = true
+	}
+	rendered := sampleReportAnalysis().Report().Map()
+	if len(rendered) != len(fields) {
+		t.Fatalf("map has %d keys, struct has %d fields", len(rendered), len(fields))
+	}
+	for key := range rendered {
+		if !fields[key] {
+			t.Fatalf("map key %q is not a Report field", key)
+		}
+	}
+}
+
+func TestParseReportRejectsUnknownShapes(t *testing.T) {
+	if _, err := ParseReport([]byte(`{"kind":"Pod"}`)); err == nil {
+		t.Fatalf("expected error without schemaVersion")
+	}
+	if _, err := ParseReport([]byte(`{"schemaVersion":"99"}`)); err == nil {
+		t.Fatalf("expected error for unsupported schemaVersion")
+	}
+	if _, err := ParseReport([]byte(`not json`)); err == nil {
+		t.Fatalf("expected error for invalid json")
+	}
+}
@@ -64,6 +64,13 @@ type Collector = evidence.Collector
 
 type Renderer = render.Renderer
 
+// Report is the versioned shape Renderer output decodes into.
+type Report = render.Report
+
+func ParseReport(data []byte) (Report, error) {
+	return render.ParseReport(data)
+}
+
 type Redactor = redact.Redactor
 
 func ResolveResource(mapper meta.RESTMapper, apiVersion, kind, resource string) (schema.GroupVersionResource, bool, error) {
@@ -78,6 +85,12 @@ func DescribeAnalysis(ctx context.Context, collector evidence.Collector, redacto
 	return render.DescribeAnalysis(ctx, collector, redactor, gvr, obj)
 }
 
+type DescribeOptions = render.DescribeOptions
+
+func DescribeAnalysisWithOptions(ctx context.Context, collector evidence.Collector, redactor *redact.Redactor, gvr schema.GroupVersionResource, obj *unstructured.Unstructured, opts DescribeOptions) render.Analysis {
+	return render.DescribeAnalysisWithOptions(ctx, collector, redactor, gvr, obj, opts)
+}
+
 // Policy helpers.
 type User = policy.User
 
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"rootcause/internal/config"
+	rcmcp "rootcause/internal/mcp"
+)
+
+// runPreflight initializes each enabled toolset on its own and runs its
+// connectivity check, so a toolset that cannot start is reported instead of
+// aborting the whole run the way server startup does.
+func runPreflight(ctx context.Context, cfg config.Config, errOut, out io.Writer) error {
+	toolCtx, reg := newToolContext(cfg, errOut, nil)
+	toolsets := effectiveToolsets(cfg.Toolsets)
+	results := make([]rcmcp.PreflightResult, 0, len(toolsets))
+	for _, id := range toolsets {
+		factory, ok := rcmcp.ToolsetFactoryFor(id)
+		if !ok {
+			results = append(results, rcmcp.PreflightResult{Toolset: id, Status: rcmcp.PreflightFailed, Detail: "unknown toolset"})
+			continue
+		}
+		toolset := factory()
+		if err := toolset.Init(toolCtx); err != nil {
+			results = append(results, rcmcp.PreflightResult{Toolset: id, Status: rcmcp.PreflightFailed, Detail: fmt.Sprintf("init: %v", err)})
+			continue
+		}
+		if err := toolset.Register(reg); err != nil {
+			results = append(results, rcmcp.PreflightResult{Toolset: id, Status: rcmcp.PreflightFailed, Detail: fmt.Sprintf("register: %v", err)})
+			continue
+		}
+		checker, ok := toolset.(rcmcp.PreflightChecker)
+		if !ok {
+			results = append(results, rcmcp.PreflightResult{Toolset: id, Status: rcmcp.PreflightSkipped, Detail: "no connectivity check"})
+			continue
+		}
+		results = append(results, rcmcp.RunPreflight(ctx, id, checker, rcmcp.DefaultPreflightTimeout))
+	}
+	writePreflightTable(out, results)
+	if failed := rcmcp.PreflightFailures(results); failed > 0 {
+		return fmt.Errorf("preflight failed: %d of %d toolsets not ready", failed, len(results))
+	}
+	return nil
+}
+
+func writePreflightTable(out io.Writer, results []rcmcp.PreflightResult) {
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TOOLSET\tSTATUS\tDURATION\tDETAIL")
+	for _, result := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%dms\t%s\n", result.Toolset, result.Status, result.DurationMs, result.Detail)
+	}
+	_ = tw.Flush()
+}
@@ -4,17 +4,24 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"rootcause/internal/archive"
 	"rootcause/internal/audit"
+	rcaws "rootcause/internal/aws"
 	"rootcause/internal/cache"
 	"rootcause/internal/config"
 	"rootcause/internal/evidence"
 	"rootcause/internal/kube"
+	"rootcause/internal/logging"
 	rcmcp "rootcause/internal/mcp"
+	"rootcause/internal/notify"
 	"rootcause/internal/policy"
 	"rootcause/internal/redact"
 	"rootcause/internal/render"
@@ -28,8 +35,13 @@ type Options struct {
 	ReadOnly           bool
 	DisableDestructive bool
 	LogLevel           string
+	LogFormat          string
 	Version            string
 	Stderr             io.Writer
+	// Preflight runs each enabled toolset's connectivity check, writes a
+	// table to Stdout, and returns instead of serving.
+	Preflight bool
+	Stdout    io.Writer
 	// Transport is an optional injection point for tests. When nil, stdio
 	// is used. Rootcause only speaks stdio — remote/network access should
 	// be fronted by a reverse proxy that exposes the stdio transport.
@@ -66,10 +78,23 @@ func Run(ctx context.Context, opts Options) error {
 	if opts.LogLevel != "" {
 		overrides.LogLevel = &opts.LogLevel
 	}
-	cfg, err := config.Load(configPath, "", overrides)
+	if opts.LogFormat != "" {
+		overrides.LogFormat = &opts.LogFormat
+	}
+	cfg, err := loadConfig(configPath, overrides)
 	if err != nil {
 		return fmt.Errorf("config load failed: %w", err)
 	}
+	if err := installLogger(cfg, errOut); err != nil {
+		return fmt.Errorf("config load failed: %w", err)
+	}
+	if opts.Preflight {
+		out := opts.Stdout
+		if out == nil {
+			out = os.Stdout
+		}
+		return runPreflight(ctx, cfg, errOut, out)
+	}
 
 	toolCtx, _, err := buildRuntime(cfg, errOut, nil)
 	if err != nil {
@@ -87,12 +112,12 @@ func Run(ctx context.Context, opts Options) error {
 	// Matches the (newly) lenient reload behavior in the goroutine below.
 	promptNames, err := rcmcp.RegisterSDKPrompts(server, toolCtx)
 	if err != nil {
-		fmt.Fprintf(errOut, "rootcause: prompt registration failed at startup, continuing without prompts: %v\n", err)
+		slog.Warn("prompt registration failed at startup, continuing without prompts", "error", err)
 		promptNames = nil
 	}
 	resourceURIs, resourceTemplates, err := rcmcp.RegisterSDKResources(server, toolCtx)
 	if err != nil {
-		fmt.Fprintf(errOut, "rootcause: resource registration failed at startup, continuing without resources: %v\n", err)
+		slog.Warn("resource registration failed at startup, continuing without resources", "error", err)
 		resourceURIs, resourceTemplates = nil, nil
 	}
 
@@ -100,14 +125,18 @@ func Run(ctx context.Context, opts Options) error {
 	notifyReload(reloadCh)
 	go func() {
 		for range reloadCh {
-			cfg, err := config.Load(configPath, "", overrides)
+			cfg, err := loadConfig(configPath, overrides)
 			if err != nil {
-				fmt.Fprintf(errOut, "config reload failed: %v\n", err)
+				slog.Error("config reload failed", "error", err)
+				continue
+			}
+			if err := installLogger(cfg, errOut); err != nil {
+				slog.Error("config reload failed", "error", err)
 				continue
 			}
 			newCtx, newReg, err := buildRuntime(cfg, errOut, invoker)
 			if err != nil {
-				fmt.Fprintf(errOut, "reload init failed: %v\n", err)
+				slog.Error("reload init failed", "error", err)
 				continue
 			}
 			newNames := newReg.Names()
@@ -125,13 +154,13 @@ func Run(ctx context.Context, opts Options) error {
 			// Prompts: probe the load before removing the live set, so a bad
 			// prompt edit on reload never leaves the server with zero prompts.
 			if _, probeErr := rcmcp.LoadPromptSpecsForCLI(newCtx); probeErr != nil {
-				fmt.Fprintf(errOut, "prompt reload skipped, keeping previous prompts: %v\n", probeErr)
+				slog.Warn("prompt reload skipped, keeping previous prompts", "error", probeErr)
 			} else {
 				if len(promptNames) > 0 {
 					server.RemovePrompts(promptNames...)
 				}
 				if names, perr := rcmcp.RegisterSDKPrompts(server, newCtx); perr != nil {
-					fmt.Fprintf(errOut, "prompt registration failed: %v\n", perr)
+					slog.Error("prompt registration failed", "error", perr)
 				} else {
 					promptNames = names
 				}
@@ -146,7 +175,7 @@ func Run(ctx context.Context, opts Options) error {
 				server.RemoveResourceTemplates(resourceTemplates...)
 			}
 			if uris, tmpls, rerr := rcmcp.RegisterSDKResources(server, newCtx); rerr != nil {
-				fmt.Fprintf(errOut, "resource registration failed: %v\n", rerr)
+				slog.Error("resource registration failed", "error", rerr)
 				resourceURIs, resourceTemplates = nil, nil
 			} else {
 				resourceURIs, resourceTemplates = uris, tmpls
@@ -158,13 +187,85 @@ func Run(ctx context.Context, opts Options) error {
 	if transport == nil {
 		transport = &sdkmcp.StdioTransport{}
 	}
-	if err := server.Run(ctx, transport); err != nil {
+	err = server.Run(ctx, transport)
+	drainBackground(invoker)
+	if err != nil {
 		return fmt.Errorf("server error: %w", err)
 	}
 	return nil
 }
 
+// drainBackground waits for the current runtime's background work, archive
+// writes and webhook alerts, so nothing in flight is lost when the server
+// exits.
+func drainBackground(invoker *rcmcp.ToolInvoker) {
+	_, current, ok := invoker.Runtime()
+	if !ok {
+		return
+	}
+	current.Archive.Wait()
+	current.Notifier.Wait()
+}
+
+// installLogger replaces the process logger with one built from the config's
+// log_level and log_format. Audit events keep their own JSON line format.
+func installLogger(cfg config.Config, errOut io.Writer) error {
+	logger, err := logging.New(errOut, cfg.LogLevel, cfg.LogFormat)
+	if err != nil {
+		return err
+	}
+	logging.Install(logger)
+	return nil
+}
+
+// loadConfig loads and validates the config against the toolsets compiled into
+// this binary, so typos fail startup (or are rejected on reload) with the
+// offending key instead of being silently ignored.
+func loadConfig(configPath string, overrides config.Overrides) (config.Config, error) {
+	cfg, err := config.Load(configPath, "", overrides)
+	if err != nil {
+		return cfg, err
+	}
+	if err := cfg.Validate(rcmcp.RegisteredToolsets()); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
 func buildRuntime(cfg config.Config, errOut io.Writer, existingInvoker *rcmcp.ToolInvoker) (rcmcp.ToolContext, *rcmcp.ToolRegistry, error) {
+	toolCtx, reg := newToolContext(cfg, errOut, existingInvoker)
+	for _, id := range effectiveToolsets(cfg.Toolsets) {
+		factory, ok := rcmcp.ToolsetFactoryFor(id)
+		if !ok {
+			return rcmcp.ToolContext{}, nil, fmt.Errorf("unknown toolset: %s", id)
+		}
+		toolset := factory()
+		if err := toolset.Init(toolCtx); err != nil {
+			return rcmcp.ToolContext{}, nil, err
+		}
+		if err := toolset.Register(reg); err != nil {
+			return rcmcp.ToolContext{}, nil, err
+		}
+		reg.AddToolset(id)
+		if checker, ok := toolset.(rcmcp.PreflightChecker); ok {
+			reg.AddPreflight(id, checker)
+		}
+	}
+	if err := rcmcp.ValidateToolDependencies(reg, rcmcp.RequiredToolDependencies()); err != nil {
+		return rcmcp.ToolContext{}, nil, err
+	}
+
+	if existingInvoker != nil {
+		existingInvoker.Swap(reg, toolCtx)
+	}
+	toolCtx.Invoker.SetContextRuntimeBuilder(contextRuntimeBuilder(cfg, toolCtx))
+
+	return toolCtx, reg, nil
+}
+
+// newToolContext builds the shared runtime state toolsets are initialized
+// with, before any toolset is added to the registry.
+func newToolContext(cfg config.Config, errOut io.Writer, existingInvoker *rcmcp.ToolInvoker) (rcmcp.ToolContext, *rcmcp.ToolRegistry) {
 	// A missing/unreachable kubeconfig is non-fatal: cloud-only toolsets (gcp,
 	// aws, terraform) and rootcause can still start. Toolsets that genuinely
 	// need a cluster (k8s, helm, istio, karpenter, linkerd) fail their own Init
@@ -174,7 +275,7 @@ func buildRuntime(cfg config.Config, errOut io.Writer, existingInvoker *rcmcp.To
 		Context:    cfg.Context,
 	})
 	if err != nil {
-		fmt.Fprintf(errOut, "rootcause: kubeconfig unavailable, k8s-dependent toolsets will be disabled: %v\n", err)
+		slog.Warn("kubeconfig unavailable, k8s-dependent toolsets will be disabled", "error", err)
 		clients = nil
 	}
 	authorizer := policy.NewAuthorizer()
@@ -182,6 +283,14 @@ func buildRuntime(cfg config.Config, errOut io.Writer, existingInvoker *rcmcp.To
 	renderer := render.NewRenderer()
 	evidenceCollector := evidence.NewCollector(clients)
 	auditLogger := audit.NewLogger(errOut)
+	archiver, err := newArchiver(cfg)
+	if err != nil {
+		slog.Warn("result archive disabled", "sink", cfg.Archive.Sink, "error", err)
+	}
+	var notifier *notify.Webhook
+	if cfg.Webhook.URL != "" {
+		notifier = notify.NewWebhook(cfg.Webhook.URL, cfg.Webhook.MinSeverity, time.Duration(cfg.Webhook.TimeoutSeconds)*time.Second)
+	}
 	cacheStore := cache.NewStore()
 	callGraph := rcmcp.NewCallGraph(cfg.Limits.MaxCallGraph)
 	reg := rcmcp.NewRegistry(&cfg)
@@ -194,6 +303,8 @@ func buildRuntime(cfg config.Config, errOut io.Writer, existingInvoker *rcmcp.To
 		Renderer:  renderer,
 		Redactor:  redactor,
 		Audit:     auditLogger,
+		Archive:   archiver,
+		Notifier:  notifier,
 		Cache:     cacheStore,
 		CallGraph: callGraph,
 		Registry:  reg,
@@ -203,29 +314,75 @@ func buildRuntime(cfg config.Config, errOut io.Writer, existingInvoker *rcmcp.To
 	} else {
 		toolCtx.Invoker = rcmcp.NewToolInvoker(reg, toolCtx)
 	}
+	return toolCtx, reg
+}
 
-	for _, id := range effectiveToolsets(cfg.Toolsets) {
-		factory, ok := rcmcp.ToolsetFactoryFor(id)
-		if !ok {
-			return rcmcp.ToolContext{}, nil, fmt.Errorf("unknown toolset: %s", id)
-		}
-		toolset := factory()
-		if err := toolset.Init(toolCtx); err != nil {
-			return rcmcp.ToolContext{}, nil, err
-		}
-		if err := toolset.Register(reg); err != nil {
-			return rcmcp.ToolContext{}, nil, err
+// newArchiver builds the result archive from the archive section, or returns
+// nil when no sink is configured. The s3 sink takes its credentials from the
+// aws section and the standard AWS chain.
+func newArchiver(cfg config.Config) (*archive.Archiver, error) {
+	switch cfg.Archive.Sink {
+	case "":
+		return nil, nil
+	case "file":
+		return archive.New(archive.NewFileSink(cfg.Archive.Dir)), nil
+	case "s3":
+		awsCfg, err := rcaws.LoadConfigWithSecrets(context.Background(), cfg.Archive.S3.Region, cfg.AWS.Region, cfg.AWS.Profile, cfg.AWS.CredentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("load AWS config: %w", err)
 		}
+		return archive.New(archive.NewS3Sink(cfg.Archive.S3.Bucket, cfg.Archive.S3.Prefix, cfg.Archive.S3.Endpoint, awsCfg)), nil
+	default:
+		return nil, fmt.Errorf("unknown archive sink %q", cfg.Archive.Sink)
 	}
-	if err := rcmcp.ValidateToolDependencies(reg, rcmcp.RequiredToolDependencies()); err != nil {
-		return rcmcp.ToolContext{}, nil, err
-	}
+}
 
-	if existingInvoker != nil {
-		existingInvoker.Swap(reg, toolCtx)
+// contextRuntimeBuilder returns the builder the invoker uses for per-call
+// kubeconfig contexts: it validates the name against the kubeconfig and
+// initializes only the kube-backed toolsets with clients for that context.
+func contextRuntimeBuilder(cfg config.Config, base rcmcp.ToolContext) rcmcp.ContextRuntimeBuilder {
+	return func(name string) (*rcmcp.ToolRegistry, rcmcp.ToolContext, error) {
+		current, names, err := kube.Contexts(cfg.Kubeconfig)
+		if err != nil {
+			return nil, rcmcp.ToolContext{}, fmt.Errorf("load kubeconfig contexts: %w", err)
+		}
+		if !slices.Contains(names, name) {
+			return nil, rcmcp.ToolContext{}, fmt.Errorf("kubeconfig context %q not found", name)
+		}
+		defaultContext := cfg.Context
+		if defaultContext == "" {
+			defaultContext = current
+		}
+		if name == defaultContext && base.Clients != nil {
+			return nil, rcmcp.ToolContext{}, nil
+		}
+		clients, err := kube.NewClients(kube.Config{Kubeconfig: cfg.Kubeconfig, Context: name})
+		if err != nil {
+			return nil, rcmcp.ToolContext{}, fmt.Errorf("kubeconfig context %q: %w", name, err)
+		}
+		scoped := base
+		scoped.Clients = clients
+		scoped.Evidence = evidence.NewCollector(clients)
+		scoped.Cache = cache.NewStore()
+		reg := rcmcp.NewRegistry(base.Config)
+		for _, id := range effectiveToolsets(cfg.Toolsets) {
+			if !rcmcp.KubeContextToolset(id) {
+				continue
+			}
+			factory, ok := rcmcp.ToolsetFactoryFor(id)
+			if !ok {
+				return nil, rcmcp.ToolContext{}, fmt.Errorf("unknown toolset: %s", id)
+			}
+			toolset := factory()
+			if err := toolset.Init(scoped); err != nil {
+				return nil, rcmcp.ToolContext{}, err
+			}
+			if err := toolset.Register(reg); err != nil {
+				return nil, rcmcp.ToolContext{}, err
+			}
+		}
+		return reg, scoped, nil
 	}
-
-	return toolCtx, reg, nil
 }
 
 func diffToolNames(oldNames, newNames []string) (toRemove, toAdd []string) {
@@ -1,12 +1,14 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
@@ -63,6 +65,45 @@ current-context: test
 	}
 }
 
+func TestRunPreflightReportsUnreachableCluster(t *testing.T) {
+	dir := t.TempDir()
+	kubeconfigPath := filepath.Join(dir, "kubeconfig")
+	kubeconfig := `
+apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:1
+users:
+- name: test
+  user:
+    token: fake
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(kubeconfig), 0600); err != nil {
+		t.Fatalf("write kubeconfig: %v", err)
+	}
+	cfg := config.DefaultConfig()
+	cfg.Kubeconfig = kubeconfigPath
+	cfg.Toolsets = []string{"k8s"}
+
+	var out bytes.Buffer
+	err := runPreflight(context.Background(), cfg, io.Discard, &out)
+	if err == nil || !strings.Contains(err.Error(), "1 of 1 toolsets not ready") {
+		t.Fatalf("expected preflight failure, got %v", err)
+	}
+	table := out.String()
+	if !strings.HasPrefix(table, "TOOLSET") || !strings.Contains(table, "k8s") || !strings.Contains(table, "failed") || !strings.Contains(table, "api server unreachable") {
+		t.Fatalf("unexpected preflight table:\n%s", table)
+	}
+}
+
 func TestEffectiveToolsetsBrowserEnv(t *testing.T) {
 	t.Setenv("MCP_BROWSER_ENABLED", "true")
 	got := effectiveToolsets([]string{"k8s", "rootcause"})
@@ -567,3 +608,81 @@ func (c *blockingConn) Close() error {
 func (c *blockingConn) SessionID() string {
 	return "blocking"
 }
+
+func TestNewArchiver(t *testing.T) {
+	cfg := config.DefaultConfig()
+	if archiver, err := newArchiver(cfg); archiver != nil || err != nil {
+		t.Fatalf("expected no archiver by default, got %v %v", archiver, err)
+	}
+	cfg.Archive.Sink = "file"
+	cfg.Archive.Dir = t.TempDir()
+	if archiver, err := newArchiver(cfg); archiver == nil || err != nil {
+		t.Fatalf("expected file archiver, got %v %v", archiver, err)
+	}
+	cfg.Archive.Sink = "gcs"
+	if _, err := newArchiver(cfg); err == nil {
+		t.Fatalf("expected error for unknown sink")
+	}
+}
+
+func TestContextRuntimeBuilder(t *testing.T) {
+	dir := t.TempDir()
+	kubeconfigPath := filepath.Join(dir, "kubeconfig")
+	kubeconfig := `
+apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://example.com
+- name: other
+  cluster:
+    server: https://other.example.com
+users:
+- name: test
+  user:
+    token: fake
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+- name: other
+  context:
+    cluster: other
+    user: test
+current-context: test
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(kubeconfig), 0600); err != nil {
+		t.Fatalf("write kubeconfig: %v", err)
+	}
+	cfg := config.DefaultConfig()
+	cfg.Kubeconfig = kubeconfigPath
+	cfg.Toolsets = []string{"k8s"}
+
+	toolCtx, _, err := buildRuntime(cfg, io.Discard, nil)
+	if err != nil {
+		t.Fatalf("buildRuntime failed: %v", err)
+	}
+	build := contextRuntimeBuilder(cfg, toolCtx)
+	if _, _, err := build("missing"); err == nil {
+		t.Fatalf("expected error for unknown context")
+	}
+	reg, _, err := build("test")
+	if err != nil || reg != nil {
+		t.Fatalf("expected default context to reuse main runtime, got reg=%v err=%v", reg, err)
+	}
+	reg, scoped, err := build("other")
+	if err != nil {
+		t.Fatalf("build other: %v", err)
+	}
+	if reg == nil || len(reg.Names()) == 0 {
+		t.Fatalf("expected kube tools registered for other context")
+	}
+	if scoped.Clients == nil || scoped.Clients == toolCtx.Clients {
+		t.Fatalf("expected dedicated clients for other context")
+	}
+	if scoped.Clients.RestConfig.Host != "https://other.example.com" {
+		t.Fatalf("unexpected host for other context: %s", scoped.Clients.RestConfig.Host)
+	}
+}
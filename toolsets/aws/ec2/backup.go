@@ -0,0 +1,149 @@
+package awsec2
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/argconv"
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+const (
+	defaultBackupMaxAgeDays = 7
+	defaultBackupVolumes    = 200
+	// snapshotVolumeBatch bounds the volume-id filter values per
+	// DescribeSnapshots call.
+	snapshotVolumeBatch = 100
+)
+
+func (s *Service) handleAnalyzeBackupCoverage(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	region := awsutil.ToString(req.Arguments["region"])
+	instanceID := awsutil.ToString(req.Arguments["instanceId"])
+	ids := awsutil.ToStringSlice(req.Arguments["volumeIds"])
+	maxAgeDays := argconv.Int(req.Arguments["maxAgeDays"], defaultBackupMaxAgeDays)
+	if maxAgeDays <= 0 {
+		return awsutil.ErrorResult(errors.New("maxAgeDays must be positive")), errors.New("maxAgeDays must be positive")
+	}
+	limit := argconv.Int(req.Arguments["limit"], defaultBackupVolumes)
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	volumeInput := &ec2.DescribeVolumesInput{}
+	if len(ids) > 0 {
+		volumeInput.VolumeIds = ids
+	}
+	if instanceID != "" {
+		volumeInput.Filters = append(volumeInput.Filters, ec2types.Filter{
+			Name:   aws.String("attachment.instance-id"),
+			Values: []string{instanceID},
+		})
+	}
+	var volumes []ec2types.Volume
+	truncated := false
+	for {
+		out, err := client.DescribeVolumes(ctx, volumeInput)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		volumes = append(volumes, out.Volumes...)
+		if limit > 0 && len(volumes) >= limit {
+			truncated = len(volumes) > limit || aws.ToString(out.NextToken) != ""
+			volumes = volumes[:limit]
+			break
+		}
+		if aws.ToString(out.NextToken) == "" {
+			break
+		}
+		volumeInput.NextToken = out.NextToken
+	}
+	latest := map[string]ec2types.Snapshot{}
+	for start := 0; start < len(volumes); start += snapshotVolumeBatch {
+		end := min(start+snapshotVolumeBatch, len(volumes))
+		volumeIDs := make([]string, 0, end-start)
+		for _, vol := range volumes[start:end] {
+			volumeIDs = append(volumeIDs, aws.ToString(vol.VolumeId))
+		}
+		snapInput := &ec2.DescribeSnapshotsInput{
+			OwnerIds: []string{"self"},
+			Filters: []ec2types.Filter{
+				{Name: aws.String("volume-id"), Values: volumeIDs},
+				{Name: aws.String("status"), Values: []string{string(ec2types.SnapshotStateCompleted)}},
+			},
+		}
+		for {
+			out, err := client.DescribeSnapshots(ctx, snapInput)
+			if err != nil {
+				return awsutil.ErrorResult(err), err
+			}
+			for _, snap := range out.Snapshots {
+				if snap.State != ec2types.SnapshotStateCompleted {
+					continue
+				}
+				volumeID := aws.ToString(snap.VolumeId)
+				if current, ok := latest[volumeID]; !ok || aws.ToTime(snap.StartTime).After(aws.ToTime(current.StartTime)) {
+					latest[volumeID] = snap
+				}
+			}
+			if aws.ToString(out.NextToken) == "" {
+				break
+			}
+			snapInput.NextToken = out.NextToken
+		}
+	}
+	data := assessBackupCoverage(volumes, latest, maxAgeDays, time.Now())
+	data["region"] = awsutil.RegionOrDefault(usedRegion)
+	if instanceID != "" {
+		data["instanceId"] = instanceID
+	}
+	if truncated {
+		data["truncated"] = true
+	}
+	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+}
+
+// assessBackupCoverage classifies each volume by its most recent completed
+// snapshot: "missing" when there is none, "stale" when it is older than
+// maxAgeDays, otherwise "ok".
+func assessBackupCoverage(volumes []ec2types.Volume, latest map[string]ec2types.Snapshot, maxAgeDays int, now time.Time) map[string]any {
+	maxAge := time.Duration(maxAgeDays) * 24 * time.Hour
+	var results []map[string]any
+	var missing, stale []string
+	for _, vol := range volumes {
+		volumeID := aws.ToString(vol.VolumeId)
+		entry := map[string]any{"volume": summarizeVolume(vol)}
+		snap, ok := latest[volumeID]
+		if !ok {
+			entry["status"] = "missing"
+			missing = append(missing, volumeID)
+			results = append(results, entry)
+			continue
+		}
+		age := now.Sub(aws.ToTime(snap.StartTime))
+		entry["latestSnapshot"] = summarizeSnapshot(snap)
+		entry["snapshotAgeDays"] = int(age.Hours() / 24)
+		if age > maxAge {
+			entry["status"] = "stale"
+			stale = append(stale, volumeID)
+		} else {
+			entry["status"] = "ok"
+		}
+		results = append(results, entry)
+	}
+	return map[string]any{
+		"maxAgeDays":   maxAgeDays,
+		"volumes":      results,
+		"count":        len(results),
+		"covered":      len(results) - len(missing) - len(stale),
+		"missing":      missing,
+		"stale":        stale,
+		"flaggedCount": len(missing) + len(stale),
+		"evaluatedAt":  now.UTC().Format(time.RFC3339),
+	}
+}
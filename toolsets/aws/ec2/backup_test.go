@@ -0,0 +1,99 @@
+package awsec2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestAssessBackupCoverage(t *testing.T) {
+	now := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	volumes := []ec2types.Volume{
+		{VolumeId: aws.String("vol-ok")},
+		{VolumeId: aws.String("vol-stale")},
+		{VolumeId: aws.String("vol-none")},
+	}
+	latest := map[string]ec2types.Snapshot{
+		"vol-ok":    {SnapshotId: aws.String("snap-ok"), VolumeId: aws.String("vol-ok"), StartTime: aws.Time(now.Add(-36 * time.Hour))},
+		"vol-stale": {SnapshotId: aws.String("snap-old"), VolumeId: aws.String("vol-stale"), StartTime: aws.Time(now.Add(-30 * 24 * time.Hour))},
+	}
+	got := assessBackupCoverage(volumes, latest, 7, now)
+	if got["count"] != 3 || got["covered"] != 1 || got["flaggedCount"] != 2 {
+		t.Fatalf("unexpected counts: %#v", got)
+	}
+	if missing := got["missing"].([]string); len(missing) != 1 || missing[0] != "vol-none" {
+		t.Fatalf("unexpected missing: %#v", missing)
+	}
+	if stale := got["stale"].([]string); len(stale) != 1 || stale[0] != "vol-stale" {
+		t.Fatalf("unexpected stale: %#v", stale)
+	}
+	entries := got["volumes"].([]map[string]any)
+	if entries[0]["status"] != "ok" || entries[0]["snapshotAgeDays"] != 1 {
+		t.Fatalf("unexpected ok entry: %#v", entries[0])
+	}
+	if entries[1]["snapshotAgeDays"] != 30 {
+		t.Fatalf("unexpected stale entry: %#v", entries[1])
+	}
+	if _, ok := entries[2]["latestSnapshot"]; ok || entries[2]["status"] != "missing" {
+		t.Fatalf("unexpected missing entry: %#v", entries[2])
+	}
+}
+
+func TestHandleAnalyzeBackupCoverage(t *testing.T) {
+	recent := time.Now().UTC().Add(-time.Hour).Format(time.RFC3339)
+	responses := map[string]string{
+		"DescribeVolumes": `<DescribeVolumesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <volumeSet>
+    <item><volumeId>vol-1</volumeId><status>in-use</status></item>
+    <item><volumeId>vol-2</volumeId><status>in-use</status></item>
+  </volumeSet>
+</DescribeVolumesResponse>`,
+		"DescribeSnapshots": `<DescribeSnapshotsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <snapshotSet>
+    <item><snapshotId>snap-old</snapshotId><volumeId>vol-1</volumeId><status>completed</status><startTime>2020-01-01T00:00:00Z</startTime></item>
+    <item><snapshotId>snap-new</snapshotId><volumeId>vol-1</volumeId><status>completed</status><startTime>` + recent + `</startTime></item>
+    <item><snapshotId>snap-pending</snapshotId><volumeId>vol-2</volumeId><status>pending</status><startTime>` + recent + `</startTime></item>
+  </snapshotSet>
+</DescribeSnapshotsResponse>`,
+	}
+	client := newEC2TestClient(t, responses)
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	if _, err := svc.handleAnalyzeBackupCoverage(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"maxAgeDays": -1}}); err == nil {
+		t.Fatalf("expected error for non-positive maxAgeDays")
+	}
+	result, err := svc.handleAnalyzeBackupCoverage(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"instanceId": "i-1"}})
+	if err != nil {
+		t.Fatalf("analyze backup coverage: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if data["covered"] != 1 || data["instanceId"] != "i-1" {
+		t.Fatalf("unexpected coverage: %#v", data)
+	}
+	entries := data["volumes"].([]map[string]any)
+	if entries[0]["latestSnapshot"].(map[string]any)["id"] != "snap-new" {
+		t.Fatalf("expected most recent snapshot, got %#v", entries[0])
+	}
+	if entries[1]["status"] != "missing" {
+		t.Fatalf("expected pending-only volume to be missing, got %#v", entries[1])
+	}
+
+	result, err = svc.handleAnalyzeBackupCoverage(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"limit": 1}})
+	if err != nil {
+		t.Fatalf("analyze backup coverage with limit: %v", err)
+	}
+	if data := result.Data.(map[string]any); data["count"] != 1 || data["truncated"] != true {
+		t.Fatalf("expected truncated result, got %#v", data)
+	}
+}
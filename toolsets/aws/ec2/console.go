@@ -0,0 +1,91 @@
+package awsec2
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	"rootcause/internal/argconv"
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+// defaultConsoleOutputBytes caps the console log returned; the end of the
+// log, where boot failures show up, is kept.
+const defaultConsoleOutputBytes = 64 * 1024
+
+func (s *Service) handleGetConsoleOutput(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	instanceID := awsutil.ToString(req.Arguments["instanceId"])
+	if instanceID == "" {
+		return awsutil.ErrorResult(errors.New("instanceId is required")), errors.New("instanceId is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	maxBytes := argconv.Int(req.Arguments["maxBytes"], defaultConsoleOutputBytes)
+	if maxBytes <= 0 {
+		maxBytes = defaultConsoleOutputBytes
+	}
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	out, err := client.GetConsoleOutput(ctx, &ec2.GetConsoleOutputInput{
+		InstanceId: aws.String(instanceID),
+		Latest:     aws.Bool(true),
+	})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	text := decodeConsoleOutput(aws.ToString(out.Output))
+	totalBytes := len(text)
+	text, truncated := tailConsoleOutput(text, maxBytes)
+	result := map[string]any{
+		"region":     awsutil.RegionOrDefault(usedRegion),
+		"instanceId": instanceID,
+		"output":     s.ctx.Redactor.RedactString(text),
+		"totalBytes": totalBytes,
+		"truncated":  truncated,
+	}
+	if out.Timestamp != nil {
+		result["timestamp"] = out.Timestamp
+	}
+	if totalBytes == 0 {
+		result["note"] = "No console output yet; it is only captured after the instance has booted far enough to write to the serial console, and can lag by a few minutes."
+	}
+	return mcp.ToolResult{
+		Data: s.ctx.Redactor.RedactValue(result),
+		Metadata: mcp.ToolMetadata{
+			Resources: []string{fmt.Sprintf("ec2/instance/%s", instanceID)},
+		},
+	}, nil
+}
+
+// decodeConsoleOutput decodes the base64 payload GetConsoleOutput returns,
+// falling back to the raw value for endpoints that return plain text.
+func decodeConsoleOutput(encoded string) string {
+	if encoded == "" {
+		return ""
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return encoded
+	}
+	return string(decoded)
+}
+
+// tailConsoleOutput keeps the last maxBytes of text, starting at a line
+// boundary when one is available so the first line is not cut mid-way.
+func tailConsoleOutput(text string, maxBytes int) (string, bool) {
+	if len(text) <= maxBytes {
+		return text, false
+	}
+	tail := text[len(text)-maxBytes:]
+	if idx := strings.IndexByte(tail, '\n'); idx >= 0 && idx < len(tail)-1 {
+		tail = tail[idx+1:]
+	}
+	return tail, true
+}
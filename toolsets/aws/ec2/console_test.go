@@ -0,0 +1,51 @@
+package awsec2
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestTailConsoleOutput(t *testing.T) {
+	if got, truncated := tailConsoleOutput("short", 10); got != "short" || truncated {
+		t.Fatalf("unexpected untruncated output: %q %v", got, truncated)
+	}
+	got, truncated := tailConsoleOutput("line one\nline two\nline three\n", 15)
+	if !truncated || got != "line three\n" {
+		t.Fatalf("expected tail starting at a line boundary, got %q", got)
+	}
+}
+
+func TestHandleGetConsoleOutput(t *testing.T) {
+	log := "[    0.000000] Linux version 6.1\nkubelet: failed to join cluster\n"
+	client := newEC2TestClient(t, map[string]string{
+		"GetConsoleOutput": `<GetConsoleOutputResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <instanceId>i-1</instanceId>
+  <timestamp>2024-01-01T00:00:00.000Z</timestamp>
+  <output>` + base64.StdEncoding.EncodeToString([]byte(log)) + `</output>
+</GetConsoleOutputResponse>`,
+	})
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleGetConsoleOutput(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"instanceId": "i-1", "maxBytes": 40}})
+	if err != nil {
+		t.Fatalf("get console output: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if data["output"] != "kubelet: failed to join cluster\n" || data["truncated"] != true || data["totalBytes"] != len(log) {
+		t.Fatalf("unexpected result: %#v", data)
+	}
+	if _, err := svc.handleGetConsoleOutput(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil || !strings.Contains(err.Error(), "instanceId") {
+		t.Fatalf("expected missing instance error, got %v", err)
+	}
+}
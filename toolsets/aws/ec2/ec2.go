@@ -2,9 +2,9 @@ package awsec2
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -17,7 +17,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 
+	"rootcause/internal/argconv"
+	awslib "rootcause/internal/aws"
 	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
 )
 
 type Service struct {
@@ -48,7 +51,7 @@ func ToolSpecs(
 	return []mcp.ToolSpec{
 		{
 			Name:        "aws.ec2.list_instances",
-			Description: "List EC2 instances (optional filters by ids, VPC, subnet, state).",
+			Description: "List EC2 instances (optional filters by ids, VPC, subnet, one or more states).",
 			ToolsetID:   toolsetID,
 			InputSchema: schemaEC2ListInstances(),
 			Safety:      mcp.SafetyReadOnly,
@@ -94,6 +97,14 @@ func ToolSpecs(
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     svc.handleGetLoadBalancer,
 		},
+		{
+			Name:        "aws.ec2.get_load_balancer_attributes",
+			Description: "Get ALB/NLB attributes: access logs, idle timeout, deletion protection, HTTP/2 and desync mitigation.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaEC2GetLoadBalancerAttributes(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleGetLoadBalancerAttributes,
+		},
 		{
 			Name:        "aws.ec2.list_target_groups",
 			Description: "List ALB/NLB target groups (optional name/ARN/LB filter).",
@@ -134,6 +145,14 @@ func ToolSpecs(
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     svc.handleGetTargetHealth,
 		},
+		{
+			Name:        "aws.ec2.get_load_balancer_health",
+			Description: "Aggregate target health across every target group of an ALB/NLB.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaEC2GetLoadBalancerHealth(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleGetLoadBalancerHealth,
+		},
 		{
 			Name:        "aws.ec2.list_listener_rules",
 			Description: "List listener rules (by listener ARN or rule ARNs).",
@@ -150,6 +169,14 @@ func ToolSpecs(
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     svc.handleGetListenerRule,
 		},
+		{
+			Name:        "aws.ec2.explain_routing",
+			Description: "Explain which ALB listener rule and target group a sample request (host/path/method) would hit.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaEC2ExplainRouting(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleExplainRouting,
+		},
 		{
 			Name:        "aws.ec2.list_auto_scaling_policies",
 			Description: "List auto scaling policies (optional ASG/policy filter).",
@@ -216,12 +243,20 @@ func ToolSpecs(
 		},
 		{
 			Name:        "aws.ec2.get_instance_iam",
-			Description: "Get instance profile and IAM roles attached to an EC2 instance.",
+			Description: "Get the instance profile and IAM roles of an EC2 instance, with the permissions their policies grant.",
 			ToolsetID:   toolsetID,
 			InputSchema: schemaEC2GetInstanceIAM(),
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     svc.handleGetInstanceIAM,
 		},
+		{
+			Name:        "aws.ec2.audit_imds",
+			Description: "Report instance metadata options and flag instances that still allow IMDSv1.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaEC2AuditIMDS(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleAuditIMDS,
+		},
 		{
 			Name:        "aws.ec2.get_security_group_rules",
 			Description: "Get security group rules by security group id.",
@@ -246,6 +281,22 @@ func ToolSpecs(
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     svc.handleGetSpotInstanceRequest,
 		},
+		{
+			Name:        "aws.ec2.analyze_spot_risk",
+			Description: "Rate interruption risk for running spot instances from spot request status and scheduled events.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaEC2AnalyzeSpotRisk(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleAnalyzeSpotRisk,
+		},
+		{
+			Name:        "aws.ec2.diagnose_spot",
+			Description: "Explain spot request failures and interruptions (capacity, price, interruption notice) for a spot request, Auto Scaling group or EKS nodegroup.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaEC2DiagnoseSpot(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleDiagnoseSpot,
+		},
 		{
 			Name:        "aws.ec2.list_capacity_reservations",
 			Description: "List EC2 capacity reservations (optional id filter).",
@@ -262,6 +313,14 @@ func ToolSpecs(
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     svc.handleGetCapacityReservation,
 		},
+		{
+			Name:        "aws.ec2.get_capacity_reservation_usage",
+			Description: "Report instances using a capacity reservation, utilization percentage and idle slots.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaEC2GetCapacityReservation(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleGetCapacityReservationUsage,
+		},
 		{
 			Name:        "aws.ec2.list_volumes",
 			Description: "List EBS volumes (optional id/instance filter).",
@@ -294,6 +353,38 @@ func ToolSpecs(
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     svc.handleGetSnapshot,
 		},
+		{
+			Name:        "aws.ec2.analyze_backup_coverage",
+			Description: "Flag EBS volumes with no completed snapshot or only snapshots older than maxAgeDays.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaEC2AnalyzeBackupCoverage(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleAnalyzeBackupCoverage,
+		},
+		{
+			Name:        "aws.ec2.trace_volume_lineage",
+			Description: "Trace an EBS volume or snapshot back through its source snapshots and volumes and forward to what was created from it, with encryption and KMS keys at each step.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaEC2TraceVolumeLineage(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleTraceVolumeLineage,
+		},
+		{
+			Name:        "aws.ec2.list_images",
+			Description: "List AMIs (self-owned by default) with creation date, architecture and block device mappings.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaEC2ListImages(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleListImages,
+		},
+		{
+			Name:        "aws.ec2.analyze_ami_usage",
+			Description: "Flag running instances launched from deregistered AMIs or AMIs older than maxAgeDays.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaEC2AnalyzeAMIUsage(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleAnalyzeAMIUsage,
+		},
 		{
 			Name:        "aws.ec2.list_volume_attachments",
 			Description: "List volume attachments (optional volume/instance filter).",
@@ -334,19 +425,29 @@ func ToolSpecs(
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     svc.handleGetInstanceStatus,
 		},
+		{
+			Name:        "aws.ec2.get_console_output",
+			Description: "Get the latest serial console log of an EC2 instance to see why it failed to boot or join a cluster.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaEC2GetConsoleOutput(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleGetConsoleOutput,
+		},
 	}
 }
 
 func (s *Service) handleListInstances(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	ids := toStringSlice(req.Arguments["instanceIds"])
-	vpcID := toString(req.Arguments["vpcId"])
-	subnetID := toString(req.Arguments["subnetId"])
-	state := strings.TrimSpace(toString(req.Arguments["state"]))
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	ids := awsutil.ToStringSlice(req.Arguments["instanceIds"])
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
+	subnetID := awsutil.ToString(req.Arguments["subnetId"])
+	states := instanceStateValues(awsutil.ToString(req.Arguments["state"]), awsutil.ToStringSlice(req.Arguments["states"]))
+	includePlacement := awsutil.ToBool(req.Arguments["includePlacement"], false)
+	includeMetadataOptions := awsutil.ToBool(req.Arguments["includeMetadataOptions"], false)
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeInstancesInput{}
 	if len(ids) > 0 {
@@ -359,65 +460,84 @@ func (s *Service) handleListInstances(ctx context.Context, req mcp.ToolRequest)
 	if subnetID != "" {
 		filters = append(filters, ec2types.Filter{Name: aws.String("subnet-id"), Values: []string{subnetID}})
 	}
-	if state != "" {
-		filters = append(filters, ec2types.Filter{Name: aws.String("instance-state-name"), Values: []string{state}})
+	if len(states) > 0 {
+		filters = append(filters, ec2types.Filter{Name: aws.String("instance-state-name"), Values: states})
 	}
 	if len(filters) > 0 {
 		input.Filters = filters
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var instances []map[string]any
 	for {
 		out, err := client.DescribeInstances(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
+		var page []ec2types.Instance
 		for _, reservation := range out.Reservations {
-			for _, inst := range reservation.Instances {
-				instances = append(instances, summarizeInstance(inst))
-				if limit > 0 && len(instances) >= limit {
-					break
-				}
+			page = append(page, reservation.Instances...)
+		}
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(page), out.NextToken)
+		for _, inst := range page[start:end] {
+			summary := summarizeInstance(inst)
+			if includePlacement {
+				summary["placement"] = summarizeInstancePlacement(inst)
 			}
-			if limit > 0 && len(instances) >= limit {
-				break
+			if includeMetadataOptions {
+				summary["metadataOptions"] = summarizeInstanceMetadataOptions(inst)
 			}
+			instances = append(instances, summary)
 		}
-		if limit > 0 && len(instances) >= limit {
-			break
-		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":    regionOrDefault(usedRegion),
+		"region":    awsutil.RegionOrDefault(usedRegion),
 		"instances": instances,
 		"count":     len(instances),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetInstance(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	instanceID := toString(req.Arguments["instanceId"])
+	instanceID := awsutil.ToString(req.Arguments["instanceId"])
 	if instanceID == "" {
-		return errorResult(errors.New("instanceId is required")), errors.New("instanceId is required")
+		return awsutil.ErrorResult(errors.New("instanceId is required")), errors.New("instanceId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
+	includePlacement := awsutil.ToBool(req.Arguments["includePlacement"], false)
+	includeMetadataOptions := awsutil.ToBool(req.Arguments["includeMetadataOptions"], false)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{InstanceIds: []string{instanceID}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	for _, reservation := range out.Reservations {
 		for _, inst := range reservation.Instances {
 			if aws.ToString(inst.InstanceId) == instanceID {
+				summary := summarizeInstance(inst)
+				if includePlacement {
+					summary["placement"] = summarizeInstancePlacement(inst)
+				}
+				if includeMetadataOptions {
+					summary["metadataOptions"] = summarizeInstanceMetadataOptions(inst)
+				}
 				result := map[string]any{
-					"region":   regionOrDefault(usedRegion),
-					"instance": summarizeInstance(inst),
+					"region":   awsutil.RegionOrDefault(usedRegion),
+					"instance": summary,
 				}
 				return mcp.ToolResult{
 					Data: s.ctx.Redactor.RedactValue(result),
@@ -428,68 +548,72 @@ func (s *Service) handleGetInstance(ctx context.Context, req mcp.ToolRequest) (m
 			}
 		}
 	}
-	return errorResult(fmt.Errorf("instance %s not found", instanceID)), fmt.Errorf("instance %s not found", instanceID)
+	return awsutil.ErrorResult(fmt.Errorf("instance %s not found", instanceID)), fmt.Errorf("instance %s not found", instanceID)
 }
 
 func (s *Service) handleListASGs(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	names := toStringSlice(req.Arguments["autoScalingGroupNames"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	names := awsutil.ToStringSlice(req.Arguments["autoScalingGroupNames"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.asgClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &autoscaling.DescribeAutoScalingGroupsInput{}
 	if len(names) > 0 {
 		input.AutoScalingGroupNames = names
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var groups []map[string]any
 	for {
 		out, err := client.DescribeAutoScalingGroups(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, group := range out.AutoScalingGroups {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.AutoScalingGroups), out.NextToken)
+		for _, group := range out.AutoScalingGroups[start:end] {
 			groups = append(groups, summarizeASG(group))
-			if limit > 0 && len(groups) >= limit {
-				break
-			}
 		}
-		if limit > 0 && len(groups) >= limit {
-			break
-		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":            regionOrDefault(usedRegion),
+		"region":            awsutil.RegionOrDefault(usedRegion),
 		"autoScalingGroups": groups,
 		"count":             len(groups),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetASG(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	name := toString(req.Arguments["autoScalingGroupName"])
+	name := awsutil.ToString(req.Arguments["autoScalingGroupName"])
 	if name == "" {
-		return errorResult(errors.New("autoScalingGroupName is required")), errors.New("autoScalingGroupName is required")
+		return awsutil.ErrorResult(errors.New("autoScalingGroupName is required")), errors.New("autoScalingGroupName is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.asgClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeAutoScalingGroups(ctx, &autoscaling.DescribeAutoScalingGroupsInput{AutoScalingGroupNames: []string{name}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.AutoScalingGroups) == 0 {
-		return errorResult(fmt.Errorf("auto scaling group %s not found", name)), fmt.Errorf("auto scaling group %s not found", name)
+		return awsutil.ErrorResult(fmt.Errorf("auto scaling group %s not found", name)), fmt.Errorf("auto scaling group %s not found", name)
 	}
 	result := map[string]any{
-		"region":           regionOrDefault(usedRegion),
+		"region":           awsutil.RegionOrDefault(usedRegion),
 		"autoScalingGroup": summarizeASG(out.AutoScalingGroups[0]),
 	}
 	return mcp.ToolResult{
@@ -501,13 +625,13 @@ func (s *Service) handleGetASG(ctx context.Context, req mcp.ToolRequest) (mcp.To
 }
 
 func (s *Service) handleListLoadBalancers(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	arns := toStringSlice(req.Arguments["loadBalancerArns"])
-	names := toStringSlice(req.Arguments["names"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	arns := awsutil.ToStringSlice(req.Arguments["loadBalancerArns"])
+	names := awsutil.ToStringSlice(req.Arguments["names"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.elbClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &elasticloadbalancingv2.DescribeLoadBalancersInput{}
 	if len(arns) > 0 {
@@ -516,44 +640,48 @@ func (s *Service) handleListLoadBalancers(ctx context.Context, req mcp.ToolReque
 	if len(names) > 0 {
 		input.Names = names
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.Marker = pager.StartToken()
 	var lbs []map[string]any
 	for {
 		out, err := client.DescribeLoadBalancers(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, lb := range out.LoadBalancers {
+		start, end, more := pager.Page(aws.ToString(input.Marker), len(out.LoadBalancers), out.NextMarker)
+		for _, lb := range out.LoadBalancers[start:end] {
 			lbs = append(lbs, summarizeLoadBalancer(lb))
-			if limit > 0 && len(lbs) >= limit {
-				break
-			}
-		}
-		if limit > 0 && len(lbs) >= limit {
-			break
 		}
-		if out.NextMarker == nil || aws.ToString(out.NextMarker) == "" {
+		if !more {
 			break
 		}
 		input.Marker = out.NextMarker
 	}
 	data := map[string]any{
-		"region":        regionOrDefault(usedRegion),
+		"region":        awsutil.RegionOrDefault(usedRegion),
 		"loadBalancers": lbs,
 		"count":         len(lbs),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetLoadBalancer(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	arn := strings.TrimSpace(toString(req.Arguments["loadBalancerArn"]))
-	name := strings.TrimSpace(toString(req.Arguments["name"]))
+	arn := strings.TrimSpace(awsutil.ToString(req.Arguments["loadBalancerArn"]))
+	name := strings.TrimSpace(awsutil.ToString(req.Arguments["name"]))
 	if arn == "" && name == "" {
-		return errorResult(errors.New("loadBalancerArn or name is required")), errors.New("loadBalancerArn or name is required")
+		return awsutil.ErrorResult(errors.New("loadBalancerArn or name is required")), errors.New("loadBalancerArn or name is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.elbClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &elasticloadbalancingv2.DescribeLoadBalancersInput{}
 	if arn != "" {
@@ -563,17 +691,17 @@ func (s *Service) handleGetLoadBalancer(ctx context.Context, req mcp.ToolRequest
 	}
 	out, err := client.DescribeLoadBalancers(ctx, input)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.LoadBalancers) == 0 {
 		key := name
 		if arn != "" {
 			key = arn
 		}
-		return errorResult(fmt.Errorf("load balancer %s not found", key)), fmt.Errorf("load balancer %s not found", key)
+		return awsutil.ErrorResult(fmt.Errorf("load balancer %s not found", key)), fmt.Errorf("load balancer %s not found", key)
 	}
 	result := map[string]any{
-		"region":       regionOrDefault(usedRegion),
+		"region":       awsutil.RegionOrDefault(usedRegion),
 		"loadBalancer": summarizeLoadBalancer(out.LoadBalancers[0]),
 	}
 	resourceID := name
@@ -588,15 +716,41 @@ func (s *Service) handleGetLoadBalancer(ctx context.Context, req mcp.ToolRequest
 	}, nil
 }
 
+func (s *Service) handleGetLoadBalancerAttributes(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	arn := strings.TrimSpace(awsutil.ToString(req.Arguments["loadBalancerArn"]))
+	if arn == "" {
+		return awsutil.ErrorResult(errors.New("loadBalancerArn is required")), errors.New("loadBalancerArn is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.elbClient(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	out, err := client.DescribeLoadBalancerAttributes(ctx, &elasticloadbalancingv2.DescribeLoadBalancerAttributesInput{
+		LoadBalancerArn: aws.String(arn),
+	})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	result := summarizeLoadBalancerAttributes(arn, out.Attributes)
+	result["region"] = awsutil.RegionOrDefault(usedRegion)
+	return mcp.ToolResult{
+		Data: s.ctx.Redactor.RedactValue(result),
+		Metadata: mcp.ToolMetadata{
+			Resources: []string{fmt.Sprintf("elbv2/load-balancer/%s", arn)},
+		},
+	}, nil
+}
+
 func (s *Service) handleListTargetGroups(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	arns := toStringSlice(req.Arguments["targetGroupArns"])
-	names := toStringSlice(req.Arguments["names"])
-	lbArn := strings.TrimSpace(toString(req.Arguments["loadBalancerArn"]))
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	arns := awsutil.ToStringSlice(req.Arguments["targetGroupArns"])
+	names := awsutil.ToStringSlice(req.Arguments["names"])
+	lbArn := strings.TrimSpace(awsutil.ToString(req.Arguments["loadBalancerArn"]))
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.elbClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &elasticloadbalancingv2.DescribeTargetGroupsInput{}
 	if len(arns) > 0 {
@@ -608,44 +762,48 @@ func (s *Service) handleListTargetGroups(ctx context.Context, req mcp.ToolReques
 	if lbArn != "" {
 		input.LoadBalancerArn = aws.String(lbArn)
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.Marker = pager.StartToken()
 	var groups []map[string]any
 	for {
 		out, err := client.DescribeTargetGroups(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, group := range out.TargetGroups {
+		start, end, more := pager.Page(aws.ToString(input.Marker), len(out.TargetGroups), out.NextMarker)
+		for _, group := range out.TargetGroups[start:end] {
 			groups = append(groups, summarizeTargetGroup(group))
-			if limit > 0 && len(groups) >= limit {
-				break
-			}
-		}
-		if limit > 0 && len(groups) >= limit {
-			break
 		}
-		if out.NextMarker == nil || aws.ToString(out.NextMarker) == "" {
+		if !more {
 			break
 		}
 		input.Marker = out.NextMarker
 	}
 	data := map[string]any{
-		"region":       regionOrDefault(usedRegion),
+		"region":       awsutil.RegionOrDefault(usedRegion),
 		"targetGroups": groups,
 		"count":        len(groups),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetTargetGroup(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	arn := strings.TrimSpace(toString(req.Arguments["targetGroupArn"]))
-	name := strings.TrimSpace(toString(req.Arguments["name"]))
+	arn := strings.TrimSpace(awsutil.ToString(req.Arguments["targetGroupArn"]))
+	name := strings.TrimSpace(awsutil.ToString(req.Arguments["name"]))
 	if arn == "" && name == "" {
-		return errorResult(errors.New("targetGroupArn or name is required")), errors.New("targetGroupArn or name is required")
+		return awsutil.ErrorResult(errors.New("targetGroupArn or name is required")), errors.New("targetGroupArn or name is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.elbClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &elasticloadbalancingv2.DescribeTargetGroupsInput{}
 	if arn != "" {
@@ -655,17 +813,17 @@ func (s *Service) handleGetTargetGroup(ctx context.Context, req mcp.ToolRequest)
 	}
 	out, err := client.DescribeTargetGroups(ctx, input)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.TargetGroups) == 0 {
 		key := name
 		if arn != "" {
 			key = arn
 		}
-		return errorResult(fmt.Errorf("target group %s not found", key)), fmt.Errorf("target group %s not found", key)
+		return awsutil.ErrorResult(fmt.Errorf("target group %s not found", key)), fmt.Errorf("target group %s not found", key)
 	}
 	result := map[string]any{
-		"region":      regionOrDefault(usedRegion),
+		"region":      awsutil.RegionOrDefault(usedRegion),
 		"targetGroup": summarizeTargetGroup(out.TargetGroups[0]),
 	}
 	resourceID := name
@@ -681,13 +839,13 @@ func (s *Service) handleGetTargetGroup(ctx context.Context, req mcp.ToolRequest)
 }
 
 func (s *Service) handleListListeners(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	arns := toStringSlice(req.Arguments["listenerArns"])
-	lbArn := strings.TrimSpace(toString(req.Arguments["loadBalancerArn"]))
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	arns := awsutil.ToStringSlice(req.Arguments["listenerArns"])
+	lbArn := strings.TrimSpace(awsutil.ToString(req.Arguments["loadBalancerArn"]))
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.elbClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &elasticloadbalancingv2.DescribeListenersInput{}
 	if len(arns) > 0 {
@@ -696,53 +854,57 @@ func (s *Service) handleListListeners(ctx context.Context, req mcp.ToolRequest)
 	if lbArn != "" {
 		input.LoadBalancerArn = aws.String(lbArn)
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.Marker = pager.StartToken()
 	var listeners []map[string]any
 	for {
 		out, err := client.DescribeListeners(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, listener := range out.Listeners {
+		start, end, more := pager.Page(aws.ToString(input.Marker), len(out.Listeners), out.NextMarker)
+		for _, listener := range out.Listeners[start:end] {
 			listeners = append(listeners, summarizeListener(listener))
-			if limit > 0 && len(listeners) >= limit {
-				break
-			}
 		}
-		if limit > 0 && len(listeners) >= limit {
-			break
-		}
-		if out.NextMarker == nil || aws.ToString(out.NextMarker) == "" {
+		if !more {
 			break
 		}
 		input.Marker = out.NextMarker
 	}
 	data := map[string]any{
-		"region":    regionOrDefault(usedRegion),
+		"region":    awsutil.RegionOrDefault(usedRegion),
 		"listeners": listeners,
 		"count":     len(listeners),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetListener(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	arn := strings.TrimSpace(toString(req.Arguments["listenerArn"]))
+	arn := strings.TrimSpace(awsutil.ToString(req.Arguments["listenerArn"]))
 	if arn == "" {
-		return errorResult(errors.New("listenerArn is required")), errors.New("listenerArn is required")
+		return awsutil.ErrorResult(errors.New("listenerArn is required")), errors.New("listenerArn is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.elbClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeListeners(ctx, &elasticloadbalancingv2.DescribeListenersInput{ListenerArns: []string{arn}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.Listeners) == 0 {
-		return errorResult(fmt.Errorf("listener %s not found", arn)), fmt.Errorf("listener %s not found", arn)
+		return awsutil.ErrorResult(fmt.Errorf("listener %s not found", arn)), fmt.Errorf("listener %s not found", arn)
 	}
 	result := map[string]any{
-		"region":   regionOrDefault(usedRegion),
+		"region":   awsutil.RegionOrDefault(usedRegion),
 		"listener": summarizeListener(out.Listeners[0]),
 	}
 	return mcp.ToolResult{
@@ -754,15 +916,15 @@ func (s *Service) handleGetListener(ctx context.Context, req mcp.ToolRequest) (m
 }
 
 func (s *Service) handleGetTargetHealth(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	groupArn := strings.TrimSpace(toString(req.Arguments["targetGroupArn"]))
+	groupArn := strings.TrimSpace(awsutil.ToString(req.Arguments["targetGroupArn"]))
 	if groupArn == "" {
-		return errorResult(errors.New("targetGroupArn is required")), errors.New("targetGroupArn is required")
+		return awsutil.ErrorResult(errors.New("targetGroupArn is required")), errors.New("targetGroupArn is required")
 	}
-	targetIDs := toStringSlice(req.Arguments["targetIds"])
-	region := toString(req.Arguments["region"])
+	targetIDs := awsutil.ToStringSlice(req.Arguments["targetIds"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.elbClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &elasticloadbalancingv2.DescribeTargetHealthInput{
 		TargetGroupArn: aws.String(groupArn),
@@ -776,7 +938,7 @@ func (s *Service) handleGetTargetHealth(ctx context.Context, req mcp.ToolRequest
 	}
 	out, err := client.DescribeTargetHealth(ctx, input)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	var health []map[string]any
 	for _, desc := range out.TargetHealthDescriptions {
@@ -794,7 +956,7 @@ func (s *Service) handleGetTargetHealth(ctx context.Context, req mcp.ToolRequest
 		})
 	}
 	result := map[string]any{
-		"region":         regionOrDefault(usedRegion),
+		"region":         awsutil.RegionOrDefault(usedRegion),
 		"targetGroupArn": groupArn,
 		"targetHealth":   health,
 		"count":          len(health),
@@ -802,17 +964,127 @@ func (s *Service) handleGetTargetHealth(ctx context.Context, req mcp.ToolRequest
 	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(result)}, nil
 }
 
+func (s *Service) handleGetLoadBalancerHealth(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	lbArn := strings.TrimSpace(awsutil.ToString(req.Arguments["loadBalancerArn"]))
+	if lbArn == "" {
+		return awsutil.ErrorResult(errors.New("loadBalancerArn is required")), errors.New("loadBalancerArn is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.elbClient(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input := &elasticloadbalancingv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(lbArn)}
+	var groups []elbtypes.TargetGroup
+	for {
+		out, err := client.DescribeTargetGroups(ctx, input)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		groups = append(groups, out.TargetGroups...)
+		if aws.ToString(out.NextMarker) == "" {
+			break
+		}
+		input.Marker = out.NextMarker
+	}
+	totals := map[string]int{}
+	var summaries []map[string]any
+	unhealthyGroups := 0
+	for _, group := range groups {
+		groupArn := aws.ToString(group.TargetGroupArn)
+		summary := map[string]any{
+			"targetGroupArn":  groupArn,
+			"targetGroupName": aws.ToString(group.TargetGroupName),
+		}
+		out, err := client.DescribeTargetHealth(ctx, &elasticloadbalancingv2.DescribeTargetHealthInput{
+			TargetGroupArn: aws.String(groupArn),
+		})
+		if err != nil {
+			// One unreadable group should not hide the health of the rest.
+			summary["error"] = err.Error()
+			summaries = append(summaries, summary)
+			continue
+		}
+		counts, reasons, unhealthy := summarizeTargetGroupHealth(out.TargetHealthDescriptions)
+		for state, count := range counts {
+			totals[state] += count
+		}
+		if counts["unhealthy"] > 0 {
+			unhealthyGroups++
+		}
+		summary["targets"] = len(out.TargetHealthDescriptions)
+		summary["counts"] = counts
+		summary["unhealthyReasons"] = reasons
+		summary["unhealthyTargets"] = unhealthy
+		summaries = append(summaries, summary)
+	}
+	result := map[string]any{
+		"region":                awsutil.RegionOrDefault(usedRegion),
+		"loadBalancerArn":       lbArn,
+		"targetGroups":          summaries,
+		"count":                 len(summaries),
+		"totals":                totals,
+		"unhealthyTargetGroups": unhealthyGroups,
+	}
+	return mcp.ToolResult{
+		Data: s.ctx.Redactor.RedactValue(result),
+		Metadata: mcp.ToolMetadata{
+			Resources: []string{fmt.Sprintf("elbv2/load-balancer/%s", lbArn)},
+		},
+	}, nil
+}
+
+// summarizeTargetGroupHealth counts targets by state (healthy, unhealthy,
+// initial, draining, ...) and collects the reasons behind unhealthy targets.
+func summarizeTargetGroupHealth(descs []elbtypes.TargetHealthDescription) (map[string]int, map[string]int, []map[string]any) {
+	counts := map[string]int{"healthy": 0, "unhealthy": 0, "initial": 0}
+	reasons := map[string]int{}
+	var unhealthy []map[string]any
+	for _, desc := range descs {
+		state := "unknown"
+		reason := ""
+		description := ""
+		if desc.TargetHealth != nil {
+			if desc.TargetHealth.State != "" {
+				state = string(desc.TargetHealth.State)
+			}
+			reason = string(desc.TargetHealth.Reason)
+			description = aws.ToString(desc.TargetHealth.Description)
+		}
+		counts[state]++
+		if state != string(elbtypes.TargetHealthStateEnumUnhealthy) {
+			continue
+		}
+		if reason != "" {
+			reasons[reason]++
+		}
+		target := map[string]any{
+			"state":       state,
+			"reason":      reason,
+			"description": description,
+		}
+		if desc.Target != nil {
+			target["id"] = aws.ToString(desc.Target.Id)
+			if desc.Target.Port != nil {
+				target["port"] = aws.ToInt32(desc.Target.Port)
+			}
+		}
+		unhealthy = append(unhealthy, target)
+	}
+	return counts, reasons, unhealthy
+}
+
 func (s *Service) handleListListenerRules(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	arns := toStringSlice(req.Arguments["ruleArns"])
-	listenerArn := strings.TrimSpace(toString(req.Arguments["listenerArn"]))
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	arns := awsutil.ToStringSlice(req.Arguments["ruleArns"])
+	listenerArn := strings.TrimSpace(awsutil.ToString(req.Arguments["listenerArn"]))
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	if len(arns) == 0 && listenerArn == "" {
-		return errorResult(errors.New("listenerArn or ruleArns is required")), errors.New("listenerArn or ruleArns is required")
+		return awsutil.ErrorResult(errors.New("listenerArn or ruleArns is required")), errors.New("listenerArn or ruleArns is required")
 	}
 	client, usedRegion, err := s.elbClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &elasticloadbalancingv2.DescribeRulesInput{}
 	if len(arns) > 0 {
@@ -820,53 +1092,57 @@ func (s *Service) handleListListenerRules(ctx context.Context, req mcp.ToolReque
 	} else if listenerArn != "" {
 		input.ListenerArn = aws.String(listenerArn)
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.Marker = pager.StartToken()
 	var rules []map[string]any
 	for {
 		out, err := client.DescribeRules(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, rule := range out.Rules {
+		start, end, more := pager.Page(aws.ToString(input.Marker), len(out.Rules), out.NextMarker)
+		for _, rule := range out.Rules[start:end] {
 			rules = append(rules, summarizeListenerRule(rule))
-			if limit > 0 && len(rules) >= limit {
-				break
-			}
-		}
-		if limit > 0 && len(rules) >= limit {
-			break
 		}
-		if out.NextMarker == nil || aws.ToString(out.NextMarker) == "" {
+		if !more {
 			break
 		}
 		input.Marker = out.NextMarker
 	}
 	data := map[string]any{
-		"region": regionOrDefault(usedRegion),
+		"region": awsutil.RegionOrDefault(usedRegion),
 		"rules":  rules,
 		"count":  len(rules),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetListenerRule(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	ruleArn := strings.TrimSpace(toString(req.Arguments["ruleArn"]))
+	ruleArn := strings.TrimSpace(awsutil.ToString(req.Arguments["ruleArn"]))
 	if ruleArn == "" {
-		return errorResult(errors.New("ruleArn is required")), errors.New("ruleArn is required")
+		return awsutil.ErrorResult(errors.New("ruleArn is required")), errors.New("ruleArn is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.elbClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeRules(ctx, &elasticloadbalancingv2.DescribeRulesInput{RuleArns: []string{ruleArn}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.Rules) == 0 {
-		return errorResult(fmt.Errorf("listener rule %s not found", ruleArn)), fmt.Errorf("listener rule %s not found", ruleArn)
+		return awsutil.ErrorResult(fmt.Errorf("listener rule %s not found", ruleArn)), fmt.Errorf("listener rule %s not found", ruleArn)
 	}
 	result := map[string]any{
-		"region": regionOrDefault(usedRegion),
+		"region": awsutil.RegionOrDefault(usedRegion),
 		"rule":   summarizeListenerRule(out.Rules[0]),
 	}
 	return mcp.ToolResult{
@@ -878,14 +1154,14 @@ func (s *Service) handleGetListenerRule(ctx context.Context, req mcp.ToolRequest
 }
 
 func (s *Service) handleListAutoScalingPolicies(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	group := toString(req.Arguments["autoScalingGroupName"])
-	policyNames := toStringSlice(req.Arguments["policyNames"])
-	policyTypes := toStringSlice(req.Arguments["policyTypes"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	group := awsutil.ToString(req.Arguments["autoScalingGroupName"])
+	policyNames := awsutil.ToStringSlice(req.Arguments["policyNames"])
+	policyTypes := awsutil.ToStringSlice(req.Arguments["policyTypes"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.asgClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &autoscaling.DescribePoliciesInput{}
 	if group != "" {
@@ -897,44 +1173,48 @@ func (s *Service) handleListAutoScalingPolicies(ctx context.Context, req mcp.Too
 	if len(policyTypes) > 0 {
 		input.PolicyTypes = policyTypes
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var policies []map[string]any
 	for {
 		out, err := client.DescribePolicies(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, policy := range out.ScalingPolicies {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.ScalingPolicies), out.NextToken)
+		for _, policy := range out.ScalingPolicies[start:end] {
 			policies = append(policies, summarizeScalingPolicy(policy))
-			if limit > 0 && len(policies) >= limit {
-				break
-			}
-		}
-		if limit > 0 && len(policies) >= limit {
-			break
 		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":          regionOrDefault(usedRegion),
+		"region":          awsutil.RegionOrDefault(usedRegion),
 		"scalingPolicies": policies,
 		"count":           len(policies),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetAutoScalingPolicy(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	policyName := toString(req.Arguments["policyName"])
-	group := toString(req.Arguments["autoScalingGroupName"])
+	policyName := awsutil.ToString(req.Arguments["policyName"])
+	group := awsutil.ToString(req.Arguments["autoScalingGroupName"])
 	if policyName == "" && group == "" {
-		return errorResult(errors.New("policyName or autoScalingGroupName is required")), errors.New("policyName or autoScalingGroupName is required")
+		return awsutil.ErrorResult(errors.New("policyName or autoScalingGroupName is required")), errors.New("policyName or autoScalingGroupName is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.asgClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &autoscaling.DescribePoliciesInput{}
 	if policyName != "" {
@@ -945,30 +1225,30 @@ func (s *Service) handleGetAutoScalingPolicy(ctx context.Context, req mcp.ToolRe
 	}
 	out, err := client.DescribePolicies(ctx, input)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.ScalingPolicies) == 0 {
 		key := policyName
 		if key == "" {
 			key = group
 		}
-		return errorResult(fmt.Errorf("scaling policy %s not found", key)), fmt.Errorf("scaling policy %s not found", key)
+		return awsutil.ErrorResult(fmt.Errorf("scaling policy %s not found", key)), fmt.Errorf("scaling policy %s not found", key)
 	}
 	result := map[string]any{
-		"region":        regionOrDefault(usedRegion),
+		"region":        awsutil.RegionOrDefault(usedRegion),
 		"scalingPolicy": summarizeScalingPolicy(out.ScalingPolicies[0]),
 	}
 	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(result)}, nil
 }
 
 func (s *Service) handleListScalingActivities(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	group := toString(req.Arguments["autoScalingGroupName"])
-	ids := toStringSlice(req.Arguments["activityIds"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	group := awsutil.ToString(req.Arguments["autoScalingGroupName"])
+	ids := awsutil.ToStringSlice(req.Arguments["activityIds"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.asgClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &autoscaling.DescribeScalingActivitiesInput{}
 	if group != "" {
@@ -977,44 +1257,48 @@ func (s *Service) handleListScalingActivities(ctx context.Context, req mcp.ToolR
 	if len(ids) > 0 {
 		input.ActivityIds = ids
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var activities []map[string]any
 	for {
 		out, err := client.DescribeScalingActivities(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, activity := range out.Activities {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.Activities), out.NextToken)
+		for _, activity := range out.Activities[start:end] {
 			activities = append(activities, summarizeScalingActivity(activity))
-			if limit > 0 && len(activities) >= limit {
-				break
-			}
 		}
-		if limit > 0 && len(activities) >= limit {
-			break
-		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":     regionOrDefault(usedRegion),
+		"region":     awsutil.RegionOrDefault(usedRegion),
 		"activities": activities,
 		"count":      len(activities),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetScalingActivity(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	activityID := toString(req.Arguments["activityId"])
+	activityID := awsutil.ToString(req.Arguments["activityId"])
 	if activityID == "" {
-		return errorResult(errors.New("activityId is required")), errors.New("activityId is required")
+		return awsutil.ErrorResult(errors.New("activityId is required")), errors.New("activityId is required")
 	}
-	region := toString(req.Arguments["region"])
-	group := toString(req.Arguments["autoScalingGroupName"])
+	region := awsutil.ToString(req.Arguments["region"])
+	group := awsutil.ToString(req.Arguments["autoScalingGroupName"])
 	client, usedRegion, err := s.asgClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &autoscaling.DescribeScalingActivitiesInput{ActivityIds: []string{activityID}}
 	if group != "" {
@@ -1022,26 +1306,26 @@ func (s *Service) handleGetScalingActivity(ctx context.Context, req mcp.ToolRequ
 	}
 	out, err := client.DescribeScalingActivities(ctx, input)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.Activities) == 0 {
-		return errorResult(fmt.Errorf("scaling activity %s not found", activityID)), fmt.Errorf("scaling activity %s not found", activityID)
+		return awsutil.ErrorResult(fmt.Errorf("scaling activity %s not found", activityID)), fmt.Errorf("scaling activity %s not found", activityID)
 	}
 	result := map[string]any{
-		"region":   regionOrDefault(usedRegion),
+		"region":   awsutil.RegionOrDefault(usedRegion),
 		"activity": summarizeScalingActivity(out.Activities[0]),
 	}
 	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(result)}, nil
 }
 
 func (s *Service) handleListLaunchTemplates(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	ids := toStringSlice(req.Arguments["launchTemplateIds"])
-	names := toStringSlice(req.Arguments["names"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	ids := awsutil.ToStringSlice(req.Arguments["launchTemplateIds"])
+	names := awsutil.ToStringSlice(req.Arguments["names"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeLaunchTemplatesInput{}
 	if len(ids) > 0 {
@@ -1050,44 +1334,48 @@ func (s *Service) handleListLaunchTemplates(ctx context.Context, req mcp.ToolReq
 	if len(names) > 0 {
 		input.LaunchTemplateNames = names
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var templates []map[string]any
 	for {
 		out, err := client.DescribeLaunchTemplates(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, tmpl := range out.LaunchTemplates {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.LaunchTemplates), out.NextToken)
+		for _, tmpl := range out.LaunchTemplates[start:end] {
 			templates = append(templates, summarizeLaunchTemplate(tmpl))
-			if limit > 0 && len(templates) >= limit {
-				break
-			}
-		}
-		if limit > 0 && len(templates) >= limit {
-			break
 		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":          regionOrDefault(usedRegion),
+		"region":          awsutil.RegionOrDefault(usedRegion),
 		"launchTemplates": templates,
 		"count":           len(templates),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetLaunchTemplate(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	id := strings.TrimSpace(toString(req.Arguments["launchTemplateId"]))
-	name := strings.TrimSpace(toString(req.Arguments["name"]))
+	id := strings.TrimSpace(awsutil.ToString(req.Arguments["launchTemplateId"]))
+	name := strings.TrimSpace(awsutil.ToString(req.Arguments["name"]))
 	if id == "" && name == "" {
-		return errorResult(errors.New("launchTemplateId or name is required")), errors.New("launchTemplateId or name is required")
+		return awsutil.ErrorResult(errors.New("launchTemplateId or name is required")), errors.New("launchTemplateId or name is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeLaunchTemplatesInput{}
 	if id != "" {
@@ -1097,112 +1385,116 @@ func (s *Service) handleGetLaunchTemplate(ctx context.Context, req mcp.ToolReque
 	}
 	out, err := client.DescribeLaunchTemplates(ctx, input)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.LaunchTemplates) == 0 {
 		key := name
 		if id != "" {
 			key = id
 		}
-		return errorResult(fmt.Errorf("launch template %s not found", key)), fmt.Errorf("launch template %s not found", key)
+		return awsutil.ErrorResult(fmt.Errorf("launch template %s not found", key)), fmt.Errorf("launch template %s not found", key)
 	}
 	result := map[string]any{
-		"region":         regionOrDefault(usedRegion),
+		"region":         awsutil.RegionOrDefault(usedRegion),
 		"launchTemplate": summarizeLaunchTemplate(out.LaunchTemplates[0]),
 	}
 	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(result)}, nil
 }
 
 func (s *Service) handleListLaunchConfigurations(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	names := toStringSlice(req.Arguments["launchConfigurationNames"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	names := awsutil.ToStringSlice(req.Arguments["launchConfigurationNames"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.asgClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &autoscaling.DescribeLaunchConfigurationsInput{}
 	if len(names) > 0 {
 		input.LaunchConfigurationNames = names
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var configs []map[string]any
 	for {
 		out, err := client.DescribeLaunchConfigurations(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, cfg := range out.LaunchConfigurations {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.LaunchConfigurations), out.NextToken)
+		for _, cfg := range out.LaunchConfigurations[start:end] {
 			configs = append(configs, summarizeLaunchConfiguration(cfg))
-			if limit > 0 && len(configs) >= limit {
-				break
-			}
 		}
-		if limit > 0 && len(configs) >= limit {
-			break
-		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":               regionOrDefault(usedRegion),
+		"region":               awsutil.RegionOrDefault(usedRegion),
 		"launchConfigurations": configs,
 		"count":                len(configs),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetLaunchConfiguration(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	name := toString(req.Arguments["launchConfigurationName"])
+	name := awsutil.ToString(req.Arguments["launchConfigurationName"])
 	if name == "" {
-		return errorResult(errors.New("launchConfigurationName is required")), errors.New("launchConfigurationName is required")
+		return awsutil.ErrorResult(errors.New("launchConfigurationName is required")), errors.New("launchConfigurationName is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.asgClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeLaunchConfigurations(ctx, &autoscaling.DescribeLaunchConfigurationsInput{
 		LaunchConfigurationNames: []string{name},
 	})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.LaunchConfigurations) == 0 {
-		return errorResult(fmt.Errorf("launch configuration %s not found", name)), fmt.Errorf("launch configuration %s not found", name)
+		return awsutil.ErrorResult(fmt.Errorf("launch configuration %s not found", name)), fmt.Errorf("launch configuration %s not found", name)
 	}
 	result := map[string]any{
-		"region":              regionOrDefault(usedRegion),
+		"region":              awsutil.RegionOrDefault(usedRegion),
 		"launchConfiguration": summarizeLaunchConfiguration(out.LaunchConfigurations[0]),
 	}
 	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(result)}, nil
 }
 
 func (s *Service) handleGetInstanceIAM(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	instanceID := toString(req.Arguments["instanceId"])
+	instanceID := awsutil.ToString(req.Arguments["instanceId"])
 	if instanceID == "" {
-		return errorResult(errors.New("instanceId is required")), errors.New("instanceId is required")
+		return awsutil.ErrorResult(errors.New("instanceId is required")), errors.New("instanceId is required")
 	}
 	if s.iamClient == nil {
-		return errorResult(errors.New("iam client not available")), errors.New("iam client not available")
+		return awsutil.ErrorResult(errors.New("iam client not available")), errors.New("iam client not available")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	ec2Client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := ec2Client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{InstanceIds: []string{instanceID}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	instance, found := findInstance(out.Reservations, instanceID)
 	if !found {
-		return errorResult(fmt.Errorf("instance %s not found", instanceID)), fmt.Errorf("instance %s not found", instanceID)
+		return awsutil.ErrorResult(fmt.Errorf("instance %s not found", instanceID)), fmt.Errorf("instance %s not found", instanceID)
 	}
 	if instance.IamInstanceProfile == nil || instance.IamInstanceProfile.Arn == nil {
 		result := map[string]any{
-			"region":     regionOrDefault(usedRegion),
+			"region":     awsutil.RegionOrDefault(usedRegion),
 			"instanceId": instanceID,
 			"iam":        "no instance profile attached",
 		}
@@ -1211,45 +1503,54 @@ func (s *Service) handleGetInstanceIAM(ctx context.Context, req mcp.ToolRequest)
 	profileArn := aws.ToString(instance.IamInstanceProfile.Arn)
 	profileName := instanceProfileNameFromArn(profileArn)
 	if profileName == "" {
-		return errorResult(fmt.Errorf("unable to parse instance profile name from ARN: %s", profileArn)), fmt.Errorf("unable to parse instance profile name from ARN: %s", profileArn)
+		return awsutil.ErrorResult(fmt.Errorf("unable to parse instance profile name from ARN: %s", profileArn)), fmt.Errorf("unable to parse instance profile name from ARN: %s", profileArn)
 	}
 	iamClient, _, err := s.iamClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	profileOut, err := iamClient.GetInstanceProfile(ctx, &iam.GetInstanceProfileInput{InstanceProfileName: aws.String(profileName)})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	profile := profileOut.InstanceProfile
 	result := map[string]any{
-		"region":     regionOrDefault(usedRegion),
+		"region":     awsutil.RegionOrDefault(usedRegion),
 		"instanceId": instanceID,
 		"profile":    summarizeInstanceProfile(profile),
 	}
+	if profile != nil && awsutil.ToBool(req.Arguments["expandPolicies"], true) {
+		policies, warnings := expandInstanceRoles(ctx, iamClient, profile.Roles)
+		result["policies"] = summarizeRolePolicies(policies)
+		result["permissions"] = permissionSurface(policies)
+		result["capabilities"] = capabilityReport(policies)
+		if len(warnings) > 0 {
+			result["warnings"] = warnings
+		}
+	}
 	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(result)}, nil
 }
 
 func (s *Service) handleGetSecurityGroupRules(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	groupID := toString(req.Arguments["groupId"])
+	groupID := awsutil.ToString(req.Arguments["groupId"])
 	if groupID == "" {
-		return errorResult(errors.New("groupId is required")), errors.New("groupId is required")
+		return awsutil.ErrorResult(errors.New("groupId is required")), errors.New("groupId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{GroupIds: []string{groupID}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.SecurityGroups) == 0 {
-		return errorResult(fmt.Errorf("security group %s not found", groupID)), fmt.Errorf("security group %s not found", groupID)
+		return awsutil.ErrorResult(fmt.Errorf("security group %s not found", groupID)), fmt.Errorf("security group %s not found", groupID)
 	}
 	sg := out.SecurityGroups[0]
 	result := map[string]any{
-		"region":   regionOrDefault(usedRegion),
+		"region":   awsutil.RegionOrDefault(usedRegion),
 		"groupId":  groupID,
 		"inbound":  summarizePermissions(sg.IpPermissions),
 		"outbound": summarizePermissions(sg.IpPermissionsEgress),
@@ -1258,13 +1559,13 @@ func (s *Service) handleGetSecurityGroupRules(ctx context.Context, req mcp.ToolR
 }
 
 func (s *Service) handleListSpotInstanceRequests(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	ids := toStringSlice(req.Arguments["spotInstanceRequestIds"])
-	states := toStringSlice(req.Arguments["states"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	ids := awsutil.ToStringSlice(req.Arguments["spotInstanceRequestIds"])
+	states := awsutil.ToStringSlice(req.Arguments["states"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeSpotInstanceRequestsInput{}
 	if len(ids) > 0 {
@@ -1276,130 +1577,178 @@ func (s *Service) handleListSpotInstanceRequests(ctx context.Context, req mcp.To
 			Values: states,
 		})
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var requests []map[string]any
 	for {
 		out, err := client.DescribeSpotInstanceRequests(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, req := range out.SpotInstanceRequests {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.SpotInstanceRequests), out.NextToken)
+		for _, req := range out.SpotInstanceRequests[start:end] {
 			requests = append(requests, summarizeSpotRequest(req))
-			if limit > 0 && len(requests) >= limit {
-				break
-			}
 		}
-		if limit > 0 && len(requests) >= limit {
-			break
-		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":               regionOrDefault(usedRegion),
+		"region":               awsutil.RegionOrDefault(usedRegion),
 		"spotInstanceRequests": requests,
 		"count":                len(requests),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetSpotInstanceRequest(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	requestID := toString(req.Arguments["spotInstanceRequestId"])
+	requestID := awsutil.ToString(req.Arguments["spotInstanceRequestId"])
 	if requestID == "" {
-		return errorResult(errors.New("spotInstanceRequestId is required")), errors.New("spotInstanceRequestId is required")
+		return awsutil.ErrorResult(errors.New("spotInstanceRequestId is required")), errors.New("spotInstanceRequestId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeSpotInstanceRequests(ctx, &ec2.DescribeSpotInstanceRequestsInput{SpotInstanceRequestIds: []string{requestID}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.SpotInstanceRequests) == 0 {
-		return errorResult(fmt.Errorf("spot instance request %s not found", requestID)), fmt.Errorf("spot instance request %s not found", requestID)
+		return awsutil.ErrorResult(fmt.Errorf("spot instance request %s not found", requestID)), fmt.Errorf("spot instance request %s not found", requestID)
 	}
 	result := map[string]any{
-		"region":              regionOrDefault(usedRegion),
+		"region":              awsutil.RegionOrDefault(usedRegion),
 		"spotInstanceRequest": summarizeSpotRequest(out.SpotInstanceRequests[0]),
 	}
 	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(result)}, nil
 }
 
 func (s *Service) handleListCapacityReservations(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	ids := toStringSlice(req.Arguments["capacityReservationIds"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	ids := awsutil.ToStringSlice(req.Arguments["capacityReservationIds"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeCapacityReservationsInput{}
 	if len(ids) > 0 {
 		input.CapacityReservationIds = ids
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var reservations []map[string]any
 	for {
 		out, err := client.DescribeCapacityReservations(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, res := range out.CapacityReservations {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.CapacityReservations), out.NextToken)
+		for _, res := range out.CapacityReservations[start:end] {
 			reservations = append(reservations, summarizeCapacityReservation(res))
-			if limit > 0 && len(reservations) >= limit {
-				break
-			}
-		}
-		if limit > 0 && len(reservations) >= limit {
-			break
 		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":               regionOrDefault(usedRegion),
+		"region":               awsutil.RegionOrDefault(usedRegion),
 		"capacityReservations": reservations,
 		"count":                len(reservations),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetCapacityReservation(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	resID := toString(req.Arguments["capacityReservationId"])
+	resID := awsutil.ToString(req.Arguments["capacityReservationId"])
 	if resID == "" {
-		return errorResult(errors.New("capacityReservationId is required")), errors.New("capacityReservationId is required")
+		return awsutil.ErrorResult(errors.New("capacityReservationId is required")), errors.New("capacityReservationId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeCapacityReservations(ctx, &ec2.DescribeCapacityReservationsInput{CapacityReservationIds: []string{resID}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.CapacityReservations) == 0 {
-		return errorResult(fmt.Errorf("capacity reservation %s not found", resID)), fmt.Errorf("capacity reservation %s not found", resID)
+		return awsutil.ErrorResult(fmt.Errorf("capacity reservation %s not found", resID)), fmt.Errorf("capacity reservation %s not found", resID)
 	}
 	result := map[string]any{
-		"region":              regionOrDefault(usedRegion),
+		"region":              awsutil.RegionOrDefault(usedRegion),
 		"capacityReservation": summarizeCapacityReservation(out.CapacityReservations[0]),
 	}
 	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(result)}, nil
 }
 
+func (s *Service) handleGetCapacityReservationUsage(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	resID := awsutil.ToString(req.Arguments["capacityReservationId"])
+	if resID == "" {
+		return awsutil.ErrorResult(errors.New("capacityReservationId is required")), errors.New("capacityReservationId is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	out, err := client.DescribeCapacityReservations(ctx, &ec2.DescribeCapacityReservationsInput{CapacityReservationIds: []string{resID}})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	if len(out.CapacityReservations) == 0 {
+		return awsutil.ErrorResult(fmt.Errorf("capacity reservation %s not found", resID)), fmt.Errorf("capacity reservation %s not found", resID)
+	}
+	reservation := out.CapacityReservations[0]
+	input := &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{{Name: aws.String("capacity-reservation-id"), Values: []string{resID}}},
+	}
+	var instances []ec2types.Instance
+	for {
+		page, err := client.DescribeInstances(ctx, input)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		for _, reservation := range page.Reservations {
+			instances = append(instances, reservation.Instances...)
+		}
+		if aws.ToString(page.NextToken) == "" {
+			break
+		}
+		input.NextToken = page.NextToken
+	}
+	result := summarizeCapacityReservationUsage(reservation, instances)
+	result["region"] = awsutil.RegionOrDefault(usedRegion)
+	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(result)}, nil
+}
+
 func (s *Service) handleListVolumes(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	ids := toStringSlice(req.Arguments["volumeIds"])
-	instanceID := toString(req.Arguments["instanceId"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	ids := awsutil.ToStringSlice(req.Arguments["volumeIds"])
+	instanceID := awsutil.ToString(req.Arguments["instanceId"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeVolumesInput{}
 	if len(ids) > 0 {
@@ -1411,67 +1760,71 @@ func (s *Service) handleListVolumes(ctx context.Context, req mcp.ToolRequest) (m
 			Values: []string{instanceID},
 		})
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var volumes []map[string]any
 	for {
 		out, err := client.DescribeVolumes(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, vol := range out.Volumes {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.Volumes), out.NextToken)
+		for _, vol := range out.Volumes[start:end] {
 			volumes = append(volumes, summarizeVolume(vol))
-			if limit > 0 && len(volumes) >= limit {
-				break
-			}
-		}
-		if limit > 0 && len(volumes) >= limit {
-			break
 		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":  regionOrDefault(usedRegion),
+		"region":  awsutil.RegionOrDefault(usedRegion),
 		"volumes": volumes,
 		"count":   len(volumes),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetVolume(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	volumeID := toString(req.Arguments["volumeId"])
+	volumeID := awsutil.ToString(req.Arguments["volumeId"])
 	if volumeID == "" {
-		return errorResult(errors.New("volumeId is required")), errors.New("volumeId is required")
+		return awsutil.ErrorResult(errors.New("volumeId is required")), errors.New("volumeId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeVolumes(ctx, &ec2.DescribeVolumesInput{VolumeIds: []string{volumeID}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.Volumes) == 0 {
-		return errorResult(fmt.Errorf("volume %s not found", volumeID)), fmt.Errorf("volume %s not found", volumeID)
+		return awsutil.ErrorResult(fmt.Errorf("volume %s not found", volumeID)), fmt.Errorf("volume %s not found", volumeID)
 	}
 	result := map[string]any{
-		"region": regionOrDefault(usedRegion),
+		"region": awsutil.RegionOrDefault(usedRegion),
 		"volume": summarizeVolume(out.Volumes[0]),
 	}
 	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(result)}, nil
 }
 
 func (s *Service) handleListSnapshots(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	ids := toStringSlice(req.Arguments["snapshotIds"])
-	owners := toStringSlice(req.Arguments["ownerIds"])
-	volumeID := toString(req.Arguments["volumeId"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	ids := awsutil.ToStringSlice(req.Arguments["snapshotIds"])
+	owners := awsutil.ToStringSlice(req.Arguments["ownerIds"])
+	volumeID := awsutil.ToString(req.Arguments["volumeId"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeSnapshotsInput{}
 	if len(ids) > 0 {
@@ -1487,70 +1840,74 @@ func (s *Service) handleListSnapshots(ctx context.Context, req mcp.ToolRequest)
 			Values: []string{volumeID},
 		})
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var snaps []map[string]any
 	for {
 		out, err := client.DescribeSnapshots(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, snap := range out.Snapshots {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.Snapshots), out.NextToken)
+		for _, snap := range out.Snapshots[start:end] {
 			snaps = append(snaps, summarizeSnapshot(snap))
-			if limit > 0 && len(snaps) >= limit {
-				break
-			}
 		}
-		if limit > 0 && len(snaps) >= limit {
-			break
-		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":    regionOrDefault(usedRegion),
+		"region":    awsutil.RegionOrDefault(usedRegion),
 		"snapshots": snaps,
 		"count":     len(snaps),
 		"owners":    owners,
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetSnapshot(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	snapshotID := toString(req.Arguments["snapshotId"])
+	snapshotID := awsutil.ToString(req.Arguments["snapshotId"])
 	if snapshotID == "" {
-		return errorResult(errors.New("snapshotId is required")), errors.New("snapshotId is required")
+		return awsutil.ErrorResult(errors.New("snapshotId is required")), errors.New("snapshotId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeSnapshots(ctx, &ec2.DescribeSnapshotsInput{
 		SnapshotIds: []string{snapshotID},
 		OwnerIds:    []string{"self"},
 	})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.Snapshots) == 0 {
-		return errorResult(fmt.Errorf("snapshot %s not found", snapshotID)), fmt.Errorf("snapshot %s not found", snapshotID)
+		return awsutil.ErrorResult(fmt.Errorf("snapshot %s not found", snapshotID)), fmt.Errorf("snapshot %s not found", snapshotID)
 	}
 	result := map[string]any{
-		"region":   regionOrDefault(usedRegion),
+		"region":   awsutil.RegionOrDefault(usedRegion),
 		"snapshot": summarizeSnapshot(out.Snapshots[0]),
 	}
 	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(result)}, nil
 }
 
 func (s *Service) handleListVolumeAttachments(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	volumeID := toString(req.Arguments["volumeId"])
-	instanceID := toString(req.Arguments["instanceId"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	volumeID := awsutil.ToString(req.Arguments["volumeId"])
+	instanceID := awsutil.ToString(req.Arguments["instanceId"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeVolumesInput{}
 	if volumeID != "" {
@@ -1562,53 +1919,56 @@ func (s *Service) handleListVolumeAttachments(ctx context.Context, req mcp.ToolR
 			Values: []string{instanceID},
 		})
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var attachments []map[string]any
 	for {
 		out, err := client.DescribeVolumes(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
+		var page []map[string]any
 		for _, vol := range out.Volumes {
 			for _, att := range vol.Attachments {
-				attachments = append(attachments, map[string]any{
+				page = append(page, awsutil.NormalizeTimes(map[string]any{
 					"volumeId":            aws.ToString(vol.VolumeId),
 					"instanceId":          aws.ToString(att.InstanceId),
 					"state":               att.State,
 					"device":              aws.ToString(att.Device),
 					"attachTime":          att.AttachTime,
 					"deleteOnTermination": att.DeleteOnTermination,
-				})
-				if limit > 0 && len(attachments) >= limit {
-					break
-				}
-			}
-			if limit > 0 && len(attachments) >= limit {
-				break
+				}))
 			}
 		}
-		if limit > 0 && len(attachments) >= limit {
-			break
-		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(page), out.NextToken)
+		attachments = append(attachments, page[start:end]...)
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":      regionOrDefault(usedRegion),
+		"region":      awsutil.RegionOrDefault(usedRegion),
 		"attachments": attachments,
 		"count":       len(attachments),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleListPlacementGroups(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	names := toStringSlice(req.Arguments["groupNames"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	names := awsutil.ToStringSlice(req.Arguments["groupNames"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribePlacementGroupsInput{}
 	if len(names) > 0 {
@@ -1616,7 +1976,7 @@ func (s *Service) handleListPlacementGroups(ctx context.Context, req mcp.ToolReq
 	}
 	out, err := client.DescribePlacementGroups(ctx, input)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	var groups []map[string]any
 	for _, group := range out.PlacementGroups {
@@ -1626,7 +1986,7 @@ func (s *Service) handleListPlacementGroups(ctx context.Context, req mcp.ToolReq
 		}
 	}
 	data := map[string]any{
-		"region":          regionOrDefault(usedRegion),
+		"region":          awsutil.RegionOrDefault(usedRegion),
 		"placementGroups": groups,
 		"count":           len(groups),
 	}
@@ -1634,92 +1994,96 @@ func (s *Service) handleListPlacementGroups(ctx context.Context, req mcp.ToolReq
 }
 
 func (s *Service) handleGetPlacementGroup(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	name := toString(req.Arguments["groupName"])
+	name := awsutil.ToString(req.Arguments["groupName"])
 	if name == "" {
-		return errorResult(errors.New("groupName is required")), errors.New("groupName is required")
+		return awsutil.ErrorResult(errors.New("groupName is required")), errors.New("groupName is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribePlacementGroups(ctx, &ec2.DescribePlacementGroupsInput{GroupNames: []string{name}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.PlacementGroups) == 0 {
-		return errorResult(fmt.Errorf("placement group %s not found", name)), fmt.Errorf("placement group %s not found", name)
+		return awsutil.ErrorResult(fmt.Errorf("placement group %s not found", name)), fmt.Errorf("placement group %s not found", name)
 	}
 	result := map[string]any{
-		"region":         regionOrDefault(usedRegion),
+		"region":         awsutil.RegionOrDefault(usedRegion),
 		"placementGroup": summarizePlacementGroup(out.PlacementGroups[0]),
 	}
 	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(result)}, nil
 }
 
 func (s *Service) handleListInstanceStatus(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	ids := toStringSlice(req.Arguments["instanceIds"])
-	includeAll := toBool(req.Arguments["includeAll"], true)
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	ids := awsutil.ToStringSlice(req.Arguments["instanceIds"])
+	includeAll := awsutil.ToBool(req.Arguments["includeAll"], true)
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeInstanceStatusInput{IncludeAllInstances: aws.Bool(includeAll)}
 	if len(ids) > 0 {
 		input.InstanceIds = ids
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var statuses []map[string]any
 	for {
 		out, err := client.DescribeInstanceStatus(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, status := range out.InstanceStatuses {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.InstanceStatuses), out.NextToken)
+		for _, status := range out.InstanceStatuses[start:end] {
 			statuses = append(statuses, summarizeInstanceStatus(status))
-			if limit > 0 && len(statuses) >= limit {
-				break
-			}
-		}
-		if limit > 0 && len(statuses) >= limit {
-			break
 		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":   regionOrDefault(usedRegion),
+		"region":   awsutil.RegionOrDefault(usedRegion),
 		"statuses": statuses,
 		"count":    len(statuses),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetInstanceStatus(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	instanceID := toString(req.Arguments["instanceId"])
+	instanceID := awsutil.ToString(req.Arguments["instanceId"])
 	if instanceID == "" {
-		return errorResult(errors.New("instanceId is required")), errors.New("instanceId is required")
+		return awsutil.ErrorResult(errors.New("instanceId is required")), errors.New("instanceId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeInstanceStatus(ctx, &ec2.DescribeInstanceStatusInput{
 		InstanceIds:         []string{instanceID},
 		IncludeAllInstances: aws.Bool(true),
 	})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.InstanceStatuses) == 0 {
-		return errorResult(fmt.Errorf("instance status %s not found", instanceID)), fmt.Errorf("instance status %s not found", instanceID)
+		return awsutil.ErrorResult(fmt.Errorf("instance status %s not found", instanceID)), fmt.Errorf("instance status %s not found", instanceID)
 	}
 	result := map[string]any{
-		"region": regionOrDefault(usedRegion),
+		"region": awsutil.RegionOrDefault(usedRegion),
 		"status": summarizeInstanceStatus(out.InstanceStatuses[0]),
 	}
 	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(result)}, nil
@@ -1730,7 +2094,7 @@ func summarizeInstance(inst ec2types.Instance) map[string]any {
 	for _, sg := range inst.SecurityGroups {
 		sgIDs = append(sgIDs, aws.ToString(sg.GroupId))
 	}
-	return map[string]any{
+	return awsutil.WithAge(awsutil.NormalizeTimes(map[string]any{
 		"id":                 aws.ToString(inst.InstanceId),
 		"state":              inst.State,
 		"type":               inst.InstanceType,
@@ -1744,8 +2108,61 @@ func summarizeInstance(inst ec2types.Instance) map[string]any {
 		"iamInstanceProfile": inst.IamInstanceProfile,
 		"launchTime":         inst.LaunchTime,
 		"securityGroupIds":   sgIDs,
-		"tags":               tagMap(inst.Tags),
+		"tags":               awsutil.TagMap(inst.Tags),
+	}), inst.LaunchTime)
+}
+
+// summarizeInstancePlacement covers the capacity details (tenancy, dedicated
+// host, purchase lifecycle) left out of summarizeInstance by default.
+func summarizeInstancePlacement(inst ec2types.Instance) map[string]any {
+	lifecycle := string(inst.InstanceLifecycle)
+	if lifecycle == "" {
+		lifecycle = "on-demand"
+	}
+	out := map[string]any{"lifecycle": lifecycle}
+	if inst.Placement != nil {
+		out["tenancy"] = inst.Placement.Tenancy
+		out["hostId"] = aws.ToString(inst.Placement.HostId)
+		out["affinity"] = aws.ToString(inst.Placement.Affinity)
+		out["placementGroup"] = aws.ToString(inst.Placement.GroupName)
+		if inst.Placement.PartitionNumber != nil {
+			out["partitionNumber"] = aws.ToInt32(inst.Placement.PartitionNumber)
+		}
+	}
+	if id := aws.ToString(inst.SpotInstanceRequestId); id != "" {
+		out["spotInstanceRequestId"] = id
 	}
+	if id := aws.ToString(inst.CapacityReservationId); id != "" {
+		out["capacityReservationId"] = id
+	}
+	return out
+}
+
+// summarizeInstanceMetadataOptions reports the IMDS settings left out of
+// summarizeInstance by default. imdsv1Allowed is true unless tokens are
+// required, since IMDSv1 requests are accepted whenever tokens are optional.
+func summarizeInstanceMetadataOptions(inst ec2types.Instance) map[string]any {
+	opts := inst.MetadataOptions
+	if opts == nil {
+		return map[string]any{"imdsv1Allowed": true}
+	}
+	return map[string]any{
+		"httpTokens":              opts.HttpTokens,
+		"httpEndpoint":            opts.HttpEndpoint,
+		"httpPutResponseHopLimit": aws.ToInt32(opts.HttpPutResponseHopLimit),
+		"instanceMetadataTags":    opts.InstanceMetadataTags,
+		"state":                   opts.State,
+		"imdsv1Allowed":           imdsv1Allowed(opts),
+	}
+}
+
+// imdsv1Allowed is true when the metadata endpoint is on and session tokens
+// are not required.
+func imdsv1Allowed(opts *ec2types.InstanceMetadataOptionsResponse) bool {
+	if opts == nil {
+		return true
+	}
+	return opts.HttpEndpoint != ec2types.InstanceMetadataEndpointStateDisabled && opts.HttpTokens != ec2types.HttpTokensStateRequired
 }
 
 func summarizeASG(group autotypes.AutoScalingGroup) map[string]any {
@@ -1798,6 +2215,74 @@ func summarizeLoadBalancer(lb elbtypes.LoadBalancer) map[string]any {
 	}
 }
 
+// summarizeLoadBalancerAttributes pulls out the attributes that matter when
+// chasing 5xx/timeouts. HTTP/2 and desync mitigation only exist on ALBs.
+func summarizeLoadBalancerAttributes(arn string, attrs []elbtypes.LoadBalancerAttribute) map[string]any {
+	values := map[string]string{}
+	for _, attr := range attrs {
+		values[aws.ToString(attr.Key)] = aws.ToString(attr.Value)
+	}
+	lbType := loadBalancerTypeFromARN(arn)
+	if lbType == "" {
+		if _, ok := values["routing.http2.enabled"]; ok {
+			lbType = "application"
+		}
+	}
+	accessLogs := map[string]any{
+		"enabled": values["access_logs.s3.enabled"] == "true",
+	}
+	if bucket := values["access_logs.s3.bucket"]; bucket != "" {
+		accessLogs["bucket"] = bucket
+	}
+	if prefix := values["access_logs.s3.prefix"]; prefix != "" {
+		accessLogs["prefix"] = prefix
+	}
+	result := map[string]any{
+		"loadBalancerArn":    arn,
+		"accessLogs":         accessLogs,
+		"deletionProtection": values["deletion_protection.enabled"] == "true",
+		"attributes":         values,
+	}
+	if lbType != "" {
+		result["type"] = lbType
+	}
+	if value, ok := values["idle_timeout.timeout_seconds"]; ok {
+		result["idleTimeoutSeconds"] = argconv.Int(value, 0)
+	}
+	if value, ok := values["load_balancing.cross_zone.enabled"]; ok {
+		result["crossZoneEnabled"] = value == "true"
+	}
+	if lbType == "application" {
+		if value, ok := values["routing.http2.enabled"]; ok {
+			result["http2Enabled"] = value == "true"
+		}
+		if value, ok := values["routing.http.desync_mitigation_mode"]; ok {
+			result["desyncMitigationMode"] = value
+		}
+	}
+	return result
+}
+
+// loadBalancerTypeFromARN maps the ARN's loadbalancer/<app|net|gwy>/ segment
+// to the load balancer type.
+func loadBalancerTypeFromARN(arn string) string {
+	_, resource, ok := strings.Cut(arn, ":loadbalancer/")
+	if !ok {
+		return ""
+	}
+	kind, _, _ := strings.Cut(resource, "/")
+	switch kind {
+	case "app":
+		return "application"
+	case "net":
+		return "network"
+	case "gwy":
+		return "gateway"
+	default:
+		return ""
+	}
+}
+
 func summarizeTargetGroup(group elbtypes.TargetGroup) map[string]any {
 	return map[string]any{
 		"arn":                 aws.ToString(group.TargetGroupArn),
@@ -1882,7 +2367,7 @@ func summarizeScalingPolicy(policy autotypes.ScalingPolicy) map[string]any {
 }
 
 func summarizeScalingActivity(activity autotypes.Activity) map[string]any {
-	return map[string]any{
+	return awsutil.NormalizeTimes(map[string]any{
 		"id":               aws.ToString(activity.ActivityId),
 		"status":           activity.StatusCode,
 		"description":      aws.ToString(activity.Description),
@@ -1892,22 +2377,22 @@ func summarizeScalingActivity(activity autotypes.Activity) map[string]any {
 		"endTime":          activity.EndTime,
 		"details":          aws.ToString(activity.Details),
 		"progress":         activity.Progress,
-	}
+	})
 }
 
 func summarizeLaunchTemplate(tmpl ec2types.LaunchTemplate) map[string]any {
-	return map[string]any{
+	return awsutil.NormalizeTimes(map[string]any{
 		"id":             aws.ToString(tmpl.LaunchTemplateId),
 		"name":           aws.ToString(tmpl.LaunchTemplateName),
 		"createdBy":      aws.ToString(tmpl.CreatedBy),
 		"createTime":     tmpl.CreateTime,
 		"defaultVersion": tmpl.DefaultVersionNumber,
 		"latestVersion":  tmpl.LatestVersionNumber,
-	}
+	})
 }
 
 func summarizeLaunchConfiguration(cfg autotypes.LaunchConfiguration) map[string]any {
-	return map[string]any{
+	return awsutil.NormalizeTimes(map[string]any{
 		"name":               aws.ToString(cfg.LaunchConfigurationName),
 		"imageId":            aws.ToString(cfg.ImageId),
 		"instanceType":       aws.ToString(cfg.InstanceType),
@@ -1917,7 +2402,7 @@ func summarizeLaunchConfiguration(cfg autotypes.LaunchConfiguration) map[string]
 		"keyName":            aws.ToString(cfg.KeyName),
 		"associatePublicIp":  cfg.AssociatePublicIpAddress,
 		"ebsOptimized":       cfg.EbsOptimized,
-	}
+	})
 }
 
 func summarizeInstanceProfile(profile *iamtypes.InstanceProfile) map[string]any {
@@ -1932,13 +2417,13 @@ func summarizeInstanceProfile(profile *iamtypes.InstanceProfile) map[string]any
 			"path": aws.ToString(role.Path),
 		})
 	}
-	return map[string]any{
+	return awsutil.NormalizeTimes(map[string]any{
 		"name":    aws.ToString(profile.InstanceProfileName),
 		"arn":     aws.ToString(profile.Arn),
 		"path":    aws.ToString(profile.Path),
 		"roles":   roles,
 		"created": profile.CreateDate,
-	}
+	})
 }
 
 func summarizePermissions(perms []ec2types.IpPermission) []map[string]any {
@@ -1992,7 +2477,7 @@ func summarizePermissions(perms []ec2types.IpPermission) []map[string]any {
 }
 
 func summarizeSpotRequest(req ec2types.SpotInstanceRequest) map[string]any {
-	return map[string]any{
+	return awsutil.NormalizeTimes(map[string]any{
 		"id":         aws.ToString(req.SpotInstanceRequestId),
 		"state":      req.State,
 		"status":     req.Status,
@@ -2003,11 +2488,11 @@ func summarizeSpotRequest(req ec2types.SpotInstanceRequest) map[string]any {
 		"validUntil": req.ValidUntil,
 		"launchSpec": req.LaunchSpecification,
 		"createTime": req.CreateTime,
-	}
+	})
 }
 
 func summarizeCapacityReservation(res ec2types.CapacityReservation) map[string]any {
-	return map[string]any{
+	return awsutil.NormalizeTimes(map[string]any{
 		"id":               aws.ToString(res.CapacityReservationId),
 		"state":            res.State,
 		"instanceType":     res.InstanceType,
@@ -2018,7 +2503,53 @@ func summarizeCapacityReservation(res ec2types.CapacityReservation) map[string]a
 		"startDate":        res.StartDate,
 		"endDate":          res.EndDate,
 		"endDateType":      res.EndDateType,
+	})
+}
+
+// summarizeCapacityReservationUsage reports how much of a reservation is
+// consumed. Utilization comes from the reservation's own counts; instances
+// are the ones currently associated with it, and only pending/running ones
+// occupy a slot.
+func summarizeCapacityReservationUsage(res ec2types.CapacityReservation, instances []ec2types.Instance) map[string]any {
+	total := aws.ToInt32(res.TotalInstanceCount)
+	available := aws.ToInt32(res.AvailableInstanceCount)
+	used := total - available
+	var consuming, inactive []map[string]any
+	for _, inst := range instances {
+		entry := map[string]any{
+			"instanceId":   aws.ToString(inst.InstanceId),
+			"instanceType": inst.InstanceType,
+			"launchTime":   inst.LaunchTime,
+		}
+		if inst.State != nil {
+			entry["state"] = inst.State.Name
+		}
+		if inst.State != nil && (inst.State.Name == ec2types.InstanceStateNameRunning || inst.State.Name == ec2types.InstanceStateNamePending) {
+			consuming = append(consuming, entry)
+		} else {
+			inactive = append(inactive, entry)
+		}
+	}
+	utilization := 0.0
+	if total > 0 {
+		utilization = math.Round(float64(used)/float64(total)*1000) / 10
 	}
+	result := map[string]any{
+		"capacityReservation": summarizeCapacityReservation(res),
+		"totalSlots":          total,
+		"usedSlots":           used,
+		"idleSlots":           available,
+		"utilizationPercent":  utilization,
+		"instances":           consuming,
+		"instanceCount":       len(consuming),
+	}
+	if len(inactive) > 0 {
+		result["inactiveInstances"] = inactive
+	}
+	if res.State == ec2types.CapacityReservationStateActive && available > 0 {
+		result["idleWarning"] = fmt.Sprintf("%d of %d reserved slots are idle and still billed", available, total)
+	}
+	return awsutil.NormalizeTimes(result)
 }
 
 func summarizeVolume(vol ec2types.Volume) map[string]any {
@@ -2031,7 +2562,7 @@ func summarizeVolume(vol ec2types.Volume) map[string]any {
 			"attachTime": att.AttachTime,
 		})
 	}
-	return map[string]any{
+	return awsutil.WithAge(awsutil.NormalizeTimes(map[string]any{
 		"id":               aws.ToString(vol.VolumeId),
 		"state":            vol.State,
 		"type":             vol.VolumeType,
@@ -2042,13 +2573,13 @@ func summarizeVolume(vol ec2types.Volume) map[string]any {
 		"encrypted":        vol.Encrypted,
 		"snapshotId":       aws.ToString(vol.SnapshotId),
 		"attachments":      attachments,
-		"tags":             tagMap(vol.Tags),
+		"tags":             awsutil.TagMap(vol.Tags),
 		"createTime":       vol.CreateTime,
-	}
+	}), vol.CreateTime)
 }
 
 func summarizeSnapshot(snap ec2types.Snapshot) map[string]any {
-	return map[string]any{
+	return awsutil.NormalizeTimes(map[string]any{
 		"id":         aws.ToString(snap.SnapshotId),
 		"state":      snap.State,
 		"volumeId":   aws.ToString(snap.VolumeId),
@@ -2057,8 +2588,8 @@ func summarizeSnapshot(snap ec2types.Snapshot) map[string]any {
 		"ownerId":    aws.ToString(snap.OwnerId),
 		"progress":   aws.ToString(snap.Progress),
 		"encrypted":  snap.Encrypted,
-		"tags":       tagMap(snap.Tags),
-	}
+		"tags":       awsutil.TagMap(snap.Tags),
+	})
 }
 
 func summarizePlacementGroup(group ec2types.PlacementGroup) map[string]any {
@@ -2067,7 +2598,7 @@ func summarizePlacementGroup(group ec2types.PlacementGroup) map[string]any {
 		"state":          group.State,
 		"strategy":       group.Strategy,
 		"partitionCount": group.PartitionCount,
-		"tags":           tagMap(group.Tags),
+		"tags":           awsutil.TagMap(group.Tags),
 	}
 }
 
@@ -2102,6 +2633,22 @@ func findInstance(reservations []ec2types.Reservation, instanceID string) (ec2ty
 	return ec2types.Instance{}, false
 }
 
+// instanceStateValues merges the legacy singular state argument with states
+// so both land in one instance-state-name filter (values are ORed).
+func instanceStateValues(state string, states []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, value := range append([]string{state}, states...) {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		out = append(out, value)
+	}
+	return out
+}
+
 func instanceProfileNameFromArn(arn string) string {
 	if arn == "" {
 		return ""
@@ -2125,18 +2672,6 @@ func instanceProfileNameFromArn(arn string) string {
 	return name
 }
 
-func tagMap(tags []ec2types.Tag) map[string]string {
-	out := map[string]string{}
-	for _, tag := range tags {
-		key := aws.ToString(tag.Key)
-		if key == "" {
-			continue
-		}
-		out[key] = aws.ToString(tag.Value)
-	}
-	return out
-}
-
 func tagMapAutoScaling(tags []autotypes.TagDescription) map[string]string {
 	out := map[string]string{}
 	for _, tag := range tags {
@@ -2148,72 +2683,3 @@ func tagMapAutoScaling(tags []autotypes.TagDescription) map[string]string {
 	}
 	return out
 }
-
-func errorResult(err error) mcp.ToolResult {
-	return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}
-}
-
-func toString(value any) string {
-	if value == nil {
-		return ""
-	}
-	if s, ok := value.(string); ok {
-		return s
-	}
-	return fmt.Sprintf("%v", value)
-}
-
-func toStringSlice(value any) []string {
-	switch v := value.(type) {
-	case []string:
-		return v
-	case []any:
-		var out []string
-		for _, item := range v {
-			if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
-				out = append(out, s)
-			}
-		}
-		return out
-	case string:
-		if strings.TrimSpace(v) == "" {
-			return nil
-		}
-		return []string{v}
-	default:
-		return nil
-	}
-}
-
-func toBool(value any, fallback bool) bool {
-	if value == nil {
-		return fallback
-	}
-	if b, ok := value.(bool); ok {
-		return b
-	}
-	return fallback
-}
-
-func toInt(value any, fallback int) int {
-	switch v := value.(type) {
-	case int:
-		return v
-	case int64:
-		return int(v)
-	case float64:
-		return int(v)
-	case json.Number:
-		if parsed, err := v.Int64(); err == nil {
-			return int(parsed)
-		}
-	}
-	return fallback
-}
-
-func regionOrDefault(region string) string {
-	if strings.TrimSpace(region) == "" {
-		return "us-east-1"
-	}
-	return region
-}
@@ -1,44 +1,14 @@
 package awsec2
 
 import (
-	"encoding/json"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	autotypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
 )
 
-func TestTypeHelpers(t *testing.T) {
-	if got := toString(nil); got != "" {
-		t.Fatalf("expected empty string")
-	}
-	if got := toString(5); got != "5" {
-		t.Fatalf("unexpected toString: %q", got)
-	}
-	if got := toStringSlice([]any{"a", " ", "b"}); len(got) != 2 {
-		t.Fatalf("unexpected toStringSlice: %#v", got)
-	}
-	if got := toStringSlice("x"); len(got) != 1 || got[0] != "x" {
-		t.Fatalf("unexpected toStringSlice string: %#v", got)
-	}
-	if got := toBool(nil, true); got != true {
-		t.Fatalf("expected fallback toBool")
-	}
-	if got := toBool(false, true); got != false {
-		t.Fatalf("expected false toBool")
-	}
-	if got := toInt(json.Number("9"), 1); got != 9 {
-		t.Fatalf("unexpected toInt: %d", got)
-	}
-	if got := toInt("bad", 2); got != 2 {
-		t.Fatalf("expected fallback toInt")
-	}
-	if got := regionOrDefault(""); got != "us-east-1" {
-		t.Fatalf("expected default region")
-	}
-}
-
 func TestTagMapAutoScaling(t *testing.T) {
 	tags := []autotypes.TagDescription{
 		{Key: aws.String("env"), Value: aws.String("prod")},
@@ -65,6 +35,20 @@ func TestSummarizers(t *testing.T) {
 	if instSummary["id"] != "i-123" {
 		t.Fatalf("unexpected instance summary: %#v", instSummary)
 	}
+	if _, ok := instSummary["placement"]; ok {
+		t.Fatalf("placement details should be opt-in: %#v", instSummary)
+	}
+	if placement := summarizeInstancePlacement(instance); placement["lifecycle"] != "on-demand" || placement["spotInstanceRequestId"] != nil {
+		t.Fatalf("unexpected on-demand placement: %#v", placement)
+	}
+	instance.InstanceLifecycle = ec2types.InstanceLifecycleTypeSpot
+	instance.SpotInstanceRequestId = aws.String("sir-1")
+	instance.Placement.Tenancy = ec2types.TenancyHost
+	instance.Placement.HostId = aws.String("h-1")
+	placement := summarizeInstancePlacement(instance)
+	if placement["lifecycle"] != "spot" || placement["spotInstanceRequestId"] != "sir-1" || placement["hostId"] != "h-1" || placement["tenancy"] != ec2types.TenancyHost {
+		t.Fatalf("unexpected spot placement: %#v", placement)
+	}
 	asg := autotypes.AutoScalingGroup{
 		AutoScalingGroupName: aws.String("asg"),
 		Instances: []autotypes.Instance{
@@ -76,3 +60,60 @@ func TestSummarizers(t *testing.T) {
 		t.Fatalf("unexpected asg summary: %#v", asgSummary)
 	}
 }
+
+func TestSummarizeLoadBalancerAttributesNetwork(t *testing.T) {
+	attrs := []elbtypes.LoadBalancerAttribute{
+		{Key: aws.String("access_logs.s3.enabled"), Value: aws.String("false")},
+		{Key: aws.String("deletion_protection.enabled"), Value: aws.String("true")},
+		{Key: aws.String("load_balancing.cross_zone.enabled"), Value: aws.String("true")},
+	}
+	got := summarizeLoadBalancerAttributes("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/nlb/abc", attrs)
+	if got["type"] != "network" || got["deletionProtection"] != true || got["crossZoneEnabled"] != true {
+		t.Fatalf("unexpected summary: %#v", got)
+	}
+	if _, ok := got["http2Enabled"]; ok {
+		t.Fatalf("expected no ALB-only fields for NLB: %#v", got)
+	}
+	if _, ok := got["idleTimeoutSeconds"]; ok {
+		t.Fatalf("expected no idle timeout for NLB: %#v", got)
+	}
+	if logs := got["accessLogs"].(map[string]any); logs["enabled"] != false || logs["bucket"] != nil {
+		t.Fatalf("unexpected access logs: %#v", logs)
+	}
+	for arn, want := range map[string]string{
+		"arn:aws:elasticloadbalancing:us-east-1:1:loadbalancer/app/a/1": "application",
+		"arn:aws:elasticloadbalancing:us-east-1:1:loadbalancer/gwy/g/1": "gateway",
+		"arn:lb": "",
+	} {
+		if got := loadBalancerTypeFromARN(arn); got != want {
+			t.Fatalf("loadBalancerTypeFromARN(%q) = %q, want %q", arn, got, want)
+		}
+	}
+}
+
+func TestSummarizeCapacityReservationUsage(t *testing.T) {
+	res := ec2types.CapacityReservation{
+		CapacityReservationId:  aws.String("cr-1"),
+		State:                  ec2types.CapacityReservationStateActive,
+		TotalInstanceCount:     aws.Int32(4),
+		AvailableInstanceCount: aws.Int32(3),
+	}
+	instances := []ec2types.Instance{
+		{InstanceId: aws.String("i-run"), State: &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning}},
+		{InstanceId: aws.String("i-stopped"), State: &ec2types.InstanceState{Name: ec2types.InstanceStateNameStopped}},
+	}
+	got := summarizeCapacityReservationUsage(res, instances)
+	if got["usedSlots"] != int32(1) || got["idleSlots"] != int32(3) || got["utilizationPercent"] != 25.0 {
+		t.Fatalf("unexpected utilization: %#v", got)
+	}
+	if got["instanceCount"] != 1 || len(got["inactiveInstances"].([]map[string]any)) != 1 {
+		t.Fatalf("expected stopped instance to be inactive: %#v", got)
+	}
+	if got["idleWarning"] == nil {
+		t.Fatalf("expected idle warning for active reservation: %#v", got)
+	}
+	res.State = ec2types.CapacityReservationStateExpired
+	if got := summarizeCapacityReservationUsage(res, nil); got["idleWarning"] != nil {
+		t.Fatalf("expected no idle warning for expired reservation: %#v", got)
+	}
+}
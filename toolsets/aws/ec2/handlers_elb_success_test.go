@@ -88,6 +88,18 @@ func TestEC2ELBHandlersWithStubbedClient(t *testing.T) {
     </Rules>
   </DescribeRulesResult>
 </DescribeRulesResponse>`,
+		"DescribeLoadBalancerAttributes": `<DescribeLoadBalancerAttributesResponse xmlns="http://elasticloadbalancing.amazonaws.com/doc/2015-12-01/">
+  <DescribeLoadBalancerAttributesResult>
+    <Attributes>
+      <member><Key>access_logs.s3.enabled</Key><Value>true</Value></member>
+      <member><Key>access_logs.s3.bucket</Key><Value>lb-logs</Value></member>
+      <member><Key>idle_timeout.timeout_seconds</Key><Value>60</Value></member>
+      <member><Key>deletion_protection.enabled</Key><Value>false</Value></member>
+      <member><Key>routing.http2.enabled</Key><Value>true</Value></member>
+      <member><Key>routing.http.desync_mitigation_mode</Key><Value>defensive</Value></member>
+    </Attributes>
+  </DescribeLoadBalancerAttributesResult>
+</DescribeLoadBalancerAttributesResponse>`,
 	}
 	client := newELBTestClient(t, responses)
 	svc := &Service{
@@ -103,6 +115,20 @@ func TestEC2ELBHandlersWithStubbedClient(t *testing.T) {
 	if _, err := svc.handleGetLoadBalancer(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"loadBalancerArn": "arn:lb"}}); err != nil {
 		t.Fatalf("get load balancer: %v", err)
 	}
+	attrs, err := svc.handleGetLoadBalancerAttributes(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"loadBalancerArn": "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/lb-1/abc"}})
+	if err != nil {
+		t.Fatalf("get load balancer attributes: %v", err)
+	}
+	data := attrs.Data.(map[string]any)
+	if data["type"] != "application" || data["idleTimeoutSeconds"] != 60 || data["http2Enabled"] != true || data["desyncMitigationMode"] != "defensive" {
+		t.Fatalf("unexpected load balancer attributes: %#v", data)
+	}
+	if logs := data["accessLogs"].(map[string]any); logs["enabled"] != true || logs["bucket"] != "lb-logs" {
+		t.Fatalf("unexpected access logs: %#v", logs)
+	}
+	if _, err := svc.handleGetLoadBalancerAttributes(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected error without loadBalancerArn")
+	}
 	if _, err := svc.handleListTargetGroups(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"limit": 1}}); err != nil {
 		t.Fatalf("list target groups: %v", err)
 	}
@@ -141,3 +167,67 @@ func newELBTestClient(t *testing.T, responses map[string]string) *elasticloadbal
 	)
 	return elasticloadbalancingv2.NewFromConfig(cfg)
 }
+
+func TestEC2GetLoadBalancerHealth(t *testing.T) {
+	responses := map[string]string{
+		"DescribeTargetGroups": `<DescribeTargetGroupsResponse xmlns="http://elasticloadbalancing.amazonaws.com/doc/2015-12-01/">
+  <DescribeTargetGroupsResult>
+    <TargetGroups>
+      <member><TargetGroupArn>arn:tg-1</TargetGroupArn><TargetGroupName>tg-1</TargetGroupName></member>
+      <member><TargetGroupArn>arn:tg-2</TargetGroupArn><TargetGroupName>tg-2</TargetGroupName></member>
+    </TargetGroups>
+  </DescribeTargetGroupsResult>
+</DescribeTargetGroupsResponse>`,
+		"DescribeTargetHealth": `<DescribeTargetHealthResponse xmlns="http://elasticloadbalancing.amazonaws.com/doc/2015-12-01/">
+  <DescribeTargetHealthResult>
+    <TargetHealthDescriptions>
+      <member>
+        <Target><Id>i-1</Id><Port>80</Port></Target>
+        <TargetHealth><State>healthy</State></TargetHealth>
+      </member>
+      <member>
+        <Target><Id>i-2</Id><Port>80</Port></Target>
+        <TargetHealth><State>unhealthy</State><Reason>Target.FailedHealthChecks</Reason><Description>Health checks failed</Description></TargetHealth>
+      </member>
+      <member>
+        <Target><Id>i-3</Id><Port>80</Port></Target>
+        <TargetHealth><State>initial</State><Reason>Elb.RegistrationInProgress</Reason></TargetHealth>
+      </member>
+    </TargetHealthDescriptions>
+  </DescribeTargetHealthResult>
+</DescribeTargetHealthResponse>`,
+	}
+	client := newELBTestClient(t, responses)
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		elbClient: func(context.Context, string) (*elasticloadbalancingv2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	if _, err := svc.handleGetLoadBalancerHealth(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected error without loadBalancerArn")
+	}
+	result, err := svc.handleGetLoadBalancerHealth(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"loadBalancerArn": "arn:lb"}})
+	if err != nil {
+		t.Fatalf("get load balancer health: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if data["count"] != 2 || data["unhealthyTargetGroups"] != 2 {
+		t.Fatalf("unexpected summary: %#v", data)
+	}
+	totals := data["totals"].(map[string]int)
+	if totals["healthy"] != 2 || totals["unhealthy"] != 2 || totals["initial"] != 2 {
+		t.Fatalf("unexpected totals: %#v", totals)
+	}
+	group := data["targetGroups"].([]map[string]any)[0]
+	if group["targetGroupName"] != "tg-1" || group["targets"] != 3 {
+		t.Fatalf("unexpected target group summary: %#v", group)
+	}
+	if reasons := group["unhealthyReasons"].(map[string]int); reasons["Target.FailedHealthChecks"] != 1 {
+		t.Fatalf("unexpected unhealthy reasons: %#v", reasons)
+	}
+	unhealthy := group["unhealthyTargets"].([]map[string]any)
+	if len(unhealthy) != 1 || unhealthy[0]["id"] != "i-2" || unhealthy[0]["port"] != int32(80) {
+		t.Fatalf("unexpected unhealthy targets: %#v", unhealthy)
+	}
+}
@@ -167,6 +167,13 @@ func TestEC2HandlersWithStubbedClient(t *testing.T) {
 	if _, err := svc.handleGetCapacityReservation(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"capacityReservationId": "cr-1"}}); err != nil {
 		t.Fatalf("get capacity reservation: %v", err)
 	}
+	usage, err := svc.handleGetCapacityReservationUsage(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"capacityReservationId": "cr-1"}})
+	if err != nil {
+		t.Fatalf("get capacity reservation usage: %v", err)
+	}
+	if data := usage.Data.(map[string]any); data["utilizationPercent"] != 50.0 || data["idleSlots"] != int32(1) {
+		t.Fatalf("unexpected capacity reservation usage: %#v", data)
+	}
 	if _, err := svc.handleGetVolume(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"volumeId": "vol-1"}}); err != nil {
 		t.Fatalf("get volume: %v", err)
 	}
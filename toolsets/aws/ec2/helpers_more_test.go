@@ -1,17 +1,10 @@
 package awsec2
 
 import (
-	"encoding/json"
 	"testing"
 )
 
 func TestEC2TypeHelpers(t *testing.T) {
-	if got := toInt(json.Number("7"), 1); got != 7 {
-		t.Fatalf("unexpected toInt: %d", got)
-	}
-	if got := toBool(nil, true); got != true {
-		t.Fatalf("unexpected toBool fallback: %v", got)
-	}
 	if got := instanceProfileNameFromArn("arn:aws:iam::123:instance-profile/team/profile"); got != "profile" {
 		t.Fatalf("unexpected instance profile name: %s", got)
 	}
@@ -19,3 +12,13 @@ func TestEC2TypeHelpers(t *testing.T) {
 		t.Fatalf("expected empty instance profile name")
 	}
 }
+
+func TestInstanceStateValues(t *testing.T) {
+	got := instanceStateValues("Stopping", []string{"pending", "stopping", " "})
+	if len(got) != 2 || got[0] != "stopping" || got[1] != "pending" {
+		t.Fatalf("expected merged, deduplicated states, got %#v", got)
+	}
+	if got := instanceStateValues("", nil); got != nil {
+		t.Fatalf("expected no filter values, got %#v", got)
+	}
+}
@@ -0,0 +1,262 @@
+package awsec2
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/argconv"
+	awslib "rootcause/internal/aws"
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+const (
+	defaultAMIMaxAgeDays = 180
+	defaultAMIInstances  = 200
+	// imageLookupBatch bounds the image-id filter values per DescribeImages
+	// call.
+	imageLookupBatch = 100
+)
+
+func (s *Service) handleListImages(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	region := awsutil.ToString(req.Arguments["region"])
+	ids := awsutil.ToStringSlice(req.Arguments["imageIds"])
+	owners := awsutil.ToStringSlice(req.Arguments["owners"])
+	name := awsutil.ToString(req.Arguments["name"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input := &ec2.DescribeImagesInput{}
+	if len(ids) > 0 {
+		input.ImageIds = ids
+	}
+	// Explicit image ids are looked up wherever they live (shared, public or
+	// Amazon-owned); only an open-ended listing defaults to the caller's AMIs.
+	if len(owners) == 0 && len(ids) == 0 {
+		owners = []string{"self"}
+	}
+	input.Owners = owners
+	if name != "" {
+		input.Filters = append(input.Filters, ec2types.Filter{
+			Name:   aws.String("name"),
+			Values: []string{name},
+		})
+	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
+	var images []map[string]any
+	for {
+		out, err := client.DescribeImages(ctx, input)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.Images), out.NextToken)
+		for _, image := range out.Images[start:end] {
+			images = append(images, summarizeImage(image))
+		}
+		if !more {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+	data := map[string]any{
+		"region": awsutil.RegionOrDefault(usedRegion),
+		"images": images,
+		"count":  len(images),
+		"owners": owners,
+	}
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
+}
+
+func (s *Service) handleAnalyzeAMIUsage(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	region := awsutil.ToString(req.Arguments["region"])
+	ids := awsutil.ToStringSlice(req.Arguments["instanceIds"])
+	maxAgeDays := argconv.Int(req.Arguments["maxAgeDays"], defaultAMIMaxAgeDays)
+	if maxAgeDays <= 0 {
+		return awsutil.ErrorResult(errors.New("maxAgeDays must be positive")), errors.New("maxAgeDays must be positive")
+	}
+	limit := argconv.Int(req.Arguments["limit"], defaultAMIInstances)
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	instanceInput := &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{
+			{Name: aws.String("instance-state-name"), Values: []string{"pending", "running"}},
+		},
+	}
+	if len(ids) > 0 {
+		instanceInput.InstanceIds = ids
+	}
+	var instances []ec2types.Instance
+	truncated := false
+	for {
+		out, err := client.DescribeInstances(ctx, instanceInput)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		for _, reservation := range out.Reservations {
+			instances = append(instances, reservation.Instances...)
+		}
+		if limit > 0 && len(instances) >= limit {
+			truncated = len(instances) > limit || aws.ToString(out.NextToken) != ""
+			instances = instances[:limit]
+			break
+		}
+		if aws.ToString(out.NextToken) == "" {
+			break
+		}
+		instanceInput.NextToken = out.NextToken
+	}
+
+	seen := map[string]bool{}
+	var imageIDs []string
+	for _, inst := range instances {
+		if id := aws.ToString(inst.ImageId); id != "" && !seen[id] {
+			seen[id] = true
+			imageIDs = append(imageIDs, id)
+		}
+	}
+	// Filtering on image-id rather than passing ImageIds keeps deregistered
+	// AMIs from failing the whole call; they simply come back missing.
+	images := map[string]ec2types.Image{}
+	for start := 0; start < len(imageIDs); start += imageLookupBatch {
+		end := min(start+imageLookupBatch, len(imageIDs))
+		imageInput := &ec2.DescribeImagesInput{
+			Filters:           []ec2types.Filter{{Name: aws.String("image-id"), Values: imageIDs[start:end]}},
+			IncludeDeprecated: aws.Bool(true),
+			IncludeDisabled:   aws.Bool(true),
+		}
+		for {
+			out, err := client.DescribeImages(ctx, imageInput)
+			if err != nil {
+				return awsutil.ErrorResult(err), err
+			}
+			for _, image := range out.Images {
+				images[aws.ToString(image.ImageId)] = image
+			}
+			if aws.ToString(out.NextToken) == "" {
+				break
+			}
+			imageInput.NextToken = out.NextToken
+		}
+	}
+	data := assessAMIUsage(instances, images, maxAgeDays, time.Now())
+	data["region"] = awsutil.RegionOrDefault(usedRegion)
+	if truncated {
+		data["truncated"] = true
+	}
+	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+}
+
+// assessAMIUsage groups instances by the AMI they were launched from and
+// classifies each AMI: "deregistered" when it no longer resolves or is not
+// available, "old" when created more than maxAgeDays ago, otherwise "ok".
+// Deprecated AMIs are marked but keep their age-based status.
+func assessAMIUsage(instances []ec2types.Instance, images map[string]ec2types.Image, maxAgeDays int, now time.Time) map[string]any {
+	maxAge := time.Duration(maxAgeDays) * 24 * time.Hour
+	byImage := map[string][]string{}
+	var order []string
+	for _, inst := range instances {
+		imageID := aws.ToString(inst.ImageId)
+		if _, ok := byImage[imageID]; !ok {
+			order = append(order, imageID)
+		}
+		byImage[imageID] = append(byImage[imageID], aws.ToString(inst.InstanceId))
+	}
+	statusCounts := map[string]int{}
+	flaggedInstances := []string{}
+	var results []map[string]any
+	for _, imageID := range order {
+		instanceIDs := byImage[imageID]
+		entry := map[string]any{
+			"imageId":       imageID,
+			"instanceIds":   instanceIDs,
+			"instanceCount": len(instanceIDs),
+		}
+		status := "ok"
+		image, ok := images[imageID]
+		if !ok || image.State == ec2types.ImageStateDeregistered {
+			status = "deregistered"
+		} else {
+			entry["name"] = aws.ToString(image.Name)
+			entry["ownerId"] = aws.ToString(image.OwnerId)
+			entry["creationDate"] = aws.ToString(image.CreationDate)
+			if image.State != ec2types.ImageStateAvailable {
+				entry["state"] = image.State
+			}
+			if created, err := time.Parse(time.RFC3339, aws.ToString(image.CreationDate)); err == nil {
+				age := now.Sub(created)
+				entry["ageDays"] = int(age.Hours() / 24)
+				if age > maxAge {
+					status = "old"
+				}
+			}
+			if deprecation, err := time.Parse(time.RFC3339, aws.ToString(image.DeprecationTime)); err == nil && !deprecation.After(now) {
+				entry["deprecated"] = true
+			}
+		}
+		entry["status"] = status
+		statusCounts[status]++
+		if status != "ok" {
+			flaggedInstances = append(flaggedInstances, instanceIDs...)
+		}
+		results = append(results, entry)
+	}
+	sort.Strings(flaggedInstances)
+	return map[string]any{
+		"maxAgeDays":       maxAgeDays,
+		"images":           results,
+		"imageCount":       len(results),
+		"instanceCount":    len(instances),
+		"statusCounts":     statusCounts,
+		"flaggedInstances": flaggedInstances,
+		"evaluatedAt":      now.UTC(),
+	}
+}
+
+func summarizeImage(image ec2types.Image) map[string]any {
+	var devices []map[string]any
+	for _, mapping := range image.BlockDeviceMappings {
+		device := map[string]any{"deviceName": aws.ToString(mapping.DeviceName)}
+		if mapping.Ebs != nil {
+			device["snapshotId"] = aws.ToString(mapping.Ebs.SnapshotId)
+			device["volumeSize"] = aws.ToInt32(mapping.Ebs.VolumeSize)
+			device["volumeType"] = mapping.Ebs.VolumeType
+			device["encrypted"] = aws.ToBool(mapping.Ebs.Encrypted)
+		}
+		if name := aws.ToString(mapping.VirtualName); name != "" {
+			device["virtualName"] = name
+		}
+		devices = append(devices, device)
+	}
+	return map[string]any{
+		"id":                  aws.ToString(image.ImageId),
+		"name":                aws.ToString(image.Name),
+		"state":               image.State,
+		"creationDate":        aws.ToString(image.CreationDate),
+		"deprecationTime":     aws.ToString(image.DeprecationTime),
+		"architecture":        image.Architecture,
+		"platform":            aws.ToString(image.PlatformDetails),
+		"rootDeviceType":      image.RootDeviceType,
+		"ownerId":             aws.ToString(image.OwnerId),
+		"public":              aws.ToBool(image.Public),
+		"blockDeviceMappings": devices,
+		"tags":                awsutil.TagMap(image.Tags),
+	}
+}
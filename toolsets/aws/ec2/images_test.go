@@ -0,0 +1,163 @@
+package awsec2
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestAssessAMIUsage(t *testing.T) {
+	now := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	instances := []ec2types.Instance{
+		{InstanceId: aws.String("i-1"), ImageId: aws.String("ami-new")},
+		{InstanceId: aws.String("i-2"), ImageId: aws.String("ami-old")},
+		{InstanceId: aws.String("i-3"), ImageId: aws.String("ami-gone")},
+		{InstanceId: aws.String("i-4"), ImageId: aws.String("ami-new")},
+	}
+	images := map[string]ec2types.Image{
+		"ami-new": {ImageId: aws.String("ami-new"), State: ec2types.ImageStateAvailable, CreationDate: aws.String("2024-06-01T00:00:00.000Z")},
+		"ami-old": {
+			ImageId: aws.String("ami-old"), State: ec2types.ImageStateAvailable,
+			CreationDate: aws.String("2023-01-01T00:00:00.000Z"), DeprecationTime: aws.String("2024-01-01T00:00:00.000Z"),
+		},
+	}
+	got := assessAMIUsage(instances, images, 180, now)
+	counts := got["statusCounts"].(map[string]int)
+	if got["imageCount"] != 3 || counts["ok"] != 1 || counts["old"] != 1 || counts["deregistered"] != 1 {
+		t.Fatalf("unexpected counts: %#v", got)
+	}
+	if flagged := got["flaggedInstances"].([]string); len(flagged) != 2 || flagged[0] != "i-2" || flagged[1] != "i-3" {
+		t.Fatalf("unexpected flagged instances: %v", flagged)
+	}
+	entries := got["images"].([]map[string]any)
+	if entries[0]["instanceCount"] != 2 || entries[0]["ageDays"] != 9 {
+		t.Fatalf("unexpected ok entry: %#v", entries[0])
+	}
+	if entries[1]["status"] != "old" || entries[1]["deprecated"] != true {
+		t.Fatalf("unexpected old entry: %#v", entries[1])
+	}
+	if _, ok := entries[2]["name"]; ok || entries[2]["status"] != "deregistered" {
+		t.Fatalf("unexpected deregistered entry: %#v", entries[2])
+	}
+}
+
+func TestHandleListImagesAndAMIUsage(t *testing.T) {
+	recent := time.Now().UTC().Add(-48 * time.Hour).Format(time.RFC3339)
+	responses := map[string]string{
+		"DescribeImages": `<DescribeImagesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <imagesSet>
+    <item>
+      <imageId>ami-1</imageId>
+      <name>node</name>
+      <imageState>available</imageState>
+      <creationDate>` + recent + `</creationDate>
+      <architecture>arm64</architecture>
+      <blockDeviceMapping>
+        <item><deviceName>/dev/xvda</deviceName><ebs><snapshotId>snap-1</snapshotId><volumeSize>20</volumeSize><volumeType>gp3</volumeType></ebs></item>
+      </blockDeviceMapping>
+    </item>
+  </imagesSet>
+</DescribeImagesResponse>`,
+		"DescribeInstances": `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <reservationSet>
+    <item>
+      <instancesSet>
+        <item><instanceId>i-1</instanceId><imageId>ami-1</imageId></item>
+        <item><instanceId>i-2</instanceId><imageId>ami-2</imageId></item>
+      </instancesSet>
+    </item>
+  </reservationSet>
+</DescribeInstancesResponse>`,
+	}
+	client := newEC2TestClient(t, responses)
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleListImages(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("list images: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	images := data["images"].([]map[string]any)
+	if data["count"] != 1 || images[0]["architecture"] != ec2types.ArchitectureValuesArm64 {
+		t.Fatalf("unexpected images: %#v", data)
+	}
+	if devices := images[0]["blockDeviceMappings"].([]map[string]any); len(devices) != 1 || devices[0]["volumeSize"] != int32(20) {
+		t.Fatalf("unexpected block device mappings: %#v", devices)
+	}
+
+	result, err = svc.handleAnalyzeAMIUsage(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("analyze ami usage: %v", err)
+	}
+	data = result.Data.(map[string]any)
+	if flagged := data["flaggedInstances"].([]string); len(flagged) != 1 || flagged[0] != "i-2" {
+		t.Fatalf("expected only the deregistered AMI instance flagged, got %#v", data)
+	}
+	if _, err := svc.handleAnalyzeAMIUsage(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"maxAgeDays": -1}}); err == nil {
+		t.Fatalf("expected error for non-positive maxAgeDays")
+	}
+}
+
+func TestHandleListImagesOwnersDefault(t *testing.T) {
+	transport := &formRecorder{next: &queryRoundTripper{responses: map[string]string{
+		"DescribeImages": `<DescribeImagesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/"><imagesSet></imagesSet></DescribeImagesResponse>`,
+	}}}
+	client := newEC2Client(transport)
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	cases := []struct {
+		name  string
+		args  map[string]any
+		owner string
+	}{
+		{name: "listing", args: map[string]any{}, owner: "self"},
+		{name: "image ids", args: map[string]any{"imageIds": []any{"ami-shared"}}, owner: ""},
+		{name: "image ids with owners", args: map[string]any{"imageIds": []any{"ami-shared"}, "owners": []any{"amazon"}}, owner: "amazon"},
+	}
+	for _, tc := range cases {
+		transport.forms = nil
+		if _, err := svc.handleListImages(context.Background(), mcp.ToolRequest{Arguments: tc.args}); err != nil {
+			t.Fatalf("%s: list images: %v", tc.name, err)
+		}
+		if len(transport.forms) != 1 {
+			t.Fatalf("%s: expected one DescribeImages call, got %d", tc.name, len(transport.forms))
+		}
+		if got := transport.forms[0].Get("Owner.1"); got != tc.owner {
+			t.Fatalf("%s: expected owner %q, got %q", tc.name, tc.owner, got)
+		}
+	}
+}
+
+// formRecorder captures the query form of each request before passing it on.
+type formRecorder struct {
+	next  http.RoundTripper
+	forms []url.Values
+}
+
+func (rt *formRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _ := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	values, _ := url.ParseQuery(string(body))
+	rt.forms = append(rt.forms, values)
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return rt.next.RoundTrip(req)
+}
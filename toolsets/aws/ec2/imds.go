@@ -0,0 +1,97 @@
+package awsec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/argconv"
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+func (s *Service) handleAuditIMDS(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	region := awsutil.ToString(req.Arguments["region"])
+	ids := awsutil.ToStringSlice(req.Arguments["instanceIds"])
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
+	onlyFlagged := awsutil.ToBool(req.Arguments["onlyFlagged"], false)
+	limit := argconv.Int(req.Arguments["limit"], 200)
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	// Terminated instances keep their metadata options but no longer matter.
+	input := &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{
+			{Name: aws.String("instance-state-name"), Values: []string{"pending", "running", "stopping", "stopped"}},
+		},
+	}
+	if len(ids) > 0 {
+		input.InstanceIds = ids
+	}
+	if vpcID != "" {
+		input.Filters = append(input.Filters, ec2types.Filter{Name: aws.String("vpc-id"), Values: []string{vpcID}})
+	}
+	var instances []ec2types.Instance
+	truncated := false
+	for {
+		out, err := client.DescribeInstances(ctx, input)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		for _, reservation := range out.Reservations {
+			instances = append(instances, reservation.Instances...)
+		}
+		if limit > 0 && len(instances) >= limit {
+			truncated = len(instances) > limit || aws.ToString(out.NextToken) != ""
+			instances = instances[:limit]
+			break
+		}
+		if aws.ToString(out.NextToken) == "" {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+
+	results, flagged := auditInstanceMetadata(instances, onlyFlagged)
+	data := map[string]any{
+		"region":       awsutil.RegionOrDefault(usedRegion),
+		"instances":    results,
+		"count":        len(instances),
+		"flaggedCount": len(flagged),
+		"flagged":      flagged,
+	}
+	if truncated {
+		data["truncated"] = true
+	}
+	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+}
+
+// auditInstanceMetadata summarizes each instance's IMDS settings and returns
+// the ids of instances that still accept IMDSv1 (tokens not required). With
+// onlyFlagged, compliant instances are left out of the entries.
+func auditInstanceMetadata(instances []ec2types.Instance, onlyFlagged bool) ([]map[string]any, []string) {
+	var results []map[string]any
+	flagged := []string{}
+	for _, inst := range instances {
+		id := aws.ToString(inst.InstanceId)
+		allowed := imdsv1Allowed(inst.MetadataOptions)
+		if allowed {
+			flagged = append(flagged, id)
+		} else if onlyFlagged {
+			continue
+		}
+		entry := map[string]any{
+			"instanceId":      id,
+			"state":           inst.State,
+			"metadataOptions": summarizeInstanceMetadataOptions(inst),
+		}
+		if name := awsutil.TagMap(inst.Tags)["Name"]; name != "" {
+			entry["name"] = name
+		}
+		results = append(results, entry)
+	}
+	return results, flagged
+}
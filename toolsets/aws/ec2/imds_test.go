@@ -0,0 +1,86 @@
+package awsec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestAuditInstanceMetadata(t *testing.T) {
+	instances := []ec2types.Instance{
+		{InstanceId: aws.String("i-required"), MetadataOptions: &ec2types.InstanceMetadataOptionsResponse{
+			HttpTokens: ec2types.HttpTokensStateRequired, HttpEndpoint: ec2types.InstanceMetadataEndpointStateEnabled,
+		}},
+		{InstanceId: aws.String("i-optional"), MetadataOptions: &ec2types.InstanceMetadataOptionsResponse{
+			HttpTokens: ec2types.HttpTokensStateOptional, HttpEndpoint: ec2types.InstanceMetadataEndpointStateEnabled,
+		}},
+		{InstanceId: aws.String("i-disabled"), MetadataOptions: &ec2types.InstanceMetadataOptionsResponse{
+			HttpTokens: ec2types.HttpTokensStateOptional, HttpEndpoint: ec2types.InstanceMetadataEndpointStateDisabled,
+		}},
+		{InstanceId: aws.String("i-unknown")},
+	}
+	results, flagged := auditInstanceMetadata(instances, false)
+	if len(results) != 4 || len(flagged) != 2 || flagged[0] != "i-optional" || flagged[1] != "i-unknown" {
+		t.Fatalf("unexpected audit: %v %#v", flagged, results)
+	}
+	results, _ = auditInstanceMetadata(instances, true)
+	if len(results) != 2 {
+		t.Fatalf("expected only flagged instances, got %#v", results)
+	}
+}
+
+func TestHandleAuditIMDS(t *testing.T) {
+	responses := map[string]string{
+		"DescribeInstances": `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <reservationSet>
+    <item>
+      <instancesSet>
+        <item>
+          <instanceId>i-1</instanceId>
+          <metadataOptions><httpTokens>optional</httpTokens><httpEndpoint>enabled</httpEndpoint><httpPutResponseHopLimit>2</httpPutResponseHopLimit></metadataOptions>
+          <tagSet><item><key>Name</key><value>web</value></item></tagSet>
+        </item>
+        <item>
+          <instanceId>i-2</instanceId>
+          <metadataOptions><httpTokens>required</httpTokens><httpEndpoint>enabled</httpEndpoint><httpPutResponseHopLimit>1</httpPutResponseHopLimit></metadataOptions>
+        </item>
+      </instancesSet>
+    </item>
+  </reservationSet>
+</DescribeInstancesResponse>`,
+	}
+	client := newEC2TestClient(t, responses)
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleAuditIMDS(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("audit imds: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if data["count"] != 2 || data["flaggedCount"] != 1 {
+		t.Fatalf("unexpected audit summary: %#v", data)
+	}
+	first := data["instances"].([]map[string]any)[0]
+	opts := first["metadataOptions"].(map[string]any)
+	if first["name"] != "web" || opts["imdsv1Allowed"] != true || opts["httpPutResponseHopLimit"] != int32(2) {
+		t.Fatalf("unexpected instance entry: %#v", first)
+	}
+
+	result, err = svc.handleAuditIMDS(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"onlyFlagged": true, "limit": 1}})
+	if err != nil {
+		t.Fatalf("audit imds with limit: %v", err)
+	}
+	if data := result.Data.(map[string]any); data["count"] != 1 || data["truncated"] != true {
+		t.Fatalf("expected truncated result, got %#v", data)
+	}
+}
@@ -0,0 +1,284 @@
+package awsec2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"golang.org/x/sync/errgroup"
+
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+// instanceIAMConcurrency bounds the IAM calls made while expanding an
+// instance profile; IAM throttles bursts per account.
+const instanceIAMConcurrency = 4
+
+// instanceCapabilities are the common "can this instance reach X" questions
+// answered from the expanded role policies, with the actions each needs.
+var instanceCapabilities = map[string][]string{
+	"ssm":            {"ssm:UpdateInstanceInformation", "ssmmessages:CreateControlChannel", "ssmmessages:OpenControlChannel"},
+	"ecrPull":        {"ecr:GetAuthorizationToken", "ecr:BatchGetImage", "ecr:GetDownloadUrlForLayer"},
+	"cloudwatchLogs": {"logs:CreateLogStream", "logs:PutLogEvents"},
+}
+
+// rolePolicy is one attached or inline policy of an instance profile role.
+type rolePolicy struct {
+	role, name, arn string
+	statements      []policyStatement
+}
+
+type policyStatement struct {
+	allow        bool
+	actions      []string
+	notAction    bool
+	hasCondition bool
+}
+
+// expandInstanceRoles fetches every policy attached to the profile roles,
+// bounding the number of IAM calls in flight. A policy that cannot be read
+// is reported as a warning rather than failing the lookup.
+func expandInstanceRoles(ctx context.Context, client *iam.Client, roles []iamtypes.Role) ([]rolePolicy, []string) {
+	var policies []rolePolicy
+	var warnings []string
+	for _, role := range roles {
+		name := aws.ToString(role.RoleName)
+		attached, err := listRoleAttachedPolicies(ctx, client, name)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("list attached policies for role %s failed: %v", name, err))
+		}
+		policies = append(policies, attached...)
+		inline, err := listRoleInlinePolicies(ctx, client, name)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("list inline policies for role %s failed: %v", name, err))
+		}
+		policies = append(policies, inline...)
+	}
+
+	errs := make([]error, len(policies))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(instanceIAMConcurrency)
+	for i := range policies {
+		group.Go(func() error {
+			doc, err := rolePolicyDocument(groupCtx, client, policies[i])
+			if err != nil {
+				errs[i] = err
+				return nil
+			}
+			policies[i].statements = parsePolicyStatements(doc)
+			return nil
+		})
+	}
+	_ = group.Wait()
+	for i, err := range errs {
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("get policy %s on role %s failed: %v", policies[i].name, policies[i].role, err))
+		}
+	}
+	return policies, warnings
+}
+
+func listRoleAttachedPolicies(ctx context.Context, client *iam.Client, roleName string) ([]rolePolicy, error) {
+	var out []rolePolicy
+	paginator := iam.NewListAttachedRolePoliciesPaginator(client, &iam.ListAttachedRolePoliciesInput{RoleName: aws.String(roleName)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return out, err
+		}
+		for _, policy := range page.AttachedPolicies {
+			out = append(out, rolePolicy{role: roleName, name: aws.ToString(policy.PolicyName), arn: aws.ToString(policy.PolicyArn)})
+		}
+	}
+	return out, nil
+}
+
+func listRoleInlinePolicies(ctx context.Context, client *iam.Client, roleName string) ([]rolePolicy, error) {
+	var out []rolePolicy
+	paginator := iam.NewListRolePoliciesPaginator(client, &iam.ListRolePoliciesInput{RoleName: aws.String(roleName)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return out, err
+		}
+		for _, name := range page.PolicyNames {
+			out = append(out, rolePolicy{role: roleName, name: name})
+		}
+	}
+	return out, nil
+}
+
+// rolePolicyDocument returns the default version of a managed policy, or
+// the inline policy document when the policy has no ARN.
+func rolePolicyDocument(ctx context.Context, client *iam.Client, policy rolePolicy) (string, error) {
+	var encoded string
+	if policy.arn == "" {
+		out, err := client.GetRolePolicy(ctx, &iam.GetRolePolicyInput{RoleName: aws.String(policy.role), PolicyName: aws.String(policy.name)})
+		if err != nil {
+			return "", err
+		}
+		encoded = aws.ToString(out.PolicyDocument)
+	} else {
+		out, err := client.GetPolicy(ctx, &iam.GetPolicyInput{PolicyArn: aws.String(policy.arn)})
+		if err != nil {
+			return "", err
+		}
+		if out.Policy == nil {
+			return "", fmt.Errorf("policy %s not found", policy.arn)
+		}
+		version, err := client.GetPolicyVersion(ctx, &iam.GetPolicyVersionInput{PolicyArn: aws.String(policy.arn), VersionId: out.Policy.DefaultVersionId})
+		if err != nil {
+			return "", err
+		}
+		if version.PolicyVersion == nil {
+			return "", fmt.Errorf("policy %s has no default version", policy.arn)
+		}
+		encoded = aws.ToString(version.PolicyVersion.Document)
+	}
+	// IAM returns policy documents URL-encoded.
+	if decoded, err := url.QueryUnescape(encoded); err == nil {
+		return decoded, nil
+	}
+	return encoded, nil
+}
+
+func parsePolicyStatements(document string) []policyStatement {
+	var doc struct {
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(document), &doc); err != nil || len(doc.Statement) == 0 {
+		return nil
+	}
+	var raw []map[string]any
+	if err := json.Unmarshal(doc.Statement, &raw); err != nil {
+		var single map[string]any
+		if err := json.Unmarshal(doc.Statement, &single); err != nil {
+			return nil
+		}
+		raw = []map[string]any{single}
+	}
+	statements := make([]policyStatement, 0, len(raw))
+	for _, stmt := range raw {
+		entry := policyStatement{
+			allow:   strings.EqualFold(awsutil.ToString(stmt["Effect"]), "Allow"),
+			actions: awsutil.ToStringSlice(stmt["Action"]),
+		}
+		if _, ok := stmt["NotAction"]; ok {
+			entry.notAction = true
+			entry.actions = awsutil.ToStringSlice(stmt["NotAction"])
+		}
+		_, entry.hasCondition = stmt["Condition"]
+		statements = append(statements, entry)
+	}
+	return statements
+}
+
+// permissionSurface groups the allowed action patterns by service prefix,
+// e.g. {"ecr": ["ecr:BatchGetImage", "ecr:Get*"]}.
+func permissionSurface(policies []rolePolicy) map[string][]string {
+	seen := map[string]map[string]struct{}{}
+	for _, policy := range policies {
+		for _, stmt := range policy.statements {
+			if !stmt.allow || stmt.notAction {
+				continue
+			}
+			for _, action := range stmt.actions {
+				service := "*"
+				if idx := strings.Index(action, ":"); idx > 0 {
+					service = strings.ToLower(action[:idx])
+				}
+				if seen[service] == nil {
+					seen[service] = map[string]struct{}{}
+				}
+				seen[service][action] = struct{}{}
+			}
+		}
+	}
+	surface := make(map[string][]string, len(seen))
+	for service, actions := range seen {
+		list := make([]string, 0, len(actions))
+		for action := range actions {
+			list = append(list, action)
+		}
+		sort.Strings(list)
+		surface[service] = list
+	}
+	return surface
+}
+
+// capabilityReport checks instanceCapabilities against the policies. An
+// action counts as allowed when an Allow statement matches it and no
+// unconditional Deny does; conditions, resource scoping, permission
+// boundaries and SCPs are not evaluated.
+func capabilityReport(policies []rolePolicy) map[string]any {
+	report := map[string]any{}
+	for name, actions := range instanceCapabilities {
+		var missing []string
+		for _, action := range actions {
+			if !actionAllowed(policies, action) {
+				missing = append(missing, action)
+			}
+		}
+		entry := map[string]any{"allowed": len(missing) == 0}
+		if len(missing) > 0 {
+			entry["missing"] = missing
+		}
+		report[name] = entry
+	}
+	return report
+}
+
+func actionAllowed(policies []rolePolicy, action string) bool {
+	allowed := false
+	for _, policy := range policies {
+		for _, stmt := range policy.statements {
+			matched := statementMatches(stmt, action)
+			if !stmt.allow && matched && !stmt.hasCondition {
+				return false
+			}
+			if stmt.allow && matched {
+				allowed = true
+			}
+		}
+	}
+	return allowed
+}
+
+func statementMatches(stmt policyStatement, action string) bool {
+	matched := false
+	for _, pattern := range stmt.actions {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(action)); ok {
+			matched = true
+			break
+		}
+	}
+	if stmt.notAction {
+		return !matched
+	}
+	return matched
+}
+
+func summarizeRolePolicies(policies []rolePolicy) []map[string]any {
+	out := make([]map[string]any, 0, len(policies))
+	for _, policy := range policies {
+		entry := map[string]any{
+			"role":       policy.role,
+			"name":       policy.name,
+			"type":       "inline",
+			"statements": len(policy.statements),
+		}
+		if policy.arn != "" {
+			entry["type"] = "managed"
+			entry["arn"] = policy.arn
+		}
+		out = append(out, entry)
+	}
+	return out
+}
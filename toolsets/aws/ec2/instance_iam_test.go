@@ -103,6 +103,83 @@ func TestEC2GetInstanceIAM(t *testing.T) {
 	}
 }
 
+func TestEC2GetInstanceIAMExpandsPolicies(t *testing.T) {
+	ec2Client := newEC2TestClient(t, map[string]string{
+		"DescribeInstances": `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <reservationSet><item><instancesSet><item>
+    <instanceId>i-3</instanceId>
+    <iamInstanceProfile><arn>arn:aws:iam::123:instance-profile/node</arn><id>ip-1</id></iamInstanceProfile>
+  </item></instancesSet></item></reservationSet>
+</DescribeInstancesResponse>`,
+	})
+	managedDoc := url.QueryEscape(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["ecr:GetAuthorizationToken","ecr:BatchGetImage","ecr:GetDownloadUrlForLayer","ssm:*"],"Resource":"*"}]}`)
+	inlineDoc := url.QueryEscape(`{"Version":"2012-10-17","Statement":{"Effect":"Deny","Action":"ssmmessages:*","Resource":"*"}}`)
+	iamClient := newIAMTestClient(t, map[string]string{
+		"GetInstanceProfile": `<GetInstanceProfileResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <GetInstanceProfileResult><InstanceProfile>
+    <Path>/</Path><InstanceProfileName>node</InstanceProfileName><InstanceProfileId>IPID</InstanceProfileId>
+    <Arn>arn:aws:iam::123:instance-profile/node</Arn><CreateDate>2024-01-01T00:00:00Z</CreateDate>
+    <Roles><member><Path>/</Path><RoleName>node-role</RoleName><RoleId>RID</RoleId><Arn>arn:aws:iam::123:role/node-role</Arn><CreateDate>2024-01-01T00:00:00Z</CreateDate></member></Roles>
+  </InstanceProfile></GetInstanceProfileResult>
+</GetInstanceProfileResponse>`,
+		"ListAttachedRolePolicies": `<ListAttachedRolePoliciesResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <ListAttachedRolePoliciesResult><AttachedPolicies><member><PolicyName>node-access</PolicyName><PolicyArn>arn:aws:iam::123:policy/node-access</PolicyArn></member></AttachedPolicies><IsTruncated>false</IsTruncated></ListAttachedRolePoliciesResult>
+</ListAttachedRolePoliciesResponse>`,
+		"GetPolicy": `<GetPolicyResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <GetPolicyResult><Policy><PolicyName>node-access</PolicyName><Arn>arn:aws:iam::123:policy/node-access</Arn><DefaultVersionId>v1</DefaultVersionId></Policy></GetPolicyResult>
+</GetPolicyResponse>`,
+		"GetPolicyVersion": `<GetPolicyVersionResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <GetPolicyVersionResult><PolicyVersion><Document>` + managedDoc + `</Document><VersionId>v1</VersionId><IsDefaultVersion>true</IsDefaultVersion></PolicyVersion></GetPolicyVersionResult>
+</GetPolicyVersionResponse>`,
+		"ListRolePolicies": `<ListRolePoliciesResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <ListRolePoliciesResult><PolicyNames><member>no-session</member></PolicyNames><IsTruncated>false</IsTruncated></ListRolePoliciesResult>
+</ListRolePoliciesResponse>`,
+		"GetRolePolicy": `<GetRolePolicyResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <GetRolePolicyResult><RoleName>node-role</RoleName><PolicyName>no-session</PolicyName><PolicyDocument>` + inlineDoc + `</PolicyDocument></GetRolePolicyResult>
+</GetRolePolicyResponse>`,
+	})
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) {
+			return ec2Client, "us-east-1", nil
+		},
+		iamClient: func(context.Context, string) (*iam.Client, string, error) {
+			return iamClient, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleGetInstanceIAM(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"instanceId": "i-3"}})
+	if err != nil {
+		t.Fatalf("get instance iam: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if policies := data["policies"].([]map[string]any); len(policies) != 2 {
+		t.Fatalf("expected managed and inline policy, got %#v", policies)
+	}
+	permissions := data["permissions"].(map[string][]string)
+	if len(permissions["ecr"]) != 3 || len(permissions["ssm"]) != 1 {
+		t.Fatalf("unexpected permission surface: %#v", permissions)
+	}
+	capabilities := data["capabilities"].(map[string]any)
+	if capabilities["ecrPull"].(map[string]any)["allowed"] != true {
+		t.Fatalf("expected ecr pull allowed: %#v", capabilities)
+	}
+	ssm := capabilities["ssm"].(map[string]any)
+	if ssm["allowed"] != false || len(ssm["missing"].([]string)) != 2 {
+		t.Fatalf("expected ssm blocked by the deny: %#v", ssm)
+	}
+	if capabilities["cloudwatchLogs"].(map[string]any)["allowed"] != false {
+		t.Fatalf("expected logs not allowed: %#v", capabilities)
+	}
+
+	result, err = svc.handleGetInstanceIAM(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"instanceId": "i-3", "expandPolicies": false}})
+	if err != nil {
+		t.Fatalf("get instance iam: %v", err)
+	}
+	if _, ok := result.Data.(map[string]any)["capabilities"]; ok {
+		t.Fatalf("expected no expansion when expandPolicies is false")
+	}
+}
+
 func newIAMTestClient(t *testing.T, responses map[string]string) *iam.Client {
 	t.Helper()
 	transport := &iamRoundTripper{responses: responses}
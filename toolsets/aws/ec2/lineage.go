@@ -0,0 +1,220 @@
+package awsec2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/argconv"
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+// placeholderVolumeID is the VolumeId EC2 reports on snapshots that were
+// copied or created from an AMI rather than taken from a volume.
+const placeholderVolumeID = "vol-ffffffff"
+
+// copiedSnapshotPattern matches the description CopySnapshot writes, which
+// is the only record of where a copied snapshot came from.
+var copiedSnapshotPattern = regexp.MustCompile(`Copied (snap-[0-9a-f]+) from ([a-z0-9-]+)`)
+
+func (s *Service) handleTraceVolumeLineage(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	volumeID := awsutil.ToString(req.Arguments["volumeId"])
+	snapshotID := awsutil.ToString(req.Arguments["snapshotId"])
+	if (volumeID == "") == (snapshotID == "") {
+		err := errors.New("exactly one of volumeId or snapshotId is required")
+		return awsutil.ErrorResult(err), err
+	}
+	maxDepth := argconv.Int(req.Arguments["maxDepth"], 10)
+	if maxDepth <= 0 {
+		maxDepth = 10
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+
+	// Walk backward from the starting resource, alternating volume and
+	// source snapshot, until the chain ends or maxDepth is reached.
+	var ancestry []map[string]any
+	var findings []string
+	nextVolume, nextSnapshot := volumeID, snapshotID
+	var previous map[string]any
+	for depth := 0; depth < maxDepth && (nextVolume != "" || nextSnapshot != ""); depth++ {
+		var step map[string]any
+		if nextVolume != "" {
+			vol, found, err := describeVolumeByID(ctx, client, nextVolume)
+			if err != nil {
+				return awsutil.ErrorResult(err), err
+			}
+			if !found {
+				if depth == 0 {
+					err := fmt.Errorf("volume %s not found", nextVolume)
+					return awsutil.ErrorResult(err), err
+				}
+				findings = append(findings, fmt.Sprintf("source volume %s no longer exists; the chain ends here", nextVolume))
+				break
+			}
+			step = lineageVolumeStep(vol)
+			nextVolume, nextSnapshot = "", aws.ToString(vol.SnapshotId)
+		} else {
+			snap, found, err := describeSnapshotByID(ctx, client, nextSnapshot)
+			if err != nil {
+				return awsutil.ErrorResult(err), err
+			}
+			if !found {
+				if depth == 0 {
+					err := fmt.Errorf("snapshot %s not found", nextSnapshot)
+					return awsutil.ErrorResult(err), err
+				}
+				findings = append(findings, fmt.Sprintf("source snapshot %s no longer exists or is not shared with this account", nextSnapshot))
+				break
+			}
+			step = lineageSnapshotStep(snap)
+			nextVolume, nextSnapshot = aws.ToString(snap.VolumeId), ""
+			if nextVolume == placeholderVolumeID {
+				nextVolume = ""
+				if match := copiedSnapshotPattern.FindStringSubmatch(aws.ToString(snap.Description)); match != nil {
+					step["copiedFrom"] = map[string]any{"snapshotId": match[1], "region": match[2]}
+					findings = append(findings, fmt.Sprintf("snapshot %s is a copy of %s in %s; follow the chain there", aws.ToString(snap.SnapshotId), match[1], match[2]))
+				} else {
+					findings = append(findings, fmt.Sprintf("snapshot %s has no source volume recorded (copied or created from an AMI)", aws.ToString(snap.SnapshotId)))
+				}
+			}
+		}
+		if previous != nil {
+			findings = append(findings, lineageEncryptionFindings(step, previous)...)
+		}
+		ancestry = append(ancestry, step)
+		previous = step
+	}
+	if len(ancestry) == maxDepth && (nextVolume != "" || nextSnapshot != "") {
+		findings = append(findings, fmt.Sprintf("stopped after maxDepth %d steps", maxDepth))
+	}
+	if len(ancestry) > 1 && ancestry[0]["type"] == "volume" && ancestry[1]["type"] == "snapshot" {
+		if started, ok := ancestry[1]["startTime"].(string); ok && started != "" {
+			findings = append(findings, fmt.Sprintf("volume %s holds data as of snapshot %s, taken at %s", ancestry[0]["id"], ancestry[1]["id"], started))
+		}
+	}
+
+	// Walk one step forward: snapshots taken from a volume, or volumes
+	// restored from a snapshot.
+	var descendants []map[string]any
+	if volumeID != "" {
+		input := &ec2.DescribeSnapshotsInput{
+			OwnerIds: []string{"self"},
+			Filters:  []ec2types.Filter{{Name: aws.String("volume-id"), Values: []string{volumeID}}},
+		}
+		for {
+			out, err := client.DescribeSnapshots(ctx, input)
+			if err != nil {
+				return awsutil.ErrorResult(err), err
+			}
+			for _, snap := range out.Snapshots {
+				descendants = append(descendants, lineageSnapshotStep(snap))
+			}
+			if aws.ToString(out.NextToken) == "" {
+				break
+			}
+			input.NextToken = out.NextToken
+		}
+	} else {
+		input := &ec2.DescribeVolumesInput{
+			Filters: []ec2types.Filter{{Name: aws.String("snapshot-id"), Values: []string{snapshotID}}},
+		}
+		for {
+			out, err := client.DescribeVolumes(ctx, input)
+			if err != nil {
+				return awsutil.ErrorResult(err), err
+			}
+			for _, vol := range out.Volumes {
+				descendants = append(descendants, lineageVolumeStep(vol))
+			}
+			if aws.ToString(out.NextToken) == "" {
+				break
+			}
+			input.NextToken = out.NextToken
+		}
+	}
+
+	data := map[string]any{
+		"region":      awsutil.RegionOrDefault(usedRegion),
+		"ancestry":    ancestry,
+		"descendants": descendants,
+		"findings":    findings,
+	}
+	resource := fmt.Sprintf("ec2/volume/%s", volumeID)
+	if snapshotID != "" {
+		resource = fmt.Sprintf("ec2/snapshot/%s", snapshotID)
+	}
+	return mcp.ToolResult{
+		Data:     s.ctx.Redactor.RedactValue(data),
+		Metadata: mcp.ToolMetadata{Resources: []string{resource}},
+	}, nil
+}
+
+func describeVolumeByID(ctx context.Context, client *ec2.Client, id string) (ec2types.Volume, bool, error) {
+	// Filtering by id returns an empty list for deleted volumes instead of
+	// an InvalidVolume.NotFound error.
+	out, err := client.DescribeVolumes(ctx, &ec2.DescribeVolumesInput{
+		Filters: []ec2types.Filter{{Name: aws.String("volume-id"), Values: []string{id}}},
+	})
+	if err != nil {
+		return ec2types.Volume{}, false, err
+	}
+	if len(out.Volumes) == 0 {
+		return ec2types.Volume{}, false, nil
+	}
+	return out.Volumes[0], true, nil
+}
+
+func describeSnapshotByID(ctx context.Context, client *ec2.Client, id string) (ec2types.Snapshot, bool, error) {
+	out, err := client.DescribeSnapshots(ctx, &ec2.DescribeSnapshotsInput{
+		Filters: []ec2types.Filter{{Name: aws.String("snapshot-id"), Values: []string{id}}},
+	})
+	if err != nil {
+		return ec2types.Snapshot{}, false, err
+	}
+	if len(out.Snapshots) == 0 {
+		return ec2types.Snapshot{}, false, nil
+	}
+	return out.Snapshots[0], true, nil
+}
+
+func lineageVolumeStep(vol ec2types.Volume) map[string]any {
+	step := summarizeVolume(vol)
+	step["type"] = "volume"
+	step["kmsKeyId"] = aws.ToString(vol.KmsKeyId)
+	return step
+}
+
+func lineageSnapshotStep(snap ec2types.Snapshot) map[string]any {
+	step := summarizeSnapshot(snap)
+	step["type"] = "snapshot"
+	step["kmsKeyId"] = aws.ToString(snap.KmsKeyId)
+	step["description"] = aws.ToString(snap.Description)
+	return step
+}
+
+// lineageEncryptionFindings compares a source step with the step derived
+// from it; encryption or key changes explain KMS access errors on restore.
+func lineageEncryptionFindings(source, derived map[string]any) []string {
+	var findings []string
+	sourceEncrypted, _ := source["encrypted"].(*bool)
+	derivedEncrypted, _ := derived["encrypted"].(*bool)
+	sourceKey, _ := source["kmsKeyId"].(string)
+	derivedKey, _ := derived["kmsKeyId"].(string)
+	switch {
+	case !aws.ToBool(sourceEncrypted) && aws.ToBool(derivedEncrypted):
+		findings = append(findings, fmt.Sprintf("%s %s is encrypted but its source %s %s is not", derived["type"], derived["id"], source["type"], source["id"]))
+	case aws.ToBool(sourceEncrypted) && aws.ToBool(derivedEncrypted) && sourceKey != derivedKey:
+		findings = append(findings, fmt.Sprintf("%s %s uses KMS key %s but its source %s %s uses %s; both keys must be usable to restore", derived["type"], derived["id"], derivedKey, source["type"], source["id"], sourceKey))
+	}
+	return findings
+}
@@ -0,0 +1,66 @@
+package awsec2
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestHandleTraceVolumeLineage(t *testing.T) {
+	client := newEC2TestClient(t, map[string]string{
+		"DescribeVolumes": `<DescribeVolumesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <volumeSet>
+    <item>
+      <volumeId>vol-1</volumeId>
+      <snapshotId>snap-1</snapshotId>
+      <encrypted>true</encrypted>
+      <kmsKeyId>key-b</kmsKeyId>
+    </item>
+  </volumeSet>
+</DescribeVolumesResponse>`,
+		"DescribeSnapshots": `<DescribeSnapshotsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <snapshotSet>
+    <item>
+      <snapshotId>snap-1</snapshotId>
+      <volumeId>vol-ffffffff</volumeId>
+      <startTime>2024-01-01T00:00:00.000Z</startTime>
+      <description>[Copied snap-0abc from us-west-2]</description>
+      <encrypted>true</encrypted>
+      <kmsKeyId>key-a</kmsKeyId>
+    </item>
+  </snapshotSet>
+</DescribeSnapshotsResponse>`,
+	})
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleTraceVolumeLineage(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"volumeId": "vol-1"}})
+	if err != nil {
+		t.Fatalf("trace volume lineage: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	ancestry := data["ancestry"].([]map[string]any)
+	if len(ancestry) != 2 || ancestry[0]["type"] != "volume" || ancestry[1]["type"] != "snapshot" || ancestry[1]["kmsKeyId"] != "key-a" {
+		t.Fatalf("unexpected ancestry: %#v", ancestry)
+	}
+	findings := strings.Join(data["findings"].([]string), "\n")
+	for _, want := range []string{"copy of snap-0abc in us-west-2", "uses KMS key key-b", "as of snapshot snap-1, taken at 2024-01-01T00:00:00Z"} {
+		if !strings.Contains(findings, want) {
+			t.Fatalf("expected finding %q in:\n%s", want, findings)
+		}
+	}
+	if len(data["descendants"].([]map[string]any)) != 1 {
+		t.Fatalf("expected snapshot descendants: %#v", data["descendants"])
+	}
+	if _, err := svc.handleTraceVolumeLineage(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"volumeId": "vol-1", "snapshotId": "snap-1"}}); err == nil {
+		t.Fatalf("expected error when both ids are set")
+	}
+}
@@ -272,7 +272,10 @@ func TestASGListPagination(t *testing.T) {
 
 func newEC2SequenceClient(t *testing.T, responses map[string][]string) *ec2.Client {
 	t.Helper()
-	transport := &sequenceRoundTripper{responses: responses}
+	return newEC2Client(&sequenceRoundTripper{responses: responses})
+}
+
+func newEC2Client(transport http.RoundTripper) *ec2.Client {
 	cfg := aws.Config{
 		Region:      "us-east-1",
 		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
@@ -322,6 +325,7 @@ type sequenceRoundTripper struct {
 	mu        sync.Mutex
 	responses map[string][]string
 	index     map[string]int
+	requests  []url.Values
 }
 
 func (rt *sequenceRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -333,6 +337,7 @@ func (rt *sequenceRoundTripper) RoundTrip(req *http.Request) (*http.Response, er
 		action = req.URL.Query().Get("Action")
 	}
 	rt.mu.Lock()
+	rt.requests = append(rt.requests, values)
 	if rt.index == nil {
 		rt.index = map[string]int{}
 	}
@@ -360,3 +365,87 @@ func (rt *sequenceRoundTripper) RoundTrip(req *http.Request) (*http.Response, er
 		Request:    req,
 	}, nil
 }
+
+func TestEC2ListInstancesNextToken(t *testing.T) {
+	page := `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <reservationSet>
+    <item>
+      <instancesSet>
+        <item><instanceId>i-1</instanceId><placement><availabilityZone>us-east-1a</availabilityZone></placement></item>
+        <item><instanceId>i-2</instanceId><placement><availabilityZone>us-east-1a</availabilityZone></placement></item>
+      </instancesSet>
+    </item>
+  </reservationSet>
+</DescribeInstancesResponse>`
+	client := newEC2SequenceClient(t, map[string][]string{"DescribeInstances": {page, page}})
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	first, err := svc.handleListInstances(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"limit": 1}})
+	if err != nil {
+		t.Fatalf("list instances: %v", err)
+	}
+	data := first.Data.(map[string]any)
+	token, _ := data["nextToken"].(string)
+	if data["count"] != 1 || token == "" {
+		t.Fatalf("expected one instance and a nextToken, got %#v", data)
+	}
+	second, err := svc.handleListInstances(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"limit": 1, "nextToken": token}})
+	if err != nil {
+		t.Fatalf("list instances page 2: %v", err)
+	}
+	data = second.Data.(map[string]any)
+	instances := data["instances"].([]map[string]any)
+	if len(instances) != 1 || instances[0]["id"] != "i-2" {
+		t.Fatalf("expected i-2 on page 2, got %#v", instances)
+	}
+	if _, ok := data["nextToken"]; ok {
+		t.Fatalf("expected no nextToken on last page")
+	}
+}
+
+func TestEC2ListInstancesLongAWSToken(t *testing.T) {
+	// Real EC2 tokens are long base64 blobs that the redactor would
+	// otherwise mistake for secrets.
+	awsToken := "eyJ2IjoiMiIsImMiOiJBUUlDQUhnSEkzRXhhbXBsZVRva2VuVmFsdWUxMjM0NTY3ODkwYWJjZGVmIn0"
+	first := `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <reservationSet><item><instancesSet><item><instanceId>i-1</instanceId><placement><availabilityZone>us-east-1a</availabilityZone></placement></item></instancesSet></item></reservationSet>
+  <nextToken>` + awsToken + `</nextToken>
+</DescribeInstancesResponse>`
+	second := `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <reservationSet><item><instancesSet><item><instanceId>i-2</instanceId><placement><availabilityZone>us-east-1a</availabilityZone></placement></item></instancesSet></item></reservationSet>
+</DescribeInstancesResponse>`
+	transport := &sequenceRoundTripper{responses: map[string][]string{"DescribeInstances": {first, second}}}
+	client := newEC2Client(transport)
+	redactor := redact.New()
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redactor},
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleListInstances(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"limit": 1}})
+	if err != nil {
+		t.Fatalf("list instances: %v", err)
+	}
+	// The invoker re-redacts results but keeps the top-level cursor as is.
+	data := result.Data.(map[string]any)
+	token, _ := data["nextToken"].(string)
+	if token == "" {
+		t.Fatalf("expected a nextToken, got %#v", data)
+	}
+	result, err = svc.handleListInstances(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"limit": 1, "nextToken": token}})
+	if err != nil {
+		t.Fatalf("list instances page 2: %v", err)
+	}
+	instances := result.Data.(map[string]any)["instances"].([]map[string]any)
+	if len(instances) != 1 || instances[0]["id"] != "i-2" {
+		t.Fatalf("expected i-2 on page 2, got %#v", instances)
+	}
+	if got := transport.requests[1].Get("NextToken"); got != awsToken {
+		t.Fatalf("expected the AWS token to round-trip, sent %q", got)
+	}
+}
@@ -0,0 +1,357 @@
+package awsec2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+
+	"rootcause/internal/argconv"
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+// routingRequest is the sample request evaluated against ALB listener rules.
+type routingRequest struct {
+	host     string
+	path     string
+	method   string
+	headers  map[string]string
+	query    url.Values
+	sourceIP string
+}
+
+func routingRequestFromArgs(args map[string]any) routingRequest {
+	req := routingRequest{
+		host:     strings.TrimSpace(awsutil.ToString(args["host"])),
+		path:     strings.TrimSpace(awsutil.ToString(args["path"])),
+		method:   strings.ToUpper(strings.TrimSpace(awsutil.ToString(args["method"]))),
+		headers:  map[string]string{},
+		query:    url.Values{},
+		sourceIP: strings.TrimSpace(awsutil.ToString(args["sourceIp"])),
+	}
+	if host, _, err := net.SplitHostPort(req.host); err == nil {
+		req.host = host
+	}
+	if path, rawQuery, ok := strings.Cut(req.path, "?"); ok {
+		req.path = path
+		if parsed, err := url.ParseQuery(rawQuery); err == nil {
+			req.query = parsed
+		}
+	}
+	if headers, ok := args["headers"].(map[string]any); ok {
+		for name, value := range headers {
+			req.headers[strings.ToLower(name)] = awsutil.ToString(value)
+		}
+	}
+	if query, ok := args["query"].(map[string]any); ok {
+		for key, value := range query {
+			req.query.Add(key, awsutil.ToString(value))
+		}
+	}
+	return req
+}
+
+func (s *Service) handleExplainRouting(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	lbArn := strings.TrimSpace(awsutil.ToString(req.Arguments["loadBalancerArn"]))
+	if lbArn == "" {
+		return awsutil.ErrorResult(errors.New("loadBalancerArn is required")), errors.New("loadBalancerArn is required")
+	}
+	request := routingRequestFromArgs(req.Arguments)
+	port := argconv.Int(req.Arguments["port"], 0)
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.elbClient(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	listenerInput := &elasticloadbalancingv2.DescribeListenersInput{LoadBalancerArn: aws.String(lbArn)}
+	var listeners []elbtypes.Listener
+	for {
+		out, err := client.DescribeListeners(ctx, listenerInput)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		for _, listener := range out.Listeners {
+			// Only ALB listeners have content-based rules.
+			if listener.Protocol != elbtypes.ProtocolEnumHttp && listener.Protocol != elbtypes.ProtocolEnumHttps {
+				continue
+			}
+			if port > 0 && int(aws.ToInt32(listener.Port)) != port {
+				continue
+			}
+			listeners = append(listeners, listener)
+		}
+		if aws.ToString(out.NextMarker) == "" {
+			break
+		}
+		listenerInput.Marker = out.NextMarker
+	}
+	if len(listeners) == 0 {
+		err := fmt.Errorf("no HTTP/HTTPS listeners found for load balancer %s", lbArn)
+		return awsutil.ErrorResult(err), err
+	}
+	var results []map[string]any
+	for _, listener := range listeners {
+		rulesInput := &elasticloadbalancingv2.DescribeRulesInput{ListenerArn: listener.ListenerArn}
+		var rules []elbtypes.Rule
+		for {
+			out, err := client.DescribeRules(ctx, rulesInput)
+			if err != nil {
+				return awsutil.ErrorResult(err), err
+			}
+			rules = append(rules, out.Rules...)
+			if aws.ToString(out.NextMarker) == "" {
+				break
+			}
+			rulesInput.Marker = out.NextMarker
+		}
+		result := explainListenerRouting(rules, request)
+		result["listenerArn"] = aws.ToString(listener.ListenerArn)
+		result["port"] = aws.ToInt32(listener.Port)
+		result["protocol"] = listener.Protocol
+		results = append(results, result)
+	}
+	data := map[string]any{
+		"region":          awsutil.RegionOrDefault(usedRegion),
+		"loadBalancerArn": lbArn,
+		"request": map[string]any{
+			"host":     request.host,
+			"path":     request.path,
+			"method":   request.method,
+			"sourceIp": request.sourceIP,
+		},
+		"listeners": results,
+		"count":     len(results),
+	}
+	return mcp.ToolResult{
+		Data: s.ctx.Redactor.RedactValue(data),
+		Metadata: mcp.ToolMetadata{
+			Resources: []string{fmt.Sprintf("elbv2/load-balancer/%s", lbArn)},
+		},
+	}, nil
+}
+
+// explainListenerRouting evaluates rules in priority order the way an ALB
+// does and reports the first match, falling back to the default rule.
+func explainListenerRouting(rules []elbtypes.Rule, request routingRequest) map[string]any {
+	ordered := make([]elbtypes.Rule, 0, len(rules))
+	var defaultRule *elbtypes.Rule
+	for i := range rules {
+		if aws.ToBool(rules[i].IsDefault) {
+			defaultRule = &rules[i]
+			continue
+		}
+		ordered = append(ordered, rules[i])
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return rulePriority(ordered[i]) < rulePriority(ordered[j])
+	})
+	var evaluated []map[string]any
+	for _, rule := range ordered {
+		matched, mismatched, unevaluated := evaluateRuleConditions(rule.Conditions, request)
+		entry := map[string]any{
+			"priority": aws.ToString(rule.Priority),
+			"ruleArn":  aws.ToString(rule.RuleArn),
+			"matched":  matched,
+		}
+		if len(mismatched) > 0 {
+			entry["mismatched"] = mismatched
+		}
+		if len(unevaluated) > 0 {
+			entry["unevaluated"] = unevaluated
+		}
+		evaluated = append(evaluated, entry)
+		if matched {
+			return map[string]any{
+				"matchedRule":  summarizeListenerRule(rule),
+				"isDefault":    false,
+				"targetGroups": ruleTargetGroups(rule),
+				"evaluated":    evaluated,
+			}
+		}
+	}
+	result := map[string]any{
+		"isDefault": true,
+		"evaluated": evaluated,
+	}
+	if defaultRule != nil {
+		result["matchedRule"] = summarizeListenerRule(*defaultRule)
+		result["targetGroups"] = ruleTargetGroups(*defaultRule)
+	}
+	return result
+}
+
+func rulePriority(rule elbtypes.Rule) int {
+	priority, err := strconv.Atoi(aws.ToString(rule.Priority))
+	if err != nil {
+		return int(^uint(0) >> 1)
+	}
+	return priority
+}
+
+// evaluateRuleConditions ANDs the rule's conditions; values within one
+// condition are ORed. Conditions that need request data the caller did not
+// supply are reported as unevaluated and do not match.
+func evaluateRuleConditions(conditions []elbtypes.RuleCondition, request routingRequest) (bool, []string, []string) {
+	var mismatched, unevaluated []string
+	for _, condition := range conditions {
+		field := aws.ToString(condition.Field)
+		ok, evaluable := evaluateRuleCondition(condition, request)
+		switch {
+		case !evaluable:
+			unevaluated = append(unevaluated, field)
+		case !ok:
+			mismatched = append(mismatched, field)
+		}
+	}
+	return len(mismatched) == 0 && len(unevaluated) == 0, mismatched, unevaluated
+}
+
+func evaluateRuleCondition(condition elbtypes.RuleCondition, request routingRequest) (bool, bool) {
+	switch aws.ToString(condition.Field) {
+	case "host-header":
+		if request.host == "" {
+			return false, false
+		}
+		values, regexValues := condition.Values, []string(nil)
+		if cfg := condition.HostHeaderConfig; cfg != nil {
+			values = append(append([]string{}, values...), cfg.Values...)
+			regexValues = cfg.RegexValues
+		}
+		return matchesAny(values, regexValues, request.host, false), true
+	case "path-pattern":
+		if request.path == "" {
+			return false, false
+		}
+		values, regexValues := condition.Values, []string(nil)
+		if cfg := condition.PathPatternConfig; cfg != nil {
+			values = append(append([]string{}, values...), cfg.Values...)
+			regexValues = cfg.RegexValues
+		}
+		return matchesAny(values, regexValues, request.path, true), true
+	case "http-request-method":
+		if request.method == "" || condition.HttpRequestMethodConfig == nil {
+			return false, false
+		}
+		for _, method := range condition.HttpRequestMethodConfig.Values {
+			if method == request.method {
+				return true, true
+			}
+		}
+		return false, true
+	case "http-header":
+		cfg := condition.HttpHeaderConfig
+		if cfg == nil {
+			return false, false
+		}
+		value, ok := request.headers[strings.ToLower(aws.ToString(cfg.HttpHeaderName))]
+		if !ok {
+			return false, false
+		}
+		return matchesAny(cfg.Values, cfg.RegexValues, value, false), true
+	case "query-string":
+		cfg := condition.QueryStringConfig
+		if cfg == nil || len(request.query) == 0 {
+			return false, false
+		}
+		for _, pair := range cfg.Values {
+			for key, values := range request.query {
+				if pair.Key != nil && !wildcardMatch(aws.ToString(pair.Key), key, false) {
+					continue
+				}
+				for _, value := range values {
+					if wildcardMatch(aws.ToString(pair.Value), value, false) {
+						return true, true
+					}
+				}
+			}
+		}
+		return false, true
+	case "source-ip":
+		cfg := condition.SourceIpConfig
+		ip := net.ParseIP(request.sourceIP)
+		if cfg == nil || ip == nil {
+			return false, false
+		}
+		for _, cidr := range cfg.Values {
+			if _, network, err := net.ParseCIDR(cidr); err == nil && network.Contains(ip) {
+				return true, true
+			}
+		}
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+func matchesAny(patterns, regexPatterns []string, value string, caseSensitive bool) bool {
+	for _, pattern := range patterns {
+		if wildcardMatch(pattern, value, caseSensitive) {
+			return true
+		}
+	}
+	for _, pattern := range regexPatterns {
+		if re, err := regexp.Compile(pattern); err == nil && re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// wildcardMatch implements the ALB pattern syntax: * matches any run of
+// characters and ? matches exactly one.
+func wildcardMatch(pattern, value string, caseSensitive bool) bool {
+	var expr strings.Builder
+	expr.WriteString("^")
+	if !caseSensitive {
+		expr.WriteString("(?i)")
+	}
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+func ruleTargetGroups(rule elbtypes.Rule) []map[string]any {
+	var groups []map[string]any
+	for _, action := range rule.Actions {
+		if action.Type != elbtypes.ActionTypeEnumForward {
+			continue
+		}
+		if action.ForwardConfig != nil && len(action.ForwardConfig.TargetGroups) > 0 {
+			for _, tuple := range action.ForwardConfig.TargetGroups {
+				group := map[string]any{"targetGroupArn": aws.ToString(tuple.TargetGroupArn)}
+				if tuple.Weight != nil {
+					group["weight"] = aws.ToInt32(tuple.Weight)
+				}
+				groups = append(groups, group)
+			}
+			continue
+		}
+		if action.TargetGroupArn != nil {
+			groups = append(groups, map[string]any{"targetGroupArn": aws.ToString(action.TargetGroupArn)})
+		}
+	}
+	return groups
+}
@@ -0,0 +1,200 @@
+package awsec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func forwardRule(priority, targetGroup string, conditions ...elbtypes.RuleCondition) elbtypes.Rule {
+	return elbtypes.Rule{
+		RuleArn:    aws.String("arn:rule-" + priority),
+		Priority:   aws.String(priority),
+		IsDefault:  aws.Bool(false),
+		Conditions: conditions,
+		Actions:    []elbtypes.Action{{Type: elbtypes.ActionTypeEnumForward, TargetGroupArn: aws.String(targetGroup)}},
+	}
+}
+
+func TestExplainListenerRouting(t *testing.T) {
+	rules := []elbtypes.Rule{
+		{
+			RuleArn:   aws.String("arn:rule-default"),
+			Priority:  aws.String("default"),
+			IsDefault: aws.Bool(true),
+			Actions:   []elbtypes.Action{{Type: elbtypes.ActionTypeEnumForward, TargetGroupArn: aws.String("arn:tg-default")}},
+		},
+		forwardRule("20", "arn:tg-api",
+			elbtypes.RuleCondition{Field: aws.String("path-pattern"), PathPatternConfig: &elbtypes.PathPatternConditionConfig{Values: []string{"/api/*"}}},
+		),
+		forwardRule("10", "arn:tg-api-v2",
+			elbtypes.RuleCondition{Field: aws.String("host-header"), HostHeaderConfig: &elbtypes.HostHeaderConditionConfig{Values: []string{"*.example.com"}}},
+			elbtypes.RuleCondition{Field: aws.String("path-pattern"), PathPatternConfig: &elbtypes.PathPatternConditionConfig{Values: []string{"/api/v2/*"}}},
+			elbtypes.RuleCondition{Field: aws.String("http-request-method"), HttpRequestMethodConfig: &elbtypes.HttpRequestMethodConditionConfig{Values: []string{"GET"}}},
+		),
+		forwardRule("5", "arn:tg-canary",
+			elbtypes.RuleCondition{Field: aws.String("http-header"), HttpHeaderConfig: &elbtypes.HttpHeaderConditionConfig{HttpHeaderName: aws.String("X-Canary"), Values: []string{"true"}}},
+		),
+	}
+
+	cases := []struct {
+		name        string
+		args        map[string]any
+		wantTarget  string
+		wantDefault bool
+	}{
+		{"priority order wins", map[string]any{"host": "App.Example.com:443", "path": "/api/v2/users", "method": "get"}, "arn:tg-api-v2", false},
+		{"method mismatch falls through", map[string]any{"host": "app.example.com", "path": "/api/v2/users", "method": "POST"}, "arn:tg-api", false},
+		{"header rule", map[string]any{"path": "/", "headers": map[string]any{"x-canary": "true"}}, "arn:tg-canary", false},
+		{"default", map[string]any{"host": "other.test", "path": "/static/app.js"}, "arn:tg-default", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := explainListenerRouting(rules, routingRequestFromArgs(tc.args))
+			if got["isDefault"] != tc.wantDefault {
+				t.Fatalf("isDefault = %v, want %v: %#v", got["isDefault"], tc.wantDefault, got)
+			}
+			groups := got["targetGroups"].([]map[string]any)
+			if len(groups) != 1 || groups[0]["targetGroupArn"] != tc.wantTarget {
+				t.Fatalf("target groups = %#v, want %s", groups, tc.wantTarget)
+			}
+		})
+	}
+
+	got := explainListenerRouting(rules, routingRequestFromArgs(map[string]any{"path": "/api/v2/x"}))
+	evaluated := got["evaluated"].([]map[string]any)
+	if evaluated[0]["priority"] != "5" || evaluated[1]["priority"] != "10" {
+		t.Fatalf("expected numeric priority order, got %#v", evaluated)
+	}
+	if unevaluated, ok := evaluated[1]["unevaluated"].([]string); !ok || len(unevaluated) != 2 {
+		t.Fatalf("expected host and method to be unevaluated without input, got %#v", evaluated[1])
+	}
+}
+
+func TestEvaluateRuleConditionQueryAndSourceIP(t *testing.T) {
+	query := elbtypes.RuleCondition{
+		Field: aws.String("query-string"),
+		QueryStringConfig: &elbtypes.QueryStringConditionConfig{Values: []elbtypes.QueryStringKeyValuePair{
+			{Key: aws.String("version"), Value: aws.String("v*")},
+		}},
+	}
+	if ok, evaluable := evaluateRuleCondition(query, routingRequestFromArgs(map[string]any{"path": "/x?version=v2"})); !ok || !evaluable {
+		t.Fatalf("expected query-string match, got ok=%v evaluable=%v", ok, evaluable)
+	}
+	if _, evaluable := evaluateRuleCondition(query, routingRequestFromArgs(map[string]any{"path": "/x"})); evaluable {
+		t.Fatalf("expected query-string without query to be unevaluated")
+	}
+	sourceIP := elbtypes.RuleCondition{
+		Field:          aws.String("source-ip"),
+		SourceIpConfig: &elbtypes.SourceIpConditionConfig{Values: []string{"10.0.0.0/8"}},
+	}
+	if ok, _ := evaluateRuleCondition(sourceIP, routingRequestFromArgs(map[string]any{"sourceIp": "10.1.2.3"})); !ok {
+		t.Fatalf("expected source-ip match")
+	}
+	if ok, evaluable := evaluateRuleCondition(sourceIP, routingRequestFromArgs(map[string]any{"sourceIp": "192.168.0.1"})); ok || !evaluable {
+		t.Fatalf("expected source-ip mismatch")
+	}
+}
+
+func TestWildcardMatch(t *testing.T) {
+	cases := []struct {
+		pattern, value string
+		caseSensitive  bool
+		want           bool
+	}{
+		{"/api/*", "/api/users", true, true},
+		{"/api/*", "/API/users", true, false},
+		{"*.example.com", "WWW.EXAMPLE.COM", false, true},
+		{"/img/?.png", "/img/a.png", true, true},
+		{"/img/?.png", "/img/ab.png", true, false},
+		{"/a.b", "/axb", true, false},
+	}
+	for _, tc := range cases {
+		if got := wildcardMatch(tc.pattern, tc.value, tc.caseSensitive); got != tc.want {
+			t.Fatalf("wildcardMatch(%q, %q) = %v, want %v", tc.pattern, tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestHandleExplainRouting(t *testing.T) {
+	responses := map[string]string{
+		"DescribeListeners": `<DescribeListenersResponse xmlns="http://elasticloadbalancing.amazonaws.com/doc/2015-12-01/">
+  <DescribeListenersResult>
+    <Listeners>
+      <member><ListenerArn>arn:listener-443</ListenerArn><Port>443</Port><Protocol>HTTPS</Protocol></member>
+      <member><ListenerArn>arn:listener-tcp</ListenerArn><Port>8443</Port><Protocol>TCP</Protocol></member>
+    </Listeners>
+  </DescribeListenersResult>
+</DescribeListenersResponse>`,
+		"DescribeRules": `<DescribeRulesResponse xmlns="http://elasticloadbalancing.amazonaws.com/doc/2015-12-01/">
+  <DescribeRulesResult>
+    <Rules>
+      <member>
+        <RuleArn>arn:rule-1</RuleArn>
+        <Priority>1</Priority>
+        <IsDefault>false</IsDefault>
+        <Conditions>
+          <member><Field>path-pattern</Field><PathPatternConfig><Values><member>/api/*</member></Values></PathPatternConfig></member>
+        </Conditions>
+        <Actions>
+          <member>
+            <Type>forward</Type>
+            <ForwardConfig><TargetGroups>
+              <member><TargetGroupArn>arn:tg-blue</TargetGroupArn><Weight>90</Weight></member>
+              <member><TargetGroupArn>arn:tg-green</TargetGroupArn><Weight>10</Weight></member>
+            </TargetGroups></ForwardConfig>
+          </member>
+        </Actions>
+      </member>
+      <member>
+        <RuleArn>arn:rule-default</RuleArn>
+        <Priority>default</Priority>
+        <IsDefault>true</IsDefault>
+        <Actions><member><Type>fixed-response</Type></member></Actions>
+      </member>
+    </Rules>
+  </DescribeRulesResult>
+</DescribeRulesResponse>`,
+	}
+	client := newELBTestClient(t, responses)
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		elbClient: func(context.Context, string) (*elasticloadbalancingv2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	if _, err := svc.handleExplainRouting(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected error without loadBalancerArn")
+	}
+	if _, err := svc.handleExplainRouting(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"loadBalancerArn": "arn:lb", "port": 80}}); err == nil {
+		t.Fatalf("expected error when no listener matches the port")
+	}
+	result, err := svc.handleExplainRouting(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"loadBalancerArn": "arn:lb", "path": "/api/orders"}})
+	if err != nil {
+		t.Fatalf("explain routing: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if data["count"] != 1 {
+		t.Fatalf("expected only the HTTPS listener to be evaluated: %#v", data)
+	}
+	listener := data["listeners"].([]map[string]any)[0]
+	groups := listener["targetGroups"].([]map[string]any)
+	if listener["isDefault"] != false || len(groups) != 2 || groups[1]["weight"] != int32(10) {
+		t.Fatalf("unexpected routing result: %#v", listener)
+	}
+
+	result, err = svc.handleExplainRouting(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"loadBalancerArn": "arn:lb", "path": "/health"}})
+	if err != nil {
+		t.Fatalf("explain routing default: %v", err)
+	}
+	listener = result.Data.(map[string]any)["listeners"].([]map[string]any)[0]
+	if listener["isDefault"] != true || len(listener["targetGroups"].([]map[string]any)) != 0 {
+		t.Fatalf("expected default fixed-response rule: %#v", listener)
+	}
+}
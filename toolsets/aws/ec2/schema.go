@@ -11,8 +11,21 @@ func schemaEC2ListInstances() map[string]any {
 			"vpcId":    map[string]any{"type": "string"},
 			"subnetId": map[string]any{"type": "string"},
 			"state":    map[string]any{"type": "string"},
-			"limit":    map[string]any{"type": "number"},
-			"region":   map[string]any{"type": "string"},
+			"states": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string"},
+			},
+			"includePlacement": map[string]any{
+				"type":        "boolean",
+				"description": "Add tenancy, dedicated host, lifecycle (spot/on-demand) and spot request id.",
+			},
+			"includeMetadataOptions": map[string]any{
+				"type":        "boolean",
+				"description": "Add IMDS settings (httpTokens, httpEndpoint, hop limit) and whether IMDSv1 is still allowed.",
+			},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -21,8 +34,10 @@ func schemaEC2GetInstance() map[string]any {
 	return map[string]any{
 		"type": "object",
 		"properties": map[string]any{
-			"instanceId": map[string]any{"type": "string"},
-			"region":     map[string]any{"type": "string"},
+			"instanceId":             map[string]any{"type": "string"},
+			"includePlacement":       map[string]any{"type": "boolean"},
+			"includeMetadataOptions": map[string]any{"type": "boolean"},
+			"region":                 map[string]any{"type": "string"},
 		},
 		"required": []string{"instanceId"},
 	}
@@ -36,8 +51,9 @@ func schemaEC2ListASGs() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -65,8 +81,9 @@ func schemaEC2ListLoadBalancers() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -82,6 +99,28 @@ func schemaEC2GetLoadBalancer() map[string]any {
 	}
 }
 
+func schemaEC2GetLoadBalancerAttributes() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"loadBalancerArn": map[string]any{"type": "string"},
+			"region":          map[string]any{"type": "string"},
+		},
+		"required": []string{"loadBalancerArn"},
+	}
+}
+
+func schemaEC2GetLoadBalancerHealth() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"loadBalancerArn": map[string]any{"type": "string"},
+			"region":          map[string]any{"type": "string"},
+		},
+		"required": []string{"loadBalancerArn"},
+	}
+}
+
 func schemaEC2GetTargetHealth() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -106,8 +145,9 @@ func schemaEC2ListListenerRules() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -123,6 +163,30 @@ func schemaEC2GetListenerRule() map[string]any {
 	}
 }
 
+func schemaEC2ExplainRouting() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"loadBalancerArn": map[string]any{"type": "string"},
+			"host":            map[string]any{"type": "string"},
+			"path":            map[string]any{"type": "string", "description": "Request path; a ?query suffix is also evaluated against query-string conditions."},
+			"method":          map[string]any{"type": "string"},
+			"headers": map[string]any{
+				"type":                 "object",
+				"additionalProperties": map[string]any{"type": "string"},
+			},
+			"query": map[string]any{
+				"type":                 "object",
+				"additionalProperties": map[string]any{"type": "string"},
+			},
+			"sourceIp": map[string]any{"type": "string"},
+			"port":     map[string]any{"type": "number", "description": "Only evaluate the listener on this port."},
+			"region":   map[string]any{"type": "string"},
+		},
+		"required": []string{"loadBalancerArn"},
+	}
+}
+
 func schemaEC2ListAutoScalingPolicies() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -136,8 +200,9 @@ func schemaEC2ListAutoScalingPolicies() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -162,8 +227,9 @@ func schemaEC2ListScalingActivities() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -192,8 +258,9 @@ func schemaEC2ListLaunchTemplates() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -217,8 +284,9 @@ func schemaEC2ListLaunchConfigurations() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -238,13 +306,30 @@ func schemaEC2GetInstanceIAM() map[string]any {
 	return map[string]any{
 		"type": "object",
 		"properties": map[string]any{
-			"instanceId": map[string]any{"type": "string"},
-			"region":     map[string]any{"type": "string"},
+			"instanceId":     map[string]any{"type": "string"},
+			"expandPolicies": map[string]any{"type": "boolean"},
+			"region":         map[string]any{"type": "string"},
 		},
 		"required": []string{"instanceId"},
 	}
 }
 
+func schemaEC2AuditIMDS() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"instanceIds": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string"},
+			},
+			"vpcId":       map[string]any{"type": "string"},
+			"onlyFlagged": map[string]any{"type": "boolean", "description": "Only return instances that still allow IMDSv1."},
+			"limit":       map[string]any{"type": "number", "description": "Maximum instances to audit (default 200)."},
+			"region":      map[string]any{"type": "string"},
+		},
+	}
+}
+
 func schemaEC2GetSecurityGroupRules() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -268,8 +353,9 @@ func schemaEC2ListSpotInstanceRequests() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -285,6 +371,33 @@ func schemaEC2GetSpotInstanceRequest() map[string]any {
 	}
 }
 
+func schemaEC2AnalyzeSpotRisk() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"instanceIds": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string"},
+			},
+			"limit":  map[string]any{"type": "number", "description": "Maximum spot instances to check (default 100)."},
+			"region": map[string]any{"type": "string"},
+		},
+	}
+}
+
+func schemaEC2DiagnoseSpot() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"spotInstanceRequestId": map[string]any{"type": "string"},
+			"autoScalingGroupName":  map[string]any{"type": "string"},
+			"clusterName":           map[string]any{"type": "string", "description": "EKS cluster of nodegroupName; narrows the nodegroup tag match."},
+			"nodegroupName":         map[string]any{"type": "string"},
+			"region":                map[string]any{"type": "string"},
+		},
+	}
+}
+
 func schemaEC2ListCapacityReservations() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -293,8 +406,9 @@ func schemaEC2ListCapacityReservations() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -320,6 +434,71 @@ func schemaEC2ListVolumes() map[string]any {
 			},
 			"instanceId": map[string]any{"type": "string"},
 			"limit":      map[string]any{"type": "number"},
+			"nextToken":  map[string]any{"type": "string"},
+			"region":     map[string]any{"type": "string"},
+		},
+	}
+}
+
+func schemaEC2AnalyzeBackupCoverage() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"volumeIds": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string"},
+			},
+			"instanceId": map[string]any{"type": "string"},
+			"maxAgeDays": map[string]any{"type": "number", "description": "Snapshots older than this are flagged as stale (default 7)."},
+			"limit":      map[string]any{"type": "number", "description": "Maximum volumes to check (default 200)."},
+			"region":     map[string]any{"type": "string"},
+		},
+	}
+}
+
+func schemaEC2TraceVolumeLineage() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"volumeId":   map[string]any{"type": "string"},
+			"snapshotId": map[string]any{"type": "string"},
+			"maxDepth":   map[string]any{"type": "number", "description": "Maximum backward steps to follow (default 10)."},
+			"region":     map[string]any{"type": "string"},
+		},
+	}
+}
+
+func schemaEC2ListImages() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"imageIds": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string"},
+			},
+			"owners": map[string]any{
+				"type":        "array",
+				"items":       map[string]any{"type": "string"},
+				"description": "Owner account ids or aliases such as amazon (default self unless imageIds is set).",
+			},
+			"name":      map[string]any{"type": "string", "description": "AMI name filter; * wildcards are allowed."},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
+		},
+	}
+}
+
+func schemaEC2AnalyzeAMIUsage() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"instanceIds": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string"},
+			},
+			"maxAgeDays": map[string]any{"type": "number", "description": "AMIs created longer ago than this are flagged as old (default 180)."},
+			"limit":      map[string]any{"type": "number", "description": "Maximum running instances to check (default 200)."},
 			"region":     map[string]any{"type": "string"},
 		},
 	}
@@ -348,9 +527,10 @@ func schemaEC2ListSnapshots() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"volumeId": map[string]any{"type": "string"},
-			"limit":    map[string]any{"type": "number"},
-			"region":   map[string]any{"type": "string"},
+			"volumeId":  map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -373,6 +553,7 @@ func schemaEC2ListVolumeAttachments() map[string]any {
 			"volumeId":   map[string]any{"type": "string"},
 			"instanceId": map[string]any{"type": "string"},
 			"limit":      map[string]any{"type": "number"},
+			"nextToken":  map[string]any{"type": "string"},
 			"region":     map[string]any{"type": "string"},
 		},
 	}
@@ -413,6 +594,7 @@ func schemaEC2ListInstanceStatus() map[string]any {
 			},
 			"includeAll": map[string]any{"type": "boolean"},
 			"limit":      map[string]any{"type": "number"},
+			"nextToken":  map[string]any{"type": "string"},
 			"region":     map[string]any{"type": "string"},
 		},
 	}
@@ -429,6 +611,18 @@ func schemaEC2GetInstanceStatus() map[string]any {
 	}
 }
 
+func schemaEC2GetConsoleOutput() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"instanceId": map[string]any{"type": "string"},
+			"region":     map[string]any{"type": "string"},
+			"maxBytes":   map[string]any{"type": "integer"},
+		},
+		"required": []string{"instanceId"},
+	}
+}
+
 func schemaEC2ListTargetGroups() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -443,6 +637,7 @@ func schemaEC2ListTargetGroups() map[string]any {
 			},
 			"loadBalancerArn": map[string]any{"type": "string"},
 			"limit":           map[string]any{"type": "number"},
+			"nextToken":       map[string]any{"type": "string"},
 			"region":          map[string]any{"type": "string"},
 		},
 	}
@@ -469,6 +664,7 @@ func schemaEC2ListListeners() map[string]any {
 			},
 			"loadBalancerArn": map[string]any{"type": "string"},
 			"limit":           map[string]any{"type": "number"},
+			"nextToken":       map[string]any{"type": "string"},
 			"region":          map[string]any{"type": "string"},
 		},
 	}
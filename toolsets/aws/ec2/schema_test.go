@@ -14,13 +14,16 @@ func TestEC2Schemas(t *testing.T) {
 		schemaEC2GetASG(),
 		schemaEC2ListLoadBalancers(),
 		schemaEC2GetLoadBalancer(),
+		schemaEC2GetLoadBalancerAttributes(),
 		schemaEC2ListTargetGroups(),
 		schemaEC2GetTargetGroup(),
 		schemaEC2ListListeners(),
 		schemaEC2GetListener(),
 		schemaEC2GetTargetHealth(),
+		schemaEC2GetLoadBalancerHealth(),
 		schemaEC2ListListenerRules(),
 		schemaEC2GetListenerRule(),
+		schemaEC2ExplainRouting(),
 		schemaEC2ListAutoScalingPolicies(),
 		schemaEC2GetAutoScalingPolicy(),
 		schemaEC2ListScalingActivities(),
@@ -30,12 +33,19 @@ func TestEC2Schemas(t *testing.T) {
 		schemaEC2ListLaunchConfigurations(),
 		schemaEC2GetLaunchConfiguration(),
 		schemaEC2GetInstanceIAM(),
+		schemaEC2AuditIMDS(),
 		schemaEC2GetSecurityGroupRules(),
 		schemaEC2ListSpotInstanceRequests(),
 		schemaEC2GetSpotInstanceRequest(),
+		schemaEC2AnalyzeSpotRisk(),
+		schemaEC2DiagnoseSpot(),
 		schemaEC2ListCapacityReservations(),
 		schemaEC2GetCapacityReservation(),
 		schemaEC2ListVolumes(),
+		schemaEC2AnalyzeBackupCoverage(),
+		schemaEC2TraceVolumeLineage(),
+		schemaEC2ListImages(),
+		schemaEC2AnalyzeAMIUsage(),
 		schemaEC2GetVolume(),
 		schemaEC2ListSnapshots(),
 		schemaEC2GetSnapshot(),
@@ -44,6 +54,7 @@ func TestEC2Schemas(t *testing.T) {
 		schemaEC2GetPlacementGroup(),
 		schemaEC2ListInstanceStatus(),
 		schemaEC2GetInstanceStatus(),
+		schemaEC2GetConsoleOutput(),
 	}
 	for i, schema := range schemas {
 		if schema == nil || schema["type"] == "" {
@@ -0,0 +1,229 @@
+package awsec2
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/argconv"
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+// spotLookupBatch bounds the ids sent per DescribeSpotInstanceRequests and
+// DescribeInstanceStatus call.
+const spotLookupBatch = 100
+
+const spotPlacementScoreNote = "Spot placement scores need ec2:GetSpotPlacementScores and are not queried; risk here comes from spot request status codes and scheduled instance events."
+
+// Spot request status codes that mean AWS is about to reclaim the instance.
+var spotInterruptionCodes = map[string]bool{
+	"marked-for-termination": true,
+	"marked-for-stop":        true,
+	"marked-for-hibernation": true,
+}
+
+// Spot request status codes that point at capacity or constraint pressure in
+// the pool, making interruptions and failed replacements more likely.
+var spotCapacityCodes = map[string]bool{
+	"capacity-not-available":                      true,
+	"capacity-oversubscribed":                     true,
+	"price-too-low":                               true,
+	"constraint-not-fulfillable":                  true,
+	"az-group-constraint":                         true,
+	"placement-group-constraint":                  true,
+	"launch-group-constraint":                     true,
+	"instance-terminated-no-capacity":             true,
+	"instance-terminated-capacity-oversubscribed": true,
+	"instance-stopped-no-capacity":                true,
+	"instance-stopped-capacity-oversubscribed":    true,
+}
+
+var spotCodeFields = []string{"statusCode", "faultCode", "reasons"}
+
+func (s *Service) handleAnalyzeSpotRisk(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	region := awsutil.ToString(req.Arguments["region"])
+	ids := awsutil.ToStringSlice(req.Arguments["instanceIds"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input := &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{
+			{Name: aws.String("instance-lifecycle"), Values: []string{"spot"}},
+			{Name: aws.String("instance-state-name"), Values: []string{"running"}},
+		},
+	}
+	if len(ids) > 0 {
+		input.InstanceIds = ids
+	}
+	var instances []ec2types.Instance
+	truncated := false
+	for {
+		out, err := client.DescribeInstances(ctx, input)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		for _, reservation := range out.Reservations {
+			instances = append(instances, reservation.Instances...)
+		}
+		if limit > 0 && len(instances) >= limit {
+			truncated = len(instances) > limit || aws.ToString(out.NextToken) != ""
+			instances = instances[:limit]
+			break
+		}
+		if aws.ToString(out.NextToken) == "" {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+
+	var instanceIDs, requestIDs []string
+	for _, inst := range instances {
+		instanceIDs = append(instanceIDs, aws.ToString(inst.InstanceId))
+		if id := aws.ToString(inst.SpotInstanceRequestId); id != "" {
+			requestIDs = append(requestIDs, id)
+		}
+	}
+	requests := map[string]ec2types.SpotInstanceRequest{}
+	for start := 0; start < len(requestIDs); start += spotLookupBatch {
+		end := min(start+spotLookupBatch, len(requestIDs))
+		spotInput := &ec2.DescribeSpotInstanceRequestsInput{SpotInstanceRequestIds: requestIDs[start:end]}
+		for {
+			out, err := client.DescribeSpotInstanceRequests(ctx, spotInput)
+			if err != nil {
+				return awsutil.ErrorResult(err), err
+			}
+			for _, spotReq := range out.SpotInstanceRequests {
+				requests[aws.ToString(spotReq.SpotInstanceRequestId)] = spotReq
+			}
+			if aws.ToString(out.NextToken) == "" {
+				break
+			}
+			spotInput.NextToken = out.NextToken
+		}
+	}
+	statuses := map[string]ec2types.InstanceStatus{}
+	for start := 0; start < len(instanceIDs); start += spotLookupBatch {
+		end := min(start+spotLookupBatch, len(instanceIDs))
+		statusInput := &ec2.DescribeInstanceStatusInput{InstanceIds: instanceIDs[start:end], IncludeAllInstances: aws.Bool(true)}
+		for {
+			out, err := client.DescribeInstanceStatus(ctx, statusInput)
+			if err != nil {
+				return awsutil.ErrorResult(err), err
+			}
+			for _, status := range out.InstanceStatuses {
+				statuses[aws.ToString(status.InstanceId)] = status
+			}
+			if aws.ToString(out.NextToken) == "" {
+				break
+			}
+			statusInput.NextToken = out.NextToken
+		}
+	}
+
+	var results []map[string]any
+	riskCounts := map[string]int{}
+	for _, inst := range instances {
+		var spotReq *ec2types.SpotInstanceRequest
+		if found, ok := requests[aws.ToString(inst.SpotInstanceRequestId)]; ok {
+			spotReq = &found
+		}
+		var status *ec2types.InstanceStatus
+		if found, ok := statuses[aws.ToString(inst.InstanceId)]; ok {
+			status = &found
+		}
+		entry := assessSpotRisk(inst, spotReq, status)
+		riskCounts[entry["risk"].(string)]++
+		results = append(results, entry)
+	}
+	data := map[string]any{
+		"region":     awsutil.RegionOrDefault(usedRegion),
+		"instances":  results,
+		"count":      len(results),
+		"riskCounts": riskCounts,
+		"note":       spotPlacementScoreNote,
+	}
+	if truncated {
+		data["truncated"] = true
+	}
+	redacted := s.ctx.Redactor.RedactValue(data).(map[string]any)
+	// Spot status and fault codes are AWS enums long enough to trip the
+	// redactor's token pattern; restore them so the risk stays explainable.
+	for i, entry := range redacted["instances"].([]map[string]any) {
+		for _, key := range spotCodeFields {
+			if value, ok := results[i][key]; ok {
+				entry[key] = value
+			}
+		}
+	}
+	return mcp.ToolResult{Data: redacted}, nil
+}
+
+// assessSpotRisk rates one running spot instance: "high" when its spot
+// request is marked for interruption, "elevated" on capacity-constrained
+// status codes or pending scheduled events, otherwise "low".
+func assessSpotRisk(inst ec2types.Instance, spotReq *ec2types.SpotInstanceRequest, status *ec2types.InstanceStatus) map[string]any {
+	entry := map[string]any{
+		"instanceId":   aws.ToString(inst.InstanceId),
+		"instanceType": inst.InstanceType,
+	}
+	if inst.Placement != nil {
+		entry["availabilityZone"] = aws.ToString(inst.Placement.AvailabilityZone)
+	}
+	risk := "low"
+	var reasons []string
+	if spotReq == nil {
+		reasons = append(reasons, "spot request not found; it may have been cancelled or expired")
+	} else {
+		entry["spotRequest"] = summarizeSpotRequest(*spotReq)
+		if spotReq.Status != nil {
+			code := aws.ToString(spotReq.Status.Code)
+			entry["statusCode"] = code
+			entry["statusMessage"] = aws.ToString(spotReq.Status.Message)
+			switch {
+			case spotInterruptionCodes[code]:
+				risk = "high"
+				reasons = append(reasons, "spot request status "+code+": interruption notice issued")
+			case spotCapacityCodes[code]:
+				risk = "elevated"
+				reasons = append(reasons, "spot request status "+code+": capacity constrained")
+			}
+		}
+		if spotReq.Fault != nil {
+			entry["faultCode"] = aws.ToString(spotReq.Fault.Code)
+			entry["faultMessage"] = aws.ToString(spotReq.Fault.Message)
+		}
+	}
+	if status != nil {
+		var notices []map[string]any
+		for _, event := range status.Events {
+			description := aws.ToString(event.Description)
+			// Completed and canceled events stay listed with a prefix.
+			if strings.HasPrefix(description, "[Completed]") || strings.HasPrefix(description, "[Canceled]") {
+				continue
+			}
+			notices = append(notices, map[string]any{
+				"code":        event.Code,
+				"description": description,
+				"notBefore":   event.NotBefore,
+			})
+		}
+		if len(notices) > 0 {
+			entry["scheduledEvents"] = notices
+			if risk == "low" {
+				risk = "elevated"
+			}
+			reasons = append(reasons, "instance has scheduled events")
+		}
+	}
+	entry["risk"] = risk
+	if len(reasons) > 0 {
+		entry["reasons"] = reasons
+	}
+	return entry
+}
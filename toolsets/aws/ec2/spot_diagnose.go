@@ -0,0 +1,293 @@
+package awsec2
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+// spotDiagnosis explains one spot request status code: the category groups
+// codes with the same fix.
+type spotDiagnosis struct {
+	category       string
+	explanation    string
+	recommendation string
+}
+
+const (
+	spotCategoryCapacity     = "capacity"
+	spotCategoryPrice        = "price"
+	spotCategoryInterruption = "interruption"
+	spotCategoryConstraint   = "constraint"
+	spotCategoryUser         = "user"
+	spotCategoryPending      = "pending"
+	spotCategoryHealthy      = "healthy"
+	spotCategoryUnknown      = "unknown"
+)
+
+var (
+	capacityRecommendation = "Diversify across more instance types and availability zones and use the price-capacity-optimized allocation strategy."
+	priceRecommendation    = "Remove the max price so it defaults to the on-demand price, or raise it above the current spot price."
+)
+
+// spotStatusDiagnoses covers the spot request status codes documented for
+// EC2; codes not listed fall back to the unknown category.
+var spotStatusDiagnoses = map[string]spotDiagnosis{
+	"fulfilled":                                   {spotCategoryHealthy, "The spot request is fulfilled and the instance is running.", ""},
+	"pending-evaluation":                          {spotCategoryPending, "The request is still being evaluated.", ""},
+	"pending-fulfillment":                         {spotCategoryPending, "EC2 is trying to provision the instance.", ""},
+	"not-scheduled-yet":                           {spotCategoryPending, "The request's validFrom time has not been reached.", ""},
+	"capacity-not-available":                      {spotCategoryCapacity, "There is no spare capacity for the requested instance type in this availability zone.", capacityRecommendation},
+	"capacity-oversubscribed":                     {spotCategoryCapacity, "The spot pool is oversubscribed; other requests are using the available capacity.", capacityRecommendation},
+	"price-too-low":                               {spotCategoryPrice, "The max price is below the current spot price, so the request cannot be fulfilled.", priceRecommendation},
+	"instance-terminated-by-price":                {spotCategoryPrice, "The instance was reclaimed because the spot price rose above the max price.", priceRecommendation},
+	"instance-stopped-by-price":                   {spotCategoryPrice, "The instance was stopped because the spot price rose above the max price.", priceRecommendation},
+	"instance-terminated-no-capacity":             {spotCategoryCapacity, "The instance was reclaimed because EC2 needed the capacity back.", capacityRecommendation},
+	"instance-terminated-capacity-oversubscribed": {spotCategoryCapacity, "The instance was reclaimed because the spot pool became oversubscribed.", capacityRecommendation},
+	"instance-stopped-no-capacity":                {spotCategoryCapacity, "The instance was stopped because EC2 needed the capacity back.", capacityRecommendation},
+	"instance-stopped-capacity-oversubscribed":    {spotCategoryCapacity, "The instance was stopped because the spot pool became oversubscribed.", capacityRecommendation},
+	"marked-for-termination":                      {spotCategoryInterruption, "An interruption notice was issued; the instance will be terminated in about two minutes.", "Make sure the node is cordoned and drained on the interruption notice (e.g. with the AWS Node Termination Handler or Karpenter interruption handling)."},
+	"marked-for-stop":                             {spotCategoryInterruption, "An interruption notice was issued; the instance will be stopped in about two minutes.", "Make sure workloads are drained on the interruption notice."},
+	"marked-for-hibernation":                      {spotCategoryInterruption, "An interruption notice was issued; the instance will hibernate in about two minutes.", "Make sure workloads are drained on the interruption notice."},
+	"az-group-constraint":                         {spotCategoryConstraint, "The request requires all instances in one availability zone and capacity is not available there.", "Drop the availability zone group constraint or request fewer instances."},
+	"placement-group-constraint":                  {spotCategoryConstraint, "The placement group cannot hold more instances right now.", "Drop the placement group or use a different one."},
+	"launch-group-constraint":                     {spotCategoryConstraint, "The launch group requires all instances to launch together and they cannot.", "Drop the launch group or request fewer instances."},
+	"constraint-not-fulfillable":                  {spotCategoryConstraint, "A request constraint (availability zone or placement group) cannot be met.", "Relax the availability zone or placement constraints."},
+	"bad-parameters":                              {spotCategoryConstraint, "The request parameters are invalid, for example an unsupported instance type or AMI.", "Check the launch specification."},
+	"schedule-expired":                            {spotCategoryUser, "The request expired before it was fulfilled.", "Extend validUntil or resubmit the request."},
+	"canceled-before-fulfillment":                 {spotCategoryUser, "The request was cancelled before it was fulfilled.", ""},
+	"request-canceled-and-instance-running":       {spotCategoryUser, "The request was cancelled but its instance is still running.", ""},
+	"instance-terminated-by-user":                 {spotCategoryUser, "The instance was terminated by a user or by Auto Scaling, not by a spot interruption.", ""},
+	"instance-stopped-by-user":                    {spotCategoryUser, "The instance was stopped by a user, not by a spot interruption.", ""},
+	"spot-instance-terminated-by-user":            {spotCategoryUser, "The instance was terminated by a user, not by a spot interruption.", ""},
+	"instance-terminated-by-service":              {spotCategoryUser, "The instance was terminated by the service that launched it, such as an EC2 Fleet or Auto Scaling scale-in.", ""},
+	"instance-terminated-by-schedule":             {spotCategoryUser, "The instance was terminated at the end of its scheduled duration.", ""},
+	"instance-terminated-launch-group-constraint": {spotCategoryConstraint, "The instance was terminated because another instance in its launch group was.", "Drop the launch group."},
+	"system-error":                                {spotCategoryUnknown, "EC2 reported an unexpected error; retry the request.", ""},
+}
+
+// spotCategoryOrder ranks categories for the headline: the first non-healthy
+// category found across instances is reported.
+var spotCategoryOrder = []string{
+	spotCategoryInterruption,
+	spotCategoryCapacity,
+	spotCategoryPrice,
+	spotCategoryConstraint,
+	spotCategoryUser,
+	spotCategoryPending,
+	spotCategoryUnknown,
+	spotCategoryHealthy,
+}
+
+func (s *Service) handleDiagnoseSpot(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	requestID := awsutil.ToString(req.Arguments["spotInstanceRequestId"])
+	asgName := awsutil.ToString(req.Arguments["autoScalingGroupName"])
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
+	nodegroup := awsutil.ToString(req.Arguments["nodegroupName"])
+	if requestID == "" && asgName == "" && nodegroup == "" {
+		err := errors.New("spotInstanceRequestId, autoScalingGroupName, or nodegroupName is required")
+		return awsutil.ErrorResult(err), err
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+
+	var instances []ec2types.Instance
+	var requestIDs []string
+	if requestID != "" {
+		requestIDs = []string{requestID}
+	} else {
+		// Terminated instances stay visible for about an hour, which keeps
+		// recently interrupted nodes and their spot request ids in scope.
+		filters := []ec2types.Filter{{Name: aws.String("instance-lifecycle"), Values: []string{"spot"}}}
+		if asgName != "" {
+			filters = append(filters, ec2types.Filter{Name: aws.String("tag:aws:autoscaling:groupName"), Values: []string{asgName}})
+		} else {
+			filters = append(filters, ec2types.Filter{Name: aws.String("tag:eks:nodegroup-name"), Values: []string{nodegroup}})
+			if cluster != "" {
+				filters = append(filters, ec2types.Filter{Name: aws.String("tag:eks:cluster-name"), Values: []string{cluster}})
+			}
+		}
+		input := &ec2.DescribeInstancesInput{Filters: filters}
+		for {
+			out, err := client.DescribeInstances(ctx, input)
+			if err != nil {
+				return awsutil.ErrorResult(err), err
+			}
+			for _, reservation := range out.Reservations {
+				instances = append(instances, reservation.Instances...)
+			}
+			if aws.ToString(out.NextToken) == "" {
+				break
+			}
+			input.NextToken = out.NextToken
+		}
+		for _, inst := range instances {
+			if id := aws.ToString(inst.SpotInstanceRequestId); id != "" {
+				requestIDs = append(requestIDs, id)
+			}
+		}
+	}
+
+	var requests []ec2types.SpotInstanceRequest
+	for start := 0; start < len(requestIDs); start += spotLookupBatch {
+		end := min(start+spotLookupBatch, len(requestIDs))
+		spotInput := &ec2.DescribeSpotInstanceRequestsInput{SpotInstanceRequestIds: requestIDs[start:end]}
+		for {
+			out, err := client.DescribeSpotInstanceRequests(ctx, spotInput)
+			if err != nil {
+				return awsutil.ErrorResult(err), err
+			}
+			requests = append(requests, out.SpotInstanceRequests...)
+			if aws.ToString(out.NextToken) == "" {
+				break
+			}
+			spotInput.NextToken = out.NextToken
+		}
+	}
+	if requestID != "" && len(requests) == 0 {
+		err := errors.New("spot instance request " + requestID + " not found")
+		return awsutil.ErrorResult(err), err
+	}
+
+	var instanceIDs []string
+	for _, spotReq := range requests {
+		if id := aws.ToString(spotReq.InstanceId); id != "" {
+			instanceIDs = append(instanceIDs, id)
+		}
+	}
+	statuses := map[string]ec2types.InstanceStatus{}
+	for start := 0; start < len(instanceIDs); start += spotLookupBatch {
+		end := min(start+spotLookupBatch, len(instanceIDs))
+		statusInput := &ec2.DescribeInstanceStatusInput{InstanceIds: instanceIDs[start:end], IncludeAllInstances: aws.Bool(true)}
+		out, err := client.DescribeInstanceStatus(ctx, statusInput)
+		if err != nil {
+			// Instances that are already gone make the whole batch fail;
+			// the spot request status still explains what happened.
+			continue
+		}
+		for _, status := range out.InstanceStatuses {
+			statuses[aws.ToString(status.InstanceId)] = status
+		}
+	}
+
+	results := make([]map[string]any, 0, len(requests))
+	categories := map[string]int{}
+	for _, spotReq := range requests {
+		var status *ec2types.InstanceStatus
+		if found, ok := statuses[aws.ToString(spotReq.InstanceId)]; ok {
+			status = &found
+		}
+		entry := diagnoseSpotRequest(spotReq, status)
+		categories[entry["category"].(string)]++
+		results = append(results, entry)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return spotCategoryRank(results[i]["category"].(string)) < spotCategoryRank(results[j]["category"].(string))
+	})
+
+	data := map[string]any{
+		"region":     awsutil.RegionOrDefault(usedRegion),
+		"requests":   results,
+		"count":      len(results),
+		"categories": categories,
+		"diagnosis":  spotHeadline(categories),
+	}
+	if len(results) == 0 {
+		data["note"] = "No spot requests found; the instances may not be spot, or interrupted instances have aged out of DescribeInstances (about an hour after termination)."
+	}
+	redacted := s.ctx.Redactor.RedactValue(data).(map[string]any)
+	// Status and fault codes are AWS enums long enough to trip the redactor's
+	// token pattern; restore them so the diagnosis stays explainable.
+	for i, entry := range redacted["requests"].([]map[string]any) {
+		for _, key := range []string{"statusCode", "faultCode"} {
+			if value, ok := results[i][key]; ok {
+				entry[key] = value
+			}
+		}
+	}
+	return mcp.ToolResult{Data: redacted}, nil
+}
+
+// diagnoseSpotRequest explains one spot request from its status code, fault
+// and the instance's pending scheduled events.
+func diagnoseSpotRequest(spotReq ec2types.SpotInstanceRequest, status *ec2types.InstanceStatus) map[string]any {
+	entry := map[string]any{
+		"spotRequest": summarizeSpotRequest(spotReq),
+		"instanceId":  aws.ToString(spotReq.InstanceId),
+	}
+	code := ""
+	if spotReq.Status != nil {
+		code = aws.ToString(spotReq.Status.Code)
+		entry["statusCode"] = code
+		entry["statusMessage"] = aws.ToString(spotReq.Status.Message)
+		entry["statusUpdateTime"] = spotReq.Status.UpdateTime
+	}
+	diagnosis, ok := spotStatusDiagnoses[code]
+	if !ok {
+		diagnosis = spotDiagnosis{category: spotCategoryUnknown, explanation: "Unrecognized spot request status " + code + "."}
+	}
+	if spotReq.Fault != nil {
+		entry["faultCode"] = aws.ToString(spotReq.Fault.Code)
+		entry["faultMessage"] = aws.ToString(spotReq.Fault.Message)
+	}
+	if status != nil {
+		summary := summarizeInstanceStatus(*status)
+		entry["instanceStatus"] = summary
+		if events, _ := summary["events"].([]map[string]any); len(events) > 0 && diagnosis.category == spotCategoryHealthy {
+			diagnosis = spotDiagnosis{category: spotCategoryInterruption, explanation: "The spot request is fulfilled but the instance has scheduled events.", recommendation: "Check the events; a pending instance-stop or instance-retirement will take the node down."}
+		}
+	}
+	entry["category"] = diagnosis.category
+	entry["explanation"] = diagnosis.explanation
+	if diagnosis.recommendation != "" {
+		entry["recommendation"] = diagnosis.recommendation
+	}
+	return awsutil.NormalizeTimes(entry)
+}
+
+func spotCategoryRank(category string) int {
+	for i, candidate := range spotCategoryOrder {
+		if candidate == category {
+			return i
+		}
+	}
+	return len(spotCategoryOrder)
+}
+
+// spotHeadline summarizes the most pressing category across all requests.
+func spotHeadline(categories map[string]int) string {
+	for _, category := range spotCategoryOrder {
+		if categories[category] == 0 {
+			continue
+		}
+		switch category {
+		case spotCategoryInterruption:
+			return "Spot interruption notices are active; nodes are about to be reclaimed."
+		case spotCategoryCapacity:
+			return "Spot capacity is not available for the requested instance types; node loss or failed launches come from capacity, not price."
+		case spotCategoryPrice:
+			return "Spot requests are failing or being reclaimed on price; the max price is below the current spot price."
+		case spotCategoryConstraint:
+			return "Spot requests cannot be fulfilled because of placement or launch constraints."
+		case spotCategoryUser:
+			return "Instances were stopped or terminated by a user or service, not by spot interruptions."
+		case spotCategoryPending:
+			return "Spot requests are still waiting to be fulfilled."
+		case spotCategoryUnknown:
+			return "Spot requests report unrecognized status codes; see the per-request details."
+		case spotCategoryHealthy:
+			return "All spot requests are fulfilled with no interruption signals."
+		}
+	}
+	return "No spot requests to diagnose."
+}
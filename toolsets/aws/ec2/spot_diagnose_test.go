@@ -0,0 +1,98 @@
+package awsec2
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestDiagnoseSpotRequest(t *testing.T) {
+	cases := map[string]string{
+		"capacity-not-available":       spotCategoryCapacity,
+		"price-too-low":                spotCategoryPrice,
+		"instance-terminated-by-price": spotCategoryPrice,
+		"marked-for-termination":       spotCategoryInterruption,
+		"instance-terminated-by-user":  spotCategoryUser,
+		"something-new":                spotCategoryUnknown,
+	}
+	for code, want := range cases {
+		entry := diagnoseSpotRequest(ec2types.SpotInstanceRequest{Status: &ec2types.SpotInstanceStatus{Code: aws.String(code)}}, nil)
+		if entry["category"] != want {
+			t.Fatalf("%s: expected %s, got %#v", code, want, entry)
+		}
+	}
+	withEvent := diagnoseSpotRequest(
+		ec2types.SpotInstanceRequest{Status: &ec2types.SpotInstanceStatus{Code: aws.String("fulfilled")}, InstanceId: aws.String("i-1")},
+		&ec2types.InstanceStatus{InstanceId: aws.String("i-1"), Events: []ec2types.InstanceStatusEvent{{Code: ec2types.EventCodeInstanceStop}}},
+	)
+	if withEvent["category"] != spotCategoryInterruption {
+		t.Fatalf("expected scheduled event to flag interruption: %#v", withEvent)
+	}
+	if got := spotHeadline(map[string]int{spotCategoryHealthy: 2, spotCategoryPrice: 1}); !strings.Contains(got, "price") {
+		t.Fatalf("expected price headline, got %q", got)
+	}
+}
+
+func TestHandleDiagnoseSpot(t *testing.T) {
+	client := newEC2TestClient(t, map[string]string{
+		"DescribeInstances": `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <reservationSet>
+    <item>
+      <instancesSet>
+        <item><instanceId>i-1</instanceId><spotInstanceRequestId>sir-1</spotInstanceRequestId></item>
+        <item><instanceId>i-2</instanceId><spotInstanceRequestId>sir-2</spotInstanceRequestId></item>
+      </instancesSet>
+    </item>
+  </reservationSet>
+</DescribeInstancesResponse>`,
+		"DescribeSpotInstanceRequests": `<DescribeSpotInstanceRequestsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <spotInstanceRequestSet>
+    <item>
+      <spotInstanceRequestId>sir-1</spotInstanceRequestId>
+      <state>active</state>
+      <status><code>fulfilled</code></status>
+      <instanceId>i-1</instanceId>
+    </item>
+    <item>
+      <spotInstanceRequestId>sir-2</spotInstanceRequestId>
+      <state>closed</state>
+      <status><code>instance-terminated-no-capacity</code></status>
+      <instanceId>i-2</instanceId>
+    </item>
+  </spotInstanceRequestSet>
+</DescribeSpotInstanceRequestsResponse>`,
+		"DescribeInstanceStatus": `<DescribeInstanceStatusResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <instanceStatusSet>
+    <item><instanceId>i-1</instanceId></item>
+  </instanceStatusSet>
+</DescribeInstanceStatusResponse>`,
+	})
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleDiagnoseSpot(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"nodegroupName": "spot-ng", "clusterName": "demo"}})
+	if err != nil {
+		t.Fatalf("diagnose spot: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	requests := data["requests"].([]map[string]any)
+	if data["count"] != 2 || requests[0]["statusCode"] != "instance-terminated-no-capacity" || requests[0]["category"] != spotCategoryCapacity {
+		t.Fatalf("unexpected requests: %#v", requests)
+	}
+	if !strings.Contains(data["diagnosis"].(string), "capacity") {
+		t.Fatalf("unexpected diagnosis: %#v", data["diagnosis"])
+	}
+	if _, err := svc.handleDiagnoseSpot(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected missing target error")
+	}
+}
@@ -0,0 +1,124 @@
+package awsec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestAssessSpotRisk(t *testing.T) {
+	inst := ec2types.Instance{InstanceId: aws.String("i-1")}
+	spotReq := func(code string) *ec2types.SpotInstanceRequest {
+		return &ec2types.SpotInstanceRequest{
+			SpotInstanceRequestId: aws.String("sir-1"),
+			Status:                &ec2types.SpotInstanceStatus{Code: aws.String(code)},
+		}
+	}
+	cases := []struct {
+		name    string
+		spotReq *ec2types.SpotInstanceRequest
+		status  *ec2types.InstanceStatus
+		want    string
+	}{
+		{"fulfilled", spotReq("fulfilled"), nil, "low"},
+		{"marked for termination", spotReq("marked-for-termination"), nil, "high"},
+		{"capacity constrained", spotReq("capacity-oversubscribed"), nil, "elevated"},
+		{"scheduled event", spotReq("fulfilled"), &ec2types.InstanceStatus{Events: []ec2types.InstanceStatusEvent{
+			{Code: ec2types.EventCodeInstanceStop, Description: aws.String("The instance is running on degraded hardware")},
+		}}, "elevated"},
+		{"completed event ignored", spotReq("fulfilled"), &ec2types.InstanceStatus{Events: []ec2types.InstanceStatusEvent{
+			{Code: ec2types.EventCodeSystemReboot, Description: aws.String("[Completed] reboot")},
+		}}, "low"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := assessSpotRisk(inst, tc.spotReq, tc.status)
+			if got["risk"] != tc.want {
+				t.Fatalf("risk = %v, want %s: %#v", got["risk"], tc.want, got)
+			}
+		})
+	}
+	if got := assessSpotRisk(inst, nil, nil); got["reasons"] == nil {
+		t.Fatalf("expected a reason when the spot request is missing: %#v", got)
+	}
+}
+
+func TestHandleAnalyzeSpotRisk(t *testing.T) {
+	responses := map[string]string{
+		"DescribeInstances": `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <reservationSet>
+    <item>
+      <instancesSet>
+        <item>
+          <instanceId>i-1</instanceId>
+          <instanceType>m5.large</instanceType>
+          <instanceLifecycle>spot</instanceLifecycle>
+          <spotInstanceRequestId>sir-1</spotInstanceRequestId>
+          <placement><availabilityZone>us-east-1a</availabilityZone></placement>
+        </item>
+        <item>
+          <instanceId>i-2</instanceId>
+          <instanceLifecycle>spot</instanceLifecycle>
+          <spotInstanceRequestId>sir-2</spotInstanceRequestId>
+        </item>
+      </instancesSet>
+    </item>
+  </reservationSet>
+</DescribeInstancesResponse>`,
+		"DescribeSpotInstanceRequests": `<DescribeSpotInstanceRequestsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <spotInstanceRequestSet>
+    <item>
+      <spotInstanceRequestId>sir-1</spotInstanceRequestId>
+      <state>active</state>
+      <status><code>marked-for-termination</code><message>Spot instance will be terminated</message></status>
+      <instanceId>i-1</instanceId>
+    </item>
+    <item>
+      <spotInstanceRequestId>sir-2</spotInstanceRequestId>
+      <state>active</state>
+      <status><code>fulfilled</code></status>
+      <instanceId>i-2</instanceId>
+    </item>
+  </spotInstanceRequestSet>
+</DescribeSpotInstanceRequestsResponse>`,
+		"DescribeInstanceStatus": `<DescribeInstanceStatusResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <instanceStatusSet>
+    <item><instanceId>i-2</instanceId></item>
+  </instanceStatusSet>
+</DescribeInstanceStatusResponse>`,
+	}
+	client := newEC2TestClient(t, responses)
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleAnalyzeSpotRisk(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("analyze spot risk: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	counts := data["riskCounts"].(map[string]int)
+	if data["count"] != 2 || counts["high"] != 1 || counts["low"] != 1 || data["note"] == nil {
+		t.Fatalf("unexpected spot risk summary: %#v", data)
+	}
+	first := data["instances"].([]map[string]any)[0]
+	if first["statusCode"] != "marked-for-termination" || first["availabilityZone"] != "us-east-1a" {
+		t.Fatalf("unexpected instance entry: %#v", first)
+	}
+
+	result, err = svc.handleAnalyzeSpotRisk(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"limit": 1}})
+	if err != nil {
+		t.Fatalf("analyze spot risk with limit: %v", err)
+	}
+	if data := result.Data.(map[string]any); data["count"] != 1 || data["truncated"] != true {
+		t.Fatalf("expected truncated result, got %#v", data)
+	}
+}
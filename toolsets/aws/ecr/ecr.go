@@ -2,16 +2,17 @@ package awsecr
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ecr"
 	ecrtypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
 
+	"rootcause/internal/argconv"
+	awslib "rootcause/internal/aws"
 	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
 )
 
 type Service struct {
@@ -75,55 +76,64 @@ func ToolSpecs(ctx mcp.ToolContext, toolsetID string, ecrClient func(context.Con
 }
 
 func (s *Service) handleListRepositories(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ecrClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ecr.DescribeRepositoriesInput{}
 	if limit > 0 {
 		input.MaxResults = aws.Int32(int32(limit))
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	paginator := ecr.NewDescribeRepositoriesPaginator(client, input)
+	pageToken := aws.ToString(input.NextToken)
 	var repos []map[string]any
 	for paginator.HasMorePages() {
 		out, err := paginator.NextPage(ctx)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, repo := range out.Repositories {
+		start, end, more := pager.Page(pageToken, len(out.Repositories), out.NextToken)
+		for _, repo := range out.Repositories[start:end] {
 			repos = append(repos, summarizeRepository(repo))
-			if limit > 0 && len(repos) >= limit {
-				repos = repos[:limit]
-				return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(map[string]any{
-					"region":       usedRegion,
-					"repositories": repos,
-				})}, nil
-			}
 		}
+		if !more {
+			break
+		}
+		pageToken = aws.ToString(out.NextToken)
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(map[string]any{
+	data := map[string]any{
 		"region":       usedRegion,
 		"repositories": repos,
-	})}, nil
+	}
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleDescribeRepository(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	repoName := strings.TrimSpace(toString(req.Arguments["repositoryName"]))
+	repoName := strings.TrimSpace(awsutil.ToString(req.Arguments["repositoryName"]))
 	if repoName == "" {
-		return errorResult(errors.New("repositoryName is required")), errors.New("repositoryName is required")
+		return awsutil.ErrorResult(errors.New("repositoryName is required")), errors.New("repositoryName is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ecrClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeRepositories(ctx, &ecr.DescribeRepositoriesInput{
 		RepositoryNames: []string{repoName},
 	})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	var repo map[string]any
 	if len(out.Repositories) > 0 {
@@ -136,16 +146,16 @@ func (s *Service) handleDescribeRepository(ctx context.Context, req mcp.ToolRequ
 }
 
 func (s *Service) handleListImages(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	repoName := strings.TrimSpace(toString(req.Arguments["repositoryName"]))
+	repoName := strings.TrimSpace(awsutil.ToString(req.Arguments["repositoryName"]))
 	if repoName == "" {
-		return errorResult(errors.New("repositoryName is required")), errors.New("repositoryName is required")
+		return awsutil.ErrorResult(errors.New("repositoryName is required")), errors.New("repositoryName is required")
 	}
-	tagStatus := toString(req.Arguments["tagStatus"])
-	limit := toInt(req.Arguments["limit"], 100)
-	region := toString(req.Arguments["region"])
+	tagStatus := awsutil.ToString(req.Arguments["tagStatus"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ecrClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ecr.ListImagesInput{
 		RepositoryName: aws.String(repoName),
@@ -156,45 +166,53 @@ func (s *Service) handleListImages(ctx context.Context, req mcp.ToolRequest) (mc
 	if limit > 0 {
 		input.MaxResults = aws.Int32(int32(limit))
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	paginator := ecr.NewListImagesPaginator(client, input)
+	pageToken := aws.ToString(input.NextToken)
 	var images []map[string]any
 	for paginator.HasMorePages() {
 		out, err := paginator.NextPage(ctx)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, image := range out.ImageIds {
+		start, end, more := pager.Page(pageToken, len(out.ImageIds), out.NextToken)
+		for _, image := range out.ImageIds[start:end] {
 			images = append(images, summarizeImageID(image))
-			if limit > 0 && len(images) >= limit {
-				images = images[:limit]
-				return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(map[string]any{
-					"region":         usedRegion,
-					"repositoryName": repoName,
-					"images":         images,
-				})}, nil
-			}
 		}
+		if !more {
+			break
+		}
+		pageToken = aws.ToString(out.NextToken)
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(map[string]any{
+	data := map[string]any{
 		"region":         usedRegion,
 		"repositoryName": repoName,
 		"images":         images,
-	})}, nil
+	}
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleDescribeImages(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	repoName := strings.TrimSpace(toString(req.Arguments["repositoryName"]))
+	repoName := strings.TrimSpace(awsutil.ToString(req.Arguments["repositoryName"]))
 	if repoName == "" {
-		return errorResult(errors.New("repositoryName is required")), errors.New("repositoryName is required")
+		return awsutil.ErrorResult(errors.New("repositoryName is required")), errors.New("repositoryName is required")
 	}
-	tagStatus := toString(req.Arguments["tagStatus"])
-	limit := toInt(req.Arguments["limit"], 100)
-	region := toString(req.Arguments["region"])
+	tagStatus := awsutil.ToString(req.Arguments["tagStatus"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ecrClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
-	ids := toImageIdentifiers(toStringSlice(req.Arguments["imageTags"]), toStringSlice(req.Arguments["imageDigests"]))
+	ids := toImageIdentifiers(awsutil.ToStringSlice(req.Arguments["imageTags"]), awsutil.ToStringSlice(req.Arguments["imageDigests"]))
 	input := &ecr.DescribeImagesInput{RepositoryName: aws.String(repoName)}
 	if len(ids) > 0 {
 		input.ImageIds = ids
@@ -205,41 +223,49 @@ func (s *Service) handleDescribeImages(ctx context.Context, req mcp.ToolRequest)
 	if limit > 0 {
 		input.MaxResults = aws.Int32(int32(limit))
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	paginator := ecr.NewDescribeImagesPaginator(client, input)
+	pageToken := aws.ToString(input.NextToken)
 	var images []map[string]any
 	for paginator.HasMorePages() {
 		out, err := paginator.NextPage(ctx)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, detail := range out.ImageDetails {
+		start, end, more := pager.Page(pageToken, len(out.ImageDetails), out.NextToken)
+		for _, detail := range out.ImageDetails[start:end] {
 			images = append(images, summarizeImageDetail(detail))
-			if limit > 0 && len(images) >= limit {
-				images = images[:limit]
-				return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(map[string]any{
-					"region":         usedRegion,
-					"repositoryName": repoName,
-					"images":         images,
-				})}, nil
-			}
 		}
+		if !more {
+			break
+		}
+		pageToken = aws.ToString(out.NextToken)
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(map[string]any{
+	data := map[string]any{
 		"region":         usedRegion,
 		"repositoryName": repoName,
 		"images":         images,
-	})}, nil
+	}
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleDescribeRegistry(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ecrClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeRegistry(ctx, &ecr.DescribeRegistryInput{})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(map[string]any{
 		"region":                   usedRegion,
@@ -250,13 +276,13 @@ func (s *Service) handleDescribeRegistry(ctx context.Context, req mcp.ToolReques
 
 func (s *Service) handleGetAuthorizationToken(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
 	if err := requireConfirm(req.Arguments); err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
-	registryIDs := toStringSlice(req.Arguments["registryIds"])
-	region := toString(req.Arguments["region"])
+	registryIDs := awsutil.ToStringSlice(req.Arguments["registryIds"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ecrClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ecr.GetAuthorizationTokenInput{}
 	if len(registryIDs) > 0 {
@@ -264,7 +290,7 @@ func (s *Service) handleGetAuthorizationToken(ctx context.Context, req mcp.ToolR
 	}
 	out, err := client.GetAuthorizationToken(ctx, input)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	tokens := make([]map[string]any, 0, len(out.AuthorizationData))
 	for _, auth := range out.AuthorizationData {
@@ -332,58 +358,6 @@ func toImageIdentifiers(tags []string, digests []string) []ecrtypes.ImageIdentif
 	return ids
 }
 
-func errorResult(err error) mcp.ToolResult {
-	return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}
-}
-
-func toString(value any) string {
-	if value == nil {
-		return ""
-	}
-	if s, ok := value.(string); ok {
-		return s
-	}
-	return fmt.Sprintf("%v", value)
-}
-
-func toStringSlice(value any) []string {
-	switch v := value.(type) {
-	case []string:
-		return v
-	case []any:
-		var out []string
-		for _, item := range v {
-			if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
-				out = append(out, s)
-			}
-		}
-		return out
-	case string:
-		if strings.TrimSpace(v) == "" {
-			return nil
-		}
-		return []string{v}
-	default:
-		return nil
-	}
-}
-
-func toInt(value any, fallback int) int {
-	switch v := value.(type) {
-	case int:
-		return v
-	case int64:
-		return int(v)
-	case float64:
-		return int(v)
-	case json.Number:
-		if parsed, err := v.Int64(); err == nil {
-			return int(parsed)
-		}
-	}
-	return fallback
-}
-
 func requireConfirm(args map[string]any) error {
 	if val, ok := args["confirm"].(bool); ok && val {
 		return nil
@@ -4,8 +4,9 @@ func schemaECRListRepositories() map[string]any {
 	return map[string]any{
 		"type": "object",
 		"properties": map[string]any{
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -28,6 +29,7 @@ func schemaECRListImages() map[string]any {
 			"repositoryName": map[string]any{"type": "string"},
 			"tagStatus":      map[string]any{"type": "string", "enum": []string{"TAGGED", "UNTAGGED", "ANY"}},
 			"limit":          map[string]any{"type": "number"},
+			"nextToken":      map[string]any{"type": "string"},
 			"region":         map[string]any{"type": "string"},
 		},
 		"required": []string{"repositoryName"},
@@ -43,6 +45,7 @@ func schemaECRDescribeImages() map[string]any {
 			"imageDigests":   map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
 			"tagStatus":      map[string]any{"type": "string", "enum": []string{"TAGGED", "UNTAGGED", "ANY"}},
 			"limit":          map[string]any{"type": "number"},
+			"nextToken":      map[string]any{"type": "string"},
 			"region":         map[string]any{"type": "string"},
 		},
 		"required": []string{"repositoryName"},
@@ -0,0 +1,167 @@
+package awseks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+func (s *Service) handleCheckAddonUpdates(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
+	if cluster == "" {
+		return awsutil.ErrorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.eksClient(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	clusterOut, err := client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: aws.String(cluster)})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	if clusterOut.Cluster == nil {
+		return awsutil.ErrorResult(fmt.Errorf("cluster %s not found", cluster)), fmt.Errorf("cluster %s not found", cluster)
+	}
+	kubernetesVersion := aws.ToString(clusterOut.Cluster.Version)
+
+	var names []string
+	input := &eks.ListAddonsInput{ClusterName: aws.String(cluster)}
+	for {
+		out, err := client.ListAddons(ctx, input)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		names = append(names, out.Addons...)
+		if aws.ToString(out.NextToken) == "" {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+	sort.Strings(names)
+
+	addons := make([]map[string]any, 0, len(names))
+	var outdated, unhealthy, warnings []string
+	for _, name := range names {
+		addonOut, err := client.DescribeAddon(ctx, &eks.DescribeAddonInput{ClusterName: aws.String(cluster), AddonName: aws.String(name)})
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("describe addon %s failed: %v", name, err))
+			continue
+		}
+		if addonOut.Addon == nil {
+			continue
+		}
+		entry := summarizeAddon(*addonOut.Addon)
+		current := aws.ToString(addonOut.Addon.AddonVersion)
+		if len(addonOut.Addon.Health.Issues) > 0 {
+			unhealthy = append(unhealthy, name)
+		}
+		compatible, defaultVersion, err := compatibleAddonVersions(ctx, client, name, kubernetesVersion)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("describe addon versions for %s failed: %v", name, err))
+			addons = append(addons, entry)
+			continue
+		}
+		newer := newerAddonVersions(current, compatible)
+		entry["defaultVersion"] = defaultVersion
+		entry["newerVersions"] = newer
+		entry["updateAvailable"] = len(newer) > 0
+		if len(newer) > 0 {
+			entry["latestVersion"] = newer[0]
+			outdated = append(outdated, name)
+		}
+		addons = append(addons, entry)
+	}
+
+	result := map[string]any{
+		"region":            awsutil.RegionOrDefault(usedRegion),
+		"clusterName":       cluster,
+		"kubernetesVersion": kubernetesVersion,
+		"addons":            addons,
+		"outdated":          outdated,
+		"unhealthy":         unhealthy,
+		"summary": map[string]any{
+			"total":     len(names),
+			"outdated":  len(outdated),
+			"unhealthy": len(unhealthy),
+		},
+	}
+	if len(warnings) > 0 {
+		result["warnings"] = warnings
+	}
+	return mcp.ToolResult{
+		Data: s.ctx.Redactor.RedactValue(result),
+		Metadata: mcp.ToolMetadata{
+			Resources: []string{fmt.Sprintf("eks/cluster/%s", cluster)},
+		},
+	}, nil
+}
+
+// compatibleAddonVersions returns the add-on versions published for the
+// cluster's Kubernetes version and the one EKS installs by default.
+func compatibleAddonVersions(ctx context.Context, client *eks.Client, name, kubernetesVersion string) ([]string, string, error) {
+	input := &eks.DescribeAddonVersionsInput{AddonName: aws.String(name)}
+	if kubernetesVersion != "" {
+		input.KubernetesVersion = aws.String(kubernetesVersion)
+	}
+	var versions []string
+	defaultVersion := ""
+	for {
+		out, err := client.DescribeAddonVersions(ctx, input)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, info := range out.Addons {
+			for _, version := range info.AddonVersions {
+				versions = append(versions, aws.ToString(version.AddonVersion))
+				for _, compat := range version.Compatibilities {
+					if compat.DefaultVersion && (kubernetesVersion == "" || aws.ToString(compat.ClusterVersion) == kubernetesVersion) {
+						defaultVersion = aws.ToString(version.AddonVersion)
+					}
+				}
+			}
+		}
+		if aws.ToString(out.NextToken) == "" {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+	return versions, defaultVersion, nil
+}
+
+// newerAddonVersions returns the versions above current, newest first.
+// Add-on versions look like v1.18.3-eksbuild.2; the eksbuild suffix parses
+// as a semver prerelease, which still orders builds numerically.
+func newerAddonVersions(current string, versions []string) []string {
+	currentVersion, err := semver.NewVersion(current)
+	if err != nil {
+		return []string{}
+	}
+	seen := map[string]struct{}{}
+	var newer []*semver.Version
+	for _, raw := range versions {
+		if _, ok := seen[raw]; ok {
+			continue
+		}
+		seen[raw] = struct{}{}
+		version, err := semver.NewVersion(raw)
+		if err != nil || !version.GreaterThan(currentVersion) {
+			continue
+		}
+		newer = append(newer, version)
+	}
+	sort.Sort(sort.Reverse(semver.Collection(newer)))
+	out := make([]string, 0, len(newer))
+	for _, version := range newer {
+		out = append(out, version.Original())
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package awseks
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestNewerAddonVersions(t *testing.T) {
+	versions := []string{"v1.18.3-eksbuild.2", "v1.18.3-eksbuild.10", "v1.19.0-eksbuild.1", "v1.18.1-eksbuild.1", "v1.19.0-eksbuild.1", "bogus"}
+	got := newerAddonVersions("v1.18.3-eksbuild.2", versions)
+	want := []string{"v1.19.0-eksbuild.1", "v1.18.3-eksbuild.10"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got := newerAddonVersions("v1.19.0-eksbuild.1", versions); len(got) != 0 {
+		t.Fatalf("expected no newer versions, got %v", got)
+	}
+	if got := newerAddonVersions("custom", versions); len(got) != 0 {
+		t.Fatalf("expected unparseable current version to report nothing, got %v", got)
+	}
+}
+
+func TestHandleCheckAddonUpdates(t *testing.T) {
+	client := newEKSTestClient(t, map[string]string{
+		"/clusters/demo":                `{"cluster":{"name":"demo","version":"1.29"}}`,
+		"/clusters/demo/addons":         `{"addons":["vpc-cni"]}`,
+		"/clusters/demo/addons/vpc-cni": `{"addon":{"addonName":"vpc-cni","addonVersion":"v1.18.1-eksbuild.1","status":"DEGRADED","health":{"issues":[{"code":"InsufficientNumberOfReplicas","message":"pods not ready"}]}}}`,
+		"/addons/supported-versions":    `{"addons":[{"addonName":"vpc-cni","addonVersions":[{"addonVersion":"v1.18.3-eksbuild.2","compatibilities":[{"clusterVersion":"1.29","defaultVersion":true}]},{"addonVersion":"v1.18.1-eksbuild.1","compatibilities":[{"clusterVersion":"1.29"}]}]}]}`,
+	})
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		eksClient: func(context.Context, string) (*eks.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleCheckAddonUpdates(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"clusterName": "demo"}})
+	if err != nil {
+		t.Fatalf("check addon updates: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if data["kubernetesVersion"] != "1.29" {
+		t.Fatalf("unexpected kubernetes version: %#v", data)
+	}
+	addon := data["addons"].([]map[string]any)[0]
+	if addon["updateAvailable"] != true || addon["latestVersion"] != "v1.18.3-eksbuild.2" || addon["defaultVersion"] != "v1.18.3-eksbuild.2" {
+		t.Fatalf("unexpected addon entry: %#v", addon)
+	}
+	summary := data["summary"].(map[string]any)
+	if summary["outdated"] != 1 || summary["unhealthy"] != 1 {
+		t.Fatalf("unexpected summary: %#v", summary)
+	}
+	if _, err := svc.handleCheckAddonUpdates(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected missing cluster error")
+	}
+}
@@ -0,0 +1,284 @@
+package awseks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	asgtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+// capacityTally accumulates node counts across managed nodegroups and
+// self-managed groups.
+type capacityTally struct {
+	desired, min, max, spot int
+	capacityTypes           map[string]int
+	instanceTypes           map[string]struct{}
+}
+
+func (c *capacityTally) add(desired, min, max, spot int, capacityType string, instanceTypes []string) {
+	c.desired += desired
+	c.min += min
+	c.max += max
+	c.spot += spot
+	c.capacityTypes[capacityType] += desired
+	for _, instanceType := range instanceTypes {
+		if instanceType != "" {
+			c.instanceTypes[instanceType] = struct{}{}
+		}
+	}
+}
+
+func (s *Service) handleCapacityOverview(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
+	if cluster == "" {
+		return awsutil.ErrorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	eksClient, usedRegion, err := s.eksClient(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	asgClient, _, err := s.asgClient(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+
+	tally := &capacityTally{capacityTypes: map[string]int{}, instanceTypes: map[string]struct{}{}}
+	var warnings []string
+
+	nodegroupNames, err := listAllNodegroups(ctx, eksClient, cluster)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	managedASGs := map[string]struct{}{}
+	nodegroups := make([]map[string]any, 0, len(nodegroupNames))
+	for _, name := range nodegroupNames {
+		out, err := eksClient.DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{ClusterName: aws.String(cluster), NodegroupName: aws.String(name)})
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("describe nodegroup %s failed: %v", name, err))
+			continue
+		}
+		if out.Nodegroup == nil {
+			continue
+		}
+		group := *out.Nodegroup
+		if group.Resources != nil {
+			for _, asg := range group.Resources.AutoScalingGroups {
+				managedASGs[aws.ToString(asg.Name)] = struct{}{}
+			}
+		}
+		desired, min, max := nodegroupScaling(group.ScalingConfig)
+		capacityType := string(group.CapacityType)
+		if capacityType == "" {
+			capacityType = string(ekstypes.CapacityTypesOnDemand)
+		}
+		spot := 0
+		if group.CapacityType == ekstypes.CapacityTypesSpot {
+			spot = desired
+		}
+		tally.add(desired, min, max, spot, capacityType, group.InstanceTypes)
+		nodegroups = append(nodegroups, summarizeNodegroup(group))
+	}
+
+	selfManaged, asgWarnings := selfManagedGroups(ctx, asgClient, cluster, managedASGs, tally)
+	warnings = append(warnings, asgWarnings...)
+
+	fargate, fargateWarnings := fargateProfiles(ctx, eksClient, cluster)
+	warnings = append(warnings, fargateWarnings...)
+
+	instanceTypes := make([]string, 0, len(tally.instanceTypes))
+	for instanceType := range tally.instanceTypes {
+		instanceTypes = append(instanceTypes, instanceType)
+	}
+	sort.Strings(instanceTypes)
+	spotPercent := 0.0
+	if tally.desired > 0 {
+		spotPercent = math.Round(float64(tally.spot)/float64(tally.desired)*1000) / 10
+	}
+	result := map[string]any{
+		"region":                awsutil.RegionOrDefault(usedRegion),
+		"clusterName":           cluster,
+		"managedNodegroups":     nodegroups,
+		"selfManagedGroups":     selfManaged,
+		"fargateProfiles":       fargate,
+		"totalDesiredNodes":     tally.desired,
+		"totalMinNodes":         tally.min,
+		"totalMaxNodes":         tally.max,
+		"spotNodes":             tally.spot,
+		"spotPercent":           spotPercent,
+		"nodesByCapacityType":   tally.capacityTypes,
+		"instanceTypes":         instanceTypes,
+		"fargateProfileCount":   len(fargate),
+		"managedNodegroupCount": len(nodegroups),
+		"selfManagedGroupCount": len(selfManaged),
+	}
+	if len(warnings) > 0 {
+		result["warnings"] = warnings
+	}
+	return mcp.ToolResult{
+		Data: s.ctx.Redactor.RedactValue(result),
+		Metadata: mcp.ToolMetadata{
+			Resources: []string{fmt.Sprintf("eks/cluster/%s", cluster)},
+		},
+	}, nil
+}
+
+func listAllNodegroups(ctx context.Context, client *eks.Client, cluster string) ([]string, error) {
+	var names []string
+	input := &eks.ListNodegroupsInput{ClusterName: aws.String(cluster)}
+	for {
+		out, err := client.ListNodegroups(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, out.Nodegroups...)
+		if aws.ToString(out.NextToken) == "" {
+			return names, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+func nodegroupScaling(config *ekstypes.NodegroupScalingConfig) (int, int, int) {
+	if config == nil {
+		return 0, 0, 0
+	}
+	return int(aws.ToInt32(config.DesiredSize)), int(aws.ToInt32(config.MinSize)), int(aws.ToInt32(config.MaxSize))
+}
+
+// selfManagedGroups finds Auto Scaling groups tagged for the cluster that
+// are not behind a managed nodegroup and adds them to tally.
+func selfManagedGroups(ctx context.Context, client *autoscaling.Client, cluster string, managed map[string]struct{}, tally *capacityTally) ([]map[string]any, []string) {
+	groups := []map[string]any{}
+	input := &autoscaling.DescribeAutoScalingGroupsInput{
+		Filters: []asgtypes.Filter{{Name: aws.String("tag-key"), Values: []string{"kubernetes.io/cluster/" + cluster}}},
+	}
+	for {
+		out, err := client.DescribeAutoScalingGroups(ctx, input)
+		if err != nil {
+			return groups, []string{fmt.Sprintf("describe auto scaling groups failed: %v", err)}
+		}
+		for _, group := range out.AutoScalingGroups {
+			name := aws.ToString(group.AutoScalingGroupName)
+			if _, ok := managed[name]; ok || asgHasTag(group, "eks:nodegroup-name") {
+				continue
+			}
+			desired := int(aws.ToInt32(group.DesiredCapacity))
+			spot := asgSpotNodes(group, desired)
+			capacityType := string(ekstypes.CapacityTypesOnDemand)
+			if spot == desired && desired > 0 {
+				capacityType = string(ekstypes.CapacityTypesSpot)
+			} else if spot > 0 {
+				capacityType = "MIXED"
+			}
+			instanceTypes := asgInstanceTypes(group)
+			tally.add(desired, int(aws.ToInt32(group.MinSize)), int(aws.ToInt32(group.MaxSize)), spot, capacityType, instanceTypes)
+			groups = append(groups, map[string]any{
+				"name":          name,
+				"desiredSize":   desired,
+				"minSize":       aws.ToInt32(group.MinSize),
+				"maxSize":       aws.ToInt32(group.MaxSize),
+				"capacityType":  capacityType,
+				"spotNodes":     spot,
+				"instanceTypes": instanceTypes,
+				"instanceCount": len(group.Instances),
+			})
+		}
+		if aws.ToString(out.NextToken) == "" {
+			return groups, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+func asgHasTag(group asgtypes.AutoScalingGroup, key string) bool {
+	for _, tag := range group.Tags {
+		if aws.ToString(tag.Key) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// asgSpotNodes estimates the spot share of desired from the mixed instances
+// distribution: the on-demand base first, then the on-demand percentage of
+// the rest, rounded up as Auto Scaling does. Groups without a mixed
+// instances policy are counted as on-demand.
+func asgSpotNodes(group asgtypes.AutoScalingGroup, desired int) int {
+	if group.MixedInstancesPolicy == nil || group.MixedInstancesPolicy.InstancesDistribution == nil {
+		return 0
+	}
+	dist := group.MixedInstancesPolicy.InstancesDistribution
+	base := int(aws.ToInt32(dist.OnDemandBaseCapacity))
+	percent := 100
+	if dist.OnDemandPercentageAboveBaseCapacity != nil {
+		percent = int(*dist.OnDemandPercentageAboveBaseCapacity)
+	}
+	if desired <= base {
+		return 0
+	}
+	above := desired - base
+	onDemandAbove := int(math.Ceil(float64(above) * float64(percent) / 100))
+	return above - onDemandAbove
+}
+
+func asgInstanceTypes(group asgtypes.AutoScalingGroup) []string {
+	seen := map[string]struct{}{}
+	var types []string
+	add := func(instanceType string) {
+		if instanceType == "" {
+			return
+		}
+		if _, ok := seen[instanceType]; ok {
+			return
+		}
+		seen[instanceType] = struct{}{}
+		types = append(types, instanceType)
+	}
+	if policy := group.MixedInstancesPolicy; policy != nil && policy.LaunchTemplate != nil {
+		for _, override := range policy.LaunchTemplate.Overrides {
+			add(aws.ToString(override.InstanceType))
+		}
+	}
+	for _, instance := range group.Instances {
+		add(aws.ToString(instance.InstanceType))
+	}
+	sort.Strings(types)
+	return types
+}
+
+func fargateProfiles(ctx context.Context, client *eks.Client, cluster string) ([]map[string]any, []string) {
+	profiles := []map[string]any{}
+	var warnings []string
+	input := &eks.ListFargateProfilesInput{ClusterName: aws.String(cluster)}
+	for {
+		out, err := client.ListFargateProfiles(ctx, input)
+		if err != nil {
+			return profiles, append(warnings, fmt.Sprintf("list fargate profiles failed: %v", err))
+		}
+		for _, name := range out.FargateProfileNames {
+			descOut, err := client.DescribeFargateProfile(ctx, &eks.DescribeFargateProfileInput{ClusterName: aws.String(cluster), FargateProfileName: aws.String(name)})
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("describe fargate profile %s failed: %v", name, err))
+				continue
+			}
+			if descOut.FargateProfile != nil {
+				profiles = append(profiles, summarizeFargateProfile(*descOut.FargateProfile))
+			}
+		}
+		if aws.ToString(out.NextToken) == "" {
+			return profiles, warnings
+		}
+		input.NextToken = out.NextToken
+	}
+}
@@ -0,0 +1,94 @@
+package awseks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	asgtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestASGSpotNodes(t *testing.T) {
+	mixed := func(base, percent int32) asgtypes.AutoScalingGroup {
+		return asgtypes.AutoScalingGroup{MixedInstancesPolicy: &asgtypes.MixedInstancesPolicy{
+			InstancesDistribution: &asgtypes.InstancesDistribution{OnDemandBaseCapacity: aws.Int32(base), OnDemandPercentageAboveBaseCapacity: aws.Int32(percent)},
+		}}
+	}
+	cases := []struct {
+		group   asgtypes.AutoScalingGroup
+		desired int
+		want    int
+	}{
+		{asgtypes.AutoScalingGroup{}, 5, 0},
+		{mixed(0, 0), 4, 4},
+		{mixed(2, 0), 4, 2},
+		{mixed(1, 50), 4, 1},
+		{mixed(5, 0), 3, 0},
+	}
+	for _, tc := range cases {
+		if got := asgSpotNodes(tc.group, tc.desired); got != tc.want {
+			t.Fatalf("asgSpotNodes(desired=%d) = %d, want %d", tc.desired, got, tc.want)
+		}
+	}
+}
+
+func TestHandleCapacityOverview(t *testing.T) {
+	eksClient := newEKSTestClient(t, map[string]string{
+		"/clusters/demo/node-groups":           `{"nodegroups":["ng-1"]}`,
+		"/clusters/demo/node-groups/ng-1":      `{"nodegroup":{"nodegroupName":"ng-1","capacityType":"SPOT","instanceTypes":["m5.large"],"scalingConfig":{"desiredSize":3,"minSize":1,"maxSize":5},"resources":{"autoScalingGroups":[{"name":"eks-ng-1"}]}}}`,
+		"/clusters/demo/fargate-profiles":      `{"fargateProfileNames":["fp-1"]}`,
+		"/clusters/demo/fargate-profiles/fp-1": `{"fargateProfile":{"fargateProfileName":"fp-1","status":"ACTIVE"}}`,
+	})
+	asgClient := newASGTestClient(t, map[string]string{
+		"DescribeAutoScalingGroups": `<DescribeAutoScalingGroupsResponse xmlns="http://autoscaling.amazonaws.com/doc/2011-01-01/">
+  <DescribeAutoScalingGroupsResult>
+    <AutoScalingGroups>
+      <member>
+        <AutoScalingGroupName>eks-ng-1</AutoScalingGroupName>
+        <DesiredCapacity>3</DesiredCapacity>
+      </member>
+      <member>
+        <AutoScalingGroupName>self-managed</AutoScalingGroupName>
+        <DesiredCapacity>1</DesiredCapacity>
+        <MinSize>1</MinSize>
+        <MaxSize>2</MaxSize>
+        <Instances>
+          <member><InstanceId>i-1</InstanceId><InstanceType>c5.xlarge</InstanceType></member>
+        </Instances>
+      </member>
+    </AutoScalingGroups>
+  </DescribeAutoScalingGroupsResult>
+</DescribeAutoScalingGroupsResponse>`,
+	})
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		eksClient: func(context.Context, string) (*eks.Client, string, error) {
+			return eksClient, "us-east-1", nil
+		},
+		asgClient: func(context.Context, string) (*autoscaling.Client, string, error) {
+			return asgClient, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleCapacityOverview(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"clusterName": "demo"}})
+	if err != nil {
+		t.Fatalf("capacity overview: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if data["totalDesiredNodes"] != 4 || data["spotNodes"] != 3 || data["spotPercent"] != 75.0 {
+		t.Fatalf("unexpected totals: %#v", data)
+	}
+	if data["selfManagedGroupCount"] != 1 || data["fargateProfileCount"] != 1 || data["managedNodegroupCount"] != 1 {
+		t.Fatalf("unexpected counts: %#v", data)
+	}
+	if types := data["instanceTypes"].([]string); len(types) != 2 || types[0] != "c5.xlarge" {
+		t.Fatalf("unexpected instance types: %#v", types)
+	}
+	if _, err := svc.handleCapacityOverview(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected missing cluster error")
+	}
+}
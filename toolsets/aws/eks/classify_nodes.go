@@ -0,0 +1,130 @@
+package awseks
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+// Node categories reported by aws.eks.classify_nodes.
+const (
+	nodeCategoryManaged      = "managed-nodegroup"
+	nodeCategorySelfManaged  = "self-managed"
+	nodeCategoryFargate      = "fargate"
+	nodeCategoryKarpenter    = "karpenter"
+	nodeCategoryUnclassified = "unclassified"
+)
+
+// Labels used to tell node provisioners apart. karpenter.sh/provisioner-name
+// is set by Karpenter releases before the NodePool API.
+const (
+	managedNodegroupLabel     = "eks.amazonaws.com/nodegroup"
+	karpenterNodePoolLabel    = "karpenter.sh/nodepool"
+	karpenterProvisionerLabel = "karpenter.sh/provisioner-name"
+)
+
+func (s *Service) handleClassifyNodes(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	if s.ctx.Clients == nil || s.ctx.Clients.Typed == nil {
+		err := errors.New("kubernetes client not configured")
+		return awsutil.ErrorResult(err), err
+	}
+	if err := s.ctx.Policy.CheckNamespace(req.User, "", false); err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	nodes, err := s.ctx.Clients.Typed.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: awsutil.ToString(req.Arguments["labelSelector"]),
+	})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	rows := make([]map[string]any, 0, len(nodes.Items))
+	counts := map[string]int{
+		nodeCategoryManaged:      0,
+		nodeCategorySelfManaged:  0,
+		nodeCategoryFargate:      0,
+		nodeCategoryKarpenter:    0,
+		nodeCategoryUnclassified: 0,
+	}
+	var unclassified []string
+	for i := range nodes.Items {
+		row := classifyNode(&nodes.Items[i])
+		category := awsutil.ToString(row["category"])
+		counts[category]++
+		if category == nodeCategoryUnclassified {
+			unclassified = append(unclassified, nodes.Items[i].Name)
+		}
+		rows = append(rows, row)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := awsutil.ToString(rows[i]["category"]), awsutil.ToString(rows[j]["category"])
+		if a != b {
+			return a < b
+		}
+		return awsutil.ToString(rows[i]["nodeName"]) < awsutil.ToString(rows[j]["nodeName"])
+	})
+	sort.Strings(unclassified)
+	data := map[string]any{
+		"nodes":      rows,
+		"count":      len(rows),
+		"categories": counts,
+	}
+	if len(unclassified) > 0 {
+		data["unclassifiedNodes"] = unclassified
+	}
+	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+}
+
+// classifyNode works out which provisioner owns a node from its labels and
+// provider id. Fargate and the provisioner labels take precedence; any other
+// node backed by an EC2 instance is assumed to come from a self-managed Auto
+// Scaling group, and anything else was joined by hand or by another tool.
+func classifyNode(node *corev1.Node) map[string]any {
+	row := map[string]any{
+		"nodeName":   node.Name,
+		"providerId": node.Spec.ProviderID,
+		"nodeReady":  nodeReady(node),
+	}
+	if instanceType := node.Labels[corev1.LabelInstanceTypeStable]; instanceType != "" {
+		row["instanceType"] = instanceType
+	}
+	if zone := node.Labels[corev1.LabelTopologyZone]; zone != "" {
+		row["zone"] = zone
+	}
+	if id := instanceIDFromProviderID(node.Spec.ProviderID); id != "" {
+		row["instanceId"] = id
+	}
+	switch {
+	case isFargateNode(node):
+		row["category"] = nodeCategoryFargate
+		if profile := node.Labels[fargateProfileLabel]; profile != "" {
+			row["fargateProfile"] = profile
+		}
+	case node.Labels[managedNodegroupLabel] != "":
+		row["category"] = nodeCategoryManaged
+		row["nodegroup"] = node.Labels[managedNodegroupLabel]
+		if capacityType := node.Labels["eks.amazonaws.com/capacityType"]; capacityType != "" {
+			row["capacityType"] = capacityType
+		}
+	case node.Labels[karpenterNodePoolLabel] != "" || node.Labels[karpenterProvisionerLabel] != "":
+		row["category"] = nodeCategoryKarpenter
+		if pool := node.Labels[karpenterNodePoolLabel]; pool != "" {
+			row["nodePool"] = pool
+		} else {
+			row["provisioner"] = node.Labels[karpenterProvisionerLabel]
+		}
+		if capacityType := node.Labels["karpenter.sh/capacity-type"]; capacityType != "" {
+			row["capacityType"] = capacityType
+		}
+	case row["instanceId"] != nil:
+		row["category"] = nodeCategorySelfManaged
+	default:
+		row["category"] = nodeCategoryUnclassified
+	}
+	return row
+}
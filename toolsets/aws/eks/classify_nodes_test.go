@@ -0,0 +1,80 @@
+package awseks
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/client-go/kubernetes/fake"
+
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/redact"
+)
+
+func TestHandleClassifyNodes(t *testing.T) {
+	managed := reconcileNode("ng-node", "aws:///us-east-1a/i-managed", true)
+	managed.Labels = map[string]string{managedNodegroupLabel: "ng-1", "eks.amazonaws.com/capacityType": "SPOT"}
+	karpenter := reconcileNode("karp-node", "aws:///us-east-1b/i-karp", true)
+	karpenter.Labels = map[string]string{karpenterNodePoolLabel: "default"}
+	legacy := reconcileNode("legacy-karp-node", "aws:///us-east-1b/i-legacy", true)
+	legacy.Labels = map[string]string{karpenterProvisionerLabel: "default"}
+	fargate := reconcileNode("fargate-ip-10-0-1-5.ec2.internal", "aws:///us-east-1a/0123abcd/fargate-ip-10-0-1-5.ec2.internal", true)
+	fargate.Labels = map[string]string{fargateComputeTypeLabel: "fargate", fargateProfileLabel: "fp-default"}
+	self := reconcileNode("asg-node", "aws:///us-east-1c/i-self", false)
+	manual := reconcileNode("manual-node", "", true)
+
+	svc := &Service{ctx: mcp.ToolContext{
+		Redactor: redact.New(),
+		Clients:  &kube.Clients{Typed: fake.NewSimpleClientset(&managed, &karpenter, &legacy, &fargate, &self, &manual)},
+		Policy:   policy.NewAuthorizer(),
+	}}
+	result, err := svc.handleClassifyNodes(context.Background(), mcp.ToolRequest{
+		Arguments: map[string]any{},
+		User:      policy.User{Role: policy.RoleCluster},
+	})
+	if err != nil {
+		t.Fatalf("classify nodes: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	counts := data["categories"].(map[string]int)
+	want := map[string]int{
+		nodeCategoryManaged:      1,
+		nodeCategoryKarpenter:    2,
+		nodeCategoryFargate:      1,
+		nodeCategorySelfManaged:  1,
+		nodeCategoryUnclassified: 1,
+	}
+	for category, count := range want {
+		if counts[category] != count {
+			t.Fatalf("%s: got %d, want %d (%#v)", category, counts[category], count, counts)
+		}
+	}
+	unclassified := data["unclassifiedNodes"].([]string)
+	if len(unclassified) != 1 || unclassified[0] != "manual-node" {
+		t.Fatalf("unexpected unclassified nodes: %#v", unclassified)
+	}
+	for _, row := range data["nodes"].([]map[string]any) {
+		switch row["nodeName"] {
+		case "ng-node":
+			if row["nodegroup"] != "ng-1" || row["capacityType"] != "SPOT" || row["instanceId"] != "i-managed" {
+				t.Fatalf("unexpected managed row: %#v", row)
+			}
+		case "legacy-karp-node":
+			if row["provisioner"] != "default" {
+				t.Fatalf("unexpected legacy karpenter row: %#v", row)
+			}
+		case "fargate-ip-10-0-1-5.ec2.internal":
+			if row["fargateProfile"] != "fp-default" {
+				t.Fatalf("unexpected fargate row: %#v", row)
+			}
+		}
+	}
+}
+
+func TestHandleClassifyNodesRequiresClient(t *testing.T) {
+	svc := &Service{ctx: mcp.ToolContext{Redactor: redact.New()}}
+	if _, err := svc.handleClassifyNodes(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected missing client error")
+	}
+}
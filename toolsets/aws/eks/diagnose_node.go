@@ -0,0 +1,267 @@
+package awseks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/argconv"
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+const nodegroupLabel = "eks.amazonaws.com/nodegroup"
+
+// instanceStatusView is the subset of aws.ec2.get_instance_status output the
+// findings below depend on.
+type instanceStatusView struct {
+	SystemStatus   struct{ Status string } `json:"systemStatus"`
+	InstanceStatus struct{ Status string } `json:"instanceStatus"`
+	Events         []struct {
+		Code        string `json:"code"`
+		Description string `json:"description"`
+	} `json:"events"`
+}
+
+func (s *Service) handleDiagnoseNode(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
+	nodeName := awsutil.ToString(req.Arguments["nodeName"])
+	if cluster == "" || nodeName == "" {
+		err := errors.New("clusterName and nodeName are required")
+		return awsutil.ErrorResult(err), err
+	}
+	if s.ctx.Clients == nil || s.ctx.Clients.Typed == nil {
+		err := errors.New("kubernetes client not configured")
+		return awsutil.ErrorResult(err), err
+	}
+	if err := s.ctx.Policy.CheckNamespace(req.User, "", false); err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	limit := argconv.Int(req.Arguments["activityLimit"], 10)
+	node, err := s.ctx.Clients.Typed.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	instanceID := instanceIDFromProviderID(node.Spec.ProviderID)
+	if instanceID == "" && isFargateNode(node) {
+		err := fmt.Errorf("node %s is a Fargate node with no EC2 instance; check its pod and Fargate profile instead", nodeName)
+		return awsutil.ErrorResult(err), err
+	}
+	if instanceID == "" {
+		err := fmt.Errorf("node %s has no EC2 provider id (%q)", nodeName, node.Spec.ProviderID)
+		return awsutil.ErrorResult(err), err
+	}
+	ec2Client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+
+	var warnings []string
+	var findings []map[string]any
+	addFinding := func(severity, summary, detail string) {
+		findings = append(findings, map[string]any{"severity": severity, "summary": summary, "detail": detail})
+	}
+
+	ready := nodeReady(node)
+	nodegroup := node.Labels[nodegroupLabel]
+	nodeInfo := map[string]any{
+		"name":       node.Name,
+		"providerId": node.Spec.ProviderID,
+		"ready":      ready,
+		"nodegroup":  nodegroup,
+		"conditions": summarizeNodeConditions(node.Status.Conditions),
+	}
+	for _, condition := range node.Status.Conditions {
+		switch {
+		case condition.Type == corev1.NodeReady && condition.Status != corev1.ConditionTrue:
+			addFinding("high", "Node NotReady", fmt.Sprintf("Ready=%s since %s: %s %s", condition.Status, condition.LastTransitionTime.UTC().Format("2006-01-02T15:04:05Z"), condition.Reason, condition.Message))
+		case condition.Type != corev1.NodeReady && condition.Status == corev1.ConditionTrue:
+			addFinding("medium", fmt.Sprintf("Node reports %s", condition.Type), condition.Message)
+		}
+	}
+
+	// Anything added after this point came from the AWS side.
+	nodeFindings := len(findings)
+	data := map[string]any{
+		"region":     awsutil.RegionOrDefault(usedRegion),
+		"cluster":    cluster,
+		"node":       nodeInfo,
+		"instanceId": instanceID,
+	}
+
+	instanceRunning := false
+	var asgNames []string
+	instances, err := describeInstancesByID(ctx, ec2Client, []string{instanceID})
+	switch {
+	case err != nil:
+		warnings = append(warnings, fmt.Sprintf("describe instance failed: %v", err))
+	case len(instances) == 0:
+		addFinding("critical", "EC2 instance not found", fmt.Sprintf("%s no longer exists; the Node object is stale and should be removed if the instance was replaced", instanceID))
+	default:
+		inst := instances[0]
+		state := ""
+		if inst.State != nil {
+			state = string(inst.State.Name)
+		}
+		instanceRunning = state == "running"
+		instanceInfo := awsutil.NormalizeTimes(map[string]any{
+			"state":            state,
+			"type":             inst.InstanceType,
+			"launchTime":       inst.LaunchTime,
+			"availabilityZone": "",
+		})
+		if inst.Placement != nil {
+			instanceInfo["availabilityZone"] = aws.ToString(inst.Placement.AvailabilityZone)
+		}
+		if inst.StateReason != nil {
+			instanceInfo["stateReason"] = aws.ToString(inst.StateReason.Message)
+		}
+		data["instance"] = instanceInfo
+		if !instanceRunning {
+			addFinding("critical", "EC2 instance not running", fmt.Sprintf("%s is %s %s", instanceID, state, awsutil.ToString(instanceInfo["stateReason"])))
+		}
+		for _, tag := range inst.Tags {
+			if aws.ToString(tag.Key) == "aws:autoscaling:groupName" {
+				asgNames = append(asgNames, aws.ToString(tag.Value))
+			}
+		}
+	}
+
+	if nodegroup != "" {
+		out, err := s.describeNodegroup(ctx, region, cluster, nodegroup)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("describe nodegroup failed: %v", err))
+		} else if out != nil {
+			data["nodegroup"] = summarizeNodegroup(*out)
+			if out.Health != nil {
+				for _, issue := range out.Health.Issues {
+					addFinding("medium", fmt.Sprintf("Nodegroup health issue %s", issue.Code), aws.ToString(issue.Message))
+				}
+			}
+			if len(asgNames) == 0 && out.Resources != nil {
+				for _, group := range out.Resources.AutoScalingGroups {
+					asgNames = append(asgNames, aws.ToString(group.Name))
+				}
+			}
+		}
+	}
+
+	if s.ctx.Registry != nil {
+		if _, ok := s.ctx.Registry.Get("aws.ec2.get_instance_status"); !ok {
+			warnings = append(warnings, "aws ec2 toolset not enabled")
+		} else {
+			result, err := s.ctx.CallTool(ctx, req.User, "aws.ec2.get_instance_status", map[string]any{
+				"instanceId": instanceID,
+				"region":     region,
+			})
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("instance status lookup failed: %v", err))
+			} else if payload, ok := result.Data.(map[string]any); ok {
+				data["instanceStatus"] = payload["status"]
+				var status instanceStatusView
+				if decodeInto(payload["status"], &status) == nil {
+					if status.SystemStatus.Status == "impaired" {
+						addFinding("high", "EC2 system status check failing", "AWS-side hardware or network problem on the host; stop/start the instance to move it")
+					}
+					if status.InstanceStatus.Status == "impaired" {
+						addFinding("high", "EC2 instance status check failing", "The OS is unreachable (kernel panic, memory exhaustion, full disk); check the console output")
+					}
+					for _, event := range status.Events {
+						if strings.HasPrefix(event.Description, "[Completed]") || strings.HasPrefix(event.Description, "[Canceled]") {
+							continue
+						}
+						addFinding("medium", fmt.Sprintf("Scheduled event %s", event.Code), event.Description)
+					}
+				}
+			}
+		}
+		if len(asgNames) > 0 {
+			if _, ok := s.ctx.Registry.Get("aws.ec2.list_scaling_activities"); ok {
+				var activities []map[string]any
+				for _, group := range asgNames {
+					result, err := s.ctx.CallTool(ctx, req.User, "aws.ec2.list_scaling_activities", map[string]any{
+						"autoScalingGroupName": group,
+						"limit":                limit,
+						"region":               region,
+					})
+					if err != nil {
+						warnings = append(warnings, fmt.Sprintf("scaling activities for %s failed: %v", group, err))
+						continue
+					}
+					payload, _ := result.Data.(map[string]any)
+					items, _ := payload["activities"].([]map[string]any)
+					for _, item := range items {
+						description := awsutil.ToString(item["description"])
+						if !strings.Contains(description, instanceID) {
+							continue
+						}
+						activities = append(activities, item)
+						if strings.HasPrefix(description, "Terminating") {
+							addFinding("high", "Auto Scaling is terminating this instance", fmt.Sprintf("%s (cause: %s)", description, awsutil.ToString(item["cause"])))
+						}
+					}
+				}
+				data["autoScalingGroups"] = asgNames
+				data["scalingActivities"] = activities
+			}
+		}
+	}
+
+	if !ready && instanceRunning && len(findings) == nodeFindings {
+		addFinding("medium", "Kubelet stopped reporting", "The instance is running with passing checks; inspect kubelet and container runtime logs on the node (SSM session) and VPC CNI health")
+	}
+	data["findings"] = findings
+	if len(warnings) > 0 {
+		data["warnings"] = warnings
+	}
+	return mcp.ToolResult{
+		Data:     s.ctx.Redactor.RedactValue(data),
+		Metadata: mcp.ToolMetadata{Resources: []string{"nodes/" + nodeName, "ec2/instance/" + instanceID}},
+	}, nil
+}
+
+func (s *Service) describeNodegroup(ctx context.Context, region, cluster, nodegroup string) (*ekstypes.Nodegroup, error) {
+	client, _, err := s.eksClient(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	out, err := client.DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{ClusterName: aws.String(cluster), NodegroupName: aws.String(nodegroup)})
+	if err != nil {
+		return nil, err
+	}
+	return out.Nodegroup, nil
+}
+
+func summarizeNodeConditions(conditions []corev1.NodeCondition) []map[string]any {
+	out := make([]map[string]any, 0, len(conditions))
+	for _, condition := range conditions {
+		out = append(out, awsutil.NormalizeTimes(map[string]any{
+			"type":               condition.Type,
+			"status":             condition.Status,
+			"reason":             condition.Reason,
+			"message":            condition.Message,
+			"lastHeartbeatTime":  condition.LastHeartbeatTime.Time,
+			"lastTransitionTime": condition.LastTransitionTime.Time,
+		}))
+	}
+	return out
+}
+
+// decodeInto re-reads a tool result fragment (typed SDK values inside
+// map[string]any) as the given struct.
+func decodeInto(value any, target any) error {
+	blob, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(blob, target)
+}
@@ -0,0 +1,109 @@
+package awseks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"rootcause/internal/config"
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/redact"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+func TestEKSDiagnoseNodeWithStubbedClients(t *testing.T) {
+	eksClient := newEKSTestClient(t, map[string]string{
+		"/clusters/demo/node-groups/ng-1": `{"nodegroup":{"nodegroupName":"ng-1","resources":{"autoScalingGroups":[{"name":"asg-1"}]}}}`,
+	})
+	ec2Client := newEC2TestClient(t, map[string]string{
+		"DescribeInstances": `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <reservationSet>
+    <item>
+      <instancesSet>
+        <item>
+          <instanceId>i-1</instanceId>
+          <instanceState><code>16</code><name>running</name></instanceState>
+        </item>
+      </instancesSet>
+    </item>
+  </reservationSet>
+</DescribeInstancesResponse>`,
+	})
+	node := reconcileNode("node-1", "aws:///us-east-1a/i-1", false)
+	node.Labels = map[string]string{nodegroupLabel: "ng-1"}
+	node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue, Message: "disk full"})
+
+	cfg := config.DefaultConfig()
+	reg := mcp.NewRegistry(&cfg)
+	toolCtx := mcp.ToolContext{
+		Config:   &cfg,
+		Redactor: redact.New(),
+		Policy:   policy.NewAuthorizer(),
+		Clients:  &kube.Clients{Typed: fake.NewSimpleClientset(&node)},
+		Registry: reg,
+	}
+	toolCtx.Invoker = mcp.NewToolInvoker(reg, toolCtx)
+	var scaledGroup string
+	_ = reg.Add(mcp.ToolSpec{
+		Name:      "aws.ec2.get_instance_status",
+		ToolsetID: "aws",
+		Handler: func(context.Context, mcp.ToolRequest) (mcp.ToolResult, error) {
+			return mcp.ToolResult{Data: map[string]any{"status": map[string]any{
+				"instanceId":     "i-1",
+				"systemStatus":   map[string]any{"Status": "ok"},
+				"instanceStatus": map[string]any{"Status": "impaired"},
+				"events":         []map[string]any{{"code": "system-reboot", "description": "scheduled reboot"}},
+			}}}, nil
+		},
+	})
+	_ = reg.Add(mcp.ToolSpec{
+		Name:      "aws.ec2.list_scaling_activities",
+		ToolsetID: "aws",
+		Handler: func(_ context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+			scaledGroup = awsutil.ToString(req.Arguments["autoScalingGroupName"])
+			return mcp.ToolResult{Data: map[string]any{"activities": []map[string]any{
+				{"description": "Terminating EC2 instance: i-1", "cause": "instance failed health check"},
+				{"description": "Launching a new EC2 instance: i-2"},
+			}}}, nil
+		},
+	})
+	svc := &Service{
+		ctx:       toolCtx,
+		eksClient: func(context.Context, string) (*eks.Client, string, error) { return eksClient, "us-east-1", nil },
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) { return ec2Client, "us-east-1", nil },
+	}
+
+	args := map[string]any{"clusterName": "demo", "nodeName": "node-1"}
+	if _, err := svc.handleDiagnoseNode(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleNamespace, AllowedNamespaces: []string{"default"}}, Arguments: args}); err == nil {
+		t.Fatalf("expected namespace users to be rejected")
+	}
+	result, err := svc.handleDiagnoseNode(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: args})
+	if err != nil {
+		t.Fatalf("diagnose node: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if scaledGroup != "asg-1" {
+		t.Fatalf("expected scaling activities for the nodegroup ASG, got %q", scaledGroup)
+	}
+	if activities := data["scalingActivities"].([]map[string]any); len(activities) != 1 {
+		t.Fatalf("expected only activities for i-1, got %#v", activities)
+	}
+	summaries := map[string]bool{}
+	for _, finding := range data["findings"].([]map[string]any) {
+		summaries[awsutil.ToString(finding["summary"])] = true
+	}
+	for _, want := range []string{"Node NotReady", "Node reports DiskPressure", "EC2 instance status check failing", "Scheduled event system-reboot", "Auto Scaling is terminating this instance"} {
+		if !summaries[want] {
+			t.Fatalf("missing finding %q in %#v", want, data["findings"])
+		}
+	}
+	if summaries["Kubelet stopped reporting"] {
+		t.Fatalf("did not expect kubelet finding when AWS explains the failure")
+	}
+}
@@ -16,10 +16,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/eks"
 	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 	"github.com/aws/aws-sdk-go-v2/service/kms"
-	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 
+	"rootcause/internal/argconv"
+	awslib "rootcause/internal/aws"
 	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
 )
 
 type Service struct {
@@ -56,66 +58,79 @@ func ToolSpecs(
 	return []mcp.ToolSpec{
 		{Name: "aws.eks.list_clusters", Description: "List EKS clusters.", ToolsetID: toolsetID, InputSchema: schemaEKSListClusters(), Safety: mcp.SafetyReadOnly, Handler: svc.handleListClusters},
 		{Name: "aws.eks.get_cluster", Description: "Get an EKS cluster by name.", ToolsetID: toolsetID, InputSchema: schemaEKSGetCluster(), Safety: mcp.SafetyReadOnly, Handler: svc.handleGetCluster},
+		{Name: "aws.eks.get_endpoint_access", Description: "Report an EKS cluster's public/private API endpoint access and public CIDRs, flagging endpoints open to 0.0.0.0/0.", ToolsetID: toolsetID, InputSchema: schemaEKSGetEndpointAccess(), Safety: mcp.SafetyReadOnly, Handler: svc.handleGetEndpointAccess},
 		{Name: "aws.eks.list_nodegroups", Description: "List EKS nodegroups for a cluster.", ToolsetID: toolsetID, InputSchema: schemaEKSListNodegroups(), Safety: mcp.SafetyReadOnly, Handler: svc.handleListNodegroups},
 		{Name: "aws.eks.get_nodegroup", Description: "Get an EKS nodegroup by name.", ToolsetID: toolsetID, InputSchema: schemaEKSGetNodegroup(), Safety: mcp.SafetyReadOnly, Handler: svc.handleGetNodegroup},
 		{Name: "aws.eks.list_addons", Description: "List EKS addons for a cluster.", ToolsetID: toolsetID, InputSchema: schemaEKSListAddons(), Safety: mcp.SafetyReadOnly, Handler: svc.handleListAddons},
 		{Name: "aws.eks.get_addon", Description: "Get an EKS addon by name.", ToolsetID: toolsetID, InputSchema: schemaEKSGetAddon(), Safety: mcp.SafetyReadOnly, Handler: svc.handleGetAddon},
+		{Name: "aws.eks.check_addon_updates", Description: "Compare each installed EKS add-on with the versions compatible with the cluster's Kubernetes version and report newer releases and health issues.", ToolsetID: toolsetID, InputSchema: schemaEKSCheckAddonUpdates(), Safety: mcp.SafetyReadOnly, Handler: svc.handleCheckAddonUpdates},
 		{Name: "aws.eks.list_fargate_profiles", Description: "List EKS fargate profiles for a cluster.", ToolsetID: toolsetID, InputSchema: schemaEKSListFargateProfiles(), Safety: mcp.SafetyReadOnly, Handler: svc.handleListFargateProfiles},
 		{Name: "aws.eks.get_fargate_profile", Description: "Get an EKS fargate profile by name.", ToolsetID: toolsetID, InputSchema: schemaEKSGetFargateProfile(), Safety: mcp.SafetyReadOnly, Handler: svc.handleGetFargateProfile},
+		{Name: "aws.eks.capacity_overview", Description: "Summarize EKS compute capacity across managed nodegroups, self-managed Auto Scaling groups and Fargate profiles, with total desired nodes and spot percentage.", ToolsetID: toolsetID, InputSchema: schemaEKSCapacityOverview(), Safety: mcp.SafetyReadOnly, Handler: svc.handleCapacityOverview},
 		{Name: "aws.eks.list_identity_provider_configs", Description: "List EKS identity provider configs.", ToolsetID: toolsetID, InputSchema: schemaEKSListIdentityProviderConfigs(), Safety: mcp.SafetyReadOnly, Handler: svc.handleListIdentityProviderConfigs},
 		{Name: "aws.eks.get_identity_provider_config", Description: "Get an EKS identity provider config.", ToolsetID: toolsetID, InputSchema: schemaEKSGetIdentityProviderConfig(), Safety: mcp.SafetyReadOnly, Handler: svc.handleGetIdentityProviderConfig},
+		{Name: "aws.eks.list_pod_identity_associations", Description: "List EKS Pod Identity associations with their namespace, service account and IAM role, checking that each service account exists.", ToolsetID: toolsetID, InputSchema: schemaEKSListPodIdentityAssociations(), Safety: mcp.SafetyReadOnly, Handler: svc.handleListPodIdentityAssociations},
 		{Name: "aws.eks.list_updates", Description: "List EKS updates for a cluster or nodegroup.", ToolsetID: toolsetID, InputSchema: schemaEKSListUpdates(), Safety: mcp.SafetyReadOnly, Handler: svc.handleListUpdates},
 		{Name: "aws.eks.get_update", Description: "Get an EKS update by id.", ToolsetID: toolsetID, InputSchema: schemaEKSGetUpdate(), Safety: mcp.SafetyReadOnly, Handler: svc.handleGetUpdate},
 		{Name: "aws.eks.list_nodes", Description: "List EC2 instances backing EKS nodegroups.", ToolsetID: toolsetID, InputSchema: schemaEKSListNodes(), Safety: mcp.SafetyReadOnly, Handler: svc.handleListNodes},
+		{Name: "aws.eks.reconcile_nodes", Description: "Reconcile EC2 instances with Kubernetes Nodes to find ghost or unregistered nodes.", ToolsetID: toolsetID, InputSchema: schemaEKSReconcileNodes(), Safety: mcp.SafetyReadOnly, Handler: svc.handleReconcileNodes},
+		{Name: "aws.eks.classify_nodes", Description: "Classify Kubernetes Nodes as managed nodegroup, self-managed, Fargate or Karpenter nodes from their labels and provider id.", ToolsetID: toolsetID, InputSchema: schemaEKSClassifyNodes(), Safety: mcp.SafetyReadOnly, Handler: svc.handleClassifyNodes},
+		{Name: "aws.eks.diagnose_node", Description: "Explain a NotReady node using its EC2 instance status, scheduled events and ASG scaling activities.", ToolsetID: toolsetID, InputSchema: schemaEKSDiagnoseNode(), Safety: mcp.SafetyReadOnly, Handler: svc.handleDiagnoseNode},
 		{Name: "aws.eks.debug", Description: "Debug an EKS cluster with optional STS/KMS/ECR/IAM checks.", ToolsetID: toolsetID, InputSchema: schemaEKSDebug(), Safety: mcp.SafetyReadOnly, Handler: svc.handleDebug},
 	}
 }
 
 func (s *Service) handleListClusters(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.eksClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &eks.ListClustersInput{}
 	if limit > 0 {
 		input.MaxResults = aws.Int32(int32(limit))
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var clusters []string
 	for {
 		out, err := client.ListClusters(ctx, input)
 		if err != nil {
-			return errorResult(err), err
-		}
-		clusters = append(clusters, out.Clusters...)
-		if limit > 0 && len(clusters) >= limit {
-			clusters = clusters[:limit]
-			break
+			return awsutil.ErrorResult(err), err
 		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.Clusters), out.NextToken)
+		clusters = append(clusters, out.Clusters[start:end]...)
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":   regionOrDefault(usedRegion),
+		"region":   awsutil.RegionOrDefault(usedRegion),
 		"clusters": clusters,
 		"count":    len(clusters),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleDebug(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	clusterName := strings.TrimSpace(toString(req.Arguments["clusterName"]))
+	clusterName := strings.TrimSpace(awsutil.ToString(req.Arguments["clusterName"]))
 	if clusterName == "" {
-		return errorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
+		return awsutil.ErrorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
 	}
-	region := toString(req.Arguments["region"])
-	serviceAccountNamespace := strings.TrimSpace(toString(req.Arguments["serviceAccountNamespace"]))
-	serviceAccountName := strings.TrimSpace(toString(req.Arguments["serviceAccountName"]))
-	roleArn := strings.TrimSpace(toString(req.Arguments["roleArn"]))
-	roleName := strings.TrimSpace(toString(req.Arguments["roleName"]))
+	region := awsutil.ToString(req.Arguments["region"])
+	serviceAccountNamespace := strings.TrimSpace(awsutil.ToString(req.Arguments["serviceAccountNamespace"]))
+	serviceAccountName := strings.TrimSpace(awsutil.ToString(req.Arguments["serviceAccountName"]))
+	roleArn := strings.TrimSpace(awsutil.ToString(req.Arguments["roleArn"]))
+	roleName := strings.TrimSpace(awsutil.ToString(req.Arguments["roleName"]))
 	includeSts := true
 	if val, ok := req.Arguments["includeSts"].(bool); ok {
 		includeSts = val
@@ -132,21 +147,21 @@ func (s *Service) handleDebug(ctx context.Context, req mcp.ToolRequest) (mcp.Too
 	if val, ok := req.Arguments["includeIam"].(bool); ok {
 		includeIAM = val
 	}
-	repoName := strings.TrimSpace(toString(req.Arguments["repositoryName"]))
+	repoName := strings.TrimSpace(awsutil.ToString(req.Arguments["repositoryName"]))
 	if repoName != "" {
 		includeEcr = true
 	}
-	imageLimit := toInt(req.Arguments["imageLimit"], 50)
-	imageTags := toStringSlice(req.Arguments["imageTags"])
-	imageDigests := toStringSlice(req.Arguments["imageDigests"])
+	imageLimit := argconv.Int(req.Arguments["imageLimit"], 50)
+	imageTags := awsutil.ToStringSlice(req.Arguments["imageTags"])
+	imageDigests := awsutil.ToStringSlice(req.Arguments["imageDigests"])
 
 	client, usedRegion, err := s.eksClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: aws.String(clusterName)})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 
 	diagnostics := map[string]any{}
@@ -196,7 +211,7 @@ func (s *Service) handleDebug(ctx context.Context, req mcp.ToolRequest) (mcp.Too
 					warnings = append(warnings, err.Error())
 					continue
 				}
-				keys = append(keys, summarizeKMSKey(desc.KeyMetadata))
+				keys = append(keys, awsutil.KMSKeySummary(desc.KeyMetadata))
 			}
 		}
 		diagnostics["kmsKeys"] = keys
@@ -302,7 +317,7 @@ func (s *Service) handleDebug(ctx context.Context, req mcp.ToolRequest) (mcp.Too
 	}
 
 	data := map[string]any{
-		"region":      regionOrDefault(usedRegion),
+		"region":      awsutil.RegionOrDefault(usedRegion),
 		"cluster":     summarizeCluster(*out.Cluster),
 		"diagnostics": diagnostics,
 	}
@@ -313,24 +328,24 @@ func (s *Service) handleDebug(ctx context.Context, req mcp.ToolRequest) (mcp.Too
 }
 
 func (s *Service) handleGetCluster(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	name := toString(req.Arguments["name"])
+	name := awsutil.ToString(req.Arguments["name"])
 	if name == "" {
-		return errorResult(errors.New("name is required")), errors.New("name is required")
+		return awsutil.ErrorResult(errors.New("name is required")), errors.New("name is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.eksClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: aws.String(name)})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if out.Cluster == nil {
-		return errorResult(fmt.Errorf("cluster %s not found", name)), fmt.Errorf("cluster %s not found", name)
+		return awsutil.ErrorResult(fmt.Errorf("cluster %s not found", name)), fmt.Errorf("cluster %s not found", name)
 	}
 	result := map[string]any{
-		"region":  regionOrDefault(usedRegion),
+		"region":  awsutil.RegionOrDefault(usedRegion),
 		"cluster": summarizeCluster(*out.Cluster),
 	}
 	return mcp.ToolResult{
@@ -342,67 +357,73 @@ func (s *Service) handleGetCluster(ctx context.Context, req mcp.ToolRequest) (mc
 }
 
 func (s *Service) handleListNodegroups(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	cluster := toString(req.Arguments["clusterName"])
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
 	if cluster == "" {
-		return errorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
+		return awsutil.ErrorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
 	}
-	region := toString(req.Arguments["region"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.eksClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &eks.ListNodegroupsInput{ClusterName: aws.String(cluster)}
 	if limit > 0 {
 		input.MaxResults = aws.Int32(int32(limit))
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var groups []string
 	for {
 		out, err := client.ListNodegroups(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		groups = append(groups, out.Nodegroups...)
-		if limit > 0 && len(groups) >= limit {
-			groups = groups[:limit]
-			break
-		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.Nodegroups), out.NextToken)
+		groups = append(groups, out.Nodegroups[start:end]...)
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":     regionOrDefault(usedRegion),
+		"region":     awsutil.RegionOrDefault(usedRegion),
 		"nodegroups": groups,
 		"count":      len(groups),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetNodegroup(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	cluster := toString(req.Arguments["clusterName"])
-	name := toString(req.Arguments["nodegroupName"])
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
+	name := awsutil.ToString(req.Arguments["nodegroupName"])
 	if cluster == "" || name == "" {
-		return errorResult(errors.New("clusterName and nodegroupName are required")), errors.New("clusterName and nodegroupName are required")
+		return awsutil.ErrorResult(errors.New("clusterName and nodegroupName are required")), errors.New("clusterName and nodegroupName are required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.eksClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{
 		ClusterName:   aws.String(cluster),
 		NodegroupName: aws.String(name),
 	})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if out.Nodegroup == nil {
-		return errorResult(fmt.Errorf("nodegroup %s not found", name)), fmt.Errorf("nodegroup %s not found", name)
+		return awsutil.ErrorResult(fmt.Errorf("nodegroup %s not found", name)), fmt.Errorf("nodegroup %s not found", name)
 	}
 	result := map[string]any{
-		"region":    regionOrDefault(usedRegion),
+		"region":    awsutil.RegionOrDefault(usedRegion),
 		"nodegroup": summarizeNodegroup(*out.Nodegroup),
 	}
 	return mcp.ToolResult{
@@ -414,67 +435,73 @@ func (s *Service) handleGetNodegroup(ctx context.Context, req mcp.ToolRequest) (
 }
 
 func (s *Service) handleListAddons(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	cluster := toString(req.Arguments["clusterName"])
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
 	if cluster == "" {
-		return errorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
+		return awsutil.ErrorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
 	}
-	region := toString(req.Arguments["region"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.eksClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &eks.ListAddonsInput{ClusterName: aws.String(cluster)}
 	if limit > 0 {
 		input.MaxResults = aws.Int32(int32(limit))
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var addons []string
 	for {
 		out, err := client.ListAddons(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		addons = append(addons, out.Addons...)
-		if limit > 0 && len(addons) >= limit {
-			addons = addons[:limit]
-			break
-		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.Addons), out.NextToken)
+		addons = append(addons, out.Addons[start:end]...)
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region": regionOrDefault(usedRegion),
+		"region": awsutil.RegionOrDefault(usedRegion),
 		"addons": addons,
 		"count":  len(addons),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetAddon(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	cluster := toString(req.Arguments["clusterName"])
-	name := toString(req.Arguments["addonName"])
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
+	name := awsutil.ToString(req.Arguments["addonName"])
 	if cluster == "" || name == "" {
-		return errorResult(errors.New("clusterName and addonName are required")), errors.New("clusterName and addonName are required")
+		return awsutil.ErrorResult(errors.New("clusterName and addonName are required")), errors.New("clusterName and addonName are required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.eksClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeAddon(ctx, &eks.DescribeAddonInput{
 		ClusterName: aws.String(cluster),
 		AddonName:   aws.String(name),
 	})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if out.Addon == nil {
-		return errorResult(fmt.Errorf("addon %s not found", name)), fmt.Errorf("addon %s not found", name)
+		return awsutil.ErrorResult(fmt.Errorf("addon %s not found", name)), fmt.Errorf("addon %s not found", name)
 	}
 	result := map[string]any{
-		"region": regionOrDefault(usedRegion),
+		"region": awsutil.RegionOrDefault(usedRegion),
 		"addon":  summarizeAddon(*out.Addon),
 	}
 	return mcp.ToolResult{
@@ -486,67 +513,73 @@ func (s *Service) handleGetAddon(ctx context.Context, req mcp.ToolRequest) (mcp.
 }
 
 func (s *Service) handleListFargateProfiles(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	cluster := toString(req.Arguments["clusterName"])
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
 	if cluster == "" {
-		return errorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
+		return awsutil.ErrorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
 	}
-	region := toString(req.Arguments["region"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.eksClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &eks.ListFargateProfilesInput{ClusterName: aws.String(cluster)}
 	if limit > 0 {
 		input.MaxResults = aws.Int32(int32(limit))
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var profiles []string
 	for {
 		out, err := client.ListFargateProfiles(ctx, input)
 		if err != nil {
-			return errorResult(err), err
-		}
-		profiles = append(profiles, out.FargateProfileNames...)
-		if limit > 0 && len(profiles) >= limit {
-			profiles = profiles[:limit]
-			break
+			return awsutil.ErrorResult(err), err
 		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.FargateProfileNames), out.NextToken)
+		profiles = append(profiles, out.FargateProfileNames[start:end]...)
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":          regionOrDefault(usedRegion),
+		"region":          awsutil.RegionOrDefault(usedRegion),
 		"fargateProfiles": profiles,
 		"count":           len(profiles),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetFargateProfile(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	cluster := toString(req.Arguments["clusterName"])
-	name := toString(req.Arguments["profileName"])
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
+	name := awsutil.ToString(req.Arguments["profileName"])
 	if cluster == "" || name == "" {
-		return errorResult(errors.New("clusterName and profileName are required")), errors.New("clusterName and profileName are required")
+		return awsutil.ErrorResult(errors.New("clusterName and profileName are required")), errors.New("clusterName and profileName are required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.eksClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeFargateProfile(ctx, &eks.DescribeFargateProfileInput{
 		ClusterName:        aws.String(cluster),
 		FargateProfileName: aws.String(name),
 	})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if out.FargateProfile == nil {
-		return errorResult(fmt.Errorf("fargate profile %s not found", name)), fmt.Errorf("fargate profile %s not found", name)
+		return awsutil.ErrorResult(fmt.Errorf("fargate profile %s not found", name)), fmt.Errorf("fargate profile %s not found", name)
 	}
 	result := map[string]any{
-		"region":         regionOrDefault(usedRegion),
+		"region":         awsutil.RegionOrDefault(usedRegion),
 		"fargateProfile": summarizeFargateProfile(*out.FargateProfile),
 	}
 	return mcp.ToolResult{
@@ -558,62 +591,66 @@ func (s *Service) handleGetFargateProfile(ctx context.Context, req mcp.ToolReque
 }
 
 func (s *Service) handleListIdentityProviderConfigs(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	cluster := toString(req.Arguments["clusterName"])
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
 	if cluster == "" {
-		return errorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
+		return awsutil.ErrorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
 	}
-	region := toString(req.Arguments["region"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.eksClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &eks.ListIdentityProviderConfigsInput{ClusterName: aws.String(cluster)}
 	if limit > 0 {
 		input.MaxResults = aws.Int32(int32(limit))
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var configs []map[string]any
 	for {
 		out, err := client.ListIdentityProviderConfigs(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, cfg := range out.IdentityProviderConfigs {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.IdentityProviderConfigs), out.NextToken)
+		for _, cfg := range out.IdentityProviderConfigs[start:end] {
 			configs = append(configs, map[string]any{
 				"type": aws.ToString(cfg.Type),
 				"name": aws.ToString(cfg.Name),
 			})
-			if limit > 0 && len(configs) >= limit {
-				break
-			}
-		}
-		if limit > 0 && len(configs) >= limit {
-			break
 		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":                  regionOrDefault(usedRegion),
+		"region":                  awsutil.RegionOrDefault(usedRegion),
 		"identityProviderConfigs": configs,
 		"count":                   len(configs),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetIdentityProviderConfig(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	cluster := toString(req.Arguments["clusterName"])
-	idpType := toString(req.Arguments["type"])
-	name := toString(req.Arguments["name"])
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
+	idpType := awsutil.ToString(req.Arguments["type"])
+	name := awsutil.ToString(req.Arguments["name"])
 	if cluster == "" || idpType == "" || name == "" {
-		return errorResult(errors.New("clusterName, type, and name are required")), errors.New("clusterName, type, and name are required")
+		return awsutil.ErrorResult(errors.New("clusterName, type, and name are required")), errors.New("clusterName, type, and name are required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.eksClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeIdentityProviderConfig(ctx, &eks.DescribeIdentityProviderConfigInput{
 		ClusterName: aws.String(cluster),
@@ -623,13 +660,13 @@ func (s *Service) handleGetIdentityProviderConfig(ctx context.Context, req mcp.T
 		},
 	})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if out.IdentityProviderConfig == nil {
-		return errorResult(fmt.Errorf("identity provider config %s not found", name)), fmt.Errorf("identity provider config %s not found", name)
+		return awsutil.ErrorResult(fmt.Errorf("identity provider config %s not found", name)), fmt.Errorf("identity provider config %s not found", name)
 	}
 	result := map[string]any{
-		"region":                 regionOrDefault(usedRegion),
+		"region":                 awsutil.RegionOrDefault(usedRegion),
 		"identityProviderConfig": summarizeIdentityProviderConfig(*out.IdentityProviderConfig),
 	}
 	return mcp.ToolResult{
@@ -641,16 +678,16 @@ func (s *Service) handleGetIdentityProviderConfig(ctx context.Context, req mcp.T
 }
 
 func (s *Service) handleListUpdates(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	cluster := toString(req.Arguments["clusterName"])
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
 	if cluster == "" {
-		return errorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
+		return awsutil.ErrorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
 	}
-	nodegroup := toString(req.Arguments["nodegroupName"])
-	region := toString(req.Arguments["region"])
-	limit := toInt(req.Arguments["limit"], 100)
+	nodegroup := awsutil.ToString(req.Arguments["nodegroupName"])
+	region := awsutil.ToString(req.Arguments["region"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.eksClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &eks.ListUpdatesInput{Name: aws.String(cluster)}
 	if nodegroup != "" {
@@ -659,41 +696,47 @@ func (s *Service) handleListUpdates(ctx context.Context, req mcp.ToolRequest) (m
 	if limit > 0 {
 		input.MaxResults = aws.Int32(int32(limit))
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var updates []string
 	for {
 		out, err := client.ListUpdates(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		updates = append(updates, out.UpdateIds...)
-		if limit > 0 && len(updates) >= limit {
-			updates = updates[:limit]
-			break
-		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.UpdateIds), out.NextToken)
+		updates = append(updates, out.UpdateIds[start:end]...)
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":  regionOrDefault(usedRegion),
+		"region":  awsutil.RegionOrDefault(usedRegion),
 		"updates": updates,
 		"count":   len(updates),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetUpdate(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	cluster := toString(req.Arguments["clusterName"])
-	updateID := toString(req.Arguments["updateId"])
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
+	updateID := awsutil.ToString(req.Arguments["updateId"])
 	if cluster == "" || updateID == "" {
-		return errorResult(errors.New("clusterName and updateId are required")), errors.New("clusterName and updateId are required")
+		return awsutil.ErrorResult(errors.New("clusterName and updateId are required")), errors.New("clusterName and updateId are required")
 	}
-	nodegroup := toString(req.Arguments["nodegroupName"])
-	region := toString(req.Arguments["region"])
+	nodegroup := awsutil.ToString(req.Arguments["nodegroupName"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.eksClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &eks.DescribeUpdateInput{Name: aws.String(cluster), UpdateId: aws.String(updateID)}
 	if nodegroup != "" {
@@ -701,13 +744,13 @@ func (s *Service) handleGetUpdate(ctx context.Context, req mcp.ToolRequest) (mcp
 	}
 	out, err := client.DescribeUpdate(ctx, input)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if out.Update == nil {
-		return errorResult(fmt.Errorf("update %s not found", updateID)), fmt.Errorf("update %s not found", updateID)
+		return awsutil.ErrorResult(fmt.Errorf("update %s not found", updateID)), fmt.Errorf("update %s not found", updateID)
 	}
 	result := map[string]any{
-		"region": regionOrDefault(usedRegion),
+		"region": awsutil.RegionOrDefault(usedRegion),
 		"update": summarizeUpdate(*out.Update),
 	}
 	return mcp.ToolResult{
@@ -719,24 +762,24 @@ func (s *Service) handleGetUpdate(ctx context.Context, req mcp.ToolRequest) (mcp
 }
 
 func (s *Service) handleListNodes(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	cluster := toString(req.Arguments["clusterName"])
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
 	if cluster == "" {
-		return errorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
+		return awsutil.ErrorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
 	}
-	nodegroup := toString(req.Arguments["nodegroupName"])
-	region := toString(req.Arguments["region"])
-	limit := toInt(req.Arguments["limit"], 100)
+	nodegroup := awsutil.ToString(req.Arguments["nodegroupName"])
+	region := awsutil.ToString(req.Arguments["region"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	eksClient, usedRegion, err := s.eksClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	asgClient, _, err := s.asgClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	ec2Client, _, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	nodegroups := []string{}
 	if nodegroup != "" {
@@ -744,11 +787,38 @@ func (s *Service) handleListNodes(ctx context.Context, req mcp.ToolRequest) (mcp
 	} else {
 		listOut, err := eksClient.ListNodegroups(ctx, &eks.ListNodegroupsInput{ClusterName: aws.String(cluster)})
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
 		nodegroups = append(nodegroups, listOut.Nodegroups...)
 	}
+	found, warnings := nodegroupInstances(ctx, eksClient, asgClient, ec2Client, cluster, nodegroups, limit)
 	var instances []map[string]any
+	for _, item := range found {
+		instances = append(instances, summarizeInstance(item.instance, item.nodegroup))
+	}
+	data := map[string]any{
+		"region":    awsutil.RegionOrDefault(usedRegion),
+		"cluster":   cluster,
+		"nodegroup": nodegroup,
+		"instances": instances,
+		"count":     len(instances),
+	}
+	if len(warnings) > 0 {
+		data["warnings"] = warnings
+	}
+	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+}
+
+type nodegroupInstance struct {
+	nodegroup string
+	instance  ec2types.Instance
+}
+
+// nodegroupInstances resolves nodegroups to their Auto Scaling groups and
+// describes the EC2 instances behind them. Per-nodegroup failures are
+// returned as warnings so one broken nodegroup does not hide the rest.
+func nodegroupInstances(ctx context.Context, eksClient *eks.Client, asgClient *autoscaling.Client, ec2Client *ec2.Client, cluster string, nodegroups []string, limit int) ([]nodegroupInstance, []string) {
+	var instances []nodegroupInstance
 	var warnings []string
 	for _, ng := range nodegroups {
 		descOut, err := eksClient.DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{
@@ -796,30 +866,14 @@ func (s *Service) handleListNodes(ctx context.Context, req mcp.ToolRequest) (mcp
 		}
 		for _, res := range ec2Out.Reservations {
 			for _, inst := range res.Instances {
-				instances = append(instances, summarizeInstance(inst, ng))
+				instances = append(instances, nodegroupInstance{nodegroup: ng, instance: inst})
 				if limit > 0 && len(instances) >= limit {
-					break
+					return instances, warnings
 				}
 			}
-			if limit > 0 && len(instances) >= limit {
-				break
-			}
-		}
-		if limit > 0 && len(instances) >= limit {
-			break
 		}
 	}
-	data := map[string]any{
-		"region":    regionOrDefault(usedRegion),
-		"cluster":   cluster,
-		"nodegroup": nodegroup,
-		"instances": instances,
-		"count":     len(instances),
-	}
-	if len(warnings) > 0 {
-		data["warnings"] = warnings
-	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	return instances, warnings
 }
 
 func summarizeCluster(cluster ekstypes.Cluster) map[string]any {
@@ -827,7 +881,7 @@ func summarizeCluster(cluster ekstypes.Cluster) map[string]any {
 	if cluster.Logging != nil {
 		logging["clusterLogging"] = cluster.Logging.ClusterLogging
 	}
-	return map[string]any{
+	return awsutil.NormalizeTimes(map[string]any{
 		"name":                    aws.ToString(cluster.Name),
 		"arn":                     aws.ToString(cluster.Arn),
 		"version":                 aws.ToString(cluster.Version),
@@ -841,7 +895,7 @@ func summarizeCluster(cluster ekstypes.Cluster) map[string]any {
 		"identity":                cluster.Identity,
 		"logging":                 logging,
 		"tags":                    cluster.Tags,
-	}
+	})
 }
 
 func summarizeNodegroup(group ekstypes.Nodegroup) map[string]any {
@@ -885,7 +939,7 @@ func summarizeAddon(addon ekstypes.Addon) map[string]any {
 }
 
 func summarizeFargateProfile(profile ekstypes.FargateProfile) map[string]any {
-	return map[string]any{
+	return awsutil.NormalizeTimes(map[string]any{
 		"name":             aws.ToString(profile.FargateProfileName),
 		"arn":              aws.ToString(profile.FargateProfileArn),
 		"status":           profile.Status,
@@ -894,7 +948,7 @@ func summarizeFargateProfile(profile ekstypes.FargateProfile) map[string]any {
 		"podExecutionRole": aws.ToString(profile.PodExecutionRoleArn),
 		"createdAt":        profile.CreatedAt,
 		"tags":             profile.Tags,
-	}
+	})
 }
 
 func summarizeIdentityProviderConfig(cfg ekstypes.IdentityProviderConfigResponse) map[string]any {
@@ -908,14 +962,14 @@ func summarizeIdentityProviderConfig(cfg ekstypes.IdentityProviderConfigResponse
 }
 
 func summarizeUpdate(update ekstypes.Update) map[string]any {
-	return map[string]any{
+	return awsutil.NormalizeTimes(map[string]any{
 		"id":        aws.ToString(update.Id),
 		"type":      update.Type,
 		"status":    update.Status,
 		"params":    update.Params,
 		"errors":    update.Errors,
 		"createdAt": update.CreatedAt,
-	}
+	})
 }
 
 func summarizeInstance(inst ec2types.Instance, nodegroup string) map[string]any {
@@ -923,7 +977,7 @@ func summarizeInstance(inst ec2types.Instance, nodegroup string) map[string]any
 	for _, sg := range inst.SecurityGroups {
 		sgIDs = append(sgIDs, aws.ToString(sg.GroupId))
 	}
-	return map[string]any{
+	return awsutil.WithAge(awsutil.NormalizeTimes(map[string]any{
 		"id":               aws.ToString(inst.InstanceId),
 		"state":            inst.State,
 		"type":             inst.InstanceType,
@@ -935,26 +989,8 @@ func summarizeInstance(inst ec2types.Instance, nodegroup string) map[string]any
 		"nodegroup":        nodegroup,
 		"securityGroupIds": sgIDs,
 		"launchTime":       inst.LaunchTime,
-		"tags":             tagMap(inst.Tags),
-	}
-}
-
-func summarizeKMSKey(meta *kmstypes.KeyMetadata) map[string]any {
-	if meta == nil {
-		return nil
-	}
-	return map[string]any{
-		"keyId":        aws.ToString(meta.KeyId),
-		"arn":          aws.ToString(meta.Arn),
-		"accountId":    aws.ToString(meta.AWSAccountId),
-		"description":  aws.ToString(meta.Description),
-		"keyState":     string(meta.KeyState),
-		"keyUsage":     string(meta.KeyUsage),
-		"origin":       string(meta.Origin),
-		"multiRegion":  meta.MultiRegion,
-		"creationDate": aws.ToTime(meta.CreationDate),
-		"enabled":      meta.Enabled,
-	}
+		"tags":             awsutil.TagMap(inst.Tags),
+	}), inst.LaunchTime)
 }
 
 func summarizeECRRepository(repo ecrtypes.Repository) map[string]any {
@@ -971,7 +1007,7 @@ func summarizeECRRepository(repo ecrtypes.Repository) map[string]any {
 	if repo.ImageScanningConfiguration != nil {
 		out["scanOnPush"] = repo.ImageScanningConfiguration.ScanOnPush
 	}
-	return out
+	return awsutil.NormalizeTimes(out)
 }
 
 func summarizeECRImageID(id ecrtypes.ImageIdentifier) map[string]any {
@@ -982,12 +1018,12 @@ func summarizeECRImageID(id ecrtypes.ImageIdentifier) map[string]any {
 }
 
 func summarizeECRImageDetail(detail ecrtypes.ImageDetail) map[string]any {
-	return map[string]any{
+	return awsutil.NormalizeTimes(map[string]any{
 		"imageDigest":      aws.ToString(detail.ImageDigest),
 		"imageTags":        detail.ImageTags,
 		"imagePushedAt":    aws.ToTime(detail.ImagePushedAt),
 		"imageSizeInBytes": detail.ImageSizeInBytes,
-	}
+	})
 }
 
 func toECRImageIdentifiers(tags []string, digests []string) []ecrtypes.ImageIdentifier {
@@ -1007,77 +1043,6 @@ func toECRImageIdentifiers(tags []string, digests []string) []ecrtypes.ImageIden
 	return ids
 }
 
-func tagMap(tags []ec2types.Tag) map[string]string {
-	out := map[string]string{}
-	for _, tag := range tags {
-		key := aws.ToString(tag.Key)
-		if key == "" {
-			continue
-		}
-		out[key] = aws.ToString(tag.Value)
-	}
-	return out
-}
-
-func errorResult(err error) mcp.ToolResult {
-	return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}
-}
-
-func toString(value any) string {
-	if value == nil {
-		return ""
-	}
-	if s, ok := value.(string); ok {
-		return s
-	}
-	return fmt.Sprintf("%v", value)
-}
-
-func toStringSlice(value any) []string {
-	switch v := value.(type) {
-	case []string:
-		return v
-	case []any:
-		var out []string
-		for _, item := range v {
-			if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
-				out = append(out, s)
-			}
-		}
-		return out
-	case string:
-		if strings.TrimSpace(v) == "" {
-			return nil
-		}
-		return []string{v}
-	default:
-		return nil
-	}
-}
-
-func toInt(value any, fallback int) int {
-	switch v := value.(type) {
-	case int:
-		return v
-	case int64:
-		return int(v)
-	case float64:
-		return int(v)
-	case json.Number:
-		if parsed, err := v.Int64(); err == nil {
-			return int(parsed)
-		}
-	}
-	return fallback
-}
-
-func regionOrDefault(region string) string {
-	if strings.TrimSpace(region) == "" {
-		return "us-east-1"
-	}
-	return region
-}
-
 func roleNameFromARN(arn string) string {
 	if arn == "" {
 		return ""
@@ -0,0 +1,97 @@
+package awseks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+func (s *Service) handleGetEndpointAccess(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	name := awsutil.ToString(req.Arguments["clusterName"])
+	if name == "" {
+		return awsutil.ErrorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.eksClient(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	out, err := client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: aws.String(name)})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	if out.Cluster == nil {
+		return awsutil.ErrorResult(fmt.Errorf("cluster %s not found", name)), fmt.Errorf("cluster %s not found", name)
+	}
+	result := map[string]any{
+		"region":      awsutil.RegionOrDefault(usedRegion),
+		"clusterName": name,
+		"endpoint":    aws.ToString(out.Cluster.Endpoint),
+	}
+	for key, value := range endpointAccess(out.Cluster.ResourcesVpcConfig) {
+		result[key] = value
+	}
+	return mcp.ToolResult{
+		Data: s.ctx.Redactor.RedactValue(result),
+		Metadata: mcp.ToolMetadata{
+			Resources: []string{fmt.Sprintf("eks/cluster/%s", name)},
+		},
+	}, nil
+}
+
+// endpointAccess grades the API server exposure: a public endpoint open to
+// any address is high risk, a CIDR-restricted public endpoint medium, and a
+// private-only endpoint low.
+func endpointAccess(vpcConfig *ekstypes.VpcConfigResponse) map[string]any {
+	if vpcConfig == nil {
+		return map[string]any{"risk": "unknown", "findings": []string{"cluster has no resourcesVpcConfig"}}
+	}
+	public := vpcConfig.EndpointPublicAccess
+	private := vpcConfig.EndpointPrivateAccess
+	cidrs := vpcConfig.PublicAccessCidrs
+	var openCIDRs []string
+	for _, cidr := range cidrs {
+		switch strings.TrimSpace(cidr) {
+		case "0.0.0.0/0", "::/0":
+			openCIDRs = append(openCIDRs, cidr)
+		}
+	}
+	openToInternet := public && len(openCIDRs) > 0
+	var findings []string
+	risk := "low"
+	switch {
+	case openToInternet:
+		risk = "high"
+		findings = append(findings, fmt.Sprintf("public endpoint accepts connections from any address (%s); anyone can reach the API server and only authentication protects it", strings.Join(openCIDRs, ", ")))
+	case public:
+		risk = "medium"
+		findings = append(findings, fmt.Sprintf("public endpoint restricted to %d CIDR(s)", len(cidrs)))
+	default:
+		findings = append(findings, "public endpoint disabled; the API server is reachable only from the VPC and connected networks")
+	}
+	if public && !private {
+		findings = append(findings, "private endpoint disabled; nodes reach the API server over the internet, so they need a NAT or internet gateway and must be inside publicAccessCidrs")
+	}
+	result := map[string]any{
+		"endpointPublicAccess":   public,
+		"endpointPrivateAccess":  private,
+		"publicAccessCidrs":      cidrs,
+		"openToInternet":         openToInternet,
+		"risk":                   risk,
+		"findings":               findings,
+		"vpcId":                  aws.ToString(vpcConfig.VpcId),
+		"clusterSecurityGroupId": aws.ToString(vpcConfig.ClusterSecurityGroupId),
+	}
+	if openToInternet {
+		result["recommendation"] = "Restrict publicAccessCidrs to known egress ranges, or disable the public endpoint and enable private access."
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+package awseks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestEndpointAccessRisk(t *testing.T) {
+	cases := []struct {
+		name   string
+		config *ekstypes.VpcConfigResponse
+		risk   string
+		open   bool
+	}{
+		{"open", &ekstypes.VpcConfigResponse{EndpointPublicAccess: true, PublicAccessCidrs: []string{"0.0.0.0/0"}}, "high", true},
+		{"restricted", &ekstypes.VpcConfigResponse{EndpointPublicAccess: true, EndpointPrivateAccess: true, PublicAccessCidrs: []string{"203.0.113.0/24"}}, "medium", false},
+		{"private", &ekstypes.VpcConfigResponse{EndpointPrivateAccess: true, PublicAccessCidrs: []string{"0.0.0.0/0"}}, "low", false},
+		{"missing", nil, "unknown", false},
+	}
+	for _, tc := range cases {
+		got := endpointAccess(tc.config)
+		if got["risk"] != tc.risk {
+			t.Fatalf("%s: expected risk %s, got %#v", tc.name, tc.risk, got)
+		}
+		if open, _ := got["openToInternet"].(bool); open != tc.open {
+			t.Fatalf("%s: unexpected openToInternet: %#v", tc.name, got)
+		}
+	}
+	publicOnly := endpointAccess(&ekstypes.VpcConfigResponse{EndpointPublicAccess: true, PublicAccessCidrs: []string{"0.0.0.0/0"}, VpcId: aws.String("vpc-1")})
+	if findings := publicOnly["findings"].([]string); len(findings) != 2 {
+		t.Fatalf("expected private-access finding: %#v", findings)
+	}
+}
+
+func TestHandleGetEndpointAccess(t *testing.T) {
+	client := newEKSTestClient(t, map[string]string{
+		"/clusters/demo": `{"cluster":{"name":"demo","endpoint":"https://demo.eks.test","resourcesVpcConfig":{"vpcId":"vpc-1","endpointPublicAccess":true,"endpointPrivateAccess":false,"publicAccessCidrs":["0.0.0.0/0"]}}}`,
+	})
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		eksClient: func(context.Context, string) (*eks.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleGetEndpointAccess(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"clusterName": "demo"}})
+	if err != nil {
+		t.Fatalf("get endpoint access: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if data["risk"] != "high" || data["openToInternet"] != true || data["recommendation"] == nil {
+		t.Fatalf("unexpected result: %#v", data)
+	}
+	if _, err := svc.handleGetEndpointAccess(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected missing clusterName error")
+	}
+}
@@ -46,12 +46,3 @@ func TestEKSGetNotFoundBranches(t *testing.T) {
 		t.Fatalf("expected update not found")
 	}
 }
-
-func TestEKSTypeHelpers(t *testing.T) {
-	if got := toStringSlice([]any{"a", 1}); len(got) != 1 || got[0] != "a" {
-		t.Fatalf("unexpected toStringSlice: %#v", got)
-	}
-	if got := toInt(float64(5), 1); got != 5 {
-		t.Fatalf("unexpected toInt: %d", got)
-	}
-}
@@ -97,7 +97,11 @@ type eksRoundTripper struct {
 }
 
 func (rt *eksRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	resp, ok := rt.responses[req.URL.Path]
+	// A "path?query" key wins over the bare path, so pages can differ.
+	resp, ok := rt.responses[req.URL.Path+"?"+req.URL.RawQuery]
+	if !ok {
+		resp, ok = rt.responses[req.URL.Path]
+	}
 	if !ok {
 		return &http.Response{
 			StatusCode: http.StatusBadRequest,
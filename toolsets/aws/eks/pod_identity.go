@@ -0,0 +1,132 @@
+package awseks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+func (s *Service) handleListPodIdentityAssociations(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
+	if cluster == "" {
+		return awsutil.ErrorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
+	}
+	namespace := awsutil.ToString(req.Arguments["namespace"])
+	serviceAccount := awsutil.ToString(req.Arguments["serviceAccount"])
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.eksClient(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input := &eks.ListPodIdentityAssociationsInput{ClusterName: aws.String(cluster)}
+	if namespace != "" {
+		input.Namespace = aws.String(namespace)
+	}
+	if serviceAccount != "" {
+		input.ServiceAccount = aws.String(serviceAccount)
+	}
+	var summaries []ekstypes.PodIdentityAssociationSummary
+	for {
+		out, err := client.ListPodIdentityAssociations(ctx, input)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		summaries = append(summaries, out.Associations...)
+		if aws.ToString(out.NextToken) == "" {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+
+	var warnings []string
+	checkServiceAccounts := s.ctx.Clients != nil && s.ctx.Clients.Typed != nil
+	if !checkServiceAccounts && len(summaries) > 0 {
+		warnings = append(warnings, "kubernetes client not configured; service accounts were not checked")
+	}
+	associations := make([]map[string]any, 0, len(summaries))
+	missing := 0
+	for _, summary := range summaries {
+		entry := map[string]any{
+			"associationId":  aws.ToString(summary.AssociationId),
+			"associationArn": aws.ToString(summary.AssociationArn),
+			"namespace":      aws.ToString(summary.Namespace),
+			"serviceAccount": aws.ToString(summary.ServiceAccount),
+		}
+		if owner := aws.ToString(summary.OwnerArn); owner != "" {
+			entry["ownerArn"] = owner
+		}
+		described, err := client.DescribePodIdentityAssociation(ctx, &eks.DescribePodIdentityAssociationInput{
+			ClusterName:   aws.String(cluster),
+			AssociationId: summary.AssociationId,
+		})
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("describe association %s failed: %v", aws.ToString(summary.AssociationId), err))
+		} else if described.Association != nil {
+			entry["roleArn"] = aws.ToString(described.Association.RoleArn)
+			if target := aws.ToString(described.Association.TargetRoleArn); target != "" {
+				entry["targetRoleArn"] = target
+			}
+		}
+		if checkServiceAccounts {
+			exists, warning := s.podIdentityServiceAccountExists(ctx, req, aws.ToString(summary.Namespace), aws.ToString(summary.ServiceAccount))
+			if exists != nil {
+				entry["serviceAccountExists"] = *exists
+				if !*exists {
+					missing++
+				}
+			}
+			if warning != "" {
+				warnings = append(warnings, warning)
+			}
+		}
+		associations = append(associations, entry)
+	}
+
+	result := map[string]any{
+		"region":       awsutil.RegionOrDefault(usedRegion),
+		"clusterName":  cluster,
+		"associations": associations,
+		"count":        len(associations),
+	}
+	if checkServiceAccounts {
+		result["missingServiceAccounts"] = missing
+	}
+	if len(warnings) > 0 {
+		result["warnings"] = warnings
+	}
+	return mcp.ToolResult{
+		Data: s.ctx.Redactor.RedactValue(result),
+		Metadata: mcp.ToolMetadata{
+			Resources: []string{fmt.Sprintf("eks/cluster/%s", cluster)},
+		},
+	}, nil
+}
+
+// podIdentityServiceAccountExists looks the association's service account up
+// in the cluster. A nil result means the check could not be made; the
+// warning says why, or flags a missing account, which leaves pods running
+// without the association's credentials.
+func (s *Service) podIdentityServiceAccountExists(ctx context.Context, req mcp.ToolRequest, namespace, name string) (*bool, string) {
+	if s.ctx.Policy != nil {
+		if err := s.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+			return nil, fmt.Sprintf("service account %s/%s not checked: %v", namespace, name, err)
+		}
+	}
+	_, err := s.ctx.Clients.Typed.CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		return aws.Bool(true), ""
+	case apierrors.IsNotFound(err):
+		return aws.Bool(false), fmt.Sprintf("service account %s/%s does not exist; pods cannot use this association until it is created", namespace, name)
+	}
+	return nil, fmt.Sprintf("service account %s/%s lookup failed: %v", namespace, name, err)
+}
@@ -0,0 +1,58 @@
+package awseks
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/redact"
+)
+
+func TestHandleListPodIdentityAssociations(t *testing.T) {
+	client := newEKSTestClient(t, map[string]string{
+		"/clusters/demo/pod-identity-associations":     `{"associations":[{"associationId":"a-1","namespace":"payments","serviceAccount":"api"},{"associationId":"a-2","namespace":"payments","serviceAccount":"worker"}]}`,
+		"/clusters/demo/pod-identity-associations/a-1": `{"association":{"associationId":"a-1","namespace":"payments","serviceAccount":"api","roleArn":"arn:aws:iam::123456789012:role/api"}}`,
+		"/clusters/demo/pod-identity-associations/a-2": `{"association":{"associationId":"a-2","namespace":"payments","serviceAccount":"worker","roleArn":"arn:aws:iam::123456789012:role/worker"}}`,
+	})
+	typed := fake.NewSimpleClientset(&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "payments"}})
+	svc := &Service{
+		ctx: mcp.ToolContext{
+			Redactor: redact.New(),
+			Clients:  &kube.Clients{Typed: typed},
+			Policy:   policy.NewAuthorizer(),
+		},
+		eksClient: func(context.Context, string) (*eks.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleListPodIdentityAssociations(context.Background(), mcp.ToolRequest{
+		Arguments: map[string]any{"clusterName": "demo"},
+		User:      policy.User{Role: policy.RoleCluster},
+	})
+	if err != nil {
+		t.Fatalf("list pod identity associations: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	associations := data["associations"].([]map[string]any)
+	if len(associations) != 2 || associations[0]["serviceAccountExists"] != true || associations[1]["serviceAccountExists"] != false {
+		t.Fatalf("unexpected associations: %#v", associations)
+	}
+	if !strings.HasSuffix(associations[0]["roleArn"].(string), "role/api") {
+		t.Fatalf("expected role arn, got %#v", associations[0])
+	}
+	warnings := data["warnings"].([]string)
+	if data["missingServiceAccounts"] != 1 || len(warnings) != 1 || !strings.Contains(warnings[0], "payments/worker does not exist") {
+		t.Fatalf("unexpected result: %#v", data)
+	}
+	if _, err := svc.handleListPodIdentityAssociations(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected missing cluster error")
+	}
+}
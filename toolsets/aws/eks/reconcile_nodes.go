@@ -0,0 +1,350 @@
+package awseks
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+// Discrepancy types reported by aws.eks.reconcile_nodes.
+const (
+	nodeMatched            = "ok"
+	nodeNotRegistered      = "running-not-registered"
+	nodeNotReady           = "running-not-ready"
+	nodeLaunching          = "launching"
+	nodeInstanceNotRunning = "instance-not-running"
+	nodeInstanceMissing    = "instance-missing"
+	nodeNotAWS             = "no-aws-provider-id"
+	nodeFargate            = "fargate"
+)
+
+// Labels EKS sets on Fargate nodes and on the pods scheduled onto them.
+const (
+	fargateComputeTypeLabel = "eks.amazonaws.com/compute-type"
+	fargateProfileLabel     = "eks.amazonaws.com/fargate-profile"
+)
+
+func (s *Service) handleReconcileNodes(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	cluster := awsutil.ToString(req.Arguments["clusterName"])
+	if cluster == "" {
+		return awsutil.ErrorResult(errors.New("clusterName is required")), errors.New("clusterName is required")
+	}
+	if s.ctx.Clients == nil || s.ctx.Clients.Typed == nil {
+		err := errors.New("kubernetes client not configured")
+		return awsutil.ErrorResult(err), err
+	}
+	if err := s.ctx.Policy.CheckNamespace(req.User, "", false); err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	eksClient, usedRegion, err := s.eksClient(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	asgClient, _, err := s.asgClient(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	ec2Client, _, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	unverified, err := s.verifyKubeCluster(ctx, eksClient, cluster)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	nodegroups, err := listAllNodegroups(ctx, eksClient, cluster)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	instances, warnings := nodegroupInstances(ctx, eksClient, asgClient, ec2Client, cluster, nodegroups, 0)
+	if unverified != "" {
+		warnings = append([]string{unverified}, warnings...)
+	}
+	nodes, err := s.ctx.Clients.Typed.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+
+	// Nodes outside managed nodegroups (Karpenter, self-managed) are looked
+	// up directly so a terminated instance still shows up as missing.
+	known := map[string]struct{}{}
+	for _, item := range instances {
+		known[aws.ToString(item.instance.InstanceId)] = struct{}{}
+	}
+	var extraIDs []string
+	for _, node := range nodes.Items {
+		if id := instanceIDFromProviderID(node.Spec.ProviderID); id != "" {
+			if _, ok := known[id]; !ok {
+				extraIDs = append(extraIDs, id)
+			}
+		}
+	}
+	if len(extraIDs) > 0 {
+		extra, err := describeInstancesByID(ctx, ec2Client, extraIDs)
+		if err != nil {
+			warnings = append(warnings, err.Error())
+		}
+		for _, inst := range extra {
+			instances = append(instances, nodegroupInstance{instance: inst})
+		}
+	}
+
+	rows := reconcileNodes(instances, nodes.Items, s.fargateProfilesByNode(ctx, nodes.Items))
+	counts := map[string]int{}
+	for _, row := range rows {
+		counts[awsutil.ToString(row["discrepancy"])]++
+	}
+	data := map[string]any{
+		"region":        awsutil.RegionOrDefault(usedRegion),
+		"cluster":       cluster,
+		"nodes":         rows,
+		"count":         len(rows),
+		"discrepancies": counts,
+	}
+	if len(warnings) > 0 {
+		data["warnings"] = warnings
+	}
+	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+}
+
+// verifyKubeCluster checks that the kube context the Node list comes from is
+// the EKS cluster whose instances are listed, by API endpoint or CA bundle.
+// Joining two different clusters would report every instance and node as a
+// discrepancy, so a mismatch is an error. When neither can be compared the
+// returned warning says the report is unverified.
+func (s *Service) verifyKubeCluster(ctx context.Context, client *eks.Client, cluster string) (string, error) {
+	restConfig := s.ctx.Clients.RestConfig
+	if restConfig == nil || restConfig.Host == "" {
+		return fmt.Sprintf("could not confirm the kube context is EKS cluster %s: no client config; discrepancies may come from another cluster", cluster), nil
+	}
+	out, err := client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: aws.String(cluster)})
+	if err != nil || out.Cluster == nil {
+		return fmt.Sprintf("could not confirm the kube context is EKS cluster %s: describe cluster failed: %v; discrepancies may come from another cluster", cluster, err), nil
+	}
+	endpoint := aws.ToString(out.Cluster.Endpoint)
+	compared := false
+	if endpoint != "" {
+		compared = true
+		if clusterHost(endpoint) == clusterHost(restConfig.Host) {
+			return "", nil
+		}
+	}
+	if out.Cluster.CertificateAuthority != nil && len(restConfig.CAData) > 0 {
+		if ca, err := base64.StdEncoding.DecodeString(aws.ToString(out.Cluster.CertificateAuthority.Data)); err == nil && len(ca) > 0 {
+			compared = true
+			if bytes.Equal(bytes.TrimSpace(ca), bytes.TrimSpace(restConfig.CAData)) {
+				return "", nil
+			}
+		}
+	}
+	if !compared {
+		return fmt.Sprintf("could not confirm the kube context is EKS cluster %s: no endpoint or CA to compare; discrepancies may come from another cluster", cluster), nil
+	}
+	return "", fmt.Errorf("kube context points at %s, not EKS cluster %s (%s); switch to that cluster's context before reconciling", restConfig.Host, cluster, endpoint)
+}
+
+// clusterHost normalizes an API server URL to its lower-cased host and port.
+func clusterHost(raw string) string {
+	host := strings.ToLower(strings.TrimSpace(raw))
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	host = strings.TrimSuffix(host, "/")
+	return strings.TrimSuffix(host, ":443")
+}
+
+// fargateProfilesByNode resolves the Fargate profile behind each Fargate
+// node from the profile label EKS puts on the pod running there. Lookup
+// failures only cost the profile name, so they are not reported.
+func (s *Service) fargateProfilesByNode(ctx context.Context, nodes []corev1.Node) map[string]string {
+	profiles := map[string]string{}
+	hasFargate := false
+	for i := range nodes {
+		if isFargateNode(&nodes[i]) {
+			hasFargate = true
+			break
+		}
+	}
+	if !hasFargate {
+		return profiles
+	}
+	pods, err := s.ctx.Clients.Typed.CoreV1().Pods("").List(ctx, metav1.ListOptions{LabelSelector: fargateProfileLabel})
+	if err != nil {
+		return profiles
+	}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != "" {
+			profiles[pod.Spec.NodeName] = pod.Labels[fargateProfileLabel]
+		}
+	}
+	return profiles
+}
+
+// describeInstancesByID filters on instance-id rather than passing
+// InstanceIds, which fails the whole call when any instance no longer exists.
+func describeInstancesByID(ctx context.Context, client *ec2.Client, ids []string) ([]ec2types.Instance, error) {
+	input := &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{{Name: aws.String("instance-id"), Values: ids}},
+	}
+	var instances []ec2types.Instance
+	for {
+		out, err := client.DescribeInstances(ctx, input)
+		if err != nil {
+			return instances, err
+		}
+		for _, res := range out.Reservations {
+			instances = append(instances, res.Instances...)
+		}
+		if aws.ToString(out.NextToken) == "" {
+			return instances, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+// reconcileNodes joins EC2 instances with Kubernetes Nodes by the instance id
+// in spec.providerID and classifies each pair. Fargate nodes have no EC2
+// instance and are reported on their own, with the profile from
+// fargateProfiles when known. Problems sort first.
+func reconcileNodes(instances []nodegroupInstance, nodes []corev1.Node, fargateProfiles map[string]string) []map[string]any {
+	byID := map[string]*corev1.Node{}
+	var rows []map[string]any
+	for i := range nodes {
+		node := &nodes[i]
+		if isFargateNode(node) {
+			row := map[string]any{
+				"nodeName":    node.Name,
+				"providerId":  node.Spec.ProviderID,
+				"nodeReady":   nodeReady(node),
+				"discrepancy": nodeFargate,
+			}
+			profile := node.Labels[fargateProfileLabel]
+			if profile == "" {
+				profile = fargateProfiles[node.Name]
+			}
+			if profile != "" {
+				row["fargateProfile"] = profile
+			}
+			rows = append(rows, row)
+			continue
+		}
+		id := instanceIDFromProviderID(node.Spec.ProviderID)
+		if id == "" {
+			rows = append(rows, map[string]any{
+				"nodeName":    node.Name,
+				"providerId":  node.Spec.ProviderID,
+				"nodeReady":   nodeReady(node),
+				"discrepancy": nodeNotAWS,
+			})
+			continue
+		}
+		byID[id] = node
+	}
+	seen := map[string]struct{}{}
+	for _, item := range instances {
+		id := aws.ToString(item.instance.InstanceId)
+		if _, dup := seen[id]; dup || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+		state := ""
+		if item.instance.State != nil {
+			state = string(item.instance.State.Name)
+		}
+		row := map[string]any{
+			"instanceId": id,
+			"nodegroup":  item.nodegroup,
+			"ec2State":   state,
+		}
+		node, registered := byID[id]
+		if registered {
+			row["nodeName"] = node.Name
+			row["nodeReady"] = nodeReady(node)
+		}
+		switch {
+		case !registered && state == string(ec2types.InstanceStateNamePending):
+			row["discrepancy"] = nodeLaunching
+		case !registered && state == string(ec2types.InstanceStateNameRunning):
+			row["discrepancy"] = nodeNotRegistered
+		case !registered:
+			// Stopped or terminated instances without a Node are not a mismatch.
+			continue
+		case state != string(ec2types.InstanceStateNameRunning):
+			row["discrepancy"] = nodeInstanceNotRunning
+		case !nodeReady(node):
+			row["discrepancy"] = nodeNotReady
+		default:
+			row["discrepancy"] = nodeMatched
+		}
+		rows = append(rows, row)
+	}
+	for id, node := range byID {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		rows = append(rows, map[string]any{
+			"instanceId":  id,
+			"nodeName":    node.Name,
+			"nodeReady":   nodeReady(node),
+			"discrepancy": nodeInstanceMissing,
+		})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := awsutil.ToString(rows[i]["discrepancy"]), awsutil.ToString(rows[j]["discrepancy"])
+		// Fargate nodes are expected to have no instance; keep them with
+		// the matched rows rather than among the problems.
+		if healthyA, healthyB := a == nodeMatched || a == nodeFargate, b == nodeMatched || b == nodeFargate; healthyA != healthyB {
+			return healthyB
+		}
+		if a != b {
+			return a < b
+		}
+		return awsutil.ToString(rows[i]["instanceId"])+awsutil.ToString(rows[i]["nodeName"]) < awsutil.ToString(rows[j]["instanceId"])+awsutil.ToString(rows[j]["nodeName"])
+	})
+	return rows
+}
+
+// instanceIDFromProviderID extracts the instance id from an AWS provider id
+// such as aws:///us-east-1a/i-0123456789abcdef0.
+func instanceIDFromProviderID(providerID string) string {
+	if !strings.HasPrefix(providerID, "aws://") {
+		return ""
+	}
+	id := providerID[strings.LastIndex(providerID, "/")+1:]
+	if !strings.HasPrefix(id, "i-") {
+		return ""
+	}
+	return id
+}
+
+// isFargateNode recognizes Fargate nodes by the compute-type label, falling
+// back to the fargate- prefix EKS gives their names and provider ids.
+func isFargateNode(node *corev1.Node) bool {
+	if node.Labels[fargateComputeTypeLabel] == "fargate" {
+		return true
+	}
+	return strings.HasPrefix(node.Name, "fargate-") || strings.Contains(node.Spec.ProviderID, "/fargate-")
+}
+
+func nodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
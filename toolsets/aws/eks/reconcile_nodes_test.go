@@ -0,0 +1,219 @@
+package awseks
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/redact"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+func reconcileNode(name, providerID string, ready bool) corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.NodeSpec{ProviderID: providerID},
+		Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}}},
+	}
+}
+
+func reconcileInstance(id string, state ec2types.InstanceStateName) nodegroupInstance {
+	return nodegroupInstance{nodegroup: "ng-1", instance: ec2types.Instance{InstanceId: aws.String(id), State: &ec2types.InstanceState{Name: state}}}
+}
+
+func TestIsFargateNode(t *testing.T) {
+	labeled := reconcileNode("node-1", "", true)
+	labeled.Labels = map[string]string{fargateComputeTypeLabel: "fargate"}
+	if !isFargateNode(&labeled) {
+		t.Fatalf("expected labeled node to be fargate")
+	}
+	named := reconcileNode("fargate-ip-10-0-0-1.ec2.internal", "", true)
+	if !isFargateNode(&named) {
+		t.Fatalf("expected fargate- prefixed node to be fargate")
+	}
+	ec2Node := reconcileNode("ip-10-0-0-1.ec2.internal", "aws:///us-east-1a/i-0abc", true)
+	if isFargateNode(&ec2Node) {
+		t.Fatalf("expected EC2 node not to be fargate")
+	}
+}
+
+func TestInstanceIDFromProviderID(t *testing.T) {
+	cases := map[string]string{
+		"aws:///us-east-1a/i-0abc":     "i-0abc",
+		"aws:///us-east-1a/fargate-ip": "",
+		"gce://project/zone/node":      "",
+		"":                             "",
+	}
+	for providerID, want := range cases {
+		if got := instanceIDFromProviderID(providerID); got != want {
+			t.Fatalf("instanceIDFromProviderID(%q) = %q, want %q", providerID, got, want)
+		}
+	}
+}
+
+func TestReconcileNodes(t *testing.T) {
+	instances := []nodegroupInstance{
+		reconcileInstance("i-ok", ec2types.InstanceStateNameRunning),
+		reconcileInstance("i-ghost", ec2types.InstanceStateNameRunning),
+		reconcileInstance("i-sick", ec2types.InstanceStateNameRunning),
+		reconcileInstance("i-boot", ec2types.InstanceStateNamePending),
+		reconcileInstance("i-stopped", ec2types.InstanceStateNameStopped),
+		reconcileInstance("i-done", ec2types.InstanceStateNameTerminated),
+	}
+	nodes := []corev1.Node{
+		reconcileNode("ok", "aws:///us-east-1a/i-ok", true),
+		reconcileNode("sick", "aws:///us-east-1a/i-sick", false),
+		reconcileNode("stopped", "aws:///us-east-1a/i-stopped", false),
+		reconcileNode("gone", "aws:///us-east-1a/i-gone", false),
+		reconcileNode("kind", "kind://docker/kind/node", true),
+		reconcileNode("fargate-ip-10-0-1-5.ec2.internal", "aws:///us-east-1a/0123abcd/fargate-ip-10-0-1-5.ec2.internal", true),
+	}
+	nodes[len(nodes)-1].Labels = map[string]string{fargateComputeTypeLabel: "fargate"}
+	rows := reconcileNodes(instances, nodes, map[string]string{"fargate-ip-10-0-1-5.ec2.internal": "fp-default"})
+	got := map[string]string{}
+	for _, row := range rows {
+		key := awsutil.ToString(row["instanceId"])
+		if key == "" {
+			key = awsutil.ToString(row["nodeName"])
+		}
+		got[key] = awsutil.ToString(row["discrepancy"])
+	}
+	want := map[string]string{
+		"i-ok":                             nodeMatched,
+		"i-ghost":                          nodeNotRegistered,
+		"i-sick":                           nodeNotReady,
+		"i-boot":                           nodeLaunching,
+		"i-stopped":                        nodeInstanceNotRunning,
+		"i-gone":                           nodeInstanceMissing,
+		"kind":                             nodeNotAWS,
+		"fargate-ip-10-0-1-5.ec2.internal": nodeFargate,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected rows: %#v", rows)
+	}
+	for key, discrepancy := range want {
+		if got[key] != discrepancy {
+			t.Fatalf("%s: got %q, want %q (rows %#v)", key, got[key], discrepancy, rows)
+		}
+	}
+	for _, row := range rows {
+		if row["discrepancy"] == nodeFargate && row["fargateProfile"] != "fp-default" {
+			t.Fatalf("expected fargate profile on row: %#v", row)
+		}
+	}
+	if awsutil.ToString(rows[len(rows)-1]["discrepancy"]) != nodeMatched {
+		t.Fatalf("expected matched nodes last, got %#v", rows)
+	}
+}
+
+func TestEKSReconcileNodesWithStubbedClients(t *testing.T) {
+	ca := []byte("-----BEGIN CERTIFICATE-----\ndemo\n-----END CERTIFICATE-----\n")
+	eksClient := newEKSTestClient(t, map[string]string{
+		"/clusters/demo": `{"cluster":{"name":"demo","endpoint":"https://ABC.gr7.us-east-1.eks.amazonaws.com","certificateAuthority":{"data":"` + base64.StdEncoding.EncodeToString(ca) + `"}}}`,
+		// ng-1 is only on the second page.
+		"/clusters/demo/node-groups":                  `{"nodegroups":[],"nextToken":"page-2"}`,
+		"/clusters/demo/node-groups?nextToken=page-2": `{"nodegroups":["ng-1"]}`,
+		"/clusters/demo/node-groups/ng-1":             `{"nodegroup":{"nodegroupName":"ng-1","resources":{"autoScalingGroups":[{"name":"asg-1"}]}}}`,
+	})
+	asgClient := newASGTestClient(t, map[string]string{
+		"DescribeAutoScalingGroups": `<DescribeAutoScalingGroupsResponse xmlns="http://autoscaling.amazonaws.com/doc/2011-01-01/">
+  <DescribeAutoScalingGroupsResult>
+    <AutoScalingGroups>
+      <member>
+        <AutoScalingGroupName>asg-1</AutoScalingGroupName>
+        <Instances>
+          <member><InstanceId>i-1</InstanceId></member>
+        </Instances>
+      </member>
+    </AutoScalingGroups>
+  </DescribeAutoScalingGroupsResult>
+</DescribeAutoScalingGroupsResponse>`,
+	})
+	ec2Client := newEC2TestClient(t, map[string]string{
+		"DescribeInstances": `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <reservationSet>
+    <item>
+      <instancesSet>
+        <item>
+          <instanceId>i-1</instanceId>
+          <instanceState><code>16</code><name>running</name></instanceState>
+        </item>
+      </instancesSet>
+    </item>
+  </reservationSet>
+</DescribeInstancesResponse>`,
+	})
+	ready := reconcileNode("node-1", "aws:///us-east-1a/i-1", true)
+	ghost := reconcileNode("node-2", "aws:///us-east-1a/i-2", false)
+	svc := &Service{
+		ctx: mcp.ToolContext{
+			Redactor: redact.New(),
+			Policy:   policy.NewAuthorizer(),
+			Clients:  &kube.Clients{Typed: fake.NewSimpleClientset(&ready, &ghost), RestConfig: &rest.Config{Host: "https://abc.gr7.us-east-1.eks.amazonaws.com:443"}},
+		},
+		eksClient: func(context.Context, string) (*eks.Client, string, error) { return eksClient, "us-east-1", nil },
+		asgClient: func(context.Context, string) (*autoscaling.Client, string, error) { return asgClient, "us-east-1", nil },
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) { return ec2Client, "us-east-1", nil },
+	}
+	args := map[string]any{"clusterName": "demo"}
+	if _, err := svc.handleReconcileNodes(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleNamespace, AllowedNamespaces: []string{"default"}}, Arguments: args}); err == nil {
+		t.Fatalf("expected namespace users to be rejected")
+	}
+	result, err := svc.handleReconcileNodes(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: args})
+	if err != nil {
+		t.Fatalf("reconcile nodes: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	counts := data["discrepancies"].(map[string]int)
+	if counts[nodeMatched] != 1 || counts[nodeInstanceMissing] != 1 {
+		t.Fatalf("unexpected discrepancies: %#v", result.Data)
+	}
+	if _, ok := data["warnings"]; ok {
+		t.Fatalf("expected no warnings for a verified context: %#v", data["warnings"])
+	}
+	for _, row := range data["nodes"].([]map[string]any) {
+		if row["instanceId"] == "i-1" && row["nodegroup"] != "ng-1" {
+			t.Fatalf("expected i-1 attributed to ng-1 from the second nodegroup page, got %#v", row)
+		}
+	}
+
+	// A different endpoint is accepted when the CA bundle matches.
+	svc.ctx.Clients.RestConfig = &rest.Config{Host: "https://127.0.0.1:6443", TLSClientConfig: rest.TLSClientConfig{CAData: ca}}
+	if _, err := svc.handleReconcileNodes(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: args}); err != nil {
+		t.Fatalf("expected a matching CA to verify the context: %v", err)
+	}
+
+	// Another cluster's context is refused instead of reporting every node.
+	svc.ctx.Clients.RestConfig = &rest.Config{Host: "https://other.gr7.us-east-1.eks.amazonaws.com"}
+	if _, err := svc.handleReconcileNodes(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: args}); err == nil || !strings.Contains(err.Error(), "not EKS cluster demo") {
+		t.Fatalf("expected a cluster mismatch error, got %v", err)
+	}
+
+	// Without a client config the report comes back flagged as unverified.
+	svc.ctx.Clients.RestConfig = nil
+	result, err = svc.handleReconcileNodes(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: args})
+	if err != nil {
+		t.Fatalf("reconcile nodes without client config: %v", err)
+	}
+	if warnings, _ := result.Data.(map[string]any)["warnings"].([]string); len(warnings) == 0 || !strings.Contains(warnings[0], "could not confirm") {
+		t.Fatalf("expected an unverified-context warning, got %#v", result.Data)
+	}
+}
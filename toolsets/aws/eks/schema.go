@@ -4,8 +4,9 @@ func schemaEKSListClusters() map[string]any {
 	return map[string]any{
 		"type": "object",
 		"properties": map[string]any{
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -21,12 +22,24 @@ func schemaEKSGetCluster() map[string]any {
 	}
 }
 
+func schemaEKSGetEndpointAccess() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"clusterName": map[string]any{"type": "string"},
+			"region":      map[string]any{"type": "string"},
+		},
+		"required": []string{"clusterName"},
+	}
+}
+
 func schemaEKSListNodegroups() map[string]any {
 	return map[string]any{
 		"type": "object",
 		"properties": map[string]any{
 			"clusterName": map[string]any{"type": "string"},
 			"limit":       map[string]any{"type": "number"},
+			"nextToken":   map[string]any{"type": "string"},
 			"region":      map[string]any{"type": "string"},
 		},
 		"required": []string{"clusterName"},
@@ -51,6 +64,7 @@ func schemaEKSListAddons() map[string]any {
 		"properties": map[string]any{
 			"clusterName": map[string]any{"type": "string"},
 			"limit":       map[string]any{"type": "number"},
+			"nextToken":   map[string]any{"type": "string"},
 			"region":      map[string]any{"type": "string"},
 		},
 		"required": []string{"clusterName"},
@@ -69,12 +83,24 @@ func schemaEKSGetAddon() map[string]any {
 	}
 }
 
+func schemaEKSCheckAddonUpdates() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"clusterName": map[string]any{"type": "string"},
+			"region":      map[string]any{"type": "string"},
+		},
+		"required": []string{"clusterName"},
+	}
+}
+
 func schemaEKSListFargateProfiles() map[string]any {
 	return map[string]any{
 		"type": "object",
 		"properties": map[string]any{
 			"clusterName": map[string]any{"type": "string"},
 			"limit":       map[string]any{"type": "number"},
+			"nextToken":   map[string]any{"type": "string"},
 			"region":      map[string]any{"type": "string"},
 		},
 		"required": []string{"clusterName"},
@@ -93,12 +119,24 @@ func schemaEKSGetFargateProfile() map[string]any {
 	}
 }
 
+func schemaEKSCapacityOverview() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"clusterName": map[string]any{"type": "string"},
+			"region":      map[string]any{"type": "string"},
+		},
+		"required": []string{"clusterName"},
+	}
+}
+
 func schemaEKSListIdentityProviderConfigs() map[string]any {
 	return map[string]any{
 		"type": "object",
 		"properties": map[string]any{
 			"clusterName": map[string]any{"type": "string"},
 			"limit":       map[string]any{"type": "number"},
+			"nextToken":   map[string]any{"type": "string"},
 			"region":      map[string]any{"type": "string"},
 		},
 		"required": []string{"clusterName"},
@@ -118,6 +156,19 @@ func schemaEKSGetIdentityProviderConfig() map[string]any {
 	}
 }
 
+func schemaEKSListPodIdentityAssociations() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"clusterName":    map[string]any{"type": "string"},
+			"namespace":      map[string]any{"type": "string"},
+			"serviceAccount": map[string]any{"type": "string"},
+			"region":         map[string]any{"type": "string"},
+		},
+		"required": []string{"clusterName"},
+	}
+}
+
 func schemaEKSListUpdates() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -125,6 +176,7 @@ func schemaEKSListUpdates() map[string]any {
 			"clusterName":   map[string]any{"type": "string"},
 			"nodegroupName": map[string]any{"type": "string"},
 			"limit":         map[string]any{"type": "number"},
+			"nextToken":     map[string]any{"type": "string"},
 			"region":        map[string]any{"type": "string"},
 		},
 		"required": []string{"clusterName"},
@@ -157,15 +209,48 @@ func schemaEKSListNodes() map[string]any {
 	}
 }
 
+func schemaEKSReconcileNodes() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"clusterName": map[string]any{"type": "string"},
+			"region":      map[string]any{"type": "string"},
+		},
+		"required": []string{"clusterName"},
+	}
+}
+
+func schemaEKSClassifyNodes() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"labelSelector": map[string]any{"type": "string"},
+		},
+	}
+}
+
+func schemaEKSDiagnoseNode() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"clusterName":   map[string]any{"type": "string"},
+			"nodeName":      map[string]any{"type": "string"},
+			"activityLimit": map[string]any{"type": "integer"},
+			"region":        map[string]any{"type": "string"},
+		},
+		"required": []string{"clusterName", "nodeName"},
+	}
+}
+
 func schemaEKSDebug() map[string]any {
 	return map[string]any{
 		"type": "object",
 		"properties": map[string]any{
-			"clusterName":            map[string]any{"type": "string"},
-			"includeSts":             map[string]any{"type": "boolean"},
-			"includeKms":             map[string]any{"type": "boolean"},
-			"includeEcr":             map[string]any{"type": "boolean"},
-			"includeIam":             map[string]any{"type": "boolean"},
+			"clusterName":             map[string]any{"type": "string"},
+			"includeSts":              map[string]any{"type": "boolean"},
+			"includeKms":              map[string]any{"type": "boolean"},
+			"includeEcr":              map[string]any{"type": "boolean"},
+			"includeIam":              map[string]any{"type": "boolean"},
 			"serviceAccountNamespace": map[string]any{"type": "string"},
 			"serviceAccountName":      map[string]any{"type": "string"},
 			"roleArn":                 map[string]any{"type": "string"},
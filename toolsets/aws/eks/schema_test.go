@@ -10,17 +10,24 @@ func TestEKSSchemas(t *testing.T) {
 	schemas := []map[string]any{
 		schemaEKSListClusters(),
 		schemaEKSGetCluster(),
+		schemaEKSGetEndpointAccess(),
+		schemaEKSCheckAddonUpdates(),
+		schemaEKSListPodIdentityAssociations(),
 		schemaEKSListNodegroups(),
 		schemaEKSGetNodegroup(),
 		schemaEKSListAddons(),
 		schemaEKSGetAddon(),
 		schemaEKSListFargateProfiles(),
 		schemaEKSGetFargateProfile(),
+		schemaEKSCapacityOverview(),
 		schemaEKSListIdentityProviderConfigs(),
 		schemaEKSGetIdentityProviderConfig(),
 		schemaEKSListUpdates(),
 		schemaEKSGetUpdate(),
 		schemaEKSListNodes(),
+		schemaEKSReconcileNodes(),
+		schemaEKSClassifyNodes(),
+		schemaEKSDiagnoseNode(),
 		schemaEKSDebug(),
 	}
 	for i, schema := range schemas {
@@ -0,0 +1,203 @@
+package awsiam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+
+	"rootcause/internal/argconv"
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+// defaultMaxRoleStatements caps the policy statements expanded by
+// aws.iam.describe_role; roles with many managed policies can have hundreds.
+const defaultMaxRoleStatements = 200
+
+func (s *Service) handleIAMDescribeRole(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	region := awsutil.ToString(req.Arguments["region"])
+	roleName := awsutil.ToString(req.Arguments["roleName"])
+	if roleName == "" {
+		roleName = roleNameFromARN(awsutil.ToString(req.Arguments["roleArn"]))
+	}
+	if roleName == "" {
+		return awsutil.ErrorResult(errors.New("roleName or roleArn is required")), errors.New("roleName or roleArn is required")
+	}
+	maxStatements := argconv.Int(req.Arguments["maxStatements"], defaultMaxRoleStatements)
+	if maxStatements <= 0 {
+		maxStatements = defaultMaxRoleStatements
+	}
+	client, usedRegion, err := s.iamClient(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	out, err := client.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String(roleName)})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	role := out.Role
+	summary := summarizeRole(*role)
+	summary["arn"] = awsutil.MaskAccountIDs(s.ctx, aws.ToString(role.Arn))
+	if role.PermissionsBoundary != nil {
+		summary["permissionsBoundary"] = awsutil.MaskAccountIDs(s.ctx, aws.ToString(role.PermissionsBoundary.PermissionsBoundaryArn))
+	}
+	var lastUsedDate *time.Time
+	lastUsedRegion := ""
+	if role.RoleLastUsed != nil {
+		lastUsedDate, lastUsedRegion = role.RoleLastUsed.LastUsedDate, aws.ToString(role.RoleLastUsed.Region)
+	}
+	result := map[string]any{
+		"region":   awsutil.RegionOrDefault(usedRegion),
+		"role":     summary,
+		"lastUsed": roleLastUsed(lastUsedDate, lastUsedRegion),
+	}
+	if doc := decodePolicyDocument(aws.ToString(role.AssumeRolePolicyDocument)); strings.TrimSpace(doc) != "" {
+		result["trustPolicy"] = parseJSONOrString(awsutil.MaskAccountIDs(s.ctx, doc))
+	}
+
+	var warnings []string
+	statements := []map[string]any{}
+	truncated := false
+	addStatements := func(source, document string) {
+		for _, stmt := range policyDocumentStatements(awsutil.MaskAccountIDs(s.ctx, document)) {
+			if len(statements) >= maxStatements {
+				truncated = true
+				return
+			}
+			stmt["policy"] = source
+			statements = append(statements, stmt)
+		}
+	}
+
+	attached, err := listAttachedRolePolicies(ctx, client, roleName)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	for _, policy := range attached {
+		arn := awsutil.ToString(policy["arn"])
+		policy["arn"] = awsutil.MaskAccountIDs(s.ctx, arn)
+		doc, err := defaultPolicyDocument(ctx, client, arn)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("get policy %s failed: %v", awsutil.ToString(policy["name"]), err))
+			continue
+		}
+		addStatements(awsutil.ToString(policy["name"]), doc)
+	}
+	inline, err := listInlineRolePolicies(ctx, client, roleName)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	for _, name := range inline {
+		policyOut, err := client.GetRolePolicy(ctx, &iam.GetRolePolicyInput{RoleName: aws.String(roleName), PolicyName: aws.String(name)})
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("get inline policy %s failed: %v", name, err))
+			continue
+		}
+		addStatements(name+" (inline)", decodePolicyDocument(aws.ToString(policyOut.PolicyDocument)))
+	}
+
+	result["attachedPolicies"] = attached
+	result["inlinePolicies"] = inline
+	result["statements"] = statements
+	if truncated {
+		result["statementsTruncated"] = true
+		warnings = append(warnings, fmt.Sprintf("only the first %d statements are shown; raise maxStatements to see more", maxStatements))
+	}
+	if len(warnings) > 0 {
+		result["warnings"] = warnings
+	}
+	return mcp.ToolResult{
+		Data: s.ctx.Redactor.RedactValue(result),
+		Metadata: mcp.ToolMetadata{
+			Resources: []string{fmt.Sprintf("iam/role/%s", roleName)},
+		},
+	}, nil
+}
+
+// roleLastUsed reports when a role was last assumed. IAM tracks roughly the
+// last 400 days, so a role without a date has not been used in that window.
+func roleLastUsed(lastUsed *time.Time, region string) map[string]any {
+	if lastUsed == nil {
+		return map[string]any{
+			"used": false,
+			"note": "no recorded use in the IAM tracking period (about 400 days)",
+		}
+	}
+	return map[string]any{
+		"used":             true,
+		"lastUsedDate":     lastUsed.UTC(),
+		"region":           region,
+		"daysSinceLastUse": int(math.Floor(time.Since(*lastUsed).Hours() / 24)),
+	}
+}
+
+func defaultPolicyDocument(ctx context.Context, client *iam.Client, policyArn string) (string, error) {
+	policyOut, err := client.GetPolicy(ctx, &iam.GetPolicyInput{PolicyArn: aws.String(policyArn)})
+	if err != nil {
+		return "", err
+	}
+	if policyOut.Policy == nil {
+		return "", fmt.Errorf("policy %s not found", policyArn)
+	}
+	versionOut, err := client.GetPolicyVersion(ctx, &iam.GetPolicyVersionInput{
+		PolicyArn: aws.String(policyArn),
+		VersionId: policyOut.Policy.DefaultVersionId,
+	})
+	if err != nil {
+		return "", err
+	}
+	return decodePolicyDocument(aws.ToString(versionOut.PolicyVersion.Document)), nil
+}
+
+// policyDocumentStatements flattens a policy document into one entry per
+// statement. Statement may be a single object or a list.
+func policyDocumentStatements(document string) []map[string]any {
+	doc, ok := parseJSONOrString(document).(map[string]any)
+	if !ok {
+		return nil
+	}
+	var raw []any
+	switch v := doc["Statement"].(type) {
+	case []any:
+		raw = v
+	case map[string]any:
+		raw = []any{v}
+	}
+	statements := make([]map[string]any, 0, len(raw))
+	for _, item := range raw {
+		stmt, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		entry := map[string]any{"effect": awsutil.ToString(stmt["Effect"])}
+		if sid := awsutil.ToString(stmt["Sid"]); sid != "" {
+			entry["sid"] = sid
+		}
+		for _, field := range []string{"Action", "NotAction", "Resource", "NotResource"} {
+			if values := awsutil.ToStringSlice(stmt[field]); len(values) > 0 {
+				entry[strings.ToLower(field[:1])+field[1:]] = values
+			}
+		}
+		if condition, ok := stmt["Condition"]; ok {
+			entry["condition"] = condition
+		}
+		statements = append(statements, entry)
+	}
+	return statements
+}
+
+// roleNameFromARN returns the role name from arn:aws:iam::<account>:role/<path>/<name>.
+func roleNameFromARN(arn string) string {
+	idx := strings.Index(arn, ":role/")
+	if idx < 0 {
+		return ""
+	}
+	resource := arn[idx+len(":role/"):]
+	return resource[strings.LastIndex(resource, "/")+1:]
+}
@@ -0,0 +1,113 @@
+package awsiam
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+
+	"rootcause/internal/config"
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestHandleIAMDescribeRole(t *testing.T) {
+	trustDoc := url.QueryEscape(`{"Statement":[{"Effect":"Allow","Principal":{"Federated":"arn:aws:iam::123456789012:oidc-provider/oidc.eks"},"Action":"sts:AssumeRoleWithWebIdentity"}]}`)
+	managedDoc := url.QueryEscape(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:ListBucket"],"Resource":"arn:aws:s3:::data/*"},{"Effect":"Deny","NotAction":"s3:*","Resource":"*"}]}`)
+	inlineDoc := url.QueryEscape(`{"Version":"2012-10-17","Statement":{"Sid":"Kms","Effect":"Allow","Action":"kms:Decrypt","Resource":"*","Condition":{"StringEquals":{"kms:ViaService":"s3.us-east-1.amazonaws.com"}}}}`)
+	client := newIAMTestClient(t, map[string]string{
+		"GetRole": fmt.Sprintf(`<GetRoleResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <GetRoleResult>
+    <Role>
+      <Path>/</Path>
+      <RoleName>app</RoleName>
+      <RoleId>RID</RoleId>
+      <Arn>arn:aws:iam::123456789012:role/app</Arn>
+      <CreateDate>2024-01-01T00:00:00Z</CreateDate>
+      <AssumeRolePolicyDocument>%s</AssumeRolePolicyDocument>
+      <RoleLastUsed><LastUsedDate>2024-02-01T00:00:00Z</LastUsedDate><Region>us-east-1</Region></RoleLastUsed>
+    </Role>
+  </GetRoleResult>
+</GetRoleResponse>`, trustDoc),
+		"ListAttachedRolePolicies": `<ListAttachedRolePoliciesResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <ListAttachedRolePoliciesResult>
+    <AttachedPolicies><member><PolicyName>data-read</PolicyName><PolicyArn>arn:aws:iam::123456789012:policy/data-read</PolicyArn></member></AttachedPolicies>
+    <IsTruncated>false</IsTruncated>
+  </ListAttachedRolePoliciesResult>
+</ListAttachedRolePoliciesResponse>`,
+		"GetPolicy": `<GetPolicyResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <GetPolicyResult><Policy><PolicyName>data-read</PolicyName><Arn>arn:aws:iam::123456789012:policy/data-read</Arn><DefaultVersionId>v2</DefaultVersionId></Policy></GetPolicyResult>
+</GetPolicyResponse>`,
+		"GetPolicyVersion": fmt.Sprintf(`<GetPolicyVersionResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <GetPolicyVersionResult><PolicyVersion><Document>%s</Document><VersionId>v2</VersionId><IsDefaultVersion>true</IsDefaultVersion></PolicyVersion></GetPolicyVersionResult>
+</GetPolicyVersionResponse>`, managedDoc),
+		"ListRolePolicies": `<ListRolePoliciesResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <ListRolePoliciesResult><PolicyNames><member>kms</member></PolicyNames><IsTruncated>false</IsTruncated></ListRolePoliciesResult>
+</ListRolePoliciesResponse>`,
+		"GetRolePolicy": fmt.Sprintf(`<GetRolePolicyResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <GetRolePolicyResult><RoleName>app</RoleName><PolicyName>kms</PolicyName><PolicyDocument>%s</PolicyDocument></GetRolePolicyResult>
+</GetRolePolicyResponse>`, inlineDoc),
+	})
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		iamClient: func(context.Context, string) (*iam.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleIAMDescribeRole(context.Background(), mcp.ToolRequest{Arguments: map[string]any{
+		"roleArn": "arn:aws:iam::123456789012:role/team/app",
+	}})
+	if err != nil {
+		t.Fatalf("describe role: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	lastUsed := data["lastUsed"].(map[string]any)
+	if lastUsed["used"] != true || lastUsed["region"] != "us-east-1" {
+		t.Fatalf("unexpected last used: %#v", lastUsed)
+	}
+	statements := data["statements"].([]map[string]any)
+	if len(statements) != 3 {
+		t.Fatalf("expected 3 statements, got %#v", statements)
+	}
+	if statements[0]["policy"] != "data-read" || len(statements[0]["action"].([]string)) != 2 {
+		t.Fatalf("unexpected managed statement: %#v", statements[0])
+	}
+	if statements[2]["policy"] != "kms (inline)" || statements[2]["condition"] == nil {
+		t.Fatalf("unexpected inline statement: %#v", statements[2])
+	}
+	if data["trustPolicy"] == nil {
+		t.Fatalf("expected trust policy: %#v", data)
+	}
+
+	svc.ctx.Config = &config.Config{AWS: config.AWSConfig{RedactAccountID: true}}
+	result, err = svc.handleIAMDescribeRole(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"roleName": "app", "maxStatements": 1}})
+	if err != nil {
+		t.Fatalf("describe role: %v", err)
+	}
+	data = result.Data.(map[string]any)
+	if data["statementsTruncated"] != true || len(data["statements"].([]map[string]any)) != 1 {
+		t.Fatalf("expected statements capped: %#v", data)
+	}
+	if role := data["role"].(map[string]any); strings.Contains(role["arn"].(string), "123456789012") {
+		t.Fatalf("expected account id masked: %#v", role)
+	}
+	if _, err := svc.handleIAMDescribeRole(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected missing role error")
+	}
+}
+
+func TestRoleNameFromARN(t *testing.T) {
+	cases := map[string]string{
+		"arn:aws:iam::123456789012:role/app":          "app",
+		"arn:aws:iam::123456789012:role/team/sub/app": "app",
+		"arn:aws:iam::123456789012:user/alice":        "",
+	}
+	for arn, want := range cases {
+		if got := roleNameFromARN(arn); got != want {
+			t.Fatalf("%s: got %q, want %q", arn, got, want)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package awsiam
 
 import (
 	"context"
-	"encoding/json"
 	"testing"
 )
 
@@ -26,12 +25,3 @@ func TestIAMDeleteNonDefaultPolicyVersions(t *testing.T) {
 		t.Fatalf("delete non-default versions: %v", err)
 	}
 }
-
-func TestIAMTypeHelpers(t *testing.T) {
-	if got := toInt(json.Number("5"), 1); got != 5 {
-		t.Fatalf("unexpected toInt: %d", got)
-	}
-	if got := toBool(nil, true); got != true {
-		t.Fatalf("unexpected toBool fallback: %v", got)
-	}
-}
@@ -15,7 +15,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 
+	"rootcause/internal/argconv"
+	awslib "rootcause/internal/aws"
 	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
 )
 
 type Service struct {
@@ -43,6 +46,14 @@ func ToolSpecs(ctx mcp.ToolContext, toolsetID string, iamClient func(context.Con
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     svc.handleIAMGetRole,
 		},
+		{
+			Name:        "aws.iam.describe_role",
+			Description: "Describe an IAM role: trust policy, attached and inline policy statements, and when it was last used.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaIAMDescribeRole(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleIAMDescribeRole,
+		},
 		{
 			Name:        "aws.iam.get_instance_profile",
 			Description: "Get an IAM instance profile and its roles.",
@@ -103,60 +114,69 @@ func ToolSpecs(ctx mcp.ToolContext, toolsetID string, iamClient func(context.Con
 }
 
 func (s *Service) handleIAMListRoles(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	pathPrefix := toString(req.Arguments["pathPrefix"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	pathPrefix := awsutil.ToString(req.Arguments["pathPrefix"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.iamClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &iam.ListRolesInput{}
 	if pathPrefix != "" {
 		input.PathPrefix = aws.String(pathPrefix)
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.Marker = pager.StartToken()
 	paginator := iam.NewListRolesPaginator(client, input)
+	pageToken := aws.ToString(input.Marker)
 	var roles []map[string]any
 	for paginator.HasMorePages() {
 		out, err := paginator.NextPage(ctx)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, role := range out.Roles {
+		start, end, more := pager.Page(pageToken, len(out.Roles), out.Marker)
+		for _, role := range out.Roles[start:end] {
 			roles = append(roles, summarizeRole(role))
-			if limit > 0 && len(roles) >= limit {
-				break
-			}
 		}
-		if limit > 0 && len(roles) >= limit {
+		if !more {
 			break
 		}
+		pageToken = aws.ToString(out.Marker)
 	}
 	data := map[string]any{
-		"region": regionOrDefault(usedRegion),
+		"region": awsutil.RegionOrDefault(usedRegion),
 		"roles":  roles,
 		"count":  len(roles),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleIAMGetRole(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	roleName := toString(req.Arguments["roleName"])
-	includePolicies := toBool(req.Arguments["includePolicies"], true)
+	region := awsutil.ToString(req.Arguments["region"])
+	roleName := awsutil.ToString(req.Arguments["roleName"])
+	includePolicies := awsutil.ToBool(req.Arguments["includePolicies"], true)
 	if roleName == "" {
-		return errorResult(errors.New("roleName is required")), errors.New("roleName is required")
+		return awsutil.ErrorResult(errors.New("roleName is required")), errors.New("roleName is required")
 	}
 	client, usedRegion, err := s.iamClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String(roleName)})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	role := out.Role
 	result := map[string]any{
-		"region": regionOrDefault(usedRegion),
+		"region": awsutil.RegionOrDefault(usedRegion),
 		"role":   summarizeRole(*role),
 	}
 	assumeDoc := decodePolicyDocument(aws.ToString(role.AssumeRolePolicyDocument))
@@ -166,15 +186,15 @@ func (s *Service) handleIAMGetRole(ctx context.Context, req mcp.ToolRequest) (mc
 	if includePolicies {
 		attached, err := listAttachedRolePolicies(ctx, client, roleName)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
 		inline, err := listInlineRolePolicies(ctx, client, roleName)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
 		instanceProfiles, err := listInstanceProfiles(ctx, client, roleName)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
 		result["attachedPolicies"] = attached
 		result["inlinePolicies"] = inline
@@ -189,21 +209,21 @@ func (s *Service) handleIAMGetRole(ctx context.Context, req mcp.ToolRequest) (mc
 }
 
 func (s *Service) handleIAMGetInstanceProfile(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	profileName := toString(req.Arguments["instanceProfileName"])
+	region := awsutil.ToString(req.Arguments["region"])
+	profileName := awsutil.ToString(req.Arguments["instanceProfileName"])
 	if profileName == "" {
-		return errorResult(errors.New("instanceProfileName is required")), errors.New("instanceProfileName is required")
+		return awsutil.ErrorResult(errors.New("instanceProfileName is required")), errors.New("instanceProfileName is required")
 	}
 	client, usedRegion, err := s.iamClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.GetInstanceProfile(ctx, &iam.GetInstanceProfileInput{InstanceProfileName: aws.String(profileName)})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	result := map[string]any{
-		"region":          regionOrDefault(usedRegion),
+		"region":          awsutil.RegionOrDefault(usedRegion),
 		"instanceProfile": summarizeInstanceProfile(out.InstanceProfile),
 	}
 	return mcp.ToolResult{
@@ -216,22 +236,22 @@ func (s *Service) handleIAMGetInstanceProfile(ctx context.Context, req mcp.ToolR
 
 func (s *Service) handleIAMUpdateRole(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
 	if err := requireConfirm(req.Arguments); err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
-	region := toString(req.Arguments["region"])
-	roleName := toString(req.Arguments["roleName"])
-	desc := toString(req.Arguments["description"])
-	assumeDoc := toString(req.Arguments["assumeRolePolicyDocument"])
-	maxSession := toInt(req.Arguments["maxSessionDurationSeconds"], 0)
+	region := awsutil.ToString(req.Arguments["region"])
+	roleName := awsutil.ToString(req.Arguments["roleName"])
+	desc := awsutil.ToString(req.Arguments["description"])
+	assumeDoc := awsutil.ToString(req.Arguments["assumeRolePolicyDocument"])
+	maxSession := argconv.Int(req.Arguments["maxSessionDurationSeconds"], 0)
 	if roleName == "" {
-		return errorResult(errors.New("roleName is required")), errors.New("roleName is required")
+		return awsutil.ErrorResult(errors.New("roleName is required")), errors.New("roleName is required")
 	}
 	if assumeDoc != "" && !json.Valid([]byte(assumeDoc)) {
-		return errorResult(errors.New("assumeRolePolicyDocument must be valid JSON")), errors.New("assumeRolePolicyDocument must be valid JSON")
+		return awsutil.ErrorResult(errors.New("assumeRolePolicyDocument must be valid JSON")), errors.New("assumeRolePolicyDocument must be valid JSON")
 	}
 	client, usedRegion, err := s.iamClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	updates := map[string]any{}
 	if desc != "" || maxSession > 0 {
@@ -246,7 +266,7 @@ func (s *Service) handleIAMUpdateRole(ctx context.Context, req mcp.ToolRequest)
 			input.MaxSessionDuration = aws.Int32(int32(maxSession)) //nolint:gosec // bounded above by MaxInt32 clamp
 		}
 		if _, err := client.UpdateRole(ctx, input); err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
 		updates["role"] = map[string]any{"description": desc, "maxSessionDurationSeconds": maxSession}
 	}
@@ -255,15 +275,15 @@ func (s *Service) handleIAMUpdateRole(ctx context.Context, req mcp.ToolRequest)
 			RoleName:       aws.String(roleName),
 			PolicyDocument: aws.String(assumeDoc),
 		}); err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
 		updates["assumeRolePolicyDocument"] = "updated"
 	}
 	if len(updates) == 0 {
-		return errorResult(errors.New("no updates specified")), errors.New("no updates specified")
+		return awsutil.ErrorResult(errors.New("no updates specified")), errors.New("no updates specified")
 	}
 	result := map[string]any{
-		"region":  regionOrDefault(usedRegion),
+		"region":  awsutil.RegionOrDefault(usedRegion),
 		"role":    roleName,
 		"updated": updates,
 	}
@@ -277,56 +297,56 @@ func (s *Service) handleIAMUpdateRole(ctx context.Context, req mcp.ToolRequest)
 
 func (s *Service) handleIAMDeleteRole(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
 	if err := requireConfirm(req.Arguments); err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
-	region := toString(req.Arguments["region"])
-	roleName := toString(req.Arguments["roleName"])
-	force := toBool(req.Arguments["force"], false)
+	region := awsutil.ToString(req.Arguments["region"])
+	roleName := awsutil.ToString(req.Arguments["roleName"])
+	force := awsutil.ToBool(req.Arguments["force"], false)
 	if roleName == "" {
-		return errorResult(errors.New("roleName is required")), errors.New("roleName is required")
+		return awsutil.ErrorResult(errors.New("roleName is required")), errors.New("roleName is required")
 	}
 	client, usedRegion, err := s.iamClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	attached, err := listAttachedRolePolicies(ctx, client, roleName)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	inline, err := listInlineRolePolicies(ctx, client, roleName)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	instanceProfiles, err := listInstanceProfiles(ctx, client, roleName)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if (!force) && (len(attached) > 0 || len(inline) > 0 || len(instanceProfiles) > 0) {
-		return errorResult(fmt.Errorf("role has attached policies or instance profiles; set force=true to detach")), fmt.Errorf("role has attached policies or instance profiles; set force=true to detach")
+		return awsutil.ErrorResult(fmt.Errorf("role has attached policies or instance profiles; set force=true to detach")), fmt.Errorf("role has attached policies or instance profiles; set force=true to detach")
 	}
 	result := map[string]any{
-		"region": regionOrDefault(usedRegion),
+		"region": awsutil.RegionOrDefault(usedRegion),
 		"role":   roleName,
 	}
 	if force {
 		detached, err := detachRolePolicies(ctx, client, roleName, attached)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
 		deletedInline, err := deleteInlineRolePolicies(ctx, client, roleName, inline)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
 		removedProfiles, err := removeRoleFromProfiles(ctx, client, roleName, instanceProfiles)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
 		result["detachedPolicies"] = detached
 		result["deletedInlinePolicies"] = deletedInline
 		result["removedInstanceProfiles"] = removedProfiles
 	}
 	if _, err := client.DeleteRole(ctx, &iam.DeleteRoleInput{RoleName: aws.String(roleName)}); err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	result["deleted"] = true
 	return mcp.ToolResult{
@@ -338,14 +358,14 @@ func (s *Service) handleIAMDeleteRole(ctx context.Context, req mcp.ToolRequest)
 }
 
 func (s *Service) handleIAMListPolicies(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	scope := toString(req.Arguments["scope"])
-	onlyAttached := toBool(req.Arguments["onlyAttached"], false)
-	pathPrefix := toString(req.Arguments["pathPrefix"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	scope := awsutil.ToString(req.Arguments["scope"])
+	onlyAttached := awsutil.ToBool(req.Arguments["onlyAttached"], false)
+	pathPrefix := awsutil.ToString(req.Arguments["pathPrefix"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.iamClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &iam.ListPoliciesInput{}
 	if scope != "" {
@@ -359,50 +379,59 @@ func (s *Service) handleIAMListPolicies(ctx context.Context, req mcp.ToolRequest
 	if pathPrefix != "" {
 		input.PathPrefix = aws.String(pathPrefix)
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.Marker = pager.StartToken()
 	paginator := iam.NewListPoliciesPaginator(client, input)
+	pageToken := aws.ToString(input.Marker)
 	var policies []map[string]any
 	for paginator.HasMorePages() {
 		out, err := paginator.NextPage(ctx)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, policy := range out.Policies {
+		start, end, more := pager.Page(pageToken, len(out.Policies), out.Marker)
+		for _, policy := range out.Policies[start:end] {
 			policies = append(policies, summarizePolicy(policy))
-			if limit > 0 && len(policies) >= limit {
-				break
-			}
 		}
-		if limit > 0 && len(policies) >= limit {
+		if !more {
 			break
 		}
+		pageToken = aws.ToString(out.Marker)
 	}
 	result := map[string]any{
-		"region":   regionOrDefault(usedRegion),
+		"region":   awsutil.RegionOrDefault(usedRegion),
 		"policies": policies,
 		"count":    len(policies),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(result)}, nil
+	redacted := s.ctx.Redactor.RedactMap(result)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleIAMGetPolicy(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	policyArn := toString(req.Arguments["policyArn"])
-	versionID := toString(req.Arguments["versionId"])
-	includeDoc := toBool(req.Arguments["includeDocument"], true)
+	region := awsutil.ToString(req.Arguments["region"])
+	policyArn := awsutil.ToString(req.Arguments["policyArn"])
+	versionID := awsutil.ToString(req.Arguments["versionId"])
+	includeDoc := awsutil.ToBool(req.Arguments["includeDocument"], true)
 	if policyArn == "" {
-		return errorResult(errors.New("policyArn is required")), errors.New("policyArn is required")
+		return awsutil.ErrorResult(errors.New("policyArn is required")), errors.New("policyArn is required")
 	}
 	client, usedRegion, err := s.iamClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.GetPolicy(ctx, &iam.GetPolicyInput{PolicyArn: aws.String(policyArn)})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	policy := out.Policy
 	result := map[string]any{
-		"region": regionOrDefault(usedRegion),
+		"region": awsutil.RegionOrDefault(usedRegion),
 		"policy": summarizePolicy(*policy),
 	}
 	if includeDoc {
@@ -414,7 +443,7 @@ func (s *Service) handleIAMGetPolicy(ctx context.Context, req mcp.ToolRequest) (
 			VersionId: aws.String(versionID),
 		})
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
 		doc := aws.ToString(versionOut.PolicyVersion.Document)
 		result["policyDocument"] = parseJSONOrString(decodePolicyDocument(doc))
@@ -430,26 +459,26 @@ func (s *Service) handleIAMGetPolicy(ctx context.Context, req mcp.ToolRequest) (
 
 func (s *Service) handleIAMUpdatePolicy(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
 	if err := requireConfirm(req.Arguments); err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
-	region := toString(req.Arguments["region"])
-	policyArn := toString(req.Arguments["policyArn"])
-	document := toString(req.Arguments["document"])
-	setDefault := toBool(req.Arguments["setDefault"], true)
-	prune := toBool(req.Arguments["prune"], false)
+	region := awsutil.ToString(req.Arguments["region"])
+	policyArn := awsutil.ToString(req.Arguments["policyArn"])
+	document := awsutil.ToString(req.Arguments["document"])
+	setDefault := awsutil.ToBool(req.Arguments["setDefault"], true)
+	prune := awsutil.ToBool(req.Arguments["prune"], false)
 	if policyArn == "" || document == "" {
-		return errorResult(errors.New("policyArn and document are required")), errors.New("policyArn and document are required")
+		return awsutil.ErrorResult(errors.New("policyArn and document are required")), errors.New("policyArn and document are required")
 	}
 	if !json.Valid([]byte(document)) {
-		return errorResult(errors.New("document must be valid JSON")), errors.New("document must be valid JSON")
+		return awsutil.ErrorResult(errors.New("document must be valid JSON")), errors.New("document must be valid JSON")
 	}
 	client, usedRegion, err := s.iamClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if prune {
 		if err := prunePolicyVersions(ctx, client, policyArn); err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
 	}
 	out, err := client.CreatePolicyVersion(ctx, &iam.CreatePolicyVersionInput{
@@ -458,10 +487,10 @@ func (s *Service) handleIAMUpdatePolicy(ctx context.Context, req mcp.ToolRequest
 		SetAsDefault:   setDefault,
 	})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	result := map[string]any{
-		"region":         regionOrDefault(usedRegion),
+		"region":         awsutil.RegionOrDefault(usedRegion),
 		"policyArn":      policyArn,
 		"versionId":      aws.ToString(out.PolicyVersion.VersionId),
 		"setAsDefault":   setDefault,
@@ -479,28 +508,28 @@ func (s *Service) handleIAMUpdatePolicy(ctx context.Context, req mcp.ToolRequest
 
 func (s *Service) handleIAMDeletePolicy(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
 	if err := requireConfirm(req.Arguments); err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
-	region := toString(req.Arguments["region"])
-	policyArn := toString(req.Arguments["policyArn"])
-	force := toBool(req.Arguments["force"], false)
+	region := awsutil.ToString(req.Arguments["region"])
+	policyArn := awsutil.ToString(req.Arguments["policyArn"])
+	force := awsutil.ToBool(req.Arguments["force"], false)
 	if policyArn == "" {
-		return errorResult(errors.New("policyArn is required")), errors.New("policyArn is required")
+		return awsutil.ErrorResult(errors.New("policyArn is required")), errors.New("policyArn is required")
 	}
 	client, usedRegion, err := s.iamClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if force {
 		if err := deleteNonDefaultPolicyVersions(ctx, client, policyArn); err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
 	}
 	if _, err := client.DeletePolicy(ctx, &iam.DeletePolicyInput{PolicyArn: aws.String(policyArn)}); err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	result := map[string]any{
-		"region":    regionOrDefault(usedRegion),
+		"region":    awsutil.RegionOrDefault(usedRegion),
 		"policyArn": policyArn,
 		"deleted":   true,
 	}
@@ -564,7 +593,7 @@ func listInstanceProfiles(ctx context.Context, client *iam.Client, roleName stri
 func detachRolePolicies(ctx context.Context, client *iam.Client, roleName string, policies []map[string]any) ([]string, error) {
 	var detached []string
 	for _, policy := range policies {
-		arn := toString(policy["arn"])
+		arn := awsutil.ToString(policy["arn"])
 		if arn == "" {
 			continue
 		}
@@ -753,50 +782,3 @@ func requireConfirm(args map[string]any) error {
 	}
 	return errors.New("confirmation required: set confirm=true to proceed")
 }
-
-func errorResult(err error) mcp.ToolResult {
-	return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}
-}
-
-func toString(value any) string {
-	if value == nil {
-		return ""
-	}
-	if s, ok := value.(string); ok {
-		return s
-	}
-	return fmt.Sprintf("%v", value)
-}
-
-func toInt(value any, fallback int) int {
-	switch v := value.(type) {
-	case int:
-		return v
-	case int64:
-		return int(v)
-	case float64:
-		return int(v)
-	case json.Number:
-		if parsed, err := v.Int64(); err == nil {
-			return int(parsed)
-		}
-	}
-	return fallback
-}
-
-func toBool(value any, fallback bool) bool {
-	if value == nil {
-		return fallback
-	}
-	if b, ok := value.(bool); ok {
-		return b
-	}
-	return fallback
-}
-
-func regionOrDefault(region string) string {
-	if strings.TrimSpace(region) == "" {
-		return "us-east-1"
-	}
-	return region
-}
@@ -1,7 +1,6 @@
 package awsiam
 
 import (
-	"encoding/json"
 	"net/url"
 	"testing"
 	"time"
@@ -44,30 +43,6 @@ func TestRequireConfirm(t *testing.T) {
 	}
 }
 
-func TestTypeHelpers(t *testing.T) {
-	if got := toString(nil); got != "" {
-		t.Fatalf("expected empty string, got %q", got)
-	}
-	if got := toString(42); got != "42" {
-		t.Fatalf("unexpected toString: %q", got)
-	}
-	if got := toInt(json.Number("5"), 1); got != 5 {
-		t.Fatalf("unexpected toInt: %d", got)
-	}
-	if got := toInt("bad", 7); got != 7 {
-		t.Fatalf("expected fallback toInt")
-	}
-	if got := toBool(nil, true); got != true {
-		t.Fatalf("expected fallback toBool")
-	}
-	if got := toBool(false, true); got != false {
-		t.Fatalf("expected false toBool")
-	}
-	if got := regionOrDefault(""); got != "us-east-1" {
-		t.Fatalf("expected default region")
-	}
-}
-
 func TestSummarizers(t *testing.T) {
 	created := time.Now()
 	role := iamtypes.Role{
@@ -6,6 +6,7 @@ func schemaIAMListRoles() map[string]any {
 		"properties": map[string]any{
 			"pathPrefix": map[string]any{"type": "string"},
 			"limit":      map[string]any{"type": "number"},
+			"nextToken":  map[string]any{"type": "string"},
 			"region":     map[string]any{"type": "string"},
 		},
 	}
@@ -23,6 +24,18 @@ func schemaIAMGetRole() map[string]any {
 	}
 }
 
+func schemaIAMDescribeRole() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"roleName":      map[string]any{"type": "string"},
+			"roleArn":       map[string]any{"type": "string"},
+			"maxStatements": map[string]any{"type": "number"},
+			"region":        map[string]any{"type": "string"},
+		},
+	}
+}
+
 func schemaIAMGetInstanceProfile() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -70,6 +83,7 @@ func schemaIAMListPolicies() map[string]any {
 			"onlyAttached": map[string]any{"type": "boolean"},
 			"pathPrefix":   map[string]any{"type": "string"},
 			"limit":        map[string]any{"type": "number"},
+			"nextToken":    map[string]any{"type": "string"},
 			"region":       map[string]any{"type": "string"},
 		},
 	}
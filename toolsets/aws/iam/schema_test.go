@@ -10,6 +10,7 @@ func TestIAMSchemas(t *testing.T) {
 	schemas := []map[string]any{
 		schemaIAMListRoles(),
 		schemaIAMGetRole(),
+		schemaIAMDescribeRole(),
 		schemaIAMGetInstanceProfile(),
 		schemaIAMUpdateRole(),
 		schemaIAMDeleteRole(),
@@ -0,0 +1,219 @@
+// Package awsutil holds the argument coercion and result helpers shared by
+// the aws.* tool packages.
+package awsutil
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/render"
+)
+
+// FallbackRegion is used when neither the environment nor any config names a
+// region.
+const FallbackRegion = "us-east-1"
+
+var defaultRegion atomic.Value
+
+// omitAge mirrors render.omit_age; set at toolset init.
+var omitAge atomic.Bool
+
+// accountIDPattern matches the 12-digit account ids in principals and ARNs.
+var accountIDPattern = regexp.MustCompile(`\b\d{12}\b`)
+
+// SetDefaultRegion records the region resolved at toolset init so
+// RegionOrDefault reports it instead of FallbackRegion. Blank resets it.
+func SetDefaultRegion(region string) {
+	defaultRegion.Store(strings.TrimSpace(region))
+}
+
+// DefaultRegion returns the region set by SetDefaultRegion, else
+// FallbackRegion.
+func DefaultRegion() string {
+	if region, _ := defaultRegion.Load().(string); region != "" {
+		return region
+	}
+	return FallbackRegion
+}
+
+// SetOmitAge records render.omit_age at toolset init so WithAge leaves the
+// derived age fields out.
+func SetOmitAge(omit bool) {
+	omitAge.Store(omit)
+}
+
+// WithAge adds ageSeconds and age to summary from a creation time, unless
+// render.omit_age is set or the time is unset.
+func WithAge(summary map[string]any, created *time.Time) map[string]any {
+	if omitAge.Load() || created == nil {
+		return summary
+	}
+	return render.AddAge(summary, *created, time.Now())
+}
+
+// ErrorResult wraps err as the tool result returned alongside it.
+func ErrorResult(err error) mcp.ToolResult {
+	return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}
+}
+
+// ToString returns string arguments as-is and formats anything else.
+func ToString(value any) string {
+	if value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// ToStringSlice accepts a []string, a JSON array of strings or a single
+// string. Blank entries are dropped.
+func ToStringSlice(value any) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []any:
+		var out []string
+		for _, item := range v {
+			if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil
+		}
+		return []string{v}
+	default:
+		return nil
+	}
+}
+
+// ToBool returns fallback unless value is a bool.
+func ToBool(value any, fallback bool) bool {
+	if value == nil {
+		return fallback
+	}
+	if b, ok := value.(bool); ok {
+		return b
+	}
+	return fallback
+}
+
+// RegionOrDefault substitutes DefaultRegion() for a blank region.
+func RegionOrDefault(region string) string {
+	if strings.TrimSpace(region) == "" {
+		return DefaultRegion()
+	}
+	return region
+}
+
+// TagMap flattens EC2 tags, skipping entries without a key.
+func TagMap(tags []ec2types.Tag) map[string]string {
+	out := map[string]string{}
+	for _, tag := range tags {
+		key := aws.ToString(tag.Key)
+		if key == "" {
+			continue
+		}
+		out[key] = aws.ToString(tag.Value)
+	}
+	return out
+}
+
+// MaskAccountIDs hides account ids in ARNs and policy documents when
+// aws.redact_account_id is set, matching aws.sts.whoami.
+func MaskAccountIDs(ctx mcp.ToolContext, value string) string {
+	if ctx.Config == nil || !ctx.Config.AWS.RedactAccountID {
+		return value
+	}
+	return accountIDPattern.ReplaceAllString(value, "[REDACTED]")
+}
+
+// NormalizeTimes rewrites the time.Time and *time.Time values of a summary,
+// including nested maps and lists of maps, as RFC3339 UTC strings and drops
+// nil or zero times, so every summary reports timestamps the same way and an
+// unset time never shows up as 0001-01-01. The summary is updated in place.
+func NormalizeTimes(summary map[string]any) map[string]any {
+	for key, value := range summary {
+		switch v := value.(type) {
+		case time.Time:
+			if v.IsZero() {
+				delete(summary, key)
+			} else {
+				summary[key] = v.UTC().Format(time.RFC3339)
+			}
+		case *time.Time:
+			if v == nil || v.IsZero() {
+				delete(summary, key)
+			} else {
+				summary[key] = v.UTC().Format(time.RFC3339)
+			}
+		case map[string]any:
+			NormalizeTimes(v)
+		case []map[string]any:
+			for _, item := range v {
+				NormalizeTimes(item)
+			}
+		case []any:
+			for _, item := range v {
+				if m, ok := item.(map[string]any); ok {
+					NormalizeTimes(m)
+				}
+			}
+		}
+	}
+	return summary
+}
+
+// KMSKeySummary summarizes KMS key metadata for aws.kms.describe_key and the
+// EKS secrets encryption check. A key that is disabled or pending deletion
+// gets a warning, since either one breaks every decrypt that depends on it.
+func KMSKeySummary(meta *kmstypes.KeyMetadata) map[string]any {
+	if meta == nil {
+		return nil
+	}
+	out := map[string]any{
+		"keyId":        aws.ToString(meta.KeyId),
+		"arn":          aws.ToString(meta.Arn),
+		"awsAccountId": aws.ToString(meta.AWSAccountId),
+		"description":  aws.ToString(meta.Description),
+		"keyState":     string(meta.KeyState),
+		"keyUsage":     string(meta.KeyUsage),
+		"keySpec":      string(meta.KeySpec),
+		"keyManager":   string(meta.KeyManager),
+		"origin":       string(meta.Origin),
+		"multiRegion":  meta.MultiRegion,
+		"creationDate": aws.ToTime(meta.CreationDate),
+		"enabled":      meta.Enabled,
+	}
+	if meta.DeletionDate != nil {
+		out["deletionDate"] = aws.ToTime(meta.DeletionDate)
+	}
+	if meta.PendingDeletionWindowInDays != nil {
+		out["pendingDeletionWindowInDays"] = aws.ToInt32(meta.PendingDeletionWindowInDays)
+	}
+	switch meta.KeyState {
+	case kmstypes.KeyStatePendingDeletion, kmstypes.KeyStatePendingReplicaDeletion:
+		warning := "key is pending deletion; cancel the deletion to use it again"
+		if meta.DeletionDate != nil {
+			warning = fmt.Sprintf("key is pending deletion on %s; cancel the deletion to use it again", meta.DeletionDate.UTC().Format("2006-01-02"))
+		}
+		out["warning"] = warning
+	case kmstypes.KeyStateDisabled:
+		out["warning"] = "key is disabled; encrypt and decrypt calls fail until it is enabled"
+	case kmstypes.KeyStateUnavailable:
+		out["warning"] = "key is unavailable; its custom key store is disconnected"
+	}
+	return out
+}
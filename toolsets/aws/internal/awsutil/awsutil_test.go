@@ -0,0 +1,176 @@
+package awsutil
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+func TestToString(t *testing.T) {
+	cases := []struct {
+		in   any
+		want string
+	}{
+		{nil, ""},
+		{"x", "x"},
+		{5, "5"},
+		{true, "true"},
+		{json.Number("12"), "12"},
+	}
+	for _, tc := range cases {
+		if got := ToString(tc.in); got != tc.want {
+			t.Fatalf("ToString(%#v) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestToStringSlice(t *testing.T) {
+	if got := ToStringSlice([]any{"a", " ", "b"}); len(got) != 2 {
+		t.Fatalf("expected blank entries dropped, got %#v", got)
+	}
+	if got := ToStringSlice([]any{"a", 1}); len(got) != 1 || got[0] != "a" {
+		t.Fatalf("expected non-strings dropped, got %#v", got)
+	}
+	if got := ToStringSlice("x"); len(got) != 1 || got[0] != "x" {
+		t.Fatalf("expected single string wrapped, got %#v", got)
+	}
+	if got := ToStringSlice("  "); got != nil {
+		t.Fatalf("expected nil for blank string, got %#v", got)
+	}
+	if got := ToStringSlice([]string{"a", ""}); len(got) != 2 {
+		t.Fatalf("expected []string passed through, got %#v", got)
+	}
+	if got := ToStringSlice(42); got != nil {
+		t.Fatalf("expected nil for unsupported type, got %#v", got)
+	}
+}
+
+func TestToBool(t *testing.T) {
+	if !ToBool(nil, true) {
+		t.Fatalf("expected fallback for nil")
+	}
+	if ToBool(false, true) {
+		t.Fatalf("expected explicit false")
+	}
+	if !ToBool("false", true) {
+		t.Fatalf("expected fallback for string")
+	}
+}
+
+func TestRegionOrDefault(t *testing.T) {
+	if got := RegionOrDefault(""); got != FallbackRegion {
+		t.Fatalf("expected fallback region, got %q", got)
+	}
+	if got := RegionOrDefault("  "); got != FallbackRegion {
+		t.Fatalf("expected fallback region for blank, got %q", got)
+	}
+	if got := RegionOrDefault("eu-west-1"); got != "eu-west-1" {
+		t.Fatalf("unexpected region %q", got)
+	}
+	SetDefaultRegion("ap-southeast-2")
+	defer SetDefaultRegion("")
+	if got := RegionOrDefault(""); got != "ap-southeast-2" {
+		t.Fatalf("expected resolved default region, got %q", got)
+	}
+	if got := RegionOrDefault("eu-west-1"); got != "eu-west-1" {
+		t.Fatalf("explicit region should win, got %q", got)
+	}
+}
+
+func TestErrorResult(t *testing.T) {
+	data, ok := ErrorResult(errors.New("boom")).Data.(map[string]any)
+	if !ok || data["error"] != "boom" {
+		t.Fatalf("unexpected error result: %#v", data)
+	}
+}
+
+func TestTagMap(t *testing.T) {
+	tags := []ec2types.Tag{
+		{Key: aws.String("team"), Value: aws.String("ops")},
+		{Key: aws.String(""), Value: aws.String("ignored")},
+		{Key: aws.String("empty")},
+	}
+	mapped := TagMap(tags)
+	if mapped["team"] != "ops" || len(mapped) != 2 {
+		t.Fatalf("unexpected tag map: %#v", mapped)
+	}
+	if value, ok := mapped["empty"]; !ok || value != "" {
+		t.Fatalf("expected nil value kept as empty string: %#v", mapped)
+	}
+	if got := TagMap(nil); got == nil || len(got) != 0 {
+		t.Fatalf("expected empty non-nil map, got %#v", got)
+	}
+}
+
+func TestNormalizeTimes(t *testing.T) {
+	launched := time.Date(2024, 3, 1, 12, 30, 0, 0, time.FixedZone("PST", -8*3600))
+	var unset *time.Time
+	summary := NormalizeTimes(map[string]any{
+		"id":         "i-1",
+		"launchTime": &launched,
+		"createTime": unset,
+		"startTime":  time.Time{},
+		"attachments": []map[string]any{
+			{"attachTime": launched},
+		},
+		"spotRequest": map[string]any{"createTime": aws.Time(launched)},
+	})
+	if summary["launchTime"] != "2024-03-01T20:30:00Z" {
+		t.Fatalf("expected RFC3339 UTC launchTime, got %#v", summary["launchTime"])
+	}
+	for _, key := range []string{"createTime", "startTime"} {
+		if _, ok := summary[key]; ok {
+			t.Fatalf("expected unset %s to be dropped: %#v", key, summary)
+		}
+	}
+	if got := summary["attachments"].([]map[string]any)[0]["attachTime"]; got != "2024-03-01T20:30:00Z" {
+		t.Fatalf("expected nested attachTime to be normalized, got %#v", got)
+	}
+	if got := summary["spotRequest"].(map[string]any)["createTime"]; got != "2024-03-01T20:30:00Z" {
+		t.Fatalf("expected nested createTime to be normalized, got %#v", got)
+	}
+}
+
+func TestWithAge(t *testing.T) {
+	created := time.Now().Add(-49 * time.Hour)
+	summary := WithAge(map[string]any{"id": "vol-1"}, &created)
+	if summary["age"] != "2d1h" {
+		t.Fatalf("expected age, got %#v", summary)
+	}
+	if summary := WithAge(map[string]any{}, nil); len(summary) != 0 {
+		t.Fatalf("expected no age for a nil time, got %#v", summary)
+	}
+	SetOmitAge(true)
+	defer SetOmitAge(false)
+	if summary := WithAge(map[string]any{}, &created); len(summary) != 0 {
+		t.Fatalf("expected render.omit_age to drop age, got %#v", summary)
+	}
+}
+
+func TestKMSKeySummary(t *testing.T) {
+	if KMSKeySummary(nil) != nil {
+		t.Fatalf("expected nil summary for nil metadata")
+	}
+	deletion := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	summary := KMSKeySummary(&kmstypes.KeyMetadata{
+		KeyId:                       aws.String("key-1"),
+		KeyState:                    kmstypes.KeyStatePendingDeletion,
+		DeletionDate:                &deletion,
+		PendingDeletionWindowInDays: aws.Int32(7),
+	})
+	if summary["deletionDate"] != deletion || summary["pendingDeletionWindowInDays"] != int32(7) {
+		t.Fatalf("expected deletion schedule: %#v", summary)
+	}
+	if warning, _ := summary["warning"].(string); !strings.Contains(warning, "2026-01-02") {
+		t.Fatalf("expected pending deletion warning: %#v", summary)
+	}
+	if _, ok := KMSKeySummary(&kmstypes.KeyMetadata{KeyState: kmstypes.KeyStateEnabled})["warning"]; ok {
+		t.Fatalf("expected no warning for enabled key")
+	}
+}
@@ -0,0 +1,111 @@
+package awskms
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+func (s *Service) handleListGrants(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	keyID := strings.TrimSpace(awsutil.ToString(req.Arguments["keyId"]))
+	if keyID == "" {
+		return awsutil.ErrorResult(errors.New("keyId is required")), errors.New("keyId is required")
+	}
+	principal := strings.TrimSpace(awsutil.ToString(req.Arguments["principal"]))
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.kmsClient(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input := &kms.ListGrantsInput{KeyId: aws.String(keyID)}
+	grants := []map[string]any{}
+	var matching []string
+	for {
+		out, err := client.ListGrants(ctx, input)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		for _, grant := range out.Grants {
+			grants = append(grants, s.summarizeGrant(grant))
+			if principal != "" && grantCoversPrincipal(grant, principal) {
+				matching = append(matching, aws.ToString(grant.GrantId))
+			}
+		}
+		if !out.Truncated || aws.ToString(out.NextMarker) == "" {
+			break
+		}
+		input.Marker = out.NextMarker
+	}
+	data := map[string]any{
+		"region": usedRegion,
+		"keyId":  keyID,
+		"grants": grants,
+		"count":  len(grants),
+	}
+	if principal != "" {
+		data["principal"] = awsutil.MaskAccountIDs(s.ctx, principal)
+		data["principalHasGrant"] = len(matching) > 0
+		data["matchingGrantIds"] = matching
+		if len(matching) == 0 {
+			data["note"] = "No grant names this principal; access must come from the key policy or an IAM policy the key policy allows."
+		}
+	}
+	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+}
+
+func (s *Service) summarizeGrant(grant kmstypes.GrantListEntry) map[string]any {
+	operations := make([]string, 0, len(grant.Operations))
+	for _, op := range grant.Operations {
+		operations = append(operations, string(op))
+	}
+	out := map[string]any{
+		"grantId":           aws.ToString(grant.GrantId),
+		"name":              aws.ToString(grant.Name),
+		"granteePrincipal":  awsutil.MaskAccountIDs(s.ctx, aws.ToString(grant.GranteePrincipal)),
+		"retiringPrincipal": awsutil.MaskAccountIDs(s.ctx, aws.ToString(grant.RetiringPrincipal)),
+		"issuingAccount":    awsutil.MaskAccountIDs(s.ctx, aws.ToString(grant.IssuingAccount)),
+		"operations":        operations,
+		"creationDate":      aws.ToTime(grant.CreationDate),
+	}
+	if grant.Constraints != nil {
+		if len(grant.Constraints.EncryptionContextEquals) > 0 {
+			out["encryptionContextEquals"] = grant.Constraints.EncryptionContextEquals
+		}
+		if len(grant.Constraints.EncryptionContextSubset) > 0 {
+			out["encryptionContextSubset"] = grant.Constraints.EncryptionContextSubset
+		}
+	}
+	return out
+}
+
+// grantCoversPrincipal reports whether a grant names principal. An
+// assumed-role session ARN is matched against the role it was assumed from,
+// since grants name the role.
+func grantCoversPrincipal(grant kmstypes.GrantListEntry, principal string) bool {
+	grantee := aws.ToString(grant.GranteePrincipal)
+	if grantee == "" {
+		return false
+	}
+	return grantee == principal || grantee == roleARNFromSession(principal)
+}
+
+// roleARNFromSession converts arn:aws:sts::<account>:assumed-role/<role>/<session>
+// into arn:aws:iam::<account>:role/<role>. Other ARNs are returned unchanged.
+func roleARNFromSession(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 || parts[2] != "sts" || !strings.HasPrefix(parts[5], "assumed-role/") {
+		return arn
+	}
+	resource := strings.Split(parts[5], "/")
+	if len(resource) < 2 {
+		return arn
+	}
+	return strings.Join([]string{parts[0], parts[1], "iam", "", parts[4], "role/" + resource[1]}, ":")
+}
@@ -0,0 +1,82 @@
+package awskms
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+
+	"rootcause/internal/config"
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestHandleListGrants(t *testing.T) {
+	client := newKMSTestClient(t, map[string]string{
+		"TrentService.ListGrants": `{"Grants":[{"GrantId":"g1","Name":"ebs","GranteePrincipal":"arn:aws:iam::123456789012:role/node","Operations":["Decrypt","CreateGrant"]},{"GrantId":"g2","GranteePrincipal":"arn:aws:iam::123456789012:role/other","Operations":["Encrypt"]}],"Truncated":false}`,
+	})
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		kmsClient: func(context.Context, string) (*kms.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleListGrants(context.Background(), mcp.ToolRequest{Arguments: map[string]any{
+		"keyId":     "key-1",
+		"principal": "arn:aws:sts::123456789012:assumed-role/node/i-0abc",
+	}})
+	if err != nil {
+		t.Fatalf("list grants: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if data["count"] != 2 || data["principalHasGrant"] != true {
+		t.Fatalf("unexpected result: %#v", data)
+	}
+	if ids := data["matchingGrantIds"].([]string); len(ids) != 1 || ids[0] != "g1" {
+		t.Fatalf("unexpected matching grants: %#v", ids)
+	}
+
+	svc.ctx.Config = &config.Config{AWS: config.AWSConfig{RedactAccountID: true}}
+	result, err = svc.handleListGrants(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"keyId": "key-1", "principal": "arn:aws:iam::123456789012:role/missing"}})
+	if err != nil {
+		t.Fatalf("list grants: %v", err)
+	}
+	data = result.Data.(map[string]any)
+	if data["principalHasGrant"] != false || data["note"] == nil {
+		t.Fatalf("expected no matching grant: %#v", data)
+	}
+	grants := data["grants"].([]map[string]any)
+	if strings.Contains(grants[0]["granteePrincipal"].(string), "123456789012") {
+		t.Fatalf("expected account id masked: %#v", grants[0])
+	}
+	if _, err := svc.handleListGrants(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected missing keyId error")
+	}
+}
+
+func TestSummarizePolicyStatements(t *testing.T) {
+	policy := `{"Version":"2012-10-17","Statement":[{"Sid":"root","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"kms:*","Resource":"*"},{"Effect":"Allow","Principal":"*","Action":["kms:Decrypt"],"Condition":{"StringEquals":{"kms:CallerAccount":"123456789012"}}}]}`
+	statements := summarizePolicyStatements(policy)
+	if len(statements) != 2 {
+		t.Fatalf("unexpected statements: %#v", statements)
+	}
+	if principals := statements[0]["principals"].([]string); len(principals) != 1 || principals[0] != "AWS:arn:aws:iam::123456789012:root" {
+		t.Fatalf("unexpected principals: %#v", principals)
+	}
+	if statements[1]["hasCondition"] != true || statements[1]["principals"].([]string)[0] != "*" {
+		t.Fatalf("unexpected second statement: %#v", statements[1])
+	}
+	if summarizePolicyStatements("not json") != nil {
+		t.Fatalf("expected nil for invalid policy")
+	}
+}
+
+func TestRoleARNFromSession(t *testing.T) {
+	if got := roleARNFromSession("arn:aws:sts::123456789012:assumed-role/node/i-0abc"); got != "arn:aws:iam::123456789012:role/node" {
+		t.Fatalf("unexpected role arn %q", got)
+	}
+	if got := roleARNFromSession("arn:aws:iam::123456789012:user/alice"); got != "arn:aws:iam::123456789012:user/alice" {
+		t.Fatalf("expected arn unchanged, got %q", got)
+	}
+}
@@ -4,15 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"math"
+	"sort"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/kms"
 	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
 
+	"rootcause/internal/argconv"
+	awslib "rootcause/internal/aws"
 	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
 )
 
 type Service struct {
@@ -56,15 +59,23 @@ func ToolSpecs(ctx mcp.ToolContext, toolsetID string, kmsClient func(context.Con
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     svc.handleGetKeyPolicy,
 		},
+		{
+			Name:        "aws.kms.list_grants",
+			Description: "List grants on a KMS key, optionally checking whether a principal holds one.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaKMSListGrants(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleListGrants,
+		},
 	}
 }
 
 func (s *Service) handleListKeys(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.kmsClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &kms.ListKeysInput{}
 	if limit > 0 {
@@ -73,36 +84,45 @@ func (s *Service) handleListKeys(ctx context.Context, req mcp.ToolRequest) (mcp.
 		}
 		input.Limit = aws.Int32(int32(limit)) //nolint:gosec // bounded above by MaxInt32 clamp
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.Marker = pager.StartToken()
 	paginator := kms.NewListKeysPaginator(client, input)
+	pageToken := aws.ToString(input.Marker)
 	var keys []map[string]any
 	for paginator.HasMorePages() {
 		out, err := paginator.NextPage(ctx)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, key := range out.Keys {
+		start, end, more := pager.Page(pageToken, len(out.Keys), out.NextMarker)
+		for _, key := range out.Keys[start:end] {
 			keys = append(keys, summarizeKeyListEntry(key))
-			if limit > 0 && len(keys) >= limit {
-				keys = keys[:limit]
-				return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(map[string]any{
-					"region": usedRegion,
-					"keys":   keys,
-				})}, nil
-			}
 		}
+		if !more {
+			break
+		}
+		pageToken = aws.ToString(out.NextMarker)
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(map[string]any{
+	data := map[string]any{
 		"region": usedRegion,
 		"keys":   keys,
-	})}, nil
+	}
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleListAliases(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.kmsClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &kms.ListAliasesInput{}
 	if limit > 0 {
@@ -111,138 +131,170 @@ func (s *Service) handleListAliases(ctx context.Context, req mcp.ToolRequest) (m
 		}
 		input.Limit = aws.Int32(int32(limit)) //nolint:gosec // bounded above by MaxInt32 clamp
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.Marker = pager.StartToken()
 	paginator := kms.NewListAliasesPaginator(client, input)
+	pageToken := aws.ToString(input.Marker)
 	var aliases []map[string]any
 	for paginator.HasMorePages() {
 		out, err := paginator.NextPage(ctx)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, alias := range out.Aliases {
+		start, end, more := pager.Page(pageToken, len(out.Aliases), out.NextMarker)
+		for _, alias := range out.Aliases[start:end] {
 			aliases = append(aliases, summarizeAlias(alias))
-			if limit > 0 && len(aliases) >= limit {
-				aliases = aliases[:limit]
-				return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(map[string]any{
-					"region":  usedRegion,
-					"aliases": aliases,
-				})}, nil
-			}
 		}
+		if !more {
+			break
+		}
+		pageToken = aws.ToString(out.NextMarker)
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(map[string]any{
+	data := map[string]any{
 		"region":  usedRegion,
 		"aliases": aliases,
-	})}, nil
+	}
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleDescribeKey(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	keyID := strings.TrimSpace(toString(req.Arguments["keyId"]))
+	keyID := strings.TrimSpace(awsutil.ToString(req.Arguments["keyId"]))
 	if keyID == "" {
-		return errorResult(errors.New("keyId is required")), errors.New("keyId is required")
+		return awsutil.ErrorResult(errors.New("keyId is required")), errors.New("keyId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.kmsClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeKey(ctx, &kms.DescribeKeyInput{KeyId: aws.String(keyID)})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(map[string]any{
 		"region": usedRegion,
-		"key":    summarizeKeyMetadata(out.KeyMetadata),
+		"key":    awsutil.KMSKeySummary(out.KeyMetadata),
 	})}, nil
 }
 
 func (s *Service) handleGetKeyPolicy(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	keyID := strings.TrimSpace(toString(req.Arguments["keyId"]))
+	keyID := strings.TrimSpace(awsutil.ToString(req.Arguments["keyId"]))
 	if keyID == "" {
-		return errorResult(errors.New("keyId is required")), errors.New("keyId is required")
+		return awsutil.ErrorResult(errors.New("keyId is required")), errors.New("keyId is required")
 	}
-	policyName := strings.TrimSpace(toString(req.Arguments["policyName"]))
+	policyName := strings.TrimSpace(awsutil.ToString(req.Arguments["policyName"]))
 	if policyName == "" {
 		policyName = "default"
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.kmsClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.GetKeyPolicy(ctx, &kms.GetKeyPolicyInput{
 		KeyId:      aws.String(keyID),
 		PolicyName: aws.String(policyName),
 	})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(map[string]any{
+	policy := awsutil.MaskAccountIDs(s.ctx, aws.ToString(out.Policy))
+	data := map[string]any{
 		"region":     usedRegion,
 		"keyId":      keyID,
 		"policyName": policyName,
-		"policy":     aws.ToString(out.Policy),
-	})}, nil
-}
-
-func summarizeKeyListEntry(entry kmstypes.KeyListEntry) map[string]any {
-	return map[string]any{
-		"keyId":  aws.ToString(entry.KeyId),
-		"keyArn": aws.ToString(entry.KeyArn),
+		"policy":     policy,
 	}
-}
-
-func summarizeAlias(alias kmstypes.AliasListEntry) map[string]any {
-	return map[string]any{
-		"aliasName":   aws.ToString(alias.AliasName),
-		"aliasArn":    aws.ToString(alias.AliasArn),
-		"targetKeyId": aws.ToString(alias.TargetKeyId),
+	if statements := summarizePolicyStatements(policy); statements != nil {
+		data["statements"] = statements
 	}
+	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
 }
 
-func summarizeKeyMetadata(meta *kmstypes.KeyMetadata) map[string]any {
-	if meta == nil {
+// summarizePolicyStatements flattens a key policy into one entry per
+// statement so the principals allowed each action can be read at a glance.
+// It returns nil when the document does not parse.
+func summarizePolicyStatements(document string) []map[string]any {
+	var doc struct {
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(document), &doc); err != nil || len(doc.Statement) == 0 {
 		return nil
 	}
-	return map[string]any{
-		"keyId":        aws.ToString(meta.KeyId),
-		"arn":          aws.ToString(meta.Arn),
-		"awsAccountId": aws.ToString(meta.AWSAccountId),
-		"description":  aws.ToString(meta.Description),
-		"keyState":     string(meta.KeyState),
-		"keyUsage":     string(meta.KeyUsage),
-		"origin":       string(meta.Origin),
-		"multiRegion":  meta.MultiRegion,
-		"creationDate": aws.ToTime(meta.CreationDate),
-		"enabled":      meta.Enabled,
+	var raw []map[string]any
+	if err := json.Unmarshal(doc.Statement, &raw); err != nil {
+		var single map[string]any
+		if err := json.Unmarshal(doc.Statement, &single); err != nil {
+			return nil
+		}
+		raw = []map[string]any{single}
 	}
+	statements := make([]map[string]any, 0, len(raw))
+	for _, stmt := range raw {
+		entry := map[string]any{
+			"sid":        awsutil.ToString(stmt["Sid"]),
+			"effect":     awsutil.ToString(stmt["Effect"]),
+			"principals": policyPrincipals(stmt["Principal"]),
+			"actions":    policyStrings(stmt["Action"]),
+		}
+		if _, ok := stmt["Condition"]; ok {
+			entry["hasCondition"] = true
+		}
+		statements = append(statements, entry)
+	}
+	return statements
 }
 
-func errorResult(err error) mcp.ToolResult {
-	return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}
-}
-
-func toString(value any) string {
-	if value == nil {
-		return ""
+// policyPrincipals flattens a Principal element, which is either "*" or a
+// map of principal type to one or more identifiers.
+func policyPrincipals(value any) []string {
+	principal, ok := value.(map[string]any)
+	if !ok {
+		return policyStrings(value)
 	}
-	if s, ok := value.(string); ok {
-		return s
+	var out []string
+	for kind, ids := range principal {
+		for _, id := range policyStrings(ids) {
+			out = append(out, kind+":"+id)
+		}
 	}
-	return fmt.Sprintf("%v", value)
+	sort.Strings(out)
+	return out
 }
 
-func toInt(value any, fallback int) int {
+func policyStrings(value any) []string {
 	switch v := value.(type) {
-	case int:
-		return v
-	case int64:
-		return int(v)
-	case float64:
-		return int(v)
-	case json.Number:
-		if parsed, err := v.Int64(); err == nil {
-			return int(parsed)
+	case string:
+		return []string{v}
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			out = append(out, awsutil.ToString(item))
 		}
+		return out
+	default:
+		return nil
+	}
+}
+
+func summarizeKeyListEntry(entry kmstypes.KeyListEntry) map[string]any {
+	return map[string]any{
+		"keyId":  aws.ToString(entry.KeyId),
+		"keyArn": aws.ToString(entry.KeyArn),
+	}
+}
+
+func summarizeAlias(alias kmstypes.AliasListEntry) map[string]any {
+	return map[string]any{
+		"aliasName":   aws.ToString(alias.AliasName),
+		"aliasArn":    aws.ToString(alias.AliasArn),
+		"targetKeyId": aws.ToString(alias.TargetKeyId),
 	}
-	return fallback
 }
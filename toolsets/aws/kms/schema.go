@@ -4,8 +4,9 @@ func schemaKMSListKeys() map[string]any {
 	return map[string]any{
 		"type": "object",
 		"properties": map[string]any{
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -14,8 +15,9 @@ func schemaKMSListAliases() map[string]any {
 	return map[string]any{
 		"type": "object",
 		"properties": map[string]any{
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -42,3 +44,15 @@ func schemaKMSGetKeyPolicy() map[string]any {
 		"required": []string{"keyId"},
 	}
 }
+
+func schemaKMSListGrants() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"keyId":     map[string]any{"type": "string"},
+			"principal": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
+		},
+		"required": []string{"keyId"},
+	}
+}
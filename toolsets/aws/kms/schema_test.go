@@ -12,6 +12,7 @@ func TestKMSSchemas(t *testing.T) {
 		schemaKMSListAliases(),
 		schemaKMSDescribeKey(),
 		schemaKMSGetKeyPolicy(),
+		schemaKMSListGrants(),
 	}
 	for i, schema := range schemas {
 		if schema == nil || schema["type"] == "" {
@@ -0,0 +1,226 @@
+package awsroute53
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+const route53NS = `xmlns="https://route53.amazonaws.com/doc/2013-04-01/"`
+
+func TestRoute53HandlerValidation(t *testing.T) {
+	called := false
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		route53Client: func(context.Context, string) (*route53.Client, string, error) {
+			called = true
+			return nil, "", nil
+		},
+	}
+	if _, err := svc.handleGetHostedZone(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil || !strings.Contains(err.Error(), "hostedZoneId is required") {
+		t.Fatalf("expected hostedZoneId error, got %v", err)
+	}
+	if _, err := svc.handleListResourceRecordSets(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"hostedZoneId": "/hostedzone/"}}); err == nil {
+		t.Fatalf("expected hostedZoneId error for bare prefix")
+	}
+	if _, err := svc.handleListHostedZones(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"zoneType": "internal"}}); err == nil {
+		t.Fatalf("expected zoneType error")
+	}
+	if called {
+		t.Fatalf("client should not be invoked")
+	}
+}
+
+func TestRoute53HostedZones(t *testing.T) {
+	transport := &route53RoundTripper{responses: map[string]string{
+		"/2013-04-01/hostedzone": `<ListHostedZonesResponse ` + route53NS + `>
+  <HostedZones>
+    <HostedZone><Id>/hostedzone/Z1</Id><Name>example.com.</Name><CallerReference>a</CallerReference><Config><PrivateZone>false</PrivateZone></Config><ResourceRecordSetCount>3</ResourceRecordSetCount></HostedZone>
+    <HostedZone><Id>/hostedzone/Z2</Id><Name>internal.example.com.</Name><CallerReference>b</CallerReference><Config><Comment>vpc</Comment><PrivateZone>true</PrivateZone></Config><ResourceRecordSetCount>2</ResourceRecordSetCount></HostedZone>
+  </HostedZones>
+  <IsTruncated>false</IsTruncated>
+  <MaxItems>100</MaxItems>
+</ListHostedZonesResponse>`,
+		"/2013-04-01/hostedzone/Z2": `<GetHostedZoneResponse ` + route53NS + `>
+  <HostedZone><Id>/hostedzone/Z2</Id><Name>internal.example.com.</Name><CallerReference>b</CallerReference><Config><PrivateZone>true</PrivateZone></Config></HostedZone>
+  <DelegationSet><NameServers><NameServer>ns-1.awsdns.com</NameServer></NameServers></DelegationSet>
+  <VPCs><VPC><VPCRegion>us-east-1</VPCRegion><VPCId>vpc-1</VPCId></VPC></VPCs>
+</GetHostedZoneResponse>`,
+	}}
+	svc := newRoute53TestService(transport)
+
+	result, err := svc.handleListHostedZones(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("list hosted zones: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if data["count"] != 2 {
+		t.Fatalf("expected two zones, got %#v", data)
+	}
+	result, err = svc.handleListHostedZones(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"zoneType": "public"}})
+	if err != nil {
+		t.Fatalf("list public hosted zones: %v", err)
+	}
+	zones := result.Data.(map[string]any)["hostedZones"].([]map[string]any)
+	if len(zones) != 1 || zones[0]["id"] != "Z1" || zones[0]["private"] != false {
+		t.Fatalf("expected only the public zone, got %#v", zones)
+	}
+	if _, err := svc.handleListHostedZones(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"zoneType": "private"}}); err != nil {
+		t.Fatalf("list private hosted zones: %v", err)
+	}
+	if got := transport.lastQuery.Get("hostedzonetype"); got != "PrivateHostedZone" {
+		t.Fatalf("expected private zone type sent to the API, got %q", got)
+	}
+
+	result, err = svc.handleGetHostedZone(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"hostedZoneId": "/hostedzone/Z2"}})
+	if err != nil {
+		t.Fatalf("get hosted zone: %v", err)
+	}
+	data = result.Data.(map[string]any)
+	if vpcs := data["vpcs"].([]map[string]any); len(vpcs) != 1 || vpcs[0]["vpcId"] != "vpc-1" {
+		t.Fatalf("expected associated vpc, got %#v", data)
+	}
+	if len(result.Metadata.Resources) != 1 || result.Metadata.Resources[0] != "route53/hostedzone/Z2" {
+		t.Fatalf("unexpected metadata: %#v", result.Metadata)
+	}
+}
+
+func TestRoute53ResourceRecordSets(t *testing.T) {
+	firstPage := `<ListResourceRecordSetsResponse ` + route53NS + `>
+  <ResourceRecordSets>
+    <ResourceRecordSet><Name>api.example.com.</Name><Type>A</Type><AliasTarget><HostedZoneId>Z35SXDOTRQ7X7K</HostedZoneId><DNSName>dualstack.alb-1.us-east-1.elb.amazonaws.com.</DNSName><EvaluateTargetHealth>true</EvaluateTargetHealth></AliasTarget></ResourceRecordSet>
+    <ResourceRecordSet><Name>api.example.com.</Name><Type>TXT</Type><TTL>300</TTL><ResourceRecords><ResourceRecord><Value>"v=1"</Value></ResourceRecord></ResourceRecords></ResourceRecordSet>
+  </ResourceRecordSets>
+  <IsTruncated>true</IsTruncated>
+  <NextRecordName>www.example.com.</NextRecordName>
+  <NextRecordType>CNAME</NextRecordType>
+  <MaxItems>2</MaxItems>
+</ListResourceRecordSetsResponse>`
+	transport := &route53RoundTripper{responses: map[string]string{
+		"/2013-04-01/hostedzone/Z1/rrset":                       firstPage,
+		"/2013-04-01/hostedzone/Z1/rrset?name=api.example.com.": firstPage,
+		"/2013-04-01/hostedzone/Z1/rrset?name=www.example.com.": `<ListResourceRecordSetsResponse ` + route53NS + `>
+  <ResourceRecordSets>
+    <ResourceRecordSet><Name>www.example.com.</Name><Type>CNAME</Type><TTL>60</TTL><ResourceRecords><ResourceRecord><Value>api.example.com</Value></ResourceRecord></ResourceRecords></ResourceRecordSet>
+  </ResourceRecordSets>
+  <IsTruncated>false</IsTruncated>
+  <MaxItems>2</MaxItems>
+</ListResourceRecordSetsResponse>`,
+	}}
+	svc := newRoute53TestService(transport)
+
+	result, err := svc.handleListResourceRecordSets(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"hostedZoneId": "Z1"}})
+	if err != nil {
+		t.Fatalf("list records: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if data["count"] != 3 || data["nextToken"] != nil {
+		t.Fatalf("expected every record across pages, got %#v", data)
+	}
+	alias := data["records"].([]map[string]any)[0]["aliasTarget"].(map[string]any)
+	if alias["dnsName"] != "dualstack.alb-1.us-east-1.elb.amazonaws.com." {
+		t.Fatalf("expected alias target, got %#v", alias)
+	}
+
+	result, err = svc.handleListResourceRecordSets(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"hostedZoneId": "Z1", "name": "API.example.com", "type": "a"}})
+	if err != nil {
+		t.Fatalf("list records by name: %v", err)
+	}
+	records := result.Data.(map[string]any)["records"].([]map[string]any)
+	if len(records) != 1 || records[0]["type"] != r53types.RRTypeA {
+		t.Fatalf("expected only the api A record, got %#v", records)
+	}
+
+	args := map[string]any{"hostedZoneId": "Z1", "limit": 1}
+	first, err := svc.handleListResourceRecordSets(context.Background(), mcp.ToolRequest{Arguments: args})
+	if err != nil {
+		t.Fatalf("list records (limit): %v", err)
+	}
+	next, _ := first.Data.(map[string]any)["nextToken"].(string)
+	if next == "" {
+		t.Fatalf("expected nextToken, got %#v", first.Data)
+	}
+	args["nextToken"] = next
+	args["limit"] = 5
+	second, err := svc.handleListResourceRecordSets(context.Background(), mcp.ToolRequest{Arguments: args})
+	if err != nil {
+		t.Fatalf("list records (next page): %v", err)
+	}
+	records = second.Data.(map[string]any)["records"].([]map[string]any)
+	if len(records) != 2 || records[0]["type"] != r53types.RRTypeTxt || records[1]["name"] != "www.example.com." {
+		t.Fatalf("expected the rest of the zone, got %#v", records)
+	}
+}
+
+func TestNormalizeRecordName(t *testing.T) {
+	if got := normalizeRecordName(" API.Example.com "); got != "api.example.com." {
+		t.Fatalf("unexpected name %q", got)
+	}
+	if got := normalizeZoneID("/hostedzone/Z1"); got != "Z1" {
+		t.Fatalf("unexpected zone id %q", got)
+	}
+	if _, err := decodeRecordStart("not-json"); err == nil {
+		t.Fatalf("expected invalid token error")
+	}
+}
+
+func newRoute53TestService(transport http.RoundTripper) *Service {
+	cfg := aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		HTTPClient:  &http.Client{Transport: transport},
+	}
+	cfg.EndpointResolverWithOptions = aws.EndpointResolverWithOptionsFunc(
+		func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+			return aws.Endpoint{URL: "https://route53.test", SigningRegion: region, HostnameImmutable: true}, nil
+		},
+	)
+	client := route53.NewFromConfig(cfg)
+	return &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		route53Client: func(context.Context, string) (*route53.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+}
+
+// route53RoundTripper serves REST-XML responses keyed by path, plus the
+// name query parameter for record listings.
+type route53RoundTripper struct {
+	responses map[string]string
+	lastQuery url.Values
+}
+
+func (rt *route53RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.lastQuery = req.URL.Query()
+	key := req.URL.Path
+	if name := rt.lastQuery.Get("name"); name != "" {
+		key += "?name=" + name
+	}
+	resp, ok := rt.responses[key]
+	if !ok {
+		return &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       io.NopCloser(strings.NewReader("unknown path " + key)),
+			Header:     http.Header{"Content-Type": []string{"text/plain"}},
+			Request:    req,
+		}, nil
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(strings.TrimSpace(resp))),
+		Header:     http.Header{"Content-Type": []string{"text/xml"}},
+		Request:    req,
+	}, nil
+}
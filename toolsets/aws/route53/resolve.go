@@ -0,0 +1,305 @@
+package awsroute53
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+// elbDNSPattern matches ALB/Classic (name.region.elb.amazonaws.com) and NLB
+// (name.elb.region.amazonaws.com) DNS names and captures the region.
+var elbDNSPattern = regexp.MustCompile(`(?:\.([a-z]{2}(?:-[a-z]+)+-\d)\.elb\.amazonaws\.com|\.elb\.([a-z]{2}(?:-[a-z]+)+-\d)\.amazonaws\.com)$`)
+
+var addressRecordTypes = []string{"A", "AAAA", "CNAME"}
+
+// recordResolver carries per-request state: load balancer DNS names are
+// listed once per region and reused for every record that points at an ELB.
+type recordResolver struct {
+	svc      *Service
+	client   *route53.Client
+	elbNames map[string]map[string]bool
+}
+
+func (s *Service) handleResolveRecord(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	name := normalizeRecordName(awsutil.ToString(req.Arguments["name"]))
+	if name == "" {
+		return awsutil.ErrorResult(errors.New("name is required")), errors.New("name is required")
+	}
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
+	region := awsutil.ToString(req.Arguments["region"])
+	types := addressRecordTypes
+	if recordType := strings.ToUpper(strings.TrimSpace(awsutil.ToString(req.Arguments["type"]))); recordType != "" {
+		types = []string{recordType}
+	}
+	client, usedRegion, err := s.route53Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	zones, err := matchingHostedZones(ctx, client, name)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	if vpcID != "" {
+		zones, err = filterPrivateZonesByVPC(ctx, client, zones, vpcID)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+	}
+	resolver := &recordResolver{svc: s, client: client, elbNames: map[string]map[string]bool{}}
+	var issues []string
+	var results []map[string]any
+	resources := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		zoneID := normalizeZoneID(aws.ToString(zone.Id))
+		resources = append(resources, "route53/hostedzone/"+zoneID)
+		records, err := recordsNamed(ctx, client, zoneID, name, types)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		summaries := make([]map[string]any, 0, len(records))
+		for _, record := range records {
+			summary := summarizeRecordSet(record)
+			recordIssues, err := resolver.checkTarget(ctx, zone, record)
+			if err != nil {
+				return awsutil.ErrorResult(err), err
+			}
+			if len(recordIssues) > 0 {
+				summary["issues"] = recordIssues
+				issues = append(issues, recordIssues...)
+			}
+			summaries = append(summaries, summary)
+		}
+		if len(records) == 0 {
+			issues = append(issues, fmt.Sprintf("no %s record for %s in hosted zone %s (%s)", strings.Join(types, "/"), name, aws.ToString(zone.Name), zoneID))
+		}
+		entry := summarizeHostedZone(zone)
+		entry["records"] = summaries
+		results = append(results, entry)
+	}
+	if len(zones) == 0 {
+		issues = append(issues, fmt.Sprintf("no hosted zone in this account matches %s", name))
+	}
+	data := map[string]any{
+		"region":      awsutil.RegionOrDefault(usedRegion),
+		"name":        name,
+		"types":       types,
+		"hostedZones": results,
+		"issues":      issues,
+	}
+	if splitHorizon(zones) {
+		data["note"] = "both public and private zones match; clients in the associated VPCs get the private answer and everyone else the public one"
+	}
+	return mcp.ToolResult{
+		Data:     s.ctx.Redactor.RedactValue(data),
+		Metadata: mcp.ToolMetadata{Resources: resources},
+	}, nil
+}
+
+// matchingHostedZones returns the most specific public zone and the most
+// specific private zones for name. Several private zones can share a name
+// when each is associated with different VPCs.
+func matchingHostedZones(ctx context.Context, client *route53.Client, name string) ([]r53types.HostedZone, error) {
+	var public, private []r53types.HostedZone
+	paginator := route53.NewListHostedZonesPaginator(client, &route53.ListHostedZonesInput{})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, zone := range out.HostedZones {
+			zoneName := normalizeRecordName(aws.ToString(zone.Name))
+			if name != zoneName && !strings.HasSuffix(name, "."+zoneName) {
+				continue
+			}
+			if zone.Config != nil && zone.Config.PrivateZone {
+				private = keepMostSpecific(private, zone)
+			} else {
+				public = keepMostSpecific(public, zone)
+			}
+		}
+	}
+	return append(public, private...), nil
+}
+
+func keepMostSpecific(zones []r53types.HostedZone, zone r53types.HostedZone) []r53types.HostedZone {
+	if len(zones) == 0 {
+		return []r53types.HostedZone{zone}
+	}
+	current := len(aws.ToString(zones[0].Name))
+	candidate := len(aws.ToString(zone.Name))
+	switch {
+	case candidate > current:
+		return []r53types.HostedZone{zone}
+	case candidate == current:
+		return append(zones, zone)
+	}
+	return zones
+}
+
+func filterPrivateZonesByVPC(ctx context.Context, client *route53.Client, zones []r53types.HostedZone, vpcID string) ([]r53types.HostedZone, error) {
+	var out []r53types.HostedZone
+	for _, zone := range zones {
+		if zone.Config == nil || !zone.Config.PrivateZone {
+			out = append(out, zone)
+			continue
+		}
+		detail, err := client.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: aws.String(normalizeZoneID(aws.ToString(zone.Id)))})
+		if err != nil {
+			return nil, err
+		}
+		for _, vpc := range detail.VPCs {
+			if aws.ToString(vpc.VPCId) == vpcID {
+				out = append(out, zone)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+// recordsNamed returns the record sets with exactly name, starting the
+// listing at name so only the matching slice of the zone is read.
+func recordsNamed(ctx context.Context, client *route53.Client, zoneID, name string, types []string) ([]r53types.ResourceRecordSet, error) {
+	var records []r53types.ResourceRecordSet
+	input := &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(zoneID),
+		StartRecordName: aws.String(name),
+	}
+	for {
+		out, err := client.ListResourceRecordSets(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range out.ResourceRecordSets {
+			if normalizeRecordName(aws.ToString(record.Name)) != name {
+				return records, nil
+			}
+			if containsType(types, string(record.Type)) {
+				records = append(records, record)
+			}
+		}
+		if !out.IsTruncated || out.NextRecordName == nil {
+			return records, nil
+		}
+		input.StartRecordName = out.NextRecordName
+		input.StartRecordType = out.NextRecordType
+		input.StartRecordIdentifier = out.NextRecordIdentifier
+	}
+}
+
+// checkTarget flags an ALIAS or CNAME whose target no longer exists: a load
+// balancer DNS name that no current ALB/NLB owns, or a name inside the same
+// zone with no records.
+func (r *recordResolver) checkTarget(ctx context.Context, zone r53types.HostedZone, record r53types.ResourceRecordSet) ([]string, error) {
+	var target string
+	switch {
+	case record.AliasTarget != nil:
+		target = aws.ToString(record.AliasTarget.DNSName)
+	case record.Type == r53types.RRTypeCname && len(record.ResourceRecords) > 0:
+		target = aws.ToString(record.ResourceRecords[0].Value)
+	default:
+		return nil, nil
+	}
+	target = normalizeRecordName(target)
+	recordLabel := fmt.Sprintf("%s %s", record.Type, aws.ToString(record.Name))
+	if lbRegion, ok := elbRegion(target); ok {
+		if r.svc.elbClient == nil {
+			return nil, nil
+		}
+		exists, err := r.loadBalancerExists(ctx, lbRegion, target)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return []string{fmt.Sprintf("%s points at load balancer %s, which no ALB/NLB in %s owns (deleted, or a Classic ELB)", recordLabel, strings.TrimSuffix(target, "."), lbRegion)}, nil
+		}
+		return nil, nil
+	}
+	zoneName := normalizeRecordName(aws.ToString(zone.Name))
+	if target == zoneName || strings.HasSuffix(target, "."+zoneName) {
+		zoneID := normalizeZoneID(aws.ToString(zone.Id))
+		targets, err := recordsNamed(ctx, r.client, zoneID, target, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(targets) == 0 {
+			return []string{fmt.Sprintf("%s points at %s, which has no records in zone %s", recordLabel, target, zoneName)}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *recordResolver) loadBalancerExists(ctx context.Context, region, target string) (bool, error) {
+	names, ok := r.elbNames[region]
+	if !ok {
+		client, _, err := r.svc.elbClient(ctx, region)
+		if err != nil {
+			return false, err
+		}
+		names = map[string]bool{}
+		paginator := elasticloadbalancingv2.NewDescribeLoadBalancersPaginator(client, &elasticloadbalancingv2.DescribeLoadBalancersInput{})
+		for paginator.HasMorePages() {
+			out, err := paginator.NextPage(ctx)
+			if err != nil {
+				return false, err
+			}
+			for _, lb := range out.LoadBalancers {
+				names[normalizeELBName(aws.ToString(lb.DNSName))] = true
+			}
+		}
+		r.elbNames[region] = names
+	}
+	return names[normalizeELBName(target)], nil
+}
+
+func elbRegion(target string) (string, bool) {
+	match := elbDNSPattern.FindStringSubmatch(strings.TrimSuffix(target, "."))
+	if match == nil {
+		return "", false
+	}
+	if match[1] != "" {
+		return match[1], true
+	}
+	return match[2], true
+}
+
+// normalizeELBName strips the dualstack prefix Route53 aliases add and the
+// trailing dot so alias targets compare equal to DescribeLoadBalancers names.
+func normalizeELBName(name string) string {
+	name = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+	return strings.TrimPrefix(name, "dualstack.")
+}
+
+func splitHorizon(zones []r53types.HostedZone) bool {
+	var public, private bool
+	for _, zone := range zones {
+		if zone.Config != nil && zone.Config.PrivateZone {
+			private = true
+		} else {
+			public = true
+		}
+	}
+	return public && private
+}
+
+func containsType(types []string, recordType string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, candidate := range types {
+		if candidate == recordType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package awsroute53
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+
+	"rootcause/internal/mcp"
+)
+
+func TestELBRegion(t *testing.T) {
+	cases := map[string]string{
+		"dualstack.web-123.us-east-1.elb.amazonaws.com.": "us-east-1",
+		"internal-api-9.eu-west-2.elb.amazonaws.com":     "eu-west-2",
+		"nlb-abc.elb.ap-southeast-1.amazonaws.com.":      "ap-southeast-1",
+		"d111111abcdef8.cloudfront.net.":                 "",
+		"bucket.s3-website-us-east-1.amazonaws.com.":     "",
+		"web-123.us-gov-west-1.elb.amazonaws.com.":       "us-gov-west-1",
+	}
+	for name, want := range cases {
+		got, ok := elbRegion(name)
+		if got != want || ok != (want != "") {
+			t.Fatalf("elbRegion(%q) = %q, %v; want %q", name, got, ok, want)
+		}
+	}
+	if normalizeELBName("dualstack.Web-123.us-east-1.elb.amazonaws.com.") != "web-123.us-east-1.elb.amazonaws.com" {
+		t.Fatalf("unexpected normalized ELB name")
+	}
+}
+
+func TestRoute53ResolveRecord(t *testing.T) {
+	transport := &route53RoundTripper{responses: map[string]string{
+		"/2013-04-01/hostedzone": `<ListHostedZonesResponse ` + route53NS + `>
+  <HostedZones>
+    <HostedZone><Id>/hostedzone/Z0</Id><Name>com.</Name><CallerReference>x</CallerReference><Config><PrivateZone>false</PrivateZone></Config></HostedZone>
+    <HostedZone><Id>/hostedzone/Z1</Id><Name>example.com.</Name><CallerReference>a</CallerReference><Config><PrivateZone>false</PrivateZone></Config></HostedZone>
+    <HostedZone><Id>/hostedzone/Z3</Id><Name>example.com.</Name><CallerReference>c</CallerReference><Config><PrivateZone>true</PrivateZone></Config></HostedZone>
+    <HostedZone><Id>/hostedzone/Z4</Id><Name>other.org.</Name><CallerReference>d</CallerReference><Config><PrivateZone>false</PrivateZone></Config></HostedZone>
+  </HostedZones>
+  <IsTruncated>false</IsTruncated>
+  <MaxItems>100</MaxItems>
+</ListHostedZonesResponse>`,
+		"/2013-04-01/hostedzone/Z1/rrset?name=api.example.com.": `<ListResourceRecordSetsResponse ` + route53NS + `>
+  <ResourceRecordSets>
+    <ResourceRecordSet><Name>api.example.com.</Name><Type>A</Type><AliasTarget><HostedZoneId>Z35SXDOTRQ7X7K</HostedZoneId><DNSName>dualstack.gone-1.us-east-1.elb.amazonaws.com.</DNSName><EvaluateTargetHealth>true</EvaluateTargetHealth></AliasTarget></ResourceRecordSet>
+    <ResourceRecordSet><Name>api.example.com.</Name><Type>AAAA</Type><AliasTarget><HostedZoneId>Z35SXDOTRQ7X7K</HostedZoneId><DNSName>dualstack.live-1.us-east-1.elb.amazonaws.com.</DNSName><EvaluateTargetHealth>true</EvaluateTargetHealth></AliasTarget></ResourceRecordSet>
+    <ResourceRecordSet><Name>api.example.com.</Name><Type>TXT</Type><TTL>300</TTL><ResourceRecords><ResourceRecord><Value>"v=1"</Value></ResourceRecord></ResourceRecords></ResourceRecordSet>
+    <ResourceRecordSet><Name>app.example.com.</Name><Type>A</Type><TTL>300</TTL><ResourceRecords><ResourceRecord><Value>10.0.0.1</Value></ResourceRecord></ResourceRecords></ResourceRecordSet>
+  </ResourceRecordSets>
+  <IsTruncated>false</IsTruncated>
+  <MaxItems>100</MaxItems>
+</ListResourceRecordSetsResponse>`,
+		"/2013-04-01/hostedzone/Z3/rrset?name=api.example.com.": `<ListResourceRecordSetsResponse ` + route53NS + `>
+  <ResourceRecordSets>
+    <ResourceRecordSet><Name>api.example.com.</Name><Type>CNAME</Type><TTL>60</TTL><ResourceRecords><ResourceRecord><Value>web.example.com</Value></ResourceRecord></ResourceRecords></ResourceRecordSet>
+  </ResourceRecordSets>
+  <IsTruncated>false</IsTruncated>
+  <MaxItems>100</MaxItems>
+</ListResourceRecordSetsResponse>`,
+		"/2013-04-01/hostedzone/Z3/rrset?name=web.example.com.": `<ListResourceRecordSetsResponse ` + route53NS + `>
+  <ResourceRecordSets>
+    <ResourceRecordSet><Name>www.example.com.</Name><Type>A</Type><TTL>60</TTL><ResourceRecords><ResourceRecord><Value>10.0.0.2</Value></ResourceRecord></ResourceRecords></ResourceRecordSet>
+  </ResourceRecordSets>
+  <IsTruncated>false</IsTruncated>
+  <MaxItems>100</MaxItems>
+</ListResourceRecordSetsResponse>`,
+	}}
+	svc := newRoute53TestService(transport)
+	elbTransport := &elbRoundTripper{}
+	svc.elbClient = func(context.Context, string) (*elasticloadbalancingv2.Client, string, error) {
+		return newELBTestClient(elbTransport), "us-east-1", nil
+	}
+
+	result, err := svc.handleResolveRecord(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"name": "API.example.com"}})
+	if err != nil {
+		t.Fatalf("resolve record: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	zones := data["hostedZones"].([]map[string]any)
+	if len(zones) != 2 || zones[0]["id"] != "Z1" || zones[1]["id"] != "Z3" || data["note"] == nil {
+		t.Fatalf("expected the public and private example.com zones, got %#v", data)
+	}
+	if records := zones[0]["records"].([]map[string]any); len(records) != 2 {
+		t.Fatalf("expected only the A and AAAA records, got %#v", records)
+	}
+	issues := data["issues"].([]string)
+	if len(issues) != 2 || !strings.Contains(issues[0], "gone-1.us-east-1.elb.amazonaws.com") || !strings.Contains(issues[1], "web.example.com.") {
+		t.Fatalf("unexpected issues: %#v", issues)
+	}
+	if elbTransport.calls != 1 {
+		t.Fatalf("expected load balancers to be listed once per region, got %d calls", elbTransport.calls)
+	}
+
+	result, err = svc.handleResolveRecord(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"name": "missing.net"}})
+	if err != nil {
+		t.Fatalf("resolve unmatched record: %v", err)
+	}
+	if issues := result.Data.(map[string]any)["issues"].([]string); len(issues) != 1 || !strings.Contains(issues[0], "no hosted zone") {
+		t.Fatalf("expected no-zone issue, got %#v", issues)
+	}
+	if _, err := svc.handleResolveRecord(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected name error")
+	}
+}
+
+func newELBTestClient(transport http.RoundTripper) *elasticloadbalancingv2.Client {
+	cfg := aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		HTTPClient:  &http.Client{Transport: transport},
+	}
+	cfg.EndpointResolverWithOptions = aws.EndpointResolverWithOptionsFunc(
+		func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+			return aws.Endpoint{URL: "https://elb.test", SigningRegion: region, HostnameImmutable: true}, nil
+		},
+	)
+	return elasticloadbalancingv2.NewFromConfig(cfg)
+}
+
+// elbRoundTripper answers every DescribeLoadBalancers call with one live ALB.
+type elbRoundTripper struct {
+	calls int
+}
+
+func (rt *elbRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.calls++
+	body := `<DescribeLoadBalancersResponse xmlns="http://elasticloadbalancing.amazonaws.com/doc/2015-12-01/">
+  <DescribeLoadBalancersResult>
+    <LoadBalancers>
+      <member><LoadBalancerName>live</LoadBalancerName><DNSName>live-1.us-east-1.elb.amazonaws.com</DNSName></member>
+    </LoadBalancers>
+  </DescribeLoadBalancersResult>
+</DescribeLoadBalancersResponse>`
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     http.Header{"Content-Type": []string{"text/xml"}},
+		Request:    req,
+	}, nil
+}
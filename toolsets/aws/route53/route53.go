@@ -0,0 +1,357 @@
+package awsroute53
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+
+	"rootcause/internal/argconv"
+	awslib "rootcause/internal/aws"
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+// maxRecordsPerPage is the ListResourceRecordSets page size ceiling.
+const maxRecordsPerPage = 300
+
+type Service struct {
+	ctx           mcp.ToolContext
+	route53Client func(context.Context, string) (*route53.Client, string, error)
+	elbClient     func(context.Context, string) (*elasticloadbalancingv2.Client, string, error)
+	toolsetID     string
+}
+
+func ToolSpecs(ctx mcp.ToolContext, toolsetID string, route53Client func(context.Context, string) (*route53.Client, string, error), elbClient func(context.Context, string) (*elasticloadbalancingv2.Client, string, error)) []mcp.ToolSpec {
+	svc := &Service{ctx: ctx, route53Client: route53Client, elbClient: elbClient, toolsetID: toolsetID}
+	return []mcp.ToolSpec{
+		{
+			Name:        "aws.route53.list_hosted_zones",
+			Description: "List Route53 hosted zones (optional public/private filter).",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaRoute53ListHostedZones(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleListHostedZones,
+		},
+		{
+			Name:        "aws.route53.get_hosted_zone",
+			Description: "Get a Route53 hosted zone with its name servers and associated VPCs.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaRoute53GetHostedZone(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleGetHostedZone,
+		},
+		{
+			Name:        "aws.route53.list_resource_record_sets",
+			Description: "List records in a Route53 hosted zone (optional exact name and type filter), including ALIAS targets.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaRoute53ListResourceRecordSets(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleListResourceRecordSets,
+		},
+		{
+			Name:        "aws.route53.resolve_record",
+			Description: "Find the hosted zones that answer for a DNS name and return its A/AAAA/CNAME records, flagging aliases to deleted load balancers and targets with no records.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaRoute53ResolveRecord(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleResolveRecord,
+		},
+	}
+}
+
+func (s *Service) handleListHostedZones(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	region := awsutil.ToString(req.Arguments["region"])
+	zoneType := strings.ToLower(strings.TrimSpace(awsutil.ToString(req.Arguments["zoneType"])))
+	if zoneType != "" && zoneType != "public" && zoneType != "private" {
+		err := errors.New("zoneType must be public or private")
+		return awsutil.ErrorResult(err), err
+	}
+	limit := argconv.Int(req.Arguments["limit"], 100)
+	client, usedRegion, err := s.route53Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input := &route53.ListHostedZonesInput{}
+	if zoneType == "private" {
+		input.HostedZoneType = r53types.HostedZoneTypePrivateHostedZone
+	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.Marker = pager.StartToken()
+	paginator := route53.NewListHostedZonesPaginator(client, input)
+	pageToken := aws.ToString(input.Marker)
+	var zones []map[string]any
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		// The API can only narrow to private zones; public is filtered here.
+		var page []r53types.HostedZone
+		for _, zone := range out.HostedZones {
+			if zoneType == "public" && zone.Config != nil && zone.Config.PrivateZone {
+				continue
+			}
+			page = append(page, zone)
+		}
+		var next *string
+		if out.IsTruncated {
+			next = out.NextMarker
+		}
+		start, end, more := pager.Page(pageToken, len(page), next)
+		for _, zone := range page[start:end] {
+			zones = append(zones, summarizeHostedZone(zone))
+		}
+		if !more {
+			break
+		}
+		pageToken = aws.ToString(out.NextMarker)
+	}
+	data := map[string]any{
+		"region":      awsutil.RegionOrDefault(usedRegion),
+		"hostedZones": zones,
+		"count":       len(zones),
+	}
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
+}
+
+func (s *Service) handleGetHostedZone(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	zoneID := normalizeZoneID(awsutil.ToString(req.Arguments["hostedZoneId"]))
+	if zoneID == "" {
+		return awsutil.ErrorResult(errors.New("hostedZoneId is required")), errors.New("hostedZoneId is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.route53Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	out, err := client.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: aws.String(zoneID)})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	data := map[string]any{
+		"region": awsutil.RegionOrDefault(usedRegion),
+	}
+	if out.HostedZone != nil {
+		data["hostedZone"] = summarizeHostedZone(*out.HostedZone)
+	}
+	if out.DelegationSet != nil {
+		data["nameServers"] = out.DelegationSet.NameServers
+	}
+	if len(out.VPCs) > 0 {
+		vpcs := make([]map[string]any, 0, len(out.VPCs))
+		for _, vpc := range out.VPCs {
+			vpcs = append(vpcs, map[string]any{"vpcId": aws.ToString(vpc.VPCId), "region": vpc.VPCRegion})
+		}
+		data["vpcs"] = vpcs
+	}
+	return mcp.ToolResult{
+		Data:     s.ctx.Redactor.RedactValue(data),
+		Metadata: mcp.ToolMetadata{Resources: []string{"route53/hostedzone/" + zoneID}},
+	}, nil
+}
+
+func (s *Service) handleListResourceRecordSets(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	zoneID := normalizeZoneID(awsutil.ToString(req.Arguments["hostedZoneId"]))
+	if zoneID == "" {
+		return awsutil.ErrorResult(errors.New("hostedZoneId is required")), errors.New("hostedZoneId is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	name := normalizeRecordName(awsutil.ToString(req.Arguments["name"]))
+	recordType := strings.ToUpper(strings.TrimSpace(awsutil.ToString(req.Arguments["type"])))
+	limit := argconv.Int(req.Arguments["limit"], 100)
+	client, usedRegion, err := s.route53Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	start, err := decodeRecordStart(aws.ToString(pager.StartToken()))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	if start.Name == "" && name != "" {
+		start.Name = name
+		if recordType != "" {
+			start.Type = recordType
+		}
+	}
+	pageSize := maxRecordsPerPage
+	if limit > 0 && limit < pageSize {
+		pageSize = limit
+	}
+	var records []map[string]any
+	for {
+		input := &route53.ListResourceRecordSetsInput{
+			HostedZoneId: aws.String(zoneID),
+			MaxItems:     aws.Int32(int32(pageSize)), //nolint:gosec // bounded by maxRecordsPerPage
+		}
+		if start.Name != "" {
+			input.StartRecordName = aws.String(start.Name)
+			input.StartRecordType = r53types.RRType(start.Type)
+			if start.Identifier != "" {
+				input.StartRecordIdentifier = aws.String(start.Identifier)
+			}
+		}
+		out, err := client.ListResourceRecordSets(ctx, input)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		var page []r53types.ResourceRecordSet
+		passed := false
+		for _, record := range out.ResourceRecordSets {
+			recordName := normalizeRecordName(aws.ToString(record.Name))
+			if name != "" && recordName != name {
+				// Records are sorted and a name's records are contiguous, so
+				// the first different name after the start ends the match.
+				passed = true
+				break
+			}
+			if recordType != "" && string(record.Type) != recordType {
+				continue
+			}
+			page = append(page, record)
+		}
+		var next *string
+		nextStart := recordStart{Name: aws.ToString(out.NextRecordName), Type: string(out.NextRecordType), Identifier: aws.ToString(out.NextRecordIdentifier)}
+		if out.IsTruncated && !passed && nextStart.Name != "" {
+			next = aws.String(nextStart.String())
+		}
+		from, to, more := pager.Page(start.String(), len(page), next)
+		for _, record := range page[from:to] {
+			records = append(records, summarizeRecordSet(record))
+		}
+		if !more {
+			break
+		}
+		start = nextStart
+	}
+	data := map[string]any{
+		"region":       awsutil.RegionOrDefault(usedRegion),
+		"hostedZoneId": zoneID,
+		"records":      records,
+		"count":        len(records),
+	}
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{
+		Data:     redacted,
+		Metadata: mcp.ToolMetadata{Resources: []string{"route53/hostedzone/" + zoneID}},
+	}, nil
+}
+
+// recordStart is the (name, type, set identifier) triple ListResourceRecordSets
+// pages on. It is carried inside the pager cursor as a single token.
+type recordStart struct {
+	Name       string `json:"n,omitempty"`
+	Type       string `json:"t,omitempty"`
+	Identifier string `json:"i,omitempty"`
+}
+
+func (r recordStart) String() string {
+	if r.Name == "" {
+		return ""
+	}
+	data, _ := json.Marshal(r)
+	return string(data)
+}
+
+func decodeRecordStart(token string) (recordStart, error) {
+	var start recordStart
+	if token == "" {
+		return start, nil
+	}
+	if err := json.Unmarshal([]byte(token), &start); err != nil {
+		return start, errors.New("invalid nextToken: pass the nextToken value from a previous result unchanged")
+	}
+	return start, nil
+}
+
+// normalizeZoneID accepts both "Z123" and the "/hostedzone/Z123" form the API
+// returns.
+func normalizeZoneID(id string) string {
+	return strings.TrimPrefix(strings.TrimSpace(id), "/hostedzone/")
+}
+
+// normalizeRecordName lowercases a DNS name and adds the trailing dot Route53
+// uses in responses.
+func normalizeRecordName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+func summarizeHostedZone(zone r53types.HostedZone) map[string]any {
+	out := map[string]any{
+		"id":          normalizeZoneID(aws.ToString(zone.Id)),
+		"name":        aws.ToString(zone.Name),
+		"recordCount": aws.ToInt64(zone.ResourceRecordSetCount),
+		"private":     false,
+	}
+	if zone.Config != nil {
+		out["private"] = zone.Config.PrivateZone
+		out["comment"] = aws.ToString(zone.Config.Comment)
+	}
+	if zone.LinkedService != nil {
+		out["linkedService"] = aws.ToString(zone.LinkedService.ServicePrincipal)
+	}
+	return out
+}
+
+func summarizeRecordSet(record r53types.ResourceRecordSet) map[string]any {
+	out := map[string]any{
+		"name": aws.ToString(record.Name),
+		"type": record.Type,
+	}
+	if record.TTL != nil {
+		out["ttl"] = aws.ToInt64(record.TTL)
+	}
+	if len(record.ResourceRecords) > 0 {
+		values := make([]string, 0, len(record.ResourceRecords))
+		for _, value := range record.ResourceRecords {
+			values = append(values, aws.ToString(value.Value))
+		}
+		out["values"] = values
+	}
+	if record.AliasTarget != nil {
+		out["aliasTarget"] = map[string]any{
+			"dnsName":              aws.ToString(record.AliasTarget.DNSName),
+			"hostedZoneId":         aws.ToString(record.AliasTarget.HostedZoneId),
+			"evaluateTargetHealth": record.AliasTarget.EvaluateTargetHealth,
+		}
+	}
+	if record.SetIdentifier != nil {
+		out["setIdentifier"] = aws.ToString(record.SetIdentifier)
+	}
+	if record.Weight != nil {
+		out["weight"] = aws.ToInt64(record.Weight)
+	}
+	if record.Region != "" {
+		out["routingRegion"] = record.Region
+	}
+	if record.Failover != "" {
+		out["failover"] = record.Failover
+	}
+	if record.HealthCheckId != nil {
+		out["healthCheckId"] = aws.ToString(record.HealthCheckId)
+	}
+	return out
+}
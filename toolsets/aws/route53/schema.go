@@ -0,0 +1,71 @@
+package awsroute53
+
+func schemaRoute53ListHostedZones() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"zoneType": map[string]any{
+				"type":        "string",
+				"enum":        []string{"public", "private"},
+				"description": "Only list public or private hosted zones.",
+			},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
+		},
+	}
+}
+
+func schemaRoute53GetHostedZone() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"hostedZoneId": map[string]any{"type": "string"},
+			"region":       map[string]any{"type": "string"},
+		},
+		"required": []string{"hostedZoneId"},
+	}
+}
+
+func schemaRoute53ListResourceRecordSets() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"hostedZoneId": map[string]any{"type": "string"},
+			"name": map[string]any{
+				"type":        "string",
+				"description": "Only return records with this exact name (e.g. api.example.com).",
+			},
+			"type": map[string]any{
+				"type":        "string",
+				"description": "Only return records of this type (A, AAAA, CNAME, TXT, ...).",
+			},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
+		},
+		"required": []string{"hostedZoneId"},
+	}
+}
+
+func schemaRoute53ResolveRecord() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name": map[string]any{
+				"type":        "string",
+				"description": "DNS name to resolve (e.g. api.example.com).",
+			},
+			"type": map[string]any{
+				"type":        "string",
+				"description": "Only return records of this type (default A, AAAA and CNAME).",
+			},
+			"vpcId": map[string]any{
+				"type":        "string",
+				"description": "Only consider private hosted zones associated with this VPC.",
+			},
+			"region": map[string]any{"type": "string"},
+		},
+		"required": []string{"name"},
+	}
+}
@@ -0,0 +1,34 @@
+package awsroute53
+
+import (
+	"testing"
+
+	"rootcause/internal/mcp"
+)
+
+func TestRoute53Schemas(t *testing.T) {
+	schemas := []map[string]any{
+		schemaRoute53ListHostedZones(),
+		schemaRoute53GetHostedZone(),
+		schemaRoute53ListResourceRecordSets(),
+		schemaRoute53ResolveRecord(),
+	}
+	for i, schema := range schemas {
+		if schema == nil || schema["type"] == "" {
+			t.Fatalf("schema %d missing type", i)
+		}
+	}
+}
+
+func TestRoute53ToolSpecs(t *testing.T) {
+	specs := ToolSpecs(mcp.ToolContext{}, "aws", nil, nil)
+	names := map[string]bool{}
+	for _, spec := range specs {
+		names[spec.Name] = true
+	}
+	for _, want := range []string{"aws.route53.list_hosted_zones", "aws.route53.get_hosted_zone", "aws.route53.list_resource_record_sets", "aws.route53.resolve_record"} {
+		if !names[want] {
+			t.Fatalf("expected %s", want)
+		}
+	}
+}
@@ -7,11 +7,13 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 
+	"rootcause/internal/config"
 	"rootcause/internal/mcp"
 	"rootcause/internal/redact"
 )
@@ -85,6 +87,83 @@ func TestSTSHandlersWithStubbedClient(t *testing.T) {
 	}
 }
 
+func TestSTSWhoAmI(t *testing.T) {
+	base := newSTSTestClient(t, map[string]string{
+		"GetCallerIdentity": `<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <GetCallerIdentityResult>
+    <Arn>arn:aws:sts::123456789012:assumed-role/ops/alice</Arn>
+    <Account>123456789012</Account>
+    <UserId>123456789012:alice</UserId>
+  </GetCallerIdentityResult>
+</GetCallerIdentityResponse>`,
+	})
+	expires := time.Now().Add(45 * time.Minute)
+	client := sts.New(base.Options(), func(o *sts.Options) {
+		o.Credentials = aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "ASIA", SecretAccessKey: "SECRET", SessionToken: "token", Source: "SSOProvider", CanExpire: true, Expires: expires}, nil
+		})
+	})
+	cfg := config.DefaultConfig()
+	svc := &Service{
+		ctx: mcp.ToolContext{Config: &cfg, Redactor: redact.New()},
+		stsClient: func(context.Context, string) (*sts.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+
+	result, err := svc.handleWhoAmI(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("whoami: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if data["type"] != "assumed-role" || data["role"] != "ops" || data["sessionName"] != "alice" || data["account"] != "123456789012" {
+		t.Fatalf("unexpected identity: %#v", data)
+	}
+	session := data["session"].(map[string]any)
+	if session["temporary"] != true || session["expired"] != false || session["source"] != "SSOProvider" {
+		t.Fatalf("unexpected session: %#v", session)
+	}
+	if !session["expiration"].(time.Time).Equal(expires) {
+		t.Fatalf("expected expiration %v, got %#v", expires, session["expiration"])
+	}
+
+	cfg.AWS.RedactAccountID = true
+	result, err = svc.handleWhoAmI(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("whoami (redacted): %v", err)
+	}
+	data = result.Data.(map[string]any)
+	if data["account"] != "[REDACTED]" || data["arn"] != "arn:aws:sts::[REDACTED]:assumed-role/ops/alice" || data["userId"] != "[REDACTED]:alice" {
+		t.Fatalf("expected account redacted, got %#v", data)
+	}
+	result, err = svc.handleGetCallerIdentity(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("get caller identity (redacted): %v", err)
+	}
+	data = result.Data.(map[string]any)
+	if data["account"] != "[REDACTED]" || data["arn"] != "arn:aws:sts::[REDACTED]:assumed-role/ops/alice" || data["userId"] != "[REDACTED]:alice" {
+		t.Fatalf("expected caller identity redacted, got %#v", data)
+	}
+}
+
+func TestIdentityFromARN(t *testing.T) {
+	cases := map[string]string{
+		"arn:aws:iam::123:user/team/demo":       "user",
+		"arn:aws:iam::123:root":                 "root",
+		"arn:aws:sts::123:federated-user/bob":   "federated-user",
+		"arn:aws:sts::123:assumed-role/ops/bob": "assumed-role",
+		"garbage":                               "unknown",
+	}
+	for arn, want := range cases {
+		if got := identityFromARN(arn)["type"]; got != want {
+			t.Fatalf("identityFromARN(%q) type = %v, want %s", arn, got, want)
+		}
+	}
+	if user := identityFromARN("arn:aws:iam::123:user/team/demo")["user"]; user != "demo" {
+		t.Fatalf("expected user name from path, got %v", user)
+	}
+}
+
 func newSTSTestClient(t *testing.T, responses map[string]string) *sts.Client {
 	t.Helper()
 	transport := &stsQueryRoundTripper{responses: responses}
@@ -7,6 +7,13 @@ func schemaSTSGetCallerIdentity() map[string]any {
 	}
 }
 
+func schemaSTSWhoAmI() map[string]any {
+	return map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"region": map[string]any{"type": "string"}},
+	}
+}
+
 func schemaSTSAssumeRole() map[string]any {
 	return map[string]any{
 		"type": "object",
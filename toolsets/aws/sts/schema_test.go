@@ -9,6 +9,7 @@ import (
 func TestSTSSchemas(t *testing.T) {
 	schemas := []map[string]any{
 		schemaSTSGetCallerIdentity(),
+		schemaSTSWhoAmI(),
 		schemaSTSAssumeRole(),
 	}
 	for i, schema := range schemas {
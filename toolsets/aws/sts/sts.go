@@ -2,16 +2,18 @@ package awssts
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
 
+	"rootcause/internal/argconv"
 	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
 )
 
 type Service struct {
@@ -31,6 +33,14 @@ func ToolSpecs(ctx mcp.ToolContext, toolsetID string, stsClient func(context.Con
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     svc.handleGetCallerIdentity,
 		},
+		{
+			Name:        "aws.sts.whoami",
+			Description: "Show which AWS identity tool calls run as: caller ARN, account, user id, and the session expiry for temporary credentials.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaSTSWhoAmI(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleWhoAmI,
+		},
 		{
 			Name:        "aws.sts.assume_role",
 			Description: "Assume an IAM role and return temporary credentials (confirm required).",
@@ -43,42 +53,112 @@ func ToolSpecs(ctx mcp.ToolContext, toolsetID string, stsClient func(context.Con
 }
 
 func (s *Service) handleGetCallerIdentity(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.stsClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(map[string]any{
 		"region":  usedRegion,
-		"arn":     aws.ToString(out.Arn),
-		"account": aws.ToString(out.Account),
-		"userId":  aws.ToString(out.UserId),
+		"arn":     awsutil.MaskAccountIDs(s.ctx, aws.ToString(out.Arn)),
+		"account": awsutil.MaskAccountIDs(s.ctx, aws.ToString(out.Account)),
+		"userId":  awsutil.MaskAccountIDs(s.ctx, aws.ToString(out.UserId)),
 	})}, nil
 }
 
+func (s *Service) handleWhoAmI(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.stsClient(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	out, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	arn := aws.ToString(out.Arn)
+	data := map[string]any{
+		"region":  awsutil.RegionOrDefault(usedRegion),
+		"arn":     awsutil.MaskAccountIDs(s.ctx, arn),
+		"account": awsutil.MaskAccountIDs(s.ctx, aws.ToString(out.Account)),
+		"userId":  awsutil.MaskAccountIDs(s.ctx, aws.ToString(out.UserId)),
+	}
+	for key, value := range identityFromARN(arn) {
+		data[key] = value
+	}
+	var warnings []string
+	if provider := client.Options().Credentials; provider != nil {
+		creds, err := provider.Retrieve(ctx)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("credential lookup failed: %v", err))
+		} else {
+			session := map[string]any{"source": creds.Source, "temporary": creds.SessionToken != ""}
+			if creds.CanExpire {
+				session["expiration"] = creds.Expires.UTC()
+				session["expiresIn"] = time.Until(creds.Expires).Round(time.Second).String()
+				session["expired"] = creds.Expired()
+			}
+			data["session"] = session
+		}
+	}
+	if len(warnings) > 0 {
+		data["warnings"] = warnings
+	}
+	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+}
+
+// identityFromARN classifies a caller ARN. Assumed-role ARNs have the form
+// arn:aws:sts::<account>:assumed-role/<role>/<session>.
+func identityFromARN(arn string) map[string]any {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 {
+		return map[string]any{"type": "unknown"}
+	}
+	resource := strings.Split(parts[5], "/")
+	switch resource[0] {
+	case "assumed-role":
+		out := map[string]any{"type": "assumed-role"}
+		if len(resource) > 1 {
+			out["role"] = resource[1]
+		}
+		if len(resource) > 2 {
+			out["sessionName"] = resource[2]
+		}
+		return out
+	case "user":
+		return map[string]any{"type": "user", "user": resource[len(resource)-1]}
+	case "federated-user":
+		return map[string]any{"type": "federated-user", "user": resource[len(resource)-1]}
+	case "root":
+		return map[string]any{"type": "root"}
+	default:
+		return map[string]any{"type": resource[0]}
+	}
+}
+
 func (s *Service) handleAssumeRole(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
 	if err := requireConfirm(req.Arguments); err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
-	roleArn := strings.TrimSpace(toString(req.Arguments["roleArn"]))
+	roleArn := strings.TrimSpace(awsutil.ToString(req.Arguments["roleArn"]))
 	if roleArn == "" {
-		return errorResult(errors.New("roleArn is required")), errors.New("roleArn is required")
+		return awsutil.ErrorResult(errors.New("roleArn is required")), errors.New("roleArn is required")
 	}
-	sessionName := strings.TrimSpace(toString(req.Arguments["sessionName"]))
+	sessionName := strings.TrimSpace(awsutil.ToString(req.Arguments["sessionName"]))
 	if sessionName == "" {
-		return errorResult(errors.New("sessionName is required")), errors.New("sessionName is required")
+		return awsutil.ErrorResult(errors.New("sessionName is required")), errors.New("sessionName is required")
 	}
-	region := toString(req.Arguments["region"])
-	duration := toInt(req.Arguments["durationSeconds"], 0)
-	externalID := strings.TrimSpace(toString(req.Arguments["externalId"]))
-	policy := strings.TrimSpace(toString(req.Arguments["policy"]))
+	region := awsutil.ToString(req.Arguments["region"])
+	duration := argconv.Int(req.Arguments["durationSeconds"], 0)
+	externalID := strings.TrimSpace(awsutil.ToString(req.Arguments["externalId"]))
+	policy := strings.TrimSpace(awsutil.ToString(req.Arguments["policy"]))
 	client, usedRegion, err := s.stsClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &sts.AssumeRoleInput{
 		RoleArn:         aws.String(roleArn),
@@ -95,7 +175,7 @@ func (s *Service) handleAssumeRole(ctx context.Context, req mcp.ToolRequest) (mc
 	}
 	out, err := client.AssumeRole(ctx, input)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(map[string]any{
 		"region":           usedRegion,
@@ -128,36 +208,6 @@ func summarizeCredentials(creds *ststypes.Credentials) map[string]any {
 	}
 }
 
-func errorResult(err error) mcp.ToolResult {
-	return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}
-}
-
-func toString(value any) string {
-	if value == nil {
-		return ""
-	}
-	if s, ok := value.(string); ok {
-		return s
-	}
-	return fmt.Sprintf("%v", value)
-}
-
-func toInt(value any, fallback int) int {
-	switch v := value.(type) {
-	case int:
-		return v
-	case int64:
-		return int(v)
-	case float64:
-		return int(v)
-	case json.Number:
-		if parsed, err := v.Int64(); err == nil {
-			return int(parsed)
-		}
-	}
-	return fallback
-}
-
 func requireConfirm(args map[string]any) error {
 	if val, ok := args["confirm"].(bool); ok && val {
 		return nil
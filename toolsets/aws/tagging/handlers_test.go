@@ -0,0 +1,146 @@
+package awstagging
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestResourceTypeFromARN(t *testing.T) {
+	cases := map[string][2]string{
+		"arn:aws:ec2:us-east-1:123:instance/i-1":                        {"ec2", "ec2:instance"},
+		"arn:aws:lambda:us-east-1:123:function:checkout":                {"lambda", "lambda:function"},
+		"arn:aws:s3:::checkout-assets":                                  {"s3", "s3"},
+		"arn:aws:elasticloadbalancing:us-east-1:123:loadbalancer/app/x": {"elasticloadbalancing", "elasticloadbalancing:loadbalancer"},
+		"not-an-arn": {"", ""},
+	}
+	for arn, want := range cases {
+		service, resourceType := resourceTypeFromARN(arn)
+		if service != want[0] || resourceType != want[1] {
+			t.Fatalf("resourceTypeFromARN(%q) = %q, %q; want %q, %q", arn, service, resourceType, want[0], want[1])
+		}
+	}
+}
+
+func TestTagFiltersFromArgs(t *testing.T) {
+	filters := tagFiltersFromArgs(map[string]any{"app": "checkout", "team": "", " ": "x"})
+	if len(filters) != 2 || aws.ToString(filters[0].Key) != "app" || len(filters[0].Values) != 1 {
+		t.Fatalf("unexpected filters: %#v", filters)
+	}
+	if len(filters[1].Values) != 0 {
+		t.Fatalf("expected key-only filter for empty value, got %#v", filters[1])
+	}
+	if tagFiltersFromArgs("app=checkout") != nil {
+		t.Fatalf("expected nil for non-object tag filters")
+	}
+}
+
+func TestSearchByTag(t *testing.T) {
+	transport := &taggingRoundTripper{pages: map[string]string{
+		"": `{"PaginationToken":"page-2","ResourceTagMappingList":[
+			{"ResourceARN":"arn:aws:ec2:us-east-1:123:instance/i-1","Tags":[{"Key":"app","Value":"checkout"}]},
+			{"ResourceARN":"arn:aws:s3:::checkout-assets","Tags":[{"Key":"app","Value":"checkout"}]}]}`,
+		"page-2": `{"PaginationToken":"","ResourceTagMappingList":[
+			{"ResourceARN":"arn:aws:ec2:us-east-1:123:instance/i-2","Tags":[{"Key":"app","Value":"checkout"}]}]}`,
+	}}
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		taggingClient: func(context.Context, string) (*resourcegroupstaggingapi.Client, string, error) {
+			return newTaggingTestClient(transport), "us-east-1", nil
+		},
+	}
+	if _, err := svc.handleSearchByTag(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil || !strings.Contains(err.Error(), "tagFilters is required") {
+		t.Fatalf("expected tagFilters error, got %v", err)
+	}
+
+	args := map[string]any{"tagFilters": map[string]any{"app": "checkout"}}
+	result, err := svc.handleSearchByTag(context.Background(), mcp.ToolRequest{Arguments: args})
+	if err != nil {
+		t.Fatalf("search by tag: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if data["count"] != 3 || data["nextToken"] != nil {
+		t.Fatalf("expected all pages, got %#v", data)
+	}
+	if counts := data["resourceTypes"].(map[string]int); counts["ec2:instance"] != 2 || counts["s3"] != 1 {
+		t.Fatalf("unexpected resource type counts: %#v", counts)
+	}
+	if transport.lastFilters != `[{"Key":"app","Values":["checkout"]}]` {
+		t.Fatalf("unexpected tag filters sent: %s", transport.lastFilters)
+	}
+
+	args["limit"] = 2
+	first, err := svc.handleSearchByTag(context.Background(), mcp.ToolRequest{Arguments: args})
+	if err != nil {
+		t.Fatalf("search by tag (limit): %v", err)
+	}
+	next, _ := first.Data.(map[string]any)["nextToken"].(string)
+	if first.Data.(map[string]any)["count"] != 2 || next == "" {
+		t.Fatalf("expected limited page with nextToken, got %#v", first.Data)
+	}
+	args["nextToken"] = next
+	second, err := svc.handleSearchByTag(context.Background(), mcp.ToolRequest{Arguments: args})
+	if err != nil {
+		t.Fatalf("search by tag (next page): %v", err)
+	}
+	resources := second.Data.(map[string]any)["resources"].([]map[string]any)
+	if len(resources) != 1 || resources[0]["arn"] != "arn:aws:ec2:us-east-1:123:instance/i-2" {
+		t.Fatalf("unexpected second page: %#v", resources)
+	}
+}
+
+func newTaggingTestClient(transport http.RoundTripper) *resourcegroupstaggingapi.Client {
+	cfg := aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		HTTPClient:  &http.Client{Transport: transport},
+	}
+	cfg.EndpointResolverWithOptions = aws.EndpointResolverWithOptionsFunc(
+		func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+			return aws.Endpoint{URL: "https://tagging.test", SigningRegion: region, HostnameImmutable: true}, nil
+		},
+	)
+	return resourcegroupstaggingapi.NewFromConfig(cfg)
+}
+
+// taggingRoundTripper serves GetResources pages keyed by PaginationToken.
+type taggingRoundTripper struct {
+	pages       map[string]string
+	lastFilters string
+}
+
+func (rt *taggingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body struct {
+		PaginationToken string
+		TagFilters      json.RawMessage
+	}
+	raw, _ := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	_ = json.Unmarshal(raw, &body)
+	rt.lastFilters = string(body.TagFilters)
+	resp, ok := rt.pages[body.PaginationToken]
+	if !ok {
+		return &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       io.NopCloser(strings.NewReader("unknown page")),
+			Header:     http.Header{"Content-Type": []string{"text/plain"}},
+			Request:    req,
+		}, nil
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(resp)),
+		Header:     http.Header{"Content-Type": []string{"application/x-amz-json-1.1"}},
+		Request:    req,
+	}, nil
+}
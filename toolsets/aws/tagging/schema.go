@@ -0,0 +1,21 @@
+package awstagging
+
+func schemaSearchByTag() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"tagFilters": map[string]any{
+				"type":                 "object",
+				"additionalProperties": map[string]any{"type": "string"},
+			},
+			"resourceTypes": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string"},
+			},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
+		},
+		"required": []string{"tagFilters"},
+	}
+}
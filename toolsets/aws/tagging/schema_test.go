@@ -0,0 +1,20 @@
+package awstagging
+
+import (
+	"testing"
+
+	"rootcause/internal/mcp"
+)
+
+func TestTaggingSchemas(t *testing.T) {
+	if schema := schemaSearchByTag(); schema == nil || schema["type"] == "" {
+		t.Fatalf("schema missing type")
+	}
+}
+
+func TestTaggingToolSpecs(t *testing.T) {
+	specs := ToolSpecs(mcp.ToolContext{}, "aws", nil)
+	if len(specs) != 1 || specs[0].Name != "aws.search_by_tag" {
+		t.Fatalf("expected aws.search_by_tag, got %#v", specs)
+	}
+}
@@ -0,0 +1,151 @@
+package awstagging
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	taggingtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+
+	"rootcause/internal/argconv"
+	awslib "rootcause/internal/aws"
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+// maxResourcesPerPage is the GetResources page size ceiling.
+const maxResourcesPerPage = 100
+
+type Service struct {
+	ctx           mcp.ToolContext
+	taggingClient func(context.Context, string) (*resourcegroupstaggingapi.Client, string, error)
+	toolsetID     string
+}
+
+func ToolSpecs(ctx mcp.ToolContext, toolsetID string, taggingClient func(context.Context, string) (*resourcegroupstaggingapi.Client, string, error)) []mcp.ToolSpec {
+	svc := &Service{ctx: ctx, taggingClient: taggingClient, toolsetID: toolsetID}
+	return []mcp.ToolSpec{
+		{
+			Name:        "aws.search_by_tag",
+			Description: "Find resources of any service in a region by tag (Resource Groups Tagging API).",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaSearchByTag(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleSearchByTag,
+		},
+	}
+}
+
+func (s *Service) handleSearchByTag(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	filters := tagFiltersFromArgs(req.Arguments["tagFilters"])
+	if len(filters) == 0 {
+		return awsutil.ErrorResult(errors.New("tagFilters is required")), errors.New("tagFilters is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	resourceTypes := awsutil.ToStringSlice(req.Arguments["resourceTypes"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
+	client, usedRegion, err := s.taggingClient(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input := &resourcegroupstaggingapi.GetResourcesInput{
+		TagFilters:       filters,
+		ResourcesPerPage: aws.Int32(maxResourcesPerPage),
+		PaginationToken:  pager.StartToken(),
+	}
+	if len(resourceTypes) > 0 {
+		input.ResourceTypeFilters = resourceTypes
+	}
+	var resources []map[string]any
+	typeCounts := map[string]int{}
+	for {
+		out, err := client.GetResources(ctx, input)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		start, end, more := pager.Page(aws.ToString(input.PaginationToken), len(out.ResourceTagMappingList), out.PaginationToken)
+		for _, mapping := range out.ResourceTagMappingList[start:end] {
+			summary := summarizeResource(mapping)
+			typeCounts[summary["resourceType"].(string)]++
+			resources = append(resources, summary)
+		}
+		if !more {
+			break
+		}
+		input.PaginationToken = out.PaginationToken
+	}
+	data := map[string]any{
+		"region":        awsutil.RegionOrDefault(usedRegion),
+		"resources":     resources,
+		"count":         len(resources),
+		"resourceTypes": typeCounts,
+	}
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
+}
+
+// tagFiltersFromArgs turns {"app": "checkout", "team": ""} into tag filters;
+// an empty value matches any resource carrying the key.
+func tagFiltersFromArgs(value any) []taggingtypes.TagFilter {
+	tags, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		if strings.TrimSpace(key) != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	filters := make([]taggingtypes.TagFilter, 0, len(keys))
+	for _, key := range keys {
+		filters = append(filters, taggingtypes.TagFilter{
+			Key:    aws.String(key),
+			Values: awsutil.ToStringSlice(tags[key]),
+		})
+	}
+	return filters
+}
+
+func summarizeResource(mapping taggingtypes.ResourceTagMapping) map[string]any {
+	arn := aws.ToString(mapping.ResourceARN)
+	service, resourceType := resourceTypeFromARN(arn)
+	tags := map[string]string{}
+	for _, tag := range mapping.Tags {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return map[string]any{
+		"arn":          arn,
+		"service":      service,
+		"resourceType": resourceType,
+		"tags":         tags,
+	}
+}
+
+// resourceTypeFromARN returns the service and a "service:type" label in the
+// form GetResources accepts as a resource type filter, e.g.
+// arn:aws:ec2:us-east-1:123:instance/i-1 -> ("ec2", "ec2:instance").
+// Resources without a type segment (S3 buckets, SQS queues) report the
+// service alone.
+func resourceTypeFromARN(arn string) (string, string) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 {
+		return "", ""
+	}
+	service, resource := parts[2], parts[5]
+	if idx := strings.IndexAny(resource, "/:"); idx > 0 {
+		return service, service + ":" + resource[:idx]
+	}
+	return service, service
+}
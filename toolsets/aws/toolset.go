@@ -3,8 +3,10 @@ package aws
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	sdkaws "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
@@ -14,6 +16,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
 	"github.com/aws/aws-sdk-go-v2/service/route53resolver"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"golang.org/x/sync/singleflight"
@@ -24,11 +28,18 @@ import (
 	awsecr "rootcause/toolsets/aws/ecr"
 	awseks "rootcause/toolsets/aws/eks"
 	awsiam "rootcause/toolsets/aws/iam"
+	"rootcause/toolsets/aws/internal/awsutil"
 	awskms "rootcause/toolsets/aws/kms"
+	awsroute53 "rootcause/toolsets/aws/route53"
 	awssts "rootcause/toolsets/aws/sts"
+	awstagging "rootcause/toolsets/aws/tagging"
 	awsvpc "rootcause/toolsets/aws/vpc"
 )
 
+// loggedRegion is the last default region logged, so a reload only logs
+// when the effective region actually changes.
+var loggedRegion atomic.Value
+
 type Toolset struct {
 	ctx   mcp.ToolContext
 	cache sync.Map
@@ -64,9 +75,32 @@ func (t *Toolset) Init(ctx mcp.ToolContext) error {
 	t.ctx = ctx
 	t.cache = sync.Map{}
 	t.sf = singleflight.Group{}
+	cfgRegion, cfgProfile, cfgCreds := t.awsConfigDefaults()
+	region, source := awslib.DefaultRegionWithConfig(context.Background(), cfgRegion, cfgProfile, cfgCreds)
+	awsutil.SetDefaultRegion(region)
+	awsutil.SetOmitAge(ctx.Config != nil && ctx.Config.Render.OmitAge)
+	if previous, _ := loggedRegion.Swap(region).(string); previous != region {
+		slog.Info("aws default region", "region", region, "source", source)
+	}
 	return nil
 }
 
+// Preflight confirms the credential chain resolves by calling STS
+// GetCallerIdentity, the same call aws.sts.whoami makes.
+func (t *Toolset) Preflight(ctx context.Context) (string, error) {
+	client, region, err := t.stsClient(ctx, "")
+	if err != nil {
+		return "", err
+	}
+	out, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", fmt.Errorf("aws credentials invalid: %w", err)
+	}
+	arn := awsutil.MaskAccountIDs(t.ctx, sdkaws.ToString(out.Arn))
+	account := awsutil.MaskAccountIDs(t.ctx, sdkaws.ToString(out.Account))
+	return fmt.Sprintf("%s (account %s, region %s)", arn, account, awsutil.RegionOrDefault(region)), nil
+}
+
 func (t *Toolset) Register(reg mcp.Registry) error {
 	for _, tool := range awsiam.ToolSpecs(t.ctx, t.ID(), t.iamClient) {
 		tool = t.wrapListCache(tool)
@@ -110,6 +144,18 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			return fmt.Errorf("register %s: %w", tool.Name, err)
 		}
 	}
+	for _, tool := range awsroute53.ToolSpecs(t.ctx, t.ID(), t.route53Client, t.elbClient) {
+		tool = t.wrapListCache(tool)
+		if err := reg.Add(tool); err != nil {
+			return fmt.Errorf("register %s: %w", tool.Name, err)
+		}
+	}
+	for _, tool := range awstagging.ToolSpecs(t.ctx, t.ID(), t.taggingClient) {
+		tool = t.wrapListCache(tool)
+		if err := reg.Add(tool); err != nil {
+			return fmt.Errorf("register %s: %w", tool.Name, err)
+		}
+	}
 	return nil
 }
 
@@ -212,6 +258,22 @@ func (t *Toolset) stsClient(ctx context.Context, region string) (*sts.Client, st
 	return raw.(*sts.Client), used, nil
 }
 
+func (t *Toolset) route53Client(ctx context.Context, region string) (*route53.Client, string, error) {
+	raw, used, err := t.loadClient(ctx, "route53", region, func(cfg sdkaws.Config) any { return route53.NewFromConfig(cfg) })
+	if err != nil {
+		return nil, "", err
+	}
+	return raw.(*route53.Client), used, nil
+}
+
+func (t *Toolset) taggingClient(ctx context.Context, region string) (*resourcegroupstaggingapi.Client, string, error) {
+	raw, used, err := t.loadClient(ctx, "tagging", region, func(cfg sdkaws.Config) any { return resourcegroupstaggingapi.NewFromConfig(cfg) })
+	if err != nil {
+		return nil, "", err
+	}
+	return raw.(*resourcegroupstaggingapi.Client), used, nil
+}
+
 func (t *Toolset) clientCacheKey(region string) string {
 	cfgRegion, cfgProfile, _ := t.awsConfigDefaults()
 	regionKey := awslib.ResolveRegionWithConfig(region, cfgRegion)
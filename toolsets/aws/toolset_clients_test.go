@@ -2,8 +2,16 @@ package aws
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
+	sdkaws "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"rootcause/internal/config"
 	"rootcause/internal/kube"
 	"rootcause/internal/mcp"
 )
@@ -62,6 +70,14 @@ func TestToolsetClientCaching(t *testing.T) {
 	if err != nil {
 		t.Fatalf("sts client: %v", err)
 	}
+	_, _, err = toolset.route53Client(context.Background(), "")
+	if err != nil {
+		t.Fatalf("route53 client: %v", err)
+	}
+	_, _, err = toolset.taggingClient(context.Background(), "")
+	if err != nil {
+		t.Fatalf("tagging client: %v", err)
+	}
 
 	ecOther, _, err := toolset.ec2Client(context.Background(), "us-east-1")
 	if err != nil || ecOther == nil {
@@ -71,3 +87,48 @@ func TestToolsetClientCaching(t *testing.T) {
 		t.Fatalf("expected different ec2 client for other region")
 	}
 }
+
+func TestToolsetPreflightMasksAccountID(t *testing.T) {
+	t.Setenv("AWS_PROFILE", "")
+	cfg := config.DefaultConfig()
+	cfg.AWS.Region = "us-east-1"
+	cfg.AWS.RedactAccountID = true
+	toolset := New()
+	if err := toolset.Init(mcp.ToolContext{Config: &cfg}); err != nil {
+		t.Fatalf("init toolset: %v", err)
+	}
+	identity := `<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <GetCallerIdentityResult>
+    <Arn>arn:aws:sts::123456789012:assumed-role/ops/alice</Arn>
+    <Account>123456789012</Account>
+    <UserId>AROA:alice</UserId>
+  </GetCallerIdentityResult>
+</GetCallerIdentityResponse>`
+	client := sts.NewFromConfig(sdkaws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(identity)),
+				Header:     http.Header{"Content-Type": []string{"text/xml"}},
+				Request:    req,
+			}, nil
+		})},
+	})
+	toolset.cache.Store("sts|"+toolset.clientCacheKey(""), &clientEntry{client: client, region: "us-east-1"})
+
+	detail, err := toolset.Preflight(context.Background())
+	if err != nil {
+		t.Fatalf("preflight: %v", err)
+	}
+	if strings.Contains(detail, "123456789012") || detail != "arn:aws:sts::[REDACTED]:assumed-role/ops/alice (account [REDACTED], region us-east-1)" {
+		t.Fatalf("expected account id masked in preflight detail, got %q", detail)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
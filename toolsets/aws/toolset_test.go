@@ -1,11 +1,15 @@
 package aws
 
 import (
+	"bytes"
+	"log/slog"
+	"strings"
 	"testing"
 
 	"rootcause/internal/config"
 	"rootcause/internal/kube"
 	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
 )
 
 func TestToolsetInitAndRegister(t *testing.T) {
@@ -44,4 +48,42 @@ func TestToolsetInitAndRegister(t *testing.T) {
 	if _, ok := reg.Get("aws.sts.get_caller_identity"); !ok {
 		t.Fatalf("expected aws.sts.get_caller_identity to be registered")
 	}
+	if _, ok := reg.Get("aws.route53.list_resource_record_sets"); !ok {
+		t.Fatalf("expected aws.route53.list_resource_record_sets to be registered")
+	}
+	if _, ok := reg.Get("aws.search_by_tag"); !ok {
+		t.Fatalf("expected aws.search_by_tag to be registered")
+	}
+}
+
+func TestToolsetInitResolvesDefaultRegion(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	defer awsutil.SetDefaultRegion("")
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	cfg := config.DefaultConfig()
+	cfg.AWS.Region = "eu-west-3"
+	for i := 0; i < 2; i++ {
+		if err := New().Init(mcp.ToolContext{Config: &cfg}); err != nil {
+			t.Fatalf("init: %v", err)
+		}
+	}
+	if got := awsutil.RegionOrDefault(""); got != "eu-west-3" {
+		t.Fatalf("expected config region as default, got %q", got)
+	}
+	if n := strings.Count(logs.String(), "region=eu-west-3"); n != 1 {
+		t.Fatalf("expected unchanged region to be logged once, got %d:\n%s", n, logs.String())
+	}
+	t.Setenv("AWS_REGION", "ca-central-1")
+	if err := New().Init(mcp.ToolContext{Config: &cfg}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if got := awsutil.RegionOrDefault(""); got != "ca-central-1" {
+		t.Fatalf("expected AWS_REGION as default, got %q", got)
+	}
+	if !strings.Contains(logs.String(), "region=ca-central-1") {
+		t.Fatalf("expected changed region to be logged:\n%s", logs.String())
+	}
 }
@@ -0,0 +1,111 @@
+package awsvpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+func (s *Service) handleGetDNSConfig(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
+	if vpcID == "" {
+		return awsutil.ErrorResult(errors.New("vpcId is required")), errors.New("vpcId is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	vpcOut, err := client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{VpcIds: []string{vpcID}})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	if len(vpcOut.Vpcs) == 0 {
+		return awsutil.ErrorResult(fmt.Errorf("vpc %s not found", vpcID)), fmt.Errorf("vpc %s not found", vpcID)
+	}
+	dhcpID := aws.ToString(vpcOut.Vpcs[0].DhcpOptionsId)
+	// "default" means no DHCP options set is associated; instances then use
+	// the Amazon-provided resolver and no search domain.
+	options := ec2types.DhcpOptions{DhcpConfigurations: []ec2types.DhcpConfiguration{{
+		Key:    aws.String("domain-name-servers"),
+		Values: []ec2types.AttributeValue{{Value: aws.String("AmazonProvidedDNS")}},
+	}}}
+	if dhcpID != "" && dhcpID != "default" {
+		dhcpOut, err := client.DescribeDhcpOptions(ctx, &ec2.DescribeDhcpOptionsInput{DhcpOptionsIds: []string{dhcpID}})
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		options = ec2types.DhcpOptions{}
+		if len(dhcpOut.DhcpOptions) > 0 {
+			options = dhcpOut.DhcpOptions[0]
+		}
+	}
+	dhcp := summarizeDhcpOptions(options, nil)
+	nameServers, _ := dhcp["domainNameServers"].([]string)
+	usesAmazonDNS, _ := dhcp["usesAmazonDNS"].(bool)
+	dnsSupport, err := vpcAttributeEnabled(ctx, client, vpcID, ec2types.VpcAttributeNameEnableDnsSupport)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	dnsHostnames, err := vpcAttributeEnabled(ctx, client, vpcID, ec2types.VpcAttributeNameEnableDnsHostnames)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+
+	data := map[string]any{
+		"region":             awsutil.RegionOrDefault(usedRegion),
+		"vpcId":              vpcID,
+		"dhcpOptionsId":      dhcpID,
+		"domainNameServers":  nameServers,
+		"domainName":         dhcp["domainName"],
+		"ntpServers":         dhcp["ntpServers"],
+		"usesAmazonDNS":      usesAmazonDNS,
+		"enableDnsSupport":   dnsSupport,
+		"enableDnsHostnames": dnsHostnames,
+		"warnings":           dnsConfigWarnings(nameServers, usesAmazonDNS, dnsSupport, dnsHostnames),
+	}
+	return mcp.ToolResult{
+		Data: s.ctx.Redactor.RedactValue(data),
+		Metadata: mcp.ToolMetadata{
+			Resources: []string{fmt.Sprintf("ec2/vpc/%s", vpcID)},
+		},
+	}, nil
+}
+
+func vpcAttributeEnabled(ctx context.Context, client *ec2.Client, vpcID string, attribute ec2types.VpcAttributeName) (bool, error) {
+	out, err := client.DescribeVpcAttribute(ctx, &ec2.DescribeVpcAttributeInput{VpcId: aws.String(vpcID), Attribute: attribute})
+	if err != nil {
+		return false, err
+	}
+	switch attribute {
+	case ec2types.VpcAttributeNameEnableDnsSupport:
+		return out.EnableDnsSupport != nil && aws.ToBool(out.EnableDnsSupport.Value), nil
+	case ec2types.VpcAttributeNameEnableDnsHostnames:
+		return out.EnableDnsHostnames != nil && aws.ToBool(out.EnableDnsHostnames.Value), nil
+	}
+	return false, nil
+}
+
+// dnsConfigWarnings explains the settings behind the usual "pods cannot
+// resolve internal names" reports: a disabled VPC resolver, missing DNS
+// hostnames, or custom name servers that bypass the VPC resolver.
+func dnsConfigWarnings(nameServers []string, usesAmazonDNS, dnsSupport, dnsHostnames bool) []string {
+	var warnings []string
+	if !dnsSupport {
+		warnings = append(warnings, "enableDnsSupport is false: the VPC resolver (VPC CIDR base +2) does not answer, so AmazonProvidedDNS, private hosted zones and AWS service names do not resolve")
+	}
+	if !dnsHostnames {
+		warnings = append(warnings, "enableDnsHostnames is false: instances get no DNS hostnames, and private hosted zones and interface endpoint private DNS need it enabled")
+	}
+	if len(nameServers) > 0 && !usesAmazonDNS {
+		warnings = append(warnings, "DHCP options use custom name servers only: private hosted zones, interface endpoint private DNS and the EKS private API endpoint resolve only if those servers forward to the VPC resolver")
+	}
+	return warnings
+}
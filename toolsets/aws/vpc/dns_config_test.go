@@ -0,0 +1,68 @@
+package awsvpc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestDNSConfigWarnings(t *testing.T) {
+	if got := dnsConfigWarnings([]string{"AmazonProvidedDNS"}, true, true, true); len(got) != 0 {
+		t.Fatalf("expected no warnings for default settings: %v", got)
+	}
+	got := dnsConfigWarnings([]string{"10.0.0.10"}, false, false, false)
+	if len(got) != 3 || !strings.Contains(got[0], "enableDnsSupport") || !strings.Contains(got[1], "enableDnsHostnames") || !strings.Contains(got[2], "custom name servers") {
+		t.Fatalf("unexpected warnings: %v", got)
+	}
+}
+
+func TestHandleGetDNSConfig(t *testing.T) {
+	responses := map[string]string{
+		"DescribeVpcs": `<DescribeVpcsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <vpcSet><item><vpcId>vpc-1</vpcId><dhcpOptionsId>dopt-1</dhcpOptionsId></item></vpcSet>
+</DescribeVpcsResponse>`,
+		"DescribeDhcpOptions": `<DescribeDhcpOptionsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <dhcpOptionsSet>
+    <item>
+      <dhcpOptionsId>dopt-1</dhcpOptionsId>
+      <dhcpConfigurationSet>
+        <item><key>domain-name</key><valueSet><item><value>corp.internal</value></item></valueSet></item>
+        <item><key>domain-name-servers</key><valueSet><item><value>10.0.0.10</value></item><item><value>10.0.0.11</value></item></valueSet></item>
+      </dhcpConfigurationSet>
+    </item>
+  </dhcpOptionsSet>
+</DescribeDhcpOptionsResponse>`,
+		"DescribeVpcAttribute": `<DescribeVpcAttributeResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <vpcId>vpc-1</vpcId>
+  <enableDnsSupport><value>true</value></enableDnsSupport>
+  <enableDnsHostnames><value>false</value></enableDnsHostnames>
+</DescribeVpcAttributeResponse>`,
+	}
+	client := newEC2TestClient(t, responses)
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleGetDNSConfig(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"vpcId": "vpc-1"}})
+	if err != nil {
+		t.Fatalf("get dns config: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	servers := data["domainNameServers"].([]string)
+	if len(servers) != 2 || servers[0] != "10.0.0.10" || data["domainName"] != "corp.internal" {
+		t.Fatalf("unexpected dhcp options: %#v", data)
+	}
+	if data["usesAmazonDNS"] != false || data["enableDnsSupport"] != true || data["enableDnsHostnames"] != false || len(data["warnings"].([]string)) != 2 {
+		t.Fatalf("unexpected dns attributes: %#v", data)
+	}
+	if _, err := svc.handleGetDNSConfig(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected error without vpcId")
+	}
+}
@@ -0,0 +1,187 @@
+package awsvpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+// eniOwner names the service and resource holding a network interface.
+type eniOwner struct {
+	Service  string `json:"service"`
+	Resource string `json:"resource,omitempty"`
+	Summary  string `json:"summary"`
+}
+
+// eniDescriptionRule matches the Description AWS services write on the ENIs
+// they create; owner builds the result from the submatches.
+type eniDescriptionRule struct {
+	pattern *regexp.Regexp
+	owner   func(match []string) eniOwner
+}
+
+var eniDescriptionRules = []eniDescriptionRule{
+	{regexp.MustCompile(`^ELB (app|net|gwy)/([^/]+)/`), func(m []string) eniOwner {
+		kinds := map[string]string{"app": "Application", "net": "Network", "gwy": "Gateway"}
+		return eniOwner{Service: "elb", Resource: m[2], Summary: fmt.Sprintf("%s Load Balancer %s", kinds[m[1]], m[2])}
+	}},
+	{regexp.MustCompile(`^ELB (\S+)$`), func(m []string) eniOwner {
+		return eniOwner{Service: "elb", Resource: m[1], Summary: "Classic Load Balancer " + m[1]}
+	}},
+	{regexp.MustCompile(`^Interface for NAT Gateway (nat-[0-9a-f]+)`), func(m []string) eniOwner {
+		return eniOwner{Service: "nat-gateway", Resource: m[1], Summary: "NAT gateway " + m[1]}
+	}},
+	{regexp.MustCompile(`^AWS Lambda VPC ENI-(.+?)(?:-[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})?$`), func(m []string) eniOwner {
+		return eniOwner{Service: "lambda", Resource: m[1], Summary: "Lambda function " + m[1]}
+	}},
+	{regexp.MustCompile(`^aws-K8S-(i-[0-9a-f]+)$`), func(m []string) eniOwner {
+		return eniOwner{Service: "eks", Resource: m[1], Summary: fmt.Sprintf("EKS pod networking (VPC CNI secondary ENI) for node %s", m[1])}
+	}},
+	{regexp.MustCompile(`^aws-k8s-(branch|trunk)-eni`), func(m []string) eniOwner {
+		return eniOwner{Service: "eks", Summary: fmt.Sprintf("EKS security groups for pods (%s ENI)", m[1])}
+	}},
+	{regexp.MustCompile(`^Amazon EKS (\S+)$`), func(m []string) eniOwner {
+		return eniOwner{Service: "eks", Resource: m[1], Summary: fmt.Sprintf("EKS control plane for cluster %s", m[1])}
+	}},
+	{regexp.MustCompile(`^RDSNetworkInterface`), func([]string) eniOwner {
+		return eniOwner{Service: "rds", Summary: "RDS database instance"}
+	}},
+	{regexp.MustCompile(`^EFS mount target for (fs-[0-9a-f]+) \((fsmt-[0-9a-f]+)\)`), func(m []string) eniOwner {
+		return eniOwner{Service: "efs", Resource: m[2], Summary: fmt.Sprintf("EFS mount target %s for %s", m[2], m[1])}
+	}},
+	{regexp.MustCompile(`^VPC Endpoint Interface (vpce-[0-9a-f]+)`), func(m []string) eniOwner {
+		return eniOwner{Service: "vpc-endpoint", Resource: m[1], Summary: "interface VPC endpoint " + m[1]}
+	}},
+	{regexp.MustCompile(`^Network Interface for Transit Gateway Attachment (tgw-attach-[0-9a-f]+)`), func(m []string) eniOwner {
+		return eniOwner{Service: "transit-gateway", Resource: m[1], Summary: "transit gateway attachment " + m[1]}
+	}},
+	{regexp.MustCompile(`^arn:aws[a-z-]*:ecs:[^:]+:\d+:attachment/(\S+)`), func(m []string) eniOwner {
+		return eniOwner{Service: "ecs", Resource: m[1], Summary: "ECS task (awsvpc network mode) attachment " + m[1]}
+	}},
+	{regexp.MustCompile(`^ElastiCache (\S+)`), func(m []string) eniOwner {
+		return eniOwner{Service: "elasticache", Resource: m[1], Summary: "ElastiCache cluster " + m[1]}
+	}},
+	{regexp.MustCompile(`^Route 53 Resolver: (rslvr-(?:in|out)-[0-9a-f]+)`), func(m []string) eniOwner {
+		return eniOwner{Service: "route53resolver", Resource: m[1], Summary: "Route 53 Resolver endpoint " + m[1]}
+	}},
+	{regexp.MustCompile(`^AWS created network interface for directory (d-[0-9a-f]+)`), func(m []string) eniOwner {
+		return eniOwner{Service: "directoryservice", Resource: m[1], Summary: "Directory Service directory " + m[1]}
+	}},
+	{regexp.MustCompile(`^DMSNetworkInterface`), func([]string) eniOwner {
+		return eniOwner{Service: "dms", Summary: "DMS replication instance"}
+	}},
+}
+
+// eniInterfaceTypes covers interface types whose owner is clear without a
+// description match.
+var eniInterfaceTypes = map[ec2types.NetworkInterfaceType]eniOwner{
+	ec2types.NetworkInterfaceTypeNatGateway:                    {Service: "nat-gateway", Summary: "NAT gateway"},
+	ec2types.NetworkInterfaceTypeNetworkLoadBalancer:           {Service: "elb", Summary: "Network Load Balancer"},
+	ec2types.NetworkInterfaceTypeGatewayLoadBalancer:           {Service: "elb", Summary: "Gateway Load Balancer"},
+	ec2types.NetworkInterfaceTypeGatewayLoadBalancerEndpoint:   {Service: "vpc-endpoint", Summary: "Gateway Load Balancer endpoint"},
+	ec2types.NetworkInterfaceTypeVpcEndpoint:                   {Service: "vpc-endpoint", Summary: "interface VPC endpoint"},
+	ec2types.NetworkInterfaceTypeLambda:                        {Service: "lambda", Summary: "Lambda function"},
+	ec2types.NetworkInterfaceTypeLoadBalancer:                  {Service: "elb", Summary: "Classic Load Balancer"},
+	ec2types.NetworkInterfaceTypeIotRulesManaged:               {Service: "iot", Summary: "IoT rule VPC destination"},
+	ec2types.NetworkInterfaceTypeTransitGateway:                {Service: "transit-gateway", Summary: "transit gateway attachment"},
+	ec2types.NetworkInterfaceTypeApiGatewayManaged:             {Service: "apigateway", Summary: "API Gateway VPC link"},
+	ec2types.NetworkInterfaceTypeQuicksight:                    {Service: "quicksight", Summary: "QuickSight VPC connection"},
+	ec2types.NetworkInterfaceTypeGlobalAcceleratorManaged:      {Service: "globalaccelerator", Summary: "Global Accelerator endpoint"},
+	ec2types.NetworkInterfaceTypeAwsCodestarConnectionsManaged: {Service: "codestar-connections", Summary: "CodeStar Connections host"},
+	ec2types.NetworkInterfaceTypeTrunk:                         {Service: "eks", Summary: "EKS security groups for pods (trunk ENI)"},
+	ec2types.NetworkInterfaceTypeBranch:                        {Service: "eks", Summary: "EKS security groups for pods (branch ENI)"},
+}
+
+func (s *Service) handleDescribeENIOwner(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	ifaceID := awsutil.ToString(req.Arguments["networkInterfaceId"])
+	if ifaceID == "" {
+		return awsutil.ErrorResult(errors.New("networkInterfaceId is required")), errors.New("networkInterfaceId is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	out, err := client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{NetworkInterfaceIds: []string{ifaceID}})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	if len(out.NetworkInterfaces) == 0 {
+		return awsutil.ErrorResult(fmt.Errorf("network interface %s not found", ifaceID)), fmt.Errorf("network interface %s not found", ifaceID)
+	}
+	iface := out.NetworkInterfaces[0]
+	owner := resolveENIOwner(iface)
+	result := map[string]any{
+		"region":           awsutil.RegionOrDefault(usedRegion),
+		"networkInterface": summarizeNetworkInterface(iface),
+		"owner":            owner,
+		"requesterManaged": aws.ToBool(iface.RequesterManaged),
+		"requesterId":      aws.ToString(iface.RequesterId),
+		"inUse":            iface.Status == ec2types.NetworkInterfaceStatusInUse,
+		"deletion":         eniDeletionHint(iface, owner),
+	}
+	return mcp.ToolResult{
+		Data: s.ctx.Redactor.RedactValue(result),
+		Metadata: mcp.ToolMetadata{
+			Resources: []string{fmt.Sprintf("ec2/network-interface/%s", ifaceID)},
+		},
+	}, nil
+}
+
+// resolveENIOwner prefers the description, which usually names the exact
+// resource, then the interface type, then the requester and attachment.
+func resolveENIOwner(iface ec2types.NetworkInterface) eniOwner {
+	description := strings.TrimSpace(aws.ToString(iface.Description))
+	for _, rule := range eniDescriptionRules {
+		if match := rule.pattern.FindStringSubmatch(description); match != nil {
+			return rule.owner(match)
+		}
+	}
+	if owner, ok := eniInterfaceTypes[iface.InterfaceType]; ok {
+		return owner
+	}
+	requester := aws.ToString(iface.RequesterId)
+	switch requester {
+	case "amazon-elb":
+		return eniOwner{Service: "elb", Summary: "Elastic Load Balancing"}
+	case "amazon-rds":
+		return eniOwner{Service: "rds", Summary: "RDS database instance"}
+	case "amazon-elasticache":
+		return eniOwner{Service: "elasticache", Summary: "ElastiCache cluster"}
+	}
+	if iface.Attachment != nil && aws.ToString(iface.Attachment.InstanceId) != "" {
+		instanceID := aws.ToString(iface.Attachment.InstanceId)
+		return eniOwner{Service: "ec2", Resource: instanceID, Summary: "EC2 instance " + instanceID}
+	}
+	if aws.ToBool(iface.RequesterManaged) {
+		summary := "an AWS service"
+		if requester != "" {
+			summary = "an AWS service (requester " + requester + ")"
+		}
+		return eniOwner{Service: "unknown", Summary: summary}
+	}
+	return eniOwner{Service: "none", Summary: "no owner; the interface was created directly and is not attached"}
+}
+
+func eniDeletionHint(iface ec2types.NetworkInterface, owner eniOwner) string {
+	switch {
+	case owner.Service == "lambda":
+		return "Lambda deletes its ENIs itself; they can remain for a while after the function is deleted or its VPC configuration is removed."
+	case aws.ToBool(iface.RequesterManaged):
+		return fmt.Sprintf("Managed by %s; delete or reconfigure that resource instead of the interface.", owner.Summary)
+	case iface.Status == ec2types.NetworkInterfaceStatusAvailable:
+		return "Not attached; it can be deleted if nothing is expected to reattach it."
+	case owner.Service == "eks":
+		return "Released by the VPC CNI when pods or the node go away; deleting it directly can break pod networking."
+	}
+	return fmt.Sprintf("Attached to %s; detach it or remove that resource first.", owner.Summary)
+}
@@ -0,0 +1,93 @@
+package awsvpc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestResolveENIOwner(t *testing.T) {
+	cases := []struct {
+		name     string
+		iface    ec2types.NetworkInterface
+		service  string
+		resource string
+	}{
+		{"alb", ec2types.NetworkInterface{Description: aws.String("ELB app/web/50dc6c495c0c9188")}, "elb", "web"},
+		{"classic elb", ec2types.NetworkInterface{Description: aws.String("ELB legacy-web")}, "elb", "legacy-web"},
+		{"nat", ec2types.NetworkInterface{Description: aws.String("Interface for NAT Gateway nat-0abc123")}, "nat-gateway", "nat-0abc123"},
+		{"lambda", ec2types.NetworkInterface{Description: aws.String("AWS Lambda VPC ENI-orders-api-6f1b2c3d-1234-4abc-9def-0123456789ab")}, "lambda", "orders-api"},
+		{"eks cni", ec2types.NetworkInterface{Description: aws.String("aws-K8S-i-0123abcd")}, "eks", "i-0123abcd"},
+		{"eks control plane", ec2types.NetworkInterface{Description: aws.String("Amazon EKS prod")}, "eks", "prod"},
+		{"rds", ec2types.NetworkInterface{Description: aws.String("RDSNetworkInterface")}, "rds", ""},
+		{"efs", ec2types.NetworkInterface{Description: aws.String("EFS mount target for fs-1a2b (fsmt-3c4d)")}, "efs", "fsmt-3c4d"},
+		{"type only", ec2types.NetworkInterface{InterfaceType: ec2types.NetworkInterfaceTypeVpcEndpoint}, "vpc-endpoint", ""},
+		{"requester", ec2types.NetworkInterface{RequesterId: aws.String("amazon-rds"), RequesterManaged: aws.Bool(true)}, "rds", ""},
+		{"instance", ec2types.NetworkInterface{Attachment: &ec2types.NetworkInterfaceAttachment{InstanceId: aws.String("i-9")}}, "ec2", "i-9"},
+		{"unknown service", ec2types.NetworkInterface{RequesterId: aws.String("123456789012"), RequesterManaged: aws.Bool(true)}, "unknown", ""},
+		{"orphan", ec2types.NetworkInterface{Status: ec2types.NetworkInterfaceStatusAvailable}, "none", ""},
+	}
+	for _, tc := range cases {
+		owner := resolveENIOwner(tc.iface)
+		if owner.Service != tc.service || owner.Resource != tc.resource {
+			t.Fatalf("%s: unexpected owner %#v", tc.name, owner)
+		}
+	}
+}
+
+func TestENIDeletionHint(t *testing.T) {
+	managed := ec2types.NetworkInterface{RequesterManaged: aws.Bool(true), Status: ec2types.NetworkInterfaceStatusInUse}
+	if hint := eniDeletionHint(managed, eniOwner{Service: "nat-gateway", Summary: "NAT gateway nat-1"}); !strings.Contains(hint, "NAT gateway nat-1") {
+		t.Fatalf("unexpected managed hint: %s", hint)
+	}
+	detached := ec2types.NetworkInterface{Status: ec2types.NetworkInterfaceStatusAvailable}
+	if hint := eniDeletionHint(detached, eniOwner{Service: "none"}); !strings.Contains(hint, "can be deleted") {
+		t.Fatalf("unexpected detached hint: %s", hint)
+	}
+}
+
+func TestHandleDescribeENIOwner(t *testing.T) {
+	responses := map[string]string{
+		"DescribeNetworkInterfaces": `<DescribeNetworkInterfacesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <networkInterfaceSet>
+    <item>
+      <networkInterfaceId>eni-1</networkInterfaceId>
+      <interfaceType>lambda</interfaceType>
+      <description>AWS Lambda VPC ENI-orders-api-6f1b2c3d-1234-4abc-9def-0123456789ab</description>
+      <requesterId>AROAEXAMPLE:orders-api</requesterId>
+      <requesterManaged>true</requesterManaged>
+      <status>in-use</status>
+    </item>
+  </networkInterfaceSet>
+</DescribeNetworkInterfacesResponse>`,
+	}
+	client := newEC2TestClient(t, responses)
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleDescribeENIOwner(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"networkInterfaceId": "eni-1"}})
+	if err != nil {
+		t.Fatalf("describe eni owner: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	owner := data["owner"].(eniOwner)
+	if owner.Service != "lambda" || owner.Resource != "orders-api" {
+		t.Fatalf("unexpected owner: %#v", owner)
+	}
+	if data["inUse"] != true || data["requesterManaged"] != true || !strings.Contains(data["deletion"].(string), "Lambda") {
+		t.Fatalf("unexpected result: %#v", data)
+	}
+	if _, err := svc.handleDescribeENIOwner(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected missing id error")
+	}
+}
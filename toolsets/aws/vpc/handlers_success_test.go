@@ -25,6 +25,7 @@ func TestVPCHandlersWithStubbedClient(t *testing.T) {
       <vpcId>vpc-1</vpcId>
       <cidrBlock>10.0.0.0/16</cidrBlock>
       <isDefault>true</isDefault>
+      <dhcpOptionsId>dopt-1</dhcpOptionsId>
     </item>
   </vpcSet>
 </DescribeVpcsResponse>`,
@@ -113,6 +114,61 @@ func TestVPCHandlersWithStubbedClient(t *testing.T) {
     </item>
   </internetGatewaySet>
 </DescribeInternetGatewaysResponse>`,
+		"DescribeEgressOnlyInternetGateways": `<DescribeEgressOnlyInternetGatewaysResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <egressOnlyInternetGatewaySet>
+    <item>
+      <egressOnlyInternetGatewayId>eigw-1</egressOnlyInternetGatewayId>
+      <attachmentSet>
+        <item>
+          <vpcId>vpc-1</vpcId>
+          <state>attached</state>
+        </item>
+      </attachmentSet>
+    </item>
+    <item>
+      <egressOnlyInternetGatewayId>eigw-2</egressOnlyInternetGatewayId>
+      <attachmentSet>
+        <item>
+          <vpcId>vpc-2</vpcId>
+          <state>attached</state>
+        </item>
+      </attachmentSet>
+    </item>
+  </egressOnlyInternetGatewaySet>
+</DescribeEgressOnlyInternetGatewaysResponse>`,
+		"DescribeDhcpOptions": `<DescribeDhcpOptionsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <dhcpOptionsSet>
+    <item>
+      <dhcpOptionsId>dopt-1</dhcpOptionsId>
+      <dhcpConfigurationSet>
+        <item>
+          <key>domain-name-servers</key>
+          <valueSet>
+            <item><value>10.0.0.53</value></item>
+          </valueSet>
+        </item>
+      </dhcpConfigurationSet>
+    </item>
+  </dhcpOptionsSet>
+</DescribeDhcpOptionsResponse>`,
+		"DescribeManagedPrefixLists": `<DescribeManagedPrefixListsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <prefixListSet>
+    <item>
+      <prefixListId>pl-63a5400a</prefixListId>
+      <prefixListName>com.amazonaws.us-east-1.s3</prefixListName>
+      <addressFamily>IPv4</addressFamily>
+      <ownerId>AWS</ownerId>
+      <state>create-complete</state>
+    </item>
+  </prefixListSet>
+</DescribeManagedPrefixListsResponse>`,
+		"GetManagedPrefixListEntries": `<GetManagedPrefixListEntriesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <entrySet>
+    <item>
+      <cidr>52.216.0.0/15</cidr>
+    </item>
+  </entrySet>
+</GetManagedPrefixListEntriesResponse>`,
 		"DescribeVpcEndpoints": `<DescribeVpcEndpointsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
   <vpcEndpointSet>
     <item>
@@ -127,6 +183,45 @@ func TestVPCHandlersWithStubbedClient(t *testing.T) {
     </item>
   </vpcEndpointSet>
 </DescribeVpcEndpointsResponse>`,
+		"DescribeVpcEndpointServiceConfigurations": `<DescribeVpcEndpointServiceConfigurationsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <serviceConfigurationSet>
+    <item>
+      <serviceId>vpce-svc-1</serviceId>
+      <serviceName>com.amazonaws.vpce.us-east-1.vpce-svc-1</serviceName>
+      <serviceState>Available</serviceState>
+      <acceptanceRequired>true</acceptanceRequired>
+    </item>
+  </serviceConfigurationSet>
+</DescribeVpcEndpointServiceConfigurationsResponse>`,
+		"DescribeVpcEndpointServicePermissions": `<DescribeVpcEndpointServicePermissionsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <allowedPrincipals>
+    <item>
+      <principal>arn:aws:iam::123456789012:root</principal>
+      <principalType>Account</principalType>
+    </item>
+  </allowedPrincipals>
+</DescribeVpcEndpointServicePermissionsResponse>`,
+		"DescribeVpcEndpointConnections": `<DescribeVpcEndpointConnectionsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <vpcEndpointConnectionSet>
+    <item>
+      <vpcEndpointId>vpce-a</vpcEndpointId>
+      <vpcEndpointState>pendingAcceptance</vpcEndpointState>
+    </item>
+    <item>
+      <vpcEndpointId>vpce-b</vpcEndpointId>
+      <vpcEndpointState>available</vpcEndpointState>
+    </item>
+  </vpcEndpointConnectionSet>
+</DescribeVpcEndpointConnectionsResponse>`,
+		"DescribeVpcEndpointServices": `<DescribeVpcEndpointServicesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <serviceDetailSet>
+    <item>
+      <serviceId>vpce-svc-2</serviceId>
+      <serviceName>com.amazonaws.us-east-1.s3</serviceName>
+      <owner>amazon</owner>
+    </item>
+  </serviceDetailSet>
+</DescribeVpcEndpointServicesResponse>`,
 		"DescribeNetworkInterfaces": `<DescribeNetworkInterfacesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
   <networkInterfaceSet>
     <item>
@@ -203,12 +298,69 @@ func TestVPCHandlersWithStubbedClient(t *testing.T) {
 	if _, err := svc.handleGetInternetGateway(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"internetGatewayId": "igw-1"}}); err != nil {
 		t.Fatalf("get internet gateway: %v", err)
 	}
+	eigws, err := svc.handleListEgressOnlyInternetGateways(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"vpcId": "vpc-1"}})
+	if err != nil {
+		t.Fatalf("list egress-only internet gateways: %v", err)
+	}
+	if gateways := eigws.Data.(map[string]any)["egressOnlyInternetGateways"].([]map[string]any); len(gateways) != 1 || gateways[0]["id"] != "eigw-1" {
+		t.Fatalf("expected only the vpc-1 gateway, got %#v", gateways)
+	}
+	if _, err := svc.handleGetEgressOnlyInternetGateway(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"egressOnlyInternetGatewayId": "eigw-1"}}); err != nil {
+		t.Fatalf("get egress-only internet gateway: %v", err)
+	}
+	dhcp, err := svc.handleListDhcpOptions(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"vpcId": "vpc-1"}})
+	if err != nil {
+		t.Fatalf("list dhcp options: %v", err)
+	}
+	options := dhcp.Data.(map[string]any)["dhcpOptions"].([]map[string]any)
+	if len(options) != 1 || options[0]["usesAmazonDNS"] != false || len(options[0]["vpcIds"].([]string)) != 1 {
+		t.Fatalf("unexpected dhcp options: %#v", options)
+	}
+	if _, err := svc.handleGetDhcpOptions(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"dhcpOptionsId": "dopt-1"}}); err != nil {
+		t.Fatalf("get dhcp options: %v", err)
+	}
+	lists, err := svc.handleListPrefixLists(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"managedBy": "customer"}})
+	if err != nil {
+		t.Fatalf("list prefix lists: %v", err)
+	}
+	if count := lists.Data.(map[string]any)["count"]; count != 0 {
+		t.Fatalf("expected AWS-managed list to be filtered out, got %v", count)
+	}
+	prefixList, err := svc.handleGetPrefixList(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"prefixListId": "pl-63a5400a"}})
+	if err != nil {
+		t.Fatalf("get prefix list: %v", err)
+	}
+	summary := prefixList.Data.(map[string]any)["prefixList"].(map[string]any)
+	if summary["managedBy"] != "aws" || len(summary["cidrs"].([]string)) != 1 {
+		t.Fatalf("unexpected prefix list: %#v", summary)
+	}
 	if _, err := svc.handleListEndpoints(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"limit": 1}}); err != nil {
 		t.Fatalf("list endpoints: %v", err)
 	}
 	if _, err := svc.handleGetEndpoint(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"endpointId": "vpce-1"}}); err != nil {
 		t.Fatalf("get endpoint: %v", err)
 	}
+	if _, err := svc.handleListEndpointServices(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"limit": 1}}); err != nil {
+		t.Fatalf("list endpoint services: %v", err)
+	}
+	available, err := svc.handleListEndpointServices(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"available": true}})
+	if err != nil {
+		t.Fatalf("list available endpoint services: %v", err)
+	}
+	if services := available.Data.(map[string]any)["services"].([]map[string]any); len(services) != 1 || services[0]["owner"] != "amazon" {
+		t.Fatalf("unexpected available services: %#v", services)
+	}
+	endpointService, err := svc.handleGetEndpointService(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"serviceId": "vpce-svc-1"}})
+	if err != nil {
+		t.Fatalf("get endpoint service: %v", err)
+	}
+	service := endpointService.Data.(map[string]any)["service"].(map[string]any)
+	if pending := service["pendingAcceptance"].([]string); len(pending) != 1 || pending[0] != "vpce-a" {
+		t.Fatalf("expected pending connection, got %#v", service)
+	}
+	if len(service["allowedPrincipals"].([]map[string]any)) != 1 || service["connectionStates"].(map[string]int)["available"] != 1 {
+		t.Fatalf("unexpected endpoint service: %#v", service)
+	}
 	if _, err := svc.handleListNetworkInterfaces(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"limit": 1}}); err != nil {
 		t.Fatalf("list network interfaces: %v", err)
 	}
@@ -41,6 +41,10 @@ func TestVPCHandlerValidation(t *testing.T) {
 		{"getSecurityGroupMissing", svc.handleGetSecurityGroup, map[string]any{}, "groupId is required"},
 		{"getNetworkAclMissing", svc.handleGetNetworkAcl, map[string]any{}, "networkAclId is required"},
 		{"getInternetGatewayMissing", svc.handleGetInternetGateway, map[string]any{}, "internetGatewayId is required"},
+		{"getEgressOnlyInternetGatewayMissing", svc.handleGetEgressOnlyInternetGateway, map[string]any{}, "egressOnlyInternetGatewayId is required"},
+		{"getDhcpOptionsMissing", svc.handleGetDhcpOptions, map[string]any{}, "dhcpOptionsId is required"},
+		{"getPrefixListMissing", svc.handleGetPrefixList, map[string]any{}, "prefixListId is required"},
+		{"getEndpointServiceMissing", svc.handleGetEndpointService, map[string]any{}, "serviceId or serviceName is required"},
 		{"getEndpointMissing", svc.handleGetEndpoint, map[string]any{}, "endpointId is required"},
 		{"getNetworkInterfaceMissing", svc.handleGetNetworkInterface, map[string]any{}, "networkInterfaceId is required"},
 		{"getResolverEndpointMissing", svc.handleGetResolverEndpoint, map[string]any{}, "resolverEndpointId is required"},
@@ -1,14 +1,10 @@
 package awsvpc
 
 import (
-	"encoding/json"
 	"testing"
 )
 
 func TestVPCTypeHelpers(t *testing.T) {
-	if got := toInt(json.Number("9"), 1); got != 9 {
-		t.Fatalf("unexpected toInt: %d", got)
-	}
 	filters := tagFiltersFromArgs(map[string]any{
 		"":      []string{"skip"},
 		"env":   "dev",
@@ -0,0 +1,205 @@
+package awsvpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+func (s *Service) handleInstanceNetworkSummary(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	instanceID := awsutil.ToString(req.Arguments["instanceId"])
+	if instanceID == "" {
+		return awsutil.ErrorResult(errors.New("instanceId is required")), errors.New("instanceId is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	instOut, err := client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{InstanceIds: []string{instanceID}})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	var inst *ec2types.Instance
+	for _, reservation := range instOut.Reservations {
+		for i := range reservation.Instances {
+			if aws.ToString(reservation.Instances[i].InstanceId) == instanceID {
+				inst = &reservation.Instances[i]
+			}
+		}
+	}
+	if inst == nil {
+		return awsutil.ErrorResult(fmt.Errorf("instance %s not found", instanceID)), fmt.Errorf("instance %s not found", instanceID)
+	}
+	subnetID := aws.ToString(inst.SubnetId)
+	vpcID := aws.ToString(inst.VpcId)
+	if subnetID == "" {
+		err := fmt.Errorf("instance %s has no subnet (EC2-Classic or terminated)", instanceID)
+		return awsutil.ErrorResult(err), err
+	}
+
+	subnetOut, err := client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{SubnetIds: []string{subnetID}})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	// A subnet without an explicit association uses the VPC's main table.
+	routeTableSource := "explicit"
+	rtOut, err := client.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{
+		Filters: []ec2types.Filter{{Name: aws.String("association.subnet-id"), Values: []string{subnetID}}},
+	})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	if len(rtOut.RouteTables) == 0 {
+		routeTableSource = "main"
+		rtOut, err = client.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{
+			Filters: []ec2types.Filter{
+				{Name: aws.String("vpc-id"), Values: []string{vpcID}},
+				{Name: aws.String("association.main"), Values: []string{"true"}},
+			},
+		})
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+	}
+	aclOut, err := client.DescribeNetworkAcls(ctx, &ec2.DescribeNetworkAclsInput{
+		Filters: []ec2types.Filter{{Name: aws.String("association.subnet-id"), Values: []string{subnetID}}},
+	})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	var groupIDs []string
+	for _, group := range inst.SecurityGroups {
+		groupIDs = append(groupIDs, aws.ToString(group.GroupId))
+	}
+	var groups []map[string]any
+	if len(groupIDs) > 0 {
+		sgOut, err := client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{GroupIds: groupIDs})
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		var resolver *prefixListResolver
+		if awsutil.ToBool(req.Arguments["expandPrefixLists"], false) {
+			resolver = newPrefixListResolver(client)
+		}
+		for _, sg := range sgOut.SecurityGroups {
+			group := summarizeSecurityGroup(sg)
+			if resolver != nil {
+				if err := resolver.expandSecurityGroup(ctx, group); err != nil {
+					return awsutil.ErrorResult(err), err
+				}
+			}
+			groups = append(groups, group)
+		}
+	}
+
+	data := map[string]any{
+		"region": awsutil.RegionOrDefault(usedRegion),
+		"instance": map[string]any{
+			"id":        instanceID,
+			"state":     inst.State,
+			"vpcId":     vpcID,
+			"subnetId":  subnetID,
+			"privateIp": aws.ToString(inst.PrivateIpAddress),
+			"publicIp":  aws.ToString(inst.PublicIpAddress),
+		},
+		"securityGroups": groups,
+	}
+	if len(subnetOut.Subnets) > 0 {
+		data["subnet"] = summarizeSubnet(subnetOut.Subnets[0])
+	}
+	var table *ec2types.RouteTable
+	if len(rtOut.RouteTables) > 0 {
+		table = &rtOut.RouteTables[0]
+		summary := summarizeRouteTable(*table)
+		summary["source"] = routeTableSource
+		data["routeTable"] = summary
+	}
+	if len(aclOut.NetworkAcls) > 0 {
+		data["networkAcl"] = summarizeNetworkAcl(aclOut.NetworkAcls[0])
+	}
+	data["reachability"] = classifySubnetReachability(table, aws.ToString(inst.PublicIpAddress) != "")
+	return mcp.ToolResult{
+		Data: s.ctx.Redactor.RedactValue(data),
+		Metadata: mcp.ToolMetadata{
+			Resources: []string{fmt.Sprintf("ec2/instance/%s", instanceID)},
+		},
+	}, nil
+}
+
+// classifySubnetReachability reads the default route of a subnet's route
+// table: to an internet gateway makes the subnet public, to a NAT gateway
+// private with outbound internet, to a transit gateway, peering or appliance
+// private behind that hop, and no default route isolated.
+func classifySubnetReachability(table *ec2types.RouteTable, hasPublicIP bool) map[string]any {
+	out := map[string]any{"hasPublicIp": hasPublicIP}
+	if table == nil {
+		out["subnetType"] = "unknown"
+		out["note"] = "no route table found for the subnet"
+		return out
+	}
+	var defaultRoute *ec2types.Route
+	for i := range table.Routes {
+		route := &table.Routes[i]
+		if aws.ToString(route.DestinationCidrBlock) == "0.0.0.0/0" {
+			defaultRoute = route
+			break
+		}
+	}
+	if defaultRoute == nil {
+		out["subnetType"] = "isolated"
+		out["note"] = "no 0.0.0.0/0 route; only VPC-local and explicitly routed destinations are reachable"
+		return out
+	}
+	target, kind := routeTarget(*defaultRoute)
+	out["defaultRouteTarget"] = target
+	if defaultRoute.State == ec2types.RouteStateBlackhole {
+		out["subnetType"] = "private"
+		out["note"] = fmt.Sprintf("default route to %s is a blackhole; outbound internet traffic is dropped", target)
+		return out
+	}
+	switch kind {
+	case "igw":
+		out["subnetType"] = "public"
+		if hasPublicIP {
+			out["note"] = "public subnet and the instance has a public IP; internet traffic flows both ways if security groups and NACLs allow it"
+		} else {
+			out["note"] = "public subnet but the instance has no public IP, so it has no internet access in either direction"
+		}
+	case "nat":
+		out["subnetType"] = "private"
+		out["note"] = fmt.Sprintf("private subnet; outbound internet goes through NAT gateway %s and inbound from the internet is not possible", target)
+	default:
+		out["subnetType"] = "private"
+		out["note"] = fmt.Sprintf("private subnet; the default route goes to %s %s", kind, target)
+	}
+	return out
+}
+
+func routeTarget(route ec2types.Route) (string, string) {
+	switch {
+	case aws.ToString(route.NatGatewayId) != "":
+		return aws.ToString(route.NatGatewayId), "nat"
+	case strings.HasPrefix(aws.ToString(route.GatewayId), "igw-"):
+		return aws.ToString(route.GatewayId), "igw"
+	case aws.ToString(route.TransitGatewayId) != "":
+		return aws.ToString(route.TransitGatewayId), "transit gateway"
+	case aws.ToString(route.VpcPeeringConnectionId) != "":
+		return aws.ToString(route.VpcPeeringConnectionId), "peering connection"
+	case aws.ToString(route.NetworkInterfaceId) != "":
+		return aws.ToString(route.NetworkInterfaceId), "network interface"
+	case aws.ToString(route.InstanceId) != "":
+		return aws.ToString(route.InstanceId), "instance"
+	case aws.ToString(route.GatewayId) != "":
+		return aws.ToString(route.GatewayId), "gateway"
+	}
+	return "", "unknown target"
+}
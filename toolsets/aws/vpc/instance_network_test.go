@@ -0,0 +1,123 @@
+package awsvpc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestClassifySubnetReachability(t *testing.T) {
+	local := ec2types.Route{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local")}
+	cases := []struct {
+		name       string
+		table      *ec2types.RouteTable
+		publicIP   bool
+		wantType   string
+		wantInNote string
+	}{
+		{"no table", nil, false, "unknown", "no route table"},
+		{"isolated", &ec2types.RouteTable{Routes: []ec2types.Route{local}}, false, "isolated", "no 0.0.0.0/0"},
+		{"public", &ec2types.RouteTable{Routes: []ec2types.Route{local, {DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")}}}, true, "public", "has a public IP"},
+		{"public without ip", &ec2types.RouteTable{Routes: []ec2types.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")}}}, false, "public", "no public IP"},
+		{"nat", &ec2types.RouteTable{Routes: []ec2types.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1")}}}, false, "private", "NAT gateway nat-1"},
+		{"transit", &ec2types.RouteTable{Routes: []ec2types.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), TransitGatewayId: aws.String("tgw-1")}}}, false, "private", "transit gateway tgw-1"},
+		{"blackhole", &ec2types.RouteTable{Routes: []ec2types.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1"), State: ec2types.RouteStateBlackhole}}}, false, "private", "blackhole"},
+	}
+	for _, tc := range cases {
+		got := classifySubnetReachability(tc.table, tc.publicIP)
+		if got["subnetType"] != tc.wantType || !strings.Contains(got["note"].(string), tc.wantInNote) {
+			t.Fatalf("%s: unexpected reachability %#v", tc.name, got)
+		}
+	}
+}
+
+func TestHandleInstanceNetworkSummary(t *testing.T) {
+	responses := map[string]string{
+		"DescribeInstances": `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <reservationSet>
+    <item>
+      <instancesSet>
+        <item>
+          <instanceId>i-1</instanceId>
+          <subnetId>subnet-1</subnetId>
+          <vpcId>vpc-1</vpcId>
+          <privateIpAddress>10.0.1.10</privateIpAddress>
+          <instanceState><code>16</code><name>running</name></instanceState>
+          <groupSet><item><groupId>sg-1</groupId><groupName>web</groupName></item></groupSet>
+        </item>
+      </instancesSet>
+    </item>
+  </reservationSet>
+</DescribeInstancesResponse>`,
+		"DescribeSubnets": `<DescribeSubnetsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <subnetSet><item><subnetId>subnet-1</subnetId><vpcId>vpc-1</vpcId><cidrBlock>10.0.1.0/24</cidrBlock></item></subnetSet>
+</DescribeSubnetsResponse>`,
+		"DescribeRouteTables": `<DescribeRouteTablesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <routeTableSet>
+    <item>
+      <routeTableId>rtb-1</routeTableId>
+      <vpcId>vpc-1</vpcId>
+      <routeSet>
+        <item><destinationCidrBlock>10.0.0.0/16</destinationCidrBlock><gatewayId>local</gatewayId><state>active</state></item>
+        <item><destinationCidrBlock>0.0.0.0/0</destinationCidrBlock><natGatewayId>nat-1</natGatewayId><state>active</state></item>
+      </routeSet>
+    </item>
+  </routeTableSet>
+</DescribeRouteTablesResponse>`,
+		"DescribeNetworkAcls": `<DescribeNetworkAclsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <networkAclSet>
+    <item>
+      <networkAclId>acl-1</networkAclId>
+      <vpcId>vpc-1</vpcId>
+      <entrySet>
+        <item><ruleNumber>100</ruleNumber><protocol>-1</protocol><ruleAction>allow</ruleAction><egress>false</egress><cidrBlock>0.0.0.0/0</cidrBlock></item>
+      </entrySet>
+    </item>
+  </networkAclSet>
+</DescribeNetworkAclsResponse>`,
+		"DescribeSecurityGroups": `<DescribeSecurityGroupsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <securityGroupInfo>
+    <item>
+      <groupId>sg-1</groupId>
+      <groupName>web</groupName>
+      <vpcId>vpc-1</vpcId>
+      <ipPermissions>
+        <item><ipProtocol>tcp</ipProtocol><fromPort>443</fromPort><toPort>443</toPort><ipRanges><item><cidrIp>10.0.0.0/16</cidrIp></item></ipRanges></item>
+      </ipPermissions>
+    </item>
+  </securityGroupInfo>
+</DescribeSecurityGroupsResponse>`,
+	}
+	client := newEC2TestClient(t, responses)
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleInstanceNetworkSummary(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"instanceId": "i-1"}})
+	if err != nil {
+		t.Fatalf("instance network summary: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	reach := data["reachability"].(map[string]any)
+	if reach["subnetType"] != "private" || reach["defaultRouteTarget"] != "nat-1" {
+		t.Fatalf("unexpected reachability: %#v", reach)
+	}
+	if data["routeTable"].(map[string]any)["source"] != "explicit" || data["networkAcl"] == nil || data["subnet"] == nil {
+		t.Fatalf("unexpected summary: %#v", data)
+	}
+	if groups := data["securityGroups"].([]map[string]any); len(groups) != 1 {
+		t.Fatalf("unexpected security groups: %#v", groups)
+	}
+	if _, err := svc.handleInstanceNetworkSummary(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected error without instanceId")
+	}
+}
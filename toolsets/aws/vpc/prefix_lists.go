@@ -0,0 +1,66 @@
+package awsvpc
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// prefixListResolver inlines the CIDRs of managed and customer prefix lists
+// referenced by security group rules. Entries are cached for the lifetime of
+// one request so a prefix list shared by many rules is fetched once.
+type prefixListResolver struct {
+	client *ec2.Client
+	cache  map[string][]map[string]any
+}
+
+func newPrefixListResolver(client *ec2.Client) *prefixListResolver {
+	return &prefixListResolver{client: client, cache: map[string][]map[string]any{}}
+}
+
+func (r *prefixListResolver) entries(ctx context.Context, id string) ([]map[string]any, error) {
+	if cached, ok := r.cache[id]; ok {
+		return cached, nil
+	}
+	entries := []map[string]any{}
+	paginator := ec2.NewGetManagedPrefixListEntriesPaginator(r.client, &ec2.GetManagedPrefixListEntriesInput{PrefixListId: aws.String(id)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range page.Entries {
+			entries = append(entries, map[string]any{
+				"cidr":        aws.ToString(entry.Cidr),
+				"description": aws.ToString(entry.Description),
+			})
+		}
+	}
+	r.cache[id] = entries
+	return entries, nil
+}
+
+// expandSecurityGroup adds a prefixLists list with the resolved entries next
+// to prefixListIds on every inbound and outbound rule of a summarized group.
+func (r *prefixListResolver) expandSecurityGroup(ctx context.Context, group map[string]any) error {
+	for _, direction := range []string{"inbound", "outbound"} {
+		rules, _ := group[direction].([]map[string]any)
+		for _, rule := range rules {
+			ids, _ := rule["prefixListIds"].([]string)
+			if len(ids) == 0 {
+				continue
+			}
+			lists := make([]map[string]any, 0, len(ids))
+			for _, id := range ids {
+				entries, err := r.entries(ctx, id)
+				if err != nil {
+					return err
+				}
+				lists = append(lists, map[string]any{"id": id, "entries": entries})
+			}
+			rule["prefixLists"] = lists
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package awsvpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestPrefixListResolverUsesCache(t *testing.T) {
+	resolver := newPrefixListResolver(nil)
+	resolver.cache["pl-1"] = []map[string]any{{"cidr": "10.1.0.0/16"}}
+	group := map[string]any{
+		"inbound":  []map[string]any{{"prefixListIds": []string{"pl-1"}}, {"ipv4Ranges": []string{"10.0.0.0/8"}}},
+		"outbound": []map[string]any{{"prefixListIds": []string{"pl-1"}}},
+	}
+	if err := resolver.expandSecurityGroup(context.Background(), group); err != nil {
+		t.Fatalf("expand: %v", err)
+	}
+	inbound := group["inbound"].([]map[string]any)
+	if lists := inbound[0]["prefixLists"].([]map[string]any); lists[0]["id"] != "pl-1" {
+		t.Fatalf("unexpected prefix lists: %#v", lists)
+	}
+	if _, ok := inbound[1]["prefixLists"]; ok {
+		t.Fatalf("rule without prefix lists should be untouched: %#v", inbound[1])
+	}
+	if _, ok := group["outbound"].([]map[string]any)[0]["prefixLists"]; !ok {
+		t.Fatalf("expected outbound rule to be expanded")
+	}
+}
+
+func TestHandleGetSecurityGroupExpandPrefixLists(t *testing.T) {
+	responses := map[string]string{
+		"DescribeSecurityGroups": `<DescribeSecurityGroupsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <securityGroupInfo>
+    <item>
+      <groupId>sg-1</groupId>
+      <groupName>web</groupName>
+      <ipPermissions>
+        <item><ipProtocol>tcp</ipProtocol><fromPort>443</fromPort><toPort>443</toPort><prefixListIds><item><prefixListId>pl-1</prefixListId></item></prefixListIds></item>
+      </ipPermissions>
+    </item>
+  </securityGroupInfo>
+</DescribeSecurityGroupsResponse>`,
+		"GetManagedPrefixListEntries": `<GetManagedPrefixListEntriesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <entrySet>
+    <item><cidr>10.1.0.0/16</cidr><description>office</description></item>
+    <item><cidr>10.2.0.0/16</cidr></item>
+  </entrySet>
+</GetManagedPrefixListEntriesResponse>`,
+	}
+	client := newEC2TestClient(t, responses)
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleGetSecurityGroup(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"groupId": "sg-1", "expandPrefixLists": true}})
+	if err != nil {
+		t.Fatalf("get security group: %v", err)
+	}
+	rule := result.Data.(map[string]any)["securityGroup"].(map[string]any)["inbound"].([]map[string]any)[0]
+	lists := rule["prefixLists"].([]map[string]any)
+	entries := lists[0]["entries"].([]map[string]any)
+	if len(entries) != 2 || entries[0]["cidr"] != "10.1.0.0/16" || entries[0]["description"] != "office" {
+		t.Fatalf("unexpected prefix list entries: %#v", lists)
+	}
+
+	result, err = svc.handleGetSecurityGroup(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"groupId": "sg-1"}})
+	if err != nil {
+		t.Fatalf("get security group: %v", err)
+	}
+	rule = result.Data.(map[string]any)["securityGroup"].(map[string]any)["inbound"].([]map[string]any)[0]
+	if _, ok := rule["prefixLists"]; ok {
+		t.Fatalf("prefix lists should only be expanded on request: %#v", rule)
+	}
+}
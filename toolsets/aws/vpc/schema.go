@@ -8,8 +8,9 @@ func schemaVPCListVPCs() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -25,6 +26,17 @@ func schemaVPCGetVPC() map[string]any {
 	}
 }
 
+func schemaVPCGetDNSConfig() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"vpcId":  map[string]any{"type": "string"},
+			"region": map[string]any{"type": "string"},
+		},
+		"required": []string{"vpcId"},
+	}
+}
+
 func schemaVPCListSubnets() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -38,8 +50,9 @@ func schemaVPCListSubnets() map[string]any {
 				"type":                 "object",
 				"additionalProperties": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -55,6 +68,34 @@ func schemaVPCGetSubnet() map[string]any {
 	}
 }
 
+func schemaVPCAnalyzeSubnetCapacity() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"vpcId": map[string]any{"type": "string"},
+			"subnetIds": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string"},
+			},
+			"thresholdPercent": map[string]any{"type": "number", "description": "Flag subnets at or above this IPv4 utilization (default 80)."},
+			"limit":            map[string]any{"type": "number", "description": "Maximum subnets to check (default 200)."},
+			"region":           map[string]any{"type": "string"},
+		},
+	}
+}
+
+func schemaVPCInstanceNetworkSummary() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"instanceId":        map[string]any{"type": "string"},
+			"expandPrefixLists": map[string]any{"type": "boolean", "description": "Resolve referenced prefix lists and inline their CIDRs."},
+			"region":            map[string]any{"type": "string"},
+		},
+		"required": []string{"instanceId"},
+	}
+}
+
 func schemaVPCListRouteTables() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -64,8 +105,9 @@ func schemaVPCListRouteTables() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -93,8 +135,9 @@ func schemaVPCListNatGateways() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -123,8 +166,10 @@ func schemaVPCListSecurityGroups() map[string]any {
 				"type":                 "object",
 				"additionalProperties": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":             map[string]any{"type": "number"},
+			"nextToken":         map[string]any{"type": "string"},
+			"expandPrefixLists": map[string]any{"type": "boolean", "description": "Resolve referenced prefix lists and inline their CIDRs."},
+			"region":            map[string]any{"type": "string"},
 		},
 	}
 }
@@ -133,8 +178,9 @@ func schemaVPCGetSecurityGroup() map[string]any {
 	return map[string]any{
 		"type": "object",
 		"properties": map[string]any{
-			"groupId": map[string]any{"type": "string"},
-			"region":  map[string]any{"type": "string"},
+			"groupId":           map[string]any{"type": "string"},
+			"expandPrefixLists": map[string]any{"type": "boolean", "description": "Resolve referenced prefix lists and inline their CIDRs."},
+			"region":            map[string]any{"type": "string"},
 		},
 		"required": []string{"groupId"},
 	}
@@ -149,8 +195,9 @@ func schemaVPCListNetworkAcls() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -175,8 +222,9 @@ func schemaVPCListInternetGateways() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -192,6 +240,114 @@ func schemaVPCGetInternetGateway() map[string]any {
 	}
 }
 
+func schemaVPCListEgressOnlyInternetGateways() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"vpcId": map[string]any{"type": "string"},
+			"egressOnlyInternetGatewayIds": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string"},
+			},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
+		},
+	}
+}
+
+func schemaVPCGetEgressOnlyInternetGateway() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"egressOnlyInternetGatewayId": map[string]any{"type": "string"},
+			"region":                      map[string]any{"type": "string"},
+		},
+		"required": []string{"egressOnlyInternetGatewayId"},
+	}
+}
+
+func schemaVPCListDhcpOptions() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"vpcId": map[string]any{"type": "string"},
+			"dhcpOptionsIds": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string"},
+			},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
+		},
+	}
+}
+
+func schemaVPCGetDhcpOptions() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"dhcpOptionsId": map[string]any{"type": "string"},
+			"region":        map[string]any{"type": "string"},
+		},
+		"required": []string{"dhcpOptionsId"},
+	}
+}
+
+func schemaVPCListPrefixLists() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"prefixListIds": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string"},
+			},
+			"managedBy": map[string]any{"type": "string", "enum": []string{"aws", "customer"}},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
+		},
+	}
+}
+
+func schemaVPCGetPrefixList() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"prefixListId": map[string]any{"type": "string"},
+			"region":       map[string]any{"type": "string"},
+		},
+		"required": []string{"prefixListId"},
+	}
+}
+
+func schemaVPCListEndpointServices() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"serviceIds": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string"},
+			},
+			"available": map[string]any{"type": "boolean"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
+		},
+	}
+}
+
+func schemaVPCGetEndpointService() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"serviceId":   map[string]any{"type": "string"},
+			"serviceName": map[string]any{"type": "string"},
+			"region":      map[string]any{"type": "string"},
+		},
+	}
+}
+
 func schemaVPCListEndpoints() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -201,8 +357,9 @@ func schemaVPCListEndpoints() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -228,8 +385,9 @@ func schemaVPCListNetworkInterfaces() map[string]any {
 				"type":  "array",
 				"items": map[string]any{"type": "string"},
 			},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -245,13 +403,25 @@ func schemaVPCGetNetworkInterface() map[string]any {
 	}
 }
 
+func schemaVPCDescribeENIOwner() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"networkInterfaceId": map[string]any{"type": "string"},
+			"region":             map[string]any{"type": "string"},
+		},
+		"required": []string{"networkInterfaceId"},
+	}
+}
+
 func schemaVPCListResolverEndpoints() map[string]any {
 	return map[string]any{
 		"type": "object",
 		"properties": map[string]any{
-			"vpcId":  map[string]any{"type": "string"},
-			"limit":  map[string]any{"type": "number"},
-			"region": map[string]any{"type": "string"},
+			"vpcId":     map[string]any{"type": "string"},
+			"limit":     map[string]any{"type": "number"},
+			"nextToken": map[string]any{"type": "string"},
+			"region":    map[string]any{"type": "string"},
 		},
 	}
 }
@@ -274,6 +444,7 @@ func schemaVPCListResolverRules() map[string]any {
 			"resolverEndpointId": map[string]any{"type": "string"},
 			"ruleType":           map[string]any{"type": "string"},
 			"limit":              map[string]any{"type": "number"},
+			"nextToken":          map[string]any{"type": "string"},
 			"region":             map[string]any{"type": "string"},
 		},
 	}
@@ -12,6 +12,9 @@ func TestVPCSchemas(t *testing.T) {
 		schemaVPCGetVPC(),
 		schemaVPCListSubnets(),
 		schemaVPCGetSubnet(),
+		schemaVPCAnalyzeSubnetCapacity(),
+		schemaVPCInstanceNetworkSummary(),
+		schemaVPCGetDNSConfig(),
 		schemaVPCListRouteTables(),
 		schemaVPCGetRouteTable(),
 		schemaVPCListNatGateways(),
@@ -22,10 +25,19 @@ func TestVPCSchemas(t *testing.T) {
 		schemaVPCGetNetworkAcl(),
 		schemaVPCListInternetGateways(),
 		schemaVPCGetInternetGateway(),
+		schemaVPCListEgressOnlyInternetGateways(),
+		schemaVPCGetEgressOnlyInternetGateway(),
+		schemaVPCListDhcpOptions(),
+		schemaVPCGetDhcpOptions(),
+		schemaVPCListPrefixLists(),
+		schemaVPCGetPrefixList(),
 		schemaVPCListEndpoints(),
 		schemaVPCGetEndpoint(),
+		schemaVPCListEndpointServices(),
+		schemaVPCGetEndpointService(),
 		schemaVPCListNetworkInterfaces(),
 		schemaVPCGetNetworkInterface(),
+		schemaVPCDescribeENIOwner(),
 		schemaVPCListResolverEndpoints(),
 		schemaVPCGetResolverEndpoint(),
 		schemaVPCListResolverRules(),
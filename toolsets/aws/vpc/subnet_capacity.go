@@ -0,0 +1,188 @@
+package awsvpc
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/netip"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/argconv"
+	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
+)
+
+const (
+	// AWS keeps the network address, the VPC router, DNS, a future-use
+	// address and the broadcast address of every subnet.
+	subnetReservedIPs        = 5
+	defaultSubnetThreshold   = 80
+	defaultCapacitySubnets   = 200
+	subnetInterfaceBatchSize = 100
+	// ipv4PrefixSize is the address count of a delegated /28 prefix, which
+	// the VPC CNI assigns when prefix delegation is on.
+	ipv4PrefixSize = 16
+)
+
+// interfaceUsage counts the ENIs in a subnet and the IPv4 addresses they hold.
+type interfaceUsage struct {
+	interfaces int
+	addresses  int
+}
+
+func (s *Service) handleAnalyzeSubnetCapacity(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	region := awsutil.ToString(req.Arguments["region"])
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
+	ids := awsutil.ToStringSlice(req.Arguments["subnetIds"])
+	threshold := argconv.Int(req.Arguments["thresholdPercent"], defaultSubnetThreshold)
+	if threshold <= 0 || threshold > 100 {
+		err := errors.New("thresholdPercent must be greater than 0 and at most 100")
+		return awsutil.ErrorResult(err), err
+	}
+	limit := argconv.Int(req.Arguments["limit"], defaultCapacitySubnets)
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input := &ec2.DescribeSubnetsInput{}
+	if len(ids) > 0 {
+		input.SubnetIds = ids
+	}
+	if vpcID != "" {
+		input.Filters = append(input.Filters, ec2types.Filter{
+			Name:   aws.String("vpc-id"),
+			Values: []string{vpcID},
+		})
+	}
+	var subnets []ec2types.Subnet
+	truncated := false
+	for {
+		out, err := client.DescribeSubnets(ctx, input)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		subnets = append(subnets, out.Subnets...)
+		if limit > 0 && len(subnets) >= limit {
+			truncated = len(subnets) > limit || aws.ToString(out.NextToken) != ""
+			subnets = subnets[:limit]
+			break
+		}
+		if aws.ToString(out.NextToken) == "" {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+
+	usage := map[string]interfaceUsage{}
+	for start := 0; start < len(subnets); start += subnetInterfaceBatchSize {
+		end := min(start+subnetInterfaceBatchSize, len(subnets))
+		subnetIDs := make([]string, 0, end-start)
+		for _, subnet := range subnets[start:end] {
+			subnetIDs = append(subnetIDs, aws.ToString(subnet.SubnetId))
+		}
+		eniInput := &ec2.DescribeNetworkInterfacesInput{
+			Filters: []ec2types.Filter{{Name: aws.String("subnet-id"), Values: subnetIDs}},
+		}
+		for {
+			out, err := client.DescribeNetworkInterfaces(ctx, eniInput)
+			if err != nil {
+				return awsutil.ErrorResult(err), err
+			}
+			for _, eni := range out.NetworkInterfaces {
+				subnetID := aws.ToString(eni.SubnetId)
+				current := usage[subnetID]
+				current.interfaces++
+				current.addresses += len(eni.PrivateIpAddresses) + ipv4PrefixSize*len(eni.Ipv4Prefixes)
+				usage[subnetID] = current
+			}
+			if aws.ToString(out.NextToken) == "" {
+				break
+			}
+			eniInput.NextToken = out.NextToken
+		}
+	}
+
+	data := assessSubnetCapacity(subnets, usage, threshold)
+	data["region"] = awsutil.RegionOrDefault(usedRegion)
+	if vpcID != "" {
+		data["vpcId"] = vpcID
+	}
+	if truncated {
+		data["truncated"] = true
+	}
+	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+}
+
+// assessSubnetCapacity works out IPv4 utilization per subnet. Usable
+// addresses are the CIDR size less the five AWS reserves; used addresses are
+// whatever of those AvailableIpAddressCount no longer covers. Subnets at or
+// above thresholdPercent are flagged, "exhausted" once nothing is left.
+// Results are ordered fullest first.
+func assessSubnetCapacity(subnets []ec2types.Subnet, usage map[string]interfaceUsage, thresholdPercent int) map[string]any {
+	var results []map[string]any
+	flagged := []string{}
+	for _, subnet := range subnets {
+		subnetID := aws.ToString(subnet.SubnetId)
+		entry := map[string]any{
+			"subnetId":         subnetID,
+			"vpcId":            aws.ToString(subnet.VpcId),
+			"cidrBlock":        aws.ToString(subnet.CidrBlock),
+			"availabilityZone": aws.ToString(subnet.AvailabilityZone),
+		}
+		if name := awsutil.TagMap(subnet.Tags)["Name"]; name != "" {
+			entry["name"] = name
+		}
+		prefix, err := netip.ParsePrefix(aws.ToString(subnet.CidrBlock))
+		if err != nil || !prefix.Addr().Is4() {
+			entry["status"] = "unknown"
+			entry["note"] = "subnet has no parsable IPv4 CIDR"
+			results = append(results, entry)
+			continue
+		}
+		total := 1 << (32 - prefix.Bits())
+		usable := max(total-subnetReservedIPs, 0)
+		available := int(aws.ToInt32(subnet.AvailableIpAddressCount))
+		used := max(usable-available, 0)
+		utilization := 0.0
+		if usable > 0 {
+			utilization = math.Round(float64(used)/float64(usable)*1000) / 10
+		}
+		entry["totalIps"] = total
+		entry["reservedIps"] = subnetReservedIPs
+		entry["usableIps"] = usable
+		entry["availableIps"] = available
+		entry["usedIps"] = used
+		entry["utilizationPercent"] = utilization
+		eni := usage[subnetID]
+		entry["networkInterfaces"] = eni.interfaces
+		entry["interfaceIps"] = eni.addresses
+		switch {
+		case available == 0:
+			entry["status"] = "exhausted"
+		case utilization >= float64(thresholdPercent):
+			entry["status"] = "high"
+		default:
+			entry["status"] = "ok"
+		}
+		if entry["status"] != "ok" {
+			flagged = append(flagged, subnetID)
+		}
+		results = append(results, entry)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		ui, _ := results[i]["utilizationPercent"].(float64)
+		uj, _ := results[j]["utilizationPercent"].(float64)
+		return ui > uj
+	})
+	return map[string]any{
+		"thresholdPercent": thresholdPercent,
+		"subnets":          results,
+		"count":            len(results),
+		"flagged":          flagged,
+		"flaggedCount":     len(flagged),
+	}
+}
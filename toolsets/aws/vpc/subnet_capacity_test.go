@@ -0,0 +1,94 @@
+package awsvpc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/redact"
+)
+
+func TestAssessSubnetCapacity(t *testing.T) {
+	subnets := []ec2types.Subnet{
+		{SubnetId: aws.String("subnet-ok"), CidrBlock: aws.String("10.0.0.0/24"), AvailableIpAddressCount: aws.Int32(200)},
+		{SubnetId: aws.String("subnet-full"), CidrBlock: aws.String("10.0.1.0/28"), AvailableIpAddressCount: aws.Int32(0)},
+		{SubnetId: aws.String("subnet-high"), CidrBlock: aws.String("10.0.2.0/24"), AvailableIpAddressCount: aws.Int32(20)},
+		{SubnetId: aws.String("subnet-v6")},
+	}
+	usage := map[string]interfaceUsage{"subnet-high": {interfaces: 3, addresses: 231}}
+	got := assessSubnetCapacity(subnets, usage, 80)
+	if got["count"] != 4 || got["flaggedCount"] != 2 {
+		t.Fatalf("unexpected counts: %#v", got)
+	}
+	entries := got["subnets"].([]map[string]any)
+	full := entries[0]
+	if full["subnetId"] != "subnet-full" || full["status"] != "exhausted" || full["usableIps"] != 11 || full["utilizationPercent"] != 100.0 {
+		t.Fatalf("unexpected exhausted entry: %#v", full)
+	}
+	high := entries[1]
+	if high["status"] != "high" || high["usedIps"] != 231 || high["utilizationPercent"] != 92.0 || high["networkInterfaces"] != 3 {
+		t.Fatalf("unexpected high entry: %#v", high)
+	}
+	if entries[2]["status"] != "ok" || entries[2]["utilizationPercent"] != 20.3 {
+		t.Fatalf("unexpected ok entry: %#v", entries[2])
+	}
+	if entries[3]["status"] != "unknown" {
+		t.Fatalf("expected subnet without IPv4 CIDR to be unknown: %#v", entries[3])
+	}
+}
+
+func TestHandleAnalyzeSubnetCapacity(t *testing.T) {
+	responses := map[string]string{
+		"DescribeSubnets": `<DescribeSubnetsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <subnetSet>
+    <item>
+      <subnetId>subnet-1</subnetId>
+      <vpcId>vpc-1</vpcId>
+      <cidrBlock>10.0.1.0/26</cidrBlock>
+      <availableIpAddressCount>4</availableIpAddressCount>
+      <tagSet><item><key>Name</key><value>eks-a</value></item></tagSet>
+    </item>
+  </subnetSet>
+</DescribeSubnetsResponse>`,
+		"DescribeNetworkInterfaces": `<DescribeNetworkInterfacesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <networkInterfaceSet>
+    <item>
+      <networkInterfaceId>eni-1</networkInterfaceId>
+      <subnetId>subnet-1</subnetId>
+      <privateIpAddressesSet>
+        <item><privateIpAddress>10.0.1.10</privateIpAddress></item>
+        <item><privateIpAddress>10.0.1.11</privateIpAddress></item>
+      </privateIpAddressesSet>
+      <ipv4PrefixSet><item><ipv4Prefix>10.0.1.16/28</ipv4Prefix></item></ipv4PrefixSet>
+    </item>
+  </networkInterfaceSet>
+</DescribeNetworkInterfacesResponse>`,
+	}
+	client := newEC2TestClient(t, responses)
+	svc := &Service{
+		ctx: mcp.ToolContext{Redactor: redact.New()},
+		ec2Client: func(context.Context, string) (*ec2.Client, string, error) {
+			return client, "us-east-1", nil
+		},
+	}
+	result, err := svc.handleAnalyzeSubnetCapacity(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"vpcId": "vpc-1"}})
+	if err != nil {
+		t.Fatalf("analyze subnet capacity: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	entry := data["subnets"].([]map[string]any)[0]
+	if data["flaggedCount"] != 1 || entry["name"] != "eks-a" || entry["status"] != "high" || entry["interfaceIps"] != 18 {
+		t.Fatalf("unexpected subnet capacity: %#v", data)
+	}
+	for _, threshold := range []int{0, 150} {
+		_, err := svc.handleAnalyzeSubnetCapacity(context.Background(), mcp.ToolRequest{Arguments: map[string]any{"thresholdPercent": threshold}})
+		if err == nil || !strings.Contains(err.Error(), "greater than 0 and at most 100") {
+			t.Fatalf("expected out of range error for threshold %d, got %v", threshold, err)
+		}
+	}
+}
@@ -83,6 +83,13 @@ func TestSummarizersExtended(t *testing.T) {
 	if igwSummary["id"] != "igw-1" {
 		t.Fatalf("unexpected internet gateway summary: %#v", igwSummary)
 	}
+	eigwSummary := summarizeEgressOnlyInternetGateway(ec2types.EgressOnlyInternetGateway{
+		EgressOnlyInternetGatewayId: aws.String("eigw-1"),
+		Attachments:                 igw.Attachments,
+	})
+	if attachments := eigwSummary["attachments"].([]map[string]any); len(attachments) != 1 || attachments[0]["state"] != ec2types.AttachmentStatusAttached {
+		t.Fatalf("unexpected egress-only internet gateway summary: %#v", eigwSummary)
+	}
 
 	endpoint := ec2types.VpcEndpoint{
 		VpcEndpointId:       aws.String("vpce-1"),
@@ -159,6 +166,18 @@ func TestSummarizersExtended(t *testing.T) {
 		t.Fatalf("expected subnet ipv6 summary")
 	}
 
+	dhcp := summarizeDhcpOptions(ec2types.DhcpOptions{
+		DhcpOptionsId: aws.String("dopt-1"),
+		DhcpConfigurations: []ec2types.DhcpConfiguration{
+			{Key: aws.String("domain-name"), Values: []ec2types.AttributeValue{{Value: aws.String("ec2.internal")}}},
+			{Key: aws.String("domain-name-servers"), Values: []ec2types.AttributeValue{{Value: aws.String("AmazonProvidedDNS")}}},
+			{Key: aws.String("ntp-servers"), Values: []ec2types.AttributeValue{{Value: aws.String("169.254.169.123")}}},
+		},
+	}, []string{"vpc-1"})
+	if dhcp["domainName"] != "ec2.internal" || dhcp["usesAmazonDNS"] != true || len(dhcp["ntpServers"].([]string)) != 1 {
+		t.Fatalf("unexpected dhcp options summary: %#v", dhcp)
+	}
+
 	filters := summarizeNatFilters("vpc-1", "subnet-1", []string{"nat-1"})
 	if filters["vpcId"] != "vpc-1" {
 		t.Fatalf("unexpected nat filters: %#v", filters)
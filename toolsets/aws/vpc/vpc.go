@@ -2,7 +2,6 @@ package awsvpc
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -13,7 +12,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/route53resolver"
 	r53types "github.com/aws/aws-sdk-go-v2/service/route53resolver/types"
 
+	"rootcause/internal/argconv"
+	awslib "rootcause/internal/aws"
 	"rootcause/internal/mcp"
+	"rootcause/toolsets/aws/internal/awsutil"
 )
 
 type Service struct {
@@ -47,6 +49,14 @@ func ToolSpecs(
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     svc.handleGetVPC,
 		},
+		{
+			Name:        "aws.vpc.get_dns_config",
+			Description: "Get a VPC's DHCP options (name servers, domain name) and its DNS support and hostname attributes.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaVPCGetDNSConfig(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleGetDNSConfig,
+		},
 		{
 			Name:        "aws.vpc.list_subnets",
 			Description: "List subnets (optional VPC or subnet id filters).",
@@ -63,6 +73,22 @@ func ToolSpecs(
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     svc.handleGetSubnet,
 		},
+		{
+			Name:        "aws.vpc.analyze_subnet_capacity",
+			Description: "Report IPv4 utilization per subnet and flag subnets close to IP exhaustion.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaVPCAnalyzeSubnetCapacity(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleAnalyzeSubnetCapacity,
+		},
+		{
+			Name:        "aws.vpc.instance_network_summary",
+			Description: "Summarize an instance's subnet, route table, network ACL and security groups, and whether the subnet is public or private.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaVPCInstanceNetworkSummary(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleInstanceNetworkSummary,
+		},
 		{
 			Name:        "aws.vpc.list_route_tables",
 			Description: "List route tables (optional VPC or route table id filters).",
@@ -143,6 +169,54 @@ func ToolSpecs(
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     svc.handleGetInternetGateway,
 		},
+		{
+			Name:        "aws.vpc.list_egress_only_internet_gateways",
+			Description: "List egress-only internet gateways used for IPv6 outbound traffic (optional VPC filter).",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaVPCListEgressOnlyInternetGateways(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleListEgressOnlyInternetGateways,
+		},
+		{
+			Name:        "aws.vpc.get_egress_only_internet_gateway",
+			Description: "Get an egress-only internet gateway by id.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaVPCGetEgressOnlyInternetGateway(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleGetEgressOnlyInternetGateway,
+		},
+		{
+			Name:        "aws.vpc.list_dhcp_options",
+			Description: "List DHCP option sets with their DNS/NTP servers and the VPCs using them.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaVPCListDhcpOptions(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleListDhcpOptions,
+		},
+		{
+			Name:        "aws.vpc.get_dhcp_options",
+			Description: "Get a DHCP option set by id.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaVPCGetDhcpOptions(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleGetDhcpOptions,
+		},
+		{
+			Name:        "aws.vpc.list_prefix_lists",
+			Description: "List managed prefix lists (AWS-managed and customer-managed).",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaVPCListPrefixLists(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleListPrefixLists,
+		},
+		{
+			Name:        "aws.vpc.get_prefix_list",
+			Description: "Get a managed prefix list by id with its CIDR entries.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaVPCGetPrefixList(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleGetPrefixList,
+		},
 		{
 			Name:        "aws.vpc.list_vpc_endpoints",
 			Description: "List VPC endpoints (optional VPC or endpoint id filters).",
@@ -159,6 +233,22 @@ func ToolSpecs(
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     svc.handleGetEndpoint,
 		},
+		{
+			Name:        "aws.vpc.list_endpoint_services",
+			Description: "List PrivateLink endpoint services owned by the account, or those available to consume.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaVPCListEndpointServices(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleListEndpointServices,
+		},
+		{
+			Name:        "aws.vpc.get_endpoint_service",
+			Description: "Get a PrivateLink endpoint service with allowed principals and endpoint connection states.",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaVPCGetEndpointService(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleGetEndpointService,
+		},
 		{
 			Name:        "aws.vpc.list_network_interfaces",
 			Description: "List network interfaces (optional VPC, subnet, or interface id filters).",
@@ -175,6 +265,14 @@ func ToolSpecs(
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     svc.handleGetNetworkInterface,
 		},
+		{
+			Name:        "aws.vpc.describe_eni_owner",
+			Description: "Identify the service and resource that owns a network interface (ELB, NAT gateway, Lambda, EKS pod networking, RDS and others).",
+			ToolsetID:   toolsetID,
+			InputSchema: schemaVPCDescribeENIOwner(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     svc.handleDescribeENIOwner,
+		},
 		{
 			Name:        "aws.vpc.list_resolver_endpoints",
 			Description: "List Route53 Resolver endpoints (optional VPC filter).",
@@ -211,64 +309,68 @@ func ToolSpecs(
 }
 
 func (s *Service) handleListVPCs(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	ids := toStringSlice(req.Arguments["vpcIds"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	ids := awsutil.ToStringSlice(req.Arguments["vpcIds"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeVpcsInput{}
 	if len(ids) > 0 {
 		input.VpcIds = ids
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var vpcs []map[string]any
 	for {
 		out, err := client.DescribeVpcs(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, vpc := range out.Vpcs {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.Vpcs), out.NextToken)
+		for _, vpc := range out.Vpcs[start:end] {
 			vpcs = append(vpcs, summarizeVPC(vpc))
-			if limit > 0 && len(vpcs) >= limit {
-				break
-			}
-		}
-		if limit > 0 && len(vpcs) >= limit {
-			break
 		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region": regionOrDefault(usedRegion),
+		"region": awsutil.RegionOrDefault(usedRegion),
 		"vpcs":   vpcs,
 		"count":  len(vpcs),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetVPC(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	vpcID := toString(req.Arguments["vpcId"])
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
 	if vpcID == "" {
-		return errorResult(errors.New("vpcId is required")), errors.New("vpcId is required")
+		return awsutil.ErrorResult(errors.New("vpcId is required")), errors.New("vpcId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{VpcIds: []string{vpcID}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.Vpcs) == 0 {
-		return errorResult(fmt.Errorf("vpc %s not found", vpcID)), fmt.Errorf("vpc %s not found", vpcID)
+		return awsutil.ErrorResult(fmt.Errorf("vpc %s not found", vpcID)), fmt.Errorf("vpc %s not found", vpcID)
 	}
 	result := map[string]any{
-		"region": regionOrDefault(usedRegion),
+		"region": awsutil.RegionOrDefault(usedRegion),
 		"vpc":    summarizeVPC(out.Vpcs[0]),
 	}
 	return mcp.ToolResult{
@@ -280,14 +382,14 @@ func (s *Service) handleGetVPC(ctx context.Context, req mcp.ToolRequest) (mcp.To
 }
 
 func (s *Service) handleListSubnets(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	vpcID := toString(req.Arguments["vpcId"])
-	ids := toStringSlice(req.Arguments["subnetIds"])
+	region := awsutil.ToString(req.Arguments["region"])
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
+	ids := awsutil.ToStringSlice(req.Arguments["subnetIds"])
 	tagFilters := tagFiltersFromArgs(req.Arguments["tagFilters"])
-	limit := toInt(req.Arguments["limit"], 100)
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeSubnetsInput{}
 	if len(ids) > 0 {
@@ -302,53 +404,57 @@ func (s *Service) handleListSubnets(ctx context.Context, req mcp.ToolRequest) (m
 	if len(tagFilters) > 0 {
 		input.Filters = append(input.Filters, tagFilters...)
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var subnets []map[string]any
 	for {
 		out, err := client.DescribeSubnets(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, subnet := range out.Subnets {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.Subnets), out.NextToken)
+		for _, subnet := range out.Subnets[start:end] {
 			subnets = append(subnets, summarizeSubnet(subnet))
-			if limit > 0 && len(subnets) >= limit {
-				break
-			}
-		}
-		if limit > 0 && len(subnets) >= limit {
-			break
 		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":  regionOrDefault(usedRegion),
+		"region":  awsutil.RegionOrDefault(usedRegion),
 		"subnets": subnets,
 		"count":   len(subnets),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetSubnet(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	subnetID := toString(req.Arguments["subnetId"])
+	subnetID := awsutil.ToString(req.Arguments["subnetId"])
 	if subnetID == "" {
-		return errorResult(errors.New("subnetId is required")), errors.New("subnetId is required")
+		return awsutil.ErrorResult(errors.New("subnetId is required")), errors.New("subnetId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{SubnetIds: []string{subnetID}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.Subnets) == 0 {
-		return errorResult(fmt.Errorf("subnet %s not found", subnetID)), fmt.Errorf("subnet %s not found", subnetID)
+		return awsutil.ErrorResult(fmt.Errorf("subnet %s not found", subnetID)), fmt.Errorf("subnet %s not found", subnetID)
 	}
 	result := map[string]any{
-		"region": regionOrDefault(usedRegion),
+		"region": awsutil.RegionOrDefault(usedRegion),
 		"subnet": summarizeSubnet(out.Subnets[0]),
 	}
 	return mcp.ToolResult{
@@ -360,13 +466,13 @@ func (s *Service) handleGetSubnet(ctx context.Context, req mcp.ToolRequest) (mcp
 }
 
 func (s *Service) handleListRouteTables(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	vpcID := toString(req.Arguments["vpcId"])
-	ids := toStringSlice(req.Arguments["routeTableIds"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
+	ids := awsutil.ToStringSlice(req.Arguments["routeTableIds"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeRouteTablesInput{}
 	if len(ids) > 0 {
@@ -378,53 +484,57 @@ func (s *Service) handleListRouteTables(ctx context.Context, req mcp.ToolRequest
 			Values: []string{vpcID},
 		})
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var tables []map[string]any
 	for {
 		out, err := client.DescribeRouteTables(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, table := range out.RouteTables {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.RouteTables), out.NextToken)
+		for _, table := range out.RouteTables[start:end] {
 			tables = append(tables, summarizeRouteTable(table))
-			if limit > 0 && len(tables) >= limit {
-				break
-			}
 		}
-		if limit > 0 && len(tables) >= limit {
-			break
-		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":      regionOrDefault(usedRegion),
+		"region":      awsutil.RegionOrDefault(usedRegion),
 		"routeTables": tables,
 		"count":       len(tables),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetRouteTable(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	tableID := toString(req.Arguments["routeTableId"])
+	tableID := awsutil.ToString(req.Arguments["routeTableId"])
 	if tableID == "" {
-		return errorResult(errors.New("routeTableId is required")), errors.New("routeTableId is required")
+		return awsutil.ErrorResult(errors.New("routeTableId is required")), errors.New("routeTableId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{RouteTableIds: []string{tableID}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.RouteTables) == 0 {
-		return errorResult(fmt.Errorf("route table %s not found", tableID)), fmt.Errorf("route table %s not found", tableID)
+		return awsutil.ErrorResult(fmt.Errorf("route table %s not found", tableID)), fmt.Errorf("route table %s not found", tableID)
 	}
 	result := map[string]any{
-		"region":     regionOrDefault(usedRegion),
+		"region":     awsutil.RegionOrDefault(usedRegion),
 		"routeTable": summarizeRouteTable(out.RouteTables[0]),
 	}
 	return mcp.ToolResult{
@@ -436,14 +546,14 @@ func (s *Service) handleGetRouteTable(ctx context.Context, req mcp.ToolRequest)
 }
 
 func (s *Service) handleListNatGateways(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	vpcID := toString(req.Arguments["vpcId"])
-	subnetID := toString(req.Arguments["subnetId"])
-	ids := toStringSlice(req.Arguments["natGatewayIds"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
+	subnetID := awsutil.ToString(req.Arguments["subnetId"])
+	ids := awsutil.ToStringSlice(req.Arguments["natGatewayIds"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeNatGatewaysInput{}
 	if len(ids) > 0 {
@@ -461,55 +571,59 @@ func (s *Service) handleListNatGateways(ctx context.Context, req mcp.ToolRequest
 			Values: []string{subnetID},
 		})
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var gateways []map[string]any
 	for {
 		out, err := client.DescribeNatGateways(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, gw := range out.NatGateways {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.NatGateways), out.NextToken)
+		for _, gw := range out.NatGateways[start:end] {
 			gateways = append(gateways, summarizeNatGateway(gw))
-			if limit > 0 && len(gateways) >= limit {
-				break
-			}
 		}
-		if limit > 0 && len(gateways) >= limit {
-			break
-		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":      regionOrDefault(usedRegion),
+		"region":      awsutil.RegionOrDefault(usedRegion),
 		"natGateways": gateways,
 		"count":       len(gateways),
 		"filtersUsed": summarizeNatFilters(vpcID, subnetID, ids),
 		"note":        "NAT gateway list is eventually consistent; recently created gateways may take time to appear.",
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetNatGateway(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	natID := toString(req.Arguments["natGatewayId"])
+	natID := awsutil.ToString(req.Arguments["natGatewayId"])
 	if natID == "" {
-		return errorResult(errors.New("natGatewayId is required")), errors.New("natGatewayId is required")
+		return awsutil.ErrorResult(errors.New("natGatewayId is required")), errors.New("natGatewayId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeNatGateways(ctx, &ec2.DescribeNatGatewaysInput{NatGatewayIds: []string{natID}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.NatGateways) == 0 {
-		return errorResult(fmt.Errorf("nat gateway %s not found", natID)), fmt.Errorf("nat gateway %s not found", natID)
+		return awsutil.ErrorResult(fmt.Errorf("nat gateway %s not found", natID)), fmt.Errorf("nat gateway %s not found", natID)
 	}
 	result := map[string]any{
-		"region":     regionOrDefault(usedRegion),
+		"region":     awsutil.RegionOrDefault(usedRegion),
 		"natGateway": summarizeNatGateway(out.NatGateways[0]),
 	}
 	return mcp.ToolResult{
@@ -521,14 +635,18 @@ func (s *Service) handleGetNatGateway(ctx context.Context, req mcp.ToolRequest)
 }
 
 func (s *Service) handleListSecurityGroups(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	vpcID := toString(req.Arguments["vpcId"])
-	ids := toStringSlice(req.Arguments["groupIds"])
+	region := awsutil.ToString(req.Arguments["region"])
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
+	ids := awsutil.ToStringSlice(req.Arguments["groupIds"])
 	tagFilters := tagFiltersFromArgs(req.Arguments["tagFilters"])
-	limit := toInt(req.Arguments["limit"], 100)
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
+	}
+	var resolver *prefixListResolver
+	if awsutil.ToBool(req.Arguments["expandPrefixLists"], false) {
+		resolver = newPrefixListResolver(client)
 	}
 	input := &ec2.DescribeSecurityGroupsInput{}
 	if len(ids) > 0 {
@@ -543,54 +661,70 @@ func (s *Service) handleListSecurityGroups(ctx context.Context, req mcp.ToolRequ
 	if len(tagFilters) > 0 {
 		input.Filters = append(input.Filters, tagFilters...)
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var groups []map[string]any
 	for {
 		out, err := client.DescribeSecurityGroups(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, sg := range out.SecurityGroups {
-			groups = append(groups, summarizeSecurityGroup(sg))
-			if limit > 0 && len(groups) >= limit {
-				break
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.SecurityGroups), out.NextToken)
+		for _, sg := range out.SecurityGroups[start:end] {
+			group := summarizeSecurityGroup(sg)
+			if resolver != nil {
+				if err := resolver.expandSecurityGroup(ctx, group); err != nil {
+					return awsutil.ErrorResult(err), err
+				}
 			}
+			groups = append(groups, group)
 		}
-		if limit > 0 && len(groups) >= limit {
-			break
-		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":         regionOrDefault(usedRegion),
+		"region":         awsutil.RegionOrDefault(usedRegion),
 		"securityGroups": groups,
 		"count":          len(groups),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetSecurityGroup(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	groupID := toString(req.Arguments["groupId"])
+	groupID := awsutil.ToString(req.Arguments["groupId"])
 	if groupID == "" {
-		return errorResult(errors.New("groupId is required")), errors.New("groupId is required")
+		return awsutil.ErrorResult(errors.New("groupId is required")), errors.New("groupId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{GroupIds: []string{groupID}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.SecurityGroups) == 0 {
-		return errorResult(fmt.Errorf("security group %s not found", groupID)), fmt.Errorf("security group %s not found", groupID)
+		return awsutil.ErrorResult(fmt.Errorf("security group %s not found", groupID)), fmt.Errorf("security group %s not found", groupID)
+	}
+	group := summarizeSecurityGroup(out.SecurityGroups[0])
+	if awsutil.ToBool(req.Arguments["expandPrefixLists"], false) {
+		if err := newPrefixListResolver(client).expandSecurityGroup(ctx, group); err != nil {
+			return awsutil.ErrorResult(err), err
+		}
 	}
 	result := map[string]any{
-		"region":        regionOrDefault(usedRegion),
-		"securityGroup": summarizeSecurityGroup(out.SecurityGroups[0]),
+		"region":        awsutil.RegionOrDefault(usedRegion),
+		"securityGroup": group,
 	}
 	return mcp.ToolResult{
 		Data: s.ctx.Redactor.RedactValue(result),
@@ -601,13 +735,13 @@ func (s *Service) handleGetSecurityGroup(ctx context.Context, req mcp.ToolReques
 }
 
 func (s *Service) handleListNetworkAcls(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	vpcID := toString(req.Arguments["vpcId"])
-	ids := toStringSlice(req.Arguments["networkAclIds"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
+	ids := awsutil.ToStringSlice(req.Arguments["networkAclIds"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeNetworkAclsInput{}
 	if len(ids) > 0 {
@@ -619,53 +753,57 @@ func (s *Service) handleListNetworkAcls(ctx context.Context, req mcp.ToolRequest
 			Values: []string{vpcID},
 		})
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var acls []map[string]any
 	for {
 		out, err := client.DescribeNetworkAcls(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, acl := range out.NetworkAcls {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.NetworkAcls), out.NextToken)
+		for _, acl := range out.NetworkAcls[start:end] {
 			acls = append(acls, summarizeNetworkAcl(acl))
-			if limit > 0 && len(acls) >= limit {
-				break
-			}
-		}
-		if limit > 0 && len(acls) >= limit {
-			break
 		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":      regionOrDefault(usedRegion),
+		"region":      awsutil.RegionOrDefault(usedRegion),
 		"networkAcls": acls,
 		"count":       len(acls),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetNetworkAcl(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	aclID := toString(req.Arguments["networkAclId"])
+	aclID := awsutil.ToString(req.Arguments["networkAclId"])
 	if aclID == "" {
-		return errorResult(errors.New("networkAclId is required")), errors.New("networkAclId is required")
+		return awsutil.ErrorResult(errors.New("networkAclId is required")), errors.New("networkAclId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeNetworkAcls(ctx, &ec2.DescribeNetworkAclsInput{NetworkAclIds: []string{aclID}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.NetworkAcls) == 0 {
-		return errorResult(fmt.Errorf("network ACL %s not found", aclID)), fmt.Errorf("network ACL %s not found", aclID)
+		return awsutil.ErrorResult(fmt.Errorf("network ACL %s not found", aclID)), fmt.Errorf("network ACL %s not found", aclID)
 	}
 	result := map[string]any{
-		"region":     regionOrDefault(usedRegion),
+		"region":     awsutil.RegionOrDefault(usedRegion),
 		"networkAcl": summarizeNetworkAcl(out.NetworkAcls[0]),
 	}
 	return mcp.ToolResult{
@@ -677,13 +815,13 @@ func (s *Service) handleGetNetworkAcl(ctx context.Context, req mcp.ToolRequest)
 }
 
 func (s *Service) handleListInternetGateways(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	vpcID := toString(req.Arguments["vpcId"])
-	ids := toStringSlice(req.Arguments["internetGatewayIds"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
+	ids := awsutil.ToStringSlice(req.Arguments["internetGatewayIds"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeInternetGatewaysInput{}
 	if len(ids) > 0 {
@@ -695,53 +833,57 @@ func (s *Service) handleListInternetGateways(ctx context.Context, req mcp.ToolRe
 			Values: []string{vpcID},
 		})
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var gateways []map[string]any
 	for {
 		out, err := client.DescribeInternetGateways(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, gw := range out.InternetGateways {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.InternetGateways), out.NextToken)
+		for _, gw := range out.InternetGateways[start:end] {
 			gateways = append(gateways, summarizeInternetGateway(gw))
-			if limit > 0 && len(gateways) >= limit {
-				break
-			}
-		}
-		if limit > 0 && len(gateways) >= limit {
-			break
 		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":           regionOrDefault(usedRegion),
+		"region":           awsutil.RegionOrDefault(usedRegion),
 		"internetGateways": gateways,
 		"count":            len(gateways),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetInternetGateway(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	igwID := toString(req.Arguments["internetGatewayId"])
+	igwID := awsutil.ToString(req.Arguments["internetGatewayId"])
 	if igwID == "" {
-		return errorResult(errors.New("internetGatewayId is required")), errors.New("internetGatewayId is required")
+		return awsutil.ErrorResult(errors.New("internetGatewayId is required")), errors.New("internetGatewayId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeInternetGateways(ctx, &ec2.DescribeInternetGatewaysInput{InternetGatewayIds: []string{igwID}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.InternetGateways) == 0 {
-		return errorResult(fmt.Errorf("internet gateway %s not found", igwID)), fmt.Errorf("internet gateway %s not found", igwID)
+		return awsutil.ErrorResult(fmt.Errorf("internet gateway %s not found", igwID)), fmt.Errorf("internet gateway %s not found", igwID)
 	}
 	result := map[string]any{
-		"region":          regionOrDefault(usedRegion),
+		"region":          awsutil.RegionOrDefault(usedRegion),
 		"internetGateway": summarizeInternetGateway(out.InternetGateways[0]),
 	}
 	return mcp.ToolResult{
@@ -752,14 +894,330 @@ func (s *Service) handleGetInternetGateway(ctx context.Context, req mcp.ToolRequ
 	}, nil
 }
 
+func (s *Service) handleListEgressOnlyInternetGateways(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	region := awsutil.ToString(req.Arguments["region"])
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
+	ids := awsutil.ToStringSlice(req.Arguments["egressOnlyInternetGatewayIds"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input := &ec2.DescribeEgressOnlyInternetGatewaysInput{}
+	if len(ids) > 0 {
+		input.EgressOnlyInternetGatewayIds = ids
+	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
+	var gateways []map[string]any
+	for {
+		out, err := client.DescribeEgressOnlyInternetGateways(ctx, input)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.EgressOnlyInternetGateways), out.NextToken)
+		for _, gw := range out.EgressOnlyInternetGateways[start:end] {
+			// The API only filters on tags, so the VPC is matched here.
+			if vpcID != "" && !gatewayAttachedTo(gw.Attachments, vpcID) {
+				continue
+			}
+			gateways = append(gateways, summarizeEgressOnlyInternetGateway(gw))
+		}
+		if !more {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+	data := map[string]any{
+		"region":                     awsutil.RegionOrDefault(usedRegion),
+		"egressOnlyInternetGateways": gateways,
+		"count":                      len(gateways),
+	}
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
+}
+
+func (s *Service) handleGetEgressOnlyInternetGateway(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	gatewayID := awsutil.ToString(req.Arguments["egressOnlyInternetGatewayId"])
+	if gatewayID == "" {
+		return awsutil.ErrorResult(errors.New("egressOnlyInternetGatewayId is required")), errors.New("egressOnlyInternetGatewayId is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	out, err := client.DescribeEgressOnlyInternetGateways(ctx, &ec2.DescribeEgressOnlyInternetGatewaysInput{EgressOnlyInternetGatewayIds: []string{gatewayID}})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	if len(out.EgressOnlyInternetGateways) == 0 {
+		return awsutil.ErrorResult(fmt.Errorf("egress-only internet gateway %s not found", gatewayID)), fmt.Errorf("egress-only internet gateway %s not found", gatewayID)
+	}
+	result := map[string]any{
+		"region":                    awsutil.RegionOrDefault(usedRegion),
+		"egressOnlyInternetGateway": summarizeEgressOnlyInternetGateway(out.EgressOnlyInternetGateways[0]),
+	}
+	return mcp.ToolResult{
+		Data: s.ctx.Redactor.RedactValue(result),
+		Metadata: mcp.ToolMetadata{
+			Resources: []string{fmt.Sprintf("ec2/egress-only-internet-gateway/%s", gatewayID)},
+		},
+	}, nil
+}
+
+func (s *Service) handleListDhcpOptions(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	region := awsutil.ToString(req.Arguments["region"])
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
+	ids := awsutil.ToStringSlice(req.Arguments["dhcpOptionsIds"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input := &ec2.DescribeDhcpOptionsInput{}
+	if len(ids) > 0 {
+		input.DhcpOptionsIds = ids
+	}
+	if vpcID != "" {
+		// DHCP option sets cannot be filtered by VPC; resolve the VPC's set first.
+		out, err := client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{VpcIds: []string{vpcID}})
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		if len(out.Vpcs) == 0 {
+			return awsutil.ErrorResult(fmt.Errorf("vpc %s not found", vpcID)), fmt.Errorf("vpc %s not found", vpcID)
+		}
+		input.Filters = append(input.Filters, ec2types.Filter{
+			Name:   aws.String("dhcp-options-id"),
+			Values: []string{aws.ToString(out.Vpcs[0].DhcpOptionsId)},
+		})
+	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
+	var sets []ec2types.DhcpOptions
+	for {
+		out, err := client.DescribeDhcpOptions(ctx, input)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.DhcpOptions), out.NextToken)
+		sets = append(sets, out.DhcpOptions[start:end]...)
+		if !more {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+	setIDs := make([]string, 0, len(sets))
+	for _, set := range sets {
+		setIDs = append(setIDs, aws.ToString(set.DhcpOptionsId))
+	}
+	usedBy, err := vpcsByDhcpOptions(ctx, client, setIDs)
+	options := make([]map[string]any, 0, len(sets))
+	for _, set := range sets {
+		options = append(options, summarizeDhcpOptions(set, usedBy[aws.ToString(set.DhcpOptionsId)]))
+	}
+	data := map[string]any{
+		"region":      awsutil.RegionOrDefault(usedRegion),
+		"dhcpOptions": options,
+		"count":       len(options),
+	}
+	if err != nil {
+		data["warnings"] = []string{fmt.Sprintf("vpc lookup failed: %v", err)}
+	}
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
+}
+
+func (s *Service) handleGetDhcpOptions(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	dhcpID := awsutil.ToString(req.Arguments["dhcpOptionsId"])
+	if dhcpID == "" {
+		return awsutil.ErrorResult(errors.New("dhcpOptionsId is required")), errors.New("dhcpOptionsId is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	out, err := client.DescribeDhcpOptions(ctx, &ec2.DescribeDhcpOptionsInput{DhcpOptionsIds: []string{dhcpID}})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	if len(out.DhcpOptions) == 0 {
+		return awsutil.ErrorResult(fmt.Errorf("dhcp options %s not found", dhcpID)), fmt.Errorf("dhcp options %s not found", dhcpID)
+	}
+	usedBy, err := vpcsByDhcpOptions(ctx, client, []string{dhcpID})
+	result := map[string]any{
+		"region":      awsutil.RegionOrDefault(usedRegion),
+		"dhcpOptions": summarizeDhcpOptions(out.DhcpOptions[0], usedBy[dhcpID]),
+	}
+	if err != nil {
+		result["warnings"] = []string{fmt.Sprintf("vpc lookup failed: %v", err)}
+	}
+	return mcp.ToolResult{
+		Data: s.ctx.Redactor.RedactValue(result),
+		Metadata: mcp.ToolMetadata{
+			Resources: []string{fmt.Sprintf("ec2/dhcp-options/%s", dhcpID)},
+		},
+	}, nil
+}
+
+// vpcsByDhcpOptions maps each DHCP option set id to the VPCs associated with
+// it, the reverse of the dhcpOptions id summarizeVPC reports.
+func vpcsByDhcpOptions(ctx context.Context, client *ec2.Client, ids []string) (map[string][]string, error) {
+	usedBy := map[string][]string{}
+	if len(ids) == 0 {
+		return usedBy, nil
+	}
+	input := &ec2.DescribeVpcsInput{
+		Filters: []ec2types.Filter{{Name: aws.String("dhcp-options-id"), Values: ids}},
+	}
+	for {
+		out, err := client.DescribeVpcs(ctx, input)
+		if err != nil {
+			return usedBy, err
+		}
+		for _, vpc := range out.Vpcs {
+			id := aws.ToString(vpc.DhcpOptionsId)
+			usedBy[id] = append(usedBy[id], aws.ToString(vpc.VpcId))
+		}
+		if aws.ToString(out.NextToken) == "" {
+			return usedBy, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+func (s *Service) handleListPrefixLists(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	region := awsutil.ToString(req.Arguments["region"])
+	ids := awsutil.ToStringSlice(req.Arguments["prefixListIds"])
+	managedBy := strings.ToLower(awsutil.ToString(req.Arguments["managedBy"]))
+	limit := argconv.Int(req.Arguments["limit"], 100)
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input := &ec2.DescribeManagedPrefixListsInput{}
+	if len(ids) > 0 {
+		input.PrefixListIds = ids
+	}
+	switch managedBy {
+	case "", "customer":
+	case "aws":
+		input.Filters = append(input.Filters, ec2types.Filter{Name: aws.String("owner-id"), Values: []string{prefixListOwnerAWS}})
+	default:
+		err := fmt.Errorf("managedBy must be aws or customer, got %q", managedBy)
+		return awsutil.ErrorResult(err), err
+	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
+	var lists []map[string]any
+	for {
+		out, err := client.DescribeManagedPrefixLists(ctx, input)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.PrefixLists), out.NextToken)
+		for _, list := range out.PrefixLists[start:end] {
+			// owner-id cannot express "anyone but AWS", so customer lists are
+			// filtered here.
+			if managedBy == "customer" && aws.ToString(list.OwnerId) == prefixListOwnerAWS {
+				continue
+			}
+			lists = append(lists, summarizePrefixList(list))
+		}
+		if !more {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+	data := map[string]any{
+		"region":      awsutil.RegionOrDefault(usedRegion),
+		"prefixLists": lists,
+		"count":       len(lists),
+	}
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
+}
+
+func (s *Service) handleGetPrefixList(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	prefixListID := awsutil.ToString(req.Arguments["prefixListId"])
+	if prefixListID == "" {
+		return awsutil.ErrorResult(errors.New("prefixListId is required")), errors.New("prefixListId is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	out, err := client.DescribeManagedPrefixLists(ctx, &ec2.DescribeManagedPrefixListsInput{PrefixListIds: []string{prefixListID}})
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	if len(out.PrefixLists) == 0 {
+		return awsutil.ErrorResult(fmt.Errorf("prefix list %s not found", prefixListID)), fmt.Errorf("prefix list %s not found", prefixListID)
+	}
+	summary := summarizePrefixList(out.PrefixLists[0])
+	entriesInput := &ec2.GetManagedPrefixListEntriesInput{PrefixListId: aws.String(prefixListID)}
+	var entries []map[string]any
+	var cidrs []string
+	for {
+		entriesOut, err := client.GetManagedPrefixListEntries(ctx, entriesInput)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		for _, entry := range entriesOut.Entries {
+			entries = append(entries, map[string]any{
+				"cidr":        aws.ToString(entry.Cidr),
+				"description": aws.ToString(entry.Description),
+			})
+			cidrs = append(cidrs, aws.ToString(entry.Cidr))
+		}
+		if aws.ToString(entriesOut.NextToken) == "" {
+			break
+		}
+		entriesInput.NextToken = entriesOut.NextToken
+	}
+	summary["entries"] = entries
+	summary["cidrs"] = cidrs
+	result := map[string]any{
+		"region":     awsutil.RegionOrDefault(usedRegion),
+		"prefixList": summary,
+	}
+	return mcp.ToolResult{
+		Data: s.ctx.Redactor.RedactValue(result),
+		Metadata: mcp.ToolMetadata{
+			Resources: []string{fmt.Sprintf("ec2/prefix-list/%s", prefixListID)},
+		},
+	}, nil
+}
+
 func (s *Service) handleListEndpoints(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	vpcID := toString(req.Arguments["vpcId"])
-	ids := toStringSlice(req.Arguments["endpointIds"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
+	ids := awsutil.ToStringSlice(req.Arguments["endpointIds"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeVpcEndpointsInput{}
 	if len(ids) > 0 {
@@ -771,53 +1229,57 @@ func (s *Service) handleListEndpoints(ctx context.Context, req mcp.ToolRequest)
 			Values: []string{vpcID},
 		})
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var endpoints []map[string]any
 	for {
 		out, err := client.DescribeVpcEndpoints(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, ep := range out.VpcEndpoints {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.VpcEndpoints), out.NextToken)
+		for _, ep := range out.VpcEndpoints[start:end] {
 			endpoints = append(endpoints, summarizeVpcEndpoint(ep))
-			if limit > 0 && len(endpoints) >= limit {
-				break
-			}
-		}
-		if limit > 0 && len(endpoints) >= limit {
-			break
 		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":    regionOrDefault(usedRegion),
+		"region":    awsutil.RegionOrDefault(usedRegion),
 		"endpoints": endpoints,
 		"count":     len(endpoints),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetEndpoint(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	endpointID := toString(req.Arguments["endpointId"])
+	endpointID := awsutil.ToString(req.Arguments["endpointId"])
 	if endpointID == "" {
-		return errorResult(errors.New("endpointId is required")), errors.New("endpointId is required")
+		return awsutil.ErrorResult(errors.New("endpointId is required")), errors.New("endpointId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeVpcEndpoints(ctx, &ec2.DescribeVpcEndpointsInput{VpcEndpointIds: []string{endpointID}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.VpcEndpoints) == 0 {
-		return errorResult(fmt.Errorf("vpc endpoint %s not found", endpointID)), fmt.Errorf("vpc endpoint %s not found", endpointID)
+		return awsutil.ErrorResult(fmt.Errorf("vpc endpoint %s not found", endpointID)), fmt.Errorf("vpc endpoint %s not found", endpointID)
 	}
 	result := map[string]any{
-		"region":   regionOrDefault(usedRegion),
+		"region":   awsutil.RegionOrDefault(usedRegion),
 		"endpoint": summarizeVpcEndpoint(out.VpcEndpoints[0]),
 	}
 	return mcp.ToolResult{
@@ -828,15 +1290,190 @@ func (s *Service) handleGetEndpoint(ctx context.Context, req mcp.ToolRequest) (m
 	}, nil
 }
 
+func (s *Service) handleListEndpointServices(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	region := awsutil.ToString(req.Arguments["region"])
+	ids := awsutil.ToStringSlice(req.Arguments["serviceIds"])
+	available := awsutil.ToBool(req.Arguments["available"], false)
+	limit := argconv.Int(req.Arguments["limit"], 100)
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	var services []map[string]any
+	if available {
+		// Consumer view: every service this account may create endpoints for,
+		// including AWS services and marketplace listings.
+		input := &ec2.DescribeVpcEndpointServicesInput{NextToken: pager.StartToken()}
+		if len(ids) > 0 {
+			input.Filters = append(input.Filters, ec2types.Filter{Name: aws.String("service-id"), Values: ids})
+		}
+		for {
+			out, err := client.DescribeVpcEndpointServices(ctx, input)
+			if err != nil {
+				return awsutil.ErrorResult(err), err
+			}
+			start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.ServiceDetails), out.NextToken)
+			for _, detail := range out.ServiceDetails[start:end] {
+				services = append(services, summarizeEndpointServiceDetail(detail))
+			}
+			if !more {
+				break
+			}
+			input.NextToken = out.NextToken
+		}
+	} else {
+		input := &ec2.DescribeVpcEndpointServiceConfigurationsInput{NextToken: pager.StartToken()}
+		if len(ids) > 0 {
+			input.ServiceIds = ids
+		}
+		for {
+			out, err := client.DescribeVpcEndpointServiceConfigurations(ctx, input)
+			if err != nil {
+				return awsutil.ErrorResult(err), err
+			}
+			start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.ServiceConfigurations), out.NextToken)
+			for _, cfg := range out.ServiceConfigurations[start:end] {
+				services = append(services, summarizeEndpointService(cfg))
+			}
+			if !more {
+				break
+			}
+			input.NextToken = out.NextToken
+		}
+	}
+	data := map[string]any{
+		"region":   awsutil.RegionOrDefault(usedRegion),
+		"services": services,
+		"count":    len(services),
+	}
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
+}
+
+func (s *Service) handleGetEndpointService(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	serviceID := awsutil.ToString(req.Arguments["serviceId"])
+	serviceName := awsutil.ToString(req.Arguments["serviceName"])
+	if serviceID == "" && serviceName == "" {
+		return awsutil.ErrorResult(errors.New("serviceId or serviceName is required")), errors.New("serviceId or serviceName is required")
+	}
+	region := awsutil.ToString(req.Arguments["region"])
+	client, usedRegion, err := s.ec2Client(ctx, region)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input := &ec2.DescribeVpcEndpointServiceConfigurationsInput{}
+	if serviceID != "" {
+		input.ServiceIds = []string{serviceID}
+	} else {
+		input.Filters = []ec2types.Filter{{Name: aws.String("service-name"), Values: []string{serviceName}}}
+	}
+	out, err := client.DescribeVpcEndpointServiceConfigurations(ctx, input)
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	if len(out.ServiceConfigurations) == 0 {
+		if serviceName == "" {
+			return awsutil.ErrorResult(fmt.Errorf("endpoint service %s not found", serviceID)), fmt.Errorf("endpoint service %s not found", serviceID)
+		}
+		// Not owned by this account; fall back to the consumer view, which has
+		// no principals or connections.
+		details, err := client.DescribeVpcEndpointServices(ctx, &ec2.DescribeVpcEndpointServicesInput{ServiceNames: []string{serviceName}})
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		if len(details.ServiceDetails) == 0 {
+			return awsutil.ErrorResult(fmt.Errorf("endpoint service %s not found", serviceName)), fmt.Errorf("endpoint service %s not found", serviceName)
+		}
+		summary := summarizeEndpointServiceDetail(details.ServiceDetails[0])
+		summary["owned"] = false
+		result := map[string]any{
+			"region":  awsutil.RegionOrDefault(usedRegion),
+			"service": summary,
+		}
+		return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(result)}, nil
+	}
+	cfg := out.ServiceConfigurations[0]
+	serviceID = aws.ToString(cfg.ServiceId)
+	summary := summarizeEndpointService(cfg)
+	summary["owned"] = true
+
+	var principals []map[string]any
+	permInput := &ec2.DescribeVpcEndpointServicePermissionsInput{ServiceId: aws.String(serviceID)}
+	for {
+		permOut, err := client.DescribeVpcEndpointServicePermissions(ctx, permInput)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		for _, principal := range permOut.AllowedPrincipals {
+			principals = append(principals, map[string]any{
+				"principal": aws.ToString(principal.Principal),
+				"type":      principal.PrincipalType,
+			})
+		}
+		if aws.ToString(permOut.NextToken) == "" {
+			break
+		}
+		permInput.NextToken = permOut.NextToken
+	}
+	summary["allowedPrincipals"] = principals
+
+	var connections []map[string]any
+	states := map[string]int{}
+	var pending []string
+	connInput := &ec2.DescribeVpcEndpointConnectionsInput{
+		Filters: []ec2types.Filter{{Name: aws.String("service-id"), Values: []string{serviceID}}},
+	}
+	for {
+		connOut, err := client.DescribeVpcEndpointConnections(ctx, connInput)
+		if err != nil {
+			return awsutil.ErrorResult(err), err
+		}
+		for _, conn := range connOut.VpcEndpointConnections {
+			connections = append(connections, summarizeEndpointConnection(conn))
+			states[string(conn.VpcEndpointState)]++
+			// The API reports both pendingAcceptance and PendingAcceptance.
+			if strings.EqualFold(string(conn.VpcEndpointState), string(ec2types.StatePendingAcceptance)) {
+				pending = append(pending, aws.ToString(conn.VpcEndpointId))
+			}
+		}
+		if aws.ToString(connOut.NextToken) == "" {
+			break
+		}
+		connInput.NextToken = connOut.NextToken
+	}
+	summary["connections"] = connections
+	summary["connectionStates"] = states
+	if len(pending) > 0 {
+		summary["pendingAcceptance"] = pending
+	}
+	result := map[string]any{
+		"region":  awsutil.RegionOrDefault(usedRegion),
+		"service": summary,
+	}
+	return mcp.ToolResult{
+		Data: s.ctx.Redactor.RedactValue(result),
+		Metadata: mcp.ToolMetadata{
+			Resources: []string{fmt.Sprintf("ec2/vpc-endpoint-service/%s", serviceID)},
+		},
+	}, nil
+}
+
 func (s *Service) handleListNetworkInterfaces(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	region := toString(req.Arguments["region"])
-	vpcID := toString(req.Arguments["vpcId"])
-	subnetID := toString(req.Arguments["subnetId"])
-	ids := toStringSlice(req.Arguments["networkInterfaceIds"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
+	subnetID := awsutil.ToString(req.Arguments["subnetId"])
+	ids := awsutil.ToStringSlice(req.Arguments["networkInterfaceIds"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &ec2.DescribeNetworkInterfacesInput{}
 	if len(ids) > 0 {
@@ -854,53 +1491,57 @@ func (s *Service) handleListNetworkInterfaces(ctx context.Context, req mcp.ToolR
 			Values: []string{subnetID},
 		})
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var interfaces []map[string]any
 	for {
 		out, err := client.DescribeNetworkInterfaces(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, iface := range out.NetworkInterfaces {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.NetworkInterfaces), out.NextToken)
+		for _, iface := range out.NetworkInterfaces[start:end] {
 			interfaces = append(interfaces, summarizeNetworkInterface(iface))
-			if limit > 0 && len(interfaces) >= limit {
-				break
-			}
 		}
-		if limit > 0 && len(interfaces) >= limit {
-			break
-		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":            regionOrDefault(usedRegion),
+		"region":            awsutil.RegionOrDefault(usedRegion),
 		"networkInterfaces": interfaces,
 		"count":             len(interfaces),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetNetworkInterface(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
-	ifaceID := toString(req.Arguments["networkInterfaceId"])
+	ifaceID := awsutil.ToString(req.Arguments["networkInterfaceId"])
 	if ifaceID == "" {
-		return errorResult(errors.New("networkInterfaceId is required")), errors.New("networkInterfaceId is required")
+		return awsutil.ErrorResult(errors.New("networkInterfaceId is required")), errors.New("networkInterfaceId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.ec2Client(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{NetworkInterfaceIds: []string{ifaceID}})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	if len(out.NetworkInterfaces) == 0 {
-		return errorResult(fmt.Errorf("network interface %s not found", ifaceID)), fmt.Errorf("network interface %s not found", ifaceID)
+		return awsutil.ErrorResult(fmt.Errorf("network interface %s not found", ifaceID)), fmt.Errorf("network interface %s not found", ifaceID)
 	}
 	result := map[string]any{
-		"region":           regionOrDefault(usedRegion),
+		"region":           awsutil.RegionOrDefault(usedRegion),
 		"networkInterface": summarizeNetworkInterface(out.NetworkInterfaces[0]),
 	}
 	return mcp.ToolResult{
@@ -913,66 +1554,70 @@ func (s *Service) handleGetNetworkInterface(ctx context.Context, req mcp.ToolReq
 
 func (s *Service) handleListResolverEndpoints(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
 	if s.resolverClient == nil {
-		return errorResult(errors.New("resolver client not available")), errors.New("resolver client not available")
+		return awsutil.ErrorResult(errors.New("resolver client not available")), errors.New("resolver client not available")
 	}
-	region := toString(req.Arguments["region"])
-	vpcID := toString(req.Arguments["vpcId"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	vpcID := awsutil.ToString(req.Arguments["vpcId"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.resolverClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &route53resolver.ListResolverEndpointsInput{}
 	if vpcID != "" {
 		input.Filters = append(input.Filters, r53types.Filter{Name: aws.String("VpcId"), Values: []string{vpcID}})
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var endpoints []map[string]any
 	for {
 		out, err := client.ListResolverEndpoints(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, ep := range out.ResolverEndpoints {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.ResolverEndpoints), out.NextToken)
+		for _, ep := range out.ResolverEndpoints[start:end] {
 			endpoints = append(endpoints, summarizeResolverEndpoint(ep))
-			if limit > 0 && len(endpoints) >= limit {
-				break
-			}
 		}
-		if limit > 0 && len(endpoints) >= limit {
-			break
-		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":            regionOrDefault(usedRegion),
+		"region":            awsutil.RegionOrDefault(usedRegion),
 		"resolverEndpoints": endpoints,
 		"count":             len(endpoints),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetResolverEndpoint(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
 	if s.resolverClient == nil {
-		return errorResult(errors.New("resolver client not available")), errors.New("resolver client not available")
+		return awsutil.ErrorResult(errors.New("resolver client not available")), errors.New("resolver client not available")
 	}
-	endpointID := toString(req.Arguments["resolverEndpointId"])
+	endpointID := awsutil.ToString(req.Arguments["resolverEndpointId"])
 	if endpointID == "" {
-		return errorResult(errors.New("resolverEndpointId is required")), errors.New("resolverEndpointId is required")
+		return awsutil.ErrorResult(errors.New("resolverEndpointId is required")), errors.New("resolverEndpointId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.resolverClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.GetResolverEndpoint(ctx, &route53resolver.GetResolverEndpointInput{ResolverEndpointId: aws.String(endpointID)})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	result := map[string]any{
-		"region":           regionOrDefault(usedRegion),
+		"region":           awsutil.RegionOrDefault(usedRegion),
 		"resolverEndpoint": summarizeResolverEndpoint(*out.ResolverEndpoint),
 	}
 	return mcp.ToolResult{
@@ -985,15 +1630,15 @@ func (s *Service) handleGetResolverEndpoint(ctx context.Context, req mcp.ToolReq
 
 func (s *Service) handleListResolverRules(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
 	if s.resolverClient == nil {
-		return errorResult(errors.New("resolver client not available")), errors.New("resolver client not available")
+		return awsutil.ErrorResult(errors.New("resolver client not available")), errors.New("resolver client not available")
 	}
-	region := toString(req.Arguments["region"])
-	endpointID := toString(req.Arguments["resolverEndpointId"])
-	ruleType := toString(req.Arguments["ruleType"])
-	limit := toInt(req.Arguments["limit"], 100)
+	region := awsutil.ToString(req.Arguments["region"])
+	endpointID := awsutil.ToString(req.Arguments["resolverEndpointId"])
+	ruleType := awsutil.ToString(req.Arguments["ruleType"])
+	limit := argconv.Int(req.Arguments["limit"], 100)
 	client, usedRegion, err := s.resolverClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	input := &route53resolver.ListResolverRulesInput{}
 	if endpointID != "" {
@@ -1002,53 +1647,57 @@ func (s *Service) handleListResolverRules(ctx context.Context, req mcp.ToolReque
 	if ruleType != "" {
 		input.Filters = append(input.Filters, r53types.Filter{Name: aws.String("RuleType"), Values: []string{ruleType}})
 	}
+	pager, err := awslib.NewPager(limit, awsutil.ToString(req.Arguments["nextToken"]))
+	if err != nil {
+		return awsutil.ErrorResult(err), err
+	}
+	input.NextToken = pager.StartToken()
 	var rules []map[string]any
 	for {
 		out, err := client.ListResolverRules(ctx, input)
 		if err != nil {
-			return errorResult(err), err
+			return awsutil.ErrorResult(err), err
 		}
-		for _, rule := range out.ResolverRules {
+		start, end, more := pager.Page(aws.ToString(input.NextToken), len(out.ResolverRules), out.NextToken)
+		for _, rule := range out.ResolverRules[start:end] {
 			rules = append(rules, summarizeResolverRule(rule))
-			if limit > 0 && len(rules) >= limit {
-				break
-			}
 		}
-		if limit > 0 && len(rules) >= limit {
-			break
-		}
-		if out.NextToken == nil || aws.ToString(out.NextToken) == "" {
+		if !more {
 			break
 		}
 		input.NextToken = out.NextToken
 	}
 	data := map[string]any{
-		"region":        regionOrDefault(usedRegion),
+		"region":        awsutil.RegionOrDefault(usedRegion),
 		"resolverRules": rules,
 		"count":         len(rules),
 	}
-	return mcp.ToolResult{Data: s.ctx.Redactor.RedactValue(data)}, nil
+	redacted := s.ctx.Redactor.RedactMap(data)
+	if next := pager.NextToken(); next != "" {
+		redacted["nextToken"] = next
+	}
+	return mcp.ToolResult{Data: redacted}, nil
 }
 
 func (s *Service) handleGetResolverRule(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
 	if s.resolverClient == nil {
-		return errorResult(errors.New("resolver client not available")), errors.New("resolver client not available")
+		return awsutil.ErrorResult(errors.New("resolver client not available")), errors.New("resolver client not available")
 	}
-	ruleID := toString(req.Arguments["resolverRuleId"])
+	ruleID := awsutil.ToString(req.Arguments["resolverRuleId"])
 	if ruleID == "" {
-		return errorResult(errors.New("resolverRuleId is required")), errors.New("resolverRuleId is required")
+		return awsutil.ErrorResult(errors.New("resolverRuleId is required")), errors.New("resolverRuleId is required")
 	}
-	region := toString(req.Arguments["region"])
+	region := awsutil.ToString(req.Arguments["region"])
 	client, usedRegion, err := s.resolverClient(ctx, region)
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	out, err := client.GetResolverRule(ctx, &route53resolver.GetResolverRuleInput{ResolverRuleId: aws.String(ruleID)})
 	if err != nil {
-		return errorResult(err), err
+		return awsutil.ErrorResult(err), err
 	}
 	result := map[string]any{
-		"region":       regionOrDefault(usedRegion),
+		"region":       awsutil.RegionOrDefault(usedRegion),
 		"resolverRule": summarizeResolverRule(*out.ResolverRule),
 	}
 	return mcp.ToolResult{
@@ -1068,7 +1717,7 @@ func summarizeVPC(vpc ec2types.Vpc) map[string]any {
 		"dhcpOptions": aws.ToString(vpc.DhcpOptionsId),
 		"tenancy":     vpc.InstanceTenancy,
 		"ipv6Cidrs":   summarizeIpv6Cidr(vpc.Ipv6CidrBlockAssociationSet),
-		"tags":        tagMap(vpc.Tags),
+		"tags":        awsutil.TagMap(vpc.Tags),
 		"ownerId":     aws.ToString(vpc.OwnerId),
 		"cidrBlocks":  summarizeCidrBlocks(vpc.CidrBlockAssociationSet),
 	}
@@ -1083,7 +1732,7 @@ func summarizeSubnet(subnet ec2types.Subnet) map[string]any {
 		"state":               subnet.State,
 		"mapPublicIpOnLaunch": subnet.MapPublicIpOnLaunch,
 		"ipv6Cidrs":           summarizeSubnetIpv6Cidr(subnet.Ipv6CidrBlockAssociationSet),
-		"tags":                tagMap(subnet.Tags),
+		"tags":                awsutil.TagMap(subnet.Tags),
 	}
 }
 
@@ -1107,7 +1756,7 @@ func summarizeRouteTable(table ec2types.RouteTable) map[string]any {
 		"vpcId":        aws.ToString(table.VpcId),
 		"routes":       routes,
 		"associations": associations,
-		"tags":         tagMap(table.Tags),
+		"tags":         awsutil.TagMap(table.Tags),
 	}
 }
 
@@ -1117,6 +1766,7 @@ func summarizeRoute(route ec2types.Route) map[string]any {
 		"destinationIpv6Cidr": aws.ToString(route.DestinationIpv6CidrBlock),
 		"destinationPrefix":   aws.ToString(route.DestinationPrefixListId),
 		"gatewayId":           aws.ToString(route.GatewayId),
+		"egressOnlyGatewayId": aws.ToString(route.EgressOnlyInternetGatewayId),
 		"natGatewayId":        aws.ToString(route.NatGatewayId),
 		"transitGatewayId":    aws.ToString(route.TransitGatewayId),
 		"instanceId":          aws.ToString(route.InstanceId),
@@ -1136,7 +1786,7 @@ func summarizeNatGateway(gw ec2types.NatGateway) map[string]any {
 			"networkInterface": aws.ToString(addr.NetworkInterfaceId),
 		})
 	}
-	return map[string]any{
+	return awsutil.WithAge(awsutil.NormalizeTimes(map[string]any{
 		"id":             aws.ToString(gw.NatGatewayId),
 		"vpcId":          aws.ToString(gw.VpcId),
 		"subnetId":       aws.ToString(gw.SubnetId),
@@ -1144,12 +1794,12 @@ func summarizeNatGateway(gw ec2types.NatGateway) map[string]any {
 		"connectivity":   gw.ConnectivityType,
 		"createTime":     gw.CreateTime,
 		"addresses":      addresses,
-		"tags":           tagMap(gw.Tags),
+		"tags":           awsutil.TagMap(gw.Tags),
 		"failureCode":    aws.ToString(gw.FailureCode),
 		"failureMessage": aws.ToString(gw.FailureMessage),
 		"provisioned":    gw.ProvisionedBandwidth,
 		"deleteTime":     gw.DeleteTime,
-	}
+	}), gw.CreateTime)
 }
 
 func summarizeSecurityGroup(sg ec2types.SecurityGroup) map[string]any {
@@ -1161,7 +1811,7 @@ func summarizeSecurityGroup(sg ec2types.SecurityGroup) map[string]any {
 		"ownerId":     aws.ToString(sg.OwnerId),
 		"inbound":     summarizePermissions(sg.IpPermissions),
 		"outbound":    summarizePermissions(sg.IpPermissionsEgress),
-		"tags":        tagMap(sg.Tags),
+		"tags":        awsutil.TagMap(sg.Tags),
 	}
 }
 
@@ -1243,22 +1893,101 @@ func summarizeNetworkAcl(acl ec2types.NetworkAcl) map[string]any {
 		"isDefault":    acl.IsDefault,
 		"entries":      entries,
 		"associations": associations,
-		"tags":         tagMap(acl.Tags),
+		"tags":         awsutil.TagMap(acl.Tags),
 	}
 }
 
 func summarizeInternetGateway(gw ec2types.InternetGateway) map[string]any {
-	attachments := make([]map[string]any, 0, len(gw.Attachments))
-	for _, attachment := range gw.Attachments {
-		attachments = append(attachments, map[string]any{
+	return map[string]any{
+		"id":          aws.ToString(gw.InternetGatewayId),
+		"attachments": summarizeGatewayAttachments(gw.Attachments),
+		"tags":        awsutil.TagMap(gw.Tags),
+	}
+}
+
+func summarizeEgressOnlyInternetGateway(gw ec2types.EgressOnlyInternetGateway) map[string]any {
+	return map[string]any{
+		"id":          aws.ToString(gw.EgressOnlyInternetGatewayId),
+		"attachments": summarizeGatewayAttachments(gw.Attachments),
+		"tags":        awsutil.TagMap(gw.Tags),
+	}
+}
+
+func summarizeGatewayAttachments(attachments []ec2types.InternetGatewayAttachment) []map[string]any {
+	out := make([]map[string]any, 0, len(attachments))
+	for _, attachment := range attachments {
+		out = append(out, map[string]any{
 			"vpcId": aws.ToString(attachment.VpcId),
 			"state": attachment.State,
 		})
 	}
+	return out
+}
+
+func gatewayAttachedTo(attachments []ec2types.InternetGatewayAttachment, vpcID string) bool {
+	for _, attachment := range attachments {
+		if aws.ToString(attachment.VpcId) == vpcID {
+			return true
+		}
+	}
+	return false
+}
+
+func summarizeDhcpOptions(opts ec2types.DhcpOptions, vpcIDs []string) map[string]any {
+	config := map[string][]string{}
+	for _, entry := range opts.DhcpConfigurations {
+		for _, value := range entry.Values {
+			config[aws.ToString(entry.Key)] = append(config[aws.ToString(entry.Key)], aws.ToString(value.Value))
+		}
+	}
+	dnsServers := config["domain-name-servers"]
+	// Without domain-name-servers instances get no resolver at all, and custom
+	// servers bypass the Route 53 Resolver (and its endpoints and rules).
+	amazonDNS := false
+	for _, server := range dnsServers {
+		if server == "AmazonProvidedDNS" {
+			amazonDNS = true
+		}
+	}
+	domainName := ""
+	if names := config["domain-name"]; len(names) > 0 {
+		domainName = strings.Join(names, " ")
+	}
 	return map[string]any{
-		"id":          aws.ToString(gw.InternetGatewayId),
-		"attachments": attachments,
-		"tags":        tagMap(gw.Tags),
+		"id":                aws.ToString(opts.DhcpOptionsId),
+		"domainName":        domainName,
+		"domainNameServers": dnsServers,
+		"usesAmazonDNS":     amazonDNS,
+		"ntpServers":        config["ntp-servers"],
+		"netbiosServers":    config["netbios-name-servers"],
+		"netbiosNodeType":   strings.Join(config["netbios-node-type"], ","),
+		"vpcIds":            vpcIDs,
+		"ownerId":           aws.ToString(opts.OwnerId),
+		"tags":              awsutil.TagMap(opts.Tags),
+	}
+}
+
+// prefixListOwnerAWS is the owner id AWS-managed prefix lists (S3, DynamoDB,
+// CloudFront origin-facing) report instead of an account id.
+const prefixListOwnerAWS = "AWS"
+
+func summarizePrefixList(list ec2types.ManagedPrefixList) map[string]any {
+	managedBy := "customer"
+	if aws.ToString(list.OwnerId) == prefixListOwnerAWS {
+		managedBy = "aws"
+	}
+	return map[string]any{
+		"id":            aws.ToString(list.PrefixListId),
+		"name":          aws.ToString(list.PrefixListName),
+		"arn":           aws.ToString(list.PrefixListArn),
+		"addressFamily": aws.ToString(list.AddressFamily),
+		"state":         list.State,
+		"stateMessage":  aws.ToString(list.StateMessage),
+		"maxEntries":    list.MaxEntries,
+		"version":       list.Version,
+		"ownerId":       aws.ToString(list.OwnerId),
+		"managedBy":     managedBy,
+		"tags":          awsutil.TagMap(list.Tags),
 	}
 }
 
@@ -1278,7 +2007,50 @@ func summarizeVpcEndpoint(ep ec2types.VpcEndpoint) map[string]any {
 		"securityGroupIds":    sgIDs,
 		"privateDnsEnabled":   ep.PrivateDnsEnabled,
 		"networkInterfaceIds": ep.NetworkInterfaceIds,
-		"tags":                tagMap(ep.Tags),
+		"tags":                awsutil.TagMap(ep.Tags),
+	}
+}
+
+func summarizeEndpointService(cfg ec2types.ServiceConfiguration) map[string]any {
+	summary := map[string]any{
+		"id":                      aws.ToString(cfg.ServiceId),
+		"name":                    aws.ToString(cfg.ServiceName),
+		"state":                   cfg.ServiceState,
+		"acceptanceRequired":      aws.ToBool(cfg.AcceptanceRequired),
+		"availabilityZones":       cfg.AvailabilityZones,
+		"networkLoadBalancerArns": cfg.NetworkLoadBalancerArns,
+		"gatewayLoadBalancerArns": cfg.GatewayLoadBalancerArns,
+		"baseEndpointDnsNames":    cfg.BaseEndpointDnsNames,
+		"privateDnsName":          aws.ToString(cfg.PrivateDnsName),
+		"tags":                    awsutil.TagMap(cfg.Tags),
+	}
+	if cfg.PrivateDnsNameConfiguration != nil {
+		summary["privateDnsNameState"] = cfg.PrivateDnsNameConfiguration.State
+	}
+	return summary
+}
+
+func summarizeEndpointServiceDetail(detail ec2types.ServiceDetail) map[string]any {
+	return map[string]any{
+		"id":                   aws.ToString(detail.ServiceId),
+		"name":                 aws.ToString(detail.ServiceName),
+		"owner":                aws.ToString(detail.Owner),
+		"acceptanceRequired":   aws.ToBool(detail.AcceptanceRequired),
+		"availabilityZones":    detail.AvailabilityZones,
+		"baseEndpointDnsNames": detail.BaseEndpointDnsNames,
+		"privateDnsName":       aws.ToString(detail.PrivateDnsName),
+		"privateDnsNameState":  detail.PrivateDnsNameVerificationState,
+		"tags":                 awsutil.TagMap(detail.Tags),
+	}
+}
+
+func summarizeEndpointConnection(conn ec2types.VpcEndpointConnection) map[string]any {
+	return map[string]any{
+		"id":            aws.ToString(conn.VpcEndpointConnectionId),
+		"endpointId":    aws.ToString(conn.VpcEndpointId),
+		"endpointOwner": aws.ToString(conn.VpcEndpointOwner),
+		"state":         conn.VpcEndpointState,
+		"createdAt":     conn.CreationTimestamp,
 	}
 }
 
@@ -1311,7 +2083,7 @@ func summarizeNetworkInterface(iface ec2types.NetworkInterface) map[string]any {
 		"ownerId":          aws.ToString(iface.OwnerId),
 		"securityGroupIds": sgIDs,
 		"attachment":       attachment,
-		"tags":             tagMap(iface.TagSet),
+		"tags":             awsutil.TagMap(iface.TagSet),
 	}
 }
 
@@ -1396,54 +2168,6 @@ func summarizeNatFilters(vpcID, subnetID string, ids []string) map[string]any {
 	return out
 }
 
-func tagMap(tags []ec2types.Tag) map[string]string {
-	out := map[string]string{}
-	for _, tag := range tags {
-		key := aws.ToString(tag.Key)
-		if key == "" {
-			continue
-		}
-		out[key] = aws.ToString(tag.Value)
-	}
-	return out
-}
-
-func errorResult(err error) mcp.ToolResult {
-	return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}
-}
-
-func toString(value any) string {
-	if value == nil {
-		return ""
-	}
-	if s, ok := value.(string); ok {
-		return s
-	}
-	return fmt.Sprintf("%v", value)
-}
-
-func toStringSlice(value any) []string {
-	switch v := value.(type) {
-	case []string:
-		return v
-	case []any:
-		var out []string
-		for _, item := range v {
-			if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
-				out = append(out, s)
-			}
-		}
-		return out
-	case string:
-		if strings.TrimSpace(v) == "" {
-			return nil
-		}
-		return []string{v}
-	default:
-		return nil
-	}
-}
-
 func tagFiltersFromArgs(value any) []ec2types.Filter {
 	tagMap, ok := value.(map[string]any)
 	if !ok {
@@ -1454,7 +2178,7 @@ func tagFiltersFromArgs(value any) []ec2types.Filter {
 		if strings.TrimSpace(key) == "" {
 			continue
 		}
-		values := toStringSlice(raw)
+		values := awsutil.ToStringSlice(raw)
 		if len(values) == 0 {
 			continue
 		}
@@ -1465,26 +2189,3 @@ func tagFiltersFromArgs(value any) []ec2types.Filter {
 	}
 	return filters
 }
-
-func toInt(value any, fallback int) int {
-	switch v := value.(type) {
-	case int:
-		return v
-	case int64:
-		return int(v)
-	case float64:
-		return int(v)
-	case json.Number:
-		if parsed, err := v.Int64(); err == nil {
-			return int(parsed)
-		}
-	}
-	return fallback
-}
-
-func regionOrDefault(region string) string {
-	if strings.TrimSpace(region) == "" {
-		return "us-east-1"
-	}
-	return region
-}
@@ -0,0 +1,335 @@
+package helm
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+)
+
+// maxDriftFields caps the fields reported per resource so one heavily edited
+// object does not drown out the rest of the release.
+const maxDriftFields = 50
+
+func (t *Toolset) handleDetectReleaseDrift(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	args := req.Arguments
+	namespace := toString(args["namespace"])
+	releaseName := toString(args["release"])
+	if releaseName == "" || namespace == "" {
+		err := errors.New("release and namespace are required")
+		return errorResult(err), err
+	}
+	if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+		return errorResult(err), err
+	}
+	cfg, err := t.actionConfig(namespace)
+	if err != nil {
+		return errorResult(err), err
+	}
+	history := action.NewHistory(cfg)
+	revisions, err := history.Run(releaseName)
+	if err != nil {
+		return errorResult(err), err
+	}
+	rel := lastDeployedRevision(revisions)
+	if rel == nil {
+		err := fmt.Errorf("release %s has no successfully deployed revision", releaseName)
+		return errorResult(err), err
+	}
+	objects, err := decodeManifest(rel.Manifest)
+	if err != nil {
+		return errorResult(err), err
+	}
+
+	drifted := make([]map[string]any, 0)
+	deleted := make([]string, 0)
+	skipped := make([]string, 0)
+	var resources []string
+	inSync := 0
+	for _, obj := range objects {
+		kind := obj.GetKind()
+		name := obj.GetName()
+		if obj.GetAPIVersion() == "" || kind == "" || name == "" {
+			skipped = append(skipped, fmt.Sprintf("incomplete object %s/%s", kind, name))
+			continue
+		}
+		gvr, namespaced, err := kube.ResolveResource(t.ctx.Clients.Mapper, obj.GetAPIVersion(), kind, "")
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s/%s: %v", kind, name, err))
+			continue
+		}
+		objNamespace := ""
+		if namespaced {
+			objNamespace = obj.GetNamespace()
+			if objNamespace == "" {
+				objNamespace = namespace
+			}
+		}
+		if err := t.ctx.Policy.CheckNamespace(req.User, objNamespace, namespaced); err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s/%s: %v", kind, name, err))
+			continue
+		}
+		ref := t.ctx.Evidence.ResourceRef(gvr, objNamespace, name)
+		client := t.ctx.Clients.Dynamic.Resource(gvr)
+		var getErr error
+		var liveObject map[string]any
+		if namespaced {
+			live, err := client.Namespace(objNamespace).Get(ctx, name, metav1.GetOptions{})
+			getErr = err
+			if err == nil {
+				liveObject = live.Object
+			}
+		} else {
+			live, err := client.Get(ctx, name, metav1.GetOptions{})
+			getErr = err
+			if err == nil {
+				liveObject = live.Object
+			}
+		}
+		if getErr != nil && !apierrors.IsNotFound(getErr) {
+			skipped = append(skipped, fmt.Sprintf("%s/%s: %v", kind, name, getErr))
+			continue
+		}
+		resources = append(resources, ref)
+		if getErr != nil {
+			deleted = append(deleted, ref)
+			continue
+		}
+		fields := manifestDrift(obj.Object, liveObject)
+		if len(fields) == 0 {
+			inSync++
+			continue
+		}
+		entry := map[string]any{
+			"resource":   ref,
+			"kind":       kind,
+			"name":       name,
+			"fieldCount": len(fields),
+		}
+		if len(fields) > maxDriftFields {
+			fields = fields[:maxDriftFields]
+			entry["truncated"] = true
+		}
+		entry["fields"] = t.ctx.Redactor.RedactValue(fields)
+		drifted = append(drifted, entry)
+	}
+	sort.Slice(drifted, func(i, j int) bool {
+		return toString(drifted[i]["resource"]) < toString(drifted[j]["resource"])
+	})
+	sort.Strings(deleted)
+	out := map[string]any{
+		"release":   releaseName,
+		"namespace": namespace,
+		"revision":  rel.Version,
+		"summary": map[string]any{
+			"checked": len(resources),
+			"inSync":  inSync,
+			"drifted": len(drifted),
+			"deleted": len(deleted),
+		},
+		"drifted": drifted,
+		"deleted": deleted,
+	}
+	if len(skipped) > 0 {
+		out["skipped"] = skipped
+	}
+	if len(drifted) > 0 || len(deleted) > 0 {
+		out["nextChecks"] = []string{
+			"Find who changed the drifted resources (audit logs, kubectl edit, other controllers such as HPAs or operators).",
+			"Run helm.upgrade with the same chart and values, or helm.rollback_advisor, to restore the release state.",
+		}
+	}
+	return mcp.ToolResult{Data: out, Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}, Resources: resources}}, nil
+}
+
+// lastDeployedRevision returns the newest revision Helm marked deployed,
+// falling back to the newest superseded one when the latest upgrade failed.
+func lastDeployedRevision(revisions []*release.Release) *release.Release {
+	var deployed, superseded *release.Release
+	for _, rel := range revisions {
+		if rel == nil || rel.Info == nil {
+			continue
+		}
+		switch rel.Info.Status {
+		case release.StatusDeployed:
+			if deployed == nil || rel.Version > deployed.Version {
+				deployed = rel
+			}
+		case release.StatusSuperseded:
+			if superseded == nil || rel.Version > superseded.Version {
+				superseded = rel
+			}
+		}
+	}
+	if deployed != nil {
+		return deployed
+	}
+	return superseded
+}
+
+// manifestDrift lists the fields set in the rendered manifest whose live
+// value differs. Fields only present live (defaults, status, server-set
+// metadata) are not drift; only labels and annotations are compared under
+// metadata. Secret payloads are compared by secretDataDrift instead.
+func manifestDrift(desired, live map[string]any) []map[string]any {
+	var out []map[string]any
+	secret := toString(desired["kind"]) == "Secret"
+	for _, key := range sortedKeys(desired) {
+		switch key {
+		case "apiVersion", "kind", "status":
+			continue
+		case "data", "stringData":
+			if secret {
+				continue
+			}
+		case "metadata":
+			desiredMeta, _ := desired[key].(map[string]any)
+			liveMeta, _ := live[key].(map[string]any)
+			for _, field := range []string{"labels", "annotations"} {
+				if value, ok := desiredMeta[field]; ok {
+					out = appendFieldDrift(out, "metadata."+field, value, liveMeta[field], liveMeta != nil && liveMeta[field] != nil)
+				}
+			}
+			continue
+		}
+		liveValue, ok := live[key]
+		out = appendFieldDrift(out, key, desired[key], liveValue, ok)
+	}
+	if secret {
+		out = append(out, secretDataDrift(desired, live)...)
+	}
+	return out
+}
+
+// secretDataDrift compares Secret keys after folding stringData into decoded
+// data, the way the API server stores them. Only the key path and the kind of
+// change are reported so secret values never reach the tool output.
+func secretDataDrift(desired, live map[string]any) []map[string]any {
+	var out []map[string]any
+	want := secretValues(desired)
+	have := secretValues(live)
+	for _, key := range sortedKeys(want) {
+		value, ok := have[key]
+		switch {
+		case !ok:
+			out = append(out, map[string]any{"path": "data." + key, "change": "removed"})
+		case value != want[key]:
+			out = append(out, map[string]any{"path": "data." + key, "change": "modified"})
+		}
+	}
+	return out
+}
+
+// secretValues returns a Secret's plaintext values keyed by data key;
+// stringData entries win over data, matching the API server's merge.
+func secretValues(obj map[string]any) map[string]any {
+	values := map[string]any{}
+	if data, ok := obj["data"].(map[string]any); ok {
+		for key, value := range data {
+			text := toString(value)
+			if decoded, err := base64.StdEncoding.DecodeString(text); err == nil {
+				text = string(decoded)
+			}
+			values[key] = text
+		}
+	}
+	if stringData, ok := obj["stringData"].(map[string]any); ok {
+		for key, value := range stringData {
+			values[key] = toString(value)
+		}
+	}
+	return values
+}
+
+func appendFieldDrift(out []map[string]any, path string, desired, live any, present bool) []map[string]any {
+	if !present {
+		if desired == nil {
+			return out
+		}
+		return append(out, map[string]any{"path": path, "expected": desired, "live": nil, "change": "removed"})
+	}
+	switch typed := desired.(type) {
+	case map[string]any:
+		liveMap, ok := live.(map[string]any)
+		if !ok {
+			break
+		}
+		for _, key := range sortedKeys(typed) {
+			value, found := liveMap[key]
+			out = appendFieldDrift(out, path+"."+key, typed[key], value, found)
+		}
+		return out
+	case []any:
+		liveList, ok := live.([]any)
+		if !ok || len(liveList) != len(typed) {
+			break
+		}
+		for i := range typed {
+			out = appendFieldDrift(out, fmt.Sprintf("%s[%d]", path, i), typed[i], liveList[i], true)
+		}
+		return out
+	default:
+		if scalarsEqual(desired, live) {
+			return out
+		}
+	}
+	return append(out, map[string]any{"path": path, "expected": desired, "live": live, "change": "modified"})
+}
+
+// scalarsEqual treats numbers of different Go types and equivalent resource
+// quantities ("500m" vs "0.5") as equal, since the API server normalizes both.
+func scalarsEqual(desired, live any) bool {
+	if reflect.DeepEqual(desired, live) {
+		return true
+	}
+	if a, ok := toFloat(desired); ok {
+		if b, ok := toFloat(live); ok {
+			return a == b
+		}
+	}
+	desiredText := strings.TrimSpace(fmt.Sprintf("%v", desired))
+	liveText := strings.TrimSpace(fmt.Sprintf("%v", live))
+	if desiredText == liveText {
+		return true
+	}
+	a, errA := resource.ParseQuantity(desiredText)
+	b, errB := resource.ParseQuantity(liveText)
+	return errA == nil && errB == nil && a.Cmp(b) == 0
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func sortedKeys(values map[string]any) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
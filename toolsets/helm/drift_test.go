@@ -0,0 +1,215 @@
+package helm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/action"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"rootcause/internal/evidence"
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/redact"
+)
+
+func TestManifestDrift(t *testing.T) {
+	desired := map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "demo", "labels": map[string]any{"app": "demo"}},
+		"spec": map[string]any{
+			"replicas": int64(2),
+			"template": map[string]any{"spec": map[string]any{"containers": []any{
+				map[string]any{"name": "app", "image": "demo:1", "resources": map[string]any{"limits": map[string]any{"cpu": "0.5"}}},
+			}}},
+		},
+	}
+	live := map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "demo", "uid": "abc", "labels": map[string]any{"app": "demo"}},
+		"spec": map[string]any{
+			"replicas":             float64(5),
+			"revisionHistoryLimit": int64(10),
+			"template": map[string]any{"spec": map[string]any{"containers": []any{
+				map[string]any{"name": "app", "image": "demo:2", "imagePullPolicy": "IfNotPresent", "resources": map[string]any{"limits": map[string]any{"cpu": "500m"}}},
+			}}},
+		},
+		"status": map[string]any{"replicas": int64(5)},
+	}
+	fields := manifestDrift(desired, live)
+	if len(fields) != 2 {
+		t.Fatalf("expected replicas and image drift, got %#v", fields)
+	}
+	if fields[0]["path"] != "spec.replicas" || fields[0]["live"] != float64(5) {
+		t.Fatalf("unexpected replicas drift: %#v", fields[0])
+	}
+	if fields[1]["path"] != "spec.template.spec.containers[0].image" || fields[1]["expected"] != "demo:1" {
+		t.Fatalf("unexpected image drift: %#v", fields[1])
+	}
+	delete(live["metadata"].(map[string]any), "labels")
+	if fields := manifestDrift(desired, live); len(fields) != 3 || fields[0]["change"] != "removed" {
+		t.Fatalf("expected removed labels to be reported: %#v", fields)
+	}
+}
+
+func TestManifestDriftSecret(t *testing.T) {
+	desired := map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]any{"name": "db"},
+		"type":       "Opaque",
+		"stringData": map[string]any{"password": "hunter2"},
+		"data":       map[string]any{"user": "YWRtaW4="},
+	}
+	live := map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]any{"name": "db"},
+		"type":       "Opaque",
+		"data":       map[string]any{"password": "aHVudGVyMg==", "user": "YWRtaW4="},
+	}
+	if fields := manifestDrift(desired, live); len(fields) != 0 {
+		t.Fatalf("expected stringData secret to be in sync, got %#v", fields)
+	}
+	live["data"].(map[string]any)["password"] = "c3dvcmRmaXNo"
+	delete(live["data"].(map[string]any), "user")
+	fields := manifestDrift(desired, live)
+	if len(fields) != 2 || fields[0]["path"] != "data.password" || fields[0]["change"] != "modified" || fields[1]["change"] != "removed" {
+		t.Fatalf("unexpected secret drift: %#v", fields)
+	}
+	text := fmt.Sprintf("%v", fields)
+	for _, value := range []string{"hunter2", "swordfish", "c3dvcmRmaXNo", "YWRtaW4=", "admin"} {
+		if strings.Contains(text, value) {
+			t.Fatalf("secret value %q leaked into drift output: %s", value, text)
+		}
+	}
+}
+
+func TestLastDeployedRevision(t *testing.T) {
+	revisions := []*release.Release{
+		{Version: 1, Info: &release.Info{Status: release.StatusSuperseded}},
+		{Version: 2, Info: &release.Info{Status: release.StatusSuperseded}},
+		{Version: 3, Info: &release.Info{Status: release.StatusFailed}},
+	}
+	if rel := lastDeployedRevision(revisions); rel == nil || rel.Version != 2 {
+		t.Fatalf("expected superseded revision 2 after a failed upgrade, got %#v", rel)
+	}
+	revisions = append(revisions, &release.Release{Version: 4, Info: &release.Info{Status: release.StatusDeployed}})
+	if rel := lastDeployedRevision(revisions); rel.Version != 4 {
+		t.Fatalf("expected deployed revision 4, got %d", rel.Version)
+	}
+	if rel := lastDeployedRevision([]*release.Release{{Version: 1, Info: &release.Info{Status: release.StatusFailed}}}); rel != nil {
+		t.Fatalf("expected no deployed revision, got %#v", rel)
+	}
+}
+
+func TestHandleDetectReleaseDrift(t *testing.T) {
+	manifest := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: demo-config
+  namespace: default
+data:
+  mode: strict
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: demo-same
+data:
+  mode: strict
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: demo-gone
+data:
+  mode: strict
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: demo-locked
+data:
+  mode: strict
+`
+	rel := &release.Release{Name: "demo", Namespace: "default", Version: 1, Manifest: manifest, Info: &release.Info{Status: release.StatusDeployed}}
+	configMap := func(name, mode string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": name, "namespace": "default", "resourceVersion": "7"},
+			"data":       map[string]any{"mode": mode},
+		}}
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), configMap("demo-config", "permissive"), configMap("demo-same", "strict"))
+	dynamicClient.PrependReactor("get", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.(k8stesting.GetAction).GetName() != "demo-locked" {
+			return false, nil, nil
+		}
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, "demo-locked", errors.New("rbac"))
+	})
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "", Version: "v1"}})
+	mapper.Add(schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	clients := &kube.Clients{Dynamic: dynamicClient, Mapper: mapper}
+	toolset := &Toolset{ctx: mcp.ToolContext{
+		Clients:  clients,
+		Policy:   policy.NewAuthorizer(),
+		Redactor: redact.New(),
+		Evidence: evidence.NewCollector(clients),
+	}}
+	toolset.actionConfigOverride = func(namespace string) (*action.Configuration, error) {
+		mem := driver.NewMemory()
+		mem.SetNamespace(namespace)
+		cfg := &action.Configuration{Releases: storage.Init(mem), Log: func(string, ...interface{}) {}}
+		cfg.KubeClient = &kubefake.PrintingKubeClient{Out: io.Discard}
+		_ = cfg.Releases.Create(rel)
+		return cfg, nil
+	}
+	result, err := toolset.handleDetectReleaseDrift(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"release": "demo", "namespace": "default"},
+	})
+	if err != nil {
+		t.Fatalf("handleDetectReleaseDrift: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	summary := data["summary"].(map[string]any)
+	if summary["checked"] != 3 || summary["inSync"] != 1 || summary["drifted"] != 1 || summary["deleted"] != 1 {
+		t.Fatalf("unexpected drift summary: %#v", summary)
+	}
+	drifted := data["drifted"].([]map[string]any)
+	field := drifted[0]["fields"].([]map[string]any)[0]
+	if drifted[0]["name"] != "demo-config" || field["path"] != "data.mode" || field["expected"] != "strict" || field["live"] != "permissive" {
+		t.Fatalf("unexpected drifted resource: %#v", drifted[0])
+	}
+	if deleted := data["deleted"].([]string); len(deleted) != 1 {
+		t.Fatalf("expected one deleted resource: %#v", deleted)
+	}
+	if skipped := data["skipped"].([]string); len(skipped) != 1 || !strings.HasPrefix(skipped[0], "ConfigMap/demo-locked: ") {
+		t.Fatalf("expected forbidden object to be skipped: %#v", data["skipped"])
+	}
+	if _, err := toolset.handleDetectReleaseDrift(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"release": "missing", "namespace": "default"},
+	}); err == nil {
+		t.Fatalf("expected error for unknown release")
+	}
+}
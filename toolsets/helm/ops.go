@@ -17,6 +17,7 @@ import (
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/getter"
 	"helm.sh/helm/v3/pkg/release"
@@ -652,6 +653,122 @@ func (t *Toolset) handleStatus(ctx context.Context, req mcp.ToolRequest) (mcp.To
 	return mcp.ToolResult{Data: summarizeRelease(rel)}, nil
 }
 
+func (t *Toolset) handleGetReleaseValues(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	args := req.Arguments
+	namespace := toString(args["namespace"])
+	releaseName := toString(args["release"])
+	if releaseName == "" || namespace == "" {
+		err := errors.New("release and namespace are required")
+		return errorResult(err), err
+	}
+	source := strings.ToLower(strings.TrimSpace(toString(args["source"])))
+	if source == "" {
+		source = "computed"
+	}
+	if source != "computed" && source != "user" && source != "defaults" {
+		err := fmt.Errorf("unsupported source %q (expected computed, user or defaults)", source)
+		return errorResult(err), err
+	}
+	if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+		return errorResult(err), err
+	}
+	cfg, err := t.actionConfig(namespace)
+	if err != nil {
+		return errorResult(err), err
+	}
+	get := action.NewGet(cfg)
+	get.Version = toInt(args["revision"])
+	rel, err := get.Run(releaseName)
+	if err != nil {
+		return errorResult(err), err
+	}
+	values, err := releaseValues(rel, source)
+	if err != nil {
+		return errorResult(err), err
+	}
+	result := summarizeRelease(rel)
+	delete(result, "notes")
+	result["source"] = source
+	result["values"] = t.ctx.Redactor.RedactMap(maskCredentialValues(values, false).(map[string]any))
+	return mcp.ToolResult{Data: result, Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+}
+
+// credentialKeyParts mark chart values that usually hold credentials. The
+// redactor only catches long token-like strings, so short passwords and
+// connection strings have to be masked by key name instead.
+var credentialKeyParts = []string{"password", "passwd", "secret", "token", "apikey", "credential", "privatekey"}
+
+func isCredentialKey(key string) bool {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", "_", "", ".", "").Replace(key))
+	for _, part := range credentialKeyParts {
+		if strings.Contains(normalized, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskCredentialValues replaces every scalar under a credential-looking key
+// with [REDACTED], keeping the structure so value paths stay visible.
+func maskCredentialValues(value any, masked bool) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(typed))
+		for key, item := range typed {
+			out[key] = maskCredentialValues(item, masked || isCredentialKey(key))
+		}
+		return out
+	case []any:
+		out := make([]any, 0, len(typed))
+		for _, item := range typed {
+			out = append(out, maskCredentialValues(item, masked))
+		}
+		return out
+	case nil:
+		return nil
+	default:
+		if masked {
+			return "[REDACTED]"
+		}
+		return value
+	}
+}
+
+// releaseValues returns the values a release was rendered with: the
+// user-supplied overrides, the chart defaults, or both coalesced the way Helm
+// does at install time. The result is normalized to plain JSON types so the
+// redactor can walk every nested map.
+func releaseValues(rel *release.Release, source string) (map[string]any, error) {
+	var values map[string]any
+	switch source {
+	case "user":
+		values = rel.Config
+	case "defaults":
+		if rel.Chart != nil {
+			values = rel.Chart.Values
+		}
+	default:
+		if rel.Chart == nil {
+			values = rel.Config
+			break
+		}
+		computed, err := chartutil.CoalesceValues(rel.Chart, rel.Config)
+		if err != nil {
+			return nil, err
+		}
+		values = computed
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (t *Toolset) handleDiffRelease(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
 	args := req.Arguments
 	namespace := toString(args["namespace"])
@@ -283,6 +283,82 @@ func TestHandleListAndStatus(t *testing.T) {
 	}
 }
 
+func TestHandleGetReleaseValues(t *testing.T) {
+	demoChart := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "demo", Version: "0.1.0"},
+		Values: map[string]any{
+			"replicas": 1,
+			"image":    map[string]any{"repository": "nginx", "tag": "1.25"},
+		},
+	}
+	releases := []*release.Release{
+		{Name: "demo", Namespace: "default", Version: 1, Chart: demoChart, Config: map[string]any{"replicas": 2}, Info: &release.Info{Status: release.StatusSuperseded}},
+		{Name: "demo", Namespace: "default", Version: 2, Chart: demoChart, Config: map[string]any{
+			"replicas":       3,
+			"image":          map[string]any{"tag": "1.26"},
+			"apiToken":       "abcdefghijklmnopqrstuvwxyz123456",
+			"postgresql":     map[string]any{"auth": map[string]any{"username": "app", "password": "hunter2"}},
+			"db_credentials": map[string]any{"url": "postgres://app:hunter2@db:5432/app"},
+		}, Info: &release.Info{Status: release.StatusDeployed}},
+	}
+	toolset := &Toolset{ctx: mcp.ToolContext{Policy: policy.NewAuthorizer(), Redactor: redact.New()}}
+	toolset.actionConfigOverride = func(namespace string) (*action.Configuration, error) {
+		mem := driver.NewMemory()
+		mem.SetNamespace(namespace)
+		cfg := &action.Configuration{Releases: storage.Init(mem), Log: func(string, ...interface{}) {}}
+		cfg.KubeClient = &kubefake.PrintingKubeClient{Out: io.Discard}
+		for _, rel := range releases {
+			_ = cfg.Releases.Create(rel)
+		}
+		return cfg, nil
+	}
+	run := func(args map[string]any) map[string]any {
+		t.Helper()
+		args["namespace"] = "default"
+		args["release"] = "demo"
+		result, err := toolset.handleGetReleaseValues(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: args})
+		if err != nil {
+			t.Fatalf("handleGetReleaseValues: %v", err)
+		}
+		return result.Data.(map[string]any)
+	}
+
+	computed := run(map[string]any{})
+	values := computed["values"].(map[string]any)
+	image := values["image"].(map[string]any)
+	if computed["revision"] != 2 || values["replicas"] != float64(3) || image["repository"] != "nginx" || image["tag"] != "1.26" {
+		t.Fatalf("unexpected computed values: %#v", computed)
+	}
+	if values["apiToken"] != "[REDACTED]" {
+		t.Fatalf("expected token to be redacted: %#v", values["apiToken"])
+	}
+	auth := values["postgresql"].(map[string]any)["auth"].(map[string]any)
+	if auth["password"] != "[REDACTED]" || auth["username"] != "app" {
+		t.Fatalf("expected short password to be masked by key name: %#v", auth)
+	}
+	if creds := values["db_credentials"].(map[string]any); creds["url"] != "[REDACTED]" {
+		t.Fatalf("expected values under a credentials key to be masked: %#v", creds)
+	}
+	user := run(map[string]any{"source": "user"})["values"].(map[string]any)
+	if _, ok := user["image"].(map[string]any)["repository"]; ok || user["replicas"] != float64(3) {
+		t.Fatalf("user values should only hold overrides: %#v", user)
+	}
+	defaults := run(map[string]any{"source": "defaults"})["values"].(map[string]any)
+	if defaults["replicas"] != float64(1) {
+		t.Fatalf("unexpected chart defaults: %#v", defaults)
+	}
+	previous := run(map[string]any{"revision": 1})
+	if previous["revision"] != 1 || previous["values"].(map[string]any)["replicas"] != float64(2) {
+		t.Fatalf("unexpected values for revision 1: %#v", previous)
+	}
+	if _, err := toolset.handleGetReleaseValues(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"namespace": "default", "release": "demo", "source": "all"},
+	}); err == nil {
+		t.Fatalf("expected error for unsupported source")
+	}
+}
+
 func TestHandleListAllNamespaces(t *testing.T) {
 	rel := &release.Release{
 		Name:      "demo",
@@ -98,6 +98,22 @@ func schemaStatus() map[string]any {
 	}
 }
 
+func schemaGetReleaseValues() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"release":   map[string]any{"type": "string"},
+			"namespace": map[string]any{"type": "string"},
+			"revision":  map[string]any{"type": "number"},
+			"source": map[string]any{
+				"type": "string",
+				"enum": []string{"computed", "user", "defaults"},
+			},
+		},
+		"required": []string{"release", "namespace"},
+	}
+}
+
 func schemaDiffRelease() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -124,6 +140,17 @@ func schemaDiffRelease() map[string]any {
 	}
 }
 
+func schemaDetectReleaseDrift() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"release":   map[string]any{"type": "string"},
+			"namespace": map[string]any{"type": "string"},
+		},
+		"required": []string{"release", "namespace"},
+	}
+}
+
 func schemaRollbackAdvisor() map[string]any {
 	return map[string]any{
 		"type": "object",
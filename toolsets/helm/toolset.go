@@ -1,7 +1,9 @@
 package helm
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
 	"helm.sh/helm/v3/pkg/action"
 
@@ -45,6 +47,23 @@ func (t *Toolset) Init(ctx mcp.ToolContext) error {
 	return nil
 }
 
+// Preflight confirms Helm's release storage can be read across namespaces.
+func (t *Toolset) Preflight(ctx context.Context) (string, error) {
+	cfg, err := t.actionConfig("")
+	if err != nil {
+		return "", err
+	}
+	list := action.NewList(cfg)
+	list.AllNamespaces = true
+	list.All = true
+	list.SetStateMask()
+	releases, err := list.Run()
+	if err != nil {
+		return "", fmt.Errorf("helm release storage unreadable: %w", err)
+	}
+	return fmt.Sprintf("release storage readable (%s driver, %d releases)", helmDriver, len(releases)), nil
+}
+
 func (t *Toolset) Register(reg mcp.Registry) error {
 	tools := []mcp.ToolSpec{
 		{
@@ -111,6 +130,14 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     t.handleStatus,
 		},
+		{
+			Name:        "helm.get_release_values",
+			Description: "Get the computed, user-supplied or chart default values of a Helm release revision (redacted).",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaGetReleaseValues(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleGetReleaseValues,
+		},
 		{
 			Name:        "helm.diff_release",
 			Description: "Diff a live release manifest against a rendered target chart.",
@@ -119,6 +146,14 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     t.handleDiffRelease,
 		},
+		{
+			Name:        "helm.detect_release_drift",
+			Description: "Compare the last deployed Helm release manifest with live cluster objects and report edited or deleted resources.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaDetectReleaseDrift(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleDetectReleaseDrift,
+		},
 		{
 			Name:        "helm.rollback_advisor",
 			Description: "Recommend safer rollback targets from Helm release history.",
@@ -0,0 +1,377 @@
+package istio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/render"
+)
+
+// configFinding is one problem found by analyze_config.
+type configFinding struct {
+	Check    string `json:"check"`
+	Severity string `json:"severity"`
+	Object   string `json:"object"`
+	Message  string `json:"message"`
+}
+
+// configCheckTitles names each check in likely root causes.
+var configCheckTitles = map[string]string{
+	"virtualservice-host-not-found":    "VirtualService destination host not found",
+	"virtualservice-subset-not-found":  "VirtualService references undefined subset",
+	"virtualservice-gateway-not-found": "VirtualService references missing Gateway",
+	"destinationrule-subset-no-pods":   "DestinationRule subset matches no pods",
+	"gateway-unbound":                  "Gateway has no VirtualService bound",
+	"peerauthentication-conflict":      "Conflicting PeerAuthentication",
+	"mtls-conflict":                    "DestinationRule TLS conflicts with STRICT mTLS",
+}
+
+var findingSeverityRank = map[string]int{"high": 0, "medium": 1, "low": 2}
+
+// meshConfig is the Istio configuration analyze_config checks, listed from
+// the requested namespace (every allowed namespace when empty) and the root
+// namespace.
+type meshConfig struct {
+	namespace       string
+	virtualServices []unstructured.Unstructured
+	destRules       []unstructured.Unstructured
+	gateways        []unstructured.Unstructured
+	serviceEntries  []unstructured.Unstructured
+	peerAuths       []unstructured.Unstructured
+	services        map[string]*corev1.Service
+	// podsByService holds the pods each Service selects, keyed by FQDN.
+	podsByService map[string][]corev1.Pod
+}
+
+func (t *Toolset) handleAnalyzeConfig(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	namespace := toString(req.Arguments["namespace"])
+	analysis := render.NewAnalysis()
+	detected, _, err := t.detectIstio(ctx)
+	if err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	if !detected {
+		analysis.AddEvidence("status", "istio not detected")
+		analysis.AddEvidence("groupsChecked", istioGroups)
+		analysis.AddNextCheck("Install Istio or verify API group availability")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
+	}
+	if namespace != "" {
+		if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+			return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+		}
+	}
+
+	cfg := meshConfig{namespace: namespace, services: map[string]*corev1.Service{}, podsByService: map[string][]corev1.Pod{}}
+	cfg.virtualServices, _ = t.meshObjects(ctx, &analysis, req.User, "VirtualService", "networking.istio.io", namespace)
+	cfg.destRules, _ = t.meshObjects(ctx, &analysis, req.User, "DestinationRule", "networking.istio.io", namespace)
+	cfg.gateways, _ = t.meshObjects(ctx, &analysis, req.User, "Gateway", "networking.istio.io", namespace)
+	cfg.serviceEntries, _ = t.meshObjects(ctx, &analysis, req.User, "ServiceEntry", "networking.istio.io", namespace)
+	cfg.peerAuths, _ = t.meshObjects(ctx, &analysis, req.User, "PeerAuthentication", "security.istio.io", namespace)
+	services, _, err := t.listServices(ctx, req.User, namespace)
+	if err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	for i := range services {
+		svc := &services[i]
+		cfg.services[fmt.Sprintf("%s.%s.svc.cluster.local", svc.Name, svc.Namespace)] = svc
+	}
+	for _, rule := range cfg.destRules {
+		host := qualifyHost(nestedString(&rule, "spec", "host"), rule.GetNamespace())
+		svc, ok := cfg.services[host]
+		if _, loaded := cfg.podsByService[host]; !ok || loaded || len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		pods, err := t.ctx.Evidence.RelatedPods(ctx, svc.Namespace, labels.SelectorFromSet(svc.Spec.Selector))
+		if err != nil {
+			return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+		}
+		cfg.podsByService[host] = pods
+	}
+
+	findings := analyzeMeshConfig(cfg)
+	analysis.AddEvidence("checked", map[string]int{
+		"VirtualService":     len(cfg.virtualServices),
+		"DestinationRule":    len(cfg.destRules),
+		"Gateway":            len(cfg.gateways),
+		"ServiceEntry":       len(cfg.serviceEntries),
+		"PeerAuthentication": len(cfg.peerAuths),
+	})
+	if len(findings) == 0 {
+		analysis.AddEvidence("status", "no configuration issues found")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+	}
+	analysis.AddEvidence("findings", findings)
+	for _, finding := range findings {
+		analysis.AddResource(finding.Object)
+		if finding.Severity != "low" {
+			analysis.AddCause(configCheckTitles[finding.Check], fmt.Sprintf("%s: %s", finding.Object, finding.Message), finding.Severity)
+		}
+	}
+	analysis.AddNextCheck("Fix high severity findings first; each one breaks routing for the hosts it names")
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+}
+
+// analyzeMeshConfig runs every static check over cfg and returns the
+// findings ordered by severity, then object.
+func analyzeMeshConfig(cfg meshConfig) []configFinding {
+	var findings []configFinding
+	findings = append(findings, virtualServiceFindings(cfg)...)
+	findings = append(findings, destinationRuleFindings(cfg)...)
+	findings = append(findings, gatewayFindings(cfg)...)
+	findings = append(findings, peerAuthenticationFindings(cfg)...)
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findingSeverityRank[findings[i].Severity] != findingSeverityRank[findings[j].Severity] {
+			return findingSeverityRank[findings[i].Severity] < findingSeverityRank[findings[j].Severity]
+		}
+		return findings[i].Object < findings[j].Object
+	})
+	return findings
+}
+
+// scanned reports whether objects in namespace were listed, so a missing
+// reference there is real rather than out of scope.
+func (cfg meshConfig) scanned(namespace string) bool {
+	return cfg.namespace == "" || namespace == cfg.namespace || namespace == istioNamespace
+}
+
+func virtualServiceFindings(cfg meshConfig) []configFinding {
+	var findings []configFinding
+	entryHosts := serviceEntryHosts(cfg.serviceEntries)
+	gateways := map[string]bool{}
+	for _, gw := range cfg.gateways {
+		gateways[gw.GetNamespace()+"/"+gw.GetName()] = true
+	}
+	for i := range cfg.virtualServices {
+		vs := &cfg.virtualServices[i]
+		ref := fmt.Sprintf("virtualservices/%s/%s", vs.GetNamespace(), vs.GetName())
+		for _, gateway := range nestedStringSlice(vs, "spec", "gateways") {
+			if gateway == "mesh" {
+				continue
+			}
+			key := gatewayKey(gateway, vs.GetNamespace())
+			gwNamespace := strings.SplitN(key, "/", 2)[0]
+			if !gateways[key] && cfg.scanned(gwNamespace) {
+				findings = append(findings, configFinding{Check: "virtualservice-gateway-not-found", Severity: "high", Object: ref,
+					Message: fmt.Sprintf("binds to Gateway %s, which does not exist; the routes are not served there", key)})
+			}
+		}
+		seen := map[string]bool{}
+		for _, dest := range virtualServiceDestinations(vs) {
+			host := qualifyHost(dest.host, vs.GetNamespace())
+			if seen[host+"|"+dest.subset] {
+				continue
+			}
+			seen[host+"|"+dest.subset] = true
+			if !cfg.hostExists(host, entryHosts) {
+				if parts := strings.Split(host, "."); strings.HasSuffix(host, ".svc.cluster.local") && len(parts) == 5 {
+					if cfg.scanned(parts[1]) {
+						findings = append(findings, configFinding{Check: "virtualservice-host-not-found", Severity: "high", Object: ref,
+							Message: fmt.Sprintf("routes to %s, but no such Service or ServiceEntry exists; requests get 503 (NR)", host)})
+					}
+				} else {
+					findings = append(findings, configFinding{Check: "virtualservice-host-not-found", Severity: "medium", Object: ref,
+						Message: fmt.Sprintf("routes to %s, which no ServiceEntry defines; the route only works with outboundTrafficPolicy ALLOW_ANY", host)})
+				}
+				continue
+			}
+			if dest.subset != "" && !definedSubsets(host, cfg.destRules)[dest.subset] {
+				findings = append(findings, configFinding{Check: "virtualservice-subset-not-found", Severity: "high", Object: ref,
+					Message: fmt.Sprintf("routes to subset %s of %s, which no DestinationRule defines; requests get 503 (NR)", dest.subset, host)})
+			}
+		}
+	}
+	return findings
+}
+
+func (cfg meshConfig) hostExists(host string, entryHosts []string) bool {
+	if _, ok := cfg.services[host]; ok {
+		return true
+	}
+	return matchServiceEntry(host, entryHosts)
+}
+
+type vsDestination struct {
+	host   string
+	subset string
+}
+
+func virtualServiceDestinations(vs *unstructured.Unstructured) []vsDestination {
+	var out []vsDestination
+	for _, section := range []string{"http", "tcp", "tls"} {
+		routes, _, _ := unstructured.NestedSlice(vs.Object, "spec", section)
+		for _, raw := range routes {
+			route, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			destinations, _, _ := unstructured.NestedSlice(route, "route")
+			if mirror, ok := route["mirror"].(map[string]any); ok {
+				destinations = append(destinations, map[string]any{"destination": mirror})
+			}
+			for _, rawDest := range destinations {
+				entry, ok := rawDest.(map[string]any)
+				if !ok {
+					continue
+				}
+				dest, _ := entry["destination"].(map[string]any)
+				if host := toString(dest["host"]); host != "" {
+					out = append(out, vsDestination{host: host, subset: toString(dest["subset"])})
+				}
+			}
+		}
+	}
+	return out
+}
+
+// definedSubsets returns the subset names the DestinationRules for host define.
+func definedSubsets(host string, rules []unstructured.Unstructured) map[string]bool {
+	defined := map[string]bool{}
+	for i := range rules {
+		if !hostMatchesPattern(host, qualifyHost(nestedString(&rules[i], "spec", "host"), rules[i].GetNamespace())) {
+			continue
+		}
+		subsets, _, _ := unstructured.NestedSlice(rules[i].Object, "spec", "subsets")
+		for _, raw := range subsets {
+			if subset, ok := raw.(map[string]any); ok {
+				defined[toString(subset["name"])] = true
+			}
+		}
+	}
+	return defined
+}
+
+func destinationRuleFindings(cfg meshConfig) []configFinding {
+	var findings []configFinding
+	for i := range cfg.destRules {
+		rule := &cfg.destRules[i]
+		ref := fmt.Sprintf("destinationrules/%s/%s", rule.GetNamespace(), rule.GetName())
+		host := qualifyHost(nestedString(rule, "spec", "host"), rule.GetNamespace())
+		pods, ok := cfg.podsByService[host]
+		if !ok {
+			continue
+		}
+		subsets, _, _ := unstructured.NestedSlice(rule.Object, "spec", "subsets")
+		for _, raw := range subsets {
+			subset, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			selector := labels.SelectorFromSet(toStringMap(subset["labels"]))
+			matched := false
+			for j := range pods {
+				if selector.Matches(labels.Set(pods[j].Labels)) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				findings = append(findings, configFinding{Check: "destinationrule-subset-no-pods", Severity: "medium", Object: ref,
+					Message: fmt.Sprintf("subset %s selects %s, but no pod of %s carries those labels; traffic routed to it gets 503 (UH)", toString(subset["name"]), selector.String(), host)})
+			}
+		}
+	}
+	return findings
+}
+
+func gatewayFindings(cfg meshConfig) []configFinding {
+	var findings []configFinding
+	bound := map[string]bool{}
+	for i := range cfg.virtualServices {
+		for _, gateway := range nestedStringSlice(&cfg.virtualServices[i], "spec", "gateways") {
+			bound[gatewayKey(gateway, cfg.virtualServices[i].GetNamespace())] = true
+		}
+	}
+	for _, gw := range cfg.gateways {
+		// Root namespace gateways are usually bound from other namespaces,
+		// which a namespaced scan does not see.
+		if cfg.namespace != "" && gw.GetNamespace() != cfg.namespace {
+			continue
+		}
+		key := gw.GetNamespace() + "/" + gw.GetName()
+		if !bound[key] {
+			findings = append(findings, configFinding{Check: "gateway-unbound", Severity: "low", Object: "gateways/" + key,
+				Message: "no VirtualService binds to this Gateway, so its servers route nothing (404)"})
+		}
+	}
+	return findings
+}
+
+// gatewayKey resolves a VirtualService gateways entry ("name" or "ns/name")
+// to "ns/name".
+func gatewayKey(gateway, namespace string) string {
+	if strings.Contains(gateway, "/") {
+		return gateway
+	}
+	return namespace + "/" + gateway
+}
+
+func peerAuthenticationFindings(cfg meshConfig) []configFinding {
+	var findings []configFinding
+	namespaceWide := map[string][]string{}
+	bySelector := map[string][]string{}
+	for i := range cfg.peerAuths {
+		auth := &cfg.peerAuths[i]
+		matchLabels, _, _ := unstructured.NestedStringMap(auth.Object, "spec", "selector", "matchLabels")
+		if len(matchLabels) == 0 {
+			namespaceWide[auth.GetNamespace()] = append(namespaceWide[auth.GetNamespace()], auth.GetName())
+			continue
+		}
+		key := auth.GetNamespace() + "|" + labels.SelectorFromSet(matchLabels).String()
+		bySelector[key] = append(bySelector[key], auth.GetName())
+	}
+	for namespace, names := range namespaceWide {
+		if len(names) < 2 {
+			continue
+		}
+		sort.Strings(names)
+		scope := "namespace-wide"
+		if namespace == istioNamespace {
+			scope = "mesh-wide"
+		}
+		findings = append(findings, configFinding{Check: "peerauthentication-conflict", Severity: "high", Object: fmt.Sprintf("peerauthentications/%s/%s", namespace, names[0]),
+			Message: fmt.Sprintf("%d %s PeerAuthentications in %s (%s); Istio applies only the oldest, so the mTLS mode is ambiguous", len(names), scope, namespace, strings.Join(names, ", "))})
+	}
+	for key, names := range bySelector {
+		if len(names) < 2 {
+			continue
+		}
+		sort.Strings(names)
+		parts := strings.SplitN(key, "|", 2)
+		findings = append(findings, configFinding{Check: "peerauthentication-conflict", Severity: "medium", Object: fmt.Sprintf("peerauthentications/%s/%s", parts[0], names[0]),
+			Message: fmt.Sprintf("PeerAuthentications %s in %s select the same workloads (%s); only the oldest applies", strings.Join(names, ", "), parts[0], parts[1])})
+	}
+	for i := range cfg.destRules {
+		rule := &cfg.destRules[i]
+		if nestedString(rule, "spec", "trafficPolicy", "tls", "mode") != "DISABLE" {
+			continue
+		}
+		host := qualifyHost(nestedString(rule, "spec", "host"), rule.GetNamespace())
+		svc, ok := cfg.services[host]
+		if !ok {
+			continue
+		}
+		var auths []unstructured.Unstructured
+		for _, auth := range cfg.peerAuths {
+			if auth.GetNamespace() == svc.Namespace || auth.GetNamespace() == istioNamespace {
+				auths = append(auths, auth)
+			}
+		}
+		var podLabels map[string]string
+		if pods := cfg.podsByService[host]; len(pods) > 0 {
+			podLabels = pods[0].Labels
+		}
+		if mode, source := effectivePeerAuthentication(auths, podLabels); mode == "STRICT" {
+			findings = append(findings, configFinding{Check: "mtls-conflict", Severity: "high", Object: fmt.Sprintf("destinationrules/%s/%s", rule.GetNamespace(), rule.GetName()),
+				Message: fmt.Sprintf("disables TLS to %s, but %s requires STRICT mTLS; the connections are reset (503)", host, source)})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,113 @@
+package istio
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+func meshObject(apiVersion, kind, namespace, name string, spec map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]any{"name": name, "namespace": namespace},
+		"spec":       spec,
+	}}
+}
+
+func TestAnalyzeConfig(t *testing.T) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviews", Namespace: "shop"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "reviews"}},
+	}
+	objects := []*unstructured.Unstructured{
+		meshObject("networking.istio.io/v1", "VirtualService", "shop", "reviews", map[string]any{
+			"hosts":    []any{"reviews"},
+			"gateways": []any{"shop-gw", "missing-gw", "mesh"},
+			"http": []any{map[string]any{"route": []any{
+				map[string]any{"destination": map[string]any{"host": "reviews", "subset": "v1"}},
+				map[string]any{"destination": map[string]any{"host": "reviews", "subset": "v3"}},
+				map[string]any{"destination": map[string]any{"host": "ratings"}},
+				map[string]any{"destination": map[string]any{"host": "api.example.com"}},
+				map[string]any{"destination": map[string]any{"host": "httpbin.org"}},
+				map[string]any{"destination": map[string]any{"host": "details.other.svc.cluster.local"}},
+			}}},
+		}),
+		meshObject("networking.istio.io/v1", "DestinationRule", "shop", "reviews", map[string]any{
+			"host":          "reviews",
+			"trafficPolicy": map[string]any{"tls": map[string]any{"mode": "DISABLE"}},
+			"subsets": []any{
+				map[string]any{"name": "v1", "labels": map[string]any{"version": "v1"}},
+				map[string]any{"name": "v2", "labels": map[string]any{"version": "v2"}},
+			},
+		}),
+		meshObject("networking.istio.io/v1", "Gateway", "shop", "shop-gw", map[string]any{}),
+		meshObject("networking.istio.io/v1", "Gateway", "shop", "idle-gw", map[string]any{}),
+		meshObject("networking.istio.io/v1", "ServiceEntry", "shop", "httpbin", map[string]any{"hosts": []any{"httpbin.org"}}),
+		meshObject("security.istio.io/v1", "PeerAuthentication", "shop", "default", map[string]any{"mtls": map[string]any{"mode": "STRICT"}}),
+		meshObject("security.istio.io/v1", "PeerAuthentication", "shop", "reviews-a", map[string]any{"selector": map[string]any{"matchLabels": map[string]any{"app": "reviews"}}}),
+		meshObject("security.istio.io/v1", "PeerAuthentication", "shop", "reviews-b", map[string]any{"selector": map[string]any{"matchLabels": map[string]any{"app": "reviews"}}}),
+	}
+	toolset := newMeshConfigToolset(t, []runtime.Object{service, reviewsPod("reviews-1", "v1", true)}, objects...)
+
+	result, err := toolset.handleAnalyzeConfig(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: map[string]any{"namespace": "shop"}})
+	if err != nil {
+		t.Fatalf("analyze config: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	var findings []configFinding
+	for _, item := range data["evidence"].([]render.EvidenceItem) {
+		if item.Summary == "findings" {
+			findings = item.Details.([]configFinding)
+		}
+	}
+	got := map[string]int{}
+	for _, finding := range findings {
+		got[finding.Check+"/"+finding.Severity]++
+	}
+	want := map[string]int{
+		"virtualservice-gateway-not-found/high": 1,
+		"virtualservice-subset-not-found/high":  1,
+		"virtualservice-host-not-found/high":    1,
+		"virtualservice-host-not-found/medium":  1,
+		"destinationrule-subset-no-pods/medium": 1,
+		"gateway-unbound/low":                   1,
+		"peerauthentication-conflict/medium":    1,
+		"mtls-conflict/high":                    1,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected findings %v, got %v (%+v)", want, got, findings)
+	}
+	for key, count := range want {
+		if got[key] != count {
+			t.Fatalf("expected %d %s, got %v (%+v)", count, key, got, findings)
+		}
+	}
+	if findings[0].Severity != "high" || findings[len(findings)-1].Severity != "low" {
+		t.Fatalf("expected findings ordered by severity: %+v", findings)
+	}
+	for _, cause := range data["likelyRootCauses"].([]render.Cause) {
+		if cause.Summary == configCheckTitles["gateway-unbound"] {
+			t.Fatalf("low severity findings should not be causes: %+v", cause)
+		}
+	}
+}
+
+func TestPeerAuthenticationFindingsNamespaceWide(t *testing.T) {
+	cfg := meshConfig{peerAuths: []unstructured.Unstructured{
+		*meshObject("security.istio.io/v1", "PeerAuthentication", istioNamespace, "default", map[string]any{"mtls": map[string]any{"mode": "STRICT"}}),
+		*meshObject("security.istio.io/v1", "PeerAuthentication", istioNamespace, "legacy", map[string]any{"mtls": map[string]any{"mode": "PERMISSIVE"}}),
+	}}
+	findings := peerAuthenticationFindings(cfg)
+	if len(findings) != 1 || findings[0].Severity != "high" || findings[0].Object != "peerauthentications/"+istioNamespace+"/default" {
+		t.Fatalf("expected one mesh-wide conflict, got %+v", findings)
+	}
+}
@@ -0,0 +1,145 @@
+package istio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/argconv"
+	"rootcause/internal/istioauthz"
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/render"
+)
+
+func (t *Toolset) handleEvaluateAuthz(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	args := req.Arguments
+	namespace := toString(args["namespace"])
+	pod := toString(args["pod"])
+	workloadLabels := toStringMap(args["workloadLabels"])
+	if namespace == "" || (pod == "" && len(workloadLabels) == 0) {
+		err := errors.New("namespace and pod or workloadLabels required")
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	analysis := render.NewAnalysis()
+	if pod != "" {
+		podObj, err := t.ctx.Clients.Typed.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				analysis.AddEvidence("status", "pod not found")
+				analysis.AddNextCheck("Verify pod name and namespace")
+				return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
+			}
+			return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+		}
+		workloadLabels = podObj.Labels
+		analysis.AddResource(fmt.Sprintf("pods/%s/%s", namespace, pod))
+		if !hasIstioProxy(podObj) {
+			analysis.AddCause("Workload has no istio-proxy", fmt.Sprintf("%s/%s has no sidecar; AuthorizationPolicies are not enforced on it", namespace, pod), "medium")
+		}
+	}
+
+	request := istioauthz.Request{
+		Principal: toString(args["sourcePrincipal"]),
+		Namespace: toString(args["sourceNamespace"]),
+		Host:      toString(args["host"]),
+		Port:      argconv.Int(args["port"], 0),
+		Method:    toString(args["method"]),
+		Path:      toString(args["path"]),
+	}
+	if sa := toString(args["sourceServiceAccount"]); sa != "" && request.Principal == "" && request.Namespace != "" {
+		request.Principal = fmt.Sprintf("cluster.local/ns/%s/sa/%s", request.Namespace, sa)
+	}
+	if request.Namespace == "" {
+		if ns, ok := principalNamespace(request.Principal); ok {
+			request.Namespace = ns
+		}
+	}
+	analysis.AddEvidence("request", request)
+
+	gvr, namespaced, err := kube.ResolveResourceBestEffort(t.ctx.Clients.Mapper, t.ctx.Clients.Discovery, "", "AuthorizationPolicy", "", "security.istio.io")
+	if err != nil {
+		analysis.AddEvidence("status", "AuthorizationPolicy resource not available")
+		analysis.AddNextCheck("Verify the security.istio.io CRDs are installed")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
+	}
+	items, _, err := t.listObjects(ctx, req.User, gvr, namespaced, namespace, "")
+	if err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	// Mesh-wide policies live in the root namespace.
+	if namespace != istioNamespace {
+		if err := t.ctx.Policy.CheckNamespace(req.User, istioNamespace, true); err == nil {
+			rootItems, _, err := t.listObjects(ctx, req.User, gvr, namespaced, istioNamespace, "")
+			if err != nil {
+				return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+			}
+			items = append(items, rootItems...)
+		} else {
+			analysis.AddEvidence("rootNamespace", fmt.Sprintf("mesh-wide AuthorizationPolicies in %s not visible: %v", istioNamespace, err))
+		}
+	}
+	var policies []istioauthz.Policy
+	for i := range items {
+		policy := istioauthz.Parse(&items[i])
+		if !policy.AppliesTo(istioNamespace, namespace, workloadLabels) {
+			continue
+		}
+		policies = append(policies, policy)
+		analysis.AddResource(t.ctx.Evidence.ResourceRef(gvr, policy.Namespace, policy.Name))
+	}
+
+	decision := istioauthz.Evaluate(policies, request)
+	analysis.AddEvidence("decision", t.ctx.Redactor.RedactValue(decision))
+	switch decision.Decision {
+	case istioauthz.ActionDeny:
+		if decision.DecidedBy != "" {
+			analysis.AddCause("Request denied by AuthorizationPolicy", fmt.Sprintf("%s: %s", decision.DecidedBy, decision.Reason), "high")
+			analysis.AddNextCheck(fmt.Sprintf("Review the DENY rules in %s", decision.DecidedBy))
+		} else {
+			analysis.AddCause("Request denied by default", decision.Reason, "high")
+			analysis.AddNextCheck("Add an ALLOW rule matching this source and operation, or correct the source principal")
+		}
+	case istioauthz.ActionCustom:
+		analysis.AddCause("Decision delegated to external authorizer", fmt.Sprintf("%s: %s", decision.DecidedBy, decision.Reason), "medium")
+		analysis.AddNextCheck("Check the external authorizer's logs for this request")
+	default:
+		analysis.AddNextCheck("If requests still get 403, check for RBAC: access denied in istio-proxy logs and mTLS mode (PeerAuthentication)")
+	}
+	if len(decision.Warnings) > 0 {
+		analysis.AddNextCheck("Supply the fields listed in warnings to evaluate the remaining rules")
+	}
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+}
+
+// principalNamespace extracts the namespace from a SPIFFE-style principal
+// (cluster.local/ns/<ns>/sa/<sa>).
+func principalNamespace(principal string) (string, bool) {
+	_, rest, ok := strings.Cut(principal, "/ns/")
+	if !ok {
+		return "", false
+	}
+	ns, sa, ok := strings.Cut(rest, "/sa/")
+	return ns, ok && ns != "" && sa != ""
+}
+
+func toStringMap(value any) map[string]string {
+	switch v := value.(type) {
+	case map[string]string:
+		return v
+	case map[string]any:
+		out := make(map[string]string, len(v))
+		for key, item := range v {
+			out[key] = toString(item)
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package istio
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"rootcause/internal/istioauthz"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+func TestEvaluateAuthz(t *testing.T) {
+	allow := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "security.istio.io/v1",
+		"kind":       "AuthorizationPolicy",
+		"metadata":   map[string]any{"name": "cart-allow", "namespace": "shop"},
+		"spec": map[string]any{
+			"selector": map[string]any{"matchLabels": map[string]any{"app": "cart"}},
+			"rules": []any{map[string]any{
+				"from": []any{map[string]any{"source": map[string]any{"namespaces": []any{"web"}}}},
+			}},
+		},
+	}}
+	meshDeny := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "security.istio.io/v1",
+		"kind":       "AuthorizationPolicy",
+		"metadata":   map[string]any{"name": "block-debug", "namespace": istioNamespace},
+		"spec": map[string]any{
+			"action": "DENY",
+			"rules":  []any{map[string]any{"to": []any{map[string]any{"operation": map[string]any{"paths": []any{"/debug*"}}}}}},
+		},
+	}}
+	toolset := newMeshConfigToolset(t, []runtime.Object{proxyPod("shop", "cart-1", map[string]string{"app": "cart"})}, allow, meshDeny)
+	decide := func(args map[string]any) istioauthz.Decision {
+		t.Helper()
+		result, err := toolset.handleEvaluateAuthz(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: args})
+		if err != nil {
+			t.Fatalf("evaluate authz: %v", err)
+		}
+		for _, item := range result.Data.(map[string]any)["evidence"].([]render.EvidenceItem) {
+			if item.Summary == "decision" {
+				return item.Details.(istioauthz.Decision)
+			}
+		}
+		t.Fatalf("no decision in %#v", result.Data)
+		return istioauthz.Decision{}
+	}
+
+	got := decide(map[string]any{"namespace": "shop", "pod": "cart-1", "sourceNamespace": "web", "sourceServiceAccount": "frontend", "method": "GET", "path": "/items"})
+	if !got.Allowed || got.DecidedBy != "shop/cart-allow" {
+		t.Fatalf("expected allow from cart-allow, got %+v", got)
+	}
+	got = decide(map[string]any{"namespace": "shop", "pod": "cart-1", "sourcePrincipal": "cluster.local/ns/web/sa/frontend", "path": "/debug/pprof"})
+	if got.Allowed || got.DecidedBy != istioNamespace+"/block-debug" {
+		t.Fatalf("expected mesh-wide deny, got %+v", got)
+	}
+	got = decide(map[string]any{"namespace": "shop", "workloadLabels": map[string]any{"app": "cart"}, "sourceNamespace": "batch", "path": "/items"})
+	if got.Allowed || got.Decision != istioauthz.ActionDeny || got.DecidedBy != "" {
+		t.Fatalf("expected default deny, got %+v", got)
+	}
+	if _, err := toolset.handleEvaluateAuthz(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: map[string]any{"namespace": "shop"}}); err == nil {
+		t.Fatalf("expected error without pod or workloadLabels")
+	}
+}
+
+func TestPrincipalNamespace(t *testing.T) {
+	if ns, ok := principalNamespace("spiffe://cluster.local/ns/web/sa/frontend"); !ok || ns != "web" {
+		t.Fatalf("unexpected namespace %q %v", ns, ok)
+	}
+	if _, ok := principalNamespace("frontend"); ok {
+		t.Fatalf("expected no namespace for bare name")
+	}
+}
@@ -0,0 +1,303 @@
+package istio
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"rootcause/internal/argconv"
+)
+
+// configDumpMaxBytes is the default cap on the config_dump data returned,
+// measured as JSON. Sidecars on large meshes and gateways dump megabytes.
+const configDumpMaxBytes = 1 << 20
+
+// envoyResourcePattern matches config_dump section fields accepted by the
+// Envoy admin `resource` parameter, e.g. "dynamic_active_clusters".
+var envoyResourcePattern = regexp.MustCompile(`^[a-z_]+$`)
+
+// configDumpSectionTypes maps the Envoy admin config_dump section message
+// names to the short type names accepted by istio.proxy_config_dump.
+var configDumpSectionTypes = map[string]string{
+	"ListenersConfigDump": "listener",
+	"ClustersConfigDump":  "cluster",
+	"RoutesConfigDump":    "route",
+	"EndpointsConfigDump": "endpoint",
+}
+
+// configDumpEntryKeys are the wrapper fields that hold the actual resource
+// inside a config_dump entry, in lookup order.
+var configDumpEntryKeys = []string{"listener", "cluster", "route_config", "endpoint_config", "active_state", "warming_state", "draining_state"}
+
+type configDumpFilter struct {
+	name string
+	kind string
+	raw  bool
+	// resource is passed to Envoy as `?resource=` so only that section is
+	// dumped, e.g. "dynamic_active_clusters".
+	resource string
+	// offset skips that many matches; with maxBytes it pages the result.
+	offset   int
+	maxBytes int
+}
+
+func configDumpFilterFromArgs(args map[string]any) (configDumpFilter, error) {
+	filter := configDumpFilter{
+		name: strings.TrimSpace(toString(args["resourceName"])),
+		kind: strings.ToLower(strings.TrimSpace(toString(args["type"]))),
+	}
+	filter.raw, _ = args["raw"].(bool)
+	filter.resource = strings.TrimSpace(toString(args["resource"]))
+	if filter.resource != "" && !envoyResourcePattern.MatchString(filter.resource) {
+		return filter, fmt.Errorf("invalid config_dump resource %q (use a section field such as dynamic_active_clusters)", filter.resource)
+	}
+	filter.offset = argconv.Int(args["offset"], 0)
+	if filter.offset < 0 {
+		return filter, fmt.Errorf("offset must not be negative")
+	}
+	filter.maxBytes = argconv.Int(args["maxBytes"], configDumpMaxBytes)
+	if filter.maxBytes <= 0 {
+		filter.maxBytes = configDumpMaxBytes
+	}
+	switch filter.kind {
+	case "", "listener", "cluster", "route", "endpoint":
+	case "listeners", "clusters", "routes", "endpoints":
+		filter.kind = strings.TrimSuffix(filter.kind, "s")
+	default:
+		return filter, fmt.Errorf("unsupported config_dump type %q (use listener, cluster, route or endpoint)", filter.kind)
+	}
+	return filter, nil
+}
+
+// active reports whether the filtered view should replace the full dump.
+func (f configDumpFilter) active() bool {
+	return !f.raw && (f.name != "" || f.kind != "")
+}
+
+// adminParams returns the Envoy admin query parameters that narrow the dump
+// before it leaves the proxy: include_eds for endpoints, which config_dump
+// omits by default, resource for one section, and name_regex for a
+// resourceName. Envoy versions without name_regex ignore it; the dump is
+// still filtered after fetch.
+func (f configDumpFilter) adminParams() map[string]string {
+	params := map[string]string{}
+	if f.kind == "endpoint" {
+		params["include_eds"] = "true"
+	}
+	if f.resource != "" {
+		params["resource"] = f.resource
+	}
+	if f.name != "" && !f.raw {
+		params["name_regex"] = ".*" + regexp.QuoteMeta(f.name) + ".*"
+	}
+	return params
+}
+
+// filterConfigDump walks the parsed config_dump and returns only the
+// listener/cluster/route/endpoint entries matching the filter. Names match
+// exactly or by substring, so "reviews" finds
+// "outbound|9080||reviews.default.svc.cluster.local".
+func filterConfigDump(payload any, filter configDumpFilter) map[string]any {
+	var matches []map[string]any
+	var sections []string
+	root, _ := payload.(map[string]any)
+	configs, _ := root["configs"].([]any)
+	for _, config := range configs {
+		section, ok := config.(map[string]any)
+		if !ok {
+			continue
+		}
+		kind, entryType := configDumpSectionType(toString(section["@type"]))
+		if kind == "" || (filter.kind != "" && kind != filter.kind) {
+			continue
+		}
+		sections = append(sections, kind)
+		if entryType != "" {
+			// With ?resource= Envoy lists the entries themselves, e.g.
+			// ClustersConfigDump.DynamicCluster, instead of whole sections.
+			name := configDumpEntryName(section)
+			if filter.name != "" && name != filter.name && !strings.Contains(name, filter.name) {
+				continue
+			}
+			matches = append(matches, map[string]any{
+				"type":   kind,
+				"state":  configDumpResourceState(entryType, filter.resource),
+				"name":   name,
+				"config": section,
+			})
+			continue
+		}
+		keys := make([]string, 0, len(section))
+		for key := range section {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			entries, ok := section[key].([]any)
+			if !ok {
+				continue
+			}
+			for _, entry := range entries {
+				entryMap, ok := entry.(map[string]any)
+				if !ok {
+					continue
+				}
+				name := configDumpEntryName(entryMap)
+				if filter.name != "" && name != filter.name && !strings.Contains(name, filter.name) {
+					continue
+				}
+				matches = append(matches, map[string]any{
+					"type":   kind,
+					"state":  configDumpEntryState(key),
+					"name":   name,
+					"config": entryMap,
+				})
+			}
+		}
+	}
+	page, truncated := pageMatches(matches, filter.offset, filter.maxBytes)
+	result := map[string]any{
+		"matches":  page,
+		"count":    len(matches),
+		"returned": len(page),
+		"filter":   map[string]any{"resourceName": filter.name, "type": filter.kind},
+	}
+	if filter.offset > 0 {
+		result["offset"] = filter.offset
+	}
+	if truncated {
+		result["truncated"] = true
+		result["nextOffset"] = filter.offset + len(page)
+	}
+	if len(sections) == 0 {
+		result["warning"] = "no matching listener/cluster/route/endpoint sections in config_dump"
+	}
+	return result
+}
+
+// pageMatches returns the matches from offset on that fit in maxBytes of
+// JSON, and whether any were left out. The first match is always returned
+// so a single oversized entry is never hidden.
+func pageMatches(matches []map[string]any, offset, maxBytes int) ([]map[string]any, bool) {
+	if offset >= len(matches) {
+		return nil, false
+	}
+	var page []map[string]any
+	size := 0
+	for i, match := range matches[offset:] {
+		encoded, err := json.Marshal(match)
+		if err == nil {
+			size += len(encoded)
+		}
+		if i > 0 && size > maxBytes {
+			return page, true
+		}
+		page = append(page, match)
+	}
+	return page, false
+}
+
+// summarizeConfigDump replaces a dump larger than the cap with the entry
+// counts per section, so the caller can pick a type, resourceName or
+// resource to fetch instead. A resource-mode dump, which lists entries
+// rather than sections, is counted per entry @type.
+func summarizeConfigDump(payload any, rawBytes, maxBytes int) map[string]any {
+	var sections []map[string]any
+	entryCounts := map[string]int{}
+	root, _ := payload.(map[string]any)
+	configs, _ := root["configs"].([]any)
+	for _, config := range configs {
+		section, ok := config.(map[string]any)
+		if !ok {
+			continue
+		}
+		typeURL := toString(section["@type"])
+		if _, entryType := configDumpSectionType(typeURL); entryType != "" {
+			entryCounts[typeURL]++
+			continue
+		}
+		entries := map[string]int{}
+		for key, value := range section {
+			if list, ok := value.([]any); ok {
+				entries[key] = len(list)
+			}
+		}
+		sections = append(sections, map[string]any{"@type": typeURL, "entries": entries})
+	}
+	typeURLs := make([]string, 0, len(entryCounts))
+	for typeURL := range entryCounts {
+		typeURLs = append(typeURLs, typeURL)
+	}
+	sort.Strings(typeURLs)
+	for _, typeURL := range typeURLs {
+		sections = append(sections, map[string]any{"@type": typeURL, "count": entryCounts[typeURL]})
+	}
+	return map[string]any{
+		"truncated": true,
+		"rawBytes":  rawBytes,
+		"maxBytes":  maxBytes,
+		"sections":  sections,
+		"hint":      "config_dump exceeds maxBytes; narrow it with type, resourceName or resource, page with offset, or raise maxBytes",
+	}
+}
+
+// configDumpSectionType returns the short type for a config_dump @type. For
+// a per-entry @type such as "...ClustersConfigDump.DynamicCluster", returned
+// when the dump is narrowed with ?resource=, entryType is the trailing
+// message name ("DynamicCluster"); it is empty for whole sections.
+func configDumpSectionType(typeURL string) (kind, entryType string) {
+	parts := strings.Split(typeURL, ".")
+	last := len(parts) - 1
+	if kind := configDumpSectionTypes[parts[last]]; kind != "" {
+		return kind, ""
+	}
+	if last > 0 {
+		if kind := configDumpSectionTypes[parts[last-1]]; kind != "" {
+			return kind, parts[last]
+		}
+	}
+	return "", ""
+}
+
+// configDumpResourceState is configDumpEntryState for resource-mode entries:
+// static entries say so in their type, the rest take the state from the
+// requested section, e.g. "dynamic_warming_clusters".
+func configDumpResourceState(entryType, resource string) string {
+	if strings.HasPrefix(entryType, "Static") {
+		return "static"
+	}
+	return configDumpEntryState(resource)
+}
+
+// configDumpEntryState condenses section keys such as
+// "dynamic_warming_clusters" to a short state; the long keys would otherwise
+// be mangled by the redactor's token pattern.
+func configDumpEntryState(section string) string {
+	for _, state := range []string{"static", "warming", "draining"} {
+		if strings.Contains(section, state) {
+			return state
+		}
+	}
+	return "active"
+}
+
+func configDumpEntryName(entry map[string]any) string {
+	if name := toString(entry["name"]); name != "" {
+		return name
+	}
+	for _, key := range configDumpEntryKeys {
+		inner, ok := entry[key].(map[string]any)
+		if !ok {
+			continue
+		}
+		if name := configDumpEntryName(inner); name != "" {
+			return name
+		}
+		if name := toString(inner["cluster_name"]); name != "" {
+			return name
+		}
+	}
+	return ""
+}
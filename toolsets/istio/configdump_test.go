@@ -0,0 +1,207 @@
+package istio
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+
+	"rootcause/internal/config"
+	"rootcause/internal/evidence"
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/redact"
+	"rootcause/internal/render"
+)
+
+const sampleConfigDump = `{"configs":[
+  {"@type":"type.googleapis.com/envoy.admin.v3.BootstrapConfigDump","bootstrap":{"node":{"id":"sidecar"}}},
+  {"@type":"type.googleapis.com/envoy.admin.v3.ClustersConfigDump",
+   "static_clusters":[{"cluster":{"name":"prometheus_stats"}}],
+   "dynamic_active_clusters":[
+     {"version_info":"1","cluster":{"name":"outbound|9080||reviews.default.svc.cluster.local"}},
+     {"version_info":"1","cluster":{"name":"outbound|9080||ratings.default.svc.cluster.local"}}
+   ]},
+  {"@type":"type.googleapis.com/envoy.admin.v3.ListenersConfigDump",
+   "dynamic_listeners":[{"name":"0.0.0.0_9080","active_state":{"listener":{"name":"0.0.0.0_9080"}}}]},
+  {"@type":"type.googleapis.com/envoy.admin.v3.RoutesConfigDump",
+   "dynamic_route_configs":[{"route_config":{"name":"9080"}}]},
+  {"@type":"type.googleapis.com/envoy.admin.v3.EndpointsConfigDump",
+   "dynamic_endpoint_configs":[{"endpoint_config":{"cluster_name":"outbound|9080||reviews.default.svc.cluster.local"}}]}
+]}`
+
+// sampleResourceConfigDump is what Envoy returns for
+// config_dump?resource=dynamic_active_clusters: the entries, not the section.
+const sampleResourceConfigDump = `{"configs":[
+  {"@type":"type.googleapis.com/envoy.admin.v3.ClustersConfigDump.DynamicCluster",
+   "version_info":"1","cluster":{"name":"outbound|9080||reviews.default.svc.cluster.local"}},
+  {"@type":"type.googleapis.com/envoy.admin.v3.ClustersConfigDump.DynamicCluster",
+   "version_info":"1","cluster":{"name":"outbound|9080||ratings.default.svc.cluster.local"}}
+]}`
+
+func TestFilterConfigDump(t *testing.T) {
+	var payload any
+	if err := json.Unmarshal([]byte(sampleConfigDump), &payload); err != nil {
+		t.Fatalf("decode sample: %v", err)
+	}
+	cases := []struct {
+		name  string
+		args  map[string]any
+		count int
+	}{
+		{"substring across types", map[string]any{"resourceName": "reviews"}, 2},
+		{"type narrows", map[string]any{"resourceName": "reviews", "type": "cluster"}, 1},
+		{"type only", map[string]any{"type": "clusters"}, 3},
+		{"listener via active_state", map[string]any{"resourceName": "0.0.0.0_9080", "type": "listener"}, 1},
+		{"route", map[string]any{"resourceName": "9080", "type": "route"}, 1},
+		{"no match", map[string]any{"resourceName": "details"}, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := configDumpFilterFromArgs(tc.args)
+			if err != nil {
+				t.Fatalf("filter args: %v", err)
+			}
+			got := filterConfigDump(payload, filter)
+			if got["count"] != tc.count {
+				t.Fatalf("count = %v, want %d: %#v", got["count"], tc.count, got["matches"])
+			}
+		})
+	}
+	if _, err := configDumpFilterFromArgs(map[string]any{"type": "secret"}); err == nil {
+		t.Fatalf("expected error for unsupported type")
+	}
+	if filter, _ := configDumpFilterFromArgs(map[string]any{"resourceName": "reviews", "raw": true}); filter.active() {
+		t.Fatalf("raw should bypass the filtered view")
+	}
+	if _, err := configDumpFilterFromArgs(map[string]any{"resource": "clusters&x=1"}); err == nil {
+		t.Fatalf("expected error for invalid resource")
+	}
+}
+
+func TestFilterConfigDumpPages(t *testing.T) {
+	var payload any
+	if err := json.Unmarshal([]byte(sampleConfigDump), &payload); err != nil {
+		t.Fatalf("decode sample: %v", err)
+	}
+	filter, err := configDumpFilterFromArgs(map[string]any{"type": "cluster", "maxBytes": 10})
+	if err != nil {
+		t.Fatalf("filter args: %v", err)
+	}
+	var names []string
+	for page := 0; page < 3; page++ {
+		got := filterConfigDump(payload, filter)
+		if got["count"] != 3 || got["returned"] != 1 {
+			t.Fatalf("page %d: expected one of three matches, got %#v", page, got)
+		}
+		names = append(names, got["matches"].([]map[string]any)[0]["name"].(string))
+		if page == 2 {
+			if got["truncated"] != nil {
+				t.Fatalf("last page should not be truncated: %#v", got)
+			}
+			break
+		}
+		if got["truncated"] != true || got["nextOffset"] != page+1 {
+			t.Fatalf("page %d: expected truncation with nextOffset, got %#v", page, got)
+		}
+		filter.offset = got["nextOffset"].(int)
+	}
+	if names[0] == names[1] || names[1] == names[2] {
+		t.Fatalf("expected distinct entries across pages, got %v", names)
+	}
+}
+
+func TestProxyConfigDumpFiltered(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "proxy", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "istio-proxy"}}},
+	}
+	client := fake.NewSimpleClientset(pod)
+	var params map[string]string
+	client.Fake.PrependProxyReactor("pods", func(action clienttesting.Action) (bool, rest.ResponseWrapper, error) {
+		params = action.(clienttesting.ProxyGetAction).GetParams()
+		if params["resource"] != "" {
+			return true, staticResponse{raw: []byte(sampleResourceConfigDump)}, nil
+		}
+		return true, staticResponse{raw: []byte(sampleConfigDump)}, nil
+	})
+	clients := &kube.Clients{Typed: client}
+	cfg := config.DefaultConfig()
+	toolset := New()
+	_ = toolset.Init(mcp.ToolContext{
+		Config:   &cfg,
+		Clients:  clients,
+		Policy:   policy.NewAuthorizer(),
+		Renderer: render.NewRenderer(),
+		Redactor: redact.New(),
+		Evidence: evidence.NewCollector(clients),
+	})
+	call := func(args map[string]any) map[string]any {
+		t.Helper()
+		args["namespace"] = "default"
+		args["pod"] = "proxy"
+		result, err := toolset.handleProxyConfigDump(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: args})
+		if err != nil {
+			t.Fatalf("config dump: %v", err)
+		}
+		return proxyDataEvidence(t, result)
+	}
+
+	data := call(map[string]any{"resourceName": "reviews", "type": "endpoint"})
+	if data["count"] != 1 || params["include_eds"] == "" {
+		t.Fatalf("expected filtered endpoint view with include_eds, got %#v params %v", data, params)
+	}
+	if data["rawBytes"] != len(sampleConfigDump) {
+		t.Fatalf("expected rawBytes, got %#v", data["rawBytes"])
+	}
+	if data := call(map[string]any{"resourceName": "reviews", "raw": true}); data["configs"] == nil {
+		t.Fatalf("expected raw config dump, got %#v", data)
+	}
+	if params["name_regex"] != "" {
+		t.Fatalf("raw should not narrow the dump on the proxy, got params %v", params)
+	}
+	data = call(map[string]any{"resourceName": "reviews.default", "type": "cluster", "resource": "dynamic_active_clusters"})
+	matches, _ := data["matches"].([]map[string]any)
+	if data["count"] != 1 || len(matches) != 1 || matches[0]["name"] != "outbound|9080||reviews.default.svc.cluster.local" || matches[0]["state"] != "active" {
+		t.Fatalf("expected the reviews cluster from the resource-mode dump, got %#v", data)
+	}
+	if data["warning"] != nil {
+		t.Fatalf("resource-mode entries should count as cluster sections, got %#v", data["warning"])
+	}
+	data = call(map[string]any{"resource": "dynamic_active_clusters", "maxBytes": 50})
+	sections, _ := data["sections"].([]map[string]any)
+	if len(sections) != 1 || sections[0]["count"] != 2 {
+		t.Fatalf("expected resource-mode summary counted per entry type, got %#v", data)
+	}
+	data = call(map[string]any{"maxBytes": 100})
+	if data["truncated"] != true || data["configs"] != nil || len(data["sections"].([]map[string]any)) != 5 {
+		t.Fatalf("expected section summary for oversized dump, got %#v", data)
+	}
+	if _, err := toolset.handleProxyConfigDump(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"namespace": "default", "pod": "proxy", "type": "secret"},
+	}); err == nil {
+		t.Fatalf("expected error for unsupported type")
+	}
+}
+
+func proxyDataEvidence(t *testing.T, result mcp.ToolResult) map[string]any {
+	t.Helper()
+	items, _ := result.Data.(map[string]any)["evidence"].([]render.EvidenceItem)
+	for _, item := range items {
+		if item.Summary != "proxyData" {
+			continue
+		}
+		if data, ok := item.Details.(map[string]any); ok {
+			return data
+		}
+	}
+	t.Fatalf("proxyData evidence not found in %#v", result.Data)
+	return nil
+}
@@ -250,11 +250,37 @@ func TestHandleServiceMeshHostsNoHosts(t *testing.T) {
 
 func TestHandleExternalDependencyCheckMissingHosts(t *testing.T) {
 	toolset := newIstioToolset(t)
-	if _, err := toolset.handleExternalDependencyCheck(context.Background(), mcp.ToolRequest{
+	result, err := toolset.handleExternalDependencyCheck(context.Background(), mcp.ToolRequest{
 		User: policy.User{Role: policy.RoleCluster},
-	}); err != nil {
+	})
+	if err != nil {
 		t.Fatalf("handleExternalDependencyCheck missing hosts: %v", err)
 	}
+	causes, _ := result.Data.(map[string]any)["likelyRootCauses"].([]render.Cause)
+	for _, cause := range causes {
+		if cause.Summary == "External host missing ServiceEntry" {
+			if cause.Confidence != render.ConfidenceHigh {
+				t.Fatalf("expected high confidence for missing ServiceEntry, got %#v", cause)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected missing ServiceEntry cause, got %#v", causes)
+}
+
+func TestLooksClusterLocal(t *testing.T) {
+	for host, want := range map[string]bool{
+		"reviews":                           true,
+		"reviews.default.svc":               true,
+		"reviews.default.svc.cluster.local": true,
+		"api.example.com":                   false,
+		"example.com":                       false,
+		"*":                                 false,
+	} {
+		if got := looksClusterLocal(host); got != want {
+			t.Fatalf("looksClusterLocal(%q) = %v, want %v", host, got, want)
+		}
+	}
 }
 
 func TestHandleExternalDependencyCheckAllCovered(t *testing.T) {
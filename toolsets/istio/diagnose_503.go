@@ -0,0 +1,365 @@
+package istio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+// diagnose503Check is one line of the 503 checklist.
+type diagnose503Check struct {
+	Check  string `json:"check"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail"`
+}
+
+func (t *Toolset) handleDiagnose503(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	namespace := toString(req.Arguments["namespace"])
+	service := toString(req.Arguments["service"])
+	if namespace == "" || service == "" {
+		err := errors.New("namespace and service required")
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	analysis := render.NewAnalysis()
+	ref := fmt.Sprintf("%s/%s", namespace, service)
+	host := fmt.Sprintf("%s.%s.svc.cluster.local", service, namespace)
+	var checks []diagnose503Check
+
+	// 1. The destination has ready endpoints.
+	backends, err := t.serviceBackends(ctx, namespace, service)
+	if err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	if backends.service == nil {
+		analysis.AddCauseWithConfidence("Service not found", fmt.Sprintf("%s does not exist; the mesh has no route for %s and answers 503 (NR)", ref, host), "high", render.ConfidenceHigh)
+		analysis.AddNextCheck("Verify service name and namespace")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+	}
+	t.addServiceBackends(&analysis, backends)
+	checks = append(checks, endpointsCheck(&analysis, ref, backends))
+
+	// 2. DestinationRules for the host and the subsets VirtualServices use.
+	rules, rulesOK := t.meshObjects(ctx, &analysis, req.User, "DestinationRule", "networking.istio.io", namespace)
+	var matching []unstructured.Unstructured
+	for _, rule := range rules {
+		ruleHost, _, _ := unstructured.NestedString(rule.Object, "spec", "host")
+		if hostMatchesPattern(host, qualifyHost(ruleHost, rule.GetNamespace())) {
+			matching = append(matching, rule)
+			analysis.AddResource(fmt.Sprintf("destinationrules/%s/%s", rule.GetNamespace(), rule.GetName()))
+		}
+	}
+	if rulesOK {
+		checks = append(checks, subsetsCheck(&analysis, backends.pods, matching))
+		services, _ := t.meshObjects(ctx, &analysis, req.User, "VirtualService", "networking.istio.io", "")
+		checks = append(checks, virtualServiceSubsetsCheck(&analysis, host, services, matching))
+	}
+
+	// 3. mTLS mode compatibility between PeerAuthentication and the client side.
+	if auths, ok := t.meshObjects(ctx, &analysis, req.User, "PeerAuthentication", "security.istio.io", namespace); ok {
+		checks = append(checks, mtlsCheck(&analysis, ref, backends.pods, auths, matching))
+	}
+
+	// 4. The destination proxies are up and synced with istiod.
+	checks = append(checks, proxySyncCheck(&analysis, backends.pods))
+
+	analysis.AddEvidence("checklist", checks)
+	if len(analysis.LikelyRootCauses) == 0 {
+		analysis.AddNextCheck("Check istio-proxy access logs on the client for the response flag (UH, UF, NR, URX) of the 503")
+	}
+	analysis.AddNextCheck(fmt.Sprintf("Compare proxy endpoints for %s with istio.proxy_endpoints on a client pod", host))
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+}
+
+// meshObjects lists kind in namespace (every allowed namespace when empty) and,
+// for a specific namespace, in the Istio root namespace where mesh-wide
+// config lives. It returns false when the kind is not installed.
+func (t *Toolset) meshObjects(ctx context.Context, analysis *render.Analysis, user policy.User, kind, group, namespace string) ([]unstructured.Unstructured, bool) {
+	gvr, namespaced, err := kube.ResolveResourceBestEffort(t.ctx.Clients.Mapper, t.ctx.Clients.Discovery, "", kind, "", group)
+	if err != nil {
+		analysis.AddEvidence(fmt.Sprintf("%s status", kind), fmt.Sprintf("%s resource not available", kind))
+		return nil, false
+	}
+	items, _, err := t.listObjects(ctx, user, gvr, namespaced, namespace, "")
+	if err != nil {
+		analysis.AddEvidence(fmt.Sprintf("%s status", kind), err.Error())
+		return nil, false
+	}
+	if namespace != "" && namespace != istioNamespace {
+		if err := t.ctx.Policy.CheckNamespace(user, istioNamespace, true); err == nil {
+			rootItems, _, err := t.listObjects(ctx, user, gvr, namespaced, istioNamespace, "")
+			if err == nil {
+				items = append(items, rootItems...)
+			}
+		}
+	}
+	return items, true
+}
+
+func endpointsCheck(analysis *render.Analysis, ref string, backends serviceBackendSet) diagnose503Check {
+	check := diagnose503Check{Check: "ready endpoints"}
+	ready, notReady := 0, 0
+	if backends.endpoints != nil {
+		for _, subset := range backends.endpoints.Subsets {
+			ready += len(subset.Addresses)
+			notReady += len(subset.NotReadyAddresses)
+		}
+	}
+	switch {
+	case ready > 0:
+		check.OK = true
+		check.Detail = fmt.Sprintf("%d ready, %d not ready", ready, notReady)
+	case len(backends.service.Spec.Selector) > 0 && len(backends.pods) == 0:
+		check.Detail = "service selector matches no pods"
+		analysis.AddCauseWithConfidence("Service selector matches no pods", fmt.Sprintf("%s selects %s but no pod carries those labels; Envoy has no upstream and answers 503 (UH)", ref, labels.Set(backends.service.Spec.Selector).String()), "high", render.ConfidenceHigh)
+		analysis.AddNextCheck("Verify service selector labels")
+	default:
+		check.Detail = fmt.Sprintf("0 ready, %d not ready", notReady)
+		analysis.AddCauseWithConfidence("Service has no ready endpoints", fmt.Sprintf("%s has no ready endpoints (%d not ready); Envoy has no healthy upstream and answers 503 (UH)", ref, notReady), "high", render.ConfidenceHigh)
+		analysis.AddNextCheck("Check readiness probes and events of the service pods")
+	}
+	return check
+}
+
+func subsetsCheck(analysis *render.Analysis, pods []corev1.Pod, rules []unstructured.Unstructured) diagnose503Check {
+	check := diagnose503Check{Check: "destinationrule subsets", OK: true}
+	if len(rules) == 0 {
+		check.Detail = "no DestinationRule for this host"
+		return check
+	}
+	var details []string
+	for _, rule := range rules {
+		ruleRef := fmt.Sprintf("%s/%s", rule.GetNamespace(), rule.GetName())
+		subsets, _, _ := unstructured.NestedSlice(rule.Object, "spec", "subsets")
+		for _, raw := range subsets {
+			subset, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			name := toString(subset["name"])
+			selector := labels.SelectorFromSet(toStringMap(subset["labels"]))
+			matched, ready := 0, 0
+			for i := range pods {
+				if !selector.Matches(labels.Set(pods[i].Labels)) {
+					continue
+				}
+				matched++
+				if isPodReady(&pods[i]) {
+					ready++
+				}
+			}
+			details = append(details, fmt.Sprintf("%s subset %s: %d pods, %d ready", ruleRef, name, matched, ready))
+			switch {
+			case matched == 0:
+				check.OK = false
+				analysis.AddCauseWithConfidence("DestinationRule subset matches no pods", fmt.Sprintf("%s subset %s selects %s but no service pod carries those labels; traffic routed to it gets 503 (UH)", ruleRef, name, selector.String()), "high", render.ConfidenceHigh)
+			case ready == 0:
+				check.OK = false
+				analysis.AddCauseWithConfidence("DestinationRule subset has no ready pods", fmt.Sprintf("%s subset %s matches %d pods but none are ready", ruleRef, name, matched), "high", render.ConfidenceMedium)
+			}
+		}
+	}
+	if len(details) == 0 {
+		details = append(details, "no subsets defined")
+	}
+	check.Detail = strings.Join(details, "; ")
+	return check
+}
+
+// virtualServiceSubsetsCheck flags VirtualService routes to host that name a
+// subset no DestinationRule defines; Envoy has no cluster for them (503 NR).
+func virtualServiceSubsetsCheck(analysis *render.Analysis, host string, services, rules []unstructured.Unstructured) diagnose503Check {
+	check := diagnose503Check{Check: "virtualservice subsets", OK: true, Detail: "every routed subset is defined"}
+	defined := map[string]bool{}
+	for _, rule := range rules {
+		subsets, _, _ := unstructured.NestedSlice(rule.Object, "spec", "subsets")
+		for _, raw := range subsets {
+			if subset, ok := raw.(map[string]any); ok {
+				defined[toString(subset["name"])] = true
+			}
+		}
+	}
+	var missing []string
+	for _, vs := range services {
+		vsRef := fmt.Sprintf("%s/%s", vs.GetNamespace(), vs.GetName())
+		for _, section := range []string{"http", "tcp", "tls"} {
+			routes, _, _ := unstructured.NestedSlice(vs.Object, "spec", section)
+			for _, raw := range routes {
+				route, ok := raw.(map[string]any)
+				if !ok {
+					continue
+				}
+				destinations, _, _ := unstructured.NestedSlice(route, "route")
+				for _, rawDest := range destinations {
+					entry, ok := rawDest.(map[string]any)
+					if !ok {
+						continue
+					}
+					dest, _ := entry["destination"].(map[string]any)
+					subset := toString(dest["subset"])
+					if subset == "" || defined[subset] || qualifyHost(toString(dest["host"]), vs.GetNamespace()) != host {
+						continue
+					}
+					missing = append(missing, fmt.Sprintf("%s -> %s", vsRef, subset))
+					analysis.AddResource(fmt.Sprintf("virtualservices/%s", vsRef))
+					analysis.AddCauseWithConfidence("VirtualService routes to undefined subset", fmt.Sprintf("%s routes to subset %s of %s but no DestinationRule defines it; Envoy has no cluster and answers 503 (NR)", vsRef, subset, host), "high", render.ConfidenceHigh)
+				}
+			}
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		check.OK = false
+		check.Detail = "undefined subsets: " + strings.Join(missing, ", ")
+	}
+	return check
+}
+
+// mtlsCheck compares the PeerAuthentication mode in force on the service pods
+// with the TLS mode clients use from the DestinationRule.
+func mtlsCheck(analysis *render.Analysis, ref string, pods []corev1.Pod, auths, rules []unstructured.Unstructured) diagnose503Check {
+	check := diagnose503Check{Check: "mtls mode", OK: true}
+	var podLabels map[string]string
+	if len(pods) > 0 {
+		podLabels = pods[0].Labels
+	}
+	mode, source := effectivePeerAuthentication(auths, podLabels)
+	clientMode, clientSource := "ISTIO_MUTUAL (auto)", ""
+	for _, rule := range rules {
+		if tlsMode, _, _ := unstructured.NestedString(rule.Object, "spec", "trafficPolicy", "tls", "mode"); tlsMode != "" {
+			clientMode, clientSource = tlsMode, fmt.Sprintf("%s/%s", rule.GetNamespace(), rule.GetName())
+			break
+		}
+	}
+	check.Detail = fmt.Sprintf("server %s (%s), client %s", mode, source, clientMode)
+	if clientSource != "" {
+		check.Detail += fmt.Sprintf(" (%s)", clientSource)
+	}
+	sidecars := 0
+	for i := range pods {
+		if hasIstioProxy(&pods[i]) {
+			sidecars++
+		}
+	}
+	switch {
+	case mode == "STRICT" && clientMode == "DISABLE":
+		check.OK = false
+		analysis.AddCauseWithConfidence("mTLS mode conflict", fmt.Sprintf("%s requires mTLS (STRICT via %s) but %s disables TLS; the server resets plaintext connections and clients see 503 (UC/URX)", ref, source, clientSource), "high", render.ConfidenceHigh)
+	case mode == "STRICT" && (clientMode == "SIMPLE" || clientMode == "MUTUAL"):
+		check.OK = false
+		analysis.AddCauseWithConfidence("mTLS mode conflict", fmt.Sprintf("%s requires Istio mTLS (STRICT via %s) but %s originates %s TLS with its own certificates", ref, source, clientSource, clientMode), "medium", render.ConfidenceMedium)
+	case clientMode == "ISTIO_MUTUAL" && len(pods) > 0 && sidecars < len(pods):
+		check.OK = false
+		analysis.AddCauseWithConfidence("mTLS to pods without a sidecar", fmt.Sprintf("%s forces ISTIO_MUTUAL but %d of %d pods of %s have no istio-proxy to terminate it", clientSource, len(pods)-sidecars, len(pods), ref), "high", render.ConfidenceHigh)
+	}
+	return check
+}
+
+// effectivePeerAuthentication returns the mTLS mode applied to a workload:
+// a matching workload policy, then the namespace policy, then the root
+// namespace policy, else Istio's PERMISSIVE default.
+func effectivePeerAuthentication(auths []unstructured.Unstructured, podLabels map[string]string) (string, string) {
+	var workload, namespaceWide, root *unstructured.Unstructured
+	for i := range auths {
+		auth := &auths[i]
+		matchLabels, hasSelector, _ := unstructured.NestedStringMap(auth.Object, "spec", "selector", "matchLabels")
+		switch {
+		case hasSelector && len(matchLabels) > 0:
+			if auth.GetNamespace() != istioNamespace && podLabels != nil && labels.SelectorFromSet(matchLabels).Matches(labels.Set(podLabels)) {
+				workload = auth
+			}
+		case auth.GetNamespace() == istioNamespace:
+			root = auth
+		default:
+			namespaceWide = auth
+		}
+	}
+	for _, auth := range []*unstructured.Unstructured{workload, namespaceWide, root} {
+		if auth == nil {
+			continue
+		}
+		mode, _, _ := unstructured.NestedString(auth.Object, "spec", "mtls", "mode")
+		if mode == "" || mode == "UNSET" {
+			continue
+		}
+		return mode, fmt.Sprintf("%s/%s", auth.GetNamespace(), auth.GetName())
+	}
+	return "PERMISSIVE", "mesh default"
+}
+
+func proxySyncCheck(analysis *render.Analysis, pods []corev1.Pod) diagnose503Check {
+	check := diagnose503Check{Check: "proxy sync", OK: true}
+	proxies := 0
+	var stale []string
+	for i := range pods {
+		pod := &pods[i]
+		if !hasIstioProxy(pod) {
+			continue
+		}
+		proxies++
+		if !istioProxyReady(pod) {
+			stale = append(stale, pod.Name)
+			analysis.AddCauseWithConfidence("Proxy not ready", fmt.Sprintf("%s/%s istio-proxy not ready; it has not received its config from istiod", pod.Namespace, pod.Name), "medium", render.ConfidenceMedium)
+		}
+	}
+	switch {
+	case proxies == 0:
+		check.Detail = "no service pod runs an istio-proxy"
+	case len(stale) > 0:
+		check.OK = false
+		check.Detail = fmt.Sprintf("%d of %d proxies not ready: %s", len(stale), proxies, strings.Join(stale, ", "))
+		analysis.AddNextCheck("Run istio.proxy_status and check istiod logs for push errors")
+	default:
+		check.Detail = fmt.Sprintf("%d proxies ready", proxies)
+	}
+	return check
+}
+
+// istioProxyReady reports the readiness of the istio-proxy container, which
+// only passes once the proxy has received its first config from istiod.
+func istioProxyReady(pod *corev1.Pod) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == "istio-proxy" {
+			return status.Ready
+		}
+	}
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.Name == "istio-proxy" {
+			return status.Ready
+		}
+	}
+	return false
+}
+
+// qualifyHost expands a short Kubernetes host in config from namespace to its
+// FQDN, e.g. "reviews" or "reviews.default" to "reviews.default.svc.cluster.local".
+func qualifyHost(host, namespace string) string {
+	host = strings.TrimSpace(host)
+	if host == "" || strings.HasPrefix(host, "*") {
+		return host
+	}
+	switch parts := strings.Split(host, "."); len(parts) {
+	case 1:
+		return fmt.Sprintf("%s.%s.svc.cluster.local", host, namespace)
+	case 2:
+		return host + ".svc.cluster.local"
+	case 3:
+		if parts[2] == "svc" {
+			return host + ".cluster.local"
+		}
+	}
+	return host
+}
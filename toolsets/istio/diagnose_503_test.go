@@ -0,0 +1,155 @@
+package istio
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/restmapper"
+
+	"rootcause/internal/config"
+	"rootcause/internal/evidence"
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/redact"
+	"rootcause/internal/render"
+)
+
+func reviewsPod(name, version string, proxyReady bool) *corev1.Pod {
+	pod := proxyPod("shop", name, map[string]string{"app": "reviews", "version": version})
+	pod.Status = corev1.PodStatus{
+		Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		ContainerStatuses: []corev1.ContainerStatus{{Name: "app", Ready: true}, {Name: "istio-proxy", Ready: proxyReady}},
+	}
+	return pod
+}
+
+func TestDiagnose503(t *testing.T) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviews", Namespace: "shop"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "reviews"}},
+	}
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviews", Namespace: "shop"},
+		Subsets:    []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}, {IP: "10.0.0.2"}}}},
+	}
+	rule := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "networking.istio.io/v1",
+		"kind":       "DestinationRule",
+		"metadata":   map[string]any{"name": "reviews", "namespace": "shop"},
+		"spec": map[string]any{
+			"host":          "reviews",
+			"trafficPolicy": map[string]any{"tls": map[string]any{"mode": "DISABLE"}},
+			"subsets": []any{
+				map[string]any{"name": "v1", "labels": map[string]any{"version": "v1"}},
+				map[string]any{"name": "v2", "labels": map[string]any{"version": "v2"}},
+			},
+		},
+	}}
+	route := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "networking.istio.io/v1",
+		"kind":       "VirtualService",
+		"metadata":   map[string]any{"name": "reviews", "namespace": "web"},
+		"spec": map[string]any{
+			"hosts": []any{"reviews.shop.svc.cluster.local"},
+			"http": []any{map[string]any{"route": []any{
+				map[string]any{"destination": map[string]any{"host": "reviews.shop", "subset": "v1"}},
+				map[string]any{"destination": map[string]any{"host": "reviews.shop", "subset": "v3"}},
+			}}},
+		},
+	}}
+	strict := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "security.istio.io/v1",
+		"kind":       "PeerAuthentication",
+		"metadata":   map[string]any{"name": "default", "namespace": "shop"},
+		"spec":       map[string]any{"mtls": map[string]any{"mode": "STRICT"}},
+	}}
+	drGVR := schema.GroupVersionResource{Group: "networking.istio.io", Version: "v1", Resource: "destinationrules"}
+	vsGVR := schema.GroupVersionResource{Group: "networking.istio.io", Version: "v1", Resource: "virtualservices"}
+	paGVR := schema.GroupVersionResource{Group: "security.istio.io", Version: "v1", Resource: "peerauthentications"}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		drGVR: "DestinationRuleList",
+		vsGVR: "VirtualServiceList",
+		paGVR: "PeerAuthenticationList",
+	}, rule, route, strict)
+	discoveryClient := &istioDiscoveryResources{
+		resources: []*metav1.APIResourceList{
+			{GroupVersion: "networking.istio.io/v1", APIResources: []metav1.APIResource{
+				{Name: "destinationrules", Kind: "DestinationRule", Namespaced: true},
+				{Name: "virtualservices", Kind: "VirtualService", Namespaced: true},
+			}},
+			{GroupVersion: "security.istio.io/v1", APIResources: []metav1.APIResource{
+				{Name: "peerauthentications", Kind: "PeerAuthentication", Namespaced: true},
+			}},
+		},
+		groups: &metav1.APIGroupList{Groups: []metav1.APIGroup{{Name: "networking.istio.io"}, {Name: "security.istio.io"}}},
+	}
+	groupResources, err := restmapper.GetAPIGroupResources(discoveryClient)
+	if err != nil {
+		t.Fatalf("get api group resources: %v", err)
+	}
+	client := k8sfake.NewSimpleClientset(service, endpoints, reviewsPod("reviews-1", "v1", true), reviewsPod("reviews-2", "v1", false))
+	clients := &kube.Clients{Typed: client, Dynamic: dynamicClient, Discovery: discoveryClient, Mapper: restmapper.NewDiscoveryRESTMapper(groupResources)}
+	cfg := config.DefaultConfig()
+	toolset := New()
+	_ = toolset.Init(mcp.ToolContext{
+		Config:   &cfg,
+		Clients:  clients,
+		Policy:   policy.NewAuthorizer(),
+		Renderer: render.NewRenderer(),
+		Redactor: redact.New(),
+		Evidence: evidence.NewCollector(clients),
+	})
+	user := policy.User{Role: policy.RoleCluster}
+
+	result, err := toolset.handleDiagnose503(context.Background(), mcp.ToolRequest{User: user, Arguments: map[string]any{"namespace": "shop", "service": "reviews"}})
+	if err != nil {
+		t.Fatalf("diagnose 503: %v", err)
+	}
+	causes := map[string]bool{}
+	for _, cause := range result.Data.(map[string]any)["likelyRootCauses"].([]render.Cause) {
+		causes[cause.Summary] = true
+	}
+	for _, want := range []string{"DestinationRule subset matches no pods", "VirtualService routes to undefined subset", "mTLS mode conflict", "Proxy not ready"} {
+		if !causes[want] {
+			t.Fatalf("expected cause %q, got %v", want, causes)
+		}
+	}
+	if causes["Service has no ready endpoints"] {
+		t.Fatalf("did not expect an endpoints cause with ready addresses: %v", causes)
+	}
+
+	result, err = toolset.handleDiagnose503(context.Background(), mcp.ToolRequest{User: user, Arguments: map[string]any{"namespace": "shop", "service": "ratings"}})
+	if err != nil {
+		t.Fatalf("diagnose 503 missing service: %v", err)
+	}
+	if causes := result.Data.(map[string]any)["likelyRootCauses"].([]render.Cause); len(causes) != 1 || causes[0].Summary != "Service not found" {
+		t.Fatalf("expected service not found, got %+v", causes)
+	}
+	if _, err := toolset.handleDiagnose503(context.Background(), mcp.ToolRequest{User: user, Arguments: map[string]any{"namespace": "shop"}}); err == nil {
+		t.Fatalf("expected error without service")
+	}
+}
+
+func TestQualifyHost(t *testing.T) {
+	cases := map[string]string{
+		"reviews":                       "reviews.shop.svc.cluster.local",
+		"reviews.web":                   "reviews.web.svc.cluster.local",
+		"reviews.web.svc":               "reviews.web.svc.cluster.local",
+		"reviews.web.svc.cluster.local": "reviews.web.svc.cluster.local",
+		"*.web.svc.cluster.local":       "*.web.svc.cluster.local",
+		"api.example.com":               "api.example.com",
+	}
+	for host, want := range cases {
+		if got := qualifyHost(host, "shop"); got != want {
+			t.Fatalf("qualifyHost(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
@@ -0,0 +1,416 @@
+package istio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"rootcause/internal/evidence"
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/render"
+)
+
+const patchContextAny = "ANY"
+
+type envoyFilterRecord struct {
+	Name             string               `json:"name"`
+	Namespace        string               `json:"namespace"`
+	Scope            string               `json:"scope"`
+	WorkloadSelector map[string]string    `json:"workloadSelector,omitempty"`
+	Priority         int64                `json:"priority"`
+	Patches          []envoyFilterPatch   `json:"patches"`
+	AffectedProxies  []string             `json:"affectedProxies,omitempty"`
+	AffectedCount    int                  `json:"affectedCount"`
+	proxies          map[string]proxyKind `json:"-"`
+}
+
+type envoyFilterPatch struct {
+	ApplyTo   string `json:"applyTo"`
+	Context   string `json:"context"`
+	Operation string `json:"operation,omitempty"`
+	Target    string `json:"target"`
+	Proxies   int    `json:"proxies"`
+}
+
+type envoyFilterConflict struct {
+	ApplyTo        string   `json:"applyTo"`
+	Target         string   `json:"target"`
+	Filters        []string `json:"filters"`
+	Operations     []string `json:"operations"`
+	Priorities     []int64  `json:"priorities"`
+	SharedProxies  []string `json:"sharedProxies"`
+	SamePriority   bool     `json:"samePriority"`
+	OrderDependent bool     `json:"orderDependent"`
+}
+
+type proxyKind int
+
+const (
+	proxySidecar proxyKind = iota
+	proxyGateway
+)
+
+func (t *Toolset) handleAnalyzeEnvoyFilters(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	namespace := toString(req.Arguments["namespace"])
+	analysis := render.NewAnalysis()
+	detected, groups, err := t.detectIstio(ctx)
+	if err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	if !detected {
+		analysis.AddEvidence("status", "istio not detected")
+		analysis.AddEvidence("groupsChecked", istioGroups)
+		analysis.AddNextCheck("Install Istio or verify API group availability")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
+	}
+	if len(groups) > 0 {
+		analysis.AddEvidence("groupsFound", groups)
+	}
+	if namespace != "" {
+		if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+			return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+		}
+	}
+	gvr, namespaced, err := kube.ResolveResourceBestEffort(t.ctx.Clients.Mapper, t.ctx.Clients.Discovery, "", "EnvoyFilter", "", "networking.istio.io")
+	if err != nil {
+		analysis.AddEvidence("status", "EnvoyFilter resource not available")
+		analysis.AddNextCheck("Verify the networking.istio.io CRDs are installed")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
+	}
+	filters, _, err := t.listObjects(ctx, req.User, gvr, namespaced, namespace, "")
+	if err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	// EnvoyFilters in the root namespace apply to every proxy in the mesh, so
+	// a namespace-scoped view still has to account for them.
+	if namespace != "" && namespace != istioNamespace {
+		if err := t.ctx.Policy.CheckNamespace(req.User, istioNamespace, true); err == nil {
+			rootFilters, _, err := t.listObjects(ctx, req.User, gvr, namespaced, istioNamespace, "")
+			if err != nil {
+				return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+			}
+			filters = append(filters, rootFilters...)
+		} else {
+			analysis.AddEvidence("rootNamespace", fmt.Sprintf("mesh-wide EnvoyFilters in %s not visible: %v", istioNamespace, err))
+		}
+	}
+	if len(filters) == 0 {
+		analysis.AddEvidence("status", "no EnvoyFilters found")
+		analysis.AddNextCheck("Review Sidecar and DestinationRule resources for proxy behavior instead")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+	}
+	proxyNamespaces, err := t.allowedNamespaces(ctx, req.User, namespace)
+	if err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+
+	pods, err := t.envoyFilterPods(ctx, filters, proxyNamespaces)
+	if err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+
+	var records []envoyFilterRecord
+	for i := range filters {
+		obj := &filters[i]
+		analysis.AddResource(t.ctx.Evidence.ResourceRef(gvr, obj.GetNamespace(), obj.GetName()))
+		record := resolveEnvoyFilter(obj, proxyNamespaces, pods)
+		if record.AffectedCount == 0 {
+			analysis.AddCause("EnvoyFilter affects no proxies", fmt.Sprintf("%s/%s matches no running istio-proxy in scope", record.Namespace, record.Name), "low")
+		}
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Namespace != records[j].Namespace {
+			return records[i].Namespace < records[j].Namespace
+		}
+		return records[i].Name < records[j].Name
+	})
+	analysis.AddEvidence("envoyFilters", t.ctx.Redactor.RedactValue(records))
+
+	conflicts := envoyFilterConflicts(records)
+	if len(conflicts) > 0 {
+		analysis.AddEvidence("conflicts", t.ctx.Redactor.RedactValue(conflicts))
+		for _, conflict := range conflicts {
+			severity := "medium"
+			if conflict.SamePriority {
+				severity = "high"
+			}
+			analysis.AddCause("EnvoyFilter patches overlap", fmt.Sprintf("%s %s patched by %s on %d proxy(ies)", conflict.ApplyTo, conflict.Target, strings.Join(conflict.Filters, ", "), len(conflict.SharedProxies)), severity)
+		}
+		analysis.AddNextCheck("Set distinct spec.priority values or merge overlapping EnvoyFilters")
+	}
+	analysis.AddNextCheck("Confirm the effective config with istio.proxy_config_dump on an affected proxy")
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+}
+
+// envoyFilterPodSet maps namespace to workload selector (in String form) to
+// the pods it selects.
+type envoyFilterPodSet map[string]map[string][]corev1.Pod
+
+// envoyFilterScope returns the filter's scope and the proxy namespaces it
+// reaches. A filter in the root namespace applies mesh-wide; anywhere else
+// it only applies to its own namespace.
+func envoyFilterScope(obj *unstructured.Unstructured, proxyNamespaces []string) (string, []string) {
+	if obj.GetNamespace() == istioNamespace {
+		return "mesh", proxyNamespaces
+	}
+	if !containsString(proxyNamespaces, obj.GetNamespace()) {
+		return "namespace", nil
+	}
+	return "namespace", []string{obj.GetNamespace()}
+}
+
+func envoyFilterSelector(obj *unstructured.Unstructured) (map[string]string, labels.Selector) {
+	selectorLabels, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "workloadSelector", "labels")
+	return selectorLabels, labels.SelectorFromSet(selectorLabels)
+}
+
+// envoyFilterPods resolves every filter's workload selector up front,
+// batching the selectors that share a namespace so each namespace costs one
+// pod List no matter how many filters target it.
+func (t *Toolset) envoyFilterPods(ctx context.Context, filters []unstructured.Unstructured, proxyNamespaces []string) (envoyFilterPodSet, error) {
+	selectors := map[string][]labels.Selector{}
+	seen := map[string]map[string]bool{}
+	var namespaces []string
+	for i := range filters {
+		_, selector := envoyFilterSelector(&filters[i])
+		_, scope := envoyFilterScope(&filters[i], proxyNamespaces)
+		for _, ns := range scope {
+			if seen[ns] == nil {
+				seen[ns] = map[string]bool{}
+				namespaces = append(namespaces, ns)
+			}
+			if seen[ns][selector.String()] {
+				continue
+			}
+			seen[ns][selector.String()] = true
+			selectors[ns] = append(selectors[ns], selector)
+		}
+	}
+	out := envoyFilterPodSet{}
+	for _, ns := range namespaces {
+		resolved, err := evidence.RelatedPodsForSelectors(ctx, t.ctx.Evidence, ns, selectors[ns])
+		if err != nil {
+			return nil, err
+		}
+		out[ns] = map[string][]corev1.Pod{}
+		for i, selector := range selectors[ns] {
+			out[ns][selector.String()] = resolved[i]
+		}
+	}
+	return out, nil
+}
+
+// resolveEnvoyFilter works out which proxies an EnvoyFilter reaches from
+// the pods pre-resolved by envoyFilterPods. An empty workloadSelector
+// selects every proxy in scope.
+func resolveEnvoyFilter(obj *unstructured.Unstructured, proxyNamespaces []string, podSet envoyFilterPodSet) envoyFilterRecord {
+	selectorLabels, selector := envoyFilterSelector(obj)
+	priority, _, _ := unstructured.NestedInt64(obj.Object, "spec", "priority")
+	scopeName, scope := envoyFilterScope(obj, proxyNamespaces)
+	record := envoyFilterRecord{
+		Name:             obj.GetName(),
+		Namespace:        obj.GetNamespace(),
+		Scope:            scopeName,
+		WorkloadSelector: selectorLabels,
+		Priority:         priority,
+		proxies:          map[string]proxyKind{},
+	}
+	for _, ns := range scope {
+		pods := podSet[ns][selector.String()]
+		for i := range pods {
+			pod := &pods[i]
+			if !hasIstioProxy(pod) {
+				continue
+			}
+			kind := proxySidecar
+			if isGatewayProxy(pod) {
+				kind = proxyGateway
+			}
+			record.proxies[fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)] = kind
+		}
+	}
+
+	patches, _, _ := unstructured.NestedSlice(obj.Object, "spec", "configPatches")
+	affected := map[string]struct{}{}
+	for _, raw := range patches {
+		patch, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		match, _ := patch["match"].(map[string]any)
+		value, _ := patch["patch"].(map[string]any)
+		item := envoyFilterPatch{
+			ApplyTo:   toString(patch["applyTo"]),
+			Context:   toString(match["context"]),
+			Operation: toString(value["operation"]),
+			Target:    patchTarget(match),
+		}
+		if item.Context == "" {
+			item.Context = patchContextAny
+		}
+		for _, name := range record.proxiesFor(item.Context) {
+			affected[name] = struct{}{}
+			item.Proxies++
+		}
+		record.Patches = append(record.Patches, item)
+	}
+	for name := range affected {
+		record.AffectedProxies = append(record.AffectedProxies, name)
+	}
+	sort.Strings(record.AffectedProxies)
+	record.AffectedCount = len(record.AffectedProxies)
+	return record
+}
+
+// proxiesFor returns the selected proxies a patch context applies to:
+// GATEWAY only reaches gateways, SIDECAR_* only sidecars, ANY both.
+func (r envoyFilterRecord) proxiesFor(patchContext string) []string {
+	var out []string
+	for name, kind := range r.proxies {
+		switch patchContext {
+		case "GATEWAY":
+			if kind != proxyGateway {
+				continue
+			}
+		case "SIDECAR_INBOUND", "SIDECAR_OUTBOUND":
+			if kind != proxySidecar {
+				continue
+			}
+		}
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// envoyFilterConflicts reports patches from different EnvoyFilters that hit
+// the same Envoy config object on at least one shared proxy. Istio applies
+// them in priority order, so overlaps at the same priority depend on creation
+// order and are the usual source of "works in one namespace only" surprises.
+func envoyFilterConflicts(records []envoyFilterRecord) []envoyFilterConflict {
+	type ref struct {
+		record *envoyFilterRecord
+		patch  envoyFilterPatch
+	}
+	byTarget := map[string][]ref{}
+	for i := range records {
+		for _, patch := range records[i].Patches {
+			key := patch.ApplyTo + " " + patch.Target
+			byTarget[key] = append(byTarget[key], ref{record: &records[i], patch: patch})
+		}
+	}
+	keys := make([]string, 0, len(byTarget))
+	for key := range byTarget {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var conflicts []envoyFilterConflict
+	for _, key := range keys {
+		refs := byTarget[key]
+		for i := 0; i < len(refs); i++ {
+			for j := i + 1; j < len(refs); j++ {
+				a, b := refs[i], refs[j]
+				if a.record == b.record || !contextsOverlap(a.patch.Context, b.patch.Context) {
+					continue
+				}
+				shared := intersectStrings(a.record.proxiesFor(a.patch.Context), b.record.proxiesFor(b.patch.Context))
+				if len(shared) == 0 {
+					continue
+				}
+				conflicts = append(conflicts, envoyFilterConflict{
+					ApplyTo:        a.patch.ApplyTo,
+					Target:         a.patch.Target,
+					Filters:        []string{a.record.Namespace + "/" + a.record.Name, b.record.Namespace + "/" + b.record.Name},
+					Operations:     []string{a.patch.Operation, b.patch.Operation},
+					Priorities:     []int64{a.record.Priority, b.record.Priority},
+					SharedProxies:  shared,
+					SamePriority:   a.record.Priority == b.record.Priority,
+					OrderDependent: a.patch.Operation != "ADD" || b.patch.Operation != "ADD",
+				})
+			}
+		}
+	}
+	return conflicts
+}
+
+// patchTarget flattens a configPatch match (minus context) into a stable
+// string such as "listener.portNumber=8080,listener.filterChain.filter.name=...".
+func patchTarget(match map[string]any) string {
+	var parts []string
+	var walk func(prefix string, value any)
+	walk = func(prefix string, value any) {
+		switch v := value.(type) {
+		case map[string]any:
+			for key, child := range v {
+				if prefix == "" && (key == "context" || key == "proxy") {
+					continue
+				}
+				name := key
+				if prefix != "" {
+					name = prefix + "." + key
+				}
+				walk(name, child)
+			}
+		default:
+			parts = append(parts, fmt.Sprintf("%s=%v", prefix, v))
+		}
+	}
+	walk("", match)
+	if len(parts) == 0 {
+		return "*"
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+func contextsOverlap(a, b string) bool {
+	return a == b || a == patchContextAny || b == patchContextAny
+}
+
+// isGatewayProxy reports whether the pod's istio-proxy runs in router mode
+// (ingress/egress gateways) rather than as a sidecar.
+func isGatewayProxy(pod *corev1.Pod) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != "istio-proxy" {
+			continue
+		}
+		for _, arg := range container.Args {
+			if arg == "router" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func intersectStrings(a, b []string) []string {
+	set := make(map[string]struct{}, len(a))
+	for _, item := range a {
+		set[item] = struct{}{}
+	}
+	var out []string
+	for _, item := range b {
+		if _, ok := set[item]; ok {
+			out = append(out, item)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func containsString(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
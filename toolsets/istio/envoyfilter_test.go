@@ -0,0 +1,154 @@
+package istio
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+func envoyFilterObject(namespace, name string, priority int64, selector map[string]any, patches ...any) *unstructured.Unstructured {
+	spec := map[string]any{"configPatches": patches}
+	if priority != 0 {
+		spec["priority"] = priority
+	}
+	if selector != nil {
+		spec["workloadSelector"] = map[string]any{"labels": selector}
+	}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "networking.istio.io/v1alpha3",
+		"kind":       "EnvoyFilter",
+		"metadata":   map[string]any{"name": name, "namespace": namespace},
+		"spec":       spec,
+	}}
+}
+
+func httpFilterPatch(patchContext, operation string) map[string]any {
+	return map[string]any{
+		"applyTo": "HTTP_FILTER",
+		"match": map[string]any{
+			"context": patchContext,
+			"listener": map[string]any{
+				"filterChain": map[string]any{
+					"filter": map[string]any{
+						"name":      "envoy.filters.network.http_connection_manager",
+						"subFilter": map[string]any{"name": "envoy.filters.http.router"},
+					},
+				},
+			},
+		},
+		"patch": map[string]any{"operation": operation},
+	}
+}
+
+func proxyPod(namespace, name string, podLabels map[string]string, args ...string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: podLabels},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "app"},
+			{Name: "istio-proxy", Args: args},
+		}},
+	}
+}
+
+func newEnvoyFilterToolset(t *testing.T, objects ...*unstructured.Unstructured) *Toolset {
+	t.Helper()
+	return newMeshConfigToolset(t, []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: istioNamespace}},
+		proxyPod("shop", "cart-1", map[string]string{"app": "cart"}),
+		proxyPod("shop", "web-1", map[string]string{"app": "web"}),
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "batch-1", Namespace: "shop", Labels: map[string]string{"app": "cart"}}, Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}},
+		proxyPod(istioNamespace, "ingress-1", map[string]string{"istio": "ingressgateway"}, "proxy", "router"),
+	}, objects...)
+}
+
+func TestAnalyzeEnvoyFiltersReportsScopeAndConflicts(t *testing.T) {
+	toolset := newEnvoyFilterToolset(t,
+		envoyFilterObject("shop", "cart-lua", 0, map[string]any{"app": "cart"}, httpFilterPatch("SIDECAR_INBOUND", "INSERT_BEFORE")),
+		envoyFilterObject(istioNamespace, "mesh-ratelimit", 0, nil, httpFilterPatch("ANY", "INSERT_BEFORE")),
+		envoyFilterObject(istioNamespace, "gateway-only", 10, map[string]any{"istio": "ingressgateway"}, httpFilterPatch("GATEWAY", "MERGE")),
+		envoyFilterObject("shop", "stale", 0, map[string]any{"app": "gone"}, httpFilterPatch("SIDECAR_OUTBOUND", "MERGE")),
+	)
+	result, err := toolset.handleAnalyzeEnvoyFilters(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"namespace": "shop"},
+	})
+	if err != nil {
+		t.Fatalf("analyze envoyfilters: %v", err)
+	}
+	raw, _ := json.Marshal(result.Data)
+	out := string(raw)
+
+	var records []envoyFilterRecord
+	var conflicts []envoyFilterConflict
+	for _, item := range result.Data.(map[string]any)["evidence"].([]render.EvidenceItem) {
+		switch item.Summary {
+		case "envoyFilters":
+			records = item.Details.([]envoyFilterRecord)
+		case "conflicts":
+			conflicts = item.Details.([]envoyFilterConflict)
+		}
+	}
+	affected := map[string][]string{}
+	for _, record := range records {
+		affected[record.Namespace+"/"+record.Name] = record.AffectedProxies
+	}
+	if got := affected["shop/cart-lua"]; len(got) != 1 || got[0] != "shop/cart-1" {
+		t.Fatalf("expected cart-lua to reach only shop/cart-1, got %v", got)
+	}
+	if got := affected[istioNamespace+"/mesh-ratelimit"]; len(got) != 2 {
+		t.Fatalf("expected root-namespace filter to reach both shop proxies, got %v", got)
+	}
+	if got := affected[istioNamespace+"/gateway-only"]; len(got) != 0 {
+		t.Fatalf("expected gateway filter to reach no proxies in shop, got %v", got)
+	}
+	if len(conflicts) != 1 {
+		t.Fatalf("expected a single conflict, got %#v", conflicts)
+	}
+	conflict := conflicts[0]
+	if !conflict.SamePriority || !conflict.OrderDependent || len(conflict.SharedProxies) != 1 || conflict.SharedProxies[0] != "shop/cart-1" {
+		t.Fatalf("unexpected conflict: %#v", conflict)
+	}
+	if !strings.Contains(out, "shop/stale matches no running istio-proxy") {
+		t.Fatalf("expected unused filter cause, got %s", out)
+	}
+}
+
+func TestAnalyzeEnvoyFiltersNoFilters(t *testing.T) {
+	toolset := newEnvoyFilterToolset(t)
+	result, err := toolset.handleAnalyzeEnvoyFilters(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{},
+	})
+	if err != nil {
+		t.Fatalf("analyze envoyfilters: %v", err)
+	}
+	raw, _ := json.Marshal(result.Data)
+	if !strings.Contains(string(raw), "no EnvoyFilters found") {
+		t.Fatalf("expected empty status, got %s", raw)
+	}
+}
+
+func TestPatchTargetIgnoresContext(t *testing.T) {
+	inbound := httpFilterPatch("SIDECAR_INBOUND", "MERGE")["match"].(map[string]any)
+	outbound := httpFilterPatch("SIDECAR_OUTBOUND", "MERGE")["match"].(map[string]any)
+	if patchTarget(inbound) != patchTarget(outbound) {
+		t.Fatalf("expected context to be excluded from target")
+	}
+	if patchTarget(nil) != "*" {
+		t.Fatalf("expected wildcard target for empty match")
+	}
+	if contextsOverlap("SIDECAR_INBOUND", "SIDECAR_OUTBOUND") || !contextsOverlap("ANY", "GATEWAY") {
+		t.Fatalf("unexpected context overlap result")
+	}
+}
@@ -0,0 +1,91 @@
+package istio
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/restmapper"
+
+	"rootcause/internal/config"
+	"rootcause/internal/evidence"
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/redact"
+	"rootcause/internal/render"
+)
+
+// meshConfigResources are the Istio kinds newMeshConfigToolset serves.
+var meshConfigResources = map[string]schema.GroupVersionResource{
+	"DestinationRule":     {Group: "networking.istio.io", Version: "v1", Resource: "destinationrules"},
+	"VirtualService":      {Group: "networking.istio.io", Version: "v1", Resource: "virtualservices"},
+	"Gateway":             {Group: "networking.istio.io", Version: "v1", Resource: "gateways"},
+	"ServiceEntry":        {Group: "networking.istio.io", Version: "v1", Resource: "serviceentries"},
+	"EnvoyFilter":         {Group: "networking.istio.io", Version: "v1alpha3", Resource: "envoyfilters"},
+	"PeerAuthentication":  {Group: "security.istio.io", Version: "v1", Resource: "peerauthentications"},
+	"AuthorizationPolicy": {Group: "security.istio.io", Version: "v1", Resource: "authorizationpolicies"},
+}
+
+// newMeshConfigToolset serves typed objects from a fake clientset and Istio
+// networking and security CRs from a fake dynamic client.
+func newMeshConfigToolset(t *testing.T, typed []runtime.Object, objects ...*unstructured.Unstructured) *Toolset {
+	t.Helper()
+	listKinds := map[schema.GroupVersionResource]string{}
+	byGroupVersion := map[string]*metav1.APIResourceList{}
+	var resources []*metav1.APIResourceList
+	kinds := make([]string, 0, len(meshConfigResources))
+	for kind := range meshConfigResources {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		gvr := meshConfigResources[kind]
+		listKinds[gvr] = kind + "List"
+		groupVersion := gvr.GroupVersion().String()
+		list, ok := byGroupVersion[groupVersion]
+		if !ok {
+			list = &metav1.APIResourceList{GroupVersion: groupVersion}
+			byGroupVersion[groupVersion] = list
+			resources = append(resources, list)
+		}
+		list.APIResources = append(list.APIResources, metav1.APIResource{Name: gvr.Resource, Kind: kind, Namespaced: true})
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+	// Create through the explicit resource: the fake tracker would guess
+	// "gatewaies" for Gateway.
+	for _, obj := range objects {
+		if _, err := dynamicClient.Resource(meshConfigResources[obj.GetKind()]).Namespace(obj.GetNamespace()).Create(context.Background(), obj, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("create %s: %v", obj.GetName(), err)
+		}
+	}
+	discoveryClient := &istioDiscoveryResources{
+		resources: resources,
+		groups:    &metav1.APIGroupList{Groups: []metav1.APIGroup{{Name: "networking.istio.io"}, {Name: "security.istio.io"}}},
+	}
+	groupResources, err := restmapper.GetAPIGroupResources(discoveryClient)
+	if err != nil {
+		t.Fatalf("get api group resources: %v", err)
+	}
+	client := k8sfake.NewSimpleClientset(typed...)
+	clients := &kube.Clients{Typed: client, Dynamic: dynamicClient, Discovery: discoveryClient, Mapper: restmapper.NewDiscoveryRESTMapper(groupResources)}
+	cfg := config.DefaultConfig()
+	toolset := New()
+	if err := toolset.Init(mcp.ToolContext{
+		Config:   &cfg,
+		Clients:  clients,
+		Policy:   policy.NewAuthorizer(),
+		Renderer: render.NewRenderer(),
+		Redactor: redact.New(),
+		Evidence: evidence.NewCollector(clients),
+	}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	return toolset
+}
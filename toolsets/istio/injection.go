@@ -0,0 +1,194 @@
+package istio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+const (
+	injectionLabel   = "istio-injection"
+	revisionLabel    = "istio.io/rev"
+	sidecarInjectKey = "sidecar.istio.io/inject"
+)
+
+// The injector webhook's namespaceSelector always skips these.
+var injectionExcludedNamespaces = map[string]bool{"kube-system": true, "kube-public": true}
+
+// injectionDecision is what the sidecar injector webhook would do with a pod
+// created from a workload's template right now.
+type injectionDecision struct {
+	Inject   bool   `json:"inject"`
+	Reason   string `json:"reason"`
+	Revision string `json:"revision,omitempty"`
+	Note     string `json:"note,omitempty"`
+}
+
+// decideInjection mirrors the webhook selectors Istio installs: the namespace
+// istio-injection label wins, then a namespace istio.io/rev label, then the
+// pod-level sidecar.istio.io/inject or istio.io/rev labels in an unlabeled
+// namespace. A pod "false" label or annotation opts out once selected.
+func decideInjection(namespace string, nsLabels map[string]string, template corev1.PodTemplateSpec) injectionDecision {
+	podLabels := template.Labels
+	podAnnotations := template.Annotations
+	if injectionExcludedNamespaces[namespace] {
+		return injectionDecision{Reason: fmt.Sprintf("namespace %s is always excluded by the injector webhook", namespace)}
+	}
+	if template.Spec.HostNetwork {
+		return injectionDecision{Reason: "pod template uses hostNetwork; the injector skips hostNetwork pods"}
+	}
+	var decision injectionDecision
+	switch {
+	case nsLabels[injectionLabel] == "disabled":
+		return injectionDecision{Reason: "namespace label istio-injection=disabled turns injection off; pod-level settings cannot override it"}
+	case nsLabels[injectionLabel] == "enabled":
+		decision = injectionDecision{Inject: true, Reason: "namespace label istio-injection=enabled", Revision: "default"}
+	case nsLabels[revisionLabel] != "":
+		decision = injectionDecision{Inject: true, Reason: fmt.Sprintf("namespace label istio.io/rev=%s", nsLabels[revisionLabel]), Revision: nsLabels[revisionLabel]}
+	case podLabels[sidecarInjectKey] == "true":
+		decision = injectionDecision{Inject: true, Reason: "pod template label sidecar.istio.io/inject=true", Revision: valueOr(podLabels[revisionLabel], "default")}
+	case podLabels[revisionLabel] != "":
+		decision = injectionDecision{Inject: true, Reason: fmt.Sprintf("pod template label istio.io/rev=%s", podLabels[revisionLabel]), Revision: podLabels[revisionLabel]}
+	default:
+		decision = injectionDecision{Reason: "neither the namespace (istio-injection or istio.io/rev label) nor the pod template (sidecar.istio.io/inject or istio.io/rev label) opts in"}
+		if podAnnotations[sidecarInjectKey] == "true" {
+			decision.Note = "the sidecar.istio.io/inject=true annotation alone does not trigger the webhook; set it as a pod label or label the namespace"
+		}
+		return decision
+	}
+	switch {
+	case podLabels[sidecarInjectKey] == "false":
+		return injectionDecision{Reason: fmt.Sprintf("%s selects the pod, but pod template label sidecar.istio.io/inject=false opts out", decision.Reason)}
+	case podAnnotations[sidecarInjectKey] == "false":
+		return injectionDecision{Reason: fmt.Sprintf("%s selects the pod, but pod template annotation sidecar.istio.io/inject=false opts out", decision.Reason)}
+	}
+	return decision
+}
+
+func (t *Toolset) handleCheckInjection(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	namespace := toString(req.Arguments["namespace"])
+	name := toString(req.Arguments["name"])
+	kind := strings.ToLower(valueOr(toString(req.Arguments["kind"]), "Deployment"))
+	if namespace == "" || name == "" {
+		err := errors.New("namespace and name required")
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	analysis := render.NewAnalysis()
+	var template corev1.PodTemplateSpec
+	var selector *metav1.LabelSelector
+	var err error
+	switch kind {
+	case "deployment":
+		var deploy *appsv1.Deployment
+		if deploy, err = t.ctx.Clients.Typed.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{}); err == nil {
+			template, selector = deploy.Spec.Template, deploy.Spec.Selector
+		}
+	case "statefulset":
+		var sts *appsv1.StatefulSet
+		if sts, err = t.ctx.Clients.Typed.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{}); err == nil {
+			template, selector = sts.Spec.Template, sts.Spec.Selector
+		}
+	default:
+		err := fmt.Errorf("unsupported kind %q (use Deployment or StatefulSet)", kind)
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			analysis.AddEvidence("status", "workload not found")
+			analysis.AddNextCheck("Verify workload kind, name and namespace")
+			return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
+		}
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	analysis.AddResource(fmt.Sprintf("%ss/%s/%s", kind, namespace, name))
+	ns, err := t.ctx.Clients.Typed.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+
+	decision := decideInjection(namespace, ns.Labels, template)
+	missingWebhook := false
+	if decision.Inject && req.User.Role == policy.RoleCluster {
+		webhooks, err := t.ctx.Clients.Typed.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{LabelSelector: revisionLabel + "=" + decision.Revision})
+		switch {
+		case err != nil:
+			analysis.AddEvidence("webhookCheck", err.Error())
+		case len(webhooks.Items) == 0:
+			missingWebhook = true
+			decision = injectionDecision{
+				Reason:   fmt.Sprintf("%s selects revision %q, but no MutatingWebhookConfiguration is labeled istio.io/rev=%s", decision.Reason, decision.Revision, decision.Revision),
+				Revision: decision.Revision,
+			}
+		}
+	}
+	analysis.AddEvidence("injection", decision)
+	analysis.AddEvidence("labels", map[string]any{
+		"namespace":              injectionKeys(ns.Labels),
+		"podTemplate":            injectionKeys(template.Labels),
+		"podTemplateAnnotations": injectionKeys(template.Annotations),
+	})
+
+	var withProxy, withoutProxy []string
+	if selector != nil {
+		podSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+		}
+		pods, err := t.ctx.Clients.Typed.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: podSelector.String()})
+		if err != nil {
+			return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if hasIstioProxy(pod) {
+				withProxy = append(withProxy, pod.Name)
+			} else {
+				withoutProxy = append(withoutProxy, pod.Name)
+			}
+		}
+	}
+	sort.Strings(withProxy)
+	sort.Strings(withoutProxy)
+	analysis.AddEvidence("pods", map[string]any{"withProxy": withProxy, "withoutProxy": withoutProxy})
+
+	switch {
+	case decision.Inject && len(withoutProxy) > 0:
+		analysis.AddCause("Running pods predate sidecar injection", fmt.Sprintf("new pods will be injected (%s) but %d running pods have no istio-proxy; they were created before injection was enabled or while the webhook was unavailable", decision.Reason, len(withoutProxy)), "high")
+		analysis.AddNextCheck(fmt.Sprintf("Restart the workload so pods are re-created through the webhook: kubectl rollout restart %s/%s -n %s", kind, name, namespace))
+	case missingWebhook:
+		analysis.AddCause("No injector webhook for revision", decision.Reason, "high")
+		analysis.AddNextCheck("Install the istiod revision or point the istio.io/rev label at an existing revision")
+	case !decision.Inject && len(withProxy) > 0:
+		analysis.AddCause("Running pods carry a sidecar new pods will not get", fmt.Sprintf("%d running pods have istio-proxy but new pods will not be injected: %s", len(withProxy), decision.Reason), "medium")
+		analysis.AddNextCheck("Confirm whether injection was disabled on purpose before the next rollout drops the sidecars")
+	case !decision.Inject:
+		analysis.AddCause("Sidecar injection not enabled", decision.Reason, "low")
+		analysis.AddNextCheck("Label the namespace istio-injection=enabled (or istio.io/rev=<revision>) and restart the workload")
+	}
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+}
+
+// injectionKeys keeps only the labels and annotations that steer injection.
+func injectionKeys(values map[string]string) map[string]string {
+	out := map[string]string{}
+	for _, key := range []string{injectionLabel, revisionLabel, sidecarInjectKey} {
+		if value, ok := values[key]; ok {
+			out[key] = value
+		}
+	}
+	return out
+}
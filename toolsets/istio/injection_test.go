@@ -0,0 +1,140 @@
+package istio
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"rootcause/internal/config"
+	"rootcause/internal/evidence"
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/redact"
+	"rootcause/internal/render"
+)
+
+func podTemplate(labels, annotations map[string]string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: labels, Annotations: annotations}}
+}
+
+func TestDecideInjection(t *testing.T) {
+	cases := []struct {
+		name      string
+		namespace string
+		nsLabels  map[string]string
+		template  corev1.PodTemplateSpec
+		inject    bool
+		reason    string
+	}{
+		{"namespace enabled", "apps", map[string]string{"istio-injection": "enabled"}, podTemplate(nil, nil), true, "istio-injection=enabled"},
+		{"namespace disabled beats pod label", "apps", map[string]string{"istio-injection": "disabled"}, podTemplate(map[string]string{"sidecar.istio.io/inject": "true"}, nil), false, "cannot override"},
+		{"namespace revision", "apps", map[string]string{"istio.io/rev": "1-20"}, podTemplate(nil, nil), true, "istio.io/rev=1-20"},
+		{"pod label opt in", "apps", nil, podTemplate(map[string]string{"sidecar.istio.io/inject": "true"}, nil), true, "pod template label"},
+		{"pod label opt out", "apps", map[string]string{"istio-injection": "enabled"}, podTemplate(map[string]string{"sidecar.istio.io/inject": "false"}, nil), false, "opts out"},
+		{"pod annotation opt out", "apps", map[string]string{"istio-injection": "enabled"}, podTemplate(nil, map[string]string{"sidecar.istio.io/inject": "false"}), false, "annotation"},
+		{"nothing opts in", "apps", nil, podTemplate(nil, nil), false, "neither"},
+		{"kube-system excluded", "kube-system", map[string]string{"istio-injection": "enabled"}, podTemplate(nil, nil), false, "always excluded"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := decideInjection(tc.namespace, tc.nsLabels, tc.template)
+			if got.Inject != tc.inject || !strings.Contains(got.Reason, tc.reason) {
+				t.Fatalf("decision = %+v, want inject=%v reason containing %q", got, tc.inject, tc.reason)
+			}
+		})
+	}
+	annotationOnly := decideInjection("apps", nil, podTemplate(nil, map[string]string{"sidecar.istio.io/inject": "true"}))
+	if annotationOnly.Inject || annotationOnly.Note == "" {
+		t.Fatalf("expected annotation-only opt in to be explained, got %+v", annotationOnly)
+	}
+}
+
+func newInjectionToolset(t *testing.T, objects ...runtime.Object) *Toolset {
+	t.Helper()
+	client := k8sfake.NewSimpleClientset(objects...)
+	cfg := config.DefaultConfig()
+	clients := &kube.Clients{Typed: client}
+	toolset := New()
+	if err := toolset.Init(mcp.ToolContext{
+		Config:   &cfg,
+		Clients:  clients,
+		Policy:   policy.NewAuthorizer(),
+		Renderer: render.NewRenderer(),
+		Redactor: redact.New(),
+		Evidence: evidence.NewCollector(clients),
+	}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	return toolset
+}
+
+func injectionCauses(t *testing.T, toolset *Toolset, args map[string]any) []render.Cause {
+	t.Helper()
+	result, err := toolset.handleCheckInjection(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: args})
+	if err != nil {
+		t.Fatalf("check injection: %v", err)
+	}
+	causes, _ := result.Data.(map[string]any)["likelyRootCauses"].([]render.Cause)
+	return causes
+}
+
+func TestHandleCheckInjection(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "apps"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: selector,
+			Template: podTemplate(map[string]string{"app": "api"}, nil),
+		},
+	}
+	oldPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-old", Namespace: "apps", Labels: map[string]string{"app": "api"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}
+	webhook := &admissionv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio-sidecar-injector", Labels: map[string]string{"istio.io/rev": "default"}},
+	}
+	labeled := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps", Labels: map[string]string{"istio-injection": "enabled"}}}
+
+	causes := injectionCauses(t, newInjectionToolset(t, labeled, deploy, oldPod, webhook), map[string]any{"namespace": "apps", "name": "api"})
+	if len(causes) != 1 || causes[0].Summary != "Running pods predate sidecar injection" {
+		t.Fatalf("expected pods-predate-label cause, got %#v", causes)
+	}
+
+	causes = injectionCauses(t, newInjectionToolset(t, labeled, deploy, oldPod), map[string]any{"namespace": "apps", "name": "api"})
+	if len(causes) != 1 || causes[0].Summary != "No injector webhook for revision" {
+		t.Fatalf("expected missing webhook cause, got %#v", causes)
+	}
+
+	unlabeled := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}}
+	causes = injectionCauses(t, newInjectionToolset(t, unlabeled, deploy, oldPod), map[string]any{"namespace": "apps", "name": "api"})
+	if len(causes) != 1 || causes[0].Summary != "Sidecar injection not enabled" {
+		t.Fatalf("expected injection-not-enabled cause, got %#v", causes)
+	}
+
+	toolset := newInjectionToolset(t, unlabeled)
+	if _, err := toolset.handleCheckInjection(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"namespace": "apps", "name": "api", "kind": "DaemonSet"},
+	}); err == nil {
+		t.Fatalf("expected error for unsupported kind")
+	}
+	result, err := toolset.handleCheckInjection(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"namespace": "apps", "name": "missing", "kind": "StatefulSet"},
+	})
+	if err != nil {
+		t.Fatalf("missing workload: %v", err)
+	}
+	if evidenceItems := result.Data.(map[string]any)["evidence"].([]render.EvidenceItem); evidenceItems[0].Details != "workload not found" {
+		t.Fatalf("expected workload not found, got %#v", evidenceItems)
+	}
+}
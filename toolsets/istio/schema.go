@@ -13,6 +13,15 @@ func schemaConfigSummary() map[string]any {
 	}
 }
 
+func schemaAnalyzeConfig() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"namespace": map[string]any{"type": "string"},
+		},
+	}
+}
+
 func schemaProxyStatus() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -23,6 +32,22 @@ func schemaProxyStatus() map[string]any {
 	}
 }
 
+func schemaSidecarResources() map[string]any {
+	return schemaProxyStatus()
+}
+
+func schemaCheckInjection() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"namespace": map[string]any{"type": "string"},
+			"name":      map[string]any{"type": "string"},
+			"kind":      map[string]any{"type": "string", "enum": []string{"Deployment", "StatefulSet"}, "description": "Workload kind (default Deployment)."},
+		},
+		"required": []string{"namespace", "name"},
+	}
+}
+
 func schemaCRStatus() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -34,6 +59,10 @@ func schemaCRStatus() map[string]any {
 			"name":          map[string]any{"type": "string"},
 			"namespace":     map[string]any{"type": "string"},
 			"labelSelector": map[string]any{"type": "string"},
+			"refreshDiscovery": map[string]any{
+				"type":        "boolean",
+				"description": "Invalidate the discovery/RESTMapper cache first so CRDs installed since the last refresh resolve.",
+			},
 		},
 	}
 }
@@ -67,6 +96,17 @@ func schemaPodsByService() map[string]any {
 	}
 }
 
+func schemaDiagnose503() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"namespace": map[string]any{"type": "string"},
+			"service":   map[string]any{"type": "string"},
+		},
+		"required": []string{"namespace", "service"},
+	}
+}
+
 func schemaExternalDependencyCheck() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -76,6 +116,50 @@ func schemaExternalDependencyCheck() map[string]any {
 	}
 }
 
+func schemaAnalyzeEnvoyFilters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"namespace": map[string]any{"type": "string"},
+		},
+	}
+}
+
+func schemaEvaluateAuthz() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"namespace":            map[string]any{"type": "string"},
+			"pod":                  map[string]any{"type": "string"},
+			"workloadLabels":       map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+			"sourcePrincipal":      map[string]any{"type": "string"},
+			"sourceNamespace":      map[string]any{"type": "string"},
+			"sourceServiceAccount": map[string]any{"type": "string"},
+			"host":                 map[string]any{"type": "string"},
+			"port":                 map[string]any{"type": "integer"},
+			"method":               map[string]any{"type": "string"},
+			"path":                 map[string]any{"type": "string"},
+		},
+		"required": []string{"namespace"},
+	}
+}
+
+func schemaSimulateRoute() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"namespace": map[string]any{"type": "string", "description": "Namespace of the caller; short hosts resolve relative to it."},
+			"host":      map[string]any{"type": "string"},
+			"path":      map[string]any{"type": "string"},
+			"method":    map[string]any{"type": "string"},
+			"port":      map[string]any{"type": "integer"},
+			"headers":   map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+			"gateway":   map[string]any{"type": "string", "description": "Gateway the request enters through (name or ns/name, default namespace istio-system). Omit for sidecar (mesh) traffic."},
+		},
+		"required": []string{"host"},
+	}
+}
+
 func schemaProxyConfig() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -88,6 +172,18 @@ func schemaProxyConfig() map[string]any {
 	}
 }
 
+func schemaProxyConfigDump() map[string]any {
+	schema := schemaProxyConfig()
+	props := schema["properties"].(map[string]any)
+	props["resourceName"] = map[string]any{"type": "string", "description": "Return only listeners/clusters/routes/endpoints whose name equals or contains this value."}
+	props["type"] = map[string]any{"type": "string", "enum": []string{"listener", "cluster", "route", "endpoint"}}
+	props["raw"] = map[string]any{"type": "boolean", "description": "Return the full config_dump even when resourceName/type are set."}
+	props["resource"] = map[string]any{"type": "string", "description": "Envoy config_dump section to dump, e.g. dynamic_active_clusters (passed as ?resource=)."}
+	props["offset"] = map[string]any{"type": "integer", "minimum": 0, "description": "Skip this many matches; use nextOffset from a truncated result."}
+	props["maxBytes"] = map[string]any{"type": "integer", "minimum": 1, "description": "Cap on the returned config_dump data (default 1 MiB)."}
+	return schema
+}
+
 func schemaHTTPRouteStatus() map[string]any {
 	return map[string]any{
 		"type": "object",
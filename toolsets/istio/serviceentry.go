@@ -0,0 +1,154 @@
+package istio
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type serviceEntryResolution struct {
+	Host         string                 `json:"host"`
+	ServiceEntry string                 `json:"serviceEntry"`
+	Resolution   string                 `json:"resolution"`
+	Location     string                 `json:"location"`
+	Addresses    []string               `json:"addresses,omitempty"`
+	Ports        []serviceEntryPort     `json:"ports,omitempty"`
+	Endpoints    []serviceEntryEndpoint `json:"endpoints,omitempty"`
+	Issues       []string               `json:"issues,omitempty"`
+}
+
+type serviceEntryPort struct {
+	Number   int64  `json:"number"`
+	Protocol string `json:"protocol,omitempty"`
+	Name     string `json:"name,omitempty"`
+}
+
+type serviceEntryEndpoint struct {
+	Address string           `json:"address"`
+	Ports   map[string]int64 `json:"ports,omitempty"`
+}
+
+// serviceEntryMatrix reports, for every external host that has a
+// ServiceEntry, how that entry actually routes egress: resolution mode,
+// location, addresses, ports and endpoints. A host referenced by several
+// objects is reported once per matching ServiceEntry.
+func serviceEntryMatrix(hosts []externalHostRecord, entries []unstructured.Unstructured) []serviceEntryResolution {
+	seen := map[string]struct{}{}
+	var rows []serviceEntryResolution
+	for _, record := range hosts {
+		if !record.ServiceEntry {
+			continue
+		}
+		for i := range entries {
+			entry := &entries[i]
+			if !matchServiceEntry(record.Host, nestedStringSlice(entry, "spec", "hosts")) {
+				continue
+			}
+			ref := fmt.Sprintf("%s/%s", entry.GetNamespace(), entry.GetName())
+			if _, ok := seen[record.Host+" "+ref]; ok {
+				continue
+			}
+			seen[record.Host+" "+ref] = struct{}{}
+			rows = append(rows, resolveServiceEntry(record.Host, ref, entry))
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Host != rows[j].Host {
+			return rows[i].Host < rows[j].Host
+		}
+		return rows[i].ServiceEntry < rows[j].ServiceEntry
+	})
+	return rows
+}
+
+func resolveServiceEntry(host, ref string, entry *unstructured.Unstructured) serviceEntryResolution {
+	row := serviceEntryResolution{
+		Host:         host,
+		ServiceEntry: ref,
+		Resolution:   nestedString(entry, "spec", "resolution"),
+		Location:     nestedString(entry, "spec", "location"),
+		Addresses:    nestedStringSlice(entry, "spec", "addresses"),
+	}
+	// Istio's defaults when the fields are omitted.
+	if row.Resolution == "" {
+		row.Resolution = "NONE"
+	}
+	if row.Location == "" {
+		row.Location = "MESH_EXTERNAL"
+	}
+	ports, _, _ := unstructured.NestedSlice(entry.Object, "spec", "ports")
+	for _, raw := range ports {
+		port, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		number, _, _ := unstructured.NestedInt64(port, "number")
+		row.Ports = append(row.Ports, serviceEntryPort{
+			Number:   number,
+			Protocol: toString(port["protocol"]),
+			Name:     toString(port["name"]),
+		})
+	}
+	endpoints, _, _ := unstructured.NestedSlice(entry.Object, "spec", "endpoints")
+	for _, raw := range endpoints {
+		endpoint, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		item := serviceEntryEndpoint{Address: toString(endpoint["address"])}
+		if endpointPorts, ok := endpoint["ports"].(map[string]any); ok {
+			item.Ports = map[string]int64{}
+			for name := range endpointPorts {
+				value, _, _ := unstructured.NestedInt64(endpointPorts, name)
+				item.Ports[name] = value
+			}
+		}
+		row.Endpoints = append(row.Endpoints, item)
+	}
+	_, hasWorkloadSelector, _ := unstructured.NestedMap(entry.Object, "spec", "workloadSelector")
+	row.Issues = serviceEntryIssues(row, nestedStringSlice(entry, "spec", "hosts"), hasWorkloadSelector)
+	return row
+}
+
+// serviceEntryIssues flags ServiceEntries that exist but cannot route the
+// traffic they were written for.
+func serviceEntryIssues(row serviceEntryResolution, hosts []string, hasWorkloadSelector bool) []string {
+	var issues []string
+	if len(row.Ports) == 0 {
+		issues = append(issues, "no ports declared; sidecars build no outbound listener for this host")
+	}
+	switch row.Resolution {
+	case "STATIC":
+		if len(row.Endpoints) == 0 && !hasWorkloadSelector {
+			issues = append(issues, "STATIC resolution with no endpoints or workloadSelector has nothing to send traffic to")
+		}
+	case "DNS", "DNS_ROUND_ROBIN":
+		for _, host := range hosts {
+			if strings.HasPrefix(host, "*") {
+				issues = append(issues, fmt.Sprintf("%s resolution cannot resolve wildcard host %s", row.Resolution, host))
+				break
+			}
+		}
+	case "NONE":
+		if len(row.Addresses) == 0 && hasTCPPort(row.Ports) {
+			issues = append(issues, "NONE resolution with TCP ports and no addresses matches any destination IP on those ports")
+		}
+	}
+	return issues
+}
+
+// hasTCPPort reports whether any port is routed by destination IP rather
+// than by Host/SNI, which is what makes an address-less NONE entry match
+// unrelated traffic.
+func hasTCPPort(ports []serviceEntryPort) bool {
+	for _, port := range ports {
+		switch strings.ToUpper(port.Protocol) {
+		case "HTTP", "HTTP2", "HTTPS", "GRPC", "TLS":
+			continue
+		}
+		return true
+	}
+	return false
+}
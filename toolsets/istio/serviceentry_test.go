@@ -0,0 +1,87 @@
+package istio
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func serviceEntryObject(name string, spec map[string]any) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "networking.istio.io/v1beta1",
+		"kind":       "ServiceEntry",
+		"metadata":   map[string]any{"name": name, "namespace": "default"},
+		"spec":       spec,
+	}}
+}
+
+func TestServiceEntryMatrix(t *testing.T) {
+	entries := []unstructured.Unstructured{
+		serviceEntryObject("payments", map[string]any{
+			"hosts":      []any{"api.payments.example.com"},
+			"resolution": "DNS",
+			"ports":      []any{map[string]any{"number": int64(443), "protocol": "TLS", "name": "tls"}},
+		}),
+		serviceEntryObject("wildcard-dns", map[string]any{
+			"hosts":      []any{"*.storage.example.com"},
+			"resolution": "DNS",
+			"ports":      []any{map[string]any{"number": int64(443), "protocol": "HTTPS"}},
+		}),
+		serviceEntryObject("legacy-db", map[string]any{
+			"hosts":      []any{"db.legacy.internal"},
+			"resolution": "STATIC",
+			"location":   "MESH_INTERNAL",
+			"ports":      []any{map[string]any{"number": int64(5432), "protocol": "TCP"}},
+		}),
+		serviceEntryObject("no-ports", map[string]any{
+			"hosts": []any{"metrics.example.com"},
+		}),
+	}
+	hosts := []externalHostRecord{
+		{Host: "api.payments.example.com", ServiceEntry: true},
+		{Host: "api.payments.example.com", ServiceEntry: true},
+		{Host: "blob.storage.example.com", ServiceEntry: true},
+		{Host: "db.legacy.internal", ServiceEntry: true},
+		{Host: "metrics.example.com", ServiceEntry: true},
+		{Host: "missing.example.com", ServiceEntry: false},
+	}
+	rows := serviceEntryMatrix(hosts, entries)
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows (deduplicated, missing skipped), got %#v", rows)
+	}
+	byHost := map[string]serviceEntryResolution{}
+	for _, row := range rows {
+		byHost[row.Host] = row
+	}
+	payments := byHost["api.payments.example.com"]
+	if payments.Resolution != "DNS" || payments.Location != "MESH_EXTERNAL" || len(payments.Ports) != 1 || payments.Ports[0].Number != 443 || len(payments.Issues) != 0 {
+		t.Fatalf("unexpected payments row: %#v", payments)
+	}
+	if issues := byHost["blob.storage.example.com"].Issues; len(issues) != 1 || !strings.Contains(issues[0], "wildcard") {
+		t.Fatalf("expected wildcard DNS issue, got %v", issues)
+	}
+	legacy := byHost["db.legacy.internal"]
+	if legacy.Location != "MESH_INTERNAL" || len(legacy.Issues) != 1 || !strings.Contains(legacy.Issues[0], "STATIC") {
+		t.Fatalf("unexpected legacy row: %#v", legacy)
+	}
+	metrics := byHost["metrics.example.com"]
+	if metrics.Resolution != "NONE" || len(metrics.Issues) != 1 || !strings.Contains(metrics.Issues[0], "no ports") {
+		t.Fatalf("unexpected metrics row: %#v", metrics)
+	}
+}
+
+func TestServiceEntryIssuesNoneResolution(t *testing.T) {
+	row := serviceEntryResolution{Resolution: "NONE", Ports: []serviceEntryPort{{Number: 9092, Protocol: "TCP"}}}
+	if issues := serviceEntryIssues(row, []string{"kafka.example.com"}, false); len(issues) != 1 {
+		t.Fatalf("expected address-less TCP issue, got %v", issues)
+	}
+	row.Addresses = []string{"10.0.0.0/24"}
+	if issues := serviceEntryIssues(row, []string{"kafka.example.com"}, false); len(issues) != 0 {
+		t.Fatalf("expected no issues with addresses, got %v", issues)
+	}
+	row = serviceEntryResolution{Resolution: "STATIC", Ports: []serviceEntryPort{{Number: 80, Protocol: "HTTP"}}}
+	if issues := serviceEntryIssues(row, nil, true); len(issues) != 0 {
+		t.Fatalf("expected workloadSelector to satisfy STATIC, got %v", issues)
+	}
+}
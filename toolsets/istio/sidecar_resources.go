@@ -0,0 +1,203 @@
+package istio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/render"
+)
+
+// Injector defaults from the istio-sidecar-injector values. Proxies that
+// still run with these were never sized for their workload.
+var (
+	defaultProxyCPURequest    = resource.MustParse("100m")
+	defaultProxyMemoryRequest = resource.MustParse("128Mi")
+	// Below this, a proxy carrying a large config (many services or
+	// endpoints) can be OOMKilled on config push alone.
+	minProxyMemoryLimit = resource.MustParse("256Mi")
+)
+
+type sidecarResourceRecord struct {
+	Namespace      string   `json:"namespace"`
+	Pod            string   `json:"pod"`
+	Workload       string   `json:"workload,omitempty"`
+	CPURequest     string   `json:"cpuRequest,omitempty"`
+	CPULimit       string   `json:"cpuLimit,omitempty"`
+	MemoryRequest  string   `json:"memoryRequest,omitempty"`
+	MemoryLimit    string   `json:"memoryLimit,omitempty"`
+	RestartCount   int32    `json:"restartCount,omitempty"`
+	LastTerminated string   `json:"lastTerminated,omitempty"`
+	Defaults       bool     `json:"defaults,omitempty"`
+	Issues         []string `json:"issues,omitempty"`
+}
+
+func (t *Toolset) handleSidecarResources(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	namespace := toString(req.Arguments["namespace"])
+	selector := toString(req.Arguments["labelSelector"])
+	if namespace != "" {
+		if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+			return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+		}
+	}
+	analysis := render.NewAnalysis()
+	namespaces, err := t.allowedNamespaces(ctx, req.User, namespace)
+	if err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	var records []sidecarResourceRecord
+	for _, ns := range namespaces {
+		pods, err := t.ctx.Clients.Typed.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if !hasIstioProxy(pod) {
+				continue
+			}
+			record := sidecarResources(pod)
+			records = append(records, record)
+			if len(record.Issues) > 0 {
+				analysis.AddResource(fmt.Sprintf("pods/%s/%s", ns, pod.Name))
+			}
+		}
+	}
+	if len(records) == 0 {
+		analysis.AddEvidence("status", "no istio proxies found")
+		analysis.AddNextCheck("Verify sidecar injection is enabled for the namespace")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+	}
+
+	mismatches := sidecarReplicaMismatches(records)
+	analysis.AddEvidence("sidecars", records)
+	if len(mismatches) > 0 {
+		analysis.AddEvidence("replicaMismatches", mismatches)
+	}
+	for _, record := range records {
+		switch {
+		case record.LastTerminated == "OOMKilled":
+			analysis.AddCause("istio-proxy OOMKilled", fmt.Sprintf("%s/%s sidecar was OOMKilled (memory limit %s, %d restarts); in-flight requests fail with 503 while it restarts", record.Namespace, record.Pod, valueOr(record.MemoryLimit, "none"), record.RestartCount), "high")
+		case len(record.Issues) > 0:
+			analysis.AddCause("istio-proxy resources likely too low", fmt.Sprintf("%s/%s: %s", record.Namespace, record.Pod, strings.Join(record.Issues, "; ")), "medium")
+		}
+	}
+	workloads := make([]string, 0, len(mismatches))
+	for workload := range mismatches {
+		workloads = append(workloads, workload)
+	}
+	sort.Strings(workloads)
+	for _, workload := range workloads {
+		analysis.AddCause("Sidecar resources differ across replicas", fmt.Sprintf("%s runs %d different istio-proxy resource configs", workload, len(mismatches[workload])), "low")
+	}
+	analysis.AddNextCheck("Size proxies with sidecar.istio.io/proxyCPU, proxyMemory, proxyCPULimit and proxyMemoryLimit annotations, then restart the workload")
+	analysis.AddNextCheck("Use a Sidecar resource to limit egress hosts when proxy memory grows with mesh size")
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+}
+
+// sidecarResources reads the injected istio-proxy container's resources as
+// they ended up in the pod spec, after injector defaults and annotations.
+func sidecarResources(pod *corev1.Pod) sidecarResourceRecord {
+	record := sidecarResourceRecord{Namespace: pod.Namespace, Pod: pod.Name, Workload: podWorkload(pod)}
+	for _, container := range pod.Spec.Containers {
+		if container.Name != "istio-proxy" {
+			continue
+		}
+		requests := container.Resources.Requests
+		limits := container.Resources.Limits
+		record.CPURequest = quantityString(requests, corev1.ResourceCPU)
+		record.CPULimit = quantityString(limits, corev1.ResourceCPU)
+		record.MemoryRequest = quantityString(requests, corev1.ResourceMemory)
+		record.MemoryLimit = quantityString(limits, corev1.ResourceMemory)
+		cpu, hasCPU := requests[corev1.ResourceCPU]
+		memory, hasMemory := requests[corev1.ResourceMemory]
+		record.Defaults = hasCPU && hasMemory && cpu.Cmp(defaultProxyCPURequest) == 0 && memory.Cmp(defaultProxyMemoryRequest) == 0
+		if limit, ok := limits[corev1.ResourceMemory]; ok && limit.Cmp(minProxyMemoryLimit) < 0 {
+			record.Issues = append(record.Issues, fmt.Sprintf("memory limit %s is below %s", limit.String(), minProxyMemoryLimit.String()))
+		}
+		if limit, ok := limits[corev1.ResourceCPU]; ok && hasCPU && limit.Cmp(cpu) == 0 {
+			record.Issues = append(record.Issues, fmt.Sprintf("CPU limit equals request (%s); the proxy is throttled under any burst", limit.String()))
+		}
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != "istio-proxy" {
+			continue
+		}
+		record.RestartCount = status.RestartCount
+		if last := status.LastTerminationState.Terminated; last != nil {
+			record.LastTerminated = last.Reason
+		}
+	}
+	// Defaults only become a finding once the proxy shows it cannot cope.
+	if record.Defaults && record.RestartCount > 0 {
+		record.Issues = append(record.Issues, fmt.Sprintf("running injector defaults with %d restarts", record.RestartCount))
+	}
+	return record
+}
+
+// sidecarReplicaMismatches groups proxies by owning workload and returns the
+// workloads whose replicas do not agree on istio-proxy resources, mapped to
+// the distinct configurations seen.
+func sidecarReplicaMismatches(records []sidecarResourceRecord) map[string][]string {
+	configs := map[string]map[string]struct{}{}
+	for _, record := range records {
+		if record.Workload == "" {
+			continue
+		}
+		key := record.Namespace + "/" + record.Workload
+		if configs[key] == nil {
+			configs[key] = map[string]struct{}{}
+		}
+		configs[key][fmt.Sprintf("requests=%s/%s limits=%s/%s", valueOr(record.CPURequest, "-"), valueOr(record.MemoryRequest, "-"), valueOr(record.CPULimit, "-"), valueOr(record.MemoryLimit, "-"))] = struct{}{}
+	}
+	out := map[string][]string{}
+	for key, seen := range configs {
+		if len(seen) < 2 {
+			continue
+		}
+		variants := make([]string, 0, len(seen))
+		for variant := range seen {
+			variants = append(variants, variant)
+		}
+		sort.Strings(variants)
+		out[key] = variants
+	}
+	return out
+}
+
+// podWorkload names the controller that owns a pod, collapsing ReplicaSets
+// back to their Deployment so replicas from one rollout group together.
+func podWorkload(pod *corev1.Pod) string {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return ""
+	}
+	if owner.Kind == "ReplicaSet" {
+		if hash := pod.Labels["pod-template-hash"]; hash != "" {
+			if name, ok := strings.CutSuffix(owner.Name, "-"+hash); ok {
+				return "Deployment/" + name
+			}
+		}
+	}
+	return owner.Kind + "/" + owner.Name
+}
+
+func quantityString(list corev1.ResourceList, name corev1.ResourceName) string {
+	if value, ok := list[name]; ok {
+		return value.String()
+	}
+	return ""
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,132 @@
+package istio
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"rootcause/internal/config"
+	"rootcause/internal/evidence"
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/redact"
+	"rootcause/internal/render"
+)
+
+func sidecarPod(name, replicaSet, hash string, requests, limits corev1.ResourceList) *corev1.Pod {
+	controller := true
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			Labels:          map[string]string{"app": "api", "pod-template-hash": hash},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: replicaSet, Controller: &controller}},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "app"},
+			{Name: "istio-proxy", Resources: corev1.ResourceRequirements{Requests: requests, Limits: limits}},
+		}},
+	}
+}
+
+func resources(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse(memory)}
+}
+
+func TestSidecarResourcesRecord(t *testing.T) {
+	pod := sidecarPod("api-1", "api-5d4f", "5d4f", resources("100m", "128Mi"), resources("100m", "128Mi"))
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:                 "istio-proxy",
+		RestartCount:         4,
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+	}}
+	record := sidecarResources(pod)
+	if record.Workload != "Deployment/api" || !record.Defaults || record.LastTerminated != "OOMKilled" {
+		t.Fatalf("unexpected record: %#v", record)
+	}
+	if len(record.Issues) != 3 {
+		t.Fatalf("expected memory, cpu and defaults issues, got %#v", record.Issues)
+	}
+
+	healthy := sidecarResources(sidecarPod("api-2", "api-5d4f", "5d4f", resources("500m", "512Mi"), resources("2", "1Gi")))
+	if len(healthy.Issues) != 0 || healthy.Defaults {
+		t.Fatalf("expected no issues, got %#v", healthy)
+	}
+	if got := podWorkload(&corev1.Pod{}); got != "" {
+		t.Fatalf("expected no workload for bare pod, got %q", got)
+	}
+}
+
+func TestSidecarReplicaMismatches(t *testing.T) {
+	records := []sidecarResourceRecord{
+		{Namespace: "default", Pod: "a", Workload: "Deployment/api", CPURequest: "100m", MemoryRequest: "128Mi"},
+		{Namespace: "default", Pod: "b", Workload: "Deployment/api", CPURequest: "500m", MemoryRequest: "512Mi"},
+		{Namespace: "default", Pod: "c", Workload: "Deployment/web", CPURequest: "100m", MemoryRequest: "128Mi"},
+		{Namespace: "default", Pod: "d", Workload: "Deployment/web", CPURequest: "100m", MemoryRequest: "128Mi"},
+		{Namespace: "default", Pod: "e"},
+	}
+	mismatches := sidecarReplicaMismatches(records)
+	if len(mismatches) != 1 || len(mismatches["default/Deployment/api"]) != 2 {
+		t.Fatalf("unexpected mismatches: %#v", mismatches)
+	}
+}
+
+func TestHandleSidecarResources(t *testing.T) {
+	oom := sidecarPod("api-1", "api-5d4f", "5d4f", resources("100m", "128Mi"), resources("2", "128Mi"))
+	oom.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:                 "istio-proxy",
+		RestartCount:         2,
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled"}},
+	}}
+	resized := sidecarPod("api-2", "api-77aa", "77aa", resources("500m", "512Mi"), resources("2", "1Gi"))
+	plain := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "plain", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}
+	client := k8sfake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}, oom, resized, plain)
+	cfg := config.DefaultConfig()
+	clients := &kube.Clients{Typed: client}
+	toolset := New()
+	if err := toolset.Init(mcp.ToolContext{
+		Config:   &cfg,
+		Clients:  clients,
+		Policy:   policy.NewAuthorizer(),
+		Renderer: render.NewRenderer(),
+		Redactor: redact.New(),
+		Evidence: evidence.NewCollector(clients),
+	}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	result, err := toolset.handleSidecarResources(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"namespace": "default"},
+	})
+	if err != nil {
+		t.Fatalf("sidecar resources: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	var sidecars []sidecarResourceRecord
+	for _, item := range data["evidence"].([]render.EvidenceItem) {
+		if item.Summary == "sidecars" {
+			sidecars = item.Details.([]sidecarResourceRecord)
+		}
+	}
+	if len(sidecars) != 2 {
+		t.Fatalf("expected 2 sidecars, got %#v", sidecars)
+	}
+	causes, _ := data["likelyRootCauses"].([]render.Cause)
+	var titles []string
+	for _, cause := range causes {
+		titles = append(titles, cause.Summary)
+	}
+	joined := strings.Join(titles, ",")
+	if !strings.Contains(joined, "istio-proxy OOMKilled") || !strings.Contains(joined, "Sidecar resources differ across replicas") {
+		t.Fatalf("unexpected causes: %v", titles)
+	}
+}
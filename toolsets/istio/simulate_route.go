@@ -0,0 +1,427 @@
+package istio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"rootcause/internal/argconv"
+	"rootcause/internal/mcp"
+	"rootcause/internal/render"
+)
+
+// routeRequest is the sample request simulate_route evaluates against
+// VirtualService HTTP routes.
+type routeRequest struct {
+	host    string
+	fqdn    string
+	path    string
+	method  string
+	port    int
+	headers map[string]string
+	// gateway is "mesh" for sidecar traffic or "ns/name" of a Gateway.
+	gateway string
+}
+
+func routeRequestFromArgs(args map[string]any) (routeRequest, error) {
+	req := routeRequest{
+		host:    strings.ToLower(strings.TrimSpace(toString(args["host"]))),
+		path:    strings.TrimSpace(toString(args["path"])),
+		method:  strings.ToUpper(strings.TrimSpace(toString(args["method"]))),
+		port:    argconv.Int(args["port"], 0),
+		headers: map[string]string{},
+		gateway: strings.TrimSpace(toString(args["gateway"])),
+	}
+	if host, port, err := net.SplitHostPort(req.host); err == nil {
+		req.host = host
+		if req.port == 0 {
+			req.port, _ = strconv.Atoi(port)
+		}
+	}
+	if req.host == "" {
+		return req, errors.New("host is required")
+	}
+	namespace := toString(args["namespace"])
+	if !strings.Contains(req.host, ".") && namespace == "" {
+		return req, fmt.Errorf("namespace is required to resolve short host %q", req.host)
+	}
+	req.fqdn = req.host
+	if namespace != "" {
+		req.fqdn = qualifyHost(req.host, namespace)
+	}
+	for name, value := range toStringMap(args["headers"]) {
+		req.headers[strings.ToLower(name)] = value
+	}
+	switch req.gateway {
+	case "", "mesh":
+		req.gateway = "mesh"
+	default:
+		req.gateway = gatewayKey(req.gateway, istioNamespace)
+	}
+	return req, nil
+}
+
+// routeEvaluation records how one HTTP route of a VirtualService compared
+// with the request.
+type routeEvaluation struct {
+	VirtualService string   `json:"virtualService"`
+	Index          int      `json:"index"`
+	Name           string   `json:"name,omitempty"`
+	Matched        bool     `json:"matched"`
+	Mismatched     []string `json:"mismatched,omitempty"`
+	Unevaluated    []string `json:"unevaluated,omitempty"`
+}
+
+type routeDestination struct {
+	Host   string `json:"host"`
+	Subset string `json:"subset,omitempty"`
+	Port   int64  `json:"port,omitempty"`
+	Weight int64  `json:"weight"`
+}
+
+func (t *Toolset) handleSimulateRoute(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	request, err := routeRequestFromArgs(req.Arguments)
+	if err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	namespace := toString(req.Arguments["namespace"])
+	if namespace != "" {
+		if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+			return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+		}
+	}
+	analysis := render.NewAnalysis()
+	analysis.AddEvidence("request", t.ctx.Redactor.RedactValue(map[string]any{
+		"host":    request.fqdn,
+		"path":    request.path,
+		"method":  request.method,
+		"port":    request.port,
+		"headers": request.headers,
+		"gateway": request.gateway,
+	}))
+
+	services, ok := t.meshObjects(ctx, &analysis, req.User, "VirtualService", "networking.istio.io", "")
+	if !ok {
+		analysis.AddNextCheck("Verify the networking.istio.io CRDs are installed")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+	}
+	candidates := routeCandidates(services, request)
+	if len(candidates) == 0 {
+		analysis.AddEvidence("status", fmt.Sprintf("no VirtualService for %s is bound to %s", request.fqdn, request.gateway))
+		if request.gateway == "mesh" {
+			analysis.AddNextCheck("Without a VirtualService, mesh traffic goes to the Kubernetes Service for the host with default load balancing")
+		} else {
+			analysis.AddCause("No VirtualService for host on gateway", fmt.Sprintf("%s has no VirtualService bound to gateway %s; the gateway returns 404", request.fqdn, request.gateway), "high")
+			analysis.AddNextCheck("Bind a VirtualService with this host to the gateway via spec.gateways")
+		}
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+	}
+	// Sidecars use a single VirtualService per host, the most specific and
+	// then the oldest; gateways merge the routes of every VirtualService
+	// bound to them.
+	if request.gateway == "mesh" && len(candidates) > 1 {
+		var ignored []string
+		for _, c := range candidates[1:] {
+			if c.exact == candidates[0].exact {
+				ignored = append(ignored, objectRef(&c.obj))
+			}
+		}
+		if len(ignored) > 0 {
+			analysis.AddEvidence("ignoredVirtualServices", ignored)
+			analysis.AddCause("Multiple VirtualServices for host", fmt.Sprintf("sidecars only use %s for %s; %s are ignored", objectRef(&candidates[0].obj), request.fqdn, strings.Join(ignored, ", ")), "medium")
+		}
+		candidates = candidates[:1]
+	}
+	var refs []string
+	var routed []unstructured.Unstructured
+	for _, c := range candidates {
+		ref := objectRef(&c.obj)
+		refs = append(refs, ref)
+		routed = append(routed, c.obj)
+		analysis.AddResource("virtualservices/" + ref)
+	}
+	analysis.AddEvidence("virtualServices", refs)
+
+	evaluated, selected, route := simulateHTTPRoutes(routed, request)
+	analysis.AddEvidence("evaluated", evaluated)
+	if selected == nil {
+		analysis.AddCause("Request matches no route", fmt.Sprintf("no HTTP route in %s matches; Envoy returns 404 (NR)", strings.Join(refs, ", ")), "high")
+		if hasUnevaluated(evaluated) {
+			analysis.AddNextCheck("Supply the request fields listed under unevaluated to evaluate the remaining match rules")
+		}
+		analysis.AddNextCheck("Add a catch-all route (no match block) as the last HTTP route")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+	}
+	outcome := map[string]any{"virtualService": selected.VirtualService, "index": selected.Index}
+	if selected.Name != "" {
+		outcome["name"] = selected.Name
+	}
+	for _, key := range []string{"redirect", "directResponse", "rewrite", "timeout", "fault", "delegate"} {
+		if value, ok := route[key]; ok {
+			outcome[key] = value
+		}
+	}
+	destinations := httpRouteDestinations(route)
+	if len(destinations) > 0 {
+		outcome["destinations"] = destinations
+	}
+	analysis.AddEvidence("selected", outcome)
+	if _, ok := route["delegate"]; ok {
+		analysis.AddNextCheck("The route delegates to another VirtualService; simulate against the delegate to see the final destination")
+	}
+
+	rules, _ := t.meshObjects(ctx, &analysis, req.User, "DestinationRule", "networking.istio.io", "")
+	vsNamespace, _, _ := strings.Cut(selected.VirtualService, "/")
+	for _, dest := range destinations {
+		if dest.Subset == "" || dest.Weight == 0 {
+			continue
+		}
+		host := qualifyHost(dest.Host, vsNamespace)
+		if !definedSubsets(host, rules)[dest.Subset] {
+			analysis.AddCause("Selected route uses undefined subset", fmt.Sprintf("subset %s of %s is not defined by any DestinationRule; requests get 503 (NR)", dest.Subset, host), "high")
+		}
+	}
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+}
+
+// routeCandidate is a VirtualService whose hosts match the request; exact is
+// false when only a wildcard host matched.
+type routeCandidate struct {
+	obj   unstructured.Unstructured
+	exact bool
+}
+
+// routeCandidates returns the VirtualServices bound to the request's gateway
+// whose hosts match it. Exact host matches come before wildcards, then the
+// oldest first, which is the order Istio resolves conflicts in.
+func routeCandidates(services []unstructured.Unstructured, request routeRequest) []routeCandidate {
+	var matched []routeCandidate
+	for _, vs := range services {
+		if !virtualServiceBound(&vs, request.gateway) {
+			continue
+		}
+		exact, ok := false, false
+		for _, host := range nestedStringSlice(&vs, "spec", "hosts") {
+			pattern := qualifyHost(host, vs.GetNamespace())
+			if pattern == request.fqdn || host == request.host {
+				exact, ok = true, true
+				break
+			}
+			if hostMatchesPattern(request.fqdn, pattern) {
+				ok = true
+			}
+		}
+		if ok {
+			matched = append(matched, routeCandidate{obj: vs, exact: exact})
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].exact != matched[j].exact {
+			return matched[i].exact
+		}
+		ti, tj := matched[i].obj.GetCreationTimestamp(), matched[j].obj.GetCreationTimestamp()
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return objectRef(&matched[i].obj) < objectRef(&matched[j].obj)
+	})
+	return matched
+}
+
+// virtualServiceBound reports whether vs applies at gateway; no gateways
+// means the mesh only.
+func virtualServiceBound(vs *unstructured.Unstructured, gateway string) bool {
+	gateways := nestedStringSlice(vs, "spec", "gateways")
+	if len(gateways) == 0 {
+		return gateway == "mesh"
+	}
+	for _, gw := range gateways {
+		if gw == "mesh" && gateway == "mesh" || gw != "mesh" && gatewayKey(gw, vs.GetNamespace()) == gateway {
+			return true
+		}
+	}
+	return false
+}
+
+// simulateHTTPRoutes walks the HTTP routes of services in order and stops at
+// the first route whose match block accepts the request.
+func simulateHTTPRoutes(services []unstructured.Unstructured, request routeRequest) ([]routeEvaluation, *routeEvaluation, map[string]any) {
+	var evaluated []routeEvaluation
+	for i := range services {
+		ref := objectRef(&services[i])
+		routes, _, _ := unstructured.NestedSlice(services[i].Object, "spec", "http")
+		for index, raw := range routes {
+			route, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			entry := routeEvaluation{VirtualService: ref, Index: index, Name: toString(route["name"])}
+			entry.Matched, entry.Mismatched, entry.Unevaluated = evaluateHTTPRoute(route, request, services[i].GetNamespace())
+			evaluated = append(evaluated, entry)
+			if entry.Matched {
+				return evaluated, &evaluated[len(evaluated)-1], route
+			}
+		}
+	}
+	return evaluated, nil, nil
+}
+
+// evaluateHTTPRoute ORs the route's match blocks; conditions within a block
+// are ANDed. A route without match blocks accepts everything. It returns the
+// failing conditions of the closest block when nothing matches.
+func evaluateHTTPRoute(route map[string]any, request routeRequest, namespace string) (bool, []string, []string) {
+	matches, _, _ := unstructured.NestedSlice(route, "match")
+	if len(matches) == 0 {
+		return true, nil, nil
+	}
+	var bestMismatched, bestUnevaluated []string
+	for i, raw := range matches {
+		match, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		mismatched, unevaluated := evaluateHTTPMatch(match, request, namespace)
+		if len(mismatched) == 0 && len(unevaluated) == 0 {
+			return true, nil, nil
+		}
+		if i == 0 || len(mismatched)+len(unevaluated) < len(bestMismatched)+len(bestUnevaluated) {
+			bestMismatched, bestUnevaluated = mismatched, unevaluated
+		}
+	}
+	return false, bestMismatched, bestUnevaluated
+}
+
+// evaluateHTTPMatch checks one HTTPMatchRequest. Conditions that need request
+// data the caller did not supply, or that this simulation does not model,
+// are reported as unevaluated and do not match.
+func evaluateHTTPMatch(match map[string]any, request routeRequest, namespace string) ([]string, []string) {
+	var mismatched, unevaluated []string
+	check := func(field string, ok, evaluable bool) {
+		switch {
+		case !evaluable:
+			unevaluated = append(unevaluated, field)
+		case !ok:
+			mismatched = append(mismatched, field)
+		}
+	}
+	ignoreCase, _ := match["ignoreUriCase"].(bool)
+	if uri, ok := match["uri"].(map[string]any); ok {
+		matched, evaluable := stringMatch(uri, request.path, request.path != "", ignoreCase)
+		check("uri", matched, evaluable)
+	}
+	if method, ok := match["method"].(map[string]any); ok {
+		matched, evaluable := stringMatch(method, request.method, request.method != "", false)
+		check("method", matched, evaluable)
+	}
+	if authority, ok := match["authority"].(map[string]any); ok {
+		matched, evaluable := stringMatch(authority, request.host, true, false)
+		check("authority", matched, evaluable)
+	}
+	if port := argconv.Int(match["port"], 0); port > 0 {
+		check("port", request.port == port, request.port > 0)
+	}
+	if headers, ok := match["headers"].(map[string]any); ok {
+		for name, raw := range headers {
+			condition, _ := raw.(map[string]any)
+			value, present := request.headers[strings.ToLower(name)]
+			matched, evaluable := stringMatch(condition, value, present, false)
+			check("headers."+name, matched, evaluable)
+		}
+	}
+	if headers, ok := match["withoutHeaders"].(map[string]any); ok {
+		for name, raw := range headers {
+			condition, _ := raw.(map[string]any)
+			value, present := request.headers[strings.ToLower(name)]
+			matched, _ := stringMatch(condition, value, present, false)
+			check("withoutHeaders."+name, !matched, true)
+		}
+	}
+	if gateways, _, _ := unstructured.NestedStringSlice(match, "gateways"); len(gateways) > 0 {
+		bound := false
+		for _, gw := range gateways {
+			if gw == request.gateway || gw != "mesh" && gatewayKey(gw, namespace) == request.gateway {
+				bound = true
+			}
+		}
+		check("gateways", bound, true)
+	}
+	for _, field := range []string{"queryParams", "scheme", "sourceLabels", "sourceNamespace"} {
+		if _, ok := match[field]; ok {
+			check(field, false, false)
+		}
+	}
+	sort.Strings(mismatched)
+	sort.Strings(unevaluated)
+	return mismatched, unevaluated
+}
+
+// stringMatch applies an Istio StringMatch (exact, prefix or regex; regexes
+// are RE2 and must match the whole value). An empty StringMatch only
+// requires the value to be present. The second result is false when the
+// value is absent.
+func stringMatch(condition map[string]any, value string, present, ignoreCase bool) (bool, bool) {
+	if !present {
+		return false, false
+	}
+	if ignoreCase {
+		value = strings.ToLower(value)
+	}
+	if exact, ok := condition["exact"].(string); ok {
+		if ignoreCase {
+			exact = strings.ToLower(exact)
+		}
+		return value == exact, true
+	}
+	if prefix, ok := condition["prefix"].(string); ok {
+		if ignoreCase {
+			prefix = strings.ToLower(prefix)
+		}
+		return strings.HasPrefix(value, prefix), true
+	}
+	if pattern, ok := condition["regex"].(string); ok {
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		return err == nil && re.MatchString(value), true
+	}
+	return true, true
+}
+
+// httpRouteDestinations lists the weighted destinations of an HTTP route. A
+// lone destination without a weight takes all traffic.
+func httpRouteDestinations(route map[string]any) []routeDestination {
+	raw, _, _ := unstructured.NestedSlice(route, "route")
+	var out []routeDestination
+	for _, item := range raw {
+		entry, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		dest, _ := entry["destination"].(map[string]any)
+		port, _, _ := unstructured.NestedInt64(dest, "port", "number")
+		weight, _, _ := unstructured.NestedInt64(entry, "weight")
+		out = append(out, routeDestination{Host: toString(dest["host"]), Subset: toString(dest["subset"]), Port: port, Weight: weight})
+	}
+	if len(out) == 1 && out[0].Weight == 0 {
+		out[0].Weight = 100
+	}
+	return out
+}
+
+func hasUnevaluated(evaluated []routeEvaluation) bool {
+	for _, entry := range evaluated {
+		if len(entry.Unevaluated) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func objectRef(obj *unstructured.Unstructured) string {
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
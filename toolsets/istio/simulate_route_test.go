@@ -0,0 +1,137 @@
+package istio
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+func routeEvidence(t *testing.T, result mcp.ToolResult) (map[string]any, []routeEvaluation, map[string]bool) {
+	t.Helper()
+	data := result.Data.(map[string]any)
+	var selected map[string]any
+	var evaluated []routeEvaluation
+	for _, item := range data["evidence"].([]render.EvidenceItem) {
+		switch item.Summary {
+		case "selected":
+			selected = item.Details.(map[string]any)
+		case "evaluated":
+			evaluated = item.Details.([]routeEvaluation)
+		}
+	}
+	causes := map[string]bool{}
+	for _, cause := range data["likelyRootCauses"].([]render.Cause) {
+		causes[cause.Summary] = true
+	}
+	return selected, evaluated, causes
+}
+
+func TestSimulateRoute(t *testing.T) {
+	reviews := meshObject("networking.istio.io/v1", "VirtualService", "shop", "reviews", map[string]any{
+		"hosts": []any{"reviews"},
+		"http": []any{
+			map[string]any{
+				"name":  "jason",
+				"match": []any{map[string]any{"headers": map[string]any{"end-user": map[string]any{"exact": "jason"}}}},
+				"route": []any{map[string]any{"destination": map[string]any{"host": "reviews", "subset": "v2"}}},
+			},
+			map[string]any{
+				"name": "api",
+				"match": []any{
+					map[string]any{"uri": map[string]any{"regex": "/api/v[0-9]+/.*"}, "method": map[string]any{"exact": "POST"}},
+					map[string]any{"uri": map[string]any{"exact": "/legacy"}},
+				},
+				"route": []any{
+					map[string]any{"destination": map[string]any{"host": "reviews", "subset": "v1"}, "weight": int64(90)},
+					map[string]any{"destination": map[string]any{"host": "reviews", "subset": "v3"}, "weight": int64(10)},
+				},
+			},
+			map[string]any{
+				"name":  "static",
+				"match": []any{map[string]any{"uri": map[string]any{"prefix": "/static"}, "ignoreUriCase": true}},
+				"route": []any{map[string]any{"destination": map[string]any{"host": "reviews", "subset": "v1", "port": map[string]any{"number": int64(9080)}}}},
+			},
+		},
+	})
+	rule := meshObject("networking.istio.io/v1", "DestinationRule", "shop", "reviews", map[string]any{
+		"host": "reviews",
+		"subsets": []any{
+			map[string]any{"name": "v1", "labels": map[string]any{"version": "v1"}},
+			map[string]any{"name": "v2", "labels": map[string]any{"version": "v2"}},
+		},
+	})
+	ingress := meshObject("networking.istio.io/v1", "VirtualService", "shop", "bookinfo", map[string]any{
+		"hosts":    []any{"bookinfo.example.com"},
+		"gateways": []any{"istio-system/public"},
+		"http": []any{map[string]any{
+			"match": []any{map[string]any{"uri": map[string]any{"prefix": "/productpage"}}},
+			"route": []any{map[string]any{"destination": map[string]any{"host": "productpage"}}},
+		}},
+	})
+	toolset := newMeshConfigToolset(t, nil, reviews, rule, ingress)
+	call := func(args map[string]any) mcp.ToolResult {
+		t.Helper()
+		result, err := toolset.handleSimulateRoute(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: args})
+		if err != nil {
+			t.Fatalf("simulate route %v: %v", args, err)
+		}
+		return result
+	}
+
+	selected, evaluated, _ := routeEvidence(t, call(map[string]any{"namespace": "shop", "host": "reviews", "path": "/", "headers": map[string]any{"End-User": "jason"}}))
+	if selected["name"] != "jason" || len(evaluated) != 1 {
+		t.Fatalf("expected header route, got %#v after %+v", selected, evaluated)
+	}
+
+	selected, evaluated, causes := routeEvidence(t, call(map[string]any{"namespace": "web", "host": "reviews.shop:9080", "path": "/api/v2/items", "method": "post"}))
+	destinations, _ := selected["destinations"].([]routeDestination)
+	if selected["name"] != "api" || len(destinations) != 2 || destinations[0].Weight != 90 || destinations[1].Subset != "v3" {
+		t.Fatalf("expected weighted api route, got %#v", selected)
+	}
+	if got := evaluated[0]; got.Matched || len(got.Unevaluated) != 1 || got.Unevaluated[0] != "headers.end-user" {
+		t.Fatalf("expected the header route to be unevaluated, got %+v", got)
+	}
+	if !causes["Selected route uses undefined subset"] {
+		t.Fatalf("expected undefined subset v3 to be reported, got %v", causes)
+	}
+
+	selected, _, _ = routeEvidence(t, call(map[string]any{"namespace": "shop", "host": "reviews", "path": "/STATIC/app.js", "method": "GET"}))
+	if destinations := selected["destinations"].([]routeDestination); selected["name"] != "static" || destinations[0].Weight != 100 || destinations[0].Port != 9080 {
+		t.Fatalf("expected case-insensitive static route, got %#v", selected)
+	}
+
+	selected, evaluated, causes = routeEvidence(t, call(map[string]any{"namespace": "shop", "host": "reviews", "path": "/api/v2/items", "method": "GET"}))
+	if selected != nil || !causes["Request matches no route"] || len(evaluated) != 3 {
+		t.Fatalf("expected fallthrough, got %#v %v", selected, causes)
+	}
+	if got := evaluated[1]; len(got.Mismatched) != 1 || got.Mismatched[0] != "method" {
+		t.Fatalf("expected the closest api match to fail on method only, got %+v", got)
+	}
+
+	selected, _, _ = routeEvidence(t, call(map[string]any{"host": "bookinfo.example.com", "path": "/productpage", "gateway": "public"}))
+	if selected["virtualService"] != "shop/bookinfo" {
+		t.Fatalf("expected gateway route, got %#v", selected)
+	}
+	_, _, causes = routeEvidence(t, call(map[string]any{"host": "bookinfo.example.com", "path": "/productpage"}))
+	if len(causes) != 0 {
+		t.Fatalf("gateway-only VirtualService should not apply to mesh traffic, got %v", causes)
+	}
+
+	if _, err := toolset.handleSimulateRoute(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: map[string]any{"host": "reviews"}}); err == nil {
+		t.Fatalf("expected error for short host without namespace")
+	}
+}
+
+func TestRouteCandidatesPreferExactHost(t *testing.T) {
+	wildcard := meshObject("networking.istio.io/v1", "VirtualService", "shop", "all", map[string]any{"hosts": []any{"*.shop.svc.cluster.local"}})
+	exact := meshObject("networking.istio.io/v1", "VirtualService", "shop", "reviews", map[string]any{"hosts": []any{"reviews"}})
+	candidates := routeCandidates([]unstructured.Unstructured{*wildcard, *exact}, routeRequest{host: "reviews", fqdn: "reviews.shop.svc.cluster.local", gateway: "mesh"})
+	if len(candidates) != 2 || candidates[0].obj.GetName() != "reviews" || candidates[1].exact {
+		t.Fatalf("expected the exact host first, got %+v", candidates)
+	}
+}
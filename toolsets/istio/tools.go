@@ -18,6 +18,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"golang.org/x/sync/errgroup"
+
+	"rootcause/internal/argconv"
 	"rootcause/internal/evidence"
 	"rootcause/internal/kube"
 	"rootcause/internal/mcp"
@@ -366,51 +369,86 @@ func (t *Toolset) handlePodsByService(ctx context.Context, req mcp.ToolRequest)
 		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
 	}
 	analysis := render.NewAnalysis()
+	backends, err := t.serviceBackends(ctx, namespace, service)
+	if err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	if backends.service == nil {
+		analysis.AddEvidence("status", "service not found")
+		analysis.AddNextCheck("Verify service name and namespace")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
+	}
+	t.addServiceBackends(&analysis, backends)
+	if len(backends.service.Spec.Selector) == 0 {
+		analysis.AddEvidence("status", "service has no selector")
+		analysis.AddNextCheck("Check Endpoints or EndpointSlice for manual backends")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
+	}
+	if len(backends.pods) == 0 {
+		analysis.AddEvidence("status", "no pods matched service selector")
+		analysis.AddNextCheck("Verify service selector labels")
+	}
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+}
+
+// serviceBackendSet is a Service with the pods its selector matches and its
+// Endpoints. service is nil when the Service does not exist.
+type serviceBackendSet struct {
+	service   *corev1.Service
+	pods      []corev1.Pod
+	endpoints *corev1.Endpoints
+}
+
+func (t *Toolset) serviceBackends(ctx context.Context, namespace, service string) (serviceBackendSet, error) {
+	var out serviceBackendSet
 	svc, err := t.ctx.Clients.Typed.CoreV1().Services(namespace).Get(ctx, service, metav1.GetOptions{})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			analysis.AddEvidence("status", "service not found")
-			analysis.AddNextCheck("Verify service name and namespace")
-			return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
+			return out, nil
 		}
-		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+		return out, err
+	}
+	out.service = svc
+	if len(svc.Spec.Selector) > 0 {
+		pods, err := t.ctx.Evidence.RelatedPods(ctx, namespace, labels.SelectorFromSet(svc.Spec.Selector))
+		if err != nil {
+			return out, err
+		}
+		out.pods = pods
+	}
+	if endpoints, err := t.ctx.Evidence.EndpointsForService(ctx, namespace, service); err == nil {
+		out.endpoints = endpoints
 	}
-	analysis.AddResource(fmt.Sprintf("services/%s/%s", namespace, service))
+	return out, nil
+}
+
+// addServiceBackends records the service, pod and endpoint evidence shared by
+// pods_by_service and diagnose_503.
+func (t *Toolset) addServiceBackends(analysis *render.Analysis, backends serviceBackendSet) {
+	svc := backends.service
+	analysis.AddResource(fmt.Sprintf("services/%s/%s", svc.Namespace, svc.Name))
 	analysis.AddEvidence("service", map[string]any{
 		"selector": svc.Spec.Selector,
 		"ports":    svc.Spec.Ports,
 		"type":     svc.Spec.Type,
 	})
 	if len(svc.Spec.Selector) == 0 {
-		analysis.AddEvidence("status", "service has no selector")
-		analysis.AddNextCheck("Check Endpoints or EndpointSlice for manual backends")
-		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
-	}
-	selector := labels.SelectorFromSet(svc.Spec.Selector)
-	pods, err := t.ctx.Clients.Typed.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
-	if err != nil {
-		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+		return
 	}
 	var podSummaries []map[string]any
-	for i := range pods.Items {
-		pod := &pods.Items[i]
+	for i := range backends.pods {
+		pod := &backends.pods[i]
 		summary := t.ctx.Evidence.PodStatusSummary(pod)
 		summary["name"] = pod.Name
 		summary["node"] = pod.Spec.NodeName
 		summary["istioProxy"] = hasIstioProxy(pod)
 		podSummaries = append(podSummaries, summary)
-		analysis.AddResource(fmt.Sprintf("pods/%s/%s", namespace, pod.Name))
+		analysis.AddResource(fmt.Sprintf("pods/%s/%s", svc.Namespace, pod.Name))
 	}
 	analysis.AddEvidence("pods", podSummaries)
-	endpoints, err := t.ctx.Evidence.EndpointsForService(ctx, namespace, service)
-	if err == nil && endpoints != nil {
-		analysis.AddEvidence("endpoints", endpoints.Subsets)
-	}
-	if len(podSummaries) == 0 {
-		analysis.AddEvidence("status", "no pods matched service selector")
-		analysis.AddNextCheck("Verify service selector labels")
+	if backends.endpoints != nil {
+		analysis.AddEvidence("endpoints", backends.endpoints.Subsets)
 	}
-	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
 }
 
 func (t *Toolset) handleExternalDependencyCheck(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
@@ -439,10 +477,11 @@ func (t *Toolset) handleExternalDependencyCheck(ctx context.Context, req mcp.Too
 		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
 	}
 	internalHosts := buildServiceHostSet(services)
-	serviceEntryHosts, err := t.collectServiceEntryHosts(ctx, req, namespace)
+	serviceEntries, err := t.collectServiceEntries(ctx, req, namespace)
 	if err != nil {
 		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
 	}
+	entryHosts := serviceEntryHosts(serviceEntries)
 
 	var externalHosts []externalHostRecord
 	missingHosts := map[string]struct{}{}
@@ -467,7 +506,7 @@ func (t *Toolset) handleExternalDependencyCheck(ctx context.Context, req mcp.Too
 				if _, ok := internalHosts[host]; ok {
 					continue
 				}
-				hasEntry := matchServiceEntry(host, serviceEntryHosts)
+				hasEntry := matchServiceEntry(host, entryHosts)
 				externalHosts = append(externalHosts, externalHostRecord{
 					Host:         host,
 					SourceKind:   kind,
@@ -506,14 +545,37 @@ func (t *Toolset) handleExternalDependencyCheck(ctx context.Context, req mcp.Too
 	} else {
 		analysis.AddEvidence("externalHosts", t.ctx.Redactor.RedactValue(externalHosts))
 	}
+	matrix := serviceEntryMatrix(externalHosts, serviceEntries)
+	if len(matrix) > 0 {
+		analysis.AddEvidence("serviceEntryMatrix", t.ctx.Redactor.RedactValue(matrix))
+		for _, row := range matrix {
+			for _, issue := range row.Issues {
+				analysis.AddCause("ServiceEntry egress misconfigured", fmt.Sprintf("%s (%s): %s", row.ServiceEntry, row.Host, issue), "medium")
+			}
+		}
+	}
 	if len(missingHosts) > 0 {
-		missing := make([]string, 0, len(missingHosts))
+		var missing, unresolved []string
 		for host := range missingHosts {
-			missing = append(missing, host)
+			if looksClusterLocal(host) {
+				unresolved = append(unresolved, host)
+			} else {
+				missing = append(missing, host)
+			}
 		}
 		sort.Strings(missing)
-		analysis.AddCause("External host missing ServiceEntry", fmt.Sprintf("%d host(s) missing ServiceEntry: %s", len(missing), strings.Join(missing, ", ")), "medium")
-		analysis.AddNextCheck("Define ServiceEntry resources for external hosts")
+		sort.Strings(unresolved)
+		if len(missing) > 0 {
+			analysis.AddCauseWithConfidence("External host missing ServiceEntry", fmt.Sprintf("%d host(s) missing ServiceEntry: %s", len(missing), strings.Join(missing, ", ")), "medium", render.ConfidenceHigh)
+			analysis.AddNextCheck("Define ServiceEntry resources for external hosts")
+		}
+		// A short or cluster-local name that matches no visible Service is
+		// only a guess at an external dependency: it may be a Service in a
+		// namespace this caller cannot list, or a typo.
+		if len(unresolved) > 0 {
+			analysis.AddCauseWithConfidence("Host matches no Service or ServiceEntry", fmt.Sprintf("%d cluster-local host(s) did not resolve: %s", len(unresolved), strings.Join(unresolved, ", ")), "medium", render.ConfidenceLow)
+			analysis.AddNextCheck("Check the unresolved hosts for typos or Services in other namespaces")
+		}
 	} else {
 		analysis.AddNextCheck("Verify external egress policies and DNS resolution")
 	}
@@ -569,17 +631,39 @@ func (t *Toolset) handleProxyAdmin(ctx context.Context, req mcp.ToolRequest, pat
 		analysis.AddNextCheck("Choose a pod with an injected istio-proxy sidecar")
 		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
 	}
-	adminPort := toInt(req.Arguments["adminPort"], 15000)
+	adminPort := argconv.Int(req.Arguments["adminPort"], 15000)
 	format := toString(req.Arguments["format"])
 	if format == "" && path != "config_dump" {
 		format = "json"
 	}
-	raw, err := t.proxyAdminRequest(ctx, namespace, podName, adminPort, path, format)
+	var filter configDumpFilter
+	if path == "config_dump" {
+		filter, err = configDumpFilterFromArgs(req.Arguments)
+		if err != nil {
+			return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+		}
+	}
+	raw, err := t.proxyAdminRequest(ctx, namespace, podName, adminPort, path, format, filter.adminParams())
 	if err != nil {
 		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
 	}
 	analysis.AddResource(fmt.Sprintf("pods/%s/%s", namespace, podName))
-	analysis.AddEvidence("proxyData", t.ctx.Redactor.RedactValue(parseProxyPayload(raw)))
+	payload := parseProxyPayload(raw)
+	if filter.active() {
+		filtered := filterConfigDump(payload, filter)
+		filtered["rawBytes"] = len(raw)
+		if filtered["count"] == 0 {
+			analysis.AddNextCheck("No config_dump entries matched; retry with raw=true or a shorter resourceName")
+		}
+		if filtered["truncated"] == true {
+			analysis.AddNextCheck(fmt.Sprintf("More entries matched; call again with offset=%v for the next page", filtered["nextOffset"]))
+		}
+		payload = filtered
+	} else if path == "config_dump" && len(raw) > filter.maxBytes {
+		payload = summarizeConfigDump(payload, len(raw), filter.maxBytes)
+		analysis.AddNextCheck("The config_dump was too large to return; pass type, resourceName or resource to fetch part of it")
+	}
+	analysis.AddEvidence("proxyData", t.ctx.Redactor.RedactValue(payload))
 	analysis.AddNextCheck("Compare proxy config with expected routes and clusters")
 	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
 }
@@ -593,6 +677,9 @@ func (t *Toolset) handleCRStatus(ctx context.Context, req mcp.ToolRequest) (mcp.
 	name := toString(args["name"])
 	namespace := toString(args["namespace"])
 	selector := toString(args["labelSelector"])
+	if refresh, _ := args["refreshDiscovery"].(bool); refresh {
+		t.ctx.Clients.InvalidateDiscovery()
+	}
 
 	analysis := render.NewAnalysis()
 	generalFetch := isGatewayAPIKind(kind, resource)
@@ -635,6 +722,9 @@ func (t *Toolset) handleCRStatus(ctx context.Context, req mcp.ToolRequest) (mcp.
 		} else {
 			analysis.AddEvidence(fmt.Sprintf("%s status", ref), t.ctx.Redactor.RedactMap(status))
 		}
+		if gvr.Group == evidence.GatewayAPIGroup {
+			t.addGatewayConditions(&analysis, ref, obj)
+		}
 		describe := render.DescribeAnalysis(ctx, t.ctx.Evidence, t.ctx.Redactor, gvr, obj)
 		analysis.AddEvidence(fmt.Sprintf("%s describe", ref), t.ctx.Renderer.Render(describe))
 	}
@@ -795,6 +885,26 @@ func (t *Toolset) handleHTTPRouteStatus(ctx context.Context, req mcp.ToolRequest
 	return t.handleKindStatus(ctx, req, "HTTPRoute")
 }
 
+// addGatewayConditions adds the pass/fail reading of a Gateway's listeners or
+// a route's parents, and a cause for each one that is not healthy, so the
+// failing condition and its message stand out from the raw status.
+func (t *Toolset) addGatewayConditions(analysis *render.Analysis, ref string, obj *unstructured.Unstructured) {
+	reports := evidence.GatewayAPIConditions(obj)
+	if len(reports) == 0 {
+		return
+	}
+	for i := range reports {
+		reports[i].Summary = t.ctx.Redactor.RedactString(reports[i].Summary)
+		for j, failure := range reports[i].Failures {
+			reports[i].Failures[j] = t.ctx.Redactor.RedactString(failure)
+		}
+		if !reports[i].OK {
+			analysis.AddCause(reports[i].Summary, ref, "high")
+		}
+	}
+	analysis.AddEvidence(fmt.Sprintf("%s conditions", ref), reports)
+}
+
 func (t *Toolset) handleKindStatus(ctx context.Context, req mcp.ToolRequest, kind string) (mcp.ToolResult, error) {
 	args := map[string]any{
 		"kind": kind,
@@ -847,17 +957,19 @@ func (t *Toolset) listObjects(ctx context.Context, user policy.User, gvr schema.
 			}
 			return list.Items, nil, nil
 		}
-		var items []unstructured.Unstructured
 		namespaces := append([]string{}, user.AllowedNamespaces...)
-		for _, ns := range namespaces {
+		items, err := listPerNamespace(ctx, namespaces, func(ctx context.Context, ns string) ([]unstructured.Unstructured, error) {
 			if err := t.ctx.Policy.CheckNamespace(user, ns, true); err != nil {
-				return nil, nil, err
+				return nil, err
 			}
 			list, err := t.ctx.Clients.Dynamic.Resource(gvr).Namespace(ns).List(ctx, opts)
 			if err != nil {
-				return nil, nil, err
+				return nil, err
 			}
-			items = append(items, list.Items...)
+			return list.Items, nil
+		})
+		if err != nil {
+			return nil, nil, err
 		}
 		return items, namespaces, nil
 	}
@@ -889,21 +1001,54 @@ func (t *Toolset) listServices(ctx context.Context, user policy.User, namespace
 		}
 		return list.Items, nil, nil
 	}
-	var services []corev1.Service
 	namespaces := append([]string{}, user.AllowedNamespaces...)
-	for _, ns := range namespaces {
+	services, err := listPerNamespace(ctx, namespaces, func(ctx context.Context, ns string) ([]corev1.Service, error) {
 		if err := t.ctx.Policy.CheckNamespace(user, ns, true); err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		list, err := t.ctx.Clients.Typed.CoreV1().Services(ns).List(ctx, metav1.ListOptions{})
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
-		services = append(services, list.Items...)
+		return list.Items, nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 	return services, namespaces, nil
 }
 
+// namespaceListConcurrency bounds the per-namespace List calls issued for
+// namespace-scoped users so large allowlists don't flood the API server.
+const namespaceListConcurrency = 8
+
+// listPerNamespace runs list for each namespace with bounded concurrency and
+// concatenates the results in namespace order, so output stays deterministic.
+// The first error cancels the remaining calls and is returned.
+func listPerNamespace[T any](ctx context.Context, namespaces []string, list func(context.Context, string) ([]T, error)) ([]T, error) {
+	results := make([][]T, len(namespaces))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(namespaceListConcurrency)
+	for i, ns := range namespaces {
+		group.Go(func() error {
+			items, err := list(groupCtx, ns)
+			if err != nil {
+				return err
+			}
+			results[i] = items
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	var out []T
+	for _, items := range results {
+		out = append(out, items...)
+	}
+	return out, nil
+}
+
 func buildServiceHostSet(services []corev1.Service) map[string]struct{} {
 	hosts := map[string]struct{}{}
 	for _, svc := range services {
@@ -923,7 +1068,7 @@ func buildServiceHostSet(services []corev1.Service) map[string]struct{} {
 	return hosts
 }
 
-func (t *Toolset) collectServiceEntryHosts(ctx context.Context, req mcp.ToolRequest, namespace string) ([]string, error) {
+func (t *Toolset) collectServiceEntries(ctx context.Context, req mcp.ToolRequest, namespace string) ([]unstructured.Unstructured, error) {
 	gvr, namespaced, err := kube.ResolveResourceBestEffort(t.ctx.Clients.Mapper, t.ctx.Clients.Discovery, "", "ServiceEntry", "", "networking.istio.io")
 	if err != nil {
 		return nil, nil
@@ -932,11 +1077,15 @@ func (t *Toolset) collectServiceEntryHosts(ctx context.Context, req mcp.ToolRequ
 	if err != nil {
 		return nil, err
 	}
+	return items, nil
+}
+
+func serviceEntryHosts(entries []unstructured.Unstructured) []string {
 	var hosts []string
-	for i := range items {
-		hosts = append(hosts, nestedStringSlice(&items[i], "spec", "hosts")...)
+	for i := range entries {
+		hosts = append(hosts, nestedStringSlice(&entries[i], "spec", "hosts")...)
 	}
-	return hosts, nil
+	return hosts
 }
 
 func matchServiceEntry(host string, patterns []string) bool {
@@ -982,11 +1131,19 @@ func gatewayServerHosts(obj *unstructured.Unstructured) []string {
 	return hosts
 }
 
-func (t *Toolset) proxyAdminRequest(ctx context.Context, namespace, pod string, port int, path, format string) ([]byte, error) {
+// proxyAdminRequest calls the Envoy admin API through the pods/proxy
+// subresource. query holds extra config_dump parameters and is ignored for
+// other paths.
+func (t *Toolset) proxyAdminRequest(ctx context.Context, namespace, pod string, port int, path, format string, query map[string]string) ([]byte, error) {
 	params := map[string]string{}
 	if format != "" && path != "config_dump" {
 		params["format"] = format
 	}
+	if path == "config_dump" {
+		for key, value := range query {
+			params[key] = value
+		}
+	}
 	return t.ctx.Clients.Typed.CoreV1().Pods(namespace).ProxyGet("http", pod, strconv.Itoa(port), path, params).DoRaw(ctx)
 }
 
@@ -1028,6 +1185,10 @@ func inferGroupForKindResource(kind, resource string) string {
 		return "gateway.networking.k8s.io"
 	case kindKey == "gateway" || resourceKey == "gateway" || resourceKey == "gateways":
 		return "gateway.networking.k8s.io"
+	case kindKey == "grpcroute" || resourceKey == "grpcroute" || resourceKey == "grpcroutes":
+		return "gateway.networking.k8s.io"
+	case kindKey == "tlsroute" || resourceKey == "tlsroute" || resourceKey == "tlsroutes":
+		return "gateway.networking.k8s.io"
 	case kindKey == "virtualservice" || resourceKey == "virtualservice" || resourceKey == "virtualservices":
 		return "networking.istio.io"
 	case kindKey == "destinationrule" || resourceKey == "destinationrule" || resourceKey == "destinationrules":
@@ -1063,6 +1224,17 @@ func (t *Toolset) allowedNamespaces(ctx context.Context, user policy.User, names
 	return append([]string{}, user.AllowedNamespaces...), nil
 }
 
+// looksClusterLocal reports whether host is written as an in-cluster name
+// (a bare short name, or *.svc / *.cluster.local) rather than an external
+// DNS name. name.namespace is indistinguishable from a domain and is treated
+// as external.
+func looksClusterLocal(host string) bool {
+	if strings.HasSuffix(host, ".svc") || strings.HasSuffix(host, ".cluster.local") {
+		return true
+	}
+	return host != "*" && !strings.Contains(host, ".")
+}
+
 func hasIstioProxy(pod *corev1.Pod) bool {
 	for _, container := range pod.Spec.Containers {
 		if container.Name == "istio-proxy" {
@@ -1091,22 +1263,6 @@ func toString(value any) string {
 	return fmt.Sprintf("%v", value)
 }
 
-func toInt(value any, fallback int) int {
-	switch v := value.(type) {
-	case int:
-		return v
-	case int64:
-		return int(v)
-	case float64:
-		return int(v)
-	case string:
-		if parsed, err := strconv.Atoi(v); err == nil {
-			return parsed
-		}
-	}
-	return fallback
-}
-
 func sliceIf(value string) []string {
 	if value == "" {
 		return nil
@@ -46,8 +46,9 @@ func (r staticResponse) Stream(context.Context) (io.ReadCloser, error) {
 }
 
 type istioDiscoveryResources struct {
-	resources []*metav1.APIResourceList
-	groups    *metav1.APIGroupList
+	resources     []*metav1.APIResourceList
+	groups        *metav1.APIGroupList
+	invalidations int
 }
 
 func (d *istioDiscoveryResources) ServerGroups() (*metav1.APIGroupList, error) {
@@ -100,7 +101,7 @@ func (d *istioDiscoveryResources) Fresh() bool {
 	return true
 }
 
-func (d *istioDiscoveryResources) Invalidate() {}
+func (d *istioDiscoveryResources) Invalidate() { d.invalidations++ }
 
 func (d *istioDiscoveryResources) WithLegacy() discovery.DiscoveryInterface {
 	return d
@@ -242,6 +243,15 @@ func newIstioToolset(t *testing.T) *Toolset {
 		"spec": map[string]any{
 			"hostnames": []any{"route.example.com"},
 		},
+		"status": map[string]any{
+			"parents": []any{map[string]any{
+				"parentRef": map[string]any{"name": "gw-api"},
+				"conditions": []any{
+					map[string]any{"type": "Accepted", "status": "True"},
+					map[string]any{"type": "ResolvedRefs", "status": "False", "reason": "BackendNotFound", "message": "backend not found"},
+				},
+			}},
+		},
 	}}
 	gvrVS := schema.GroupVersionResource{Group: "networking.istio.io", Version: "v1beta1", Resource: "virtualservices"}
 	gvrDR := schema.GroupVersionResource{Group: "networking.istio.io", Version: "v1beta1", Resource: "destinationrules"}
@@ -361,12 +371,17 @@ func TestIstioHandlersWithResources(t *testing.T) {
 	}); err != nil {
 		t.Fatalf("handleGatewayStatus: %v", err)
 	}
-	if _, err := toolset.handleHTTPRouteStatus(ctx, mcp.ToolRequest{
+	routeResult, err := toolset.handleHTTPRouteStatus(ctx, mcp.ToolRequest{
 		User:      user,
 		Arguments: map[string]any{"namespace": "default", "name": "route-api"},
-	}); err != nil {
+	})
+	if err != nil {
 		t.Fatalf("handleHTTPRouteStatus: %v", err)
 	}
+	causes, _ := routeResult.Data.(map[string]any)["likelyRootCauses"].([]render.Cause)
+	if len(causes) != 1 || causes[0].Summary != "httproute route-api on parent default/gw-api has ResolvedRefs=False: backend not found" {
+		t.Fatalf("expected ResolvedRefs cause, got %#v", causes)
+	}
 	if toolset.Version() == "" {
 		t.Fatalf("expected toolset version")
 	}
@@ -464,6 +479,25 @@ func TestIstioListHelpers(t *testing.T) {
 	}
 }
 
+func TestIstioCRStatusRefreshDiscovery(t *testing.T) {
+	toolset := newIstioToolset(t)
+	discoveryClient := toolset.ctx.Clients.Discovery.(*istioDiscoveryResources)
+	args := map[string]any{"kind": "VirtualService", "namespace": "default"}
+	if _, err := toolset.handleCRStatus(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: args}); err != nil {
+		t.Fatalf("handleCRStatus: %v", err)
+	}
+	if discoveryClient.invalidations != 0 {
+		t.Fatalf("expected no invalidation without refreshDiscovery")
+	}
+	args["refreshDiscovery"] = true
+	if _, err := toolset.handleCRStatus(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: args}); err != nil {
+		t.Fatalf("handleCRStatus refresh: %v", err)
+	}
+	if discoveryClient.invalidations != 1 {
+		t.Fatalf("expected refreshDiscovery to invalidate discovery once, got %d", discoveryClient.invalidations)
+	}
+}
+
 func TestIstioCRStatusNameWithoutNamespace(t *testing.T) {
 	toolset := newIstioToolset(t)
 	_, err := toolset.handleCRStatus(context.Background(), mcp.ToolRequest{
@@ -515,26 +549,11 @@ func TestIstioProxyAdminNotFoundAndNoProxy(t *testing.T) {
 	}
 }
 
-func TestIstioInitAndToInt(t *testing.T) {
+func TestIstioInit(t *testing.T) {
 	toolset := New()
 	if err := toolset.Init(mcp.ToolContext{}); err == nil {
 		t.Fatalf("expected init error without clients")
 	}
-	if toInt("15000", 15000) != 15000 {
-		t.Fatalf("expected string toInt fallback")
-	}
-	if toInt(float64(9000), 15000) != 9000 {
-		t.Fatalf("expected float toInt")
-	}
-	if toInt(int64(7000), 15000) != 7000 {
-		t.Fatalf("expected int64 toInt")
-	}
-	if toInt(3000, 15000) != 3000 {
-		t.Fatalf("expected int toInt")
-	}
-	if toInt("bad", 15) != 15 {
-		t.Fatalf("expected toInt fallback on parse error")
-	}
 }
 
 func TestHandlePodsByServiceBranches(t *testing.T) {
@@ -789,6 +808,14 @@ func TestIstioHelperFunctions(t *testing.T) {
 	if !isGatewayAPIKind("HTTPRoute", "") {
 		t.Fatalf("expected gateway API kind")
 	}
+	for _, kind := range []string{"GRPCRoute", "TLSRoute"} {
+		if !isGatewayAPIKind(kind, "") {
+			t.Fatalf("expected %s to be a gateway API kind", kind)
+		}
+	}
+	if !isGatewayAPIKind("", "tlsroutes") {
+		t.Fatalf("expected tlsroutes to be a gateway API resource")
+	}
 }
 
 func TestHandleConfigSummaryNotDetected(t *testing.T) {
@@ -1,8 +1,10 @@
 package istio
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"rootcause/internal/mcp"
 )
@@ -37,6 +39,18 @@ func (t *Toolset) Init(ctx mcp.ToolContext) error {
 	return nil
 }
 
+// Preflight confirms the Istio CRDs or control plane are present.
+func (t *Toolset) Preflight(ctx context.Context) (string, error) {
+	detected, groups, err := t.detectIstio(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !detected {
+		return "", fmt.Errorf("istio not detected (no %s API groups or control plane)", strings.Join(istioGroups, ", "))
+	}
+	return fmt.Sprintf("istio detected (groups: %s)", strings.Join(groups, ", ")), nil
+}
+
 func (t *Toolset) Register(reg mcp.Registry) error {
 	tools := []mcp.ToolSpec{
 		{
@@ -55,6 +69,14 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     t.handleConfigSummary,
 		},
+		{
+			Name:        "istio.analyze_config",
+			Description: "Statically check Istio config for common misconfigurations (istioctl analyze equivalent).",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaAnalyzeConfig(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleAnalyzeConfig,
+		},
 		{
 			Name:        "istio.proxy_status",
 			Description: "Check Istio proxy sidecar readiness across pods.",
@@ -63,6 +85,22 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     t.handleProxyStatus,
 		},
+		{
+			Name:        "istio.sidecar_resources",
+			Description: "Audit istio-proxy CPU/memory requests and limits across pods and replicas.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaSidecarResources(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleSidecarResources,
+		},
+		{
+			Name:        "istio.check_injection",
+			Description: "Explain whether a Deployment/StatefulSet gets an istio-proxy sidecar and whether its running pods have one.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaCheckInjection(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleCheckInjection,
+		},
 		{
 			Name:        "istio.service_mesh_hosts",
 			Description: "List service mesh hosts referenced by Istio routing resources.",
@@ -87,6 +125,14 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     t.handlePodsByService,
 		},
+		{
+			Name:        "istio.diagnose_503",
+			Description: "Run the 503 checklist for a service: ready endpoints, DestinationRule subsets, mTLS mode and proxy sync.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaDiagnose503(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleDiagnose503,
+		},
 		{
 			Name:        "istio.external_dependency_check",
 			Description: "Check external dependencies referenced by Istio resources.",
@@ -95,6 +141,30 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     t.handleExternalDependencyCheck,
 		},
+		{
+			Name:        "istio.analyze_envoyfilters",
+			Description: "Resolve EnvoyFilter scope to affected proxies and flag overlapping patches.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaAnalyzeEnvoyFilters(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleAnalyzeEnvoyFilters,
+		},
+		{
+			Name:        "istio.evaluate_authz",
+			Description: "Evaluate AuthorizationPolicies for a simulated request to a workload and report the deciding policy.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaEvaluateAuthz(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleEvaluateAuthz,
+		},
+		{
+			Name:        "istio.simulate_route",
+			Description: "Simulate a request against VirtualService HTTP routes and report the matched route and destinations, or why nothing matched.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaSimulateRoute(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleSimulateRoute,
+		},
 		{
 			Name:        "istio.proxy_clusters",
 			Description: "Fetch Envoy proxy cluster configuration (pods/proxy).",
@@ -137,9 +207,9 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 		},
 		{
 			Name:        "istio.proxy_config_dump",
-			Description: "Fetch full Envoy proxy config dump (pods/proxy).",
+			Description: "Fetch Envoy proxy config dump (pods/proxy), optionally filtered to one listener/cluster/route/endpoint.",
 			ToolsetID:   t.ID(),
-			InputSchema: schemaProxyConfig(),
+			InputSchema: schemaProxyConfigDump(),
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     t.handleProxyConfigDump,
 		},
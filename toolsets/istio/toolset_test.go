@@ -53,9 +53,6 @@ func TestIstioHelpers(t *testing.T) {
 	if got := toString(nil); got != "" {
 		t.Fatalf("expected empty string")
 	}
-	if got := toInt("5", 1); got != 5 {
-		t.Fatalf("unexpected toInt: %d", got)
-	}
 	if got := sliceIf(""); got != nil {
 		t.Fatalf("expected nil slice")
 	}
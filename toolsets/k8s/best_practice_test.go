@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 
+	"rootcause/internal/argconv"
 	"rootcause/internal/config"
 	"rootcause/internal/kube"
 	"rootcause/internal/mcp"
@@ -45,7 +46,7 @@ func TestHandleBestPracticeDeployment(t *testing.T) {
 	if compliant, ok := root["compliant"].(bool); !ok || compliant {
 		t.Fatalf("expected non-compliant deployment result")
 	}
-	if score := toInt(root["score"], 0); score >= 100 {
+	if score := argconv.Int(root["score"], 0); score >= 100 {
 		t.Fatalf("expected degraded score, got %d", score)
 	}
 }
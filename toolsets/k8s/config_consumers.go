@@ -0,0 +1,176 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/render"
+)
+
+type configConsumer struct {
+	Workload  string `json:"workload"`
+	Container string `json:"container,omitempty"`
+	Source    string `json:"source"`
+	Key       string `json:"key,omitempty"`
+	Optional  bool   `json:"optional,omitempty"`
+	// RestartRequired is true when the value is copied into the container
+	// at start (env, envFrom, subPath mounts) rather than synced by the kubelet.
+	RestartRequired bool `json:"restartRequired"`
+}
+
+func (t *Toolset) handleFindConfigConsumers(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	namespace := toString(req.Arguments["namespace"])
+	name := toString(req.Arguments["name"])
+	kind := strings.ToLower(toString(req.Arguments["kind"]))
+	if namespace == "" || name == "" {
+		err := errors.New("namespace and name are required")
+		return errorResult(err), err
+	}
+	if kind == "" {
+		kind = "configmap"
+	}
+	if kind != "configmap" && kind != "secret" {
+		err := fmt.Errorf("unsupported kind: %s", kind)
+		return errorResult(err), err
+	}
+	if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+		return errorResult(err), err
+	}
+
+	analysis := render.NewAnalysis()
+	var err error
+	if kind == "configmap" {
+		_, err = t.ctx.Clients.Typed.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	} else {
+		_, err = t.ctx.Clients.Typed.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	switch {
+	case apierrors.IsNotFound(err):
+		analysis.AddCause("Config missing", fmt.Sprintf("%s %s/%s does not exist; consumers below will fail unless the reference is optional", titleKind(kind), namespace, name), "high")
+	case err != nil:
+		return errorResult(err), err
+	}
+
+	cache, warnings := t.buildGraphCache(ctx, namespace, false)
+	var consumers []configConsumer
+	for _, item := range cache.deploymentList {
+		consumers = append(consumers, configConsumersInPodSpec(&item.Spec.Template.Spec, "Deployment/"+item.Name, kind, name)...)
+	}
+	for _, item := range cache.statefulsetList {
+		consumers = append(consumers, configConsumersInPodSpec(&item.Spec.Template.Spec, "StatefulSet/"+item.Name, kind, name)...)
+	}
+	for _, item := range cache.daemonsetList {
+		consumers = append(consumers, configConsumersInPodSpec(&item.Spec.Template.Spec, "DaemonSet/"+item.Name, kind, name)...)
+	}
+	// Pods owned by the workloads above are already covered by their template.
+	for _, pod := range cache.podList {
+		if owner := metav1.GetControllerOf(pod); owner != nil && (owner.Kind == "ReplicaSet" || owner.Kind == "StatefulSet" || owner.Kind == "DaemonSet") {
+			continue
+		}
+		consumers = append(consumers, configConsumersInPodSpec(&pod.Spec, "Pod/"+pod.Name, kind, name)...)
+	}
+	if len(warnings) > 0 {
+		analysis.AddEvidence("warnings", warnings)
+	}
+	if len(consumers) == 0 {
+		analysis.AddEvidence("status", fmt.Sprintf("no workloads reference %s %s", kind, name))
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+	}
+	analysis.AddEvidence("consumers", consumers)
+	var restart []string
+	for _, consumer := range consumers {
+		kind, workload, _ := strings.Cut(consumer.Workload, "/")
+		analysis.AddResource(fmt.Sprintf("%ss/%s/%s", strings.ToLower(kind), namespace, workload))
+		if consumer.RestartRequired {
+			restart = append(restart, consumer.Workload)
+		}
+	}
+	if restart = uniqueStrings(restart); len(restart) > 0 {
+		analysis.AddEvidence("restartRequired", restart)
+		analysis.AddNextCheck(fmt.Sprintf("After editing, restart %s (kubectl rollout restart); env and subPath consumers do not see updates", strings.Join(restart, ", ")))
+	}
+	analysis.AddNextCheck("Volume-mounted consumers pick up changes after the kubelet sync period (about a minute)")
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+}
+
+// configConsumersInPodSpec lists every place a pod spec references the named
+// ConfigMap or Secret: env valueFrom, envFrom, volumes (including projected)
+// and, for Secrets, imagePullSecrets.
+func configConsumersInPodSpec(spec *corev1.PodSpec, workload, kind, name string) []configConsumer {
+	var out []configConsumer
+	volumes := map[string]configConsumer{}
+	for _, volume := range spec.Volumes {
+		switch {
+		case kind == "configmap" && volume.ConfigMap != nil && volume.ConfigMap.Name == name:
+			volumes[volume.Name] = configConsumer{Workload: workload, Source: "volume", Optional: optionalRef(volume.ConfigMap.Optional)}
+		case kind == "secret" && volume.Secret != nil && volume.Secret.SecretName == name:
+			volumes[volume.Name] = configConsumer{Workload: workload, Source: "volume", Optional: optionalRef(volume.Secret.Optional)}
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if kind == "configmap" && source.ConfigMap != nil && source.ConfigMap.Name == name {
+					volumes[volume.Name] = configConsumer{Workload: workload, Source: "projected", Optional: optionalRef(source.ConfigMap.Optional)}
+				}
+				if kind == "secret" && source.Secret != nil && source.Secret.Name == name {
+					volumes[volume.Name] = configConsumer{Workload: workload, Source: "projected", Optional: optionalRef(source.Secret.Optional)}
+				}
+			}
+		}
+	}
+	mounted := map[string]bool{}
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if kind == "configmap" && envFrom.ConfigMapRef != nil && envFrom.ConfigMapRef.Name == name {
+				out = append(out, configConsumer{Workload: workload, Container: container.Name, Source: "envFrom", Optional: optionalRef(envFrom.ConfigMapRef.Optional), RestartRequired: true})
+			}
+			if kind == "secret" && envFrom.SecretRef != nil && envFrom.SecretRef.Name == name {
+				out = append(out, configConsumer{Workload: workload, Container: container.Name, Source: "envFrom", Optional: optionalRef(envFrom.SecretRef.Optional), RestartRequired: true})
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if ref := env.ValueFrom.ConfigMapKeyRef; kind == "configmap" && ref != nil && ref.Name == name {
+				out = append(out, configConsumer{Workload: workload, Container: container.Name, Source: "env", Key: ref.Key, Optional: optionalRef(ref.Optional), RestartRequired: true})
+			}
+			if ref := env.ValueFrom.SecretKeyRef; kind == "secret" && ref != nil && ref.Name == name {
+				out = append(out, configConsumer{Workload: workload, Container: container.Name, Source: "env", Key: ref.Key, Optional: optionalRef(ref.Optional), RestartRequired: true})
+			}
+		}
+		for _, mount := range container.VolumeMounts {
+			consumer, ok := volumes[mount.Name]
+			if !ok {
+				continue
+			}
+			mounted[mount.Name] = true
+			consumer.Container = container.Name
+			if mount.SubPath != "" || mount.SubPathExpr != "" {
+				consumer.Source += " (subPath)"
+				consumer.RestartRequired = true
+			}
+			out = append(out, consumer)
+		}
+	}
+	// An unmounted volume still has to resolve before the pod can start.
+	for _, volume := range spec.Volumes {
+		if consumer, ok := volumes[volume.Name]; ok && !mounted[volume.Name] {
+			out = append(out, consumer)
+		}
+	}
+	if kind == "secret" {
+		for _, ref := range spec.ImagePullSecrets {
+			if ref.Name == name {
+				out = append(out, configConsumer{Workload: workload, Source: "imagePullSecrets"})
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,91 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+func TestConfigConsumersInPodSpec(t *testing.T) {
+	spec := &corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{Name: "conf", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app"}}}},
+			{Name: "bundle", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+				{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "app"}}},
+			}}}},
+			{Name: "other", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "other"}}}},
+		},
+		Containers: []corev1.Container{{
+			Name:    "api",
+			EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app"}}}},
+			Env: []corev1.EnvVar{{Name: "MODE", ValueFrom: &corev1.EnvVarSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "app"}, Key: "mode"},
+			}}},
+			VolumeMounts: []corev1.VolumeMount{{Name: "conf", MountPath: "/etc/app.yaml", SubPath: "app.yaml"}, {Name: "other", MountPath: "/other"}},
+		}},
+	}
+	consumers := configConsumersInPodSpec(spec, "Deployment/api", "configmap", "app")
+	if len(consumers) != 4 {
+		t.Fatalf("expected envFrom, env, subPath and unmounted projected consumers, got %#v", consumers)
+	}
+	if consumers[1].Source != "env" || consumers[1].Key != "mode" || consumers[2].Source != "volume (subPath)" || !consumers[2].RestartRequired {
+		t.Fatalf("unexpected consumers: %#v", consumers)
+	}
+	if consumers[3].Source != "projected" || consumers[3].Container != "" || consumers[3].RestartRequired {
+		t.Fatalf("expected unmounted projected volume without restart, got %#v", consumers[3])
+	}
+
+	spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "registry"}}
+	if secrets := configConsumersInPodSpec(spec, "Pod/p", "secret", "registry"); len(secrets) != 1 || secrets[0].Source != "imagePullSecrets" {
+		t.Fatalf("expected imagePullSecrets consumer, got %#v", secrets)
+	}
+}
+
+func TestHandleFindConfigConsumers(t *testing.T) {
+	template := corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+		Name: "api",
+		Env: []corev1.EnvVar{{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "creds"}, Key: "token"},
+		}}},
+	}}}}
+	controller := true
+	toolset := newDebugToolset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}, Spec: appsv1.DeploymentSpec{Template: template}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "default", OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "api-abc", Controller: &controller}}},
+			Spec:       template.Spec,
+		},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "default"}, Spec: template.Spec},
+	)
+	result, err := toolset.handleFindConfigConsumers(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"namespace": "default", "kind": "Secret", "name": "creds"},
+	})
+	if err != nil {
+		t.Fatalf("handleFindConfigConsumers: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	var consumers []configConsumer
+	for _, item := range data["evidence"].([]render.EvidenceItem) {
+		if item.Summary == "consumers" {
+			consumers = item.Details.([]configConsumer)
+		}
+	}
+	if len(consumers) != 2 || consumers[0].Workload != "Deployment/api" || consumers[1].Workload != "Pod/debug" {
+		t.Fatalf("expected deployment and bare pod consumers, got %#v", consumers)
+	}
+	causes := data["likelyRootCauses"].([]render.Cause)
+	if len(causes) != 1 || causes[0].Summary != "Config missing" {
+		t.Fatalf("expected missing secret cause, got %#v", causes)
+	}
+	if _, err := toolset.handleFindConfigConsumers(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: map[string]any{"namespace": "default", "kind": "pvc", "name": "x"}}); err == nil {
+		t.Fatalf("expected unsupported kind error")
+	}
+}
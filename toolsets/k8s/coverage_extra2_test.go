@@ -68,12 +68,6 @@ func TestHandleDiagnoseWithEventsAndCrashLoop(t *testing.T) {
 	}
 }
 
-func TestDiscoveryErrorIsPartialNil(t *testing.T) {
-	if discoveryErrorIsPartial(nil) {
-		t.Fatalf("expected false for nil error")
-	}
-}
-
 func TestOwnedByFalse(t *testing.T) {
 	meta := metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "api"}}}
 	if ownedBy(&meta, "StatefulSet", "db") {
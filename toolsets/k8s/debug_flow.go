@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"rootcause/internal/argconv"
 	"rootcause/internal/mcp"
 )
 
@@ -36,7 +37,7 @@ func (t *Toolset) handleDebugFlow(ctx context.Context, req mcp.ToolRequest) (mcp
 	kind := strings.ToLower(toString(req.Arguments["kind"]))
 	name := toString(req.Arguments["name"])
 	scenario := strings.ToLower(toString(req.Arguments["scenario"]))
-	maxSteps := toInt(req.Arguments["maxSteps"], 20)
+	maxSteps := argconv.Int(req.Arguments["maxSteps"], 20)
 	if maxSteps <= 0 {
 		maxSteps = 20
 	}
@@ -0,0 +1,308 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/evidence"
+	"rootcause/internal/mcp"
+	"rootcause/internal/render"
+)
+
+// Image pull failure categories, derived from the kubelet/runtime message.
+const (
+	pullAuth      = "auth"
+	pullNotFound  = "not-found"
+	pullRateLimit = "rate-limit"
+	pullNetwork   = "network"
+	pullUnknown   = "unknown"
+)
+
+var pullFailurePatterns = []struct {
+	category string
+	needles  []string
+}{
+	{pullRateLimit, []string{"toomanyrequests", "too many requests", "rate limit", "429"}},
+	{pullAuth, []string{"unauthorized", "authentication required", "no basic auth credentials", "403 forbidden", "not authorized", "denied: "}},
+	// Docker Hub answers "pull access denied" for repositories that do not exist.
+	{pullNotFound, []string{"not found", "manifest unknown", "name unknown", "does not exist", "pull access denied"}},
+	{pullNetwork, []string{"i/o timeout", "no such host", "connection refused", "connection reset", "tls handshake", "context deadline exceeded", "x509"}},
+}
+
+type imagePullFinding struct {
+	Pod                   string   `json:"pod"`
+	Container             string   `json:"container"`
+	Image                 string   `json:"image"`
+	Registry              string   `json:"registry"`
+	Repository            string   `json:"repository"`
+	Tag                   string   `json:"tag,omitempty"`
+	Digest                string   `json:"digest,omitempty"`
+	Reason                string   `json:"reason"`
+	Category              string   `json:"category"`
+	Message               string   `json:"message,omitempty"`
+	PullSecrets           []string `json:"pullSecrets,omitempty"`
+	MissingPullSecrets    []string `json:"missingPullSecrets,omitempty"`
+	PullSecretForRegistry bool     `json:"pullSecretForRegistry"`
+	ECRRepository         string   `json:"ecrRepository,omitempty"`
+}
+
+func (t *Toolset) handleDiagnoseImagePull(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	namespace := toString(req.Arguments["namespace"])
+	podName := toString(req.Arguments["pod"])
+	selector := toString(req.Arguments["labelSelector"])
+	checkECR := true
+	if value, ok := req.Arguments["checkEcr"].(bool); ok {
+		checkECR = value
+	}
+	if namespace == "" {
+		err := errors.New("namespace is required")
+		return errorResult(err), err
+	}
+	if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+		return errorResult(err), err
+	}
+	var pods []corev1.Pod
+	if podName != "" {
+		pod, err := t.ctx.Clients.Typed.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return errorResult(err), err
+		}
+		pods = append(pods, *pod)
+	} else {
+		list, err := t.ctx.Clients.Typed.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return errorResult(err), err
+		}
+		pods = list.Items
+	}
+
+	analysis := render.NewAnalysis()
+	var findings []imagePullFinding
+	for i := range pods {
+		pod := &pods[i]
+		issues := collectImagePullIssues(pod)
+		if len(issues) == 0 {
+			continue
+		}
+		analysis.AddResource(fmt.Sprintf("pods/%s/%s", namespace, pod.Name))
+		secrets, missing, registries := t.podPullSecrets(ctx, pod)
+		events, _ := evidence.RecentEvents(ctx, t.ctx.Evidence, namespace, corev1.ObjectReference{Kind: "Pod", Name: pod.Name, UID: pod.UID})
+		for _, issue := range issues {
+			image := issue.Image
+			if spec := podContainerImage(pod, issue.Container); spec != "" {
+				image = spec
+			}
+			ref := parseImageRef(image)
+			finding := imagePullFinding{
+				Pod:                pod.Name,
+				Container:          issue.Container,
+				Image:              image,
+				Registry:           ref.Registry,
+				Repository:         ref.Repository,
+				Tag:                ref.Tag,
+				Digest:             ref.Digest,
+				Reason:             issue.Reason,
+				Message:            imagePullMessage(events, image, issue.Message),
+				PullSecrets:        secrets,
+				MissingPullSecrets: missing,
+			}
+			finding.Category = classifyImagePullMessage(finding.Message)
+			_, finding.PullSecretForRegistry = registries[registryAuthKey(ref.Registry)]
+			findings = append(findings, finding)
+		}
+	}
+	if len(findings) == 0 {
+		analysis.AddEvidence("status", "no containers in ImagePullBackOff or ErrImagePull")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+	}
+	if checkECR {
+		t.checkECRRepositories(ctx, req, &analysis, findings)
+	}
+	analysis.AddEvidence("findings", findings)
+
+	categories := map[string]bool{}
+	for _, finding := range findings {
+		categories[finding.Category] = true
+		subject := fmt.Sprintf("%s/%s container %s (%s)", namespace, finding.Pod, finding.Container, finding.Image)
+		switch {
+		case finding.ECRRepository == "missing":
+			analysis.AddCause("ECR repository does not exist", fmt.Sprintf("%s: repository %s not found in %s", subject, finding.Repository, finding.Registry), "high")
+		case len(finding.MissingPullSecrets) > 0:
+			analysis.AddCause("imagePullSecret missing", fmt.Sprintf("%s references missing secrets: %s", subject, strings.Join(finding.MissingPullSecrets, ", ")), "high")
+		case finding.Category == pullAuth:
+			detail := "no imagePullSecret covers this registry"
+			if finding.PullSecretForRegistry {
+				detail = "the attached imagePullSecret was rejected"
+			}
+			if isECRImage(finding.Registry) {
+				detail = "check the node role's ECR permissions and repository policy"
+			}
+			analysis.AddCause("Registry rejected credentials", fmt.Sprintf("%s: %s", subject, detail), "high")
+		case finding.Category == pullNotFound:
+			analysis.AddCause("Image not found", fmt.Sprintf("%s: repository or tag does not exist in %s", subject, finding.Registry), "high")
+		case finding.Category == pullRateLimit:
+			analysis.AddCause("Registry rate limit", fmt.Sprintf("%s: %s is throttling pulls", subject, finding.Registry), "medium")
+		case finding.Category == pullNetwork:
+			analysis.AddCause("Registry unreachable", fmt.Sprintf("%s: nodes cannot reach %s", subject, finding.Registry), "high")
+		default:
+			analysis.AddCause("Image pull failing", fmt.Sprintf("%s: %s", subject, finding.Message), "medium")
+		}
+	}
+	if categories[pullAuth] {
+		analysis.AddNextCheck("Confirm the imagePullSecret's .dockerconfigjson has an auths entry for the registry host")
+	}
+	if categories[pullRateLimit] {
+		analysis.AddNextCheck("Authenticate Docker Hub pulls or mirror the image to a private registry")
+	}
+	if categories[pullNetwork] {
+		analysis.AddNextCheck("Check node egress (NAT gateway, proxy, VPC endpoints for ECR) and DNS for the registry host")
+	}
+	analysis.AddNextCheck("Run k8s.events_timeline on the pod for the full pull error history")
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+}
+
+// podPullSecrets returns the pod's and service account's imagePullSecrets,
+// the ones that do not exist, and the registry hosts the rest authenticate.
+func (t *Toolset) podPullSecrets(ctx context.Context, pod *corev1.Pod) ([]string, []string, map[string]struct{}) {
+	refs := append([]corev1.LocalObjectReference{}, pod.Spec.ImagePullSecrets...)
+	saName := pod.Spec.ServiceAccountName
+	if saName == "" {
+		saName = "default"
+	}
+	if sa, err := t.ctx.Clients.Typed.CoreV1().ServiceAccounts(pod.Namespace).Get(ctx, saName, metav1.GetOptions{}); err == nil {
+		refs = append(refs, sa.ImagePullSecrets...)
+	}
+	var names, missing []string
+	registries := map[string]struct{}{}
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+		secret, err := t.ctx.Clients.Typed.CoreV1().Secrets(pod.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			missing = append(missing, ref.Name)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		for host := range dockerConfigRegistries(secret) {
+			registries[host] = struct{}{}
+		}
+	}
+	return uniqueStrings(names), uniqueStrings(missing), registries
+}
+
+// dockerConfigRegistries lists the registry hosts in a docker config secret;
+// only the keys of the auths map are read.
+func dockerConfigRegistries(secret *corev1.Secret) map[string]struct{} {
+	out := map[string]struct{}{}
+	var auths map[string]json.RawMessage
+	if data, ok := secret.Data[corev1.DockerConfigJsonKey]; ok {
+		var config struct {
+			Auths map[string]json.RawMessage `json:"auths"`
+		}
+		if json.Unmarshal(data, &config) == nil {
+			auths = config.Auths
+		}
+	} else if data, ok := secret.Data[corev1.DockerConfigKey]; ok {
+		_ = json.Unmarshal(data, &auths)
+	}
+	for host := range auths {
+		out[registryAuthKey(host)] = struct{}{}
+	}
+	return out
+}
+
+// registryAuthKey normalises a registry host the way docker config auths
+// keys are written, including Docker Hub's legacy index URL.
+func registryAuthKey(host string) string {
+	host = strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(host), "https://"), "http://")
+	host = strings.SplitN(host, "/", 2)[0]
+	switch host {
+	case "index.docker.io", "registry-1.docker.io", "docker.io":
+		return "docker.io"
+	}
+	return host
+}
+
+func podContainerImage(pod *corev1.Pod, name string) string {
+	for _, container := range append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+		if container.Name == name {
+			return container.Image
+		}
+	}
+	return ""
+}
+
+// imagePullMessage prefers the newest Failed event for the image (events
+// arrive newest first): the ImagePullBackOff waiting message only says the
+// kubelet is backing off.
+func imagePullMessage(events []corev1.Event, image, fallback string) string {
+	for _, event := range events {
+		if event.Type == corev1.EventTypeWarning && event.Reason == "Failed" && strings.Contains(event.Message, image) {
+			return event.Message
+		}
+	}
+	return fallback
+}
+
+func classifyImagePullMessage(message string) string {
+	lower := strings.ToLower(message)
+	for _, pattern := range pullFailurePatterns {
+		for _, needle := range pattern.needles {
+			if strings.Contains(lower, needle) {
+				return pattern.category
+			}
+		}
+	}
+	return pullUnknown
+}
+
+func (t *Toolset) checkECRRepositories(ctx context.Context, req mcp.ToolRequest, analysis *render.Analysis, findings []imagePullFinding) {
+	hasECR := false
+	for _, finding := range findings {
+		hasECR = hasECR || isECRImage(finding.Registry)
+	}
+	if !hasECR {
+		return
+	}
+	if t.ctx.Registry == nil {
+		analysis.AddEvidence("awsEcr", "tool registry unavailable")
+		return
+	}
+	if _, ok := t.ctx.Registry.Get("aws.ecr.describe_repository"); !ok {
+		analysis.AddEvidence("awsEcr", "aws toolset not enabled")
+		return
+	}
+	checked := map[string]string{}
+	for i := range findings {
+		finding := &findings[i]
+		if !isECRImage(finding.Registry) || finding.Repository == "" {
+			continue
+		}
+		key := finding.Registry + "/" + finding.Repository
+		if status, ok := checked[key]; ok {
+			finding.ECRRepository = status
+			continue
+		}
+		_, err := t.ctx.CallTool(ctx, req.User, "aws.ecr.describe_repository", map[string]any{
+			"repositoryName": finding.Repository,
+			"region":         regionFromECRRegistry(finding.Registry),
+		})
+		switch {
+		case err == nil:
+			finding.ECRRepository = "exists"
+		case strings.Contains(err.Error(), "RepositoryNotFound"):
+			finding.ECRRepository = "missing"
+		default:
+			finding.ECRRepository = "lookup failed: " + err.Error()
+		}
+		checked[key] = finding.ECRRepository
+	}
+}
@@ -0,0 +1,140 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"rootcause/internal/config"
+	"rootcause/internal/evidence"
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/redact"
+	"rootcause/internal/render"
+)
+
+func TestClassifyImagePullMessage(t *testing.T) {
+	cases := map[string]string{
+		`failed to authorize: 401 Unauthorized`:                                              pullAuth,
+		`no basic auth credentials`:                                                          pullAuth,
+		`toomanyrequests: You have reached your pull rate limit`:                             pullRateLimit,
+		`manifest for nginx:nope not found: manifest unknown`:                                pullNotFound,
+		`pull access denied for acme/private, repository does not exist or may require auth`: pullNotFound,
+		`dial tcp: lookup registry.internal: no such host`:                                   pullNetwork,
+		`Back-off pulling image "nginx"`:                                                     pullUnknown,
+	}
+	for message, want := range cases {
+		if got := classifyImagePullMessage(message); got != want {
+			t.Fatalf("classifyImagePullMessage(%q) = %q, want %q", message, got, want)
+		}
+	}
+}
+
+func TestDockerConfigRegistries(t *testing.T) {
+	secret := &corev1.Secret{Data: map[string][]byte{
+		corev1.DockerConfigJsonKey: []byte(`{"auths":{"https://index.docker.io/v1/":{},"ghcr.io":{}}}`),
+	}}
+	registries := dockerConfigRegistries(secret)
+	if _, ok := registries["docker.io"]; !ok {
+		t.Fatalf("expected docker hub auth key, got %#v", registries)
+	}
+	if _, ok := registries["ghcr.io"]; !ok || len(registries) != 2 {
+		t.Fatalf("unexpected registries: %#v", registries)
+	}
+}
+
+func pullPod(name, image, reason string, secrets ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "api"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: image}}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "app",
+			Image: image,
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: "Back-off pulling image " + image}},
+		}}},
+	}
+	for _, secret := range secrets {
+		pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+	}
+	return pod
+}
+
+func TestHandleDiagnoseImagePull(t *testing.T) {
+	ecrImage := "123456789012.dkr.ecr.us-west-2.amazonaws.com/team/api:v1"
+	client := fake.NewSimpleClientset(
+		pullPod("private", "ghcr.io/acme/api:v1", "ErrImagePull", "ghcr"),
+		pullPod("ecr", ecrImage, "ImagePullBackOff"),
+		pullPod("healthy", "nginx:1.27", ""),
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "private.1", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "private"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "Failed",
+			Message:        `Failed to pull image "ghcr.io/acme/api:v1": 401 Unauthorized`,
+		},
+	)
+	cfg := config.DefaultConfig()
+	reg := mcp.NewRegistry(&cfg)
+	clients := &kube.Clients{Typed: client}
+	toolCtx := mcp.ToolContext{
+		Config:   &cfg,
+		Clients:  clients,
+		Policy:   policy.NewAuthorizer(),
+		Renderer: render.NewRenderer(),
+		Redactor: redact.New(),
+		Evidence: evidence.NewCollector(clients),
+		Registry: reg,
+	}
+	toolCtx.Invoker = mcp.NewToolInvoker(reg, toolCtx)
+	_ = reg.Add(mcp.ToolSpec{
+		Name:      "aws.ecr.describe_repository",
+		ToolsetID: "aws",
+		Handler: func(context.Context, mcp.ToolRequest) (mcp.ToolResult, error) {
+			return mcp.ToolResult{}, errors.New("RepositoryNotFoundException: repository team/api does not exist")
+		},
+	})
+	toolset := New()
+	_ = toolset.Init(toolCtx)
+
+	result, err := toolset.handleDiagnoseImagePull(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"namespace": "default", "labelSelector": "app=api"},
+	})
+	if err != nil {
+		t.Fatalf("handleDiagnoseImagePull: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	var findings []imagePullFinding
+	for _, item := range data["evidence"].([]render.EvidenceItem) {
+		if item.Summary == "findings" {
+			findings = item.Details.([]imagePullFinding)
+		}
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected two findings, got %#v", findings)
+	}
+	byPod := map[string]imagePullFinding{}
+	for _, finding := range findings {
+		byPod[finding.Pod] = finding
+	}
+	private := byPod["private"]
+	if private.Registry != "ghcr.io" || private.Category != pullAuth || len(private.MissingPullSecrets) != 1 || private.MissingPullSecrets[0] != "ghcr" {
+		t.Fatalf("unexpected private finding: %#v", private)
+	}
+	if ecr := byPod["ecr"]; ecr.ECRRepository != "missing" || ecr.Repository != "team/api" {
+		t.Fatalf("unexpected ecr finding: %#v", ecr)
+	}
+	causes := data["likelyRootCauses"].([]render.Cause)
+	summaries := map[string]bool{}
+	for _, cause := range causes {
+		summaries[cause.Summary] = true
+	}
+	if !summaries["imagePullSecret missing"] || !summaries["ECR repository does not exist"] {
+		t.Fatalf("unexpected causes: %#v", causes)
+	}
+}
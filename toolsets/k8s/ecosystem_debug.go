@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	"rootcause/internal/argconv"
 	"rootcause/internal/kube"
 	"rootcause/internal/mcp"
 )
@@ -181,7 +182,7 @@ func (t *Toolset) handleEcosystemDiagnose(ctx context.Context, req mcp.ToolReque
 			return errorResult(err), err
 		}
 	}
-	limit := toInt(req.Arguments["limit"], 50)
+	limit := argconv.Int(req.Arguments["limit"], 50)
 	if limit <= 0 {
 		limit = 50
 	}
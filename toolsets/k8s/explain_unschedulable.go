@@ -0,0 +1,332 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+// Categories of scheduler filter failures.
+const (
+	schedInsufficientCPU    = "insufficient-cpu"
+	schedInsufficientMemory = "insufficient-memory"
+	schedInsufficientOther  = "insufficient-resource"
+	schedNodeAffinity       = "node-affinity"
+	schedTaint              = "taint"
+	schedUnschedulableNode  = "node-unschedulable"
+	schedVolumeZone         = "volume-zone"
+	schedVolumeBinding      = "volume-binding"
+	schedPodAffinity        = "pod-affinity"
+	schedTopologySpread     = "topology-spread"
+	schedPorts              = "host-ports"
+	schedNoNodes            = "no-nodes"
+	schedOther              = "other"
+)
+
+// Ordered so the more specific phrases win.
+var schedulerReasonPatterns = []struct {
+	category string
+	match    string
+}{
+	{schedInsufficientCPU, "insufficient cpu"},
+	{schedInsufficientMemory, "insufficient memory"},
+	{schedInsufficientOther, "insufficient "},
+	{schedInsufficientOther, "too many pods"},
+	{schedVolumeZone, "volume node affinity conflict"},
+	{schedVolumeBinding, "persistent volumes to bind"},
+	{schedVolumeBinding, "unbound immediate persistentvolumeclaims"},
+	{schedVolumeBinding, "persistentvolumeclaim"},
+	{schedPodAffinity, "pod affinity"},
+	{schedPodAffinity, "pod anti-affinity"},
+	{schedTopologySpread, "topology spread"},
+	{schedNodeAffinity, "node affinity"},
+	{schedNodeAffinity, "node selector"},
+	{schedTaint, "taint"},
+	{schedUnschedulableNode, "unschedulable"},
+	{schedPorts, "free ports"},
+	{schedNoNodes, "no nodes available"},
+}
+
+var schedulerSummaryPattern = regexp.MustCompile(`^(\d+)/(\d+) nodes are available:?\s*(.*)$`)
+var schedulerCountPattern = regexp.MustCompile(`^(\d+)\s+(.*)$`)
+
+type schedulingReason struct {
+	Category string `json:"category"`
+	Nodes    int    `json:"nodes,omitempty"`
+	Detail   string `json:"detail"`
+}
+
+type unschedulablePod struct {
+	Namespace      string             `json:"namespace"`
+	Pod            string             `json:"pod"`
+	NodesAvailable *int               `json:"nodesAvailable,omitempty"`
+	NodesTotal     *int               `json:"nodesTotal,omitempty"`
+	Reasons        []schedulingReason `json:"reasons"`
+	CPURequest     string             `json:"cpuRequest,omitempty"`
+	MemoryRequest  string             `json:"memoryRequest,omitempty"`
+	Suggestions    []string           `json:"suggestions,omitempty"`
+}
+
+func (t *Toolset) handleExplainUnschedulable(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	namespace := toString(req.Arguments["namespace"])
+	if namespace != "" {
+		if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+			return errorResult(err), err
+		}
+	} else if err := t.ctx.Policy.CheckNamespace(req.User, "", false); err != nil {
+		return errorResult(err), err
+	}
+	pods, err := t.ctx.Clients.Typed.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: toString(req.Arguments["labelSelector"]),
+		FieldSelector: "status.phase=Pending",
+	})
+	if err != nil {
+		return errorResult(err), err
+	}
+	analysis := render.NewAnalysis()
+
+	// Node headroom sharpens the suggestions but needs cluster access.
+	var nodes []nodePressureSummary
+	if req.User.Role == policy.RoleCluster {
+		nodeList, nodeErr := t.ctx.Clients.Typed.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		allPods, podErr := t.ctx.Clients.Typed.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		switch {
+		case nodeErr != nil:
+			analysis.AddEvidence("nodeWarning", nodeErr.Error())
+		case podErr != nil:
+			analysis.AddEvidence("nodeWarning", podErr.Error())
+		default:
+			nodes = nodePressureSummaries(nodeList.Items, allPods.Items, defaultNodeHeadroomThreshold)
+		}
+	}
+
+	var explained []unschedulablePod
+	categories := map[string][]string{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		reason, message := pendingReason(pod)
+		if reason != corev1.PodReasonUnschedulable {
+			continue
+		}
+		entry := unschedulablePod{Namespace: pod.Namespace, Pod: pod.Name}
+		entry.NodesAvailable, entry.NodesTotal, entry.Reasons = parseSchedulerMessage(message)
+		cpu, memory := podResourceRequests(*pod)
+		if cpu > 0 {
+			entry.CPURequest = formatCPU(cpu)
+		}
+		if memory > 0 {
+			entry.MemoryRequest = formatMemory(memory)
+		}
+		entry.Suggestions = schedulingSuggestions(pod, entry.Reasons, nodes, cpu, memory)
+		explained = append(explained, entry)
+		analysis.AddResource(fmt.Sprintf("pods/%s/%s", pod.Namespace, pod.Name))
+		for _, r := range entry.Reasons {
+			categories[r.Category] = append(categories[r.Category], pod.Namespace+"/"+pod.Name)
+		}
+	}
+	if len(explained) == 0 {
+		analysis.AddEvidence("status", "no unschedulable pods found")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+	}
+	analysis.AddEvidence("pods", explained)
+	names := make([]string, 0, len(categories))
+	for category := range categories {
+		names = append(names, category)
+	}
+	sort.Strings(names)
+	for _, category := range names {
+		podNames := uniqueStrings(categories[category])
+		analysis.AddCause(fmt.Sprintf("Scheduling blocked: %s", category), fmt.Sprintf("%d pods: %s", len(podNames), strings.Join(podNames, ", ")), schedulingCategorySeverity(category))
+	}
+	analysis.AddNextCheck("Apply the per-pod suggestions, starting with the reason that rejected the most nodes")
+	if len(nodes) > 0 {
+		analysis.AddNextCheck("See k8s.node_pressure for full per-node headroom, taints and cordons")
+	}
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+}
+
+// parseSchedulerMessage splits a FailedScheduling message such as
+// "0/5 nodes are available: 3 Insufficient cpu, 2 node(s) had untolerated
+// taint {dedicated: gpu}. preemption: ..." into categorized reasons, sorted
+// by how many nodes each rejected.
+func parseSchedulerMessage(message string) (*int, *int, []schedulingReason) {
+	message = strings.TrimSpace(message)
+	if idx := strings.Index(message, " preemption:"); idx >= 0 {
+		message = message[:idx]
+	}
+	message = strings.TrimSuffix(message, ".")
+	var available, total *int
+	body := message
+	if match := schedulerSummaryPattern.FindStringSubmatch(message); match != nil {
+		a, _ := strconv.Atoi(match[1])
+		n, _ := strconv.Atoi(match[2])
+		available, total, body = &a, &n, match[3]
+		if n == 0 {
+			return available, total, []schedulingReason{{Category: schedNoNodes, Detail: "the cluster has no nodes"}}
+		}
+	}
+	var reasons []schedulingReason
+	for _, part := range splitSchedulerReasons(body) {
+		reason := schedulingReason{Category: schedOther, Detail: part}
+		if match := schedulerCountPattern.FindStringSubmatch(part); match != nil {
+			reason.Nodes, _ = strconv.Atoi(match[1])
+			reason.Detail = match[2]
+		}
+		lower := strings.ToLower(reason.Detail)
+		for _, pattern := range schedulerReasonPatterns {
+			if strings.Contains(lower, pattern.match) {
+				reason.Category = pattern.category
+				break
+			}
+		}
+		reasons = append(reasons, reason)
+	}
+	sort.SliceStable(reasons, func(i, j int) bool { return reasons[i].Nodes > reasons[j].Nodes })
+	return available, total, reasons
+}
+
+// splitSchedulerReasons splits on commas outside the braces the scheduler
+// uses to print taints.
+func splitSchedulerReasons(body string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range body {
+		switch r {
+		case '{':
+			depth++
+		case '}':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, body[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, body[start:])
+	out := parts[:0]
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// schedulingSuggestions names the constraint to relax for each reason,
+// using node headroom when it is available.
+func schedulingSuggestions(pod *corev1.Pod, reasons []schedulingReason, nodes []nodePressureSummary, cpuMilli, memoryBytes int64) []string {
+	var suggestions []string
+	seen := map[string]struct{}{}
+	add := func(text string) {
+		if _, ok := seen[text]; !ok {
+			seen[text] = struct{}{}
+			suggestions = append(suggestions, text)
+		}
+	}
+	for _, reason := range reasons {
+		switch reason.Category {
+		case schedInsufficientCPU:
+			if free, node := maxFreeCPU(nodes); node != "" {
+				add(fmt.Sprintf("Pod requests %s CPU but the most free on a schedulable node is %s (%s); lower the request or add capacity", formatCPU(cpuMilli), formatCPU(free), node))
+			} else {
+				add("Lower the CPU request or add node capacity")
+			}
+		case schedInsufficientMemory:
+			if free, node := maxFreeMemory(nodes); node != "" {
+				add(fmt.Sprintf("Pod requests %s memory but the most free on a schedulable node is %s (%s); lower the request or add capacity", formatMemory(memoryBytes), formatMemory(free), node))
+			} else {
+				add("Lower the memory request or add node capacity")
+			}
+		case schedInsufficientOther:
+			add(fmt.Sprintf("Add nodes providing the missing resource (%s)", reason.Detail))
+		case schedNodeAffinity:
+			if len(pod.Spec.NodeSelector) > 0 {
+				add(fmt.Sprintf("No node carries nodeSelector %v; fix the labels or relax the selector", pod.Spec.NodeSelector))
+			} else {
+				add("Relax required node affinity or label nodes to match it")
+			}
+		case schedTaint:
+			if tainted := taintedNodes(nodes); len(tainted) > 0 {
+				add(fmt.Sprintf("Add tolerations for the taints on %s, or schedule elsewhere", strings.Join(tainted, ", ")))
+			} else {
+				add("Add tolerations for the node taints or remove the taints")
+			}
+		case schedUnschedulableNode:
+			add("Uncordon nodes (k8s.node_management) once maintenance is finished")
+		case schedVolumeZone:
+			add("The bound PersistentVolume lives in a zone with no eligible node; add capacity in that zone or use a WaitForFirstConsumer StorageClass")
+		case schedVolumeBinding:
+			add("Check the PVC with k8s.storage_debug; it is unbound or its StorageClass cannot provision")
+		case schedPodAffinity:
+			add("Relax required pod (anti-)affinity or add nodes so replicas can spread")
+		case schedTopologySpread:
+			add("Relax topologySpreadConstraints (maxSkew or whenUnsatisfiable: ScheduleAnyway) or add nodes in the missing domains")
+		case schedPorts:
+			add("Another pod already holds the hostPort on every candidate node; drop hostPort or add nodes")
+		case schedNoNodes:
+			add("No nodes are registered; check the node group or Karpenter NodePools")
+		}
+	}
+	return suggestions
+}
+
+func maxFreeCPU(nodes []nodePressureSummary) (int64, string) {
+	var best int64 = -1
+	var name string
+	for _, node := range nodes {
+		if !node.Ready || node.Unschedulable {
+			continue
+		}
+		if free := node.CPUAllocatableMilli - node.CPURequestedMilli; free > best {
+			best, name = free, node.Node
+		}
+	}
+	return best, name
+}
+
+func maxFreeMemory(nodes []nodePressureSummary) (int64, string) {
+	var best int64 = -1
+	var name string
+	for _, node := range nodes {
+		if !node.Ready || node.Unschedulable {
+			continue
+		}
+		if free := node.MemoryAllocatableBytes - node.MemoryRequestedBytes; free > best {
+			best, name = free, node.Node
+		}
+	}
+	return best, name
+}
+
+func taintedNodes(nodes []nodePressureSummary) []string {
+	var out []string
+	for _, node := range nodes {
+		if len(node.SchedulingTaints) > 0 {
+			out = append(out, fmt.Sprintf("%s (%s)", node.Node, strings.Join(node.SchedulingTaints, ", ")))
+		}
+	}
+	return out
+}
+
+func schedulingCategorySeverity(category string) string {
+	switch category {
+	case schedNoNodes, schedInsufficientCPU, schedInsufficientMemory, schedVolumeZone, schedVolumeBinding:
+		return "high"
+	case schedOther:
+		return "low"
+	}
+	return "medium"
+}
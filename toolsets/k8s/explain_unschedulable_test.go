@@ -0,0 +1,92 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+func TestParseSchedulerMessage(t *testing.T) {
+	available, total, reasons := parseSchedulerMessage("0/6 nodes are available: 1 node(s) had untolerated taint {node-role.kubernetes.io/control-plane: }, 3 Insufficient cpu, 2 node(s) didn't match Pod's node affinity/selector. preemption: 0/6 nodes are available: 6 Preemption is not helpful for scheduling.")
+	if available == nil || *available != 0 || total == nil || *total != 6 {
+		t.Fatalf("unexpected node counts: %v/%v", available, total)
+	}
+	want := []schedulingReason{
+		{Category: schedInsufficientCPU, Nodes: 3, Detail: "Insufficient cpu"},
+		{Category: schedNodeAffinity, Nodes: 2, Detail: "node(s) didn't match Pod's node affinity/selector"},
+		{Category: schedTaint, Nodes: 1, Detail: "node(s) had untolerated taint {node-role.kubernetes.io/control-plane: }"},
+	}
+	if len(reasons) != len(want) {
+		t.Fatalf("expected %d reasons, got %#v", len(want), reasons)
+	}
+	for i := range want {
+		if reasons[i] != want[i] {
+			t.Fatalf("reason %d: expected %#v, got %#v", i, want[i], reasons[i])
+		}
+	}
+
+	cases := map[string]string{
+		"0/3 nodes are available: 3 node(s) had volume node affinity conflict.":            schedVolumeZone,
+		"0/3 nodes are available: 3 node(s) didn't match pod anti-affinity rules.":         schedPodAffinity,
+		"0/3 nodes are available: 3 node(s) were unschedulable.":                           schedUnschedulableNode,
+		"0/3 nodes are available: 3 node(s) didn't match pod topology spread constraints.": schedTopologySpread,
+		"0/3 nodes are available: pod has unbound immediate PersistentVolumeClaims.":       schedVolumeBinding,
+		"0/0 nodes are available":             schedNoNodes,
+		"no nodes available to schedule pods": schedNoNodes,
+		"something new":                       schedOther,
+	}
+	for message, category := range cases {
+		if _, _, reasons := parseSchedulerMessage(message); len(reasons) != 1 || reasons[0].Category != category {
+			t.Fatalf("%q: expected %s, got %#v", message, category, reasons)
+		}
+	}
+}
+
+func TestHandleExplainUnschedulable(t *testing.T) {
+	pending := requestPod("big", "", "3", corev1.PodPending)
+	pending.Status.Conditions = []corev1.PodCondition{{
+		Type:    corev1.PodScheduled,
+		Status:  corev1.ConditionFalse,
+		Reason:  corev1.PodReasonUnschedulable,
+		Message: "0/2 nodes are available: 1 Insufficient cpu, 1 node(s) had untolerated taint {dedicated: gpu}.",
+	}}
+	waiting := requestPod("image", "", "100m", corev1.PodPending)
+	tainted := pressureNode("gpu", true)
+	tainted.Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}
+	toolset := newDebugToolset(pending, waiting, pressureNode("small", true), tainted, requestPod("running", "small", "1500m", corev1.PodRunning))
+
+	result, err := toolset.handleExplainUnschedulable(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"namespace": "default"},
+	})
+	if err != nil {
+		t.Fatalf("handleExplainUnschedulable: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	var pods []unschedulablePod
+	for _, item := range data["evidence"].([]render.EvidenceItem) {
+		if item.Summary == "pods" {
+			pods = item.Details.([]unschedulablePod)
+		}
+	}
+	if len(pods) != 1 || pods[0].Pod != "big" || pods[0].CPURequest != "3" {
+		t.Fatalf("expected only the unschedulable pod, got %#v", pods)
+	}
+	joined := strings.Join(pods[0].Suggestions, "\n")
+	if !strings.Contains(joined, "most free on a schedulable node is 2 (gpu)") || !strings.Contains(joined, "gpu (dedicated=gpu:NoSchedule)") {
+		t.Fatalf("expected headroom and taint suggestions, got %s", joined)
+	}
+
+	if _, err := toolset.handleExplainUnschedulable(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleNamespace, AllowedNamespaces: []string{"default"}},
+		Arguments: map[string]any{},
+	}); err == nil {
+		t.Fatalf("expected cluster-wide request to require cluster role")
+	}
+}
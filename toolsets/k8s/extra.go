@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/tools/clientcmd"
 
+	"rootcause/internal/argconv"
 	"rootcause/internal/kube"
 	"rootcause/internal/mcp"
 	"rootcause/internal/render"
@@ -200,7 +201,7 @@ func (t *Toolset) handleRestartSafetyCheck(ctx context.Context, req mcp.ToolRequ
 		replicas = *deployment.Spec.Replicas
 	}
 	ready := deployment.Status.ReadyReplicas
-	minReady := int32(toInt(args["minReadyReplicas"], 1))
+	minReady := int32(argconv.Int(args["minReadyReplicas"], 1))
 	if ready < minReady {
 		issues = append(issues, map[string]any{"severity": "high", "reason": "low_ready_replicas", "details": fmt.Sprintf("ready replicas %d below minimum %d", ready, minReady)})
 	}
@@ -264,7 +265,8 @@ func (t *Toolset) handleContext(ctx context.Context, req mcp.ToolRequest) (mcp.T
 		action = "list"
 	}
 	if action == "use" {
-		return errorResult(errors.New("use action not supported; restart server with --context")), errors.New("use action not supported; restart server with --context")
+		err := errors.New("use action not supported; pass the context argument on individual kube tool calls instead")
+		return errorResult(err), err
 	}
 
 	rules := clientcmd.NewDefaultClientConfigLoadingRules()
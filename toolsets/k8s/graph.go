@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"golang.org/x/sync/errgroup"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -16,11 +17,14 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/discovery"
 
+	"rootcause/internal/argconv"
+	"rootcause/internal/evidence"
+	"rootcause/internal/istioauthz"
 	"rootcause/internal/kube"
 	"rootcause/internal/mcp"
 	"rootcause/internal/policy"
+	"rootcause/internal/render"
 )
 
 type graphNode struct {
@@ -36,15 +40,78 @@ type graphEdge struct {
 	From     string `json:"from"`
 	To       string `json:"to"`
 	Relation string `json:"relation"`
+	// Attributes explain an edge, e.g. matchedLabels for the labels that
+	// made a selector match.
+	Attributes map[string]string `json:"attributes,omitempty"`
 }
 
+type graphEdgeKey struct {
+	from, to, relation string
+}
+
+// graphBuilder is not safe for concurrent use. Work that runs concurrently,
+// like the mesh resource lists, builds into its own part graphs and merges
+// them in a fixed order, so which nodes survive the budget does not depend on
+// scheduling.
 type graphBuilder struct {
-	nodes map[string]graphNode
-	edges []graphEdge
+	nodes     map[string]graphNode
+	order     []string
+	edges     []graphEdge
+	edgeIndex map[graphEdgeKey]int
+
+	// maxNodes and maxEdges bound the graph (0 means unbounded). Once either
+	// is reached, new nodes and edges are dropped and truncated is set so
+	// expansion can stop early.
+	maxNodes  int
+	maxEdges  int
+	truncated bool
+
+	// expanded holds the ids of nodes whose neighbours have been walked, so
+	// back-references (Service -> Pod -> Service) expand each node once.
+	expanded map[string]struct{}
 }
 
 func newGraphBuilder() *graphBuilder {
-	return &graphBuilder{nodes: map[string]graphNode{}}
+	return &graphBuilder{nodes: map[string]graphNode{}, edgeIndex: map[graphEdgeKey]int{}}
+}
+
+func newBoundedGraphBuilder(maxNodes, maxEdges int) *graphBuilder {
+	graph := newGraphBuilder()
+	graph.maxNodes = maxNodes
+	graph.maxEdges = maxEdges
+	return graph
+}
+
+// expand marks id as expanded and reports whether it was not already, i.e.
+// whether the caller should walk its neighbours.
+func (g *graphBuilder) expand(id string) bool {
+	if g.expanded == nil {
+		g.expanded = map[string]struct{}{}
+	}
+	if _, ok := g.expanded[id]; ok {
+		return false
+	}
+	g.expanded[id] = struct{}{}
+	return true
+}
+
+// exhausted reports whether the node or edge budget has been hit; callers
+// check it before expanding further.
+func (g *graphBuilder) exhausted() bool {
+	return g.truncated
+}
+
+// newPart returns a graph for work merged into g later, bounded by what is
+// left of g's budget so a part cannot grow past what the merge would keep.
+func (g *graphBuilder) newPart() *graphBuilder {
+	maxNodes, maxEdges := 0, 0
+	if g.maxNodes > 0 {
+		maxNodes = max(g.maxNodes-len(g.nodes), 1)
+	}
+	if g.maxEdges > 0 {
+		maxEdges = max(g.maxEdges-len(g.edges), 1)
+	}
+	return newBoundedGraphBuilder(maxNodes, maxEdges)
 }
 
 type graphCache struct {
@@ -110,7 +177,7 @@ func (t *Toolset) buildGraphCache(ctx context.Context, namespace string, cluster
 	warnings := []string{}
 
 	if list, err := t.ctx.Clients.Typed.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{}); err != nil {
-		warnings = append(warnings, fmt.Sprintf("service list failed: %v", err))
+		warnings = append(warnings, graphWarning("services", "list", err))
 	} else {
 		cache.servicesLoaded = true
 		for i := range list.Items {
@@ -121,7 +188,7 @@ func (t *Toolset) buildGraphCache(ctx context.Context, namespace string, cluster
 	}
 
 	if list, err := t.ctx.Clients.Typed.CoreV1().Endpoints(namespace).List(ctx, metav1.ListOptions{}); err != nil {
-		warnings = append(warnings, fmt.Sprintf("endpoints list failed: %v", err))
+		warnings = append(warnings, graphWarning("endpoints", "list", err))
 	} else {
 		cache.endpointsLoaded = true
 		for i := range list.Items {
@@ -132,7 +199,7 @@ func (t *Toolset) buildGraphCache(ctx context.Context, namespace string, cluster
 	}
 
 	if list, err := t.ctx.Clients.Typed.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{}); err != nil {
-		warnings = append(warnings, fmt.Sprintf("pod list failed: %v", err))
+		warnings = append(warnings, graphWarning("pods", "list", err))
 	} else {
 		cache.podsLoaded = true
 		for i := range list.Items {
@@ -143,7 +210,7 @@ func (t *Toolset) buildGraphCache(ctx context.Context, namespace string, cluster
 	}
 
 	if list, err := t.ctx.Clients.Typed.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{}); err != nil {
-		warnings = append(warnings, fmt.Sprintf("deployment list failed: %v", err))
+		warnings = append(warnings, graphWarning("deployments", "list", err))
 	} else {
 		cache.deploymentsLoaded = true
 		for i := range list.Items {
@@ -154,7 +221,7 @@ func (t *Toolset) buildGraphCache(ctx context.Context, namespace string, cluster
 	}
 
 	if list, err := t.ctx.Clients.Typed.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{}); err != nil {
-		warnings = append(warnings, fmt.Sprintf("replicaset list failed: %v", err))
+		warnings = append(warnings, graphWarning("replicasets", "list", err))
 	} else {
 		cache.replicasetsLoaded = true
 		for i := range list.Items {
@@ -165,7 +232,7 @@ func (t *Toolset) buildGraphCache(ctx context.Context, namespace string, cluster
 	}
 
 	if list, err := t.ctx.Clients.Typed.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{}); err != nil {
-		warnings = append(warnings, fmt.Sprintf("statefulset list failed: %v", err))
+		warnings = append(warnings, graphWarning("statefulsets", "list", err))
 	} else {
 		cache.statefulsetsLoaded = true
 		for i := range list.Items {
@@ -176,7 +243,7 @@ func (t *Toolset) buildGraphCache(ctx context.Context, namespace string, cluster
 	}
 
 	if list, err := t.ctx.Clients.Typed.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{}); err != nil {
-		warnings = append(warnings, fmt.Sprintf("daemonset list failed: %v", err))
+		warnings = append(warnings, graphWarning("daemonsets", "list", err))
 	} else {
 		cache.daemonsetsLoaded = true
 		for i := range list.Items {
@@ -187,7 +254,7 @@ func (t *Toolset) buildGraphCache(ctx context.Context, namespace string, cluster
 	}
 
 	if list, err := t.ctx.Clients.Typed.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{}); err != nil {
-		warnings = append(warnings, fmt.Sprintf("ingress list failed: %v", err))
+		warnings = append(warnings, graphWarning("ingresses", "list", err))
 	} else {
 		cache.ingressesLoaded = true
 		for i := range list.Items {
@@ -198,7 +265,7 @@ func (t *Toolset) buildGraphCache(ctx context.Context, namespace string, cluster
 	}
 
 	if list, err := t.ctx.Clients.Typed.NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{}); err != nil {
-		warnings = append(warnings, fmt.Sprintf("networkpolicy list failed: %v", err))
+		warnings = append(warnings, graphWarning("networkpolicies", "list", err))
 	} else {
 		cache.networkPoliciesLoaded = true
 		for i := range list.Items {
@@ -210,7 +277,7 @@ func (t *Toolset) buildGraphCache(ctx context.Context, namespace string, cluster
 
 	if clusterAccess {
 		if list, err := t.ctx.Clients.Typed.CoreV1().Namespaces().List(ctx, metav1.ListOptions{}); err != nil {
-			warnings = append(warnings, fmt.Sprintf("namespace list failed: %v", err))
+			warnings = append(warnings, graphWarning("namespaces", "list", err))
 		} else {
 			cache.namespacesLoaded = true
 			for i := range list.Items {
@@ -226,23 +293,236 @@ func (t *Toolset) buildGraphCache(ctx context.Context, namespace string, cluster
 
 func (g *graphBuilder) addNode(kind, group, namespace, name string, details map[string]any) string {
 	id := nodeID(kind, group, namespace, name)
-	if _, ok := g.nodes[id]; !ok {
+	existing, ok := g.nodes[id]
+	if !ok && g.maxNodes > 0 && len(g.nodes) >= g.maxNodes {
+		g.truncated = true
+		return id
+	}
+	if !ok {
 		g.nodes[id] = graphNode{ID: id, Kind: kind, Group: group, Name: name, Namespace: namespace, Details: details}
+		g.order = append(g.order, id)
+	} else if existing.Details == nil && details != nil {
+		// A reference can create a placeholder before the listed object is
+		// seen; let the object's details win whichever arrives first.
+		existing.Details = details
+		g.nodes[id] = existing
 	}
 	return id
 }
 
+// merge adds part's nodes, then its edges, in the order part recorded them,
+// under this graph's budget. A part that hit its own budget truncates g too.
+func (g *graphBuilder) merge(part *graphBuilder) {
+	if part.truncated {
+		g.truncated = true
+	}
+	for _, id := range part.order {
+		node := part.nodes[id]
+		g.addNode(node.Kind, node.Group, node.Namespace, node.Name, node.Details)
+	}
+	for _, edge := range part.edges {
+		g.addEdgeWithAttributes(edge.From, edge.To, edge.Relation, edge.Attributes)
+	}
+}
+
+// addEdge records a relationship once; different resources often imply the
+// same edge (e.g. a Service selecting a pod and its Endpoints naming it).
 func (g *graphBuilder) addEdge(from, to, relation string) {
-	g.edges = append(g.edges, graphEdge{From: from, To: to, Relation: relation})
+	g.addEdgeWithAttributes(from, to, relation, nil)
+}
+
+// addEdgeWithAttributes is addEdge for edges that carry attributes. As with
+// node details, the first non-nil attributes recorded for an edge win.
+func (g *graphBuilder) addEdgeWithAttributes(from, to, relation string, attributes map[string]string) {
+	key := graphEdgeKey{from: from, to: to, relation: relation}
+	if i, ok := g.edgeIndex[key]; ok {
+		if g.edges[i].Attributes == nil && attributes != nil {
+			g.edges[i].Attributes = attributes
+		}
+		return
+	}
+	if g.maxEdges > 0 && len(g.edges) >= g.maxEdges {
+		g.truncated = true
+		return
+	}
+	if g.truncated {
+		// Skip edges to nodes dropped by the node budget.
+		if _, ok := g.nodes[from]; !ok {
+			return
+		}
+		if _, ok := g.nodes[to]; !ok {
+			return
+		}
+	}
+	if g.edgeIndex == nil {
+		g.edgeIndex = map[graphEdgeKey]int{}
+	}
+	g.edgeIndex[key] = len(g.edges)
+	g.edges = append(g.edges, graphEdge{From: from, To: to, Relation: relation, Attributes: attributes})
+}
+
+// selectorMatchAttributes records which of a target's labels satisfied a
+// selector over keys, so over-broad selectors show up in the graph. It
+// returns nil when the selector names no keys.
+func selectorMatchAttributes(keys []string, targetLabels map[string]string) map[string]string {
+	matched := make([]string, 0, len(keys))
+	seen := map[string]struct{}{}
+	for _, key := range keys {
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		if value, ok := targetLabels[key]; ok {
+			matched = append(matched, key+"="+value)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+	sort.Strings(matched)
+	return map[string]string{"matchedLabels": strings.Join(matched, ",")}
+}
+
+func labelKeys(set map[string]string) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func labelSelectorKeys(selector metav1.LabelSelector) []string {
+	keys := labelKeys(selector.MatchLabels)
+	for _, expr := range selector.MatchExpressions {
+		keys = append(keys, expr.Key)
+	}
+	return keys
+}
+
+// graphFilter narrows a graph to a subgraph. Kinds and relations are
+// compared case-insensitively; an empty set means no restriction.
+type graphFilter struct {
+	root             string
+	includeKinds     map[string]struct{}
+	excludeKinds     map[string]struct{}
+	includeRelations map[string]struct{}
+}
+
+func newGraphFilter(root string, args map[string]any) graphFilter {
+	return graphFilter{
+		root:             root,
+		includeKinds:     lowerSet(toStringSlice(args["includeKinds"])),
+		excludeKinds:     lowerSet(toStringSlice(args["excludeKinds"])),
+		includeRelations: lowerSet(toStringSlice(args["includeRelations"])),
+	}
+}
+
+func (f graphFilter) active() bool {
+	return len(f.includeKinds) > 0 || len(f.excludeKinds) > 0 || len(f.includeRelations) > 0
+}
+
+// key identifies the filter in graph cache keys.
+func (f graphFilter) key() string {
+	if !f.active() {
+		return ""
+	}
+	return fmt.Sprintf("%s|%s|%s", setKey(f.includeKinds), setKey(f.excludeKinds), setKey(f.includeRelations))
+}
+
+func (f graphFilter) allowsNode(node graphNode) bool {
+	if node.ID == f.root {
+		return true
+	}
+	kind := strings.ToLower(node.Kind)
+	if len(f.includeKinds) > 0 {
+		if _, ok := f.includeKinds[kind]; !ok {
+			return false
+		}
+	}
+	_, excluded := f.excludeKinds[kind]
+	return !excluded
+}
+
+func (f graphFilter) allowsRelation(relation string) bool {
+	if len(f.includeRelations) == 0 {
+		return true
+	}
+	_, ok := f.includeRelations[strings.ToLower(relation)]
+	return ok
 }
 
-func (g *graphBuilder) result() map[string]any {
-	nodes := make([]graphNode, 0, len(g.nodes))
-	for _, node := range g.nodes {
+// result returns the graph, restricted to filter when one is set. Filtering
+// keeps edges whose relation and both endpoint kinds pass, then drops nodes
+// left without edges, except the root the graph was built for.
+func (g *graphBuilder) result(filter graphFilter) map[string]any {
+	edges := append([]graphEdge(nil), g.edges...)
+	keep := g.nodes
+	if filter.active() {
+		edges = make([]graphEdge, 0, len(g.edges))
+		keep = map[string]graphNode{}
+		if root, ok := g.nodes[filter.root]; ok {
+			keep[root.ID] = root
+		}
+		for _, edge := range g.edges {
+			from, fromOK := g.nodes[edge.From]
+			to, toOK := g.nodes[edge.To]
+			if !fromOK || !toOK || !filter.allowsRelation(edge.Relation) || !filter.allowsNode(from) || !filter.allowsNode(to) {
+				continue
+			}
+			edges = append(edges, edge)
+			keep[from.ID] = from
+			keep[to.ID] = to
+		}
+	}
+	nodes := make([]graphNode, 0, len(keep))
+	for _, node := range keep {
 		nodes = append(nodes, node)
 	}
 	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
-	return map[string]any{"nodes": nodes, "edges": g.edges}
+	// Edges are built while walking maps, so sort them like nodes to keep
+	// the output stable for diffing and caching.
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		if edges[i].To != edges[j].To {
+			return edges[i].To < edges[j].To
+		}
+		return edges[i].Relation < edges[j].Relation
+	})
+	return map[string]any{"nodes": nodes, "edges": edges}
+}
+
+func lowerSet(values []string) map[string]struct{} {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+			out[value] = struct{}{}
+		}
+	}
+	return out
+}
+
+func setKey(set map[string]struct{}) string {
+	values := make([]string, 0, len(set))
+	for value := range set {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return strings.Join(values, ",")
+}
+
+// podNodeDetails is the detail map of a Pod node: its phase and, unless
+// render.omit_age is set, how long ago it was created.
+func (t *Toolset) podNodeDetails(pod *corev1.Pod) map[string]any {
+	details := map[string]any{"phase": pod.Status.Phase}
+	if t.ctx.Config != nil && t.ctx.Config.Render.OmitAge {
+		return details
+	}
+	return render.AddAge(details, pod.CreationTimestamp.Time, time.Now())
 }
 
 func nodeID(kind, group, namespace, name string) string {
@@ -271,18 +551,28 @@ func (t *Toolset) handleGraph(ctx context.Context, req mcp.ToolRequest) (mcp.Too
 	if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
 		return errorResult(err), err
 	}
+	groupBy, err := parseGraphGroupBy(toString(args["groupBy"]))
+	if err != nil {
+		return errorResult(err), err
+	}
 	clusterAccess := req.User.Role == policy.RoleCluster
+	root := nodeID(kind, "", namespace, name)
+	filter := newGraphFilter(root, args)
+	maxNodes, maxEdges := t.graphBudget(args)
+	cacheKey := graphCacheKey(kind, namespace, name, clusterAccess) + filter.key() + fmt.Sprintf("|max:%d/%d", maxNodes, maxEdges)
+	if groupBy != graphGroupNone {
+		cacheKey += "|group:" + groupBy
+	}
 	if t.ctx.Cache != nil && t.ctx.Config != nil {
 		ttlSeconds := t.ctx.Config.Cache.GraphTTLSeconds
 		if ttlSeconds > 0 {
-			key := graphCacheKey(kind, namespace, name, clusterAccess)
-			if cached, ok := t.ctx.Cache.Get(key); ok {
+			if cached, ok := t.ctx.Cache.Get(cacheKey); ok {
 				return mcp.ToolResult{Data: cached, Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
 			}
 		}
 	}
 
-	graph := newGraphBuilder()
+	graph := newBoundedGraphBuilder(maxNodes, maxEdges)
 	cache, cacheWarnings := t.buildGraphCache(ctx, namespace, clusterAccess)
 	warnings := append([]string{}, cacheWarnings...)
 
@@ -333,23 +623,53 @@ func (t *Toolset) handleGraph(ctx context.Context, req mcp.ToolRequest) (mcp.Too
 		return errorResult(errors.New("unsupported kind for graph")), errors.New("unsupported kind for graph")
 	}
 
-	warnings = append(warnings, t.addNetworkPolicyGraph(ctx, graph, namespace, cache)...)
-	warnings = append(warnings, t.addMeshGraph(ctx, graph, namespace, cache)...)
+	if !graph.exhausted() {
+		warnings = append(warnings, t.addNetworkPolicyGraph(ctx, graph, namespace, cache)...)
+	}
+	if !graph.exhausted() {
+		warnings = append(warnings, t.addMeshGraph(ctx, req.User, graph, namespace, cache)...)
+	}
+	if graph.exhausted() {
+		warnings = append(warnings, fmt.Sprintf("graph truncated at %d nodes and %d edges (limits maxNodes=%d, maxEdges=%d); narrow the root (a pod or workload rather than a busy Service or Ingress), filter with includeKinds/excludeKinds/includeRelations, or raise maxNodes/maxEdges", len(graph.nodes), len(graph.edges), maxNodes, maxEdges))
+	}
 
-	out := graph.result()
-	if len(warnings) > 0 {
+	out := groupGraphPods(graph.result(filter), groupBy, root)
+	if graph.truncated {
+		out["truncated"] = true
+	}
+	// Each mesh group re-runs discovery, so a partial failure would otherwise
+	// repeat the same warning per group.
+	if warnings = uniqueStrings(warnings); len(warnings) > 0 {
 		out["warnings"] = warnings
 	}
+	if causes := graphAccessCauses(warnings); len(causes) > 0 {
+		out["causes"] = causes
+	}
 	if t.ctx.Cache != nil && t.ctx.Config != nil {
 		ttlSeconds := t.ctx.Config.Cache.GraphTTLSeconds
 		if ttlSeconds > 0 {
-			key := graphCacheKey(kind, namespace, name, clusterAccess)
-			t.ctx.Cache.Set(key, out, time.Duration(ttlSeconds)*time.Second)
+			t.ctx.Cache.Set(cacheKey, out, time.Duration(ttlSeconds)*time.Second)
 		}
 	}
 	return mcp.ToolResult{Data: out, Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
 }
 
+// graphBudget returns the node and edge limits for one graph: the maxNodes
+// and maxEdges arguments when set, else limits.max_graph_nodes/edges.
+func (t *Toolset) graphBudget(args map[string]any) (int, int) {
+	maxNodes, maxEdges := 0, 0
+	if t.ctx.Config != nil {
+		maxNodes, maxEdges = t.ctx.Config.Limits.MaxGraphNodes, t.ctx.Config.Limits.MaxGraphEdges
+	}
+	if n := argconv.Int(args["maxNodes"], 0); n > 0 {
+		maxNodes = n
+	}
+	if n := argconv.Int(args["maxEdges"], 0); n > 0 {
+		maxEdges = n
+	}
+	return maxNodes, maxEdges
+}
+
 func graphCacheKey(kind, namespace, name string, clusterAccess bool) string {
 	return fmt.Sprintf("graph:%s:%s:%s:%t", kind, namespace, name, clusterAccess)
 }
@@ -360,7 +680,7 @@ var (
 	gatewayGroups = []string{"gateway.networking.k8s.io"}
 )
 
-func (t *Toolset) addMeshGraph(ctx context.Context, graph *graphBuilder, namespace string, cache *graphCache) []string {
+func (t *Toolset) addMeshGraph(ctx context.Context, user policy.User, graph *graphBuilder, namespace string, cache *graphCache) []string {
 	warnings := []string{}
 	var services []corev1.Service
 	if cache != nil && cache.servicesLoaded {
@@ -376,26 +696,32 @@ func (t *Toolset) addMeshGraph(ctx context.Context, graph *graphBuilder, namespa
 	}
 	serviceIndex := buildServiceIndex(namespace, services)
 
+	var groups []string
+	gatewayPresent := false
 	if present, _, err := kube.GroupsPresent(t.ctx.Clients.Discovery, gatewayGroups); err != nil {
 		warnings = append(warnings, fmt.Sprintf("gateway api discovery failed: %v", err))
 	} else if present {
-		warnings = append(warnings, t.addGroupResources(ctx, graph, namespace, "gateway.networking.k8s.io", serviceIndex, cache)...)
-		warnings = append(warnings, t.addGatewayAPIGraph(ctx, graph, namespace, serviceIndex)...)
+		gatewayPresent = true
+		groups = append(groups, "gateway.networking.k8s.io")
 	}
 
 	if present, _, err := kube.GroupsPresent(t.ctx.Clients.Discovery, istioGroups); err != nil {
 		warnings = append(warnings, fmt.Sprintf("istio discovery failed: %v", err))
 	} else if present {
-		warnings = append(warnings, t.addGroupResources(ctx, graph, namespace, "networking.istio.io", serviceIndex, cache)...)
-		warnings = append(warnings, t.addGroupResources(ctx, graph, namespace, "security.istio.io", serviceIndex, cache)...)
+		groups = append(groups, "networking.istio.io", "security.istio.io")
 	}
 
 	if present, _, err := kube.GroupsPresent(t.ctx.Clients.Discovery, linkerdGroups); err != nil {
 		warnings = append(warnings, fmt.Sprintf("linkerd discovery failed: %v", err))
 	} else if present {
-		warnings = append(warnings, t.addGroupResources(ctx, graph, namespace, "linkerd.io", serviceIndex, cache)...)
-		warnings = append(warnings, t.addGroupResources(ctx, graph, namespace, "policy.linkerd.io", serviceIndex, cache)...)
+		groups = append(groups, "linkerd.io", "policy.linkerd.io")
+	}
+
+	warnings = append(warnings, t.addGroupsResources(ctx, graph, namespace, groups, serviceIndex, cache)...)
+	if gatewayPresent {
+		warnings = append(warnings, t.addGatewayAPIGraph(ctx, user, graph, namespace, serviceIndex)...)
 	}
+	sort.Strings(warnings)
 
 	return warnings
 }
@@ -410,36 +736,51 @@ func (t *Toolset) addNetworkPolicyGraph(ctx context.Context, graph *graphBuilder
 	} else {
 		list, err := t.ctx.Clients.Typed.NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
 		if err != nil {
-			return append(warnings, fmt.Sprintf("networkpolicy list failed: %v", err))
+			return append(warnings, graphWarning("networkpolicies", "list", err))
 		}
 		policies = list.Items
 	}
+	// Resolve every policy's podSelector in one batch so N policies cost one
+	// pod List rather than N.
+	selectorErrs := make([]error, len(policies))
+	selectorIndex := make([]int, len(policies))
+	var selectors []labels.Selector
+	for i := range policies {
+		selector, err := metav1.LabelSelectorAsSelector(&policies[i].Spec.PodSelector)
+		if err != nil {
+			selectorErrs[i] = err
+			continue
+		}
+		selectorIndex[i] = len(selectors)
+		selectors = append(selectors, selector)
+	}
+	podsBySelector, lookupErr := t.podsForSelectors(ctx, namespace, selectors, cache)
 	for i := range policies {
 		policy := &policies[i]
 		policyID := graph.addNode("NetworkPolicy", "", namespace, policy.Name, nil)
-		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
-		if err != nil {
+		if err := selectorErrs[i]; err != nil {
 			warnings = append(warnings, fmt.Sprintf("networkpolicy %s selector invalid: %v", policy.Name, err))
 			continue
 		}
-		pods, err := t.podsForSelector(ctx, namespace, selector, cache)
-		if err != nil {
-			warnings = append(warnings, fmt.Sprintf("networkpolicy %s pod lookup failed: %v", policy.Name, err))
+		if lookupErr != nil {
+			warnings = append(warnings, fmt.Sprintf("networkpolicy %s: %s", policy.Name, graphWarning("pods", "list", lookupErr)))
 			continue
 		}
+		pods := podsBySelector[selectorIndex[i]]
+		selectorKeys := labelSelectorKeys(policy.Spec.PodSelector)
 		for _, pod := range pods {
-			podID := graph.addNode("Pod", "", namespace, pod.Name, map[string]any{"phase": pod.Status.Phase})
-			graph.addEdge(policyID, podID, "selects")
+			podID := graph.addNode("Pod", "", namespace, pod.Name, t.podNodeDetails(&pod))
+			graph.addEdgeWithAttributes(policyID, podID, "selects", selectorMatchAttributes(selectorKeys, pod.Labels))
 		}
 		if policyAppliesIngress(policy) && len(policy.Spec.Ingress) == 0 {
 			for _, pod := range pods {
-				podID := graph.addNode("Pod", "", namespace, pod.Name, map[string]any{"phase": pod.Status.Phase})
+				podID := graph.addNode("Pod", "", namespace, pod.Name, t.podNodeDetails(&pod))
 				graph.addEdge(podID, policyID, "blocked-by")
 			}
 		}
 		if policyAppliesEgress(policy) && len(policy.Spec.Egress) == 0 {
 			for _, pod := range pods {
-				podID := graph.addNode("Pod", "", namespace, pod.Name, map[string]any{"phase": pod.Status.Phase})
+				podID := graph.addNode("Pod", "", namespace, pod.Name, t.podNodeDetails(&pod))
 				graph.addEdge(podID, policyID, "egress-blocked-by")
 			}
 		}
@@ -480,59 +821,96 @@ type groupResource struct {
 	ShortNames []string
 }
 
+// meshGraphConcurrency bounds the resource List calls issued while building
+// the mesh graph so clusters with many mesh CRDs don't flood the API server.
+const meshGraphConcurrency = 8
+
 func (t *Toolset) addGroupResources(ctx context.Context, graph *graphBuilder, namespace, group string, serviceIndex map[string]string, cache *graphCache) []string {
+	return t.addGroupsResources(ctx, graph, namespace, []string{group}, serviceIndex, cache)
+}
+
+// addGroupsResources lists every resource type in groups with bounded
+// concurrency. Each resource is built into its own part graph, bounded by the
+// budget left in graph; the parts and warnings are then merged in discovery
+// order, so the output does not depend on scheduling. Nothing is listed once
+// graph is exhausted.
+func (t *Toolset) addGroupsResources(ctx context.Context, graph *graphBuilder, namespace string, groups []string, serviceIndex map[string]string, cache *graphCache) []string {
 	warnings := []string{}
-	resources, err := t.groupResources(group)
-	if err != nil {
-		return append(warnings, fmt.Sprintf("resource discovery failed for %s: %v", group, err))
+	if graph.exhausted() {
+		return warnings
 	}
-	for _, res := range resources {
-		if res.Namespaced {
-			list, err := t.ctx.Clients.Dynamic.Resource(res.GVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
-			if err != nil {
-				if apierrors.IsNotFound(err) {
-					continue
-				}
-				warnings = append(warnings, fmt.Sprintf("%s list failed: %v", res.GVR.Resource, err))
-				continue
-			}
-			for i := range list.Items {
-				obj := &list.Items[i]
-				nodeID := graph.addNode(res.Kind, res.Group, namespace, obj.GetName(), map[string]any{
-					"apiVersion": obj.GetAPIVersion(),
-					"resource":   res.Resource,
-					"scope":      "namespaced",
-				})
-				_ = nodeID
-				warnings = append(warnings, t.addMeshEdges(ctx, graph, obj, res, namespace, serviceIndex, cache)...)
-			}
+	var resources []groupResource
+	for _, group := range groups {
+		groupRes, discoveryWarnings, err := t.groupResources(group)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("resource discovery failed for %s: %v", group, err))
 			continue
 		}
-		list, err := t.ctx.Clients.Dynamic.Resource(res.GVR).List(ctx, metav1.ListOptions{})
+		warnings = append(warnings, discoveryWarnings...)
+		resources = append(resources, groupRes...)
+	}
+	parts := make([]*graphBuilder, len(resources))
+	results := make([][]string, len(resources))
+	var workers errgroup.Group
+	workers.SetLimit(meshGraphConcurrency)
+	for i, res := range resources {
+		parts[i] = graph.newPart()
+		workers.Go(func() error {
+			results[i] = t.addResourceObjects(ctx, parts[i], namespace, res, serviceIndex, cache)
+			return nil
+		})
+	}
+	_ = workers.Wait()
+	for i, resourceWarnings := range results {
+		graph.merge(parts[i])
+		warnings = append(warnings, resourceWarnings...)
+	}
+	return warnings
+}
+
+func (t *Toolset) addResourceObjects(ctx context.Context, graph *graphBuilder, namespace string, res groupResource, serviceIndex map[string]string, cache *graphCache) []string {
+	warnings := []string{}
+	if res.Namespaced {
+		list, err := t.ctx.Clients.Dynamic.Resource(res.GVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
 		if err != nil {
 			if apierrors.IsNotFound(err) {
-				continue
+				return warnings
 			}
-			warnings = append(warnings, fmt.Sprintf("%s list failed: %v", res.GVR.Resource, err))
-			continue
+			return append(warnings, graphWarning(res.GVR.GroupResource().String(), "list", err))
 		}
 		for i := range list.Items {
 			obj := &list.Items[i]
-			nodeID := graph.addNode(res.Kind, res.Group, "", obj.GetName(), map[string]any{
+			graph.addNode(res.Kind, res.Group, namespace, obj.GetName(), map[string]any{
 				"apiVersion": obj.GetAPIVersion(),
 				"resource":   res.Resource,
-				"scope":      "cluster",
+				"scope":      "namespaced",
 			})
-			_ = nodeID
+			warnings = append(warnings, t.addMeshEdges(ctx, graph, obj, res, namespace, serviceIndex, cache)...)
+		}
+		return warnings
+	}
+	list, err := t.ctx.Clients.Dynamic.Resource(res.GVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return warnings
 		}
+		return append(warnings, graphWarning(res.GVR.GroupResource().String(), "list", err))
+	}
+	for i := range list.Items {
+		obj := &list.Items[i]
+		graph.addNode(res.Kind, res.Group, "", obj.GetName(), map[string]any{
+			"apiVersion": obj.GetAPIVersion(),
+			"resource":   res.Resource,
+			"scope":      "cluster",
+		})
 	}
 	return warnings
 }
 
-func (t *Toolset) groupResources(group string) ([]groupResource, error) {
-	lists, err := t.ctx.Clients.Discovery.ServerPreferredResources()
-	if err != nil && !discoveryErrorIsPartial(err) {
-		return nil, err
+func (t *Toolset) groupResources(group string) ([]groupResource, []string, error) {
+	lists, warnings, err := kube.PreferredResources(t.ctx.Clients.Discovery)
+	if err != nil {
+		return nil, nil, err
 	}
 	var resources []groupResource
 	for _, list := range lists {
@@ -555,14 +933,7 @@ func (t *Toolset) groupResources(group string) ([]groupResource, error) {
 			})
 		}
 	}
-	return resources, nil
-}
-
-func discoveryErrorIsPartial(err error) bool {
-	if err == nil {
-		return false
-	}
-	return discovery.IsGroupDiscoveryFailedError(err)
+	return resources, warnings, nil
 }
 
 func (t *Toolset) addMeshEdges(ctx context.Context, graph *graphBuilder, obj *unstructured.Unstructured, res groupResource, namespace string, serviceIndex map[string]string, cache *graphCache) []string {
@@ -598,6 +969,12 @@ func (t *Toolset) addMeshEdges(ctx context.Context, graph *graphBuilder, obj *un
 	if kind == "authorizationpolicy" && res.Group == "security.istio.io" {
 		warnings = append(warnings, t.linkIstioAuthorizationPolicyToServiceAccounts(graph, obj, res, namespace)...)
 	}
+	if kind == "virtualservice" && res.Group == "networking.istio.io" {
+		warnings = append(warnings, t.linkVirtualServiceCalls(ctx, graph, obj, namespace, serviceIndex, cache)...)
+	}
+	if kind == "sidecar" && res.Group == "networking.istio.io" {
+		warnings = append(warnings, t.linkSidecarEgressCalls(ctx, graph, obj, namespace, serviceIndex, cache)...)
+	}
 
 	return warnings
 }
@@ -641,9 +1018,10 @@ func (t *Toolset) linkBySelector(ctx context.Context, graph *graphBuilder, obj *
 			warnings = append(warnings, fmt.Sprintf("selector lookup failed for %s: %v", res.Resource, err))
 			continue
 		}
+		keys := labelKeys(selector)
 		for _, pod := range pods {
-			podID := graph.addNode("Pod", "", namespace, pod.Name, map[string]any{"phase": pod.Status.Phase})
-			graph.addEdge(sourceID, podID, "applies-to")
+			podID := graph.addNode("Pod", "", namespace, pod.Name, t.podNodeDetails(&pod))
+			graph.addEdgeWithAttributes(sourceID, podID, "applies-to", selectorMatchAttributes(keys, pod.Labels))
 		}
 	}
 	return warnings
@@ -763,11 +1141,11 @@ func (t *Toolset) addNetworkPolicyPeerEdges(ctx context.Context, graph *graphBui
 		}
 		pods, err := t.podsForSelector(ctx, ns, podSelector, cache)
 		if err != nil {
-			*warnings = append(*warnings, fmt.Sprintf("networkpolicy peer pod lookup failed: %v", err))
+			*warnings = append(*warnings, fmt.Sprintf("networkpolicy peer: %s", graphWarning("pods", "list", err)))
 			continue
 		}
 		for _, pod := range pods {
-			podID := graph.addNode("Pod", "", ns, pod.Name, map[string]any{"phase": pod.Status.Phase})
+			podID := graph.addNode("Pod", "", ns, pod.Name, t.podNodeDetails(&pod))
 			graph.addEdge(policyID, podID, relation)
 		}
 	}
@@ -790,39 +1168,170 @@ func (t *Toolset) linkIstioAuthorizationPolicyToServiceAccounts(graph *graphBuil
 	return warnings
 }
 
-func istioAuthorizationPolicyPrincipals(obj *unstructured.Unstructured) []string {
-	out := []string{}
-	rules, _, _ := unstructured.NestedSlice(obj.Object, "spec", "rules")
-	for _, rule := range rules {
-		ruleMap, ok := rule.(map[string]any)
-		if !ok {
-			continue
+// linkVirtualServiceCalls adds "calls" edges from the workloads a
+// VirtualService routes for to the Services it sends them to. Callers are the
+// pods picked by a rule's sourceLabels match, or the non-mesh gateways the
+// VirtualService is bound to; mesh-wide rules without sourceLabels have no
+// identifiable caller and only keep their routes-to edges.
+func (t *Toolset) linkVirtualServiceCalls(ctx context.Context, graph *graphBuilder, obj *unstructured.Unstructured, namespace string, serviceIndex map[string]string, cache *graphCache) []string {
+	warnings := []string{}
+	edges := map[string]struct{}{}
+	addCalls := func(sourceID string, destinations []string) {
+		for _, dest := range destinations {
+			key := sourceID + " " + dest
+			if _, ok := edges[key]; ok || sourceID == dest {
+				continue
+			}
+			edges[key] = struct{}{}
+			graph.addEdge(sourceID, dest, "calls")
 		}
-		froms, ok := ruleMap["from"].([]any)
-		if !ok {
+	}
+	var gatewayIDs []string
+	for _, gateway := range nestedStringSlice(obj, "spec", "gateways") {
+		if gateway == "mesh" {
 			continue
 		}
-		for _, from := range froms {
-			fromMap, ok := from.(map[string]any)
+		// Same node as the attached-to edge in addMeshEdges.
+		name := gateway
+		if _, rest, ok := strings.Cut(gateway, "/"); ok {
+			name = rest
+		}
+		gatewayIDs = append(gatewayIDs, graph.addNode("Gateway", "networking.istio.io", namespace, name, nil))
+	}
+	for _, section := range []string{"http", "tcp", "tls"} {
+		rules, _, _ := unstructured.NestedSlice(obj.Object, "spec", section)
+		for _, raw := range rules {
+			rule, ok := raw.(map[string]any)
 			if !ok {
 				continue
 			}
-			source, ok := fromMap["source"].(map[string]any)
-			if !ok {
+			destinations := virtualServiceRuleDestinations(graph, rule, namespace, serviceIndex)
+			if len(destinations) == 0 {
 				continue
 			}
-			if principals, ok := source["principals"].([]any); ok {
-				for _, principal := range principals {
-					if value, ok := principal.(string); ok && value != "" {
-						out = append(out, value)
-					}
+			for _, gwID := range gatewayIDs {
+				addCalls(gwID, destinations)
+			}
+			matches, _ := rule["match"].([]any)
+			for _, rawMatch := range matches {
+				match, ok := rawMatch.(map[string]any)
+				if !ok {
+					continue
+				}
+				sourceLabels, _, _ := unstructured.NestedStringMap(match, "sourceLabels")
+				if len(sourceLabels) == 0 {
+					continue
+				}
+				pods, err := t.podsForSelector(ctx, namespace, labels.SelectorFromSet(sourceLabels), cache)
+				if err != nil {
+					warnings = append(warnings, fmt.Sprintf("sourceLabels lookup failed for virtualservice %s: %v", obj.GetName(), err))
+					continue
+				}
+				for _, pod := range pods {
+					podID := graph.addNode("Pod", "", namespace, pod.Name, t.podNodeDetails(&pod))
+					addCalls(podID, destinations)
 				}
 			}
 		}
 	}
+	return warnings
+}
+
+func virtualServiceRuleDestinations(graph *graphBuilder, rule map[string]any, namespace string, serviceIndex map[string]string) []string {
+	var out []string
+	routes, _ := rule["route"].([]any)
+	for _, raw := range routes {
+		route, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		destination, _ := route["destination"].(map[string]any)
+		svcNamespace, svcName, ok := meshHostService(toString(destination["host"]), namespace, serviceIndex)
+		if !ok {
+			continue
+		}
+		out = append(out, graph.addNode("Service", "", svcNamespace, svcName, nil))
+	}
 	return out
 }
 
+// linkSidecarEgressCalls adds "calls" edges from the pods a Sidecar applies to
+// (its workloadSelector, or every pod in the namespace without one) to the
+// Services named in its egress hosts. Wildcard hosts are skipped since they
+// do not name a destination.
+func (t *Toolset) linkSidecarEgressCalls(ctx context.Context, graph *graphBuilder, obj *unstructured.Unstructured, namespace string, serviceIndex map[string]string, cache *graphCache) []string {
+	warnings := []string{}
+	var destinations []string
+	egress, _, _ := unstructured.NestedSlice(obj.Object, "spec", "egress")
+	for _, raw := range egress {
+		listener, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		hosts, _ := listener["hosts"].([]any)
+		for _, rawHost := range hosts {
+			hostNamespace, host, ok := strings.Cut(toString(rawHost), "/")
+			if !ok || hostNamespace == "~" || strings.Contains(host, "*") {
+				continue
+			}
+			if hostNamespace == "." || hostNamespace == "*" {
+				hostNamespace = namespace
+			}
+			index := serviceIndex
+			if hostNamespace != namespace {
+				index = nil
+			}
+			svcNamespace, svcName, ok := meshHostService(host, hostNamespace, index)
+			if !ok {
+				continue
+			}
+			destinations = append(destinations, graph.addNode("Service", "", svcNamespace, svcName, nil))
+		}
+	}
+	if len(destinations) == 0 {
+		return warnings
+	}
+	selector := labels.Everything()
+	if workload := nestedStringMap(obj, "spec", "workloadSelector", "labels"); len(workload) > 0 {
+		selector = labels.SelectorFromSet(workload)
+	}
+	pods, err := t.podsForSelector(ctx, namespace, selector, cache)
+	if err != nil {
+		return append(warnings, fmt.Sprintf("workload lookup failed for sidecar %s: %v", obj.GetName(), err))
+	}
+	for _, pod := range pods {
+		if graph.exhausted() {
+			break
+		}
+		podID := graph.addNode("Pod", "", namespace, pod.Name, t.podNodeDetails(&pod))
+		for _, dest := range destinations {
+			graph.addEdge(podID, dest, "calls")
+		}
+	}
+	return warnings
+}
+
+// meshHostService resolves an Istio host to a Service. Short names resolve
+// through the namespace's service index; cluster-local FQDNs
+// (name.ns.svc[.cluster.local]) resolve to their own namespace.
+func meshHostService(host, namespace string, serviceIndex map[string]string) (string, string, bool) {
+	if host == "" {
+		return "", "", false
+	}
+	if svcName, ok := serviceIndex[host]; ok {
+		return namespace, svcName, true
+	}
+	parts := strings.Split(strings.TrimSuffix(host, ".cluster.local"), ".")
+	if len(parts) == 3 && parts[2] == "svc" && parts[0] != "" && parts[1] != "" {
+		return parts[1], parts[0], true
+	}
+	return "", "", false
+}
+
+func istioAuthorizationPolicyPrincipals(obj *unstructured.Unstructured) []string {
+	return istioauthz.Parse(obj).Principals()
+}
+
 func parseIstioServiceAccountPrincipal(principal string) (string, string, bool) {
 	value := strings.TrimSpace(principal)
 	if value == "" {
@@ -856,42 +1365,60 @@ func nestedStringMap(obj *unstructured.Unstructured, fields ...string) map[strin
 	return value
 }
 
-func (t *Toolset) addGatewayAPIGraph(ctx context.Context, graph *graphBuilder, namespace string, serviceIndex map[string]string) []string {
+// gatewayRouteKinds are the Gateway API route kinds added to the graph. All of
+// them attach through spec.parentRefs and route through spec.rules[].backendRefs.
+// GRPCRoute and TLSRoute are often not installed (TLSRoute is experimental
+// channel only), so failing to resolve them is not reported.
+var gatewayRouteKinds = []string{"HTTPRoute", "GRPCRoute", "TLSRoute"}
+
+func (t *Toolset) addGatewayAPIGraph(ctx context.Context, user policy.User, graph *graphBuilder, namespace string, serviceIndex map[string]string) []string {
 	warnings := []string{}
 	gvrGateway, _, err := kube.ResolveResourceBestEffort(t.ctx.Clients.Mapper, t.ctx.Clients.Discovery, "", "Gateway", "", "gateway.networking.k8s.io")
 	if err != nil {
 		return append(warnings, fmt.Sprintf("gateway api resolve failed: %v", err))
 	}
-	gvrHTTPRoute, _, err := kube.ResolveResourceBestEffort(t.ctx.Clients.Mapper, t.ctx.Clients.Discovery, "", "HTTPRoute", "", "gateway.networking.k8s.io")
-	if err != nil {
-		return append(warnings, fmt.Sprintf("httproute resolve failed: %v", err))
-	}
 	gateways, err := t.ctx.Clients.Dynamic.Resource(gvrGateway).Namespace(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil && !apierrors.IsNotFound(err) {
-		warnings = append(warnings, fmt.Sprintf("gateway list failed: %v", err))
+		warnings = append(warnings, graphWarning(gvrGateway.GroupResource().String(), "list", err))
 	}
-	routes, err := t.ctx.Clients.Dynamic.Resource(gvrHTTPRoute).Namespace(namespace).List(ctx, metav1.ListOptions{})
-	if err != nil && !apierrors.IsNotFound(err) {
-		warnings = append(warnings, fmt.Sprintf("httproute list failed: %v", err))
+	if gateways != nil {
+		for i := range gateways.Items {
+			gw := &gateways.Items[i]
+			graph.addNode("Gateway", "gateway.networking.k8s.io", namespace, gw.GetName(), nil)
+		}
 	}
-	for i := range gateways.Items {
-		gw := &gateways.Items[i]
-		graph.addNode("Gateway", "gateway.networking.k8s.io", namespace, gw.GetName(), nil)
-	}
-	for i := range routes.Items {
-		route := &routes.Items[i]
-		routeID := graph.addNode("HTTPRoute", "gateway.networking.k8s.io", namespace, route.GetName(), nil)
-		for _, parent := range nestedParentRefs(route) {
-			if parent != "" {
-				gwID := graph.addNode("Gateway", "gateway.networking.k8s.io", namespace, parent, nil)
-				graph.addEdge(routeID, gwID, "attached-to")
+	grants := newReferenceGrants(t, user)
+	for _, kind := range gatewayRouteKinds {
+		gvrRoute, _, err := kube.ResolveResourceBestEffort(t.ctx.Clients.Mapper, t.ctx.Clients.Discovery, "", kind, "", "gateway.networking.k8s.io")
+		if err != nil {
+			if kind == "HTTPRoute" {
+				warnings = append(warnings, fmt.Sprintf("httproute resolve failed: %v", err))
 			}
+			continue
 		}
-		for _, backend := range nestedBackendRefs(route) {
-			if svcName, ok := serviceIndex[backend]; ok {
-				svcID := graph.addNode("Service", "", namespace, svcName, nil)
-				graph.addEdge(routeID, svcID, "routes-to")
+		routes, err := t.ctx.Clients.Dynamic.Resource(gvrRoute).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				warnings = append(warnings, graphWarning(gvrRoute.GroupResource().String(), "list", err))
+			}
+			continue
+		}
+		for i := range routes.Items {
+			route := &routes.Items[i]
+			routeID := graph.addNode(kind, "gateway.networking.k8s.io", namespace, route.GetName(), nil)
+			for _, parent := range nestedParentRefs(route) {
+				if parent != "" {
+					gwID := graph.addNode("Gateway", "gateway.networking.k8s.io", namespace, parent, nil)
+					graph.addEdge(routeID, gwID, "attached-to")
+				}
 			}
+			for _, backend := range nestedBackendRefs(route) {
+				if svcName, ok := serviceIndex[backend]; ok {
+					svcID := graph.addNode("Service", "", namespace, svcName, nil)
+					graph.addEdge(routeID, svcID, "routes-to")
+				}
+			}
+			warnings = append(warnings, linkCrossNamespaceBackends(ctx, graph, grants, routeID, kind, route)...)
 		}
 	}
 	return warnings
@@ -913,15 +1440,15 @@ func (t *Toolset) addIstioGraph(ctx context.Context, graph *graphBuilder, namesp
 	}
 	virtuals, err := t.ctx.Clients.Dynamic.Resource(virtualGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil && !apierrors.IsNotFound(err) {
-		warnings = append(warnings, fmt.Sprintf("virtualservice list failed: %v", err))
+		warnings = append(warnings, graphWarning(virtualGVR.GroupResource().String(), "list", err))
 	}
 	dests, err := t.ctx.Clients.Dynamic.Resource(destGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil && !apierrors.IsNotFound(err) {
-		warnings = append(warnings, fmt.Sprintf("destinationrule list failed: %v", err))
+		warnings = append(warnings, graphWarning(destGVR.GroupResource().String(), "list", err))
 	}
 	gateways, err := t.ctx.Clients.Dynamic.Resource(gatewayGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil && !apierrors.IsNotFound(err) {
-		warnings = append(warnings, fmt.Sprintf("istio gateway list failed: %v", err))
+		warnings = append(warnings, graphWarning(gatewayGVR.GroupResource().String(), "list", err))
 	}
 	for i := range gateways.Items {
 		gw := &gateways.Items[i]
@@ -973,7 +1500,7 @@ func (t *Toolset) addLinkerdGraph(ctx context.Context, graph *graphBuilder, name
 	}
 	profiles, err := t.ctx.Clients.Dynamic.Resource(spGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil && !apierrors.IsNotFound(err) {
-		warnings = append(warnings, fmt.Sprintf("serviceprofile list failed: %v", err))
+		warnings = append(warnings, graphWarning(spGVR.GroupResource().String(), "list", err))
 	}
 	for i := range profiles.Items {
 		profile := &profiles.Items[i]
@@ -1037,6 +1564,9 @@ func nestedBackendRefs(obj *unstructured.Unstructured) []string {
 			if kind, ok := bm["kind"].(string); ok && kind != "" && kind != "Service" {
 				continue
 			}
+			if ns, ok := bm["namespace"].(string); ok && ns != "" && ns != obj.GetNamespace() {
+				continue
+			}
 			if name, ok := bm["name"].(string); ok && name != "" {
 				out = append(out, name)
 			}
@@ -1057,6 +1587,9 @@ func (t *Toolset) addIngressGraph(ctx context.Context, graph *graphBuilder, name
 		warnings = append(warnings, "ingress has no backend services")
 	}
 	for _, svc := range services {
+		if graph.exhausted() {
+			break
+		}
 		svcID := graph.addNode("Service", "", namespace, svc, nil)
 		graph.addEdge(ingressID, svcID, "routes-to")
 		warn, err := t.addServiceGraph(ctx, graph, namespace, svc, cache)
@@ -1074,6 +1607,9 @@ func (t *Toolset) addIngressGraph(ctx context.Context, graph *graphBuilder, name
 
 func (t *Toolset) addServiceGraph(ctx context.Context, graph *graphBuilder, namespace, name string, cache *graphCache) ([]string, error) {
 	warnings := []string{}
+	if !graph.expand(nodeID("Service", "", namespace, name)) {
+		return warnings, nil
+	}
 	service, err := t.getService(ctx, cache, namespace, name)
 	if err != nil {
 		return nil, err
@@ -1091,6 +1627,9 @@ func (t *Toolset) addServiceGraph(ctx context.Context, graph *graphBuilder, name
 		graph.addEdge(serviceID, endpointsID, "selects")
 		pods := podsFromEndpoints(endpoints)
 		for _, podName := range pods {
+			if graph.exhausted() {
+				break
+			}
 			podID := graph.addNode("Pod", "", namespace, podName, nil)
 			graph.addEdge(endpointsID, podID, "targets")
 			warn, err := t.addPodGraph(ctx, graph, namespace, podName, cache)
@@ -1114,9 +1653,13 @@ func (t *Toolset) addServiceGraph(ctx context.Context, graph *graphBuilder, name
 	if err != nil {
 		return warnings, err
 	}
+	selectorKeys := labelKeys(service.Spec.Selector)
 	for _, pod := range pods {
-		podID := graph.addNode("Pod", "", namespace, pod.Name, map[string]any{"phase": pod.Status.Phase})
-		graph.addEdge(serviceID, podID, "selects")
+		if graph.exhausted() {
+			break
+		}
+		podID := graph.addNode("Pod", "", namespace, pod.Name, t.podNodeDetails(&pod))
+		graph.addEdgeWithAttributes(serviceID, podID, "selects", selectorMatchAttributes(selectorKeys, pod.Labels))
 		warn, err := t.addPodGraph(ctx, graph, namespace, pod.Name, cache)
 		if err != nil {
 			if apierrors.IsNotFound(err) {
@@ -1146,6 +1689,9 @@ func (t *Toolset) addDeploymentGraph(ctx context.Context, graph *graphBuilder, n
 		return warnings, err
 	}
 	for _, rs := range replicasets {
+		if graph.exhausted() {
+			break
+		}
 		if !ownedBy(&rs.ObjectMeta, "Deployment", deployment.Name) {
 			continue
 		}
@@ -1199,7 +1745,10 @@ func (t *Toolset) addReplicaSetPods(ctx context.Context, graph *graphBuilder, na
 	}
 	rsID := graph.addNode("ReplicaSet", "", namespace, rs.Name, nil)
 	for _, pod := range pods {
-		podID := graph.addNode("Pod", "", namespace, pod.Name, map[string]any{"phase": pod.Status.Phase})
+		if graph.exhausted() {
+			break
+		}
+		podID := graph.addNode("Pod", "", namespace, pod.Name, t.podNodeDetails(&pod))
 		graph.addEdge(rsID, podID, "owns")
 		warn, err := t.addPodGraph(ctx, graph, namespace, pod.Name, cache)
 		if err != nil {
@@ -1230,7 +1779,10 @@ func (t *Toolset) addStatefulSetGraph(ctx context.Context, graph *graphBuilder,
 		return warnings, err
 	}
 	for _, pod := range pods {
-		podID := graph.addNode("Pod", "", namespace, pod.Name, map[string]any{"phase": pod.Status.Phase})
+		if graph.exhausted() {
+			break
+		}
+		podID := graph.addNode("Pod", "", namespace, pod.Name, t.podNodeDetails(&pod))
 		graph.addEdge(ssID, podID, "owns")
 		warn, err := t.addPodGraph(ctx, graph, namespace, pod.Name, cache)
 		if err != nil {
@@ -1275,7 +1827,10 @@ func (t *Toolset) addDaemonSetGraph(ctx context.Context, graph *graphBuilder, na
 		return warnings, err
 	}
 	for _, pod := range pods {
-		podID := graph.addNode("Pod", "", namespace, pod.Name, map[string]any{"phase": pod.Status.Phase})
+		if graph.exhausted() {
+			break
+		}
+		podID := graph.addNode("Pod", "", namespace, pod.Name, t.podNodeDetails(&pod))
 		graph.addEdge(dsID, podID, "owns")
 		warn, err := t.addPodGraph(ctx, graph, namespace, pod.Name, cache)
 		if err != nil {
@@ -1293,11 +1848,14 @@ func (t *Toolset) addDaemonSetGraph(ctx context.Context, graph *graphBuilder, na
 
 func (t *Toolset) addPodGraph(ctx context.Context, graph *graphBuilder, namespace, name string, cache *graphCache) ([]string, error) {
 	warnings := []string{}
+	if graph.exhausted() || !graph.expand(nodeID("Pod", "", namespace, name)) {
+		return warnings, nil
+	}
 	pod, err := t.getPod(ctx, cache, namespace, name)
 	if err != nil {
 		return nil, err
 	}
-	podID := graph.addNode("Pod", "", namespace, pod.Name, map[string]any{"phase": pod.Status.Phase})
+	podID := graph.addNode("Pod", "", namespace, pod.Name, t.podNodeDetails(pod))
 	owner := firstOwner(&pod.ObjectMeta)
 	if owner == nil {
 		warnings = append(warnings, "pod has no owner references")
@@ -1356,7 +1914,7 @@ func (t *Toolset) linkServicesForLabels(ctx context.Context, graph *graphBuilder
 		if selector.Matches(labels.Set(labelsMap)) {
 			svcID := graph.addNode("Service", "", namespace, svc.Name, nil)
 			targetID := graph.addNode(targetKind, "", namespace, targetName, nil)
-			graph.addEdge(svcID, targetID, "selects")
+			graph.addEdgeWithAttributes(svcID, targetID, "selects", selectorMatchAttributes(labelKeys(svc.Spec.Selector), labelsMap))
 		}
 	}
 	return warnings
@@ -1455,6 +2013,24 @@ func (t *Toolset) podsForSelector(ctx context.Context, namespace string, selecto
 	return t.ctx.Evidence.RelatedPods(ctx, namespace, selector)
 }
 
+// podsForSelectors resolves several selectors in namespace, one pod slice
+// per selector, using the graph cache when loaded and a single batched
+// evidence lookup otherwise.
+func (t *Toolset) podsForSelectors(ctx context.Context, namespace string, selectors []labels.Selector, cache *graphCache) ([][]corev1.Pod, error) {
+	if cache != nil && cache.podsLoaded {
+		out := make([][]corev1.Pod, len(selectors))
+		for i, selector := range selectors {
+			pods, err := t.podsForSelector(ctx, namespace, selector, cache)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = pods
+		}
+		return out, nil
+	}
+	return evidence.RelatedPodsForSelectors(ctx, t.ctx.Evidence, namespace, selectors)
+}
+
 func (t *Toolset) replicasetsForSelector(ctx context.Context, namespace string, selector labels.Selector, cache *graphCache) ([]appsv1.ReplicaSet, error) {
 	if cache != nil && cache.replicasetsLoaded {
 		out := make([]appsv1.ReplicaSet, 0, len(cache.replicasetList))
@@ -202,7 +202,7 @@ func TestAddGatewayAndIstioGraphWarnings(t *testing.T) {
 		Policy:  policy.NewAuthorizer(),
 	})
 	graph := newGraphBuilder()
-	_ = toolset.addGatewayAPIGraph(context.Background(), graph, namespace, map[string]string{"api": "api"})
+	_ = toolset.addGatewayAPIGraph(context.Background(), policy.User{Role: policy.RoleCluster}, graph, namespace, map[string]string{"api": "api"})
 	_ = toolset.addIstioGraph(context.Background(), graph, namespace, map[string]string{"api": "api"})
 }
 
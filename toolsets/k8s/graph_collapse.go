@@ -0,0 +1,185 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Values accepted by k8s.graph's groupBy argument.
+const (
+	graphGroupNone      = "none"
+	graphGroupWorkload  = "workload"
+	graphGroupNamespace = "namespace"
+)
+
+func parseGraphGroupBy(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", graphGroupNone:
+		return graphGroupNone, nil
+	case graphGroupWorkload:
+		return graphGroupWorkload, nil
+	case graphGroupNamespace:
+		return graphGroupNamespace, nil
+	default:
+		return "", fmt.Errorf("unsupported groupBy %q (use none, workload or namespace)", value)
+	}
+}
+
+// groupGraphPods collapses Pods that share a top-level owner (workload) or a
+// namespace into one PodGroup node carrying the count, pod names and phases,
+// so a large Deployment reads as one node. Edges touching a collapsed pod
+// are moved to its group and deduplicated, which keeps one representative
+// edge per neighbour and relation. Groups of one pod and the root node are
+// left as they are.
+func groupGraphPods(out map[string]any, groupBy, root string) map[string]any {
+	nodes, okNodes := out["nodes"].([]graphNode)
+	edges, okEdges := out["edges"].([]graphEdge)
+	if groupBy == graphGroupNone || !okNodes || !okEdges {
+		return out
+	}
+	byID := make(map[string]graphNode, len(nodes))
+	for _, node := range nodes {
+		byID[node.ID] = node
+	}
+	owners := graphOwners(edges)
+
+	members := map[string][]graphNode{}
+	for _, node := range nodes {
+		if node.Kind != "Pod" || node.ID == root {
+			continue
+		}
+		key := node.Namespace
+		if groupBy == graphGroupWorkload {
+			key = topLevelOwner(node.ID, owners)
+			if key == "" {
+				continue
+			}
+		}
+		members[key] = append(members[key], node)
+	}
+
+	groupOf := map[string]string{}
+	var groups []map[string]any
+	grouped := map[string]graphNode{}
+	for key, pods := range members {
+		if len(pods) < 2 {
+			continue
+		}
+		sort.Slice(pods, func(i, j int) bool { return pods[i].ID < pods[j].ID })
+		names := make([]string, 0, len(pods))
+		phases := map[string]int{}
+		for _, pod := range pods {
+			names = append(names, pod.Name)
+			if phase, ok := pod.Details["phase"]; ok && fmt.Sprint(phase) != "" {
+				phases[fmt.Sprint(phase)]++
+			}
+		}
+		details := map[string]any{"count": len(pods), "pods": names}
+		if len(phases) > 0 {
+			details["phases"] = phases
+		}
+		name := pods[0].Namespace
+		if groupBy == graphGroupWorkload {
+			owner := byID[key]
+			details["owner"] = key
+			details["ownerKind"] = owner.Kind
+			name = owner.Name
+			if name == "" {
+				name = key
+			}
+		}
+		group := graphNode{
+			ID:        "podgroup/" + key,
+			Kind:      "PodGroup",
+			Name:      name,
+			Namespace: pods[0].Namespace,
+			Details:   details,
+		}
+		grouped[group.ID] = group
+		for _, pod := range pods {
+			groupOf[pod.ID] = group.ID
+		}
+		groups = append(groups, map[string]any{"id": group.ID, "name": name, "count": len(pods)})
+	}
+	if len(groupOf) == 0 {
+		return out
+	}
+
+	keptNodes := make([]graphNode, 0, len(nodes))
+	for _, node := range nodes {
+		if _, collapsed := groupOf[node.ID]; !collapsed {
+			keptNodes = append(keptNodes, node)
+		}
+	}
+	for _, group := range grouped {
+		keptNodes = append(keptNodes, group)
+	}
+	sort.Slice(keptNodes, func(i, j int) bool { return keptNodes[i].ID < keptNodes[j].ID })
+
+	seen := map[graphEdgeKey]struct{}{}
+	keptEdges := make([]graphEdge, 0, len(edges))
+	for _, edge := range edges {
+		if id, ok := groupOf[edge.From]; ok {
+			edge.From = id
+		}
+		if id, ok := groupOf[edge.To]; ok {
+			edge.To = id
+		}
+		if edge.From == edge.To {
+			continue
+		}
+		key := graphEdgeKey{from: edge.From, to: edge.To, relation: edge.Relation}
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		keptEdges = append(keptEdges, edge)
+	}
+	sort.SliceStable(keptEdges, func(i, j int) bool {
+		if keptEdges[i].From != keptEdges[j].From {
+			return keptEdges[i].From < keptEdges[j].From
+		}
+		return keptEdges[i].To < keptEdges[j].To
+	})
+	sort.Slice(groups, func(i, j int) bool { return groups[i]["id"].(string) < groups[j]["id"].(string) })
+
+	result := make(map[string]any, len(out)+2)
+	for k, v := range out {
+		result[k] = v
+	}
+	result["nodes"] = keptNodes
+	result["edges"] = keptEdges
+	result["groupBy"] = groupBy
+	result["groups"] = groups
+	return result
+}
+
+// graphOwners maps each node to its owner from the owns and owned-by edges.
+func graphOwners(edges []graphEdge) map[string]string {
+	owners := map[string]string{}
+	for _, edge := range edges {
+		switch edge.Relation {
+		case "owns":
+			owners[edge.To] = edge.From
+		case "owned-by":
+			owners[edge.From] = edge.To
+		}
+	}
+	return owners
+}
+
+// topLevelOwner follows owner links up from id, e.g. Pod to ReplicaSet to
+// Deployment, and returns "" for a node without an owner.
+func topLevelOwner(id string, owners map[string]string) string {
+	current := ""
+	seen := map[string]struct{}{id: {}}
+	for next, ok := owners[id]; ok; next, ok = owners[next] {
+		if _, loop := seen[next]; loop {
+			break
+		}
+		seen[next] = struct{}{}
+		current = next
+	}
+	return current
+}
@@ -0,0 +1,81 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+)
+
+func TestGroupGraphPodsByWorkload(t *testing.T) {
+	graph := newGraphBuilder()
+	dep := graph.addNode("Deployment", "", "default", "web", nil)
+	rs := graph.addNode("ReplicaSet", "", "default", "web-rs", nil)
+	svc := graph.addNode("Service", "", "default", "web", nil)
+	graph.addEdge(dep, rs, "owns")
+	for _, name := range []string{"web-1", "web-2", "web-3"} {
+		pod := graph.addNode("Pod", "", "default", name, map[string]any{"phase": "Running"})
+		graph.addEdge(rs, pod, "owns")
+		graph.addEdge(svc, pod, "selects")
+	}
+	lone := graph.addNode("Pod", "", "default", "batch-1", nil)
+	graph.addEdge(svc, lone, "selects")
+
+	out := groupGraphPods(graph.result(graphFilter{}), graphGroupWorkload, svc)
+	nodes := out["nodes"].([]graphNode)
+	edges := out["edges"].([]graphEdge)
+	var group *graphNode
+	for i := range nodes {
+		if nodes[i].Kind == "Pod" && nodes[i].Name != "batch-1" {
+			t.Fatalf("expected workload pods collapsed, found %s", nodes[i].ID)
+		}
+		if nodes[i].Kind == "PodGroup" {
+			group = &nodes[i]
+		}
+	}
+	if group == nil || group.Details["count"] != 3 || group.Details["owner"] != dep || group.Name != "web" {
+		t.Fatalf("unexpected group node: %#v", group)
+	}
+	if phases := group.Details["phases"].(map[string]int); phases["Running"] != 3 {
+		t.Fatalf("unexpected phases: %#v", phases)
+	}
+	relations := map[string]int{}
+	for _, edge := range edges {
+		if edge.To == group.ID {
+			relations[edge.Relation]++
+		}
+	}
+	if relations["owns"] != 1 || relations["selects"] != 1 {
+		t.Fatalf("expected one representative edge per relation, got %#v", edges)
+	}
+	if groups := out["groups"].([]map[string]any); len(groups) != 1 || out["groupBy"] != graphGroupWorkload {
+		t.Fatalf("unexpected groups: %#v", out)
+	}
+
+	byNamespace := groupGraphPods(graph.result(graphFilter{}), graphGroupNamespace, svc)
+	for _, node := range byNamespace["nodes"].([]graphNode) {
+		if node.Kind == "Pod" {
+			t.Fatalf("expected all pods in the namespace grouped, found %s", node.ID)
+		}
+	}
+	if ungrouped := groupGraphPods(graph.result(graphFilter{}), graphGroupNone, svc); len(ungrouped["nodes"].([]graphNode)) != 7 {
+		t.Fatalf("expected full detail without grouping: %#v", ungrouped["nodes"])
+	}
+}
+
+func TestHandleGraphGroupBy(t *testing.T) {
+	toolset := newGraphToolset()
+	req := func(groupBy string) mcp.ToolRequest {
+		return mcp.ToolRequest{
+			User:      policy.User{Role: policy.RoleCluster},
+			Arguments: map[string]any{"kind": "service", "name": "api", "namespace": "default", "groupBy": groupBy},
+		}
+	}
+	if _, err := toolset.handleGraph(context.Background(), req("workload")); err != nil {
+		t.Fatalf("handleGraph: %v", err)
+	}
+	if _, err := toolset.handleGraph(context.Background(), req("owner")); err == nil {
+		t.Fatalf("expected unsupported groupBy error")
+	}
+}
@@ -3,11 +3,13 @@ package k8s
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	openapi_v2 "github.com/google/gnostic-models/openapiv2"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/openapi"
@@ -68,7 +70,7 @@ func TestAddMeshGraphResolveErrors(t *testing.T) {
 		Policy:  policy.NewAuthorizer(),
 	})
 	graph := newGraphBuilder()
-	if warnings := toolset.addGatewayAPIGraph(context.Background(), graph, "default", map[string]string{}); len(warnings) == 0 {
+	if warnings := toolset.addGatewayAPIGraph(context.Background(), policy.User{Role: policy.RoleCluster}, graph, "default", map[string]string{}); len(warnings) == 0 {
 		t.Fatalf("expected gateway api resolve warning")
 	}
 	if warnings := toolset.addIstioGraph(context.Background(), graph, "default", map[string]string{}); len(warnings) == 0 {
@@ -77,3 +79,62 @@ func TestAddMeshGraphResolveErrors(t *testing.T) {
 }
 
 var _ discovery.CachedDiscoveryInterface = &errorDiscoveryGroup{}
+
+// partialDiscoveryGroup resolves the istio group but reports the aggregated
+// metrics API as failed, like a cluster with a down metrics-server.
+type partialDiscoveryGroup struct {
+	apiDiscovery
+}
+
+func (d *partialDiscoveryGroup) failure() error {
+	return &discovery.ErrGroupDiscoveryFailed{Groups: map[schema.GroupVersion]error{
+		{Group: "metrics.k8s.io", Version: "v1beta1"}: errors.New("the server is currently unable to handle the request"),
+	}}
+}
+
+func (d *partialDiscoveryGroup) ServerGroups() (*metav1.APIGroupList, error) {
+	return &metav1.APIGroupList{Groups: []metav1.APIGroup{{Name: "networking.istio.io"}}}, d.failure()
+}
+
+func (d *partialDiscoveryGroup) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	lists, _ := d.apiDiscovery.ServerPreferredResources()
+	return lists, d.failure()
+}
+
+func TestPartialDiscoveryDegradesToWarnings(t *testing.T) {
+	cfg := config.DefaultConfig()
+	toolset := New()
+	_ = toolset.Init(mcp.ToolContext{
+		Config: &cfg,
+		Clients: &kube.Clients{Discovery: &partialDiscoveryGroup{apiDiscovery{resourcesByGV: map[string]*metav1.APIResourceList{
+			"networking.istio.io/v1beta1": {
+				GroupVersion: "networking.istio.io/v1beta1",
+				APIResources: []metav1.APIResource{{Name: "virtualservices", Kind: "VirtualService", Namespaced: true}},
+			},
+		}}}},
+		Policy: policy.NewAuthorizer(),
+	})
+
+	resources, warnings, err := toolset.groupResources("networking.istio.io")
+	if err != nil {
+		t.Fatalf("expected partial discovery to succeed, got %v", err)
+	}
+	if len(resources) != 1 || resources[0].Resource != "virtualservices" {
+		t.Fatalf("expected resolved istio resources, got %#v", resources)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "metrics.k8s.io/v1beta1") {
+		t.Fatalf("expected warning naming failed group, got %#v", warnings)
+	}
+
+	result, err := toolset.handleAPIResources(context.Background(), mcp.ToolRequest{
+		Arguments: map[string]any{"query": "virtualservice"},
+		User:      policy.User{Role: policy.RoleCluster},
+	})
+	if err != nil {
+		t.Fatalf("handleAPIResources: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if data["matched"] != 1 || data["warnings"] == nil {
+		t.Fatalf("expected matched resources with warnings, got %#v", data)
+	}
+}
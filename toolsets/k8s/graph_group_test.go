@@ -2,12 +2,16 @@ package k8s
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	"rootcause/internal/config"
 	"rootcause/internal/kube"
@@ -54,3 +58,138 @@ func TestAddGroupResourcesClusterScoped(t *testing.T) {
 		t.Fatalf("expected cluster node in graph")
 	}
 }
+
+func TestAddGroupsResourcesConcurrentDeterministic(t *testing.T) {
+	var objects []runtime.Object
+	resourcesByGV := map[string]*metav1.APIResourceList{}
+	var groups []string
+	for g := 0; g < 3; g++ {
+		group := fmt.Sprintf("g%d.io", g)
+		groups = append(groups, group)
+		list := &metav1.APIResourceList{GroupVersion: group + "/v1"}
+		for r := 0; r < 6; r++ {
+			kind := fmt.Sprintf("Thing%d", r)
+			list.APIResources = append(list.APIResources, metav1.APIResource{Name: fmt.Sprintf("thing%ds", r), Kind: kind, Namespaced: true})
+			objects = append(objects, &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": group + "/v1",
+				"kind":       kind,
+				"metadata":   map[string]any{"name": "obj", "namespace": "default"},
+			}})
+		}
+		resourcesByGV[group+"/v1"] = list
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+	for _, resource := range []string{"thing5s", "thing1s"} {
+		dynamicClient.PrependReactor("list", resource, func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("boom")
+		})
+	}
+
+	cfg := config.DefaultConfig()
+	toolset := New()
+	_ = toolset.Init(mcp.ToolContext{
+		Config:  &cfg,
+		Clients: &kube.Clients{Dynamic: dynamicClient, Discovery: &apiDiscovery{resourcesByGV: resourcesByGV}},
+		Policy:  policy.NewAuthorizer(),
+	})
+
+	var first []string
+	for run := 0; run < 5; run++ {
+		graph := newGraphBuilder()
+		warnings := toolset.addGroupsResources(context.Background(), graph, "default", groups, map[string]string{}, nil)
+		if len(graph.nodes) != 12 {
+			t.Fatalf("expected 12 nodes, got %d", len(graph.nodes))
+		}
+		if len(warnings) != 6 {
+			t.Fatalf("expected 6 warnings, got %#v", warnings)
+		}
+		if run == 0 {
+			first = warnings
+			continue
+		}
+		if !reflect.DeepEqual(first, warnings) {
+			t.Fatalf("warnings changed between runs: %#v vs %#v", first, warnings)
+		}
+	}
+	if first[0] != "thing1s.g0.io list failed: boom" || first[1] != "thing5s.g0.io list failed: boom" {
+		t.Fatalf("expected warnings in discovery order, got %#v", first)
+	}
+
+	var kept []string
+	var keptGroups []string
+	for run := 0; run < 5; run++ {
+		graph := newBoundedGraphBuilder(5, 0)
+		toolset.addGroupsResources(context.Background(), graph, "default", groups, map[string]string{}, nil)
+		if !graph.truncated || len(graph.nodes) != 5 {
+			t.Fatalf("expected a truncated graph of 5 nodes, got %d (truncated=%v)", len(graph.nodes), graph.truncated)
+		}
+		if run == 0 {
+			kept = graph.order
+			for _, id := range kept {
+				keptGroups = append(keptGroups, graph.nodes[id].Group)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(kept, graph.order) {
+			t.Fatalf("budgeted nodes changed between runs: %v vs %v", kept, graph.order)
+		}
+	}
+	// g0.io lists four resources successfully, so the budget keeps those and
+	// the first of g1.io.
+	if want := []string{"g0.io", "g0.io", "g0.io", "g0.io", "g1.io"}; !reflect.DeepEqual(keptGroups, want) {
+		t.Fatalf("expected the budget to follow discovery order, got %v", kept)
+	}
+}
+
+func TestGraphBuilderPartsShareRemainingBudget(t *testing.T) {
+	graph := newBoundedGraphBuilder(3, 0)
+	graph.addNode("Service", "", "default", "api", nil)
+	part := graph.newPart()
+	if part.maxNodes != 2 || part.maxEdges != 0 {
+		t.Fatalf("expected part budget of 2 nodes and unbounded edges, got %d/%d", part.maxNodes, part.maxEdges)
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		part.addNode("Thing", "sample.io", "default", name, nil)
+	}
+	if len(part.nodes) != 2 || !part.truncated {
+		t.Fatalf("expected the part to stop at the remaining budget, got %d nodes", len(part.nodes))
+	}
+	graph.merge(part)
+	if len(graph.nodes) != 3 || !graph.exhausted() {
+		t.Fatalf("expected a truncated part to exhaust the parent, got %d nodes (truncated=%v)", len(graph.nodes), graph.truncated)
+	}
+	if unbounded := newGraphBuilder().newPart(); unbounded.maxNodes != 0 || unbounded.maxEdges != 0 {
+		t.Fatalf("expected parts of an unbounded graph to be unbounded")
+	}
+}
+
+func TestAddGroupsResourcesSkipsExhaustedGraph(t *testing.T) {
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	discoveryClient := &apiDiscovery{resourcesByGV: map[string]*metav1.APIResourceList{
+		"sample.io/v1": {GroupVersion: "sample.io/v1", APIResources: []metav1.APIResource{{Name: "things", Kind: "Thing", Namespaced: true}}},
+	}}
+	cfg := config.DefaultConfig()
+	toolset := New()
+	_ = toolset.Init(mcp.ToolContext{
+		Config:  &cfg,
+		Clients: &kube.Clients{Dynamic: dynamicClient, Discovery: discoveryClient},
+		Policy:  policy.NewAuthorizer(),
+	})
+	graph := newBoundedGraphBuilder(1, 0)
+	graph.addNode("Service", "", "default", "api", nil)
+	graph.addNode("Service", "", "default", "web", nil)
+	toolset.addGroupsResources(context.Background(), graph, "default", []string{"sample.io"}, map[string]string{}, nil)
+	if actions := dynamicClient.Actions(); len(actions) != 0 {
+		t.Fatalf("expected no mesh lists once the graph is exhausted, got %v", actions)
+	}
+}
+
+func TestGraphBuilderAddNodeKeepsListedDetails(t *testing.T) {
+	graph := newGraphBuilder()
+	id := graph.addNode("Gateway", "networking.istio.io", "default", "web", nil)
+	graph.addNode("Gateway", "networking.istio.io", "default", "web", map[string]any{"scope": "namespaced"})
+	graph.addNode("Gateway", "networking.istio.io", "default", "web", nil)
+	if graph.nodes[id].Details["scope"] != "namespaced" {
+		t.Fatalf("expected listed details to replace placeholder, got %#v", graph.nodes[id])
+	}
+}
@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -115,6 +116,28 @@ func newGraphToolset() *Toolset {
 				},
 			},
 		},
+		{
+			Object: map[string]any{
+				"apiVersion": "gateway.networking.k8s.io/v1",
+				"kind":       "GRPCRoute",
+				"metadata":   map[string]any{"name": "grpc", "namespace": namespace},
+				"spec": map[string]any{
+					"parentRefs": []any{map[string]any{"name": "gateway"}},
+					"rules":      []any{map[string]any{"backendRefs": []any{map[string]any{"name": "api"}}}},
+				},
+			},
+		},
+		{
+			Object: map[string]any{
+				"apiVersion": "gateway.networking.k8s.io/v1alpha2",
+				"kind":       "TLSRoute",
+				"metadata":   map[string]any{"name": "tls", "namespace": namespace},
+				"spec": map[string]any{
+					"parentRefs": []any{map[string]any{"name": "tls-gateway"}},
+					"rules":      []any{map[string]any{"backendRefs": []any{map[string]any{"name": "api"}}}},
+				},
+			},
+		},
 		{
 			Object: map[string]any{
 				"apiVersion": "networking.istio.io/v1beta1",
@@ -179,6 +202,8 @@ func newGraphToolset() *Toolset {
 	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
 		{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"}:           "GatewayList",
 		{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"}:         "HTTPRouteList",
+		{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "grpcroutes"}:         "GRPCRouteList",
+		{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Resource: "tlsroutes"}:    "TLSRouteList",
 		{Group: "networking.istio.io", Version: "v1beta1", Resource: "virtualservices"}:     "VirtualServiceList",
 		{Group: "networking.istio.io", Version: "v1beta1", Resource: "destinationrules"}:    "DestinationRuleList",
 		{Group: "networking.istio.io", Version: "v1beta1", Resource: "gateways"}:            "GatewayList",
@@ -191,6 +216,10 @@ func newGraphToolset() *Toolset {
 		{GroupVersion: "gateway.networking.k8s.io/v1", APIResources: []metav1.APIResource{
 			{Name: "gateways", Kind: "Gateway", Namespaced: true},
 			{Name: "httproutes", Kind: "HTTPRoute", Namespaced: true},
+			{Name: "grpcroutes", Kind: "GRPCRoute", Namespaced: true},
+		}},
+		{GroupVersion: "gateway.networking.k8s.io/v1alpha2", APIResources: []metav1.APIResource{
+			{Name: "tlsroutes", Kind: "TLSRoute", Namespaced: true},
 		}},
 		{GroupVersion: "networking.istio.io/v1beta1", APIResources: []metav1.APIResource{
 			{Name: "virtualservices", Kind: "VirtualService", Namespaced: true},
@@ -211,9 +240,14 @@ func newGraphToolset() *Toolset {
 	cached := memory.NewMemCacheClient(discovery)
 	mapper := restmapper.NewDiscoveryRESTMapper([]*restmapper.APIGroupResources{
 		{
-			Group: metav1.APIGroup{Name: "gateway.networking.k8s.io", Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "gateway.networking.k8s.io/v1", Version: "v1"}}, PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "gateway.networking.k8s.io/v1", Version: "v1"}},
+			Group: metav1.APIGroup{Name: "gateway.networking.k8s.io", Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "gateway.networking.k8s.io/v1", Version: "v1"}, {GroupVersion: "gateway.networking.k8s.io/v1alpha2", Version: "v1alpha2"}}, PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "gateway.networking.k8s.io/v1", Version: "v1"}},
 			VersionedResources: map[string][]metav1.APIResource{
-				"v1": {{Name: "gateways", Kind: "Gateway", Namespaced: true}, {Name: "httproutes", Kind: "HTTPRoute", Namespaced: true}},
+				"v1": {
+					{Name: "gateways", Kind: "Gateway", Namespaced: true},
+					{Name: "httproutes", Kind: "HTTPRoute", Namespaced: true},
+					{Name: "grpcroutes", Kind: "GRPCRoute", Namespaced: true},
+				},
+				"v1alpha2": {{Name: "tlsroutes", Kind: "TLSRoute", Namespaced: true}},
 			},
 		},
 		{
@@ -303,6 +337,30 @@ func TestHandleGraphService(t *testing.T) {
 	}
 }
 
+func TestAddGatewayAPIGraphRouteKinds(t *testing.T) {
+	toolset := newGraphToolset()
+	graph := newGraphBuilder()
+	warnings := toolset.addGatewayAPIGraph(context.Background(), policy.User{Role: policy.RoleCluster}, graph, "default", map[string]string{"api": "api"})
+	if len(warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+	const gw = "gateway.networking.k8s.io"
+	svc := nodeID("Service", "", "default", "api")
+	want := []graphEdgeKey{
+		{from: nodeID("HTTPRoute", gw, "default", "route"), to: nodeID("Gateway", gw, "default", "gateway"), relation: "attached-to"},
+		{from: nodeID("HTTPRoute", gw, "default", "route"), to: svc, relation: "routes-to"},
+		{from: nodeID("GRPCRoute", gw, "default", "grpc"), to: nodeID("Gateway", gw, "default", "gateway"), relation: "attached-to"},
+		{from: nodeID("GRPCRoute", gw, "default", "grpc"), to: svc, relation: "routes-to"},
+		{from: nodeID("TLSRoute", gw, "default", "tls"), to: nodeID("Gateway", gw, "default", "tls-gateway"), relation: "attached-to"},
+		{from: nodeID("TLSRoute", gw, "default", "tls"), to: svc, relation: "routes-to"},
+	}
+	for _, key := range want {
+		if _, ok := graph.edgeIndex[key]; !ok {
+			t.Fatalf("missing edge %+v; have %+v", key, graph.edges)
+		}
+	}
+}
+
 func TestHandleGraphDeploymentKinds(t *testing.T) {
 	toolset := newGraphToolset()
 	tests := []struct {
@@ -329,3 +387,61 @@ func TestHandleGraphDeploymentKinds(t *testing.T) {
 		}
 	}
 }
+
+func TestHandleGraphTruncatesAtBudget(t *testing.T) {
+	toolset := newGraphToolset()
+	result, err := toolset.handleGraph(context.Background(), mcp.ToolRequest{
+		User: policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{
+			"kind":      "service",
+			"name":      "api",
+			"namespace": "default",
+			"maxNodes":  2,
+		},
+	})
+	if err != nil {
+		t.Fatalf("handleGraph: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	if data["truncated"] != true {
+		t.Fatalf("expected truncated graph, got %+v", data)
+	}
+	nodes := data["nodes"].([]graphNode)
+	if len(nodes) > 2 {
+		t.Fatalf("expected at most 2 nodes, got %d", len(nodes))
+	}
+	present := map[string]bool{}
+	for _, node := range nodes {
+		present[node.ID] = true
+	}
+	for _, edge := range data["edges"].([]graphEdge) {
+		if !present[edge.From] || !present[edge.To] {
+			t.Fatalf("edge to a dropped node: %+v", edge)
+		}
+	}
+	warnings, _ := data["warnings"].([]string)
+	found := false
+	for _, warning := range warnings {
+		if strings.Contains(warning, "graph truncated") && strings.Contains(warning, "includeKinds") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected truncation warning, got %v", warnings)
+	}
+}
+
+func TestGraphBuilderEdgeBudget(t *testing.T) {
+	graph := newBoundedGraphBuilder(0, 1)
+	a := graph.addNode("Pod", "", "default", "a", nil)
+	b := graph.addNode("Pod", "", "default", "b", nil)
+	graph.addEdge(a, b, "calls")
+	graph.addEdge(a, b, "calls")
+	if graph.exhausted() {
+		t.Fatalf("a duplicate edge should not use the budget")
+	}
+	graph.addEdge(b, a, "calls")
+	if !graph.exhausted() || len(graph.edges) != 1 {
+		t.Fatalf("expected the second edge to be dropped, got %+v", graph.edges)
+	}
+}
@@ -1,15 +1,16 @@
 package k8s
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/discovery"
 )
 
 func TestGraphHelperFunctions(t *testing.T) {
@@ -21,10 +22,6 @@ func TestGraphHelperFunctions(t *testing.T) {
 	if !ownedBy(&meta, "Deployment", "api") {
 		t.Fatalf("expected ownedBy true")
 	}
-	err := &discovery.ErrGroupDiscoveryFailed{Groups: map[schema.GroupVersion]error{schema.GroupVersion{Group: "x", Version: "v1"}: nil}}
-	if !discoveryErrorIsPartial(err) {
-		t.Fatalf("expected partial discovery error")
-	}
 }
 
 func TestAddNetworkPolicyPeerEdges(t *testing.T) {
@@ -65,3 +62,151 @@ func TestLinkByTargetRef(t *testing.T) {
 		t.Fatalf("expected targetRef edge")
 	}
 }
+
+func TestGraphResultFilter(t *testing.T) {
+	graph := newGraphBuilder()
+	svc := graph.addNode("Service", "", "default", "api", nil)
+	pod := graph.addNode("Pod", "", "default", "api-1", nil)
+	policy := graph.addNode("NetworkPolicy", "networking.k8s.io", "default", "deny", nil)
+	vs := graph.addNode("VirtualService", "networking.istio.io", "default", "api", nil)
+	graph.addNode("Namespace", "", "", "default", nil)
+	graph.addEdge(svc, pod, "selects")
+	graph.addEdge(policy, pod, "selects")
+	graph.addEdge(pod, policy, "blocked-by")
+	graph.addEdge(vs, svc, "routes-to")
+
+	all := graph.result(newGraphFilter(svc, map[string]any{}))
+	if len(all["nodes"].([]graphNode)) != 5 || len(all["edges"].([]graphEdge)) != 4 {
+		t.Fatalf("expected unfiltered graph, got %#v", all)
+	}
+
+	byRelation := graph.result(newGraphFilter(svc, map[string]any{"includeRelations": []any{"Blocked-By"}}))
+	edges := byRelation["edges"].([]graphEdge)
+	nodes := byRelation["nodes"].([]graphNode)
+	if len(edges) != 1 || edges[0].Relation != "blocked-by" {
+		t.Fatalf("expected only blocked-by edge, got %#v", edges)
+	}
+	// Root service is kept even though it has no remaining edges.
+	if len(nodes) != 3 || nodes[2].ID != svc {
+		t.Fatalf("expected pod, policy and root, got %#v", nodes)
+	}
+
+	byKind := graph.result(newGraphFilter(svc, map[string]any{"includeKinds": []any{"networkpolicy", "pod"}}))
+	if got := len(byKind["edges"].([]graphEdge)); got != 3 {
+		t.Fatalf("expected root-to-pod and policy edges, got %d", got)
+	}
+
+	excluded := graph.result(newGraphFilter(svc, map[string]any{"excludeKinds": []any{"Pod"}}))
+	edges = excluded["edges"].([]graphEdge)
+	if len(edges) != 1 || edges[0].From != vs {
+		t.Fatalf("expected only the mesh edge, got %#v", edges)
+	}
+	if len(excluded["nodes"].([]graphNode)) != 2 {
+		t.Fatalf("expected orphaned nodes pruned, got %#v", excluded["nodes"])
+	}
+
+	if newGraphFilter(svc, map[string]any{}).key() != "" {
+		t.Fatalf("expected empty cache key suffix without filters")
+	}
+	if a, b := newGraphFilter(svc, map[string]any{"includeKinds": []any{"Pod", "Service"}}).key(), newGraphFilter(svc, map[string]any{"includeKinds": []any{"service", "pod"}}).key(); a != b {
+		t.Fatalf("expected order-insensitive filter key, got %q and %q", a, b)
+	}
+}
+
+func TestGraphResultEdgesDedupedAndSorted(t *testing.T) {
+	build := func(reverse bool) []byte {
+		graph := newGraphBuilder()
+		svc := graph.addNode("Service", "", "default", "api", nil)
+		pods := []string{
+			graph.addNode("Pod", "", "default", "api-1", nil),
+			graph.addNode("Pod", "", "default", "api-2", nil),
+		}
+		eps := graph.addNode("Endpoints", "", "default", "api", nil)
+		edges := [][3]string{
+			{svc, pods[0], "selects"},
+			{svc, pods[1], "selects"},
+			{eps, pods[0], "targets"},
+			{svc, pods[0], "selects"},
+			{svc, eps, "has-endpoints"},
+		}
+		if reverse {
+			for i, j := 0, len(edges)-1; i < j; i, j = i+1, j-1 {
+				edges[i], edges[j] = edges[j], edges[i]
+			}
+		}
+		for _, edge := range edges {
+			graph.addEdge(edge[0], edge[1], edge[2])
+		}
+		raw, err := json.Marshal(graph.result(graphFilter{}))
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		return raw
+	}
+	forward, backward := build(false), build(true)
+	if !bytes.Equal(forward, backward) {
+		t.Fatalf("expected identical output:\n%s\n%s", forward, backward)
+	}
+	var out struct {
+		Edges []graphEdge `json:"edges"`
+	}
+	if err := json.Unmarshal(forward, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(out.Edges) != 4 {
+		t.Fatalf("expected duplicate edge dropped, got %#v", out.Edges)
+	}
+}
+
+func TestGraphEdgeAttributes(t *testing.T) {
+	graph := newGraphBuilder()
+	svc := graph.addNode("Service", "", "default", "web", nil)
+	pod := graph.addNode("Pod", "", "default", "web-1", nil)
+	graph.addEdge(svc, pod, "selects")
+	attrs := selectorMatchAttributes([]string{"tier", "app", "app"}, map[string]string{"app": "web", "tier": "frontend", "pod-template-hash": "abc"})
+	graph.addEdgeWithAttributes(svc, pod, "selects", attrs)
+	if len(graph.edges) != 1 {
+		t.Fatalf("expected duplicate edge to merge, got %#v", graph.edges)
+	}
+	if got := graph.edges[0].Attributes["matchedLabels"]; got != "app=web,tier=frontend" {
+		t.Fatalf("unexpected matched labels %q", got)
+	}
+	if selectorMatchAttributes(nil, map[string]string{"app": "web"}) != nil {
+		t.Fatalf("expected nil attributes for an empty selector")
+	}
+	if keys := labelSelectorKeys(metav1.LabelSelector{
+		MatchLabels:      map[string]string{"app": "web"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: metav1.LabelSelectorOpExists}},
+	}); len(keys) != 2 {
+		t.Fatalf("unexpected selector keys %#v", keys)
+	}
+}
+
+func TestLinkServicesForLabelsRecordsMatchedLabels(t *testing.T) {
+	toolset := newGraphToolset()
+	graph := newGraphBuilder()
+	cache, _ := toolset.buildGraphCache(context.Background(), "default", true)
+	_ = toolset.linkServicesForLabels(context.Background(), graph, "default", map[string]string{"app": "api", "version": "v2"}, "Pod", "api-1", cache)
+	if len(graph.edges) != 1 || graph.edges[0].Attributes["matchedLabels"] != "app=api" {
+		t.Fatalf("expected matched labels on selects edge, got %#v", graph.edges)
+	}
+}
+
+func TestPodNodeDetailsAge(t *testing.T) {
+	toolset := newGraphToolset()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1", CreationTimestamp: metav1.NewTime(time.Now().Add(-90 * time.Minute))},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	details := toolset.podNodeDetails(pod)
+	if details["phase"] != corev1.PodRunning || details["age"] != "90m" {
+		t.Fatalf("expected phase and age, got %#v", details)
+	}
+	if seconds, ok := details["ageSeconds"].(int64); !ok || seconds < 5400 {
+		t.Fatalf("expected ageSeconds, got %#v", details["ageSeconds"])
+	}
+	toolset.ctx.Config.Render.OmitAge = true
+	if details := toolset.podNodeDetails(pod); details["age"] != nil {
+		t.Fatalf("expected render.omit_age to drop age, got %#v", details)
+	}
+}
@@ -209,7 +209,7 @@ func TestAddMeshGraphCoverage(t *testing.T) {
 
 	graph := newGraphBuilder()
 	cache, _ := toolset.buildGraphCache(context.Background(), namespace, true)
-	warnings := toolset.addMeshGraph(context.Background(), graph, namespace, cache)
+	warnings := toolset.addMeshGraph(context.Background(), policy.User{Role: policy.RoleCluster}, graph, namespace, cache)
 	if len(graph.nodes) == 0 || len(graph.edges) == 0 {
 		t.Fatalf("expected mesh graph nodes/edges")
 	}
@@ -217,3 +217,99 @@ func TestAddMeshGraphCoverage(t *testing.T) {
 		t.Fatalf("expected warnings slice")
 	}
 }
+
+func TestMeshCallsEdges(t *testing.T) {
+	namespace := "default"
+	frontend := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "frontend-1", Namespace: namespace, Labels: map[string]string{"app": "frontend"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	worker := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Namespace: namespace, Labels: map[string]string{"app": "worker"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	toolset := newDebugToolset(frontend, worker)
+	serviceIndex := buildServiceIndex(namespace, []corev1.Service{
+		{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: namespace}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "api-canary", Namespace: namespace}},
+	})
+
+	virtualService := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "api", "namespace": namespace},
+		"spec": map[string]any{
+			"hosts":    []any{"api"},
+			"gateways": []any{"mesh", "istio-system/public"},
+			"http": []any{
+				map[string]any{
+					"match": []any{map[string]any{"sourceLabels": map[string]any{"app": "frontend"}}},
+					"route": []any{map[string]any{"destination": map[string]any{"host": "api-canary"}}},
+				},
+				map[string]any{
+					"route": []any{
+						map[string]any{"destination": map[string]any{"host": "api"}},
+						map[string]any{"destination": map[string]any{"host": "payments.billing.svc.cluster.local"}},
+						map[string]any{"destination": map[string]any{"host": "example.com"}},
+					},
+				},
+			},
+		},
+	}}
+	sidecar := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "worker", "namespace": namespace},
+		"spec": map[string]any{
+			"workloadSelector": map[string]any{"labels": map[string]any{"app": "worker"}},
+			"egress":           []any{map[string]any{"hosts": []any{"./api.default.svc.cluster.local", "billing/payments.billing.svc.cluster.local", "istio-system/*", "~/*"}}},
+		},
+	}}
+
+	graph := newGraphBuilder()
+	if warnings := toolset.linkVirtualServiceCalls(context.Background(), graph, virtualService, namespace, serviceIndex, nil); len(warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+	if warnings := toolset.linkSidecarEgressCalls(context.Background(), graph, sidecar, namespace, serviceIndex, nil); len(warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+
+	calls := map[string]bool{}
+	for _, edge := range graph.edges {
+		if edge.Relation != "calls" {
+			t.Fatalf("unexpected relation %q", edge.Relation)
+		}
+		calls[edge.From+" -> "+edge.To] = true
+	}
+	for _, want := range []string{
+		"pod/default/frontend-1 -> service/default/api-canary",
+		"gateway.networking.istio.io/default/public -> service/default/api-canary",
+		"gateway.networking.istio.io/default/public -> service/default/api",
+		"gateway.networking.istio.io/default/public -> service/billing/payments",
+		"pod/default/worker-1 -> service/default/api",
+		"pod/default/worker-1 -> service/billing/payments",
+	} {
+		if !calls[want] {
+			t.Fatalf("missing calls edge %s; got %v", want, calls)
+		}
+	}
+	if len(calls) != 6 {
+		t.Fatalf("expected 6 calls edges, got %v", calls)
+	}
+}
+
+func TestMeshHostService(t *testing.T) {
+	index := map[string]string{"api": "api"}
+	cases := []struct {
+		host, ns, name string
+		ok             bool
+	}{
+		{"api", "default", "api", true},
+		{"payments.billing.svc", "billing", "payments", true},
+		{"payments.billing.svc.cluster.local", "billing", "payments", true},
+		{"example.com", "", "", false},
+		{"", "", "", false},
+	}
+	for _, tc := range cases {
+		ns, name, ok := meshHostService(tc.host, "default", index)
+		if ns != tc.ns || name != tc.name || ok != tc.ok {
+			t.Fatalf("meshHostService(%q) = %q, %q, %v", tc.host, ns, name, ok)
+		}
+	}
+}
@@ -21,7 +21,7 @@ func TestAddMeshGraphDiscoveryError(t *testing.T) {
 	cache := newGraphCache()
 	cache.servicesLoaded = true
 	graph := newGraphBuilder()
-	warnings := toolset.addMeshGraph(context.Background(), graph, "default", cache)
+	warnings := toolset.addMeshGraph(context.Background(), policy.User{Role: policy.RoleCluster}, graph, "default", cache)
 	if len(warnings) == 0 {
 		t.Fatalf("expected mesh graph warnings with missing discovery client")
 	}
@@ -0,0 +1,163 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"rootcause/internal/kube"
+	"rootcause/internal/policy"
+)
+
+// gatewayBackendRef is a Service backendRef of a Gateway API route.
+type gatewayBackendRef struct {
+	name      string
+	namespace string
+}
+
+// crossNamespaceBackendRefs returns the Service backendRefs of a route that
+// point outside the route's namespace. The Gateway API only honours those
+// when a ReferenceGrant in the target namespace allows them.
+func crossNamespaceBackendRefs(obj *unstructured.Unstructured) []gatewayBackendRef {
+	var out []gatewayBackendRef
+	rules, _, _ := unstructured.NestedSlice(obj.Object, "spec", "rules")
+	for _, rule := range rules {
+		ruleMap, ok := rule.(map[string]any)
+		if !ok {
+			continue
+		}
+		backendRefs, _, _ := unstructured.NestedSlice(ruleMap, "backendRefs")
+		for _, backend := range backendRefs {
+			bm, ok := backend.(map[string]any)
+			if !ok {
+				continue
+			}
+			if kind, ok := bm["kind"].(string); ok && kind != "" && kind != "Service" {
+				continue
+			}
+			name, _ := bm["name"].(string)
+			namespace, _ := bm["namespace"].(string)
+			if name == "" || namespace == "" || namespace == obj.GetNamespace() {
+				continue
+			}
+			out = append(out, gatewayBackendRef{name: name, namespace: namespace})
+		}
+	}
+	return out
+}
+
+// referenceGrants lists ReferenceGrants per namespace once per graph build.
+type referenceGrants struct {
+	t      *Toolset
+	user   policy.User
+	byNS   map[string][]unstructured.Unstructured
+	failed map[string]bool
+}
+
+func newReferenceGrants(t *Toolset, user policy.User) *referenceGrants {
+	return &referenceGrants{t: t, user: user, byNS: map[string][]unstructured.Unstructured{}, failed: map[string]bool{}}
+}
+
+// list returns the grants in namespace. A failed lookup is reported once and
+// then treated as unknown, so a missing grant is never claimed on an error.
+// Namespaces the user may not read are unknown too, without a warning, so
+// neither grant names nor their absence leak across the policy boundary.
+func (r *referenceGrants) list(ctx context.Context, namespace string) ([]unstructured.Unstructured, bool, string) {
+	if grants, ok := r.byNS[namespace]; ok {
+		return grants, true, ""
+	}
+	if r.failed[namespace] {
+		return nil, false, ""
+	}
+	if err := r.t.ctx.Policy.CheckNamespace(r.user, namespace, true); err != nil {
+		r.failed[namespace] = true
+		return nil, false, ""
+	}
+	gvr, _, err := kube.ResolveResourceBestEffort(r.t.ctx.Clients.Mapper, r.t.ctx.Clients.Discovery, "", "ReferenceGrant", "", "gateway.networking.k8s.io")
+	if err != nil {
+		r.failed[namespace] = true
+		return nil, false, fmt.Sprintf("referencegrant resolve failed: %v", err)
+	}
+	list, err := r.t.ctx.Clients.Dynamic.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		r.failed[namespace] = true
+		return nil, false, graphWarning(gvr.GroupResource().String(), "list", err)
+	}
+	var grants []unstructured.Unstructured
+	if list != nil {
+		grants = list.Items
+	}
+	r.byNS[namespace] = grants
+	return grants, true, ""
+}
+
+// referenceGrantAllows reports whether grant lets routes of fromKind in
+// fromNamespace reference the Service toName in the grant's namespace.
+func referenceGrantAllows(grant *unstructured.Unstructured, fromKind, fromNamespace, toName string) bool {
+	fromMatch := false
+	from, _, _ := unstructured.NestedSlice(grant.Object, "spec", "from")
+	for _, raw := range from {
+		entry, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		if entry["group"] == "gateway.networking.k8s.io" && entry["kind"] == fromKind && entry["namespace"] == fromNamespace {
+			fromMatch = true
+			break
+		}
+	}
+	if !fromMatch {
+		return false
+	}
+	to, _, _ := unstructured.NestedSlice(grant.Object, "spec", "to")
+	for _, raw := range to {
+		entry, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		group, _ := entry["group"].(string)
+		name, _ := entry["name"].(string)
+		if group == "" && entry["kind"] == "Service" && (name == "" || name == toName) {
+			return true
+		}
+	}
+	return false
+}
+
+// linkCrossNamespaceBackends adds a routes-to edge, naming the permitting
+// ReferenceGrant, for each cross-namespace backend a grant allows, and a
+// missing-reference-grant edge plus a warning for each one it does not.
+// Without the grant the implementation drops the backend, which is the usual
+// reason a cross-namespace route silently sends no traffic.
+func linkCrossNamespaceBackends(ctx context.Context, graph *graphBuilder, grants *referenceGrants, routeID, kind string, route *unstructured.Unstructured) []string {
+	var warnings []string
+	for _, backend := range crossNamespaceBackendRefs(route) {
+		list, known, warning := grants.list(ctx, backend.namespace)
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+		svcID := graph.addNode("Service", "", backend.namespace, backend.name, nil)
+		if !known {
+			graph.addEdge(routeID, svcID, "routes-to")
+			continue
+		}
+		permitted := ""
+		for i := range list {
+			if referenceGrantAllows(&list[i], kind, route.GetNamespace(), backend.name) {
+				permitted = list[i].GetName()
+				break
+			}
+		}
+		if permitted != "" {
+			graph.addEdgeWithAttributes(routeID, svcID, "routes-to", map[string]string{"referenceGrant": permitted})
+			continue
+		}
+		graph.addEdge(routeID, svcID, "missing-reference-grant")
+		warnings = append(warnings, fmt.Sprintf("%s %s/%s references Service %s/%s but no ReferenceGrant in %s allows it; the backend is not routed",
+			kind, route.GetNamespace(), route.GetName(), backend.namespace, backend.name, backend.namespace))
+	}
+	return warnings
+}
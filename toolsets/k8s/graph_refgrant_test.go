@@ -0,0 +1,169 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	discoveryfake "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/restmapper"
+	clienttesting "k8s.io/client-go/testing"
+
+	"rootcause/internal/config"
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+)
+
+func newReferenceGrantToolset(objects ...runtime.Object) *Toolset {
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"}:             "GatewayList",
+		{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"}:           "HTTPRouteList",
+		{Group: "gateway.networking.k8s.io", Version: "v1beta1", Resource: "referencegrants"}: "ReferenceGrantList",
+	}, objects...)
+	resources := []*metav1.APIResourceList{
+		{GroupVersion: "gateway.networking.k8s.io/v1", APIResources: []metav1.APIResource{
+			{Name: "gateways", Kind: "Gateway", Namespaced: true},
+			{Name: "httproutes", Kind: "HTTPRoute", Namespaced: true},
+		}},
+		{GroupVersion: "gateway.networking.k8s.io/v1beta1", APIResources: []metav1.APIResource{
+			{Name: "referencegrants", Kind: "ReferenceGrant", Namespaced: true},
+		}},
+	}
+	mapper := restmapper.NewDiscoveryRESTMapper([]*restmapper.APIGroupResources{{
+		Group: metav1.APIGroup{
+			Name:             "gateway.networking.k8s.io",
+			Versions:         []metav1.GroupVersionForDiscovery{{GroupVersion: "gateway.networking.k8s.io/v1", Version: "v1"}, {GroupVersion: "gateway.networking.k8s.io/v1beta1", Version: "v1beta1"}},
+			PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "gateway.networking.k8s.io/v1", Version: "v1"},
+		},
+		VersionedResources: map[string][]metav1.APIResource{
+			"v1":      resources[0].APIResources,
+			"v1beta1": resources[1].APIResources,
+		},
+	}})
+	discovery := memory.NewMemCacheClient(&discoveryfake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: resources}})
+	cfg := config.DefaultConfig()
+	toolset := New()
+	_ = toolset.Init(mcp.ToolContext{
+		Config:  &cfg,
+		Clients: &kube.Clients{Dynamic: dyn, Discovery: discovery, Mapper: mapper},
+		Policy:  policy.NewAuthorizer(),
+	})
+	return toolset
+}
+
+func TestAddGatewayAPIGraphReferenceGrants(t *testing.T) {
+	route := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "HTTPRoute",
+		"metadata":   map[string]any{"name": "shop", "namespace": "default"},
+		"spec": map[string]any{
+			"rules": []any{map[string]any{"backendRefs": []any{
+				map[string]any{"name": "api", "namespace": "shop"},
+				map[string]any{"name": "db", "namespace": "shop"},
+				map[string]any{"name": "web", "namespace": "default"},
+			}}},
+		},
+	}}
+	grant := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "gateway.networking.k8s.io/v1beta1",
+		"kind":       "ReferenceGrant",
+		"metadata":   map[string]any{"name": "allow-default", "namespace": "shop"},
+		"spec": map[string]any{
+			"from": []any{map[string]any{"group": "gateway.networking.k8s.io", "kind": "HTTPRoute", "namespace": "default"}},
+			"to":   []any{map[string]any{"group": "", "kind": "Service", "name": "api"}},
+		},
+	}}
+	toolset := newReferenceGrantToolset(route, grant)
+	graph := newGraphBuilder()
+	warnings := toolset.addGatewayAPIGraph(context.Background(), policy.User{Role: policy.RoleCluster}, graph, "default", map[string]string{"web": "web"})
+
+	const gw = "gateway.networking.k8s.io"
+	routeID := nodeID("HTTPRoute", gw, "default", "shop")
+	allowed, ok := graph.edgeIndex[graphEdgeKey{from: routeID, to: nodeID("Service", "", "shop", "api"), relation: "routes-to"}]
+	if !ok {
+		t.Fatalf("expected routes-to edge for the granted backend: %+v", graph.edges)
+	}
+	if graph.edges[allowed].Attributes["referenceGrant"] != "allow-default" {
+		t.Fatalf("expected referenceGrant attribute, got %+v", graph.edges[allowed])
+	}
+	if _, ok := graph.edgeIndex[graphEdgeKey{from: routeID, to: nodeID("Service", "", "shop", "db"), relation: "missing-reference-grant"}]; !ok {
+		t.Fatalf("expected missing-reference-grant edge: %+v", graph.edges)
+	}
+	if _, ok := graph.edgeIndex[graphEdgeKey{from: routeID, to: nodeID("Service", "", "default", "web"), relation: "routes-to"}]; !ok {
+		t.Fatalf("expected same-namespace backend to route: %+v", graph.edges)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "shop/db") || !strings.Contains(warnings[0], "ReferenceGrant") {
+		t.Fatalf("expected one missing grant warning, got %v", warnings)
+	}
+}
+
+func TestAddGatewayAPIGraphReferenceGrantsRestrictedUser(t *testing.T) {
+	route := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "HTTPRoute",
+		"metadata":   map[string]any{"name": "shop", "namespace": "default"},
+		"spec": map[string]any{
+			"rules": []any{map[string]any{"backendRefs": []any{
+				map[string]any{"name": "api", "namespace": "shop"},
+				map[string]any{"name": "db", "namespace": "shop"},
+			}}},
+		},
+	}}
+	grant := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "gateway.networking.k8s.io/v1beta1",
+		"kind":       "ReferenceGrant",
+		"metadata":   map[string]any{"name": "allow-default", "namespace": "shop"},
+		"spec": map[string]any{
+			"from": []any{map[string]any{"group": "gateway.networking.k8s.io", "kind": "HTTPRoute", "namespace": "default"}},
+			"to":   []any{map[string]any{"group": "", "kind": "Service", "name": "api"}},
+		},
+	}}
+	toolset := newReferenceGrantToolset(route, grant)
+	graph := newGraphBuilder()
+	user := policy.User{Role: policy.RoleNamespace, AllowedNamespaces: []string{"default"}}
+	warnings := toolset.addGatewayAPIGraph(context.Background(), user, graph, "default", map[string]string{})
+
+	if len(warnings) != 0 {
+		t.Fatalf("expected no grant warnings for a namespace the user cannot read, got %v", warnings)
+	}
+	routeID := nodeID("HTTPRoute", "gateway.networking.k8s.io", "default", "shop")
+	for _, name := range []string{"api", "db"} {
+		idx, ok := graph.edgeIndex[graphEdgeKey{from: routeID, to: nodeID("Service", "", "shop", name), relation: "routes-to"}]
+		if !ok {
+			t.Fatalf("expected plain routes-to edge for shop/%s: %+v", name, graph.edges)
+		}
+		if len(graph.edges[idx].Attributes) != 0 {
+			t.Fatalf("expected no grant details for shop/%s, got %+v", name, graph.edges[idx])
+		}
+	}
+	for _, action := range toolset.ctx.Clients.Dynamic.(*dynamicfake.FakeDynamicClient).Actions() {
+		if action.GetResource().Resource == "referencegrants" {
+			t.Fatalf("expected no ReferenceGrant list in a denied namespace, got %v", action)
+		}
+	}
+}
+
+func TestReferenceGrantAllows(t *testing.T) {
+	grant := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"from": []any{map[string]any{"group": "gateway.networking.k8s.io", "kind": "GRPCRoute", "namespace": "edge"}},
+			"to":   []any{map[string]any{"group": "", "kind": "Service"}},
+		},
+	}}
+	if !referenceGrantAllows(grant, "GRPCRoute", "edge", "any") {
+		t.Fatalf("expected a grant without a name to allow every Service")
+	}
+	if referenceGrantAllows(grant, "HTTPRoute", "edge", "any") {
+		t.Fatalf("expected the grant not to cover HTTPRoute")
+	}
+	if referenceGrantAllows(grant, "GRPCRoute", "other", "any") {
+		t.Fatalf("expected the grant not to cover another namespace")
+	}
+}
@@ -0,0 +1,92 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"rootcause/internal/config"
+	"rootcause/internal/evidence"
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+)
+
+func TestAddServiceGraphExpandsEachPodOnce(t *testing.T) {
+	const namespace = "default"
+	podLabels := map[string]string{"app": "web"}
+	replicas := int32(2)
+	objects := []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: namespace},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: podLabels},
+				Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: podLabels}},
+			},
+		},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-rs", Namespace: namespace, OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web"}}},
+			Spec:       appsv1.ReplicaSetSpec{Replicas: &replicas, Selector: &metav1.LabelSelector{MatchLabels: podLabels}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: namespace},
+			Spec:       corev1.ServiceSpec{Selector: podLabels},
+		},
+	}
+	var addresses []corev1.EndpointAddress
+	for i := 1; i <= 2; i++ {
+		name := fmt.Sprintf("web-%d", i)
+		objects = append(objects, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: namespace, Labels: podLabels,
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-rs"}},
+		}})
+		addresses = append(addresses, corev1.EndpointAddress{TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: name}})
+	}
+	objects = append(objects, &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: namespace},
+		Subsets:    []corev1.EndpointSubset{{Addresses: addresses}},
+	})
+	client := k8sfake.NewSimpleClientset(objects...)
+	podGets := 0
+	client.PrependReactor("get", "pods", func(clienttesting.Action) (bool, runtime.Object, error) {
+		podGets++
+		return false, nil, nil
+	})
+	cfg := config.DefaultConfig()
+	toolset := New()
+	clients := &kube.Clients{Typed: client}
+	_ = toolset.Init(mcp.ToolContext{Config: &cfg, Clients: clients, Policy: policy.NewAuthorizer(), Evidence: evidence.NewCollector(clients)})
+
+	graph := newGraphBuilder()
+	// The Service reaches each pod twice (Endpoints and selector), and each
+	// pod links back to the Service; without the visited set every pod
+	// would be expanded once per path.
+	if _, err := toolset.addServiceGraph(context.Background(), graph, namespace, "web", nil); err != nil {
+		t.Fatalf("addServiceGraph: %v", err)
+	}
+	if _, err := toolset.addServiceGraph(context.Background(), graph, namespace, "web", nil); err != nil {
+		t.Fatalf("addServiceGraph again: %v", err)
+	}
+	if podGets != 2 {
+		t.Fatalf("expected each pod to be expanded once, got %d pod lookups", podGets)
+	}
+	serviceID := nodeID("Service", "", namespace, "web")
+	rsID := nodeID("ReplicaSet", "", namespace, "web-rs")
+	for _, key := range []graphEdgeKey{
+		{from: serviceID, to: nodeID("Pod", "", namespace, "web-1"), relation: "selects"},
+		{from: nodeID("Pod", "", namespace, "web-2"), to: rsID, relation: "owned-by"},
+		{from: rsID, to: nodeID("Deployment", "", namespace, "web"), relation: "owned-by"},
+	} {
+		if _, ok := graph.edgeIndex[key]; !ok {
+			t.Fatalf("missing edge %+v; have %+v", key, graph.edges)
+		}
+	}
+}
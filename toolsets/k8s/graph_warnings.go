@@ -0,0 +1,72 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// graphAccessCause names an RBAC rule the server's identity is missing,
+// derived from a forbidden lookup while building the graph.
+type graphAccessCause struct {
+	Resource   string `json:"resource"`
+	Verb       string `json:"verb"`
+	Suggestion string `json:"suggestion"`
+}
+
+// forbiddenWarningPattern matches the warnings graphWarning emits for
+// Forbidden errors so graphAccessCauses can recover the resource and verb.
+var forbiddenWarningPattern = regexp.MustCompile(`(\S+) (\S+) forbidden: `)
+
+// graphWarning turns a failed lookup into a warning that says what to do
+// about it. resource is the RBAC resource name (plural, group-qualified for
+// CRDs) so a Forbidden warning can be pasted into a Role.
+func graphWarning(resource, verb string, err error) string {
+	switch {
+	case apierrors.IsForbidden(err):
+		return fmt.Sprintf("%s %s forbidden: grant %s on %s to the rootcause service account (%v)", resource, verb, rbacVerbs(verb), resource, err)
+	case apierrors.IsNotFound(err):
+		return fmt.Sprintf("%s %s skipped: resource not found on this cluster", resource, verb)
+	case apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err) || errors.Is(err, context.DeadlineExceeded):
+		return fmt.Sprintf("%s %s timed out: the API server did not answer in time; retry or raise the request timeout (%v)", resource, verb, err)
+	}
+	return fmt.Sprintf("%s %s failed: %v", resource, verb, err)
+}
+
+// rbacVerbs lists the verbs a lookup needs; listing is only useful together
+// with get, so both are suggested.
+func rbacVerbs(verb string) string {
+	if verb == "list" {
+		return "get/list"
+	}
+	return verb
+}
+
+// graphAccessCauses collects one cause per forbidden resource and verb found
+// in warnings, in the order they first appear.
+func graphAccessCauses(warnings []string) []graphAccessCause {
+	var causes []graphAccessCause
+	seen := map[string]struct{}{}
+	for _, warning := range warnings {
+		match := forbiddenWarningPattern.FindStringSubmatch(warning)
+		if match == nil {
+			continue
+		}
+		resource, verb := match[1], match[2]
+		key := resource + "/" + verb
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		causes = append(causes, graphAccessCause{
+			Resource:   resource,
+			Verb:       verb,
+			Suggestion: fmt.Sprintf("Grant %s on %s to the rootcause service account; the graph is missing these objects and their edges.", strings.ReplaceAll(rbacVerbs(verb), "/", " and "), resource),
+		})
+	}
+	return causes
+}
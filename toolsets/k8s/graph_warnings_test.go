@@ -0,0 +1,50 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestGraphWarningClassifiesErrors(t *testing.T) {
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "deployments"}, "", errors.New("rbac"))
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{forbidden, "deployments list forbidden: grant get/list on deployments"},
+		{apierrors.NewNotFound(schema.GroupResource{Resource: "deployments"}, ""), "deployments list skipped"},
+		{apierrors.NewTimeoutError("slow", 1), "deployments list timed out"},
+		{context.DeadlineExceeded, "deployments list timed out"},
+		{errors.New("boom"), "deployments list failed: boom"},
+	}
+	for _, tc := range cases {
+		if got := graphWarning("deployments", "list", tc.err); !strings.HasPrefix(got, tc.want) {
+			t.Fatalf("expected %q prefix, got %q", tc.want, got)
+		}
+	}
+}
+
+func TestGraphAccessCauses(t *testing.T) {
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("rbac"))
+	warnings := []string{
+		graphWarning("pods", "list", forbidden),
+		"networkpolicy web: " + graphWarning("pods", "list", forbidden),
+		graphWarning("virtualservices.networking.istio.io", "list", forbidden),
+		graphWarning("services", "list", errors.New("boom")),
+	}
+	causes := graphAccessCauses(warnings)
+	if len(causes) != 2 {
+		t.Fatalf("expected 2 causes, got %#v", causes)
+	}
+	if causes[0].Resource != "pods" || causes[0].Verb != "list" || !strings.Contains(causes[0].Suggestion, "get and list on pods") {
+		t.Fatalf("unexpected cause: %#v", causes[0])
+	}
+	if causes[1].Resource != "virtualservices.networking.istio.io" {
+		t.Fatalf("unexpected cause: %#v", causes[1])
+	}
+}
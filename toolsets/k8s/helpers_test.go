@@ -232,9 +232,6 @@ func TestMetricsHelpers(t *testing.T) {
 	if got := toBool(nil, true); got != true {
 		t.Fatalf("expected fallback toBool")
 	}
-	if got := toInt(float64(3), 1); got != 3 {
-		t.Fatalf("unexpected toInt: %d", got)
-	}
 }
 
 func TestIsDeploymentTarget(t *testing.T) {
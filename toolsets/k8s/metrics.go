@@ -12,6 +12,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 
+	"rootcause/internal/argconv"
 	"rootcause/internal/kube"
 	"rootcause/internal/mcp"
 	"rootcause/internal/policy"
@@ -68,7 +69,7 @@ func (t *Toolset) handleResourceUsage(ctx context.Context, req mcp.ToolRequest)
 	includePods := toBool(args["includePods"], true)
 	includeNodes := toBool(args["includeNodes"], true)
 	sortBy := strings.ToLower(toString(args["sortBy"]))
-	limit := toInt(args["limit"], 0)
+	limit := argconv.Int(args["limit"], 0)
 	if sortBy != "memory" {
 		sortBy = "cpu"
 	}
@@ -241,15 +242,3 @@ func toBool(value any, fallback bool) bool {
 	}
 	return fallback
 }
-
-func toInt(value any, fallback int) int {
-	switch v := value.(type) {
-	case int:
-		return v
-	case int64:
-		return int(v)
-	case float64:
-		return int(v)
-	}
-	return fallback
-}
@@ -10,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/yaml"
 
+	"rootcause/internal/argconv"
 	"rootcause/internal/kube"
 	"rootcause/internal/mcp"
 )
@@ -98,7 +99,7 @@ func (t *Toolset) handleSafeMutationPreflight(ctx context.Context, req mcp.ToolR
 			addCheck("target-exists", "pass", "Target object exists", "", map[string]any{"resource": gvr.Resource, "name": name})
 		}
 		if operation == "scale" {
-			replicas := toInt(args["replicas"], -1)
+			replicas := argconv.Int(args["replicas"], -1)
 			if replicas < 0 {
 				addCheck("scale-replicas", "fail", "replicas must be >= 0", "Set a non-negative replicas value.", nil)
 			} else if replicas < 2 {
@@ -0,0 +1,424 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"rootcause/internal/argconv"
+	"rootcause/internal/mcp"
+	"rootcause/internal/render"
+)
+
+// flowEndpoint is one side of a simulated connection.
+type flowEndpoint struct {
+	Namespace       string            `json:"namespace"`
+	Pod             string            `json:"pod,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	IP              string            `json:"ip,omitempty"`
+	namespaceLabels map[string]string
+	pod             *corev1.Pod
+}
+
+type flowDirectionResult struct {
+	Direction string   `json:"direction"`
+	Isolated  bool     `json:"isolated"`
+	Policies  []string `json:"policies,omitempty"`
+	Allowed   bool     `json:"allowed"`
+	DecidedBy string   `json:"decidedBy,omitempty"`
+	Rule      *int     `json:"rule,omitempty"`
+	// PortDependent is set when no port was given and a rule allows the
+	// peer only on AllowedPorts, so the verdict hinges on the port.
+	PortDependent bool     `json:"portDependent,omitempty"`
+	AllowedPorts  []string `json:"allowedPorts,omitempty"`
+	Reason        string   `json:"reason"`
+}
+
+func (t *Toolset) handleEvaluateNetworkPolicy(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	args := req.Arguments
+	namespace := toString(args["namespace"])
+	podName := toString(args["pod"])
+	if namespace == "" || podName == "" {
+		return errorResult(errors.New("namespace and pod are required")), errors.New("namespace and pod are required")
+	}
+	sourceNamespace := toString(args["sourceNamespace"])
+	if sourceNamespace == "" {
+		sourceNamespace = namespace
+	}
+	for _, ns := range []string{namespace, sourceNamespace} {
+		if err := t.ctx.Policy.CheckNamespace(req.User, ns, true); err != nil {
+			return errorResult(err), err
+		}
+	}
+	protocol := corev1.Protocol(strings.ToUpper(toString(args["protocol"])))
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+	port := argconv.Int(args["port"], 0)
+
+	analysis := render.NewAnalysis()
+	var warnings []string
+	dest, err := t.flowPodEndpoint(ctx, namespace, podName)
+	if err != nil {
+		return errorResult(err), err
+	}
+	source := &flowEndpoint{Namespace: sourceNamespace, Labels: flowLabels(args["sourceLabels"]), IP: toString(args["sourceIP"])}
+	if sourcePod := toString(args["sourcePod"]); sourcePod != "" {
+		source, err = t.flowPodEndpoint(ctx, sourceNamespace, sourcePod)
+		if err != nil {
+			return errorResult(err), err
+		}
+	}
+	for _, endpoint := range []*flowEndpoint{source, dest} {
+		nsLabels, warning := t.flowNamespaceLabels(ctx, endpoint.Namespace)
+		endpoint.namespaceLabels = nsLabels
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	analysis.AddEvidence("flow", map[string]any{"source": source, "destination": dest, "port": port, "protocol": protocol})
+
+	ingressPolicies, err := t.ctx.Clients.Typed.NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errorResult(err), err
+	}
+	ingress := evaluateFlowDirection("ingress", ingressPolicies.Items, dest, source, dest, port, protocol, &warnings)
+	analysis.AddEvidence("ingress", ingress)
+
+	// Egress can only be evaluated when we know which pods the source is.
+	egress := flowDirectionResult{Direction: "egress", Allowed: true, Reason: "source labels unknown; egress not evaluated"}
+	if source.Labels != nil {
+		egressPolicies, err := t.ctx.Clients.Typed.NetworkingV1().NetworkPolicies(sourceNamespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return errorResult(err), err
+		}
+		egress = evaluateFlowDirection("egress", egressPolicies.Items, source, dest, dest, port, protocol, &warnings)
+	} else {
+		warnings = append(warnings, "pass sourcePod or sourceLabels to evaluate egress policies on the source")
+	}
+	analysis.AddEvidence("egress", egress)
+	if len(warnings) > 0 {
+		analysis.AddEvidence("warnings", warnings)
+	}
+
+	allowed := ingress.Allowed && egress.Allowed
+	decision := map[string]any{"allowed": allowed}
+	if !allowed && (ingress.Allowed || ingress.PortDependent) && (egress.Allowed || egress.PortDependent) {
+		decision["dependsOnPort"] = true
+	}
+	analysis.AddEvidence("decision", decision)
+	analysis.AddResource(fmt.Sprintf("pods/%s/%s", namespace, podName))
+	for _, result := range []flowDirectionResult{egress, ingress} {
+		if result.Allowed {
+			continue
+		}
+		direction := strings.ToUpper(result.Direction[:1]) + result.Direction[1:]
+		if result.PortDependent {
+			analysis.AddCauseWithConfidence(fmt.Sprintf("%s depends on the destination port", direction), result.Reason, "low", render.ConfidenceHigh)
+			analysis.AddNextCheck(fmt.Sprintf("Pass port to decide; %s is allowed only on %s", result.Direction, strings.Join(result.AllowedPorts, ", ")))
+			continue
+		}
+		analysis.AddCauseWithConfidence(fmt.Sprintf("%s denied by NetworkPolicy", direction), result.Reason, "high", render.ConfidenceHigh)
+		analysis.AddNextCheck(fmt.Sprintf("Add a %s rule to one of %s that matches this peer and port", result.Direction, strings.Join(result.Policies, ", ")))
+	}
+	if allowed {
+		analysis.AddNextCheck("NetworkPolicy allows this flow; check Service endpoints, the CNI, and mesh policies if traffic still fails")
+	}
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: uniqueStrings([]string{namespace, sourceNamespace})}}, nil
+}
+
+func (t *Toolset) flowPodEndpoint(ctx context.Context, namespace, name string) (*flowEndpoint, error) {
+	pod, err := t.ctx.Clients.Typed.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	labelSet := pod.Labels
+	if labelSet == nil {
+		labelSet = map[string]string{}
+	}
+	return &flowEndpoint{Namespace: namespace, Pod: name, Labels: labelSet, IP: pod.Status.PodIP, pod: pod}, nil
+}
+
+// flowNamespaceLabels returns the namespace's labels, falling back to the
+// kubernetes.io/metadata.name label the API server always sets when the
+// caller cannot read the namespace.
+func (t *Toolset) flowNamespaceLabels(ctx context.Context, namespace string) (map[string]string, string) {
+	fallback := map[string]string{"kubernetes.io/metadata.name": namespace}
+	ns, err := t.ctx.Clients.Typed.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return fallback, fmt.Sprintf("namespace %s labels unavailable (%v); namespaceSelector matched on name only", namespace, err)
+	}
+	out := map[string]string{}
+	for key, value := range ns.Labels {
+		out[key] = value
+	}
+	if _, ok := out["kubernetes.io/metadata.name"]; !ok {
+		out["kubernetes.io/metadata.name"] = namespace
+	}
+	return out, ""
+}
+
+// evaluateFlowDirection applies the policies selecting subject in one
+// direction. NetworkPolicies are additive: a pod selected by no policy for
+// the direction is not isolated and allows everything; once selected, the
+// flow is allowed only if some rule of some selecting policy matches peer
+// and port. Without a port, rules that match the peer on specific ports
+// make the result port-dependent rather than a deny.
+func evaluateFlowDirection(direction string, policies []networkingv1.NetworkPolicy, subject, peer, dest *flowEndpoint, port int, protocol corev1.Protocol, warnings *[]string) flowDirectionResult {
+	result := flowDirectionResult{Direction: direction}
+	for i := range policies {
+		policy := &policies[i]
+		if !flowPolicyApplies(policy, direction) {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err != nil || !selector.Matches(labels.Set(subject.Labels)) {
+			continue
+		}
+		result.Isolated = true
+		result.Policies = append(result.Policies, policy.Name)
+		if result.Allowed {
+			continue
+		}
+		if direction == "ingress" {
+			for idx, rule := range policy.Spec.Ingress {
+				if result.match(policy, idx, rule.From, rule.Ports, peer, dest, port, protocol, warnings) {
+					break
+				}
+			}
+		} else {
+			for idx, rule := range policy.Spec.Egress {
+				if result.match(policy, idx, rule.To, rule.Ports, peer, dest, port, protocol, warnings) {
+					break
+				}
+			}
+		}
+	}
+	switch {
+	case !result.Isolated:
+		result.Allowed = true
+		result.Reason = fmt.Sprintf("no NetworkPolicy selects %s for %s; all traffic allowed", subject.describe(), direction)
+	case result.Allowed:
+		result.AllowedPorts = nil
+		result.Reason = fmt.Sprintf("%s rule %d of %s matches", direction, *result.Rule, result.DecidedBy)
+	case len(result.AllowedPorts) > 0:
+		result.PortDependent = true
+		result.AllowedPorts = uniqueStrings(result.AllowedPorts)
+		result.Reason = fmt.Sprintf("%s is isolated for %s by %s and allows %s only on %s; no port was given", subject.describe(), direction, strings.Join(result.Policies, ", "), peer.describe(), strings.Join(result.AllowedPorts, ", "))
+	default:
+		result.Reason = fmt.Sprintf("%s is isolated for %s by %s and no rule matches %s on %s/%d", subject.describe(), direction, strings.Join(result.Policies, ", "), peer.describe(), protocol, port)
+	}
+	return result
+}
+
+// match applies rule idx of policy to the flow and reports whether it
+// allows it. When no port was given, a rule that matches the peer only on
+// specific ports records them instead.
+func (r *flowDirectionResult) match(policy *networkingv1.NetworkPolicy, idx int, peers []networkingv1.NetworkPolicyPeer, ports []networkingv1.NetworkPolicyPort, peer, dest *flowEndpoint, port int, protocol corev1.Protocol, warnings *[]string) bool {
+	if !flowPeersMatch(peers, policy.Namespace, peer, warnings) {
+		return false
+	}
+	if flowPortsMatch(ports, dest, port, protocol) {
+		r.allow(policy.Name, idx)
+		return true
+	}
+	if port == 0 {
+		r.AllowedPorts = append(r.AllowedPorts, flowRulePorts(ports, dest, protocol)...)
+	}
+	return false
+}
+
+func (r *flowDirectionResult) allow(policy string, rule int) {
+	r.Allowed = true
+	r.DecidedBy = policy
+	r.Rule = &rule
+}
+
+func flowPolicyApplies(policy *networkingv1.NetworkPolicy, direction string) bool {
+	if direction == "ingress" {
+		return policyAppliesIngress(policy)
+	}
+	// Without explicit policyTypes, egress is implied by egress rules.
+	return policyAppliesEgress(policy) || (len(policy.Spec.PolicyTypes) == 0 && len(policy.Spec.Egress) > 0)
+}
+
+// flowPeersMatch mirrors addNetworkPolicyPeerEdges: podSelector alone picks
+// pods in the policy's namespace, namespaceSelector alone picks every pod in
+// matching namespaces, and both together intersect. An empty peer list
+// matches everything.
+func flowPeersMatch(peers []networkingv1.NetworkPolicyPeer, policyNamespace string, peer *flowEndpoint, warnings *[]string) bool {
+	if len(peers) == 0 {
+		return true
+	}
+	for _, candidate := range peers {
+		if candidate.IPBlock != nil {
+			if flowIPBlockMatches(candidate.IPBlock, peer.IP) {
+				return true
+			}
+			if peer.IP == "" {
+				*warnings = append(*warnings, fmt.Sprintf("ipBlock %s not evaluated: peer IP unknown", candidate.IPBlock.CIDR))
+			}
+			continue
+		}
+		if peer.Labels == nil {
+			continue
+		}
+		if candidate.NamespaceSelector != nil {
+			nsSelector, err := metav1.LabelSelectorAsSelector(candidate.NamespaceSelector)
+			if err != nil || !nsSelector.Matches(labels.Set(peer.namespaceLabels)) {
+				continue
+			}
+		} else if peer.Namespace != policyNamespace {
+			continue
+		}
+		if candidate.PodSelector != nil {
+			podSelector, err := metav1.LabelSelectorAsSelector(candidate.PodSelector)
+			if err != nil || !podSelector.Matches(labels.Set(peer.Labels)) {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func flowIPBlockMatches(block *networkingv1.IPBlock, ip string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	_, cidr, err := net.ParseCIDR(block.CIDR)
+	if err != nil || !cidr.Contains(addr) {
+		return false
+	}
+	for _, except := range block.Except {
+		if _, excluded, err := net.ParseCIDR(except); err == nil && excluded.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// flowPortsMatch checks the rule's ports against the destination port.
+// Named ports resolve against the destination pod's container ports.
+func flowPortsMatch(ports []networkingv1.NetworkPolicyPort, dest *flowEndpoint, port int, protocol corev1.Protocol) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for _, rule := range ports {
+		ruleProtocol := corev1.ProtocolTCP
+		if rule.Protocol != nil {
+			ruleProtocol = *rule.Protocol
+		}
+		if ruleProtocol != protocol {
+			continue
+		}
+		if rule.Port == nil {
+			return true
+		}
+		if port == 0 {
+			continue
+		}
+		number := rule.Port.IntValue()
+		if number == 0 {
+			number = namedContainerPort(dest.pod, rule.Port.StrVal, protocol)
+		}
+		if number == 0 {
+			continue
+		}
+		end := number
+		if rule.EndPort != nil {
+			end = int(*rule.EndPort)
+		}
+		if port >= number && port <= end {
+			return true
+		}
+	}
+	return false
+}
+
+// flowRulePorts describes the ports a rule opens for protocol, such as
+// "TCP/8080", "TCP/8000-8100" or "TCP/http (8080)".
+func flowRulePorts(ports []networkingv1.NetworkPolicyPort, dest *flowEndpoint, protocol corev1.Protocol) []string {
+	var out []string
+	for _, rule := range ports {
+		ruleProtocol := corev1.ProtocolTCP
+		if rule.Protocol != nil {
+			ruleProtocol = *rule.Protocol
+		}
+		if ruleProtocol != protocol || rule.Port == nil {
+			continue
+		}
+		switch {
+		case rule.Port.IntValue() == 0:
+			described := fmt.Sprintf("%s/%s", protocol, rule.Port.StrVal)
+			if number := namedContainerPort(dest.pod, rule.Port.StrVal, protocol); number != 0 {
+				described += fmt.Sprintf(" (%d)", number)
+			}
+			out = append(out, described)
+		case rule.EndPort != nil:
+			out = append(out, fmt.Sprintf("%s/%d-%d", protocol, rule.Port.IntValue(), *rule.EndPort))
+		default:
+			out = append(out, fmt.Sprintf("%s/%d", protocol, rule.Port.IntValue()))
+		}
+	}
+	return out
+}
+
+func namedContainerPort(pod *corev1.Pod, name string, protocol corev1.Protocol) int {
+	if pod == nil {
+		return 0
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			portProtocol := port.Protocol
+			if portProtocol == "" {
+				portProtocol = corev1.ProtocolTCP
+			}
+			if port.Name == name && portProtocol == protocol {
+				return int(port.ContainerPort)
+			}
+		}
+	}
+	return 0
+}
+
+// flowLabels accepts sourceLabels as an object or a "k=v,k2=v2" string.
+func flowLabels(value any) map[string]string {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]string, len(v))
+		for key, val := range v {
+			out[key] = toString(val)
+		}
+		return out
+	case map[string]string:
+		return v
+	case string:
+		set, err := labels.ConvertSelectorToLabelsMap(v)
+		if err != nil || v == "" {
+			return nil
+		}
+		return set
+	}
+	return nil
+}
+
+func (e *flowEndpoint) describe() string {
+	switch {
+	case e.Pod != "":
+		return fmt.Sprintf("pod %s/%s", e.Namespace, e.Pod)
+	case e.Labels != nil:
+		return fmt.Sprintf("pods %v in %s", e.Labels, e.Namespace)
+	case e.IP != "":
+		return e.IP
+	}
+	return fmt.Sprintf("namespace %s", e.Namespace)
+}
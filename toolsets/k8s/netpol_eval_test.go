@@ -0,0 +1,164 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+func flowPod(namespace, name, ip string, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name:  "app",
+			Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+		}}},
+		Status: corev1.PodStatus{PodIP: ip},
+	}
+}
+
+func flowDecision(t *testing.T, result mcp.ToolResult) (bool, map[string]flowDirectionResult) {
+	t.Helper()
+	data := result.Data.(map[string]any)
+	directions := map[string]flowDirectionResult{}
+	allowed := false
+	for _, item := range data["evidence"].([]render.EvidenceItem) {
+		switch item.Summary {
+		case "ingress", "egress":
+			directions[item.Summary] = item.Details.(flowDirectionResult)
+		case "decision":
+			allowed = item.Details.(map[string]any)["allowed"].(bool)
+		}
+	}
+	return allowed, directions
+}
+
+func TestEvaluateNetworkPolicyIngress(t *testing.T) {
+	api := flowPod("prod", "api", "10.0.0.5", map[string]string{"app": "api"})
+	web := flowPod("prod", "web", "10.0.0.6", map[string]string{"app": "web"})
+	other := flowPod("dev", "tool", "10.0.1.7", map[string]string{"app": "web"})
+	allowWeb := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-web", Namespace: "prod"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From:  []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}}},
+				Ports: []networkingv1.NetworkPolicyPort{{Port: &intstr.IntOrString{Type: intstr.String, StrVal: "http"}}},
+			}},
+		},
+	}
+	toolset := newDebugToolset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev"}},
+		api, web, other, allowWeb,
+	)
+	user := policy.User{Role: policy.RoleCluster}
+
+	cases := []struct {
+		name    string
+		args    map[string]any
+		allowed bool
+	}{
+		{"same namespace named port", map[string]any{"namespace": "prod", "pod": "api", "port": 8080, "sourcePod": "web"}, true},
+		{"wrong port", map[string]any{"namespace": "prod", "pod": "api", "port": 9090, "sourcePod": "web"}, false},
+		{"other namespace", map[string]any{"namespace": "prod", "pod": "api", "port": 8080, "sourcePod": "tool", "sourceNamespace": "dev"}, false},
+		{"labels only", map[string]any{"namespace": "prod", "pod": "api", "port": 8080, "sourceLabels": map[string]any{"app": "web"}}, true},
+		{"not isolated", map[string]any{"namespace": "prod", "pod": "web", "port": 80, "sourcePod": "tool", "sourceNamespace": "dev"}, true},
+	}
+	for _, tc := range cases {
+		result, err := toolset.handleEvaluateNetworkPolicy(context.Background(), mcp.ToolRequest{User: user, Arguments: tc.args})
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		allowed, directions := flowDecision(t, result)
+		if allowed != tc.allowed {
+			t.Fatalf("%s: expected allowed=%v, got %#v", tc.name, tc.allowed, directions)
+		}
+		if tc.name == "same namespace named port" && (directions["ingress"].DecidedBy != "allow-web" || *directions["ingress"].Rule != 0) {
+			t.Fatalf("expected allow-web rule 0 to decide, got %#v", directions["ingress"])
+		}
+	}
+
+	result, err := toolset.handleEvaluateNetworkPolicy(context.Background(), mcp.ToolRequest{User: user, Arguments: map[string]any{"namespace": "prod", "pod": "api", "sourcePod": "web"}})
+	if err != nil {
+		t.Fatalf("no port: %v", err)
+	}
+	allowed, directions := flowDecision(t, result)
+	ingress := directions["ingress"]
+	if allowed || !ingress.PortDependent || len(ingress.AllowedPorts) != 1 || ingress.AllowedPorts[0] != "TCP/http (8080)" {
+		t.Fatalf("expected a port-dependent verdict listing the allowed port, got %#v", ingress)
+	}
+	data := result.Data.(map[string]any)
+	for _, item := range data["evidence"].([]render.EvidenceItem) {
+		if item.Summary == "decision" && item.Details.(map[string]any)["dependsOnPort"] != true {
+			t.Fatalf("expected the decision to depend on the port, got %#v", item.Details)
+		}
+	}
+	for _, cause := range data["likelyRootCauses"].([]render.Cause) {
+		if cause.Summary == "Ingress denied by NetworkPolicy" {
+			t.Fatalf("a port-dependent flow should not be reported as denied")
+		}
+	}
+
+	if _, err := toolset.handleEvaluateNetworkPolicy(context.Background(), mcp.ToolRequest{User: user, Arguments: map[string]any{"namespace": "prod"}}); err == nil {
+		t.Fatalf("expected error without pod")
+	}
+}
+
+func TestEvaluateNetworkPolicyEgressAndNamespaces(t *testing.T) {
+	db := flowPod("data", "db", "10.0.2.9", map[string]string{"app": "db"})
+	api := flowPod("prod", "api", "10.0.0.5", map[string]string{"app": "api"})
+	denyEgress := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "egress-dns-only", Namespace: "prod"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress: []networkingv1.NetworkPolicyEgressRule{{
+				To: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}}}},
+			}},
+		},
+	}
+	fromProd := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "from-prod", Namespace: "data"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "prod"}}}},
+			}},
+		},
+	}
+	toolset := newDebugToolset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "data", Labels: map[string]string{"team": "data"}}},
+		db, api, denyEgress, fromProd,
+	)
+	result, err := toolset.handleEvaluateNetworkPolicy(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"namespace": "data", "pod": "db", "port": 5432, "sourcePod": "api", "sourceNamespace": "prod"},
+	})
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	allowed, directions := flowDecision(t, result)
+	if allowed || !directions["ingress"].Allowed || directions["egress"].Allowed {
+		t.Fatalf("expected ingress allowed and egress denied, got %#v", directions)
+	}
+	if len(directions["egress"].Policies) != 1 || directions["egress"].Policies[0] != "egress-dns-only" {
+		t.Fatalf("expected egress-dns-only to isolate, got %#v", directions["egress"])
+	}
+}
+
+func TestFlowIPBlockMatches(t *testing.T) {
+	block := &networkingv1.IPBlock{CIDR: "10.0.0.0/16", Except: []string{"10.0.1.0/24"}}
+	if !flowIPBlockMatches(block, "10.0.0.5") || flowIPBlockMatches(block, "10.0.1.5") || flowIPBlockMatches(block, "") {
+		t.Fatalf("unexpected ipBlock matching")
+	}
+}
@@ -0,0 +1,203 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/render"
+)
+
+// defaultNodeHeadroomThreshold is the requested/allocatable ratio at which a
+// node counts as near capacity for new pods.
+const defaultNodeHeadroomThreshold = 0.85
+
+var nodePressureConditions = []corev1.NodeConditionType{
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+}
+
+type nodePressureSummary struct {
+	Node                   string   `json:"node"`
+	Ready                  bool     `json:"ready"`
+	Unschedulable          bool     `json:"unschedulable,omitempty"`
+	Pressure               []string `json:"pressure,omitempty"`
+	SchedulingTaints       []string `json:"schedulingTaints,omitempty"`
+	CPURequestedMilli      int64    `json:"cpuRequestedMilli"`
+	CPUAllocatableMilli    int64    `json:"cpuAllocatableMilli"`
+	CPUPct                 float64  `json:"cpuPct"`
+	MemoryRequestedBytes   int64    `json:"memoryRequestedBytes"`
+	MemoryAllocatableBytes int64    `json:"memoryAllocatableBytes"`
+	MemoryPct              float64  `json:"memoryPct"`
+	Pods                   int64    `json:"pods"`
+	PodsAllocatable        int64    `json:"podsAllocatable"`
+	NearCapacity           []string `json:"nearCapacity,omitempty"`
+}
+
+func (s nodePressureSummary) healthy() bool {
+	return s.Ready && !s.Unschedulable && len(s.Pressure) == 0 && len(s.SchedulingTaints) == 0 && len(s.NearCapacity) == 0
+}
+
+func (t *Toolset) handleNodePressure(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	if err := t.ctx.Policy.CheckNamespace(req.User, "", false); err != nil {
+		return errorResult(err), err
+	}
+	threshold := defaultNodeHeadroomThreshold
+	if value, ok := req.Arguments["threshold"].(float64); ok && value > 0 && value <= 1 {
+		threshold = value
+	}
+	nodes, err := t.ctx.Clients.Typed.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: toString(req.Arguments["labelSelector"])})
+	if err != nil {
+		return errorResult(err), err
+	}
+	pods, err := t.ctx.Clients.Typed.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errorResult(err), err
+	}
+	summaries := nodePressureSummaries(nodes.Items, pods.Items, threshold)
+
+	analysis := render.NewAnalysis()
+	analysis.AddEvidence("threshold", threshold)
+	var flagged []nodePressureSummary
+	for _, summary := range summaries {
+		if !summary.healthy() {
+			flagged = append(flagged, summary)
+			analysis.AddResource("nodes/" + summary.Node)
+		}
+	}
+	analysis.AddEvidence("nodes", summaries)
+	if len(flagged) == 0 {
+		analysis.AddEvidence("status", fmt.Sprintf("%d nodes healthy with headroom below %.0f%%", len(summaries), threshold*100))
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
+	}
+	for _, summary := range flagged {
+		if !summary.Ready {
+			analysis.AddCause("Node not ready", fmt.Sprintf("%s is NotReady; its pods are evicted once the taint toleration expires", summary.Node), "critical")
+		}
+		if len(summary.Pressure) > 0 {
+			analysis.AddCause("Node under resource pressure", fmt.Sprintf("%s reports %s; the kubelet is evicting pods and rejecting new ones", summary.Node, strings.Join(summary.Pressure, ", ")), "high")
+		}
+		if len(summary.NearCapacity) > 0 {
+			analysis.AddCause("Node near allocatable capacity", fmt.Sprintf("%s requests are at %s of allocatable", summary.Node, strings.Join(summary.NearCapacity, ", ")), "medium")
+		}
+		if summary.Unschedulable {
+			analysis.AddCause("Node cordoned", fmt.Sprintf("%s is marked unschedulable", summary.Node), "low")
+		}
+		if len(summary.SchedulingTaints) > 0 {
+			analysis.AddCause("Node taints block scheduling", fmt.Sprintf("%s has %s; pods without matching tolerations cannot land there", summary.Node, strings.Join(summary.SchedulingTaints, ", ")), "low")
+		}
+	}
+	analysis.AddNextCheck("Run k8s.scheduling_debug on Pending pods to see which of these nodes rejected them")
+	analysis.AddNextCheck("Compare requests with live usage via k8s.resource_usage before resizing nodes or pods")
+	analysis.AddNextCheck("With Karpenter, check karpenter.status for NodePool limits and disruption budgets")
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
+}
+
+// nodePressureSummaries sums the requests of every non-terminated pod bound
+// to each node and compares them with the node's allocatable resources.
+// Nodes sort with the most troubled first.
+func nodePressureSummaries(nodes []corev1.Node, pods []corev1.Pod, threshold float64) []nodePressureSummary {
+	byNode := make(map[string]*nodePressureSummary, len(nodes))
+	out := make([]*nodePressureSummary, 0, len(nodes))
+	for i := range nodes {
+		node := &nodes[i]
+		summary := &nodePressureSummary{
+			Node:                   node.Name,
+			Unschedulable:          node.Spec.Unschedulable,
+			CPUAllocatableMilli:    quantityMilli(node.Status.Allocatable[corev1.ResourceCPU]),
+			MemoryAllocatableBytes: quantityValue(node.Status.Allocatable[corev1.ResourceMemory]),
+			PodsAllocatable:        quantityValue(node.Status.Allocatable[corev1.ResourcePods]),
+		}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady {
+				summary.Ready = condition.Status == corev1.ConditionTrue
+			}
+			for _, pressure := range nodePressureConditions {
+				if condition.Type == pressure && condition.Status == corev1.ConditionTrue {
+					summary.Pressure = append(summary.Pressure, string(pressure))
+				}
+			}
+		}
+		for _, taint := range node.Spec.Taints {
+			if taint.Effect == corev1.TaintEffectPreferNoSchedule || nodeConditionTaint(taint.Key) {
+				continue
+			}
+			summary.SchedulingTaints = append(summary.SchedulingTaints, nodeTaintString(taint))
+		}
+		byNode[node.Name] = summary
+		out = append(out, summary)
+	}
+	for i := range pods {
+		pod := pods[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		summary, ok := byNode[pod.Spec.NodeName]
+		if !ok {
+			continue
+		}
+		cpu, memory := podResourceRequests(pod)
+		summary.CPURequestedMilli += cpu
+		summary.MemoryRequestedBytes += memory
+		summary.Pods++
+	}
+	result := make([]nodePressureSummary, 0, len(out))
+	for _, summary := range out {
+		if summary.CPUAllocatableMilli > 0 {
+			summary.CPUPct = float64(summary.CPURequestedMilli) / float64(summary.CPUAllocatableMilli)
+		}
+		if summary.MemoryAllocatableBytes > 0 {
+			summary.MemoryPct = float64(summary.MemoryRequestedBytes) / float64(summary.MemoryAllocatableBytes)
+		}
+		if summary.CPUPct >= threshold {
+			summary.NearCapacity = append(summary.NearCapacity, fmt.Sprintf("cpu %.0f%%", summary.CPUPct*100))
+		}
+		if summary.MemoryPct >= threshold {
+			summary.NearCapacity = append(summary.NearCapacity, fmt.Sprintf("memory %.0f%%", summary.MemoryPct*100))
+		}
+		if summary.PodsAllocatable > 0 && float64(summary.Pods)/float64(summary.PodsAllocatable) >= threshold {
+			summary.NearCapacity = append(summary.NearCapacity, fmt.Sprintf("pods %d/%d", summary.Pods, summary.PodsAllocatable))
+		}
+		result = append(result, *summary)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if a, b := nodePressureRank(result[i]), nodePressureRank(result[j]); a != b {
+			return a < b
+		}
+		return result[i].Node < result[j].Node
+	})
+	return result
+}
+
+// nodeConditionTaint reports taints the node lifecycle controller mirrors
+// from conditions already reported as Ready, pressure, or cordon state.
+func nodeConditionTaint(key string) bool {
+	return strings.HasPrefix(key, "node.kubernetes.io/")
+}
+
+func nodeTaintString(taint corev1.Taint) string {
+	if taint.Value == "" {
+		return fmt.Sprintf("%s:%s", taint.Key, taint.Effect)
+	}
+	return fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect)
+}
+
+func nodePressureRank(summary nodePressureSummary) int {
+	switch {
+	case !summary.Ready:
+		return 0
+	case len(summary.Pressure) > 0:
+		return 1
+	case len(summary.NearCapacity) > 0:
+		return 2
+	case summary.Unschedulable || len(summary.SchedulingTaints) > 0:
+		return 3
+	}
+	return 4
+}
@@ -0,0 +1,103 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+func pressureNode(name string, ready bool, conditions ...corev1.NodeConditionType) *corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+				corev1.ResourcePods:   resource.MustParse("10"),
+			},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+		},
+	}
+	for _, condition := range conditions {
+		node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{Type: condition, Status: corev1.ConditionTrue})
+	}
+	return node
+}
+
+func requestPod(name, node, cpu string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PodSpec{NodeName: node, Containers: []corev1.Container{{
+			Name:      "app",
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}},
+		}}},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestNodePressureSummaries(t *testing.T) {
+	busy := pressureNode("busy", true)
+	tainted := pressureNode("tainted", true)
+	tainted.Spec.Unschedulable = true
+	tainted.Spec.Taints = []corev1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "node.kubernetes.io/unschedulable", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "soft", Effect: corev1.TaintEffectPreferNoSchedule},
+	}
+	nodes := []corev1.Node{*pressureNode("idle", true), *busy, *tainted, *pressureNode("pressured", true, corev1.NodeMemoryPressure, corev1.NodeDiskPressure), *pressureNode("down", false)}
+	pods := []corev1.Pod{
+		*requestPod("a", "busy", "1500m", corev1.PodRunning),
+		*requestPod("b", "busy", "300m", corev1.PodRunning),
+		*requestPod("done", "busy", "2", corev1.PodSucceeded),
+		*requestPod("pending", "", "2", corev1.PodPending),
+	}
+	summaries := nodePressureSummaries(nodes, pods, 0.85)
+	order := []string{"down", "pressured", "busy", "tainted", "idle"}
+	for i, name := range order {
+		if summaries[i].Node != name {
+			t.Fatalf("position %d: expected %s, got %#v", i, name, summaries)
+		}
+	}
+	if summaries[1].Pressure[0] != "MemoryPressure" || len(summaries[1].Pressure) != 2 {
+		t.Fatalf("expected memory and disk pressure, got %#v", summaries[1])
+	}
+	if summaries[2].CPURequestedMilli != 1800 || summaries[2].Pods != 2 || len(summaries[2].NearCapacity) != 1 {
+		t.Fatalf("expected busy node at 90%% cpu, got %#v", summaries[2])
+	}
+	if len(summaries[3].SchedulingTaints) != 1 || summaries[3].SchedulingTaints[0] != "dedicated=gpu:NoSchedule" {
+		t.Fatalf("expected only the dedicated taint, got %#v", summaries[3])
+	}
+	if !summaries[4].healthy() {
+		t.Fatalf("expected idle node healthy, got %#v", summaries[4])
+	}
+}
+
+func TestHandleNodePressure(t *testing.T) {
+	toolset := newDebugToolset(pressureNode("ok", true), pressureNode("hot", true, corev1.NodePIDPressure))
+	result, err := toolset.handleNodePressure(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("handleNodePressure: %v", err)
+	}
+	causes := result.Data.(map[string]any)["likelyRootCauses"].([]render.Cause)
+	if len(causes) != 1 || causes[0].Summary != "Node under resource pressure" {
+		t.Fatalf("unexpected causes: %#v", causes)
+	}
+
+	if _, err := toolset.handleNodePressure(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleNamespace, AllowedNamespaces: []string{"default"}},
+		Arguments: map[string]any{},
+	}); err == nil {
+		t.Fatalf("expected namespace-scoped user to be denied")
+	}
+}
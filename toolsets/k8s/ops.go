@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/yaml"
 
+	"rootcause/internal/argconv"
 	"rootcause/internal/kube"
 	"rootcause/internal/mcp"
 	"rootcause/internal/policy"
@@ -159,7 +160,8 @@ func (t *Toolset) handleDescribe(ctx context.Context, req mcp.ToolRequest) (mcp.
 	if err != nil {
 		return errorResult(err), err
 	}
-	analysis := t.ctx.Renderer.Render(render.DescribeAnalysis(ctx, t.ctx.Evidence, t.ctx.Redactor, gvr, obj))
+	opts := render.DescribeOptions{IncludeNormalEvents: toBool(args["includeNormalEvents"], false)}
+	analysis := t.ctx.Renderer.Render(render.DescribeAnalysisWithOptions(ctx, t.ctx.Evidence, t.ctx.Redactor, gvr, obj, opts))
 	return mcp.ToolResult{Data: analysis, Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace), Resources: []string{t.ctx.Evidence.ResourceRef(gvr, namespace, name)}}}, nil
 }
 
@@ -317,6 +319,11 @@ func (t *Toolset) handlePatch(ctx context.Context, req mcp.ToolRequest) (mcp.Too
 	return mcp.ToolResult{Data: t.redactUnstructured(obj), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace), Resources: []string{t.ctx.Evidence.ResourceRef(gvr, namespace, name)}}}, nil
 }
 
+// maxLogBytes caps how much of a container log k8s.logs returns. Only the
+// newest output is kept; anything before it is dropped and reported as
+// truncated.
+const maxLogBytes = 1 << 20
+
 func (t *Toolset) handleLogs(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
 	args := req.Arguments
 	namespace := toString(args["namespace"])
@@ -328,29 +335,101 @@ func (t *Toolset) handleLogs(ctx context.Context, req mcp.ToolRequest) (mcp.Tool
 	if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
 		return errorResult(err), err
 	}
-	options := &corev1.PodLogOptions{}
-	if container != "" {
-		options.Container = container
+	metadata := mcp.ToolMetadata{Namespaces: []string{namespace}, Resources: []string{fmt.Sprintf("pods/%s/%s", namespace, pod)}}
+	if container == "" {
+		podObj, err := t.ctx.Clients.Typed.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+		if err != nil {
+			return errorResult(err), err
+		}
+		// Guessing a container on a multi-container pod usually returns the
+		// sidecar's logs; hand the choice back to the caller instead.
+		if len(podObj.Spec.Containers) > 1 {
+			var containers, initContainers []string
+			for _, c := range podObj.Spec.Containers {
+				containers = append(containers, c.Name)
+			}
+			for _, c := range podObj.Spec.InitContainers {
+				initContainers = append(initContainers, c.Name)
+			}
+			data := map[string]any{
+				"containers": containers,
+				"message":    "pod has multiple containers; pass container to choose one",
+			}
+			if len(initContainers) > 0 {
+				data["initContainers"] = initContainers
+			}
+			return mcp.ToolResult{Data: data, Metadata: metadata}, nil
+		}
+		if len(podObj.Spec.Containers) == 1 {
+			container = podObj.Spec.Containers[0].Name
+		}
+	}
+	options := &corev1.PodLogOptions{
+		Container: container,
+		Previous:  toBool(args["previous"], false),
 	}
-	if val, ok := args["tailLines"].(float64); ok {
-		lines := int64(val)
-		options.TailLines = &lines
+	if lines := argconv.Int(args["tailLines"], 0); lines > 0 {
+		tail := int64(lines)
+		options.TailLines = &tail
 	}
-	if val, ok := args["sinceSeconds"].(float64); ok {
-		sec := int64(val)
-		options.SinceSeconds = &sec
+	if seconds := argconv.Int(args["sinceSeconds"], 0); seconds > 0 {
+		since := int64(seconds)
+		options.SinceSeconds = &since
 	}
 	stream, err := t.ctx.Clients.Typed.CoreV1().Pods(namespace).GetLogs(pod, options).Stream(ctx)
 	if err != nil {
 		return errorResult(err), err
 	}
 	defer stream.Close()
-	buf := new(bytes.Buffer)
-	if _, err := io.Copy(buf, stream); err != nil {
+	tail := &logTail{keep: maxLogBytes + 1}
+	if _, err := io.Copy(tail, stream); err != nil {
 		return errorResult(err), err
 	}
-	output := t.ctx.Redactor.RedactString(buf.String())
-	return mcp.ToolResult{Data: map[string]any{"logs": output}, Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}, Resources: []string{fmt.Sprintf("pods/%s/%s", namespace, pod)}}}, nil
+	lines, truncated := splitLogLines(tail.buf, maxLogBytes)
+	for i := range lines {
+		lines[i] = t.ctx.Redactor.RedactString(lines[i])
+	}
+	return mcp.ToolResult{Data: map[string]any{
+		"container": container,
+		"lines":     lines,
+		"truncated": truncated,
+	}, Metadata: metadata}, nil
+}
+
+// logTail is an io.Writer that keeps at least the last keep bytes written
+// to it, so a long log can be read through without holding all of it.
+type logTail struct {
+	keep int
+	buf  []byte
+}
+
+func (w *logTail) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	if len(w.buf) > 2*w.keep {
+		w.buf = append(w.buf[:0], w.buf[len(w.buf)-w.keep:]...)
+	}
+	return len(p), nil
+}
+
+// splitLogLines splits raw log output into lines. When raw exceeds limit only
+// its end is kept, from the first complete line within the last limit bytes,
+// and truncated is true: the newest lines are the ones that explain a crash.
+func splitLogLines(raw []byte, limit int) ([]string, bool) {
+	truncated := len(raw) > limit
+	if truncated {
+		cut := len(raw) - limit
+		if raw[cut-1] != '\n' {
+			if idx := bytes.IndexByte(raw[cut:], '\n'); idx >= 0 {
+				cut += idx + 1
+			}
+		}
+		raw = raw[cut:]
+	}
+	text := strings.TrimSuffix(string(raw), "\n")
+	if text == "" {
+		return []string{}, truncated
+	}
+	return strings.Split(text, "\n"), truncated
 }
 
 func (t *Toolset) handleEvents(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
@@ -406,7 +485,7 @@ func (t *Toolset) handleEvents(ctx context.Context, req mcp.ToolRequest) (mcp.To
 func (t *Toolset) handleEventsTimeline(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
 	args := req.Arguments
 	includeNormal := toBool(args["includeNormal"], false)
-	limit := toInt(args["limit"], 200)
+	limit := argconv.Int(args["limit"], 200)
 	if limit <= 0 {
 		limit = 200
 	}
@@ -473,7 +552,7 @@ func (t *Toolset) handleAPIResources(ctx context.Context, req mcp.ToolRequest) (
 	if val, ok := req.Arguments["limit"].(float64); ok {
 		limit = int(val)
 	}
-	groups, err := t.ctx.Clients.Discovery.ServerPreferredResources()
+	groups, warnings, err := kube.PreferredResources(t.ctx.Clients.Discovery)
 	if err != nil {
 		return errorResult(err), err
 	}
@@ -503,7 +582,11 @@ func (t *Toolset) handleAPIResources(ctx context.Context, req mcp.ToolRequest) (
 			break
 		}
 	}
-	return mcp.ToolResult{Data: map[string]any{"apiResources": results, "matched": matched}}, nil
+	data := map[string]any{"apiResources": results, "matched": matched}
+	if len(warnings) > 0 {
+		data["warnings"] = warnings
+	}
+	return mcp.ToolResult{Data: data}, nil
 }
 
 func apiResourceMatches(query, groupVersion string, resource metav1.APIResource) bool {
@@ -28,6 +28,10 @@ func (stubCollector) EventsForObject(context.Context, *unstructured.Unstructured
 	return nil, nil
 }
 
+func (stubCollector) RecentEvents(context.Context, string, corev1.ObjectReference) ([]corev1.Event, error) {
+	return nil, nil
+}
+
 func (stubCollector) OwnerChain(context.Context, *unstructured.Unstructured) ([]string, error) {
 	return nil, nil
 }
@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
@@ -149,8 +150,8 @@ func TestHandleLogsError(t *testing.T) {
 	})
 	if err == nil {
 		data := result.Data.(map[string]any)
-		if _, ok := data["logs"]; !ok {
-			t.Fatalf("expected logs output")
+		if _, ok := data["lines"]; !ok {
+			t.Fatalf("expected log lines output")
 		}
 	}
 }
@@ -197,6 +198,87 @@ func TestHandleLogsOptions(t *testing.T) {
 	})
 }
 
+func TestHandleLogsContainerSelection(t *testing.T) {
+	multi := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "multi", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "migrate"}},
+			Containers:     []corev1.Container{{Name: "app"}, {Name: "istio-proxy"}},
+		},
+	}
+	single := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "single", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}
+	client := k8sfake.NewSimpleClientset(multi, single)
+	clients := &kube.Clients{Typed: client}
+	cfg := config.DefaultConfig()
+	toolset := New()
+	_ = toolset.Init(mcp.ToolContext{
+		Config:   &cfg,
+		Clients:  clients,
+		Policy:   policy.NewAuthorizer(),
+		Renderer: render.NewRenderer(),
+		Redactor: redact.New(),
+		Evidence: evidence.NewCollector(clients),
+	})
+	user := policy.User{Role: policy.RoleCluster}
+
+	result, err := toolset.handleLogs(context.Background(), mcp.ToolRequest{User: user, Arguments: map[string]any{"namespace": "default", "pod": "multi"}})
+	if err != nil {
+		t.Fatalf("logs multi: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	containers, _ := data["containers"].([]string)
+	if len(containers) != 2 || data["lines"] != nil {
+		t.Fatalf("expected container list instead of logs, got %#v", data)
+	}
+	if init, _ := data["initContainers"].([]string); len(init) != 1 || init[0] != "migrate" {
+		t.Fatalf("expected init containers listed, got %#v", data["initContainers"])
+	}
+
+	result, err = toolset.handleLogs(context.Background(), mcp.ToolRequest{User: user, Arguments: map[string]any{"namespace": "default", "pod": "single", "previous": true, "tailLines": 5}})
+	if err != nil {
+		t.Fatalf("logs single: %v", err)
+	}
+	data = result.Data.(map[string]any)
+	if data["container"] != "app" || data["truncated"] != false {
+		t.Fatalf("expected single container resolved, got %#v", data)
+	}
+	if lines, _ := data["lines"].([]string); len(lines) != 1 || lines[0] != "fake logs" {
+		t.Fatalf("unexpected lines: %#v", data["lines"])
+	}
+}
+
+func TestSplitLogLines(t *testing.T) {
+	lines, truncated := splitLogLines([]byte("a\nb\n"), 10)
+	if truncated || len(lines) != 2 || lines[1] != "b" {
+		t.Fatalf("unexpected split: %v %v", lines, truncated)
+	}
+	lines, truncated = splitLogLines([]byte("first-line-too-long\nlast"), 10)
+	if !truncated || len(lines) != 1 || lines[0] != "last" {
+		t.Fatalf("expected cut at first full line, got %v %v", lines, truncated)
+	}
+	if lines, _ := splitLogLines(nil, 10); len(lines) != 0 {
+		t.Fatalf("expected no lines for empty log")
+	}
+}
+
+func TestLogTailKeepsLastLines(t *testing.T) {
+	const limit = 64
+	tail := &logTail{keep: limit + 1}
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(tail, "line %03d\n", i)
+	}
+	lines, truncated := splitLogLines(tail.buf, limit)
+	if !truncated || len(lines) == 0 || len(lines) > limit/9 {
+		t.Fatalf("expected a truncated tail, got %v %v", lines, truncated)
+	}
+	if lines[len(lines)-1] != "line 099" || lines[0] != fmt.Sprintf("line %03d", 100-len(lines)) {
+		t.Fatalf("expected the last lines to survive, got %v", lines)
+	}
+}
+
 func TestHandlePortForwardMissingArgs(t *testing.T) {
 	cfg := config.DefaultConfig()
 	toolset := New()
@@ -0,0 +1,201 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/render"
+)
+
+const (
+	orphanNoPodsMatch   = "no pods match"
+	orphanNoneReady     = "pods match but none ready"
+	orphanEmptyEndpoint = "endpoints empty"
+)
+
+type orphanedService struct {
+	Namespace      string            `json:"namespace"`
+	Name           string            `json:"name"`
+	Selector       map[string]string `json:"selector,omitempty"`
+	Issue          string            `json:"issue"`
+	MatchingPods   int               `json:"matchingPods"`
+	ReadyPods      int               `json:"readyPods"`
+	ReadyEndpoints int               `json:"readyEndpoints"`
+	NotReadyPods   []string          `json:"notReadyPods,omitempty"`
+}
+
+func (t *Toolset) handleFindOrphanedServices(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	namespace := toString(req.Arguments["namespace"])
+	if namespace != "" {
+		if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+			return errorResult(err), err
+		}
+	}
+	namespaces, err := t.allowedNamespaces(ctx, req.User)
+	if err != nil {
+		return errorResult(err), err
+	}
+	if namespace != "" {
+		namespaces = []string{namespace}
+	}
+	analysis := render.NewAnalysis()
+	var (
+		orphans  []orphanedService
+		checked  int
+		warnings []string
+	)
+	for _, ns := range namespaces {
+		services, err := t.ctx.Clients.Typed.CoreV1().Services(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return errorResult(err), err
+		}
+		if len(services.Items) == 0 {
+			continue
+		}
+		pods, err := t.ctx.Clients.Typed.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return errorResult(err), err
+		}
+		endpoints, err := t.ctx.Clients.Typed.CoreV1().Endpoints(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return errorResult(err), err
+		}
+		endpointsByService := map[string]*corev1.Endpoints{}
+		for i := range endpoints.Items {
+			endpointsByService[endpoints.Items[i].Name] = &endpoints.Items[i]
+		}
+		// EndpointSlices are authoritative on newer clusters; Endpoints are
+		// capped at 1000 addresses and may be mirrored late.
+		slicesByService := map[string][]discoveryv1.EndpointSlice{}
+		slices, err := t.ctx.Clients.Typed.DiscoveryV1().EndpointSlices(ns).List(ctx, metav1.ListOptions{})
+		switch {
+		case err == nil:
+			for _, slice := range slices.Items {
+				if service := slice.Labels[discoveryv1.LabelServiceName]; service != "" {
+					slicesByService[service] = append(slicesByService[service], slice)
+				}
+			}
+		case apierrors.IsNotFound(err) || apierrors.IsForbidden(err):
+			warnings = append(warnings, fmt.Sprintf("endpointslices unavailable in %s (%v); using Endpoints only", ns, err))
+		default:
+			return errorResult(err), err
+		}
+		for _, svc := range services.Items {
+			if svc.Spec.Type == corev1.ServiceTypeExternalName {
+				continue
+			}
+			checked++
+			if orphan, ok := serviceOrphanStatus(&svc, pods.Items, endpointsByService[svc.Name], slicesByService[svc.Name]); ok {
+				orphans = append(orphans, orphan)
+				analysis.AddResource(fmt.Sprintf("services/%s/%s", ns, svc.Name))
+			}
+		}
+	}
+	analysis.AddEvidence("servicesChecked", checked)
+	if len(warnings) > 0 {
+		analysis.AddEvidence("warnings", warnings)
+	}
+	if len(orphans) == 0 {
+		analysis.AddEvidence("status", "every Service has ready endpoints")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+	}
+	sort.Slice(orphans, func(i, j int) bool {
+		if orphans[i].Namespace != orphans[j].Namespace {
+			return orphans[i].Namespace < orphans[j].Namespace
+		}
+		return orphans[i].Name < orphans[j].Name
+	})
+	analysis.AddEvidence("orphanedServices", orphans)
+	for _, orphan := range orphans {
+		ref := fmt.Sprintf("%s/%s", orphan.Namespace, orphan.Name)
+		switch orphan.Issue {
+		case orphanNoPodsMatch:
+			analysis.AddCauseWithConfidence("Service selector matches no pods", fmt.Sprintf("%s selects %s but no running pod carries those labels; clients get connection refused or 503", ref, labels.Set(orphan.Selector).String()), "high", render.ConfidenceHigh)
+		case orphanNoneReady:
+			analysis.AddCauseWithConfidence("Service pods not ready", fmt.Sprintf("%s matches %d pods but none are ready", ref, orphan.MatchingPods), "high", render.ConfidenceHigh)
+		default:
+			analysis.AddCauseWithConfidence("Service has no ready endpoints", fmt.Sprintf("%s has %d ready pods but no ready endpoints; check targetPort and endpoint controller health", ref, orphan.ReadyPods), "medium", render.ConfidenceMedium)
+		}
+	}
+	analysis.AddNextCheck("Compare Service selectors with the labels on the intended pods (k8s.list pods --show-labels)")
+	analysis.AddNextCheck("For unready pods, run k8s.crashloop_debug or check readiness probes")
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+}
+
+// serviceOrphanStatus reports whether svc has nothing ready behind it and,
+// if so, why. Selectorless Services manage their own endpoints, so only
+// empty endpoints count against them.
+func serviceOrphanStatus(svc *corev1.Service, pods []corev1.Pod, endpoints *corev1.Endpoints, slices []discoveryv1.EndpointSlice) (orphanedService, bool) {
+	orphan := orphanedService{
+		Namespace:      svc.Namespace,
+		Name:           svc.Name,
+		Selector:       svc.Spec.Selector,
+		ReadyEndpoints: readyEndpointCount(endpoints, slices),
+	}
+	if len(svc.Spec.Selector) > 0 {
+		selector := labels.SelectorFromSet(svc.Spec.Selector)
+		for i := range pods {
+			pod := &pods[i]
+			if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed || pod.DeletionTimestamp != nil {
+				continue
+			}
+			if !selector.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+			orphan.MatchingPods++
+			if isPodReady(pod) {
+				orphan.ReadyPods++
+			} else {
+				orphan.NotReadyPods = append(orphan.NotReadyPods, pod.Name)
+			}
+		}
+		switch {
+		case orphan.MatchingPods == 0:
+			orphan.Issue = orphanNoPodsMatch
+			return orphan, true
+		case orphan.ReadyPods == 0:
+			orphan.Issue = orphanNoneReady
+			return orphan, true
+		}
+	}
+	if orphan.ReadyEndpoints == 0 {
+		orphan.Issue = orphanEmptyEndpoint
+		return orphan, true
+	}
+	return orphan, false
+}
+
+// readyEndpointCount prefers EndpointSlices and falls back to the ready
+// addresses in Endpoints when no slices exist.
+func readyEndpointCount(endpoints *corev1.Endpoints, slices []discoveryv1.EndpointSlice) int {
+	if len(slices) > 0 {
+		count := 0
+		for _, slice := range slices {
+			for _, endpoint := range slice.Endpoints {
+				if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+					count++
+				}
+			}
+		}
+		return count
+	}
+	if endpoints == nil {
+		return 0
+	}
+	if pods := podsFromEndpoints(endpoints); len(pods) > 0 {
+		return len(pods)
+	}
+	count := 0
+	for _, subset := range endpoints.Subsets {
+		count += len(subset.Addresses)
+	}
+	return count
+}
@@ -0,0 +1,105 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+func orphanService(name string, selector map[string]string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Selector: selector},
+	}
+}
+
+func orphanPod(name string, labels map[string]string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+func TestHandleFindOrphanedServices(t *testing.T) {
+	ready := true
+	objects := []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		orphanService("typo", map[string]string{"app": "apii"}),
+		orphanService("unready", map[string]string{"app": "web"}),
+		orphanService("healthy", map[string]string{"app": "api"}),
+		orphanService("manual", nil),
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "example.com"},
+		},
+		orphanPod("api-1", map[string]string{"app": "api"}, true),
+		orphanPod("web-1", map[string]string{"app": "web"}, false),
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{Name: "healthy-abc", Namespace: "default", Labels: map[string]string{discoveryv1.LabelServiceName: "healthy"}},
+			Endpoints:  []discoveryv1.Endpoint{{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: &ready}}},
+		},
+	}
+	toolset := newDebugToolset(objects...)
+	result, err := toolset.handleFindOrphanedServices(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"namespace": "default"},
+	})
+	if err != nil {
+		t.Fatalf("handleFindOrphanedServices: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	var orphans []orphanedService
+	for _, item := range data["evidence"].([]render.EvidenceItem) {
+		if item.Summary == "orphanedServices" {
+			orphans = item.Details.([]orphanedService)
+		}
+	}
+	want := map[string]string{"manual": orphanEmptyEndpoint, "typo": orphanNoPodsMatch, "unready": orphanNoneReady}
+	if len(orphans) != len(want) {
+		t.Fatalf("expected %d orphans, got %#v", len(want), orphans)
+	}
+	for _, orphan := range orphans {
+		if want[orphan.Name] != orphan.Issue {
+			t.Fatalf("%s: expected %q, got %#v", orphan.Name, want[orphan.Name], orphan)
+		}
+	}
+	if orphans[2].NotReadyPods[0] != "web-1" {
+		t.Fatalf("expected web-1 listed as not ready, got %#v", orphans[2])
+	}
+}
+
+func TestReadyEndpointCount(t *testing.T) {
+	notReady := false
+	slices := []discoveryv1.EndpointSlice{{Endpoints: []discoveryv1.Endpoint{
+		{Addresses: []string{"10.0.0.1"}},
+		{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: &notReady}},
+	}}}
+	if got := readyEndpointCount(nil, slices); got != 1 {
+		t.Fatalf("expected 1 ready slice endpoint, got %d", got)
+	}
+	endpoints := &corev1.Endpoints{Subsets: []corev1.EndpointSubset{{
+		Addresses:         []corev1.EndpointAddress{{IP: "10.0.0.3"}},
+		NotReadyAddresses: []corev1.EndpointAddress{{IP: "10.0.0.4"}},
+	}}}
+	if got := readyEndpointCount(endpoints, nil); got != 1 {
+		t.Fatalf("expected 1 ready address, got %d", got)
+	}
+	if got := readyEndpointCount(nil, nil); got != 0 {
+		t.Fatalf("expected 0 without endpoints, got %d", got)
+	}
+}
@@ -0,0 +1,178 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/render"
+)
+
+type pdbStatusSummary struct {
+	Namespace          string   `json:"namespace"`
+	Name               string   `json:"name"`
+	Selector           string   `json:"selector"`
+	MinAvailable       string   `json:"minAvailable,omitempty"`
+	MaxUnavailable     string   `json:"maxUnavailable,omitempty"`
+	CurrentHealthy     int32    `json:"currentHealthy"`
+	DesiredHealthy     int32    `json:"desiredHealthy"`
+	ExpectedPods       int32    `json:"expectedPods"`
+	DisruptionsAllowed int32    `json:"disruptionsAllowed"`
+	Blocking           bool     `json:"blocking"`
+	Reason             string   `json:"reason,omitempty"`
+	Workloads          []string `json:"workloads,omitempty"`
+	UnhealthyPods      []string `json:"unhealthyPods,omitempty"`
+}
+
+func (t *Toolset) handlePDBBlocking(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	namespace := toString(req.Arguments["namespace"])
+	if namespace != "" {
+		if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+			return errorResult(err), err
+		}
+	}
+	namespaces, err := t.allowedNamespaces(ctx, req.User)
+	if err != nil {
+		return errorResult(err), err
+	}
+	if namespace != "" {
+		namespaces = []string{namespace}
+	}
+	analysis := render.NewAnalysis()
+	var summaries []pdbStatusSummary
+	for _, ns := range namespaces {
+		pdbs, err := t.ctx.Clients.Typed.PolicyV1().PodDisruptionBudgets(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return errorResult(err), err
+		}
+		if len(pdbs.Items) == 0 {
+			continue
+		}
+		pods, err := t.ctx.Clients.Typed.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return errorResult(err), err
+		}
+		for i := range pdbs.Items {
+			summaries = append(summaries, pdbStatus(&pdbs.Items[i], pods.Items))
+		}
+	}
+	if len(summaries) == 0 {
+		analysis.AddEvidence("status", "no PodDisruptionBudgets found")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].Blocking != summaries[j].Blocking {
+			return summaries[i].Blocking
+		}
+		if summaries[i].Namespace != summaries[j].Namespace {
+			return summaries[i].Namespace < summaries[j].Namespace
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+	analysis.AddEvidence("podDisruptionBudgets", summaries)
+	blocking := 0
+	for _, summary := range summaries {
+		if !summary.Blocking {
+			continue
+		}
+		blocking++
+		analysis.AddResource(fmt.Sprintf("poddisruptionbudgets/%s/%s", summary.Namespace, summary.Name))
+		protects := "no workload"
+		if len(summary.Workloads) > 0 {
+			protects = strings.Join(summary.Workloads, ", ")
+		}
+		analysis.AddCause("PodDisruptionBudget blocks voluntary disruptions", fmt.Sprintf("%s/%s (protecting %s) allows 0 disruptions: %s", summary.Namespace, summary.Name, protects, summary.Reason), "high")
+	}
+	if blocking == 0 {
+		analysis.AddEvidence("status", "no PodDisruptionBudget is blocking evictions")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+	}
+	analysis.AddNextCheck("Node drains and Karpenter consolidation wait on these budgets; fix the unhealthy pods or scale the workload up first")
+	analysis.AddNextCheck("Budgets with no slack need minAvailable below the replica count (or maxUnavailable >= 1)")
+	analysis.AddNextCheck("Set unhealthyPodEvictionPolicy: AlwaysAllow so crash-looping pods do not hold the budget")
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+}
+
+// pdbStatus summarises one budget from its status, matched against the
+// namespace's pods to name the workloads it protects and explain a block.
+func pdbStatus(pdb *policyv1.PodDisruptionBudget, pods []corev1.Pod) pdbStatusSummary {
+	summary := pdbStatusSummary{
+		Namespace:          pdb.Namespace,
+		Name:               pdb.Name,
+		CurrentHealthy:     pdb.Status.CurrentHealthy,
+		DesiredHealthy:     pdb.Status.DesiredHealthy,
+		ExpectedPods:       pdb.Status.ExpectedPods,
+		DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+	}
+	if pdb.Spec.MinAvailable != nil {
+		summary.MinAvailable = pdb.Spec.MinAvailable.String()
+	}
+	if pdb.Spec.MaxUnavailable != nil {
+		summary.MaxUnavailable = pdb.Spec.MaxUnavailable.String()
+	}
+	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	if err != nil {
+		summary.Selector = err.Error()
+		return summary
+	}
+	summary.Selector = selector.String()
+	workloads := map[string]struct{}{}
+	// A nil selector matches nothing and an empty one matches every pod.
+	if pdb.Spec.Selector != nil {
+		for i := range pods {
+			pod := &pods[i]
+			if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed || !selector.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+			workloads[pdbPodWorkload(pod)] = struct{}{}
+			if !isPodReady(pod) {
+				summary.UnhealthyPods = append(summary.UnhealthyPods, pod.Name)
+			}
+		}
+	}
+	for workload := range workloads {
+		summary.Workloads = append(summary.Workloads, workload)
+	}
+	sort.Strings(summary.Workloads)
+	sort.Strings(summary.UnhealthyPods)
+
+	// Budgets that select nothing also report 0 allowed, but block nothing.
+	if summary.DisruptionsAllowed > 0 || summary.ExpectedPods == 0 {
+		return summary
+	}
+	summary.Blocking = true
+	switch {
+	case summary.CurrentHealthy < summary.DesiredHealthy:
+		summary.Reason = fmt.Sprintf("%d of %d desired pods healthy", summary.CurrentHealthy, summary.DesiredHealthy)
+		if pdb.Spec.UnhealthyPodEvictionPolicy != nil && *pdb.Spec.UnhealthyPodEvictionPolicy == policyv1.AlwaysAllow {
+			summary.Reason += "; unhealthy pods can still be evicted (AlwaysAllow)"
+		}
+	default:
+		summary.Reason = fmt.Sprintf("budget leaves no slack: %d healthy pods and %d required", summary.CurrentHealthy, summary.DesiredHealthy)
+	}
+	return summary
+}
+
+// pdbPodWorkload names the controller owning a pod, collapsing ReplicaSets
+// back to their Deployment.
+func pdbPodWorkload(pod *corev1.Pod) string {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return "Pod/" + pod.Name
+	}
+	if owner.Kind == "ReplicaSet" {
+		if hash := pod.Labels["pod-template-hash"]; hash != "" {
+			if name, ok := strings.CutSuffix(owner.Name, "-"+hash); ok {
+				return "Deployment/" + name
+			}
+		}
+	}
+	return owner.Kind + "/" + owner.Name
+}
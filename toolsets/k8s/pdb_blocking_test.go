@@ -0,0 +1,85 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+func pdb(name string, selector map[string]string, status policyv1.PodDisruptionBudgetStatus) *policyv1.PodDisruptionBudget {
+	minAvailable := intstr.FromInt(2)
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector:     &metav1.LabelSelector{MatchLabels: selector},
+		},
+		Status: status,
+	}
+}
+
+func TestPDBStatus(t *testing.T) {
+	controller := true
+	ready := orphanPod("api-5d4f-a", map[string]string{"app": "api", "pod-template-hash": "5d4f"}, true)
+	ready.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "api-5d4f", Controller: &controller}}
+	crashing := orphanPod("api-5d4f-b", map[string]string{"app": "api", "pod-template-hash": "5d4f"}, false)
+	crashing.OwnerReferences = ready.OwnerReferences
+	pods := []corev1.Pod{*ready, *crashing}
+
+	unhealthy := pdbStatus(pdb("api", map[string]string{"app": "api"}, policyv1.PodDisruptionBudgetStatus{CurrentHealthy: 1, DesiredHealthy: 2, ExpectedPods: 2}), pods)
+	if !unhealthy.Blocking || !strings.Contains(unhealthy.Reason, "1 of 2") || unhealthy.MinAvailable != "2" {
+		t.Fatalf("expected unhealthy block, got %#v", unhealthy)
+	}
+	if len(unhealthy.Workloads) != 1 || unhealthy.Workloads[0] != "Deployment/api" || len(unhealthy.UnhealthyPods) != 1 {
+		t.Fatalf("expected Deployment/api with one unhealthy pod, got %#v", unhealthy)
+	}
+
+	tight := pdbStatus(pdb("tight", map[string]string{"app": "api"}, policyv1.PodDisruptionBudgetStatus{CurrentHealthy: 2, DesiredHealthy: 2, ExpectedPods: 2}), pods)
+	if !tight.Blocking || !strings.Contains(tight.Reason, "no slack") {
+		t.Fatalf("expected no-slack block, got %#v", tight)
+	}
+
+	empty := pdbStatus(pdb("empty", map[string]string{"app": "gone"}, policyv1.PodDisruptionBudgetStatus{}), pods)
+	if empty.Blocking || len(empty.Workloads) != 0 {
+		t.Fatalf("expected budget without pods not to block, got %#v", empty)
+	}
+
+	ok := pdbStatus(pdb("ok", map[string]string{"app": "api"}, policyv1.PodDisruptionBudgetStatus{CurrentHealthy: 3, DesiredHealthy: 2, ExpectedPods: 3, DisruptionsAllowed: 1}), pods)
+	if ok.Blocking {
+		t.Fatalf("expected budget with allowance not to block, got %#v", ok)
+	}
+}
+
+func TestHandlePDBBlocking(t *testing.T) {
+	toolset := newDebugToolset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		orphanPod("web-1", map[string]string{"app": "web"}, true),
+		pdb("web", map[string]string{"app": "web"}, policyv1.PodDisruptionBudgetStatus{CurrentHealthy: 1, DesiredHealthy: 1, ExpectedPods: 1}),
+		pdb("spare", map[string]string{"app": "web"}, policyv1.PodDisruptionBudgetStatus{CurrentHealthy: 1, DesiredHealthy: 0, ExpectedPods: 1, DisruptionsAllowed: 1}),
+	)
+	result, err := toolset.handlePDBBlocking(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("handlePDBBlocking: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	causes := data["likelyRootCauses"].([]render.Cause)
+	if len(causes) != 1 || !strings.Contains(causes[0].Details, "default/web (protecting Pod/web-1)") {
+		t.Fatalf("unexpected causes: %#v", causes)
+	}
+	for _, item := range data["evidence"].([]render.EvidenceItem) {
+		if item.Summary == "podDisruptionBudgets" {
+			if summaries := item.Details.([]pdbStatusSummary); summaries[0].Name != "web" {
+				t.Fatalf("expected blocking budget first, got %#v", summaries)
+			}
+		}
+	}
+}
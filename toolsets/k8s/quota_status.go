@@ -0,0 +1,295 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/render"
+)
+
+const defaultQuotaThreshold = 0.9
+
+type quotaUsage struct {
+	Namespace   string  `json:"namespace"`
+	Quota       string  `json:"quota"`
+	Resource    string  `json:"resource"`
+	Used        string  `json:"used"`
+	Hard        string  `json:"hard"`
+	Utilization float64 `json:"utilization"`
+}
+
+type limitRangeRisk struct {
+	Namespace  string `json:"namespace"`
+	LimitRange string `json:"limitRange,omitempty"`
+	Workload   string `json:"workload,omitempty"`
+	Container  string `json:"container,omitempty"`
+	Issue      string `json:"issue"`
+}
+
+func (t *Toolset) handleQuotaStatus(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	namespace := toString(req.Arguments["namespace"])
+	if namespace != "" {
+		if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+			return errorResult(err), err
+		}
+	}
+	namespaces, err := t.allowedNamespaces(ctx, req.User)
+	if err != nil {
+		return errorResult(err), err
+	}
+	if namespace != "" {
+		namespaces = []string{namespace}
+	}
+	threshold := defaultQuotaThreshold
+	if value, ok := req.Arguments["threshold"].(float64); ok && value > 0 && value <= 1 {
+		threshold = value
+	}
+
+	analysis := render.NewAnalysis()
+	var (
+		usages []quotaUsage
+		risks  []limitRangeRisk
+	)
+	for _, ns := range namespaces {
+		quotas, err := t.ctx.Clients.Typed.CoreV1().ResourceQuotas(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return errorResult(err), err
+		}
+		ranges, err := t.ctx.Clients.Typed.CoreV1().LimitRanges(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return errorResult(err), err
+		}
+		if len(quotas.Items) == 0 && len(ranges.Items) == 0 {
+			continue
+		}
+		usages = append(usages, quotaUsages(quotas.Items)...)
+		templates, err := t.podTemplates(ctx, ns)
+		if err != nil {
+			return errorResult(err), err
+		}
+		risks = append(risks, limitRangeRisks(ns, quotas.Items, ranges.Items, templates)...)
+		if len(ranges.Items) > 0 {
+			analysis.AddEvidence(fmt.Sprintf("%s limitRanges", ns), summarizeLimitRanges(ranges.Items))
+		}
+	}
+	if len(usages) == 0 && len(risks) == 0 {
+		analysis.AddEvidence("status", "no ResourceQuotas or LimitRange risks found")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+	}
+	sort.SliceStable(usages, func(i, j int) bool { return usages[i].Utilization > usages[j].Utilization })
+	if len(usages) > 0 {
+		analysis.AddEvidence("quotaUsage", usages)
+	}
+	if len(risks) > 0 {
+		analysis.AddEvidence("limitRangeRisks", risks)
+	}
+	for _, usage := range usages {
+		ref := fmt.Sprintf("%s/%s %s (%s of %s)", usage.Namespace, usage.Quota, usage.Resource, usage.Used, usage.Hard)
+		switch {
+		case usage.Utilization >= 1:
+			analysis.AddCause("ResourceQuota exhausted", ref+"; new pods or objects counted by it are rejected at admission", "high")
+		case usage.Utilization >= threshold:
+			analysis.AddCause("ResourceQuota nearly exhausted", fmt.Sprintf("%s at %.0f%%; the next scale-up may be rejected", ref, usage.Utilization*100), "medium")
+		default:
+			continue
+		}
+		analysis.AddResource(fmt.Sprintf("resourcequotas/%s/%s", usage.Namespace, usage.Quota))
+	}
+	for _, risk := range risks {
+		subject := risk.Namespace
+		if risk.Workload != "" {
+			subject = fmt.Sprintf("%s/%s container %s", risk.Namespace, risk.Workload, risk.Container)
+		}
+		analysis.AddCause("Pods may be rejected at admission", fmt.Sprintf("%s: %s", subject, risk.Issue), "medium")
+	}
+	analysis.AddNextCheck("Look for FailedCreate events on ReplicaSets/StatefulSets; admission rejections never reach pod status")
+	analysis.AddNextCheck("Raise the quota, free unused objects, or lower requests before scaling")
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: sliceIf(namespace)}}, nil
+}
+
+func quotaUsages(quotas []corev1.ResourceQuota) []quotaUsage {
+	var out []quotaUsage
+	for i := range quotas {
+		quota := &quotas[i]
+		names := make([]string, 0, len(quota.Status.Hard))
+		for name := range quota.Status.Hard {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			hard := quota.Status.Hard[corev1.ResourceName(name)]
+			used := quota.Status.Used[corev1.ResourceName(name)]
+			out = append(out, quotaUsage{
+				Namespace:   quota.Namespace,
+				Quota:       quota.Name,
+				Resource:    name,
+				Used:        used.String(),
+				Hard:        hard.String(),
+				Utilization: quantityRatio(used, hard),
+			})
+		}
+	}
+	return out
+}
+
+// quantityRatio is used/hard; a zero hard limit forbids any use and counts
+// as exhausted.
+func quantityRatio(used, hard resource.Quantity) float64 {
+	if hard.IsZero() {
+		return 1
+	}
+	return float64(used.MilliValue()) / float64(hard.MilliValue())
+}
+
+type podTemplate struct {
+	workload string
+	spec     corev1.PodSpec
+}
+
+func (t *Toolset) podTemplates(ctx context.Context, namespace string) ([]podTemplate, error) {
+	var out []podTemplate
+	deployments, err := t.ctx.Clients.Typed.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range deployments.Items {
+		out = append(out, podTemplate{workload: "Deployment/" + item.Name, spec: item.Spec.Template.Spec})
+	}
+	statefulSets, err := t.ctx.Clients.Typed.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range statefulSets.Items {
+		out = append(out, podTemplate{workload: "StatefulSet/" + item.Name, spec: item.Spec.Template.Spec})
+	}
+	daemonSets, err := t.ctx.Clients.Typed.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range daemonSets.Items {
+		out = append(out, podTemplate{workload: "DaemonSet/" + item.Name, spec: item.Spec.Template.Spec})
+	}
+	return out, nil
+}
+
+type quotaComputeField struct {
+	resource corev1.ResourceName
+	limit    bool
+}
+
+// quotaComputeResources maps compute quota names to the container field a
+// pod must set (or have defaulted) to be admitted under them.
+var quotaComputeResources = map[corev1.ResourceName]quotaComputeField{
+	corev1.ResourceCPU:            {corev1.ResourceCPU, false},
+	corev1.ResourceMemory:         {corev1.ResourceMemory, false},
+	corev1.ResourceRequestsCPU:    {corev1.ResourceCPU, false},
+	corev1.ResourceRequestsMemory: {corev1.ResourceMemory, false},
+	corev1.ResourceLimitsCPU:      {corev1.ResourceCPU, true},
+	corev1.ResourceLimitsMemory:   {corev1.ResourceMemory, true},
+}
+
+// limitRangeRisks finds workload containers the LimitRanger or quota
+// admission would reject: outside Container min/max, over the
+// limit/request ratio, or missing a value a compute quota requires and no
+// LimitRange defaults.
+func limitRangeRisks(namespace string, quotas []corev1.ResourceQuota, ranges []corev1.LimitRange, templates []podTemplate) []limitRangeRisk {
+	var risks []limitRangeRisk
+	var containerLimits []corev1.LimitRangeItem
+	defaults := corev1.ResourceList{}
+	defaultRequests := corev1.ResourceList{}
+	for _, lr := range ranges {
+		for _, item := range lr.Spec.Limits {
+			if item.Type != corev1.LimitTypeContainer {
+				continue
+			}
+			containerLimits = append(containerLimits, item)
+			for name, value := range item.Default {
+				defaults[name] = value
+				if _, ok := item.DefaultRequest[name]; !ok {
+					defaultRequests[name] = value
+				}
+			}
+			for name, value := range item.DefaultRequest {
+				defaultRequests[name] = value
+			}
+			for name, max := range item.Max {
+				if value, ok := item.Default[name]; ok && value.Cmp(max) > 0 {
+					risks = append(risks, limitRangeRisk{Namespace: namespace, LimitRange: lr.Name, Issue: fmt.Sprintf("default %s %s exceeds max %s; every defaulted container is rejected", name, value.String(), max.String())})
+				}
+			}
+		}
+	}
+	required := map[string]quotaComputeField{}
+	for _, quota := range quotas {
+		for name := range quota.Spec.Hard {
+			if field, ok := quotaComputeResources[name]; ok {
+				required[string(name)] = field
+			}
+		}
+	}
+	for _, template := range templates {
+		containers := append(append([]corev1.Container{}, template.spec.InitContainers...), template.spec.Containers...)
+		for _, container := range containers {
+			for _, issue := range containerAdmissionIssues(container, containerLimits, required, defaults, defaultRequests) {
+				risks = append(risks, limitRangeRisk{Namespace: namespace, Workload: template.workload, Container: container.Name, Issue: issue})
+			}
+		}
+	}
+	return risks
+}
+
+func containerAdmissionIssues(container corev1.Container, limits []corev1.LimitRangeItem, required map[string]quotaComputeField, defaults, defaultRequests corev1.ResourceList) []string {
+	var issues []string
+	requests := container.Resources.Requests
+	limitValues := container.Resources.Limits
+	for _, item := range limits {
+		for name, max := range item.Max {
+			if value, ok := limitValues[name]; ok && value.Cmp(max) > 0 {
+				issues = append(issues, fmt.Sprintf("%s limit %s exceeds LimitRange max %s", name, value.String(), max.String()))
+			} else if value, ok := requests[name]; ok && value.Cmp(max) > 0 {
+				issues = append(issues, fmt.Sprintf("%s request %s exceeds LimitRange max %s", name, value.String(), max.String()))
+			}
+		}
+		for name, min := range item.Min {
+			if value, ok := requests[name]; ok && value.Cmp(min) < 0 {
+				issues = append(issues, fmt.Sprintf("%s request %s is below LimitRange min %s", name, value.String(), min.String()))
+			}
+		}
+		for name, ratio := range item.MaxLimitRequestRatio {
+			limit, hasLimit := limitValues[name]
+			request, hasRequest := requests[name]
+			if hasLimit && hasRequest && !request.IsZero() && float64(limit.MilliValue())/float64(request.MilliValue()) > ratio.AsApproximateFloat64() {
+				issues = append(issues, fmt.Sprintf("%s limit/request ratio exceeds LimitRange maxLimitRequestRatio %s", name, ratio.String()))
+			}
+		}
+	}
+	names := make([]string, 0, len(required))
+	for name := range required {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, quotaName := range names {
+		field := required[quotaName]
+		if field.limit {
+			if _, ok := limitValues[field.resource]; !ok {
+				if _, defaulted := defaults[field.resource]; !defaulted {
+					issues = append(issues, fmt.Sprintf("quota tracks %s but the container sets no %s limit and no LimitRange default exists", quotaName, field.resource))
+				}
+			}
+			continue
+		}
+		_, hasRequest := requests[field.resource]
+		_, hasLimit := limitValues[field.resource]
+		_, defaulted := defaultRequests[field.resource]
+		if !hasRequest && !hasLimit && !defaulted {
+			issues = append(issues, fmt.Sprintf("quota tracks %s but the container sets no %s request and no LimitRange default exists", quotaName, field.resource))
+		}
+	}
+	return uniqueStrings(issues)
+}
@@ -0,0 +1,100 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+func TestQuotaUsagesAndLimitRangeRisks(t *testing.T) {
+	quota := corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "team"},
+		Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceLimitsMemory: resource.MustParse("8Gi")}},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10"), corev1.ResourceLimitsMemory: resource.MustParse("8Gi"), corev1.ResourceServicesLoadBalancers: resource.MustParse("0")},
+			Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("5"), corev1.ResourceLimitsMemory: resource.MustParse("7680Mi")},
+		},
+	}
+	usages := quotaUsages([]corev1.ResourceQuota{quota})
+	if len(usages) != 3 || usages[0].Resource != "limits.memory" || usages[0].Utilization < 0.93 || usages[0].Utilization > 0.94 {
+		t.Fatalf("unexpected usages: %#v", usages)
+	}
+	if usages[2].Resource != "services.loadbalancers" || usages[2].Utilization != 1 {
+		t.Fatalf("expected zero hard limit to count as exhausted, got %#v", usages[2])
+	}
+
+	limitRange := corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "team"},
+		Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{{
+			Type:                 corev1.LimitTypeContainer,
+			Max:                  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			Min:                  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")},
+			MaxLimitRequestRatio: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+		}}},
+	}
+	templates := []podTemplate{
+		{workload: "Deployment/big", spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: "app",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		}}}},
+		{workload: "Deployment/bare", spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}},
+	}
+	risks := limitRangeRisks("team", []corev1.ResourceQuota{quota}, []corev1.LimitRange{limitRange}, templates)
+	var issues []string
+	for _, risk := range risks {
+		issues = append(issues, risk.Workload+": "+risk.Issue)
+	}
+	joined := strings.Join(issues, "\n")
+	for _, want := range []string{
+		"Deployment/big: cpu limit 3 exceeds LimitRange max 2",
+		"Deployment/big: cpu limit/request ratio exceeds LimitRange maxLimitRequestRatio 4",
+		"Deployment/bare: quota tracks limits.memory but the container sets no memory limit",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("missing %q in:\n%s", want, joined)
+		}
+	}
+	if len(risks) != 3 {
+		t.Fatalf("expected 3 risks, got:\n%s", joined)
+	}
+}
+
+func TestHandleQuotaStatus(t *testing.T) {
+	replicas := int32(3)
+	toolset := newDebugToolset(
+		&corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "pods", Namespace: "default"},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("4")},
+				Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("4")},
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+	)
+	result, err := toolset.handleQuotaStatus(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"namespace": "default"},
+	})
+	if err != nil {
+		t.Fatalf("handleQuotaStatus: %v", err)
+	}
+	causes := result.Data.(map[string]any)["likelyRootCauses"].([]render.Cause)
+	if len(causes) != 1 || causes[0].Summary != "ResourceQuota exhausted" || !strings.Contains(causes[0].Details, "default/pods pods (4 of 4)") {
+		t.Fatalf("unexpected causes: %#v", causes)
+	}
+}
@@ -0,0 +1,234 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/argconv"
+	"rootcause/internal/evidence"
+	"rootcause/internal/mcp"
+	"rootcause/internal/render"
+)
+
+const (
+	restartPatternOOMKilled     = "OOMKilled"
+	restartPatternLivenessProbe = "LivenessProbe"
+	restartPatternCrashLoop     = "CrashLoopBackOff"
+	restartPatternRestarting    = "Restarting"
+)
+
+type containerRestartSummary struct {
+	Pod            string `json:"pod"`
+	Container      string `json:"container"`
+	Init           bool   `json:"init,omitempty"`
+	RestartCount   int32  `json:"restartCount"`
+	Pattern        string `json:"pattern"`
+	Waiting        string `json:"waiting,omitempty"`
+	LastReason     string `json:"lastReason,omitempty"`
+	LastExitCode   *int32 `json:"lastExitCode,omitempty"`
+	LastFinishedAt string `json:"lastFinishedAt,omitempty"`
+	Evidence       string `json:"evidence,omitempty"`
+}
+
+func (t *Toolset) handlePodRestartAnalysis(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	namespace := toString(req.Arguments["namespace"])
+	selector := toString(req.Arguments["labelSelector"])
+	podName := toString(req.Arguments["pod"])
+	limit := argconv.Int(req.Arguments["limit"], 20)
+	if namespace == "" {
+		return errorResult(errors.New("namespace is required")), errors.New("namespace is required")
+	}
+	if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+		return errorResult(err), err
+	}
+	var pods []corev1.Pod
+	if podName != "" {
+		pod, err := t.ctx.Clients.Typed.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return errorResult(err), err
+		}
+		pods = []corev1.Pod{*pod}
+	} else {
+		list, err := t.ctx.Clients.Typed.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return errorResult(err), err
+		}
+		pods = list.Items
+	}
+
+	analysis := render.NewAnalysis()
+	var offenders []containerRestartSummary
+	patterns := map[string]int{}
+	byName := map[string]*corev1.Pod{}
+	for i := range pods {
+		pod := &pods[i]
+		summaries := containerRestartSummaries(pod)
+		if len(summaries) == 0 {
+			continue
+		}
+		byName[pod.Name] = pod
+		analysis.AddResource(fmt.Sprintf("pods/%s/%s", namespace, pod.Name))
+		// Liveness kills only show up in events; look them up for pods that
+		// actually restarted rather than for every pod in the namespace.
+		events, err := evidence.RecentEvents(ctx, t.ctx.Evidence, namespace, corev1.ObjectReference{Kind: "Pod", Name: pod.Name, UID: pod.UID})
+		if err == nil {
+			markLivenessKills(summaries, events)
+		}
+		for _, summary := range summaries {
+			patterns[summary.Pattern]++
+		}
+		offenders = append(offenders, summaries...)
+	}
+	if len(offenders) == 0 {
+		analysis.AddEvidence("status", fmt.Sprintf("no restarting containers across %d pod(s)", len(pods)))
+		analysis.AddNextCheck("Check readiness probes and service endpoints if traffic is still failing")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+	}
+	rankRestartOffenders(offenders)
+	if limit > 0 && len(offenders) > limit {
+		analysis.AddEvidence("truncated", fmt.Sprintf("showing %d of %d restarting containers", limit, len(offenders)))
+		offenders = offenders[:limit]
+	}
+	analysis.AddEvidence("patterns", patterns)
+	analysis.AddEvidence("offenders", offenders)
+	reported := map[string]struct{}{}
+	for _, offender := range offenders {
+		if _, ok := reported[offender.Pod]; ok {
+			continue
+		}
+		reported[offender.Pod] = struct{}{}
+		analysis.AddEvidence(offender.Pod, t.ctx.Evidence.PodStatusSummary(byName[offender.Pod]))
+	}
+
+	worst := offenders[0]
+	analysis.AddCause(restartPatternCause(worst.Pattern), fmt.Sprintf("%s/%s restarted %d time(s)%s", worst.Pod, worst.Container, worst.RestartCount, restartDetail(worst)), "high")
+	switch worst.Pattern {
+	case restartPatternOOMKilled:
+		analysis.AddNextCheck(fmt.Sprintf("Compare %s memory limit with actual usage (k8s.resource_usage)", worst.Container))
+	case restartPatternLivenessProbe:
+		analysis.AddNextCheck(fmt.Sprintf("Review %s livenessProbe timeouts and startup time", worst.Container))
+	default:
+		analysis.AddNextCheck(fmt.Sprintf("Read previous logs: k8s.logs pod=%s container=%s previous=true", worst.Pod, worst.Container))
+	}
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+}
+
+// containerRestartSummaries returns one entry per container (init containers
+// included) that has restarted or is currently backing off.
+func containerRestartSummaries(pod *corev1.Pod) []containerRestartSummary {
+	var out []containerRestartSummary
+	add := func(status corev1.ContainerStatus, init bool) {
+		waiting := ""
+		if status.State.Waiting != nil {
+			waiting = status.State.Waiting.Reason
+		}
+		if status.RestartCount == 0 && waiting != restartPatternCrashLoop {
+			return
+		}
+		summary := containerRestartSummary{
+			Pod:          pod.Name,
+			Container:    status.Name,
+			Init:         init,
+			RestartCount: status.RestartCount,
+			Waiting:      waiting,
+			Pattern:      restartPatternRestarting,
+		}
+		last := status.LastTerminationState.Terminated
+		if last == nil {
+			last = status.State.Terminated
+		}
+		if last != nil {
+			summary.LastReason = last.Reason
+			exitCode := last.ExitCode
+			summary.LastExitCode = &exitCode
+			if !last.FinishedAt.IsZero() {
+				summary.LastFinishedAt = last.FinishedAt.UTC().Format("2006-01-02T15:04:05Z")
+			}
+		}
+		switch {
+		case summary.LastReason == "OOMKilled":
+			summary.Pattern = restartPatternOOMKilled
+		case waiting == restartPatternCrashLoop:
+			summary.Pattern = restartPatternCrashLoop
+		}
+		out = append(out, summary)
+	}
+	for _, status := range pod.Status.InitContainerStatuses {
+		add(status, true)
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		add(status, false)
+	}
+	return out
+}
+
+// markLivenessKills reclassifies containers the kubelet restarted because
+// their liveness probe failed. The kubelet records these as "Killing" events
+// ("Container app failed liveness probe, will be restarted"); the container
+// itself just exits 137, which otherwise looks like an ordinary crash.
+func markLivenessKills(summaries []containerRestartSummary, events []corev1.Event) {
+	for i := range summaries {
+		if summaries[i].Pattern == restartPatternOOMKilled {
+			continue
+		}
+		needle := fmt.Sprintf("container %s failed liveness probe", strings.ToLower(summaries[i].Container))
+		for _, event := range events {
+			if strings.Contains(strings.ToLower(event.Message), needle) {
+				summaries[i].Pattern = restartPatternLivenessProbe
+				summaries[i].Evidence = event.Message
+				break
+			}
+		}
+	}
+}
+
+// rankRestartOffenders puts the containers most worth looking at first:
+// currently crash looping or OOM-killed ahead of everything else, then by
+// restart count.
+func rankRestartOffenders(offenders []containerRestartSummary) {
+	severity := func(pattern string) int {
+		switch pattern {
+		case restartPatternOOMKilled, restartPatternCrashLoop, restartPatternLivenessProbe:
+			return 1
+		}
+		return 0
+	}
+	sort.SliceStable(offenders, func(i, j int) bool {
+		a, b := offenders[i], offenders[j]
+		if severity(a.Pattern) != severity(b.Pattern) {
+			return severity(a.Pattern) > severity(b.Pattern)
+		}
+		if a.RestartCount != b.RestartCount {
+			return a.RestartCount > b.RestartCount
+		}
+		if a.Pod != b.Pod {
+			return a.Pod < b.Pod
+		}
+		return a.Container < b.Container
+	})
+}
+
+func restartPatternCause(pattern string) string {
+	switch pattern {
+	case restartPatternOOMKilled:
+		return "Container OOMKilled"
+	case restartPatternLivenessProbe:
+		return "Container killed by liveness probe"
+	case restartPatternCrashLoop:
+		return "CrashLoopBackOff"
+	}
+	return "Container restarting"
+}
+
+func restartDetail(summary containerRestartSummary) string {
+	if summary.LastReason == "" || summary.LastExitCode == nil {
+		return ""
+	}
+	return fmt.Sprintf("; last exit %s (code %d)", summary.LastReason, *summary.LastExitCode)
+}
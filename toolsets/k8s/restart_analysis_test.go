@@ -0,0 +1,135 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+func restartingPod(name string, statuses ...corev1.ContainerStatus) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID("uid-" + name), Labels: map[string]string{"app": "demo"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: statuses},
+	}
+}
+
+func lastTerminated(reason string, code int32) corev1.ContainerState {
+	return corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: reason, ExitCode: code}}
+}
+
+func TestHandlePodRestartAnalysisRanksOffenders(t *testing.T) {
+	oom := restartingPod("oom", corev1.ContainerStatus{
+		Name:                 "app",
+		RestartCount:         3,
+		LastTerminationState: lastTerminated("OOMKilled", 137),
+	})
+	probe := restartingPod("probe", corev1.ContainerStatus{
+		Name:                 "web",
+		RestartCount:         7,
+		LastTerminationState: lastTerminated("Error", 137),
+	})
+	crash := restartingPod("crash",
+		corev1.ContainerStatus{
+			Name:                 "app",
+			RestartCount:         12,
+			State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: lastTerminated("Error", 1),
+		},
+		corev1.ContainerStatus{Name: "sidecar"},
+	)
+	flaky := restartingPod("flaky", corev1.ContainerStatus{Name: "app", RestartCount: 20})
+	healthy := restartingPod("healthy", corev1.ContainerStatus{Name: "app"})
+	killing := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "probe.kill", Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "probe", Namespace: "default", UID: "uid-probe"},
+		Reason:         "Killing",
+		Message:        "Container web failed liveness probe, will be restarted",
+		Type:           corev1.EventTypeNormal,
+	}
+
+	toolset := newDebugToolset(oom, probe, crash, flaky, healthy, killing)
+	result, err := toolset.handlePodRestartAnalysis(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"namespace": "default", "labelSelector": "app=demo"},
+	})
+	if err != nil {
+		t.Fatalf("handlePodRestartAnalysis: %v", err)
+	}
+	offenders := restartOffendersFrom(t, result)
+	want := []struct {
+		pod     string
+		pattern string
+	}{
+		{"crash", restartPatternCrashLoop},
+		{"probe", restartPatternLivenessProbe},
+		{"oom", restartPatternOOMKilled},
+		{"flaky", restartPatternRestarting},
+	}
+	if len(offenders) != len(want) {
+		t.Fatalf("expected %d offenders, got %#v", len(want), offenders)
+	}
+	for i, expected := range want {
+		if offenders[i].Pod != expected.pod || offenders[i].Pattern != expected.pattern {
+			t.Fatalf("offender %d: expected %s/%s, got %#v", i, expected.pod, expected.pattern, offenders[i])
+		}
+	}
+	if offenders[2].LastExitCode == nil || *offenders[2].LastExitCode != 137 {
+		t.Fatalf("expected OOM exit code 137, got %#v", offenders[2])
+	}
+}
+
+func TestHandlePodRestartAnalysisSinglePodAndLimit(t *testing.T) {
+	pod := restartingPod("multi",
+		corev1.ContainerStatus{Name: "a", RestartCount: 1},
+		corev1.ContainerStatus{Name: "b", RestartCount: 5},
+	)
+	pod.Status.InitContainerStatuses = []corev1.ContainerStatus{{Name: "init", RestartCount: 2, LastTerminationState: lastTerminated("Error", 2)}}
+	toolset := newDebugToolset(pod)
+	user := policy.User{Role: policy.RoleCluster}
+
+	result, err := toolset.handlePodRestartAnalysis(context.Background(), mcp.ToolRequest{
+		User:      user,
+		Arguments: map[string]any{"namespace": "default", "pod": "multi", "limit": 2},
+	})
+	if err != nil {
+		t.Fatalf("handlePodRestartAnalysis: %v", err)
+	}
+	offenders := restartOffendersFrom(t, result)
+	if len(offenders) != 2 || offenders[0].Container != "b" || offenders[1].Container != "init" || !offenders[1].Init {
+		t.Fatalf("unexpected offenders: %#v", offenders)
+	}
+
+	if _, err := toolset.handlePodRestartAnalysis(context.Background(), mcp.ToolRequest{User: user, Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected error without namespace")
+	}
+	if _, err := toolset.handlePodRestartAnalysis(context.Background(), mcp.ToolRequest{
+		User:      user,
+		Arguments: map[string]any{"namespace": "default", "pod": "missing"},
+	}); err == nil {
+		t.Fatalf("expected error for missing pod")
+	}
+}
+
+func restartOffendersFrom(t *testing.T, result mcp.ToolResult) []containerRestartSummary {
+	t.Helper()
+	data, ok := result.Data.(map[string]any)
+	if !ok {
+		t.Fatalf("unexpected result data: %#v", result.Data)
+	}
+	items, _ := data["evidence"].([]render.EvidenceItem)
+	for _, item := range items {
+		if item.Summary == "offenders" {
+			offenders, _ := item.Details.([]containerRestartSummary)
+			return offenders
+		}
+	}
+	t.Fatalf("offenders evidence missing: %#v", data["evidence"])
+	return nil
+}
@@ -0,0 +1,329 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/render"
+)
+
+const (
+	rolloutComplete    = "complete"
+	rolloutProgressing = "progressing"
+	rolloutStalled     = "stalled"
+	rolloutUnknown     = "unknown"
+)
+
+// Waiting reasons that mean a new pod will not become ready without
+// intervention, so a rollout waiting on it is stalled rather than slow.
+var rolloutStuckReasons = map[string]struct{}{
+	"CrashLoopBackOff":           {},
+	"ImagePullBackOff":           {},
+	"ErrImagePull":               {},
+	"InvalidImageName":           {},
+	"CreateContainerConfigError": {},
+	"CreateContainerError":       {},
+	"RunContainerError":          {},
+}
+
+type rolloutState struct {
+	Kind               string `json:"kind"`
+	Name               string `json:"name"`
+	Verdict            string `json:"verdict"`
+	Reason             string `json:"reason"`
+	Generation         int64  `json:"generation"`
+	ObservedGeneration int64  `json:"observedGeneration"`
+	Desired            int32  `json:"desired"`
+	Updated            int32  `json:"updated"`
+	Ready              int32  `json:"ready"`
+	Available          int32  `json:"available"`
+	UpdateRevision     string `json:"updateRevision,omitempty"`
+}
+
+type rolloutPod struct {
+	Name   string `json:"name"`
+	Phase  string `json:"phase"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func (t *Toolset) handleRolloutStatus(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	args := req.Arguments
+	name := toString(args["name"])
+	namespace := toString(args["namespace"])
+	if name == "" || namespace == "" {
+		err := errors.New("name and namespace are required")
+		return errorResult(err), err
+	}
+	if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+		return errorResult(err), err
+	}
+	analysis := render.NewAnalysis()
+	var (
+		state    rolloutState
+		selector *metav1.LabelSelector
+		revision map[string]string
+	)
+	switch strings.ToLower(toString(args["kind"])) {
+	case "", "deployment", "deploy", "deployments":
+		deployment, err := t.ctx.Clients.Typed.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return errorResult(err), err
+		}
+		state = deploymentRolloutState(deployment)
+		selector = deployment.Spec.Selector
+		newRS, err := t.newReplicaSet(ctx, deployment)
+		if err != nil {
+			return errorResult(err), err
+		}
+		if newRS != nil {
+			revision = map[string]string{"pod-template-hash": newRS.Labels["pod-template-hash"]}
+			if state.Verdict != rolloutComplete {
+				analysis.AddEvidence("blockingReplicaSet", map[string]any{
+					"name":              newRS.Name,
+					"revision":          newRS.Annotations["deployment.kubernetes.io/revision"],
+					"replicas":          newRS.Status.Replicas,
+					"readyReplicas":     newRS.Status.ReadyReplicas,
+					"availableReplicas": newRS.Status.AvailableReplicas,
+				})
+				analysis.AddResource(fmt.Sprintf("replicasets/%s/%s", namespace, newRS.Name))
+			}
+		}
+	case "statefulset", "sts", "statefulsets":
+		statefulSet, err := t.ctx.Clients.Typed.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return errorResult(err), err
+		}
+		state = statefulSetRolloutState(statefulSet)
+		selector = statefulSet.Spec.Selector
+		if statefulSet.Status.UpdateRevision != "" {
+			revision = map[string]string{appsv1.ControllerRevisionHashLabelKey: statefulSet.Status.UpdateRevision}
+		}
+	case "daemonset", "ds", "daemonsets":
+		daemonSet, err := t.ctx.Clients.Typed.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return errorResult(err), err
+		}
+		state = daemonSetRolloutState(daemonSet)
+		selector = daemonSet.Spec.Selector
+	default:
+		err := errors.New("kind must be Deployment, StatefulSet, or DaemonSet")
+		return errorResult(err), err
+	}
+	analysis.AddResource(fmt.Sprintf("%ss/%s/%s", strings.ToLower(state.Kind), namespace, name))
+
+	// Only inspect pods while the rollout is still waiting on them.
+	if state.Verdict == rolloutProgressing || state.Verdict == rolloutStalled {
+		pods, err := t.rolloutBlockingPods(ctx, namespace, selector, revision)
+		if err != nil {
+			return errorResult(err), err
+		}
+		if len(pods) > 0 {
+			analysis.AddEvidence("blockingPods", pods)
+			for _, pod := range pods {
+				if _, stuck := rolloutStuckReasons[pod.Reason]; stuck && state.Verdict == rolloutProgressing {
+					state.Verdict = rolloutStalled
+					state.Reason = fmt.Sprintf("%s; new pod %s is in %s", state.Reason, pod.Name, pod.Reason)
+				}
+			}
+		}
+	}
+	analysis.AddEvidence("rollout", state)
+
+	switch state.Verdict {
+	case rolloutComplete:
+		analysis.AddNextCheck("Rollout is complete; if errors persist, compare behaviour against the previous revision")
+	case rolloutStalled:
+		analysis.AddCauseWithConfidence(fmt.Sprintf("%s rollout stalled", state.Kind), state.Reason, "high", render.ConfidenceHigh)
+		analysis.AddNextCheck("Inspect the blocking pods with k8s.crashloop_debug or k8s.describe")
+		analysis.AddNextCheck("Fix the new revision or roll back to the previous one")
+	case rolloutProgressing:
+		analysis.AddCauseWithConfidence(fmt.Sprintf("%s rollout in progress", state.Kind), state.Reason, "low", render.ConfidenceMedium)
+		analysis.AddNextCheck("Re-run k8s.rollout_status to confirm the rollout keeps moving")
+	default:
+		analysis.AddNextCheck(state.Reason)
+	}
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+}
+
+// deploymentRolloutState follows the checks kubectl rollout status makes,
+// plus ProgressDeadlineExceeded, which kubectl reports as an error.
+func deploymentRolloutState(deployment *appsv1.Deployment) rolloutState {
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	state := rolloutState{
+		Kind:               "Deployment",
+		Name:               deployment.Name,
+		Generation:         deployment.Generation,
+		ObservedGeneration: status.ObservedGeneration,
+		Desired:            desired,
+		Updated:            status.UpdatedReplicas,
+		Ready:              status.ReadyReplicas,
+		Available:          status.AvailableReplicas,
+	}
+	if deployment.Generation > status.ObservedGeneration {
+		return state.with(rolloutProgressing, "waiting for the deployment spec update to be observed")
+	}
+	for _, condition := range status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return state.with(rolloutStalled, fmt.Sprintf("progress deadline exceeded: %s", condition.Message))
+		}
+	}
+	switch {
+	case status.UpdatedReplicas < desired:
+		return state.with(rolloutProgressing, fmt.Sprintf("%d of %d new replicas have been updated", status.UpdatedReplicas, desired))
+	case status.Replicas > status.UpdatedReplicas:
+		return state.with(rolloutProgressing, fmt.Sprintf("%d old replicas are pending termination", status.Replicas-status.UpdatedReplicas))
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		return state.with(rolloutProgressing, fmt.Sprintf("%d of %d updated replicas are available", status.AvailableReplicas, status.UpdatedReplicas))
+	}
+	return state.with(rolloutComplete, "all replicas are updated and available")
+}
+
+func statefulSetRolloutState(statefulSet *appsv1.StatefulSet) rolloutState {
+	desired := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		desired = *statefulSet.Spec.Replicas
+	}
+	status := statefulSet.Status
+	state := rolloutState{
+		Kind:               "StatefulSet",
+		Name:               statefulSet.Name,
+		Generation:         statefulSet.Generation,
+		ObservedGeneration: status.ObservedGeneration,
+		Desired:            desired,
+		Updated:            status.UpdatedReplicas,
+		Ready:              status.ReadyReplicas,
+		Available:          status.AvailableReplicas,
+		UpdateRevision:     status.UpdateRevision,
+	}
+	if statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return state.with(rolloutUnknown, "OnDelete update strategy: pods only update when deleted, so rollout progress cannot be tracked")
+	}
+	if statefulSet.Generation > status.ObservedGeneration {
+		return state.with(rolloutProgressing, "waiting for the statefulset spec update to be observed")
+	}
+	if status.ReadyReplicas < desired {
+		return state.with(rolloutProgressing, fmt.Sprintf("%d of %d pods are ready", status.ReadyReplicas, desired))
+	}
+	if rolling := statefulSet.Spec.UpdateStrategy.RollingUpdate; rolling != nil && rolling.Partition != nil && *rolling.Partition > 0 {
+		target := desired - *rolling.Partition
+		if status.UpdatedReplicas < target {
+			return state.with(rolloutProgressing, fmt.Sprintf("%d of %d partitioned pods have been updated", status.UpdatedReplicas, target))
+		}
+		return state.with(rolloutComplete, fmt.Sprintf("partitioned rollout complete: %d new pods updated", status.UpdatedReplicas))
+	}
+	if status.UpdateRevision != status.CurrentRevision {
+		return state.with(rolloutProgressing, fmt.Sprintf("%d of %d pods are on update revision %s", status.UpdatedReplicas, desired, status.UpdateRevision))
+	}
+	return state.with(rolloutComplete, "all pods are on the current revision and ready")
+}
+
+func daemonSetRolloutState(daemonSet *appsv1.DaemonSet) rolloutState {
+	status := daemonSet.Status
+	state := rolloutState{
+		Kind:               "DaemonSet",
+		Name:               daemonSet.Name,
+		Generation:         daemonSet.Generation,
+		ObservedGeneration: status.ObservedGeneration,
+		Desired:            status.DesiredNumberScheduled,
+		Updated:            status.UpdatedNumberScheduled,
+		Ready:              status.NumberReady,
+		Available:          status.NumberAvailable,
+	}
+	if daemonSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+		return state.with(rolloutUnknown, "OnDelete update strategy: pods only update when deleted, so rollout progress cannot be tracked")
+	}
+	if daemonSet.Generation > status.ObservedGeneration {
+		return state.with(rolloutProgressing, "waiting for the daemonset spec update to be observed")
+	}
+	if status.UpdatedNumberScheduled < status.DesiredNumberScheduled {
+		return state.with(rolloutProgressing, fmt.Sprintf("%d of %d updated pods have been scheduled", status.UpdatedNumberScheduled, status.DesiredNumberScheduled))
+	}
+	if status.NumberAvailable < status.DesiredNumberScheduled {
+		return state.with(rolloutProgressing, fmt.Sprintf("%d of %d updated pods are available", status.NumberAvailable, status.DesiredNumberScheduled))
+	}
+	return state.with(rolloutComplete, "all pods are updated and available")
+}
+
+func (s rolloutState) with(verdict, reason string) rolloutState {
+	s.Verdict = verdict
+	s.Reason = reason
+	return s
+}
+
+// newReplicaSet finds the ReplicaSet for the deployment's current revision,
+// which is the one a stuck rollout is waiting on.
+func (t *Toolset) newReplicaSet(ctx context.Context, deployment *appsv1.Deployment) (*appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	list, err := t.ctx.Clients.Typed.AppsV1().ReplicaSets(deployment.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	revision := deployment.Annotations["deployment.kubernetes.io/revision"]
+	var newest *appsv1.ReplicaSet
+	for i := range list.Items {
+		rs := &list.Items[i]
+		if owner := metav1.GetControllerOf(rs); owner == nil || owner.UID != deployment.UID {
+			continue
+		}
+		if revision != "" && rs.Annotations["deployment.kubernetes.io/revision"] == revision {
+			return rs, nil
+		}
+		if newest == nil || newest.CreationTimestamp.Before(&rs.CreationTimestamp) {
+			newest = rs
+		}
+	}
+	return newest, nil
+}
+
+// rolloutBlockingPods lists the workload's pods that are not ready, limited to
+// the new revision when its hash label is known.
+func (t *Toolset) rolloutBlockingPods(ctx context.Context, namespace string, selector *metav1.LabelSelector, revision map[string]string) ([]rolloutPod, error) {
+	if selector == nil {
+		return nil, nil
+	}
+	podSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := t.ctx.Clients.Typed.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: podSelector.String()})
+	if err != nil {
+		return nil, err
+	}
+	revisionSelector := labels.SelectorFromSet(revision)
+	var out []rolloutPod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !revisionSelector.Matches(labels.Set(pod.Labels)) || isPodReady(pod) {
+			continue
+		}
+		out = append(out, rolloutPod{Name: pod.Name, Phase: string(pod.Status.Phase), Reason: podWaitingReason(pod)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+func podWaitingReason(pod *corev1.Pod) string {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+			return status.State.Waiting.Reason
+		}
+	}
+	return pod.Status.Reason
+}
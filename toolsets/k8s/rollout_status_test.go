@@ -0,0 +1,161 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+func rolloutDeployment(replicas int32, status appsv1.DeploymentStatus) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "api",
+			Namespace:   "default",
+			UID:         "uid-api",
+			Generation:  3,
+			Annotations: map[string]string{"deployment.kubernetes.io/revision": "3"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+		},
+		Status: status,
+	}
+}
+
+func rolloutEvidence(t *testing.T, result mcp.ToolResult) (rolloutState, map[string]any) {
+	t.Helper()
+	data := result.Data.(map[string]any)
+	items := map[string]any{}
+	for _, item := range data["evidence"].([]render.EvidenceItem) {
+		items[item.Summary] = item.Details
+	}
+	state, ok := items["rollout"].(rolloutState)
+	if !ok {
+		t.Fatalf("rollout evidence missing: %#v", items)
+	}
+	return state, items
+}
+
+func TestDeploymentRolloutState(t *testing.T) {
+	cases := []struct {
+		name    string
+		status  appsv1.DeploymentStatus
+		verdict string
+	}{
+		{"complete", appsv1.DeploymentStatus{ObservedGeneration: 3, Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2, AvailableReplicas: 2}, rolloutComplete},
+		{"not observed", appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}, rolloutProgressing},
+		{"old replicas", appsv1.DeploymentStatus{ObservedGeneration: 3, Replicas: 3, UpdatedReplicas: 2, AvailableReplicas: 2}, rolloutProgressing},
+		{"unavailable", appsv1.DeploymentStatus{ObservedGeneration: 3, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1}, rolloutProgressing},
+		{"deadline", appsv1.DeploymentStatus{ObservedGeneration: 3, Replicas: 2, UpdatedReplicas: 1, Conditions: []appsv1.DeploymentCondition{{
+			Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded", Message: `ReplicaSet "api-new" has timed out progressing.`,
+		}}}, rolloutStalled},
+	}
+	for _, tc := range cases {
+		if got := deploymentRolloutState(rolloutDeployment(2, tc.status)); got.Verdict != tc.verdict {
+			t.Fatalf("%s: expected %s, got %#v", tc.name, tc.verdict, got)
+		}
+	}
+}
+
+func TestStatefulSetAndDaemonSetRolloutState(t *testing.T) {
+	replicas, partition := int32(3), int32(2)
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Generation: 1},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 3, UpdatedReplicas: 1, CurrentRevision: "db-1", UpdateRevision: "db-2"},
+	}
+	if got := statefulSetRolloutState(sts); got.Verdict != rolloutComplete {
+		t.Fatalf("expected partitioned rollout complete, got %#v", got)
+	}
+	sts.Spec.UpdateStrategy.RollingUpdate = nil
+	if got := statefulSetRolloutState(sts); got.Verdict != rolloutProgressing || !strings.Contains(got.Reason, "db-2") {
+		t.Fatalf("expected progressing towards db-2, got %#v", got)
+	}
+	sts.Spec.UpdateStrategy.Type = appsv1.OnDeleteStatefulSetStrategyType
+	if got := statefulSetRolloutState(sts); got.Verdict != rolloutUnknown {
+		t.Fatalf("expected unknown for OnDelete, got %#v", got)
+	}
+
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Generation: 4},
+		Status:     appsv1.DaemonSetStatus{ObservedGeneration: 4, DesiredNumberScheduled: 5, UpdatedNumberScheduled: 5, NumberAvailable: 4},
+	}
+	if got := daemonSetRolloutState(ds); got.Verdict != rolloutProgressing {
+		t.Fatalf("expected progressing daemonset, got %#v", got)
+	}
+	ds.Status.NumberAvailable = 5
+	if got := daemonSetRolloutState(ds); got.Verdict != rolloutComplete {
+		t.Fatalf("expected complete daemonset, got %#v", got)
+	}
+}
+
+func TestHandleRolloutStatusReportsBlockingReplicaSet(t *testing.T) {
+	deployment := rolloutDeployment(2, appsv1.DeploymentStatus{ObservedGeneration: 3, Replicas: 3, UpdatedReplicas: 1, ReadyReplicas: 2, AvailableReplicas: 2})
+	controller := true
+	replicaSet := func(name, hash, revision string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			Labels:          map[string]string{"app": "api", "pod-template-hash": hash},
+			Annotations:     map[string]string{"deployment.kubernetes.io/revision": revision},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "api", UID: "uid-api", Controller: &controller}},
+		}}
+	}
+	oldRS, newRS := replicaSet("api-old", "old", "2"), replicaSet("api-new", "new", "3")
+	crashing := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-new-1", Namespace: "default", Labels: map[string]string{"app": "api", "pod-template-hash": "new"}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "app",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		}}},
+	}
+	oldPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-old-1", Namespace: "default", Labels: map[string]string{"app": "api", "pod-template-hash": "old"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	toolset := newDebugToolset(deployment, oldRS, newRS, crashing, oldPod)
+	user := policy.User{Role: policy.RoleCluster}
+
+	result, err := toolset.handleRolloutStatus(context.Background(), mcp.ToolRequest{
+		User:      user,
+		Arguments: map[string]any{"kind": "Deployment", "name": "api", "namespace": "default"},
+	})
+	if err != nil {
+		t.Fatalf("handleRolloutStatus: %v", err)
+	}
+	state, items := rolloutEvidence(t, result)
+	if state.Verdict != rolloutStalled || !strings.Contains(state.Reason, "CrashLoopBackOff") {
+		t.Fatalf("expected stalled on CrashLoopBackOff, got %#v", state)
+	}
+	if rs := items["blockingReplicaSet"].(map[string]any); rs["name"] != "api-new" {
+		t.Fatalf("expected api-new to block, got %#v", rs)
+	}
+	if pods := items["blockingPods"].([]rolloutPod); len(pods) != 1 || pods[0].Name != "api-new-1" {
+		t.Fatalf("expected only the new pod, got %#v", pods)
+	}
+
+	if _, err := toolset.handleRolloutStatus(context.Background(), mcp.ToolRequest{
+		User:      user,
+		Arguments: map[string]any{"kind": "CronJob", "name": "api", "namespace": "default"},
+	}); err == nil {
+		t.Fatalf("expected error for unsupported kind")
+	}
+	if _, err := toolset.handleRolloutStatus(context.Background(), mcp.ToolRequest{User: user, Arguments: map[string]any{"name": "api"}}); err == nil {
+		t.Fatalf("expected error without namespace")
+	}
+}
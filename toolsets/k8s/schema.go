@@ -38,7 +38,18 @@ func schemaList() map[string]any {
 }
 
 func schemaDescribe() map[string]any {
-	return schemaGet()
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"apiVersion":          map[string]any{"type": "string"},
+			"kind":                map[string]any{"type": "string"},
+			"resource":            map[string]any{"type": "string"},
+			"name":                map[string]any{"type": "string"},
+			"namespace":           map[string]any{"type": "string"},
+			"includeNormalEvents": map[string]any{"type": "boolean"},
+		},
+		"required": []string{"name"},
+	}
 }
 
 func schemaDelete() map[string]any {
@@ -96,6 +107,7 @@ func schemaLogs() map[string]any {
 			"container":    map[string]any{"type": "string"},
 			"tailLines":    map[string]any{"type": "number"},
 			"sinceSeconds": map[string]any{"type": "number"},
+			"previous":     map[string]any{"type": "boolean"},
 		},
 		"required": []string{"namespace", "pod"},
 	}
@@ -204,6 +216,18 @@ func schemaRollout() map[string]any {
 	}
 }
 
+func schemaRolloutStatus() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"kind":      map[string]any{"type": "string", "enum": []string{"Deployment", "StatefulSet", "DaemonSet"}},
+			"name":      map[string]any{"type": "string"},
+			"namespace": map[string]any{"type": "string"},
+		},
+		"required": []string{"name", "namespace"},
+	}
+}
+
 func schemaRestartSafetyCheck() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -253,9 +277,15 @@ func schemaGraph() map[string]any {
 	return map[string]any{
 		"type": "object",
 		"properties": map[string]any{
-			"kind":      map[string]any{"type": "string"},
-			"name":      map[string]any{"type": "string"},
-			"namespace": map[string]any{"type": "string"},
+			"kind":             map[string]any{"type": "string"},
+			"name":             map[string]any{"type": "string"},
+			"namespace":        map[string]any{"type": "string"},
+			"includeKinds":     map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"excludeKinds":     map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"includeRelations": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"groupBy":          map[string]any{"type": "string", "enum": []string{"none", "workload", "namespace"}},
+			"maxNodes":         map[string]any{"type": "integer", "minimum": 1},
+			"maxEdges":         map[string]any{"type": "integer", "minimum": 1},
 		},
 		"required": []string{"kind", "name", "namespace"},
 	}
@@ -366,6 +396,36 @@ func schemaOverview() map[string]any {
 	}
 }
 
+func schemaExplainUnschedulable() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"namespace":     map[string]any{"type": "string"},
+			"labelSelector": map[string]any{"type": "string"},
+		},
+	}
+}
+
+func schemaQuotaStatus() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"namespace": map[string]any{"type": "string"},
+			"threshold": map[string]any{"type": "number"},
+		},
+	}
+}
+
+func schemaNodePressure() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"labelSelector": map[string]any{"type": "string"},
+			"threshold":     map[string]any{"type": "number"},
+		},
+	}
+}
+
 func schemaCrashloopDebug() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -377,6 +437,19 @@ func schemaCrashloopDebug() map[string]any {
 	}
 }
 
+func schemaPodRestartAnalysis() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"namespace":     map[string]any{"type": "string"},
+			"labelSelector": map[string]any{"type": "string"},
+			"pod":           map[string]any{"type": "string"},
+			"limit":         map[string]any{"type": "number"},
+		},
+		"required": []string{"namespace"},
+	}
+}
+
 func schemaSchedulingDebug() map[string]any {
 	return schemaCrashloopDebug()
 }
@@ -396,6 +469,29 @@ func schemaVPADebug() map[string]any {
 	return schemaHPADebug()
 }
 
+func schemaDiagnoseImagePull() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"namespace":     map[string]any{"type": "string"},
+			"pod":           map[string]any{"type": "string"},
+			"labelSelector": map[string]any{"type": "string"},
+			"checkEcr":      map[string]any{"type": "boolean"},
+		},
+		"required": []string{"namespace"},
+	}
+}
+
+func schemaStorageStatus() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"namespace": map[string]any{"type": "string"},
+		},
+		"required": []string{"namespace"},
+	}
+}
+
 func schemaStorageDebug() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -423,6 +519,18 @@ func schemaConfigDebug() map[string]any {
 	}
 }
 
+func schemaFindConfigConsumers() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"namespace": map[string]any{"type": "string"},
+			"kind":      map[string]any{"type": "string", "enum": []string{"ConfigMap", "Secret", "configmap", "secret"}},
+			"name":      map[string]any{"type": "string"},
+		},
+		"required": []string{"namespace", "name"},
+	}
+}
+
 func schemaPermissionDebug() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -465,6 +573,34 @@ func schemaNetworkDebug() map[string]any {
 	}
 }
 
+func schemaPDBBlocking() map[string]any {
+	return schemaOverview()
+}
+
+func schemaFindOrphanedServices() map[string]any {
+	return schemaOverview()
+}
+
+func schemaEvaluateNetworkPolicy() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"namespace":       map[string]any{"type": "string"},
+			"pod":             map[string]any{"type": "string"},
+			"port":            map[string]any{"type": "integer"},
+			"protocol":        map[string]any{"type": "string", "enum": []string{"TCP", "UDP", "SCTP"}},
+			"sourcePod":       map[string]any{"type": "string"},
+			"sourceNamespace": map[string]any{"type": "string"},
+			"sourceLabels": map[string]any{
+				"type":                 "object",
+				"additionalProperties": map[string]any{"type": "string"},
+			},
+			"sourceIP": map[string]any{"type": "string"},
+		},
+		"required": []string{"namespace", "pod"},
+	}
+}
+
 func schemaPrivateLinkDebug() map[string]any {
 	return schemaNetworkDebug()
 }
@@ -0,0 +1,202 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/evidence"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+const defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+type pvcStatusRow struct {
+	Name               string   `json:"name"`
+	Phase              string   `json:"phase"`
+	StorageClass       string   `json:"storageClass,omitempty"`
+	StorageClassExists *bool    `json:"storageClassExists,omitempty"`
+	Provisioner        string   `json:"provisioner,omitempty"`
+	BindingMode        string   `json:"volumeBindingMode,omitempty"`
+	Volume             string   `json:"volume,omitempty"`
+	VolumePhase        string   `json:"volumePhase,omitempty"`
+	Requested          string   `json:"requested,omitempty"`
+	Capacity           string   `json:"capacity,omitempty"`
+	AccessModes        []string `json:"accessModes,omitempty"`
+	Consumers          []string `json:"consumers,omitempty"`
+	ExpectedPending    bool     `json:"expectedPending,omitempty"`
+	Reason             string   `json:"reason,omitempty"`
+}
+
+func (t *Toolset) handleStorageStatus(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	namespace := toString(req.Arguments["namespace"])
+	if namespace == "" {
+		err := errors.New("namespace is required")
+		return errorResult(err), err
+	}
+	if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+		return errorResult(err), err
+	}
+	pvcs, err := t.ctx.Clients.Typed.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errorResult(err), err
+	}
+	analysis := render.NewAnalysis()
+	if len(pvcs.Items) == 0 {
+		analysis.AddEvidence("status", "no pvcs found")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+	}
+	pods, err := t.ctx.Clients.Typed.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errorResult(err), err
+	}
+	consumers := map[string][]string{}
+	for i := range pods.Items {
+		for _, claim := range collectPVCNamesFromPod(&pods.Items[i]) {
+			consumers[claim] = append(consumers[claim], pods.Items[i].Name)
+		}
+	}
+
+	// StorageClasses and PVs are cluster-scoped.
+	clusterView := req.User.Role == policy.RoleCluster
+	classes := map[string]*storagev1.StorageClass{}
+	defaultClass := ""
+	if clusterView {
+		list, err := t.ctx.Clients.Typed.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return errorResult(err), err
+		}
+		for i := range list.Items {
+			sc := &list.Items[i]
+			classes[sc.Name] = sc
+			if sc.Annotations[defaultStorageClassAnnotation] == "true" {
+				defaultClass = sc.Name
+			}
+		}
+	} else {
+		analysis.AddEvidence("clusterChecks", "StorageClass and PersistentVolume checks require cluster role")
+	}
+
+	rows := make([]pvcStatusRow, 0, len(pvcs.Items))
+	for i := range pvcs.Items {
+		pvc := &pvcs.Items[i]
+		row := pvcStatusRow{
+			Name:      pvc.Name,
+			Phase:     string(pvc.Status.Phase),
+			Volume:    pvc.Spec.VolumeName,
+			Consumers: consumers[pvc.Name],
+		}
+		for _, mode := range pvc.Spec.AccessModes {
+			row.AccessModes = append(row.AccessModes, string(mode))
+		}
+		if requested, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+			row.Requested = requested.String()
+		}
+		if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+			row.Capacity = capacity.String()
+		}
+		if pvc.Spec.StorageClassName != nil {
+			row.StorageClass = *pvc.Spec.StorageClassName
+		} else {
+			row.StorageClass = defaultClass
+		}
+		if clusterView && row.StorageClass != "" {
+			sc, exists := classes[row.StorageClass]
+			row.StorageClassExists = &exists
+			if exists {
+				row.Provisioner = sc.Provisioner
+				row.BindingMode = string(storagev1.VolumeBindingImmediate)
+				if sc.VolumeBindingMode != nil {
+					row.BindingMode = string(*sc.VolumeBindingMode)
+				}
+			}
+		}
+		if clusterView && pvc.Spec.VolumeName != "" {
+			pv, err := t.ctx.Clients.Typed.CoreV1().PersistentVolumes().Get(ctx, pvc.Spec.VolumeName, metav1.GetOptions{})
+			switch {
+			case apierrors.IsNotFound(err):
+				row.VolumePhase = "Missing"
+			case err != nil:
+				return errorResult(err), err
+			default:
+				row.VolumePhase = string(pv.Status.Phase)
+			}
+		}
+		if pvc.Status.Phase == corev1.ClaimPending {
+			row.ExpectedPending, row.Reason = t.pendingClaimReason(ctx, pvc, row, clusterView)
+		}
+		rows = append(rows, row)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return pvcStatusRank(rows[i]) < pvcStatusRank(rows[j])
+	})
+	analysis.AddEvidence("persistentVolumeClaims", rows)
+
+	for _, row := range rows {
+		ref := fmt.Sprintf("%s/%s", namespace, row.Name)
+		switch {
+		case row.Phase == string(corev1.ClaimLost) || row.VolumePhase == "Missing":
+			analysis.AddCause("PVC lost its volume", fmt.Sprintf("%s was bound to %s, which no longer exists", ref, row.Volume), "critical")
+		case row.Phase == string(corev1.ClaimPending) && row.ExpectedPending:
+			analysis.AddCause("PVC pending until a pod is scheduled", fmt.Sprintf("%s: %s", ref, row.Reason), "low")
+		case row.Phase == string(corev1.ClaimPending):
+			analysis.AddCause("PVC stuck Pending", fmt.Sprintf("%s: %s", ref, row.Reason), "high")
+		default:
+			continue
+		}
+		analysis.AddResource(fmt.Sprintf("persistentvolumeclaims/%s/%s", namespace, row.Name))
+	}
+	analysis.AddNextCheck("Run k8s.storage_debug with pvc=<name> for PV matching, VolumeAttachments and CSI driver details")
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+}
+
+// pendingClaimReason explains a Pending claim, preferring the latest
+// provisioner warning. WaitForFirstConsumer claims without a consuming pod
+// are expected to stay Pending.
+func (t *Toolset) pendingClaimReason(ctx context.Context, pvc *corev1.PersistentVolumeClaim, row pvcStatusRow, clusterView bool) (bool, string) {
+	events, err := evidence.RecentEvents(ctx, t.ctx.Evidence, pvc.Namespace, corev1.ObjectReference{Kind: "PersistentVolumeClaim", Name: pvc.Name, UID: pvc.UID})
+	if err == nil {
+		for _, event := range events {
+			if event.Type == corev1.EventTypeWarning {
+				return false, fmt.Sprintf("%s: %s", event.Reason, event.Message)
+			}
+		}
+	}
+	switch {
+	case pvc.Spec.StorageClassName == nil && !clusterView:
+		return false, "no StorageClass set; the default StorageClass could not be checked"
+	case pvc.Spec.StorageClassName == nil && row.StorageClass == "":
+		return false, "no StorageClass set and the cluster has no default StorageClass"
+	case row.StorageClass == "":
+		return false, "static binding: no PersistentVolume matches the claim yet"
+	case row.StorageClassExists != nil && !*row.StorageClassExists:
+		return false, fmt.Sprintf("StorageClass %s does not exist", row.StorageClass)
+	case row.BindingMode == string(storagev1.VolumeBindingWaitForFirstConsumer) && len(row.Consumers) == 0:
+		return true, fmt.Sprintf("StorageClass %s uses WaitForFirstConsumer and no pod uses the claim", row.StorageClass)
+	case row.BindingMode == string(storagev1.VolumeBindingWaitForFirstConsumer):
+		return false, fmt.Sprintf("WaitForFirstConsumer: waiting for consumer pod %s to be scheduled", row.Consumers[0])
+	case row.Provisioner != "":
+		return false, fmt.Sprintf("waiting for provisioner %s", row.Provisioner)
+	}
+	return false, "waiting for a volume to be provisioned or bound"
+}
+
+func pvcStatusRank(row pvcStatusRow) int {
+	switch {
+	case row.Phase == string(corev1.ClaimLost) || row.VolumePhase == "Missing":
+		return 0
+	case row.Phase == string(corev1.ClaimPending) && !row.ExpectedPending:
+		return 1
+	case row.Phase == string(corev1.ClaimPending):
+		return 2
+	}
+	return 3
+}
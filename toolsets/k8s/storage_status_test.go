@@ -0,0 +1,112 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+func statusPVC(name string, class *string, phase corev1.PersistentVolumeClaimPhase, volume string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: class,
+			VolumeName:       volume,
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{Phase: phase},
+	}
+}
+
+func TestHandleStorageStatus(t *testing.T) {
+	wait := storagev1.VolumeBindingWaitForFirstConsumer
+	gp3, missing := "gp3", "fast"
+	bound := statusPVC("data", nil, corev1.ClaimBound, "pv-data")
+	bound.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}
+	toolset := newDebugToolset(
+		&storagev1.StorageClass{
+			ObjectMeta:        metav1.ObjectMeta{Name: "gp3", Annotations: map[string]string{defaultStorageClassAnnotation: "true"}},
+			Provisioner:       "ebs.csi.aws.com",
+			VolumeBindingMode: &wait,
+		},
+		&corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-data"},
+			Status:     corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+		},
+		bound,
+		statusPVC("idle", &gp3, corev1.ClaimPending, ""),
+		statusPVC("broken", &missing, corev1.ClaimPending, ""),
+		statusPVC("gone", &gp3, corev1.ClaimBound, "pv-deleted"),
+	)
+	result, err := toolset.handleStorageStatus(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"namespace": "default"},
+	})
+	if err != nil {
+		t.Fatalf("handleStorageStatus: %v", err)
+	}
+	data := result.Data.(map[string]any)
+	var rows []pvcStatusRow
+	for _, item := range data["evidence"].([]render.EvidenceItem) {
+		if item.Summary == "persistentVolumeClaims" {
+			rows = item.Details.([]pvcStatusRow)
+		}
+	}
+	if len(rows) != 4 || rows[0].Name != "gone" || rows[1].Name != "broken" || rows[2].Name != "idle" || rows[3].Name != "data" {
+		t.Fatalf("unexpected rows: %#v", rows)
+	}
+	if rows[3].StorageClass != "gp3" || rows[3].VolumePhase != "Bound" || rows[3].Capacity != "10Gi" || rows[3].Provisioner != "ebs.csi.aws.com" {
+		t.Fatalf("expected default class and bound PV on data, got %#v", rows[3])
+	}
+	if !rows[2].ExpectedPending || rows[2].BindingMode != string(wait) {
+		t.Fatalf("expected WaitForFirstConsumer claim without pods to be expected pending, got %#v", rows[2])
+	}
+	if rows[1].StorageClassExists == nil || *rows[1].StorageClassExists || !strings.Contains(rows[1].Reason, "StorageClass fast does not exist") {
+		t.Fatalf("expected missing StorageClass reason, got %#v", rows[1])
+	}
+	causes := data["likelyRootCauses"].([]render.Cause)
+	if len(causes) != 3 || causes[0].Summary != "PVC lost its volume" || causes[1].Summary != "PVC stuck Pending" || causes[2].Severity != "low" {
+		t.Fatalf("unexpected causes: %#v", causes)
+	}
+}
+
+func TestHandleStorageStatusPendingEvent(t *testing.T) {
+	class := "gp3"
+	toolset := newDebugToolset(
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "gp3"}, Provisioner: "ebs.csi.aws.com"},
+		statusPVC("data", &class, corev1.ClaimPending, ""),
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "data.1", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "PersistentVolumeClaim", Name: "data"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "ProvisioningFailed",
+			Message:        "UnauthorizedOperation",
+		},
+	)
+	result, err := toolset.handleStorageStatus(context.Background(), mcp.ToolRequest{
+		User:      policy.User{Role: policy.RoleCluster},
+		Arguments: map[string]any{"namespace": "default"},
+	})
+	if err != nil {
+		t.Fatalf("handleStorageStatus: %v", err)
+	}
+	causes := result.Data.(map[string]any)["likelyRootCauses"].([]render.Cause)
+	if len(causes) != 1 || !strings.Contains(causes[0].Details, "ProvisioningFailed: UnauthorizedOperation") {
+		t.Fatalf("unexpected causes: %#v", causes)
+	}
+	if _, err := toolset.handleStorageStatus(context.Background(), mcp.ToolRequest{User: policy.User{Role: policy.RoleCluster}, Arguments: map[string]any{}}); err == nil {
+		t.Fatalf("expected namespace to be required")
+	}
+}
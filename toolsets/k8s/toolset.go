@@ -1,10 +1,16 @@
 package k8s
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+
+	"rootcause/internal/kube"
 	"rootcause/internal/mcp"
 )
 
@@ -38,6 +44,44 @@ func (t *Toolset) Init(ctx mcp.ToolContext) error {
 	return nil
 }
 
+// Preflight confirms the API server answers and discovery works. Groups
+// that fail discovery, such as a down metrics-server, are reported rather
+// than failing the check.
+func (t *Toolset) Preflight(ctx context.Context) (string, error) {
+	info, err := serverVersion(ctx, t.ctx.Clients.Discovery)
+	if err != nil {
+		return "", fmt.Errorf("api server unreachable: %w", err)
+	}
+	groups, warnings, err := kube.ServerGroups(t.ctx.Clients.Discovery)
+	if err != nil {
+		return "", fmt.Errorf("discovery failed: %w", err)
+	}
+	detail := fmt.Sprintf("Kubernetes %s, %d API groups", info.GitVersion, len(groups.Groups))
+	if len(warnings) > 0 {
+		detail += " (" + strings.Join(warnings, "; ") + ")"
+	}
+	return detail, nil
+}
+
+// serverVersion reads /version with ctx, so the preflight timeout applies;
+// DiscoveryInterface.ServerVersion takes no context. Fake clients have no
+// REST client and fall back to ServerVersion.
+func serverVersion(ctx context.Context, client discovery.DiscoveryInterface) (*version.Info, error) {
+	restClient := client.RESTClient()
+	if restClient == nil {
+		return client.ServerVersion()
+	}
+	body, err := restClient.Get().AbsPath("/version").Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+	var info version.Info
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("decode server version: %w", err)
+	}
+	return &info, nil
+}
+
 func (t *Toolset) Register(reg mcp.Registry) error {
 	tools := []mcp.ToolSpec{
 		{
@@ -278,6 +322,14 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			Handler:     t.handleRollout,
 			Preflight:   &mcp.PreflightSpec{GuardTool: "k8s.safe_mutation_preflight", Operation: "rollout"},
 		},
+		{
+			Name:        "k8s.rollout_status",
+			Description: "Report whether a Deployment, StatefulSet, or DaemonSet rollout is complete, progressing, or stalled.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaRolloutStatus(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleRolloutStatus,
+		},
 		{
 			Name:        "k8s.restart_safety_check",
 			Description: "Preflight safety checks before restarting a deployment.",
@@ -286,6 +338,14 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     t.handleRestartSafetyCheck,
 		},
+		{
+			Name:        "k8s.pdb_blocking",
+			Description: "List PodDisruptionBudgets that block voluntary disruptions (drains, consolidation) and the workloads they protect.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaPDBBlocking(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handlePDBBlocking,
+		},
 		{
 			Name:        "k8s.best_practice",
 			Description: "Evaluate workload best practices for resilience and security.",
@@ -376,6 +436,14 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     t.handleOverview,
 		},
+		{
+			Name:        "k8s.node_pressure",
+			Description: "Report node pressure conditions, cordons, scheduling taints, and allocatable-vs-requested headroom.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaNodePressure(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleNodePressure,
+		},
 		{
 			Name:        "k8s.crashloop_debug",
 			Description: "Analyze CrashLoopBackOff/ImagePullBackOff pods with image pull evidence.",
@@ -384,6 +452,22 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     t.handleCrashloopDebug,
 		},
+		{
+			Name:        "k8s.diagnose_image_pull",
+			Description: "Explain ImagePullBackOff/ErrImagePull containers: registry, pull secrets, and auth vs not-found vs rate-limit.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaDiagnoseImagePull(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleDiagnoseImagePull,
+		},
+		{
+			Name:        "k8s.pod_restart_analysis",
+			Description: "Rank restarting containers and classify OOMKilled, liveness-probe kills, and CrashLoopBackOff.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaPodRestartAnalysis(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handlePodRestartAnalysis,
+		},
 		{
 			Name:        "k8s.scheduling_debug",
 			Description: "Analyze Pending pods, quotas, priorities, and scheduling blockers.",
@@ -392,6 +476,22 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     t.handleSchedulingDebug,
 		},
+		{
+			Name:        "k8s.explain_unschedulable",
+			Description: "Explain why pending pods are unschedulable with categorized, per-pod reasons and suggestions.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaExplainUnschedulable(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleExplainUnschedulable,
+		},
+		{
+			Name:        "k8s.quota_status",
+			Description: "Report ResourceQuota usage by utilization and LimitRange settings that would reject pods at admission.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaQuotaStatus(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleQuotaStatus,
+		},
 		{
 			Name:        "k8s.hpa_debug",
 			Description: "Analyze HPA conditions and replica decisions.",
@@ -408,6 +508,14 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     t.handleVPADebug,
 		},
+		{
+			Name:        "k8s.storage_status",
+			Description: "List PVCs with their bound PVs, capacity and StorageClass, explaining claims stuck Pending.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaStorageStatus(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleStorageStatus,
+		},
 		{
 			Name:        "k8s.storage_debug",
 			Description: "Analyze PVC binding, PV matching, and VolumeAttachment errors.",
@@ -424,6 +532,14 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     t.handleConfigDebug,
 		},
+		{
+			Name:        "k8s.find_config_consumers",
+			Description: "Find workloads that consume a ConfigMap or Secret and which need a restart after it changes.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaFindConfigConsumers(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleFindConfigConsumers,
+		},
 		{
 			Name:        "k8s.permission_debug",
 			Description: "Analyze ServiceAccount RBAC and IRSA IAM role bindings.",
@@ -448,6 +564,22 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     t.handleNetworkDebug,
 		},
+		{
+			Name:        "k8s.find_orphaned_services",
+			Description: "Find Services whose selector matches no pods, whose pods are all unready, or whose endpoints are empty.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaFindOrphanedServices(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleFindOrphanedServices,
+		},
+		{
+			Name:        "k8s.evaluate_network_policy",
+			Description: "Simulate whether NetworkPolicies allow a flow from a source pod or labels to a destination pod and port.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaEvaluateNetworkPolicy(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleEvaluateNetworkPolicy,
+		},
 		{
 			Name:        "k8s.private_link_debug",
 			Description: "Analyze private link connectivity for a service.",
@@ -0,0 +1,70 @@
+package k8s
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+)
+
+// partialGroupsDiscovery reports one aggregated API group as unavailable,
+// the way a down metrics-server shows up in discovery.
+type partialGroupsDiscovery struct {
+	discovery.CachedDiscoveryInterface
+}
+
+func (d partialGroupsDiscovery) ServerGroups() (*metav1.APIGroupList, error) {
+	groups := &metav1.APIGroupList{Groups: []metav1.APIGroup{{Name: "apps"}}}
+	return groups, &discovery.ErrGroupDiscoveryFailed{Groups: map[schema.GroupVersion]error{
+		{Group: "metrics.k8s.io", Version: "v1beta1"}: context.DeadlineExceeded,
+	}}
+}
+
+func TestPreflight(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/version":
+			_, _ = w.Write([]byte(`{"major":"1","minor":"30","gitVersion":"v1.30.2"}`))
+		case "/api":
+			_, _ = w.Write([]byte(`{"kind":"APIVersions","versions":["v1"]}`))
+		case "/apis":
+			_, _ = w.Write([]byte(`{"kind":"APIGroupList","groups":[{"name":"apps","versions":[{"groupVersion":"apps/v1","version":"v1"}]}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := memory.NewMemCacheClient(discovery.NewDiscoveryClientForConfigOrDie(&rest.Config{Host: server.URL}))
+	toolset := New()
+	if err := toolset.Init(mcp.ToolContext{Clients: &kube.Clients{Discovery: client}}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	detail, err := toolset.Preflight(context.Background())
+	if err != nil || detail != "Kubernetes v1.30.2, 2 API groups" {
+		t.Fatalf("unexpected preflight %q (%v)", detail, err)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := toolset.Preflight(canceled); err == nil {
+		t.Fatalf("expected a canceled context to stop the version check")
+	}
+
+	toolset.ctx.Clients.Discovery = partialGroupsDiscovery{client}
+	detail, err = toolset.Preflight(context.Background())
+	if err != nil || !strings.Contains(detail, "1 API groups") || !strings.Contains(detail, "metrics.k8s.io/v1beta1") {
+		t.Fatalf("expected partial discovery to be reported, got %q (%v)", detail, err)
+	}
+}
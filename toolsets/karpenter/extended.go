@@ -11,8 +11,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/discovery"
 
+	"rootcause/internal/kube"
 	"rootcause/internal/mcp"
 	"rootcause/internal/policy"
 	"rootcause/internal/render"
@@ -280,8 +280,8 @@ func (t *Toolset) handleInterruptionDebug(ctx context.Context, req mcp.ToolReque
 }
 
 func (t *Toolset) findResourcesByKind(kindMatch func(string) bool, groupMatch func(string) bool) ([]resourceMatch, error) {
-	lists, err := t.ctx.Clients.Discovery.ServerPreferredResources()
-	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+	lists, _, err := kube.PreferredResources(t.ctx.Clients.Discovery)
+	if err != nil {
 		return nil, err
 	}
 	var resources []resourceMatch
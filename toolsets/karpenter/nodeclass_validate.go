@@ -0,0 +1,270 @@
+package karpenter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+// validAMIFamilies lists spec.amiFamily values accepted by the AWS provider
+// across v1beta1 and v1; aliasFamilies are the lower-case prefixes allowed in
+// an amiSelectorTerms alias such as "al2023@latest".
+var (
+	validAMIFamilies = []string{"AL2", "AL2023", "Bottlerocket", "Custom", "Ubuntu", "Windows2019", "Windows2022"}
+	aliasFamilies    = []string{"al2", "al2023", "bottlerocket", "windows2019", "windows2022"}
+)
+
+type danglingReference struct {
+	Field     string `json:"field"`
+	Reference string `json:"reference"`
+	Problem   string `json:"problem"`
+}
+
+type nodeClassValidator struct {
+	t        *Toolset
+	user     policy.User
+	region   string
+	analysis *render.Analysis
+}
+
+func (t *Toolset) handleValidateNodeClass(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	analysis := render.NewAnalysis()
+	name := toString(req.Arguments["name"])
+	matches, err := t.findResourcesByKind(func(kind string) bool {
+		return kind == "EC2NodeClass"
+	}, func(group string) bool {
+		return strings.Contains(group, "karpenter.k8s.aws")
+	})
+	if err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	if len(matches) == 0 {
+		analysis.AddEvidence("status", "no EC2NodeClass resources found")
+		analysis.AddNextCheck("Install the Karpenter AWS provider CRDs (karpenter.k8s.aws)")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
+	}
+	validator := nodeClassValidator{
+		t:        t,
+		user:     req.User,
+		region:   toString(req.Arguments["region"]),
+		analysis: &analysis,
+	}
+	if t.ctx.Registry == nil {
+		analysis.AddEvidence("awsToolset", "tool registry unavailable; only AMI family settings were checked")
+	}
+	checked := 0
+	for _, match := range matches {
+		objects, _, err := t.listResourceObjects(ctx, req.User, match, "", name, "")
+		if err != nil {
+			return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+		}
+		for i := range objects {
+			obj := &objects[i]
+			checked++
+			analysis.AddResource(t.ctx.Evidence.ResourceRef(match.GVR, "", obj.GetName()))
+			dangling := validator.validate(ctx, obj)
+			analysis.AddEvidence(fmt.Sprintf("EC2NodeClass %s", obj.GetName()), map[string]any{
+				"selectors": extractAWSNodeClassSelectors(obj).summary(),
+				"amiFamily": nestedString(obj, "spec", "amiFamily"),
+				"dangling":  dangling,
+			})
+			if len(dangling) == 0 {
+				continue
+			}
+			problems := make([]string, 0, len(dangling))
+			for _, ref := range dangling {
+				problems = append(problems, fmt.Sprintf("%s %s: %s", ref.Field, ref.Reference, ref.Problem))
+			}
+			analysis.AddCause("EC2NodeClass has dangling references",
+				fmt.Sprintf("EC2NodeClass %s: %s", obj.GetName(), strings.Join(problems, "; ")), "high")
+		}
+	}
+	if checked == 0 {
+		analysis.AddEvidence("status", "no EC2NodeClass objects matched")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
+	}
+	if len(analysis.LikelyRootCauses) > 0 {
+		analysis.AddNextCheck("Fix or remove the dangling selectors; Karpenter cannot launch nodes from a NodeClass whose subnets, security groups, AMIs or instance profile do not resolve")
+		analysis.AddNextCheck("Check the EC2NodeClass status conditions and Karpenter controller logs for the resolution errors")
+	}
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
+}
+
+func (v nodeClassValidator) validate(ctx context.Context, obj *unstructured.Unstructured) []danglingReference {
+	dangling := checkAMIFamily(obj)
+	amiIDs, amiTagTerms := extractSelectorInfo(obj, "amiSelectorTerms", "amiSelector", []string{"id"})
+	selectors := extractAWSNodeClassSelectors(obj)
+
+	dangling = append(dangling, v.checkIDs(ctx, "aws.vpc.list_subnets", "subnetIds", "subnet", selectors.subnetIDs)...)
+	dangling = append(dangling, v.checkTagTerms(ctx, "aws.vpc.list_subnets", "subnet", selectors.subnetTagTerms)...)
+	dangling = append(dangling, v.checkIDs(ctx, "aws.vpc.list_security_groups", "groupIds", "securityGroup", selectors.securityGroupIDs)...)
+	dangling = append(dangling, v.checkTagTerms(ctx, "aws.vpc.list_security_groups", "securityGroup", selectors.securityGroupTerms)...)
+	dangling = append(dangling, v.checkIDs(ctx, "aws.ec2.list_images", "imageIds", "ami", amiIDs)...)
+	if len(amiTagTerms) > 0 {
+		v.analysis.AddEvidence(fmt.Sprintf("AMI tag selectors %s", obj.GetName()), "not validated; AMI tag selectors may match images shared from other accounts")
+	}
+	if selectors.instanceProfile != "" {
+		dangling = append(dangling, v.checkNamed(ctx, "aws.iam.get_instance_profile", "instanceProfileName", "instanceProfile", selectors.instanceProfile)...)
+	}
+	if selectors.roleName != "" {
+		dangling = append(dangling, v.checkNamed(ctx, "aws.iam.get_role", "roleName", "role", selectors.roleName)...)
+	}
+	if selectors.instanceProfile == "" && selectors.roleName == "" {
+		dangling = append(dangling, danglingReference{Field: "role", Problem: "neither spec.role nor spec.instanceProfile is set"})
+	}
+	return dangling
+}
+
+// checkAMIFamily validates spec.amiFamily and any amiSelectorTerms alias
+// without calling AWS.
+func checkAMIFamily(obj *unstructured.Unstructured) []danglingReference {
+	var dangling []danglingReference
+	family := nestedString(obj, "spec", "amiFamily")
+	if family != "" && !containsFold(validAMIFamilies, family) {
+		dangling = append(dangling, danglingReference{Field: "amiFamily", Reference: family, Problem: fmt.Sprintf("unknown AMI family (expected one of %s)", strings.Join(validAMIFamilies, ", "))})
+	}
+	terms := selectorTerms(obj, "amiSelectorTerms")
+	for _, term := range terms {
+		alias := strings.TrimSpace(fmt.Sprintf("%v", term["alias"]))
+		if term["alias"] == nil || alias == "" {
+			continue
+		}
+		aliasFamily, version, ok := strings.Cut(alias, "@")
+		if !ok || version == "" {
+			dangling = append(dangling, danglingReference{Field: "amiSelectorTerms.alias", Reference: alias, Problem: "alias must be family@version, e.g. al2023@latest"})
+			continue
+		}
+		if !containsFold(aliasFamilies, aliasFamily) {
+			dangling = append(dangling, danglingReference{Field: "amiSelectorTerms.alias", Reference: alias, Problem: fmt.Sprintf("unknown alias family (expected one of %s)", strings.Join(aliasFamilies, ", "))})
+		}
+	}
+	if strings.EqualFold(family, "Custom") && len(terms) == 0 {
+		dangling = append(dangling, danglingReference{Field: "amiFamily", Reference: family, Problem: "Custom AMI family requires amiSelectorTerms"})
+	}
+	return dangling
+}
+
+// checkIDs looks up all ids in one call and only falls back to per-id calls
+// when something is missing, so the common healthy case costs one request.
+func (v nodeClassValidator) checkIDs(ctx context.Context, tool, argKey, field string, ids []string) []danglingReference {
+	if len(ids) == 0 || !v.toolAvailable(tool, field) {
+		return nil
+	}
+	count, err := v.lookupCount(ctx, tool, map[string]any{argKey: ids})
+	if err == nil && count >= len(ids) {
+		return nil
+	}
+	var dangling []danglingReference
+	for _, id := range ids {
+		count, err := v.lookupCount(ctx, tool, map[string]any{argKey: []string{id}})
+		switch {
+		case err != nil && isAWSNotFound(err):
+			dangling = append(dangling, danglingReference{Field: field, Reference: id, Problem: "not found"})
+		case err != nil:
+			v.lookupFailed(field, err)
+			return dangling
+		case count == 0:
+			dangling = append(dangling, danglingReference{Field: field, Reference: id, Problem: "not found"})
+		}
+	}
+	return dangling
+}
+
+func (v nodeClassValidator) checkTagTerms(ctx context.Context, tool, field string, terms []map[string]string) []danglingReference {
+	if len(terms) == 0 || !v.toolAvailable(tool, field) {
+		return nil
+	}
+	var dangling []danglingReference
+	for _, term := range terms {
+		count, err := v.lookupCount(ctx, tool, map[string]any{"tagFilters": term})
+		if err != nil {
+			v.lookupFailed(field, err)
+			return dangling
+		}
+		if count == 0 {
+			dangling = append(dangling, danglingReference{Field: field, Reference: formatTagTerm(term), Problem: "tag selector matches nothing"})
+		}
+	}
+	return dangling
+}
+
+func (v nodeClassValidator) checkNamed(ctx context.Context, tool, argKey, field, name string) []danglingReference {
+	if !v.toolAvailable(tool, field) {
+		return nil
+	}
+	_, err := v.t.ctx.CallTool(ctx, v.user, tool, v.args(map[string]any{argKey: name}))
+	if err == nil {
+		return nil
+	}
+	if isAWSNotFound(err) {
+		return []danglingReference{{Field: field, Reference: name, Problem: "not found"}}
+	}
+	v.lookupFailed(field, err)
+	return nil
+}
+
+func (v nodeClassValidator) toolAvailable(tool, field string) bool {
+	if v.t.ctx.Registry == nil {
+		return false
+	}
+	if _, ok := v.t.ctx.Registry.Get(tool); !ok {
+		v.analysis.AddEvidence(fmt.Sprintf("%sValidation", field), fmt.Sprintf("%s not available; aws toolset not enabled", tool))
+		return false
+	}
+	return true
+}
+
+func (v nodeClassValidator) lookupCount(ctx context.Context, tool string, args map[string]any) (int, error) {
+	result, err := v.t.ctx.CallTool(ctx, v.user, tool, v.args(args))
+	if err != nil {
+		return 0, err
+	}
+	data, _ := result.Data.(map[string]any)
+	count, _ := data["count"].(int)
+	return count, nil
+}
+
+func (v nodeClassValidator) args(args map[string]any) map[string]any {
+	if v.region != "" {
+		args["region"] = v.region
+	}
+	return args
+}
+
+func (v nodeClassValidator) lookupFailed(field string, err error) {
+	v.analysis.AddCause(fmt.Sprintf("AWS %s lookup failed", field), err.Error(), "medium")
+}
+
+func isAWSNotFound(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "NotFound") || strings.Contains(msg, "NoSuchEntity") || strings.Contains(msg, "does not exist")
+}
+
+func formatTagTerm(term map[string]string) string {
+	keys := make([]string, 0, len(term))
+	for key := range term {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+term[key])
+	}
+	return strings.Join(parts, ",")
+}
+
+func containsFold(items []string, value string) bool {
+	for _, item := range items {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package karpenter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"rootcause/internal/config"
+	"rootcause/internal/evidence"
+	"rootcause/internal/kube"
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/redact"
+	"rootcause/internal/render"
+)
+
+func TestCheckAMIFamily(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"amiFamily": "Custom",
+		},
+	}}
+	got := checkAMIFamily(obj)
+	if len(got) != 1 || !strings.Contains(got[0].Problem, "requires amiSelectorTerms") {
+		t.Fatalf("expected Custom without selector terms to be flagged: %#v", got)
+	}
+	obj.Object["spec"] = map[string]any{
+		"amiFamily": "AL3",
+		"amiSelectorTerms": []any{
+			map[string]any{"alias": "al2023@latest"},
+			map[string]any{"alias": "amazonlinux@v1"},
+			map[string]any{"alias": "bottlerocket"},
+		},
+	}
+	got = checkAMIFamily(obj)
+	if len(got) != 3 || got[0].Field != "amiFamily" || got[1].Reference != "amazonlinux@v1" || got[2].Reference != "bottlerocket" {
+		t.Fatalf("unexpected AMI family findings: %#v", got)
+	}
+	obj.Object["spec"] = map[string]any{"amiFamily": "bottlerocket"}
+	if got := checkAMIFamily(obj); len(got) != 0 {
+		t.Fatalf("expected valid family to pass: %#v", got)
+	}
+}
+
+func TestValidateNodeClassReportsDanglingReferences(t *testing.T) {
+	nodeClass := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "karpenter.k8s.aws/v1",
+		"kind":       "EC2NodeClass",
+		"metadata":   map[string]any{"name": "default"},
+		"spec": map[string]any{
+			"instanceProfile": "KarpenterProfile",
+			"amiSelectorTerms": []any{
+				map[string]any{"id": "ami-1"},
+			},
+			"subnetSelectorTerms": []any{
+				map[string]any{"id": "subnet-ok"},
+				map[string]any{"id": "subnet-gone"},
+			},
+			"securityGroupSelectorTerms": []any{
+				map[string]any{"tags": map[string]any{"karpenter.sh/discovery": "other"}},
+			},
+		},
+	}}
+	gvr := schema.GroupVersionResource{Group: "karpenter.k8s.aws", Version: "v1", Resource: "ec2nodeclasses"}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		gvr: "EC2NodeClassList",
+	}, nodeClass)
+	discoveryClient := &fakeCachedDiscovery{resources: []*metav1.APIResourceList{{
+		GroupVersion: "karpenter.k8s.aws/v1",
+		APIResources: []metav1.APIResource{{Name: "ec2nodeclasses", Kind: "EC2NodeClass"}},
+	}}}
+
+	cfg := config.DefaultConfig()
+	reg := mcp.NewRegistry(&cfg)
+	var regions []string
+	addStub := func(name string, handler func(map[string]any) (mcp.ToolResult, error)) {
+		_ = reg.Add(mcp.ToolSpec{
+			Name:      name,
+			ToolsetID: "aws",
+			Safety:    mcp.SafetyReadOnly,
+			Handler: func(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+				regions = append(regions, toString(req.Arguments["region"]))
+				return handler(req.Arguments)
+			},
+		})
+	}
+	addStub("aws.vpc.list_subnets", func(args map[string]any) (mcp.ToolResult, error) {
+		ids, _ := args["subnetIds"].([]string)
+		if len(ids) == 1 && ids[0] == "subnet-gone" {
+			return mcp.ToolResult{}, errors.New("api error InvalidSubnetID.NotFound: The subnet ID 'subnet-gone' does not exist")
+		}
+		if len(ids) > 1 {
+			return mcp.ToolResult{}, errors.New("api error InvalidSubnetID.NotFound")
+		}
+		return mcp.ToolResult{Data: map[string]any{"count": 1}}, nil
+	})
+	addStub("aws.vpc.list_security_groups", func(map[string]any) (mcp.ToolResult, error) {
+		return mcp.ToolResult{Data: map[string]any{"count": 0}}, nil
+	})
+	addStub("aws.ec2.list_images", func(map[string]any) (mcp.ToolResult, error) {
+		return mcp.ToolResult{Data: map[string]any{"count": 1}}, nil
+	})
+	addStub("aws.iam.get_instance_profile", func(map[string]any) (mcp.ToolResult, error) {
+		return mcp.ToolResult{}, errors.New("api error NoSuchEntity: Instance Profile KarpenterProfile cannot be found")
+	})
+
+	clients := &kube.Clients{Dynamic: dynamicClient, Discovery: discoveryClient, Typed: kubefake.NewSimpleClientset()}
+	toolCtx := mcp.ToolContext{
+		Config:   &cfg,
+		Clients:  clients,
+		Policy:   policy.NewAuthorizer(),
+		Evidence: evidence.NewCollector(clients),
+		Renderer: render.NewRenderer(),
+		Redactor: redact.New(),
+		Registry: reg,
+	}
+	toolCtx.Invoker = mcp.NewToolInvoker(reg, toolCtx)
+	toolset := New()
+	if err := toolset.Init(toolCtx); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+	result, err := toolset.handleValidateNodeClass(context.Background(), mcp.ToolRequest{
+		Arguments: map[string]any{"region": "us-west-2"},
+		User:      policy.User{Role: policy.RoleCluster},
+	})
+	if err != nil {
+		t.Fatalf("validate nodeclass: %v", err)
+	}
+	causes := result.Data.(map[string]any)["likelyRootCauses"].([]render.Cause)
+	if len(causes) != 1 {
+		t.Fatalf("expected one dangling reference cause, got %#v", causes)
+	}
+	for _, want := range []string{"subnet subnet-gone: not found", "karpenter.sh/discovery=other: tag selector matches nothing", "instanceProfile KarpenterProfile: not found"} {
+		if !strings.Contains(causes[0].Details, want) {
+			t.Fatalf("expected %q in %q", want, causes[0].Details)
+		}
+	}
+	if strings.Contains(causes[0].Details, "subnet-ok") || strings.Contains(causes[0].Details, "ami-1") {
+		t.Fatalf("resolved references should not be reported: %q", causes[0].Details)
+	}
+	for _, region := range regions {
+		if region != "us-west-2" {
+			t.Fatalf("expected region to be forwarded, got %q", region)
+		}
+	}
+}
@@ -0,0 +1,424 @@
+package karpenter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/render"
+)
+
+// Label prefixes Karpenter and the cloud provider set on every node they
+// launch, so a pod may select them even when a NodePool does not constrain
+// them. Any other label must be defined by the NodePool.
+var wellKnownNodeLabels = []string{
+	"kubernetes.io/arch",
+	"kubernetes.io/os",
+	"kubernetes.io/hostname",
+	"node.kubernetes.io/instance-type",
+	"topology.kubernetes.io/",
+	"karpenter.sh/",
+	"karpenter.k8s.aws/",
+}
+
+// nodePoolFit is the outcome of checking one pending pod against one
+// NodePool (or v1alpha5 Provisioner).
+type nodePoolFit struct {
+	Name         string   `json:"name"`
+	Kind         string   `json:"kind"`
+	Weight       int64    `json:"weight,omitempty"`
+	Compatible   bool     `json:"compatible"`
+	Reasons      []string `json:"reasons,omitempty"`
+	Architecture []string `json:"architecture,omitempty"`
+}
+
+// poolRequirement is what a NodePool allows for one label key: an operator
+// and its values, in NodeSelectorRequirement terms.
+type poolRequirement struct {
+	operator corev1.NodeSelectorOperator
+	values   []string
+}
+
+func (t *Toolset) handleExplainPending(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	namespace := toString(req.Arguments["namespace"])
+	name := toString(req.Arguments["pod"])
+	if namespace == "" || name == "" {
+		err := errors.New("namespace and pod are required")
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	if err := t.ctx.Policy.CheckNamespace(req.User, namespace, true); err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	analysis := render.NewAnalysis()
+	pod, err := t.ctx.Clients.Typed.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			analysis.AddEvidence("status", "pod not found")
+			analysis.AddNextCheck("Verify the pod name and namespace")
+			return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
+		}
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	analysis.AddResource(fmt.Sprintf("pods/%s/%s", namespace, name))
+	reason, message := pendingReason(pod)
+	analysis.AddEvidence("pod", map[string]any{
+		"phase":         pod.Status.Phase,
+		"reason":        reason,
+		"message":       message,
+		"nodeSelector":  pod.Spec.NodeSelector,
+		"requests":      podRequests(pod),
+		"tolerations":   pod.Spec.Tolerations,
+		"nodeAffinity":  requiredNodeAffinity(pod) != nil,
+		"schedulerName": pod.Spec.SchedulerName,
+	})
+	if pod.Status.Phase != corev1.PodPending || pod.Spec.NodeName != "" {
+		analysis.AddEvidence("status", "pod is not waiting for a node")
+		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+	}
+
+	matches, err := t.findResourcesByKind(func(kind string) bool {
+		return strings.EqualFold(kind, "NodePool") || strings.EqualFold(kind, "Provisioner")
+	}, func(group string) bool {
+		return group == "karpenter.sh"
+	})
+	if err != nil {
+		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+	}
+	var fits []nodePoolFit
+	for _, match := range matches {
+		objects, _, err := t.listResourceObjects(ctx, req.User, match, "", "", "")
+		if err != nil {
+			return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
+		}
+		for i := range objects {
+			analysis.AddResource(t.ctx.Evidence.ResourceRef(match.GVR, objects[i].GetNamespace(), objects[i].GetName()))
+			fits = append(fits, evaluateNodePool(pod, match.Kind, &objects[i]))
+		}
+	}
+	sort.SliceStable(fits, func(i, j int) bool {
+		if fits[i].Weight != fits[j].Weight {
+			return fits[i].Weight > fits[j].Weight
+		}
+		return fits[i].Name < fits[j].Name
+	})
+	analysis.AddEvidence("nodePools", fits)
+
+	var compatible []string
+	var rejections []string
+	for _, fit := range fits {
+		if fit.Compatible {
+			compatible = append(compatible, fit.Name)
+			continue
+		}
+		rejections = append(rejections, fmt.Sprintf("%s: %s", fit.Name, strings.Join(fit.Reasons, "; ")))
+	}
+	switch {
+	case len(fits) == 0:
+		analysis.AddCause("No NodePools defined", "Karpenter has no NodePool or Provisioner to launch a node from", "high")
+		analysis.AddNextCheck("Create a NodePool whose requirements cover the pod")
+	case len(compatible) == 0:
+		analysis.AddCause("No NodePool can provision the pod", strings.Join(rejections, " | "), "high")
+		analysis.AddNextCheck("Relax the pod's nodeSelector/affinity/tolerations or add a NodePool that matches them")
+	default:
+		analysis.AddCause("Compatible NodePool found but pod still pending", fmt.Sprintf("%s can provision the pod; the blocker is likely instance type availability, NodeClass errors or cloud capacity", strings.Join(compatible, ", ")), "medium")
+		analysis.AddNextCheck("Check Karpenter controller logs and NodeClaim events for launch errors")
+		analysis.AddNextCheck("Run karpenter.nodeclass_debug for the compatible NodePool's NodeClass")
+	}
+	return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis), Metadata: mcp.ToolMetadata{Namespaces: []string{namespace}}}, nil
+}
+
+// evaluateNodePool checks a pod against one pool the way Karpenter's
+// scheduler does: the pool must be ready, its requirements and template
+// labels must satisfy the pod's nodeSelector and at least one required node
+// affinity term, the pod must tolerate its taints, and its limits must leave
+// room for the pod's requests.
+func evaluateNodePool(pod *corev1.Pod, kind string, pool *unstructured.Unstructured) nodePoolFit {
+	fit := nodePoolFit{Name: pool.GetName(), Kind: kind, Weight: nestedInt(pool, "spec", "weight")}
+	for _, cond := range extractConditions(pool) {
+		if isConditionFalse(cond, []string{"Ready"}) {
+			fit.Reasons = append(fit.Reasons, fmt.Sprintf("pool is not Ready: %v", cond["message"]))
+		}
+	}
+	requirements := nodePoolRequirements(pool)
+	if arch, ok := requirements[corev1.LabelArchStable]; ok && arch.operator == corev1.NodeSelectorOpIn {
+		fit.Architecture = arch.values
+	}
+
+	keys := make([]string, 0, len(pod.Spec.NodeSelector))
+	for key := range pod.Spec.NodeSelector {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		expr := corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpIn, Values: []string{pod.Spec.NodeSelector[key]}}
+		if reason := requirementConflict(expr, requirements); reason != "" {
+			fit.Reasons = append(fit.Reasons, "nodeSelector "+reason)
+		}
+	}
+	if affinity := requiredNodeAffinity(pod); affinity != nil && len(affinity.NodeSelectorTerms) > 0 {
+		var termReasons []string
+		for _, term := range affinity.NodeSelectorTerms {
+			var conflicts []string
+			for _, expr := range term.MatchExpressions {
+				if reason := requirementConflict(expr, requirements); reason != "" {
+					conflicts = append(conflicts, reason)
+				}
+			}
+			if len(conflicts) == 0 {
+				termReasons = nil
+				break
+			}
+			termReasons = append(termReasons, strings.Join(conflicts, ", "))
+		}
+		if len(termReasons) > 0 {
+			fit.Reasons = append(fit.Reasons, "no required node affinity term matches: "+strings.Join(termReasons, " / "))
+		}
+	}
+	for _, taint := range nodePoolTaints(pool) {
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		if !toleratesTaint(pod.Spec.Tolerations, taint) {
+			fit.Reasons = append(fit.Reasons, fmt.Sprintf("pod does not tolerate taint %s", taintString(taint)))
+		}
+	}
+	fit.Reasons = append(fit.Reasons, limitConflicts(pool, podRequests(pod))...)
+	fit.Compatible = len(fit.Reasons) == 0
+	return fit
+}
+
+// nodePoolRequirements merges a pool's requirements with its template
+// labels; a label is an In requirement with a single value.
+func nodePoolRequirements(pool *unstructured.Unstructured) map[string]poolRequirement {
+	out := map[string]poolRequirement{}
+	for _, req := range extractRequirements(pool) {
+		key := toString(req["key"])
+		if key == "" {
+			continue
+		}
+		var values []string
+		if items, ok := req["values"].([]any); ok {
+			for _, item := range items {
+				values = append(values, toString(item))
+			}
+		}
+		out[key] = poolRequirement{operator: corev1.NodeSelectorOperator(toString(req["operator"])), values: values}
+	}
+	for _, path := range [][]string{{"spec", "template", "metadata", "labels"}, {"spec", "labels"}} {
+		labels, found, _ := unstructured.NestedStringMap(pool.Object, path...)
+		if !found {
+			continue
+		}
+		for key, value := range labels {
+			out[key] = poolRequirement{operator: corev1.NodeSelectorOpIn, values: []string{value}}
+		}
+		break
+	}
+	return out
+}
+
+func nodePoolTaints(pool *unstructured.Unstructured) []corev1.Taint {
+	var out []corev1.Taint
+	for _, taint := range extractTaints(pool) {
+		out = append(out, corev1.Taint{
+			Key:    toString(taint["key"]),
+			Value:  toString(taint["value"]),
+			Effect: corev1.TaintEffect(toString(taint["effect"])),
+		})
+	}
+	return out
+}
+
+// requirementConflict explains why no node from the pool can satisfy expr,
+// or returns "" when some node could.
+func requirementConflict(expr corev1.NodeSelectorRequirement, requirements map[string]poolRequirement) string {
+	pool, constrained := requirements[expr.Key]
+	if !constrained {
+		switch expr.Operator {
+		case corev1.NodeSelectorOpNotIn, corev1.NodeSelectorOpDoesNotExist:
+			return ""
+		}
+		if isWellKnownNodeLabel(expr.Key) {
+			return ""
+		}
+		return fmt.Sprintf("%s is not defined by the pool's requirements or labels, so its nodes never carry it", expr.Key)
+	}
+	if pool.operator == corev1.NodeSelectorOpDoesNotExist {
+		if expr.Operator == corev1.NodeSelectorOpNotIn || expr.Operator == corev1.NodeSelectorOpDoesNotExist {
+			return ""
+		}
+		return fmt.Sprintf("%s requires the label but the pool forbids it", expr.Key)
+	}
+	if expr.Operator == corev1.NodeSelectorOpDoesNotExist {
+		return fmt.Sprintf("%s must be absent but the pool always sets it", expr.Key)
+	}
+	// Without an In list the pool allows an open-ended set of values.
+	if pool.operator != corev1.NodeSelectorOpIn {
+		if expr.Operator == corev1.NodeSelectorOpIn && pool.operator == corev1.NodeSelectorOpNotIn && subset(expr.Values, pool.values) {
+			return fmt.Sprintf("%s in %v but the pool excludes %v", expr.Key, expr.Values, pool.values)
+		}
+		return ""
+	}
+	var allowed []string
+	for _, value := range pool.values {
+		if valueSatisfies(expr, value) {
+			allowed = append(allowed, value)
+		}
+	}
+	if len(allowed) > 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s %s %v but the pool only allows %v", expr.Key, strings.ToLower(string(expr.Operator)), expr.Values, pool.values)
+}
+
+func valueSatisfies(expr corev1.NodeSelectorRequirement, value string) bool {
+	switch expr.Operator {
+	case corev1.NodeSelectorOpIn:
+		return slices.Contains(expr.Values, value)
+	case corev1.NodeSelectorOpNotIn:
+		return !slices.Contains(expr.Values, value)
+	case corev1.NodeSelectorOpExists:
+		return true
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		if len(expr.Values) != 1 {
+			return false
+		}
+		got, err1 := strconv.ParseInt(value, 10, 64)
+		want, err2 := strconv.ParseInt(expr.Values[0], 10, 64)
+		if err1 != nil || err2 != nil {
+			return false
+		}
+		if expr.Operator == corev1.NodeSelectorOpGt {
+			return got > want
+		}
+		return got < want
+	}
+	return false
+}
+
+func subset(items, of []string) bool {
+	for _, item := range items {
+		if !slices.Contains(of, item) {
+			return false
+		}
+	}
+	return true
+}
+
+func isWellKnownNodeLabel(key string) bool {
+	for _, known := range wellKnownNodeLabels {
+		if key == known || (strings.HasSuffix(known, "/") && strings.HasPrefix(key, known)) {
+			return true
+		}
+	}
+	return false
+}
+
+// toleratesTaint follows the core scheduler: an empty key with Exists
+// tolerates everything, and an empty effect matches any effect.
+func toleratesTaint(tolerations []corev1.Toleration, taint corev1.Taint) bool {
+	for _, tol := range tolerations {
+		if tol.Effect != "" && tol.Effect != taint.Effect {
+			continue
+		}
+		if tol.Key == "" {
+			if tol.Operator == corev1.TolerationOpExists {
+				return true
+			}
+			continue
+		}
+		if tol.Key != taint.Key {
+			continue
+		}
+		if tol.Operator == corev1.TolerationOpExists || tol.Value == taint.Value {
+			return true
+		}
+	}
+	return false
+}
+
+func taintString(taint corev1.Taint) string {
+	if taint.Value == "" {
+		return fmt.Sprintf("%s:%s", taint.Key, taint.Effect)
+	}
+	return fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect)
+}
+
+func requiredNodeAffinity(pod *corev1.Pod) *corev1.NodeSelector {
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil {
+		return nil
+	}
+	return pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+}
+
+// podRequests is the pod's effective request: the sum over containers, or
+// the largest init container when that is bigger, plus pod overhead.
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	out := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		for name, qty := range container.Resources.Requests {
+			sum := out[name]
+			sum.Add(qty)
+			out[name] = sum
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, qty := range container.Resources.Requests {
+			if current, ok := out[name]; !ok || qty.Cmp(current) > 0 {
+				out[name] = qty.DeepCopy()
+			}
+		}
+	}
+	for name, qty := range pod.Spec.Overhead {
+		sum := out[name]
+		sum.Add(qty)
+		out[name] = sum
+	}
+	return out
+}
+
+// limitConflicts reports pool limits the pod's requests would push past,
+// given what the pool already has provisioned (status.resources).
+func limitConflicts(pool *unstructured.Unstructured, requests corev1.ResourceList) []string {
+	limits := extractLimits(pool)
+	if len(limits) == 0 {
+		return nil
+	}
+	used, _ := nestedMap(pool, "status", "resources")
+	names := mapKeys(limits)
+	var out []string
+	for _, name := range names {
+		limit, err := resource.ParseQuantity(toString(limits[name]))
+		if err != nil {
+			continue
+		}
+		total := resource.Quantity{}
+		if value, ok := used[name]; ok {
+			if q, err := resource.ParseQuantity(toString(value)); err == nil {
+				total = q
+			}
+		}
+		if total.Cmp(limit) >= 0 {
+			out = append(out, fmt.Sprintf("limit %s=%s already reached (%s in use)", name, limit.String(), total.String()))
+			continue
+		}
+		if req, ok := requests[corev1.ResourceName(name)]; ok {
+			total.Add(req)
+			if total.Cmp(limit) > 0 {
+				out = append(out, fmt.Sprintf("requests would exceed limit %s=%s", name, limit.String()))
+			}
+		}
+	}
+	return out
+}
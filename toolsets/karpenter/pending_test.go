@@ -0,0 +1,138 @@
+package karpenter
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"rootcause/internal/mcp"
+	"rootcause/internal/policy"
+	"rootcause/internal/render"
+)
+
+func testNodePool(spec map[string]any, status map[string]any) *unstructured.Unstructured {
+	obj := map[string]any{
+		"apiVersion": "karpenter.sh/v1",
+		"kind":       "NodePool",
+		"metadata":   map[string]any{"name": "general"},
+		"spec":       spec,
+	}
+	if status != nil {
+		obj["status"] = status
+	}
+	return &unstructured.Unstructured{Object: obj}
+}
+
+func TestEvaluateNodePool(t *testing.T) {
+	pool := testNodePool(map[string]any{
+		"template": map[string]any{
+			"metadata": map[string]any{"labels": map[string]any{"team": "web"}},
+			"spec": map[string]any{
+				"requirements": []any{
+					map[string]any{"key": "kubernetes.io/arch", "operator": "In", "values": []any{"amd64"}},
+					map[string]any{"key": "karpenter.sh/capacity-type", "operator": "In", "values": []any{"spot", "on-demand"}},
+				},
+				"taints": []any{map[string]any{"key": "dedicated", "value": "web", "effect": "NoSchedule"}},
+			},
+		},
+		"limits": map[string]any{"cpu": "10"},
+	}, map[string]any{"resources": map[string]any{"cpu": "8"}})
+	tolerant := []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "web", Effect: corev1.TaintEffectNoSchedule}}
+	podWith := func(spec corev1.PodSpec) *corev1.Pod {
+		if spec.Tolerations == nil {
+			spec.Tolerations = tolerant
+		}
+		return &corev1.Pod{Spec: spec}
+	}
+	requests := func(cpu string) []corev1.Container {
+		return []corev1.Container{{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}}}}
+	}
+	affinity := func(terms ...corev1.NodeSelectorTerm) *corev1.Affinity {
+		return &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: terms}}}
+	}
+	term := func(key string, op corev1.NodeSelectorOperator, values ...string) corev1.NodeSelectorTerm {
+		return corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: key, Operator: op, Values: values}}}
+	}
+
+	cases := []struct {
+		name   string
+		pod    *corev1.Pod
+		reason string
+	}{
+		{"fits", podWith(corev1.PodSpec{NodeSelector: map[string]string{"team": "web", "topology.kubernetes.io/zone": "us-east-1a"}, Containers: requests("1")}), ""},
+		{"arch mismatch", podWith(corev1.PodSpec{NodeSelector: map[string]string{"kubernetes.io/arch": "arm64"}}), "only allows [amd64]"},
+		{"undefined label", podWith(corev1.PodSpec{NodeSelector: map[string]string{"gpu": "true"}}), "gpu is not defined"},
+		{"taint not tolerated", podWith(corev1.PodSpec{Tolerations: []corev1.Toleration{}}), "dedicated=web:NoSchedule"},
+		{"exceeds limit", podWith(corev1.PodSpec{Containers: requests("4")}), "exceed limit cpu=10"},
+		{"one affinity term matches", podWith(corev1.PodSpec{Affinity: affinity(
+			term("kubernetes.io/arch", corev1.NodeSelectorOpIn, "arm64"),
+			term("karpenter.sh/capacity-type", corev1.NodeSelectorOpNotIn, "spot"),
+		)}), ""},
+		{"no affinity term matches", podWith(corev1.PodSpec{Affinity: affinity(
+			term("karpenter.sh/capacity-type", corev1.NodeSelectorOpIn, "reserved"),
+		)}), "no required node affinity term"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fit := evaluateNodePool(tc.pod, "NodePool", pool)
+			if tc.reason == "" {
+				if !fit.Compatible {
+					t.Fatalf("expected compatible, got %v", fit.Reasons)
+				}
+				return
+			}
+			if fit.Compatible || !strings.Contains(strings.Join(fit.Reasons, "; "), tc.reason) {
+				t.Fatalf("expected reason containing %q, got %+v", tc.reason, fit)
+			}
+		})
+	}
+	if fit := evaluateNodePool(podWith(corev1.PodSpec{}), "NodePool", pool); len(fit.Architecture) != 1 || fit.Architecture[0] != "amd64" {
+		t.Fatalf("expected architecture to be reported, got %+v", fit)
+	}
+	full := testNodePool(map[string]any{"limits": map[string]any{"cpu": "10"}}, map[string]any{"resources": map[string]any{"cpu": "10"}})
+	if fit := evaluateNodePool(&corev1.Pod{}, "NodePool", full); fit.Compatible || !strings.Contains(fit.Reasons[0], "already reached") {
+		t.Fatalf("expected limit reached, got %+v", fit)
+	}
+}
+
+func TestHandleExplainPending(t *testing.T) {
+	toolset := newKarpenterToolset(t)
+	user := policy.User{Role: policy.RoleCluster}
+	pods := toolset.ctx.Clients.Typed.CoreV1().Pods("default")
+	for name, selector := range map[string]map[string]string{"plain": nil, "gpu": {"gpu": "true"}} {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeSelector: selector},
+			Status:     corev1.PodStatus{Phase: corev1.PodPending},
+		}
+		if _, err := pods.Create(context.Background(), pod, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("create pod: %v", err)
+		}
+	}
+	cause := func(pod string) render.Cause {
+		t.Helper()
+		result, err := toolset.handleExplainPending(context.Background(), mcp.ToolRequest{User: user, Arguments: map[string]any{"namespace": "default", "pod": pod}})
+		if err != nil {
+			t.Fatalf("explain pending: %v", err)
+		}
+		causes := result.Data.(map[string]any)["likelyRootCauses"].([]render.Cause)
+		if len(causes) != 1 {
+			t.Fatalf("expected one cause, got %#v", causes)
+		}
+		return causes[0]
+	}
+	if got := cause("plain"); got.Summary != "Compatible NodePool found but pod still pending" || !strings.Contains(got.Details, "pool") {
+		t.Fatalf("unexpected cause for plain pod: %#v", got)
+	}
+	if got := cause("gpu"); got.Summary != "No NodePool can provision the pod" || !strings.Contains(got.Details, "gpu is not defined") {
+		t.Fatalf("unexpected cause for gpu pod: %#v", got)
+	}
+	if _, err := toolset.handleExplainPending(context.Background(), mcp.ToolRequest{User: user, Arguments: map[string]any{"namespace": "default"}}); err == nil {
+		t.Fatalf("expected error without pod")
+	}
+}
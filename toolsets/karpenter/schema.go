@@ -18,6 +18,17 @@ func schemaNodeProvisioningDebug() map[string]any {
 	}
 }
 
+func schemaExplainPending() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"namespace": map[string]any{"type": "string"},
+			"pod":       map[string]any{"type": "string"},
+		},
+		"required": []string{"namespace", "pod"},
+	}
+}
+
 func schemaCRStatus() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -29,6 +40,10 @@ func schemaCRStatus() map[string]any {
 			"name":          map[string]any{"type": "string"},
 			"namespace":     map[string]any{"type": "string"},
 			"labelSelector": map[string]any{"type": "string"},
+			"refreshDiscovery": map[string]any{
+				"type":        "boolean",
+				"description": "Invalidate the discovery/RESTMapper cache first so CRDs installed since the last refresh resolve.",
+			},
 		},
 	}
 }
@@ -56,6 +71,16 @@ func schemaNodeClassDebug() map[string]any {
 	}
 }
 
+func schemaValidateNodeClass() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name":   map[string]any{"type": "string"},
+			"region": map[string]any{"type": "string"},
+		},
+	}
+}
+
 func schemaInterruptionDebug() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -71,6 +71,9 @@ func (t *Toolset) handleCRStatus(ctx context.Context, req mcp.ToolRequest) (mcp.
 	name := toString(args["name"])
 	namespace := toString(args["namespace"])
 	selector := toString(args["labelSelector"])
+	if refresh, _ := args["refreshDiscovery"].(bool); refresh {
+		t.ctx.Clients.InvalidateDiscovery()
+	}
 
 	analysis := render.NewAnalysis()
 	detected, _, groups, err := t.detectKarpenter(ctx)
@@ -1,8 +1,10 @@
 package karpenter
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"rootcause/internal/mcp"
 )
@@ -37,6 +39,21 @@ func (t *Toolset) Init(ctx mcp.ToolContext) error {
 	return nil
 }
 
+// Preflight confirms the Karpenter CRDs or controller are present.
+func (t *Toolset) Preflight(ctx context.Context) (string, error) {
+	detected, namespaces, _, err := t.detectKarpenter(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !detected {
+		return "", fmt.Errorf("karpenter not detected (no %s API group or controller)", strings.Join(karpenterGroups, ", "))
+	}
+	if len(namespaces) == 0 {
+		return "karpenter CRDs present, no control plane namespace found", nil
+	}
+	return fmt.Sprintf("karpenter detected (namespaces: %s)", strings.Join(namespaces, ", ")), nil
+}
+
 func (t *Toolset) Register(reg mcp.Registry) error {
 	tools := []mcp.ToolSpec{
 		{
@@ -63,6 +80,14 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     t.handleNodeProvisioningDebug,
 		},
+		{
+			Name:        "karpenter.explain_pending",
+			Description: "Explain why Karpenter has not provisioned a node for a pending pod by checking it against every NodePool.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaExplainPending(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleExplainPending,
+		},
 		{
 			Name:        "karpenter.nodepool_debug",
 			Description: "Inspect NodePools/Provisioners, requirements, taints, and NodeClass refs.",
@@ -79,6 +104,14 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 			Safety:      mcp.SafetyReadOnly,
 			Handler:     t.handleNodeClassDebug,
 		},
+		{
+			Name:        "karpenter.validate_nodeclass",
+			Description: "Check that each EC2NodeClass references a valid AMI family and existing AMIs, subnets, security groups and instance profile.",
+			ToolsetID:   t.ID(),
+			InputSchema: schemaValidateNodeClass(),
+			Safety:      mcp.SafetyReadOnly,
+			Handler:     t.handleValidateNodeClass,
+		},
 		{
 			Name:        "karpenter.interruption_debug",
 			Description: "Inspect NodeClaims and interruption/drift signals.",
@@ -33,6 +33,10 @@ func schemaCRStatus() map[string]any {
 			"name":          map[string]any{"type": "string"},
 			"namespace":     map[string]any{"type": "string"},
 			"labelSelector": map[string]any{"type": "string"},
+			"refreshDiscovery": map[string]any{
+				"type":        "boolean",
+				"description": "Invalidate the discovery/RESTMapper cache first so CRDs installed since the last refresh resolve.",
+			},
 		},
 	}
 }
@@ -151,10 +151,13 @@ func (t *Toolset) handlePolicyDebug(ctx context.Context, req mcp.ToolRequest) (m
 		analysis.AddNextCheck("Install Linkerd control plane")
 		return mcp.ToolResult{Data: t.ctx.Renderer.Render(analysis)}, nil
 	}
-	groups, err := t.ctx.Clients.Discovery.ServerGroups()
+	groups, warnings, err := kube.ServerGroups(t.ctx.Clients.Discovery)
 	if err != nil {
 		return mcp.ToolResult{Data: map[string]any{"error": err.Error()}}, err
 	}
+	for _, warning := range warnings {
+		analysis.AddEvidence("discovery", warning)
+	}
 	var found bool
 	for _, group := range groups.Groups {
 		if group.Name == "policy.linkerd.io" {
@@ -181,6 +184,9 @@ func (t *Toolset) handleCRStatus(ctx context.Context, req mcp.ToolRequest) (mcp.
 	name := toString(args["name"])
 	namespace := toString(args["namespace"])
 	selector := toString(args["labelSelector"])
+	if refresh, _ := args["refreshDiscovery"].(bool); refresh {
+		t.ctx.Clients.InvalidateDiscovery()
+	}
 
 	analysis := render.NewAnalysis()
 	generalFetch := isGeneralMeshKind(kind, resource)
@@ -223,6 +229,9 @@ func (t *Toolset) handleCRStatus(ctx context.Context, req mcp.ToolRequest) (mcp.
 		} else {
 			analysis.AddEvidence(fmt.Sprintf("%s status", ref), t.ctx.Redactor.RedactMap(status))
 		}
+		if gvr.Group == evidence.GatewayAPIGroup {
+			t.addGatewayConditions(&analysis, ref, obj)
+		}
 		describe := render.DescribeAnalysis(ctx, t.ctx.Evidence, t.ctx.Redactor, gvr, obj)
 		analysis.AddEvidence(fmt.Sprintf("%s describe", ref), t.ctx.Renderer.Render(describe))
 	}
@@ -383,6 +392,26 @@ func (t *Toolset) handleDestinationRuleStatus(ctx context.Context, req mcp.ToolR
 	return t.handleKindStatus(ctx, req, "DestinationRule")
 }
 
+// addGatewayConditions adds the pass/fail reading of a Gateway's listeners or
+// a route's parents, and a cause for each one that is not healthy, so the
+// failing condition and its message stand out from the raw status.
+func (t *Toolset) addGatewayConditions(analysis *render.Analysis, ref string, obj *unstructured.Unstructured) {
+	reports := evidence.GatewayAPIConditions(obj)
+	if len(reports) == 0 {
+		return
+	}
+	for i := range reports {
+		reports[i].Summary = t.ctx.Redactor.RedactString(reports[i].Summary)
+		for j, failure := range reports[i].Failures {
+			reports[i].Failures[j] = t.ctx.Redactor.RedactString(failure)
+		}
+		if !reports[i].OK {
+			analysis.AddCause(reports[i].Summary, ref, "high")
+		}
+	}
+	analysis.AddEvidence(fmt.Sprintf("%s conditions", ref), reports)
+}
+
 func (t *Toolset) handleKindStatus(ctx context.Context, req mcp.ToolRequest, kind string) (mcp.ToolResult, error) {
 	args := map[string]any{
 		"kind": kind,
@@ -423,6 +452,10 @@ func inferGroupForKindResource(kind, resource string) string {
 		return "gateway.networking.k8s.io"
 	case kindKey == "gateway" || resourceKey == "gateway" || resourceKey == "gateways":
 		return "gateway.networking.k8s.io"
+	case kindKey == "grpcroute" || resourceKey == "grpcroute" || resourceKey == "grpcroutes":
+		return "gateway.networking.k8s.io"
+	case kindKey == "tlsroute" || resourceKey == "tlsroute" || resourceKey == "tlsroutes":
+		return "gateway.networking.k8s.io"
 	case kindKey == "virtualservice" || resourceKey == "virtualservice" || resourceKey == "virtualservices":
 		return "networking.istio.io"
 	case kindKey == "destinationrule" || resourceKey == "destinationrule" || resourceKey == "destinationrules":
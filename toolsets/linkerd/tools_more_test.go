@@ -295,6 +295,9 @@ func TestLinkerdHelpers(t *testing.T) {
 	if inferGroupForKindResource("HTTPRoute", "") != "gateway.networking.k8s.io" {
 		t.Fatalf("expected HTTPRoute group")
 	}
+	if inferGroupForKindResource("", "grpcroutes") != "gateway.networking.k8s.io" {
+		t.Fatalf("expected grpcroutes gateway group")
+	}
 	if !isGeneralMeshKind("VirtualService", "") {
 		t.Fatalf("expected general mesh kind")
 	}
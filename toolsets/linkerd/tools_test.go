@@ -30,6 +30,11 @@ func (f *fakeCollector) EventsForObject(ctx context.Context, obj *unstructured.U
 	return nil, nil
 }
 
+func (f *fakeCollector) RecentEvents(ctx context.Context, namespace string, involvedObject corev1.ObjectReference) ([]corev1.Event, error) {
+	f.eventsCalled = true
+	return nil, nil
+}
+
 func (f *fakeCollector) OwnerChain(ctx context.Context, obj *unstructured.Unstructured) ([]string, error) {
 	return []string{"Deployment/demo"}, nil
 }
@@ -89,7 +94,7 @@ func TestProxyStatusUsesSharedEvidence(t *testing.T) {
 		t.Fatalf("handleProxyStatus failed: %v", err)
 	}
 	if !collector.eventsCalled {
-		t.Fatalf("expected EventsForObject to be called via shared describe helper")
+		t.Fatalf("expected events to be collected via shared describe helper")
 	}
 	data, ok := result.Data.(map[string]any)
 	if !ok {
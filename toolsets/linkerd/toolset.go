@@ -1,8 +1,10 @@
 package linkerd
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"rootcause/internal/mcp"
 )
@@ -37,6 +39,21 @@ func (t *Toolset) Init(ctx mcp.ToolContext) error {
 	return nil
 }
 
+// Preflight confirms the Linkerd CRDs or control plane are present.
+func (t *Toolset) Preflight(ctx context.Context) (string, error) {
+	detected, namespaces, _, err := t.detectLinkerd(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !detected {
+		return "", fmt.Errorf("linkerd not detected (no %s API groups or control plane)", strings.Join(linkerdGroups, ", "))
+	}
+	if len(namespaces) == 0 {
+		return "linkerd CRDs present, no control plane namespace found", nil
+	}
+	return fmt.Sprintf("linkerd detected (namespaces: %s)", strings.Join(namespaces, ", ")), nil
+}
+
 func (t *Toolset) Register(reg mcp.Registry) error {
 	tools := []mcp.ToolSpec{
 		{
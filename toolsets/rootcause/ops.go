@@ -59,6 +59,34 @@ func (t *Toolset) handleCapabilities(ctx context.Context, req mcp.ToolRequest) (
 	return mcp.ToolResult{Data: out}, nil
 }
 
+func (t *Toolset) handlePreflight(ctx context.Context, req mcp.ToolRequest) (mcp.ToolResult, error) {
+	timeout := mcp.DefaultPreflightTimeout
+	if seconds := intOrDefault(req.Arguments["timeoutSeconds"], 0); seconds > 0 {
+		timeout = time.Duration(seconds) * time.Second
+	}
+	var enabled []string
+	if reg, ok := t.ctx.Registry.(*mcp.ToolRegistry); ok && reg != nil {
+		enabled = reg.Toolsets()
+	}
+	results := mcp.RunPreflights(ctx, t.ctx.Registry, enabled, timeout)
+	counts := map[string]int{mcp.PreflightReady: 0, mcp.PreflightFailed: 0, mcp.PreflightSkipped: 0}
+	for _, result := range results {
+		counts[result.Status]++
+	}
+	out := map[string]any{
+		"ready":    counts[mcp.PreflightReady] > 0 && counts[mcp.PreflightFailed] == 0,
+		"summary":  counts,
+		"toolsets": results,
+	}
+	if counts[mcp.PreflightFailed] > 0 {
+		out["nextChecks"] = []string{
+			"Fix the failed toolsets before relying on them during an incident, or disable them in the toolsets config.",
+			"Run rootcause --preflight from the same environment as the MCP client to reproduce the checks outside a session.",
+		}
+	}
+	return mcp.ToolResult{Data: out}, nil
+}
+
 type bundleChainStep struct {
 	Tool    string
 	Section string
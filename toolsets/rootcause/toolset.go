@@ -105,6 +105,17 @@ func (t *Toolset) Register(reg mcp.Registry) error {
 	if err := reg.Add(capabilitiesSpec); err != nil {
 		return fmt.Errorf("register %s: %w", capabilitiesSpec.Name, err)
 	}
+	preflightSpec := mcp.ToolSpec{
+		Name:        "rootcause.preflight",
+		Description: "Check that each enabled toolset can reach its backend (Kubernetes API, AWS credentials, mesh and Karpenter CRDs, Helm storage).",
+		ToolsetID:   t.ID(),
+		InputSchema: schemaPreflight(),
+		Safety:      mcp.SafetyReadOnly,
+		Handler:     t.handlePreflight,
+	}
+	if err := reg.Add(preflightSpec); err != nil {
+		return fmt.Errorf("register %s: %w", preflightSpec.Name, err)
+	}
 	return nil
 }
 
@@ -117,6 +128,15 @@ func schemaCapabilities() map[string]any {
 	}
 }
 
+func schemaPreflight() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"timeoutSeconds": map[string]any{"type": "number", "description": "Per-toolset check timeout (default 10)."},
+		},
+	}
+}
+
 func schemaIncidentBundle() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -360,6 +360,46 @@ func TestHandleCapabilities(t *testing.T) {
 	}
 }
 
+type stubPreflight struct {
+	detail string
+	err    error
+}
+
+func (s stubPreflight) Preflight(context.Context) (string, error) {
+	return s.detail, s.err
+}
+
+func TestHandlePreflight(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Toolsets = []string{"k8s", "aws"}
+	reg := mcp.NewRegistry(&cfg)
+	// rootcause is enabled in the runtime but missing from the config list,
+	// as the browser toolset is when enabled from the environment.
+	for _, id := range []string{"k8s", "aws", "rootcause"} {
+		reg.AddToolset(id)
+	}
+	reg.AddPreflight("k8s", stubPreflight{detail: "Kubernetes v1.29.0"})
+	reg.AddPreflight("aws", stubPreflight{err: fmt.Errorf("no credentials")})
+	ctx := mcp.ToolContext{Config: &cfg, Registry: reg}
+	ctx.Invoker = mcp.NewToolInvoker(reg, ctx)
+	toolset := New()
+	if err := toolset.Init(ctx); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	result, err := toolset.handlePreflight(context.Background(), mcp.ToolRequest{Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("handlePreflight: %v", err)
+	}
+	root := result.Data.(map[string]any)
+	summary := root["summary"].(map[string]int)
+	if root["ready"] != false || summary["ready"] != 1 || summary["failed"] != 1 || summary["skipped"] != 1 {
+		t.Fatalf("unexpected preflight result: %#v", root)
+	}
+	if _, ok := root["nextChecks"]; !ok {
+		t.Fatalf("expected nextChecks on failure")
+	}
+}
+
 func TestHandleIncidentBundleTimelineMode(t *testing.T) {
 	cfg := config.DefaultConfig()
 	reg := mcp.NewRegistry(&cfg)